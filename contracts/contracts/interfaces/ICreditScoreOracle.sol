@@ -34,6 +34,13 @@ interface ICreditScoreOracle is IOracle {
         uint256 timestamp
     );
 
+    /**
+     * @dev Emitted when a credit score is published with an expiry
+     * @param userAddress The address of the user
+     * @param expiresAt Timestamp after which the score is no longer valid
+     */
+    event CreditScoreExpirySet(address indexed userAddress, uint64 expiresAt);
+
     /**
      * @dev Updates credit score for a user
      * @param userAddress The address of the user
@@ -48,6 +55,45 @@ interface ICreditScoreOracle is IOracle {
         bytes calldata additionalData
     ) external;
 
+    /**
+     * @dev Updates credit score for a user, valid until an expiry
+     * @param userAddress The address of the user
+     * @param creditScore The credit score (300-850)
+     * @param riskLevel The risk level (1-5)
+     * @param additionalData Additional credit data as bytes
+     * @param expiresAt Timestamp after which the score is no longer valid
+     */
+    function updateScoreWithExpiry(
+        address userAddress,
+        uint16 creditScore,
+        uint8 riskLevel,
+        bytes calldata additionalData,
+        uint64 expiresAt
+    ) external;
+
+    /**
+     * @dev Updates credit scores for many users, each valid until an expiry
+     * @param userAddresses The addresses of the users
+     * @param creditScores The credit scores (300-850)
+     * @param riskLevels The risk levels (1-5)
+     * @param additionalData Additional credit data for each user
+     * @param expiresAt Timestamps after which each score is no longer valid
+     */
+    function updateScoresWithExpiry(
+        address[] calldata userAddresses,
+        uint16[] calldata creditScores,
+        uint8[] calldata riskLevels,
+        bytes[] calldata additionalData,
+        uint64[] calldata expiresAt
+    ) external;
+
+    /**
+     * @dev Gets the expiry of a user's credit score
+     * @param userAddress The address of the user
+     * @return expiresAt The expiry timestamp, 0 if the score has none
+     */
+    function getScoreExpiry(address userAddress) external view returns (uint64 expiresAt);
+
     /**
      * @dev Gets the credit score for a user
      * @param userAddress The address of the user
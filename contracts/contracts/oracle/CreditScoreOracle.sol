@@ -24,6 +24,9 @@ contract CreditScoreOracle is ICreditScoreOracle, AccessControl, Pausable, Reent
     uint8 public constant MAX_RISK_LEVEL = 5;
     uint8 public constant MIN_RISK_LEVEL = 1;
 
+    // Maximum number of scores accepted by a single updateScores or updateScoresWithExpiry call
+    uint256 public constant MAX_BATCH_SIZE = 200;
+
     // Maximum age for credit data before it's considered stale (in seconds)
     uint256 public maxDataAge;
 
@@ -34,6 +37,7 @@ contract CreditScoreOracle is ICreditScoreOracle, AccessControl, Pausable, Reent
         bytes additionalData;
         uint256 lastUpdated;
         bool isValid;
+        uint64 expiresAt; // 0 when the score was published without an expiry
     }
 
     // Storage mappings
@@ -42,6 +46,7 @@ contract CreditScoreOracle is ICreditScoreOracle, AccessControl, Pausable, Reent
 
     // Additional events
     event MaxDataAgeUpdated(uint256 oldAge, uint256 newAge);
+    event CreditScoresBatchUpdated(uint256 count, uint256 timestamp);
 
     /**
      * @dev Constructor
@@ -68,6 +73,105 @@ contract CreditScoreOracle is ICreditScoreOracle, AccessControl, Pausable, Reent
         uint8 riskLevel,
         bytes calldata additionalData
     ) external override onlyRole(ORACLE_OPERATOR_ROLE) whenNotPaused nonReentrant {
+        _updateCreditScore(userAddress, creditScore, riskLevel, additionalData, 0);
+    }
+
+    /**
+     * @dev Updates credit score for a user, valid until an expiry
+     * @param userAddress The address of the user
+     * @param creditScore The credit score (300-850)
+     * @param riskLevel The risk level (1-5)
+     * @param additionalData Additional credit data as bytes
+     * @param expiresAt Timestamp after which the score is no longer valid
+     */
+    function updateScoreWithExpiry(
+        address userAddress,
+        uint16 creditScore,
+        uint8 riskLevel,
+        bytes calldata additionalData,
+        uint64 expiresAt
+    ) external override onlyRole(ORACLE_OPERATOR_ROLE) whenNotPaused nonReentrant {
+        _requireFutureExpiry(expiresAt);
+        _updateCreditScore(userAddress, creditScore, riskLevel, additionalData, expiresAt);
+    }
+
+    /**
+     * @dev Updates credit scores for many users in one transaction
+     * @param userAddresses The addresses of the users
+     * @param creditScores The credit scores (300-850)
+     * @param riskLevels The risk levels (1-5)
+     * @param additionalData Additional credit data for each user
+     */
+    function updateScores(
+        address[] calldata userAddresses,
+        uint16[] calldata creditScores,
+        uint8[] calldata riskLevels,
+        bytes[] calldata additionalData
+    ) external onlyRole(ORACLE_OPERATOR_ROLE) whenNotPaused nonReentrant {
+        uint256 count = userAddresses.length;
+        require(
+            creditScores.length == count && riskLevels.length == count && additionalData.length == count,
+            "CreditScoreOracle: Array length mismatch"
+        );
+        require(count <= MAX_BATCH_SIZE, "CreditScoreOracle: Batch too large");
+
+        for (uint256 i = 0; i < count; i++) {
+            _updateCreditScore(userAddresses[i], creditScores[i], riskLevels[i], additionalData[i], 0);
+        }
+
+        emit CreditScoresBatchUpdated(count, block.timestamp);
+    }
+
+    /**
+     * @dev Updates credit scores for many users in one transaction, each valid until an expiry
+     * @param userAddresses The addresses of the users
+     * @param creditScores The credit scores (300-850)
+     * @param riskLevels The risk levels (1-5)
+     * @param additionalData Additional credit data for each user
+     * @param expiresAt Timestamps after which each score is no longer valid
+     */
+    function updateScoresWithExpiry(
+        address[] calldata userAddresses,
+        uint16[] calldata creditScores,
+        uint8[] calldata riskLevels,
+        bytes[] calldata additionalData,
+        uint64[] calldata expiresAt
+    ) external override onlyRole(ORACLE_OPERATOR_ROLE) whenNotPaused nonReentrant {
+        uint256 count = userAddresses.length;
+        require(
+            creditScores.length == count &&
+                riskLevels.length == count &&
+                additionalData.length == count &&
+                expiresAt.length == count,
+            "CreditScoreOracle: Array length mismatch"
+        );
+        require(count <= MAX_BATCH_SIZE, "CreditScoreOracle: Batch too large");
+
+        for (uint256 i = 0; i < count; i++) {
+            _requireFutureExpiry(expiresAt[i]);
+            _updateCreditScore(userAddresses[i], creditScores[i], riskLevels[i], additionalData[i], expiresAt[i]);
+        }
+
+        emit CreditScoresBatchUpdated(count, block.timestamp);
+    }
+
+    /**
+     * @dev Rejects expiries that have already passed
+     */
+    function _requireFutureExpiry(uint64 expiresAt) internal view {
+        require(expiresAt > block.timestamp, "CreditScoreOracle: Expiry must be in the future");
+    }
+
+    /**
+     * @dev Validates and stores a single credit score update
+     */
+    function _updateCreditScore(
+        address userAddress,
+        uint256 creditScore,
+        uint8 riskLevel,
+        bytes calldata additionalData,
+        uint64 expiresAt
+    ) internal {
         require(userAddress != address(0), "CreditScoreOracle: Invalid user address");
         require(
             creditScore >= MIN_CREDIT_SCORE && creditScore <= MAX_CREDIT_SCORE,
@@ -87,13 +191,17 @@ contract CreditScoreOracle is ICreditScoreOracle, AccessControl, Pausable, Reent
             riskLevel: riskLevel,
             additionalData: additionalData,
             lastUpdated: block.timestamp,
-            isValid: true
+            isValid: true,
+            expiresAt: expiresAt
         });
 
         dataHashes[userAddress] = dataHash;
 
         emit CreditScoreUpdated(userAddress, creditScore, riskLevel, block.timestamp);
         emit CreditDataUpdated(userAddress, dataHash, block.timestamp);
+        if (expiresAt != 0) {
+            emit CreditScoreExpirySet(userAddress, expiresAt);
+        }
     }
 
     /**
@@ -140,7 +248,26 @@ contract CreditScoreOracle is ICreditScoreOracle, AccessControl, Pausable, Reent
      */
     function hasValidCreditScore(address userAddress) public view override returns (bool hasScore) {
         CreditData memory data = creditScores[userAddress];
-        return data.isValid && !isCreditDataStale(userAddress);
+        return data.isValid && !isCreditDataStale(userAddress) && !isCreditScoreExpired(userAddress);
+    }
+
+    /**
+     * @dev Gets the expiry of a user's credit score
+     * @param userAddress The address of the user
+     * @return expiresAt The expiry timestamp, 0 if the score has none
+     */
+    function getScoreExpiry(address userAddress) external view override returns (uint64 expiresAt) {
+        return creditScores[userAddress].expiresAt;
+    }
+
+    /**
+     * @dev Checks if a credit score has passed its expiry
+     * @param userAddress The address of the user
+     * @return expired True if the score has an expiry that has passed
+     */
+    function isCreditScoreExpired(address userAddress) public view returns (bool expired) {
+        uint64 expiresAt = creditScores[userAddress].expiresAt;
+        return expiresAt != 0 && block.timestamp >= expiresAt;
     }
 
     /**
@@ -124,6 +124,168 @@ describe("CreditScoreOracle", function () {
     });
   });
 
+  describe("Batch Updates", function () {
+    it("Should update many credit scores in one call", async function () {
+      const [, , , user2] = await ethers.getSigners();
+      const additionalData = [
+        ethers.toUtf8Bytes("Good payment history"),
+        ethers.toUtf8Bytes("Thin file"),
+      ];
+
+      await expect(creditScoreOracle.connect(operator).updateScores(
+        [user.address, user2.address],
+        [750, 610],
+        [2, 4],
+        additionalData
+      )).to.emit(creditScoreOracle, "CreditScoresBatchUpdated");
+
+      const [score1, risk1] = await creditScoreOracle.getCreditScore(user.address);
+      const [score2, risk2] = await creditScoreOracle.getCreditScore(user2.address);
+      expect(score1).to.equal(750);
+      expect(risk1).to.equal(2);
+      expect(score2).to.equal(610);
+      expect(risk2).to.equal(4);
+    });
+
+    it("Should reject mismatched array lengths", async function () {
+      await expect(creditScoreOracle.connect(operator).updateScores(
+        [user.address],
+        [750, 610],
+        [2],
+        [ethers.toUtf8Bytes("Good payment history")]
+      )).to.be.revertedWith("CreditScoreOracle: Array length mismatch");
+    });
+
+    it("Should reject the whole batch if one score is invalid", async function () {
+      const [, , , user2] = await ethers.getSigners();
+
+      await expect(creditScoreOracle.connect(operator).updateScores(
+        [user.address, user2.address],
+        [750, 900],
+        [2, 1],
+        [ethers.toUtf8Bytes("a"), ethers.toUtf8Bytes("b")]
+      )).to.be.revertedWith("CreditScoreOracle: Invalid credit score range");
+
+      expect(await creditScoreOracle.hasValidCreditScore(user.address)).to.be.false;
+    });
+
+    it("Should not allow non-operator to batch update", async function () {
+      await expect(creditScoreOracle.connect(user).updateScores(
+        [user.address],
+        [750],
+        [2],
+        [ethers.toUtf8Bytes("Good payment history")]
+      )).to.be.reverted;
+    });
+  });
+
+  describe("Score Expiry", function () {
+    async function inFuture(seconds) {
+      const block = await ethers.provider.getBlock("latest");
+      return block.timestamp + seconds;
+    }
+
+    it("Should store a score with its expiry", async function () {
+      const expiresAt = await inFuture(3600);
+
+      await expect(creditScoreOracle.connect(operator).updateScoreWithExpiry(
+        user.address,
+        750,
+        2,
+        ethers.toUtf8Bytes("Good payment history"),
+        expiresAt
+      ))
+        .to.emit(creditScoreOracle, "CreditScoreExpirySet")
+        .withArgs(user.address, expiresAt);
+
+      const [score, risk] = await creditScoreOracle.getCreditScore(user.address);
+      expect(score).to.equal(750);
+      expect(risk).to.equal(2);
+      expect(await creditScoreOracle.getScoreExpiry(user.address)).to.equal(expiresAt);
+    });
+
+    it("Should invalidate a score once it expires", async function () {
+      await creditScoreOracle.connect(operator).updateScoreWithExpiry(
+        user.address,
+        750,
+        2,
+        ethers.toUtf8Bytes("data"),
+        await inFuture(3600)
+      );
+
+      await ethers.provider.send("evm_increaseTime", [3601]);
+      await ethers.provider.send("evm_mine", []);
+
+      expect(await creditScoreOracle.isCreditScoreExpired(user.address)).to.be.true;
+      expect(await creditScoreOracle.hasValidCreditScore(user.address)).to.be.false;
+      await expect(creditScoreOracle.getCreditScore(user.address))
+        .to.be.revertedWith("CreditScoreOracle: No valid credit score found");
+    });
+
+    it("Should reject an expiry that has passed", async function () {
+      const block = await ethers.provider.getBlock("latest");
+
+      await expect(creditScoreOracle.connect(operator).updateScoreWithExpiry(
+        user.address,
+        750,
+        2,
+        ethers.toUtf8Bytes("data"),
+        block.timestamp
+      )).to.be.revertedWith("CreditScoreOracle: Expiry must be in the future");
+    });
+
+    it("Should clear the expiry on an update without one", async function () {
+      await creditScoreOracle.connect(operator).updateScoreWithExpiry(
+        user.address,
+        750,
+        2,
+        ethers.toUtf8Bytes("data"),
+        await inFuture(3600)
+      );
+      await creditScoreOracle.connect(operator).updateCreditScore(user.address, 700, 2, ethers.toUtf8Bytes("data"));
+
+      expect(await creditScoreOracle.getScoreExpiry(user.address)).to.equal(0);
+    });
+
+    it("Should update many scores with expiries in one call", async function () {
+      const [, , , user2] = await ethers.getSigners();
+      const expiries = [await inFuture(3600), await inFuture(7200)];
+
+      await expect(creditScoreOracle.connect(operator).updateScoresWithExpiry(
+        [user.address, user2.address],
+        [750, 610],
+        [2, 4],
+        [ethers.toUtf8Bytes("a"), ethers.toUtf8Bytes("b")],
+        expiries
+      )).to.emit(creditScoreOracle, "CreditScoresBatchUpdated");
+
+      expect(await creditScoreOracle.getScoreExpiry(user.address)).to.equal(expiries[0]);
+      expect(await creditScoreOracle.getScoreExpiry(user2.address)).to.equal(expiries[1]);
+      const [score2] = await creditScoreOracle.getCreditScore(user2.address);
+      expect(score2).to.equal(610);
+    });
+
+    it("Should reject a batch with a missing expiry", async function () {
+      await expect(creditScoreOracle.connect(operator).updateScoresWithExpiry(
+        [user.address],
+        [750],
+        [2],
+        [ethers.toUtf8Bytes("a")],
+        []
+      )).to.be.revertedWith("CreditScoreOracle: Array length mismatch");
+    });
+
+    it("Should not allow non-operator to update with expiry", async function () {
+      await expect(creditScoreOracle.connect(user).updateScoreWithExpiry(
+        user.address,
+        750,
+        2,
+        ethers.toUtf8Bytes("data"),
+        await inFuture(3600)
+      )).to.be.reverted;
+    });
+  });
+
   describe("Validation", function () {
     it("Should reject invalid credit score range", async function () {
       await expect(creditScoreOracle.connect(operator).updateCreditScore(
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/joho/godotenv"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/api/routes"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/config"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func main() {
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	// Initialize logger
+	logger.Init()
+
+	// Load configuration
+	cfg := config.Load()
+
+	// Initialize Gin router
+	router := gin.Default()
+
+	// Setup routes
+	shutdown := routes.Setup(router, cfg)
+
+	// Start server
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+
+	// Serve HTTPS, verifying client certificates if required, when a certificate is set
+	tlsConfig, err := routes.ServerTLSConfig(cfg)
+	if err != nil {
+		logger.Fatal("Invalid server TLS settings", zap.Error(err))
+	}
+
+	server := &http.Server{Addr: ":" + port, Handler: router, TLSConfig: tlsConfig}
+	go func() {
+		var err error
+		if tlsConfig != nil {
+			logger.Info("Starting oracle service with TLS", zap.String("port", port), zap.String("clientAuth", cfg.TLSClientAuth))
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			logger.Info("Starting oracle service", zap.String("port", port))
+			err = server.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Fatal("Failed to start server", zap.Error(err))
+		}
+	}()
+
+	// Finish in-flight requests, stop background workers and write out queued history
+	// and events before exiting
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+
+	logger.Info("Shutting down oracle service")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		logger.Error("Failed to shut down server", zap.Error(err))
+	}
+	shutdown()
+}
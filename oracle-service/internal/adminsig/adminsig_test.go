@@ -0,0 +1,83 @@
+package adminsig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func encodeKey(t *testing.T, key interface{}) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+func TestVerifyECDSA(t *testing.T) {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	keys, err := ParseKeys(map[string]string{"bob": encodeKey(t, &private.PublicKey)})
+	if err != nil {
+		t.Fatalf("Failed to parse keys: %v", err)
+	}
+	now := time.Unix(1760000000, 0)
+	verifier := NewVerifier(keys, 5*time.Minute)
+	verifier.now = func() time.Time { return now }
+
+	body := []byte(`{"credential":"new"}`)
+	path := "/api/v1/admin/providers/plaid/credentials"
+	digest := sha256.Sum256(Message("bob", "POST", path, now.Unix(), body))
+	signature, err := ecdsa.SignASN1(rand.Reader, private, digest[:])
+	if err != nil {
+		t.Fatalf("Failed to sign: %v", err)
+	}
+	encoded := base64.StdEncoding.EncodeToString(signature)
+
+	if err := verifier.Verify("bob", "POST", path, now.Unix(), body, encoded); err != nil {
+		t.Fatalf("Expected a valid signature, got %v", err)
+	}
+	if err := verifier.Verify("bob", "POST", path, now.Unix(), body, encoded); !errors.Is(err, ErrReplayed) {
+		t.Errorf("Expected a replay to be rejected, got %v", err)
+	}
+	if err := verifier.Verify("bob", "DELETE", path, now.Unix(), body, encoded); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected a signature for another method to be rejected, got %v", err)
+	}
+
+	// Once the timestamp is stale the request is rejected anyway, so it is forgotten
+	signedAt := now.Unix()
+	now = now.Add(10 * time.Minute)
+	if err := verifier.Verify("bob", "POST", path, signedAt, body, encoded); !errors.Is(err, ErrStaleSignature) {
+		t.Errorf("Expected a stale signature to be rejected, got %v", err)
+	}
+	digest = sha256.Sum256(Message("bob", "POST", path, now.Unix(), body))
+	signature, _ = ecdsa.SignASN1(rand.Reader, private, digest[:])
+	if err := verifier.Verify("bob", "POST", path, now.Unix(), body, base64.StdEncoding.EncodeToString(signature)); err != nil {
+		t.Fatalf("Expected a valid signature, got %v", err)
+	}
+	if len(verifier.seen) != 1 {
+		t.Errorf("Expected only the latest request remembered, %d remembered", len(verifier.seen))
+	}
+}
+
+func TestParseKeysRejectsRSA(t *testing.T) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	if _, err := ParseKeys(map[string]string{"carol": encodeKey(t, &private.PublicKey)}); err == nil {
+		t.Error("Expected an RSA key to be rejected")
+	}
+	if _, err := ParseKeys(map[string]string{"carol": "not base64!"}); err == nil {
+		t.Error("Expected an invalid key to be rejected")
+	}
+}
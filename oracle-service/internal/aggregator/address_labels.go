@@ -0,0 +1,98 @@
+package aggregator
+
+import (
+	"strings"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/labels"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+)
+
+// FundingProfile summarizes a wallet's labelled counterparties
+type FundingProfile struct {
+	TotalInflows     uint32
+	CEXInflows       uint32
+	MixerInflows     uint32
+	BridgeInflows    uint32
+	PayrollInflows   uint32
+	CEXActiveMonths  uint32   // Distinct calendar months with an exchange withdrawal
+	ScamInteractions uint32   // Transfers to or from flagged scam addresses
+	Exchanges        []string // Distinct exchanges the wallet withdrew from
+	Protocols        []string // Distinct labelled protocols the wallet interacted with
+}
+
+// AnalyzeFundingSources classifies a wallet's transfers against the label registry
+func AnalyzeFundingSources(
+	address string,
+	txs []providers.BlockscoutTransaction,
+	transfers []providers.BlockscoutTokenTransfer,
+	registry *labels.Registry,
+) *FundingProfile {
+	profile := &FundingProfile{
+		Exchanges: []string{},
+		Protocols: []string{},
+	}
+	months := make(map[string]bool)
+	exchanges := make(map[string]bool)
+	protocols := make(map[string]bool)
+
+	// Each list has its own set, so a name labelled in both categories is in both
+	addUnique := func(seen map[string]bool, list *[]string, name string) {
+		if !seen[name] {
+			seen[name] = true
+			*list = append(*list, name)
+		}
+	}
+
+	record := func(from, to, timestamp string) {
+		inbound := strings.EqualFold(to, address)
+		counterparty := from
+		if !inbound {
+			counterparty = to
+		}
+		if inbound {
+			profile.TotalInflows++
+		}
+
+		label, ok := registry.Lookup(counterparty)
+		if !ok {
+			return
+		}
+
+		switch label.Category {
+		case models.LabelCategoryScam:
+			profile.ScamInteractions++
+		case models.LabelCategoryProtocol:
+			addUnique(protocols, &profile.Protocols, label.Name)
+		}
+
+		if !inbound {
+			return
+		}
+
+		switch label.Category {
+		case models.LabelCategoryExchange:
+			profile.CEXInflows++
+			addUnique(exchanges, &profile.Exchanges, label.Name)
+			if t, err := providers.ParseTimestamp(timestamp); err == nil {
+				months[t.UTC().Format("2006-01")] = true
+			}
+		case models.LabelCategoryMixer:
+			profile.MixerInflows++
+		case models.LabelCategoryBridge:
+			profile.BridgeInflows++
+		case models.LabelCategoryPayroll:
+			profile.PayrollInflows++
+		}
+	}
+
+	for _, tx := range txs {
+		record(tx.From, tx.To, tx.TimeStamp)
+	}
+	for _, transfer := range transfers {
+		record(transfer.From, transfer.To, transfer.TimeStamp)
+	}
+
+	profile.CEXActiveMonths = uint32(len(months))
+	return profile
+}
@@ -0,0 +1,79 @@
+package aggregator
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/labels"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+)
+
+func TestAnalyzeFundingSources(t *testing.T) {
+	scammer := "0x000000000000000000000000000000000000dead"
+	registry := labels.NewRegistry(append(labels.DefaultLabels(), models.AddressLabel{
+		Address: scammer, Name: "Drainer", Category: models.LabelCategoryScam,
+	}))
+	binance := "0x28c6c06298d514db089934071355e5743bf21d60"
+	tornado := "0x910cbd523d972eb0a6f4cae4618ad62622b39dbf"
+	aave := "0x87870bca3f3fd6335c3f4ce8392d69350b4fa4e2"
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	var txs []providers.BlockscoutTransaction
+	for i := 0; i < 4; i++ {
+		txs = append(txs, providers.BlockscoutTransaction{
+			From:      binance,
+			To:        testWallet,
+			TimeStamp: strconv.FormatInt(start.AddDate(0, i, 0).Unix(), 10),
+		})
+	}
+	txs = append(txs,
+		providers.BlockscoutTransaction{From: tornado, To: testWallet, TimeStamp: "1700000000"},
+		// Outbound transactions are not inflows
+		providers.BlockscoutTransaction{From: testWallet, To: binance, TimeStamp: "1700000000"},
+		providers.BlockscoutTransaction{From: testWallet, To: aave, TimeStamp: "1700000000"},
+		providers.BlockscoutTransaction{From: testWallet, To: scammer, TimeStamp: "1700000000"},
+	)
+
+	profile := AnalyzeFundingSources(testWallet, txs, nil, registry)
+
+	if profile.TotalInflows != 5 {
+		t.Errorf("Expected 5 inflows, got %d", profile.TotalInflows)
+	}
+	if profile.CEXInflows != 4 || profile.CEXActiveMonths != 4 {
+		t.Errorf("Expected 4 CEX inflows across 4 months, got %d across %d", profile.CEXInflows, profile.CEXActiveMonths)
+	}
+	if profile.MixerInflows != 1 {
+		t.Errorf("Expected 1 mixer inflow, got %d", profile.MixerInflows)
+	}
+	if profile.ScamInteractions != 1 {
+		t.Errorf("Expected 1 scam interaction, got %d", profile.ScamInteractions)
+	}
+	if len(profile.Exchanges) != 1 || profile.Exchanges[0] != "Binance 14" {
+		t.Errorf("Expected exchanges [Binance 14], got %v", profile.Exchanges)
+	}
+	if len(profile.Protocols) != 1 || profile.Protocols[0] != "Aave V3 Pool" {
+		t.Errorf("Expected protocols [Aave V3 Pool], got %v", profile.Protocols)
+	}
+}
+
+func TestAnalyzeFundingSourcesListsNamesPerCategory(t *testing.T) {
+	// One operator runs both an exchange hot wallet and a protocol contract
+	hotWallet := "0x00000000000000000000000000000000000000c1"
+	router := "0x00000000000000000000000000000000000000c2"
+	registry := labels.NewRegistry([]models.AddressLabel{
+		{Address: hotWallet, Name: "Crypto.com", Category: models.LabelCategoryExchange},
+		{Address: router, Name: "Crypto.com", Category: models.LabelCategoryProtocol},
+	})
+
+	txs := []providers.BlockscoutTransaction{
+		{From: testWallet, To: router, TimeStamp: "1700000000"},
+		{From: hotWallet, To: testWallet, TimeStamp: "1700000000"},
+	}
+	profile := AnalyzeFundingSources(testWallet, txs, nil, registry)
+
+	if len(profile.Exchanges) != 1 || len(profile.Protocols) != 1 {
+		t.Errorf("Expected the name in both lists, got exchanges %v and protocols %v", profile.Exchanges, profile.Protocols)
+	}
+}
@@ -0,0 +1,46 @@
+package aggregator
+
+import (
+	"math"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+)
+
+// CalculateBalanceStability scores how consistently a wallet held its current balance
+// over the sampled history (0-1). A wallet funded yesterday scores near zero even with
+// a large balance; one holding steadily for a year scores near one.
+func CalculateBalanceStability(samples []providers.BalanceSample) float64 {
+	if len(samples) < models.MinBalanceSamples {
+		return 0
+	}
+
+	current := samples[len(samples)-1].Value
+	if current <= 0 {
+		return 0
+	}
+
+	// Coverage: average share of today's balance that was already held at each sample
+	coverage := 0.0
+	mean := 0.0
+	for _, sample := range samples {
+		coverage += math.Min(sample.Value, current) / current
+		mean += sample.Value
+	}
+	coverage /= float64(len(samples))
+	mean /= float64(len(samples))
+
+	// Volatility: coefficient of variation across samples
+	variance := 0.0
+	for _, sample := range samples {
+		variance += (sample.Value - mean) * (sample.Value - mean)
+	}
+	variance /= float64(len(samples))
+
+	cv := 0.0
+	if mean > 0 {
+		cv = math.Sqrt(variance) / mean
+	}
+
+	return 0.7*coverage + 0.3*(1-math.Min(cv, 1))
+}
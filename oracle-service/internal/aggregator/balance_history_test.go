@@ -0,0 +1,40 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+)
+
+func monthlySamples(values ...float64) []providers.BalanceSample {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	samples := make([]providers.BalanceSample, len(values))
+	for i, v := range values {
+		samples[i] = providers.BalanceSample{Timestamp: start.AddDate(0, i, 0), Value: v}
+	}
+	return samples
+}
+
+func TestCalculateBalanceStability(t *testing.T) {
+	steady := CalculateBalanceStability(monthlySamples(5000, 5100, 4900, 5000, 5050, 5000))
+	recentlyFunded := CalculateBalanceStability(monthlySamples(0, 0, 0, 0, 0, 5000))
+	growing := CalculateBalanceStability(monthlySamples(1000, 2000, 3000, 4000, 5000, 6000))
+
+	if steady < 0.9 {
+		t.Errorf("Expected steady holder to score above 0.9, got %f", steady)
+	}
+	if recentlyFunded > 0.3 {
+		t.Errorf("Expected recently funded wallet to score below 0.3, got %f", recentlyFunded)
+	}
+	if growing <= recentlyFunded || growing >= steady {
+		t.Errorf("Expected growing balance (%f) between recently funded (%f) and steady (%f)", growing, recentlyFunded, steady)
+	}
+
+	if got := CalculateBalanceStability(monthlySamples(5000, 5000)); got != 0 {
+		t.Errorf("Expected too few samples to score 0, got %f", got)
+	}
+	if got := CalculateBalanceStability(monthlySamples(5000, 5000, 0)); got != 0 {
+		t.Errorf("Expected empty current balance to score 0, got %f", got)
+	}
+}
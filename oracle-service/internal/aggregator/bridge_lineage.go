@@ -0,0 +1,133 @@
+package aggregator
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/labels"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+)
+
+// sourceHistoryLead is how long a wallet must have been active on the source chain
+// before bridging for the deposit to count as its own funds. A wallet created on the
+// source chain just to bridge is no older for it.
+const sourceHistoryLead = temporaryDepositWindow
+
+// BridgeDeposit is an inbound transfer released by a labelled bridge
+type BridgeDeposit struct {
+	Hash        string
+	Bridge      string    // Label of the bridge
+	SourceChain string    // "" if the bridge's source chain isn't known
+	Timestamp   time.Time // Zero if the transfer had no valid timestamp
+}
+
+// DetectBridgeDeposits finds the wallet's inbound transactions and token transfers
+// from bridge addresses in the label registry
+func DetectBridgeDeposits(
+	address string,
+	txs []providers.BlockscoutTransaction,
+	transfers []providers.BlockscoutTokenTransfer,
+	registry *labels.Registry,
+) []BridgeDeposit {
+	var deposits []BridgeDeposit
+	record := func(hash, from, to, timestamp string) {
+		if !strings.EqualFold(to, address) {
+			return
+		}
+		label, ok := registry.Lookup(from)
+		if !ok || label.Category != models.LabelCategoryBridge {
+			return
+		}
+
+		deposit := BridgeDeposit{
+			Hash:        hash,
+			Bridge:      label.Name,
+			SourceChain: labels.SourceChain(label),
+		}
+		if t, err := providers.ParseTimestamp(timestamp); err == nil {
+			deposit.Timestamp = t
+		}
+		deposits = append(deposits, deposit)
+	}
+
+	for _, tx := range txs {
+		record(tx.Hash, tx.From, tx.To, tx.TimeStamp)
+	}
+	for _, transfer := range transfers {
+		record(transfer.Hash, transfer.From, transfer.To, transfer.TimeStamp)
+	}
+	return deposits
+}
+
+// BridgeLineage links a wallet's bridge deposits to its history on the chains they
+// came from
+type BridgeLineage struct {
+	Deposits     []BridgeDeposit
+	SourceChains []string                           // Distinct known source chains, sorted
+	Attributed   map[string]providers.ChainActivity // Source-chain activity the summary didn't already include
+	carried      map[string]bool                    // Hashes of deposits moving the wallet's own funds
+}
+
+// NewBridgeLineage traces bridge deposits to the wallet's activity on their source
+// chains. known is the activity already in the wallet summary; fetched is activity
+// looked up for the source chains it lacked. A deposit is carried over, rather than
+// fresh money, when the wallet was active on the source chain well before bridging.
+func NewBridgeLineage(deposits []BridgeDeposit, known, fetched map[string]providers.ChainActivity) *BridgeLineage {
+	lineage := &BridgeLineage{
+		Deposits:   deposits,
+		Attributed: make(map[string]providers.ChainActivity),
+		carried:    make(map[string]bool),
+	}
+	seen := make(map[string]bool)
+
+	for _, deposit := range deposits {
+		chain := deposit.SourceChain
+		if chain == "" {
+			continue
+		}
+
+		activity, ok := known[chain]
+		if !ok {
+			if activity, ok = fetched[chain]; ok {
+				lineage.Attributed[chain] = activity
+			}
+		}
+		if !seen[chain] {
+			seen[chain] = true
+			lineage.SourceChains = append(lineage.SourceChains, chain)
+		}
+
+		if ok && !activity.FirstTransaction.IsZero() && !deposit.Timestamp.IsZero() &&
+			deposit.Timestamp.Sub(activity.FirstTransaction) >= sourceHistoryLead {
+			lineage.carried[deposit.Hash] = true
+		}
+	}
+
+	sort.Strings(lineage.SourceChains)
+	return lineage
+}
+
+// CarriedCount is the number of deposits moving the wallet's own funds
+func (l *BridgeLineage) CarriedCount() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.carried)
+}
+
+// WithoutCarried leaves the carried deposits out of a wallet's transactions
+func (l *BridgeLineage) WithoutCarried(txs []providers.BlockscoutTransaction) []providers.BlockscoutTransaction {
+	if l.CarriedCount() == 0 {
+		return txs
+	}
+
+	kept := make([]providers.BlockscoutTransaction, 0, len(txs))
+	for _, tx := range txs {
+		if !l.carried[tx.Hash] {
+			kept = append(kept, tx)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,97 @@
+package aggregator
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/labels"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+)
+
+func TestBridgeLineage(t *testing.T) {
+	customBridge := "0x000000000000000000000000000000000000b1d9"
+	registry := labels.NewRegistry(append(labels.DefaultLabels(), models.AddressLabel{
+		Address: customBridge, Name: "Unknown Bridge", Category: models.LabelCategoryBridge,
+	}))
+	arbitrumBridge := "0x8315177ab297ba92a06054ce80a67ed4dbd7ed3a"
+	baseBridge := "0x3154cf16ccdb4c6d922629664174b904d80f2c35"
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	at := func(t time.Time) string { return strconv.FormatInt(t.Unix(), 10) }
+
+	txs := []providers.BlockscoutTransaction{
+		{Hash: "0xarb", From: arbitrumBridge, To: testWallet, TimeStamp: at(now.Add(-10 * day))},
+		{Hash: "0xbase", From: baseBridge, To: testWallet, TimeStamp: at(now.Add(-2 * day))},
+		{Hash: "0xcustom", From: customBridge, To: testWallet, TimeStamp: at(now.Add(-1 * day))},
+		// Sending funds back through a bridge is no deposit
+		{Hash: "0xout", From: testWallet, To: arbitrumBridge, TimeStamp: at(now)},
+	}
+	transfers := []providers.BlockscoutTokenTransfer{
+		{Hash: "0xarb-usdc", From: arbitrumBridge, To: testWallet, TimeStamp: at(now.Add(-5 * day))},
+	}
+
+	deposits := DetectBridgeDeposits(testWallet, txs, transfers, registry)
+	if len(deposits) != 4 {
+		t.Fatalf("Expected 4 bridge deposits, got %+v", deposits)
+	}
+	if deposits[0].SourceChain != "arbitrum" || deposits[0].Bridge != "Arbitrum Bridge" {
+		t.Errorf("Expected the Arbitrum deposit traced to arbitrum, got %+v", deposits[0])
+	}
+	if deposits[2].SourceChain != "" {
+		t.Errorf("Expected no source chain for a bridge without one, got %q", deposits[2].SourceChain)
+	}
+
+	// Arbitrum activity is in the summary; Base activity was looked up, and the wallet
+	// only appeared on Base the day before bridging
+	known := map[string]providers.ChainActivity{
+		"ethereum": {FirstTransaction: now.Add(-30 * day), WalletAge: 30, TotalTransactions: 12},
+		"arbitrum": {FirstTransaction: now.Add(-400 * day), WalletAge: 400, TotalTransactions: 250},
+	}
+	fetched := map[string]providers.ChainActivity{
+		"base": {FirstTransaction: now.Add(-3 * day), WalletAge: 3, TotalTransactions: 2},
+	}
+	lineage := NewBridgeLineage(deposits, known, fetched)
+
+	if len(lineage.SourceChains) != 2 || lineage.SourceChains[0] != "arbitrum" || lineage.SourceChains[1] != "base" {
+		t.Errorf("Expected source chains [arbitrum base], got %v", lineage.SourceChains)
+	}
+	if _, ok := lineage.Attributed["arbitrum"]; ok || len(lineage.Attributed) != 1 {
+		t.Errorf("Expected only base attributed, got %v", lineage.Attributed)
+	}
+	if lineage.CarriedCount() != 2 {
+		t.Errorf("Expected both Arbitrum deposits carried over, got %d", lineage.CarriedCount())
+	}
+
+	kept := lineage.WithoutCarried(txs)
+	if len(kept) != 3 || kept[0].Hash != "0xbase" {
+		t.Errorf("Expected the carried Arbitrum deposit left out, got %+v", kept)
+	}
+}
+
+func TestApplyBridgeLineage(t *testing.T) {
+	agg := &EnhancedOnChainAggregator{}
+	metrics := &models.OnChainMetrics{UserAddress: testWallet, WalletAge: 30, TotalTransactions: 12}
+
+	agg.applyBridgeLineage(metrics, nil)
+	if metrics.BridgedFrom != "" || metrics.WalletAge != 30 {
+		t.Fatalf("Expected no change without bridge deposits, got %+v", metrics)
+	}
+
+	lineage := &BridgeLineage{
+		Deposits:     []BridgeDeposit{{Hash: "0xa"}, {Hash: "0xb"}},
+		SourceChains: []string{"arbitrum", "polygon"},
+		Attributed: map[string]providers.ChainActivity{
+			"polygon": {WalletAge: 700, TotalTransactions: 90},
+		},
+	}
+	agg.applyBridgeLineage(metrics, lineage)
+
+	if metrics.BridgedFrom != "arbitrum,polygon" {
+		t.Errorf("Expected bridged from arbitrum,polygon, got %q", metrics.BridgedFrom)
+	}
+	if metrics.WalletAge != 700 || metrics.TotalTransactions != 102 {
+		t.Errorf("Expected Polygon age and activity attributed, got age %d and %d transactions", metrics.WalletAge, metrics.TotalTransactions)
+	}
+}
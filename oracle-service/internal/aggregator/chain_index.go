@@ -0,0 +1,80 @@
+package aggregator
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/chains"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ChainIndex serves address data from a self-hosted chain index, for private and
+// consortium chains no explorer provider covers
+type ChainIndex interface {
+	Summary(ctx context.Context, address string) (*providers.BlockchainSummary, error) // nil for unseen addresses
+	AddressInfo(ctx context.Context, address string) (*providers.BlockscoutAddressInfo, error)
+	Transactions(ctx context.Context, address string, limit int) ([]providers.BlockscoutTransaction, error)
+	TokenTransfers(ctx context.Context, address string, limit int) ([]providers.BlockscoutTokenTransfer, error)
+	NativeAsset() chains.NativeAsset // Coin of the indexed chain
+}
+
+// indexedMetrics converts an indexed summary to on-chain metrics
+func indexedMetrics(address string, summary *providers.BlockchainSummary) *models.OnChainMetrics {
+	repayments := MatchRepayments(summary.DeFiActivities)
+	return &models.OnChainMetrics{
+		UserAddress:         address,
+		WalletAge:           uint32(summary.WalletAge),
+		TotalTransactions:   uint32(summary.TotalTransactions),
+		AvgTransactionValue: units.DecimalFromFloat(summary.AverageTransactionSize),
+		DeFiInteractions:    uint32(providers.SuccessfulDeFiActivities(summary.DeFiActivities)),
+		BorrowingHistory:    repayments.Borrows,
+		RepaymentHistory:    repayments.Repaid,
+		RepaymentRatio:      repayments.RepaymentRatio,
+		AvgDaysToRepay:      repayments.AvgDaysToRepay,
+		OutstandingLoans:    repayments.OutstandingLoans,
+		CollateralValue:     units.DecimalFromFloat(summary.TotalPortfolioValue),
+		LastActivity:        summary.LastTransaction,
+		UpdatedAt:           time.Now(),
+	}
+}
+
+// fetchIndexedSummary summarizes the address from the chain index. It returns nil if
+// the address was never indexed or the index could not be read.
+func (a *EnhancedOnChainAggregator) fetchIndexedSummary(ctx context.Context, address string) *providers.BlockchainSummary {
+	callCtx, cancel := callContext(ctx, a.callTimeout)
+	defer cancel()
+	summary, err := a.chainIndex.Summary(callCtx, address)
+	if err != nil {
+		logger.Error("Failed to read chain index, trying direct RPC", zap.Error(err))
+		return nil
+	}
+	if summary == nil {
+		logger.Info("Address not in chain index, trying direct RPC", zap.String("address", address))
+	}
+	return summary
+}
+
+// fetchIndexedTransfers reads the wallet's transactions, token transfers and balance
+// from the chain index. It returns nil if the transactions could not be read.
+func (a *EnhancedOnChainAggregator) fetchIndexedTransfers(ctx context.Context, address string) *transferHistory {
+	callCtx, cancel := callContext(ctx, a.callTimeout)
+	defer cancel()
+
+	txs, err := a.chainIndex.Transactions(callCtx, address, 500)
+	if err != nil {
+		logger.Warn("Failed to read indexed transactions for transfer analysis", zap.Error(err))
+		return nil
+	}
+	history := &transferHistory{txs: txs, native: a.chainIndex.NativeAsset()}
+	if history.transfers, err = a.chainIndex.TokenTransfers(callCtx, address, 500); err != nil {
+		logger.Warn("Failed to read indexed token transfers for transfer analysis", zap.Error(err))
+	}
+	if history.info, err = a.chainIndex.AddressInfo(callCtx, address); err != nil {
+		logger.Warn("Failed to read balance for net-flow analysis", zap.Error(err))
+	}
+	return history
+}
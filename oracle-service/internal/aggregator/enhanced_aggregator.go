@@ -3,10 +3,15 @@ package aggregator
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/yourusername/p2p-lend/oracle-service/internal/chains"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/labels"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
 	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -15,83 +20,90 @@ import (
 type EnhancedOffChainAggregator struct {
 	creditBureauProvider *providers.CreditBureauProvider
 	plaidProvider        *providers.PlaidProvider
-	useMockData          bool
+	normalizer           *scoring.BureauNormalizer
+	income               *IncomeTriangulator
+	mock                 providers.MockSettings
+	callTimeout          time.Duration // Bounds each provider call
 }
 
 // NewEnhancedOffChainAggregator creates an enhanced off-chain aggregator
 func NewEnhancedOffChainAggregator(
 	creditBureauProvider *providers.CreditBureauProvider,
 	plaidProvider *providers.PlaidProvider,
-	useMockData bool,
+	normalizer *scoring.BureauNormalizer,
+	mock providers.MockSettings,
 ) *EnhancedOffChainAggregator {
+	if normalizer == nil {
+		normalizer = scoring.NewBureauNormalizer(nil, nil)
+	}
+
 	return &EnhancedOffChainAggregator{
 		creditBureauProvider: creditBureauProvider,
 		plaidProvider:        plaidProvider,
-		useMockData:          useMockData,
+		normalizer:           normalizer,
+		income:               NewIncomeTriangulator(DefaultIncomePrecedence),
+		mock:                 mock,
+		callTimeout:          DefaultCallTimeout,
 	}
 }
 
+// SetIncomePrecedence sets the order income sources are trusted in when their
+// figures conflict
+func (a *EnhancedOffChainAggregator) SetIncomePrecedence(precedence []string) {
+	a.income = NewIncomeTriangulator(precedence)
+}
+
+// SetCallTimeout sets how long each provider call may take. Zero bounds calls only by
+// the request.
+func (a *EnhancedOffChainAggregator) SetCallTimeout(timeout time.Duration) {
+	a.callTimeout = timeout
+}
+
 // FetchMetrics gathers comprehensive off-chain metrics
 func (a *EnhancedOffChainAggregator) FetchMetrics(ctx context.Context, userID, address string) (*models.OffChainMetrics, error) {
 	logger.Info("Fetching enhanced off-chain metrics",
 		zap.String("userID", userID),
 		zap.String("address", address),
-		zap.Bool("mockData", a.useMockData),
+		zap.Stringer("mockData", a.mock),
 	)
 
 	metrics := &models.OffChainMetrics{
 		UserAddress: address,
 	}
 
-	// Fetch credit bureau data
-	if a.useMockData {
-		logger.Info("Using mock credit bureau data")
-		creditData := a.creditBureauProvider.MockCreditBureauData(userID)
-		metrics.TraditionalCreditScore = uint16(creditData.CreditScore)
-		metrics.DebtToIncomeRatio = creditData.DebtToIncomeRatio
-		metrics.EmploymentStatus = creditData.EmploymentStatus
-		metrics.DataSource = creditData.DataSource
-	} else {
-		creditData, err := a.creditBureauProvider.GetCreditReport(ctx, userID)
-		if err != nil {
-			logger.Error("Failed to fetch credit bureau data", zap.Error(err))
-			// Continue with partial data
-		} else {
-			metrics.TraditionalCreditScore = uint16(creditData.CreditScore)
-			metrics.DebtToIncomeRatio = creditData.DebtToIncomeRatio
-			metrics.EmploymentStatus = creditData.EmploymentStatus
-			metrics.DataSource = creditData.DataSource
-		}
-	}
-
-	// Fetch Plaid banking data
-	if a.useMockData {
-		logger.Info("Using mock Plaid data")
-		plaidData := a.plaidProvider.MockPlaidData(userID)
-		if plaidData.IncomeData != nil {
-			metrics.IncomeVerified = plaidData.IncomeData.IncomeVerified
-			metrics.IncomeLevel = a.categorizeIncome(plaidData.IncomeData.AnnualIncome)
+	// The bureau and Plaid are fetched concurrently, then applied in order
+	var creditData *providers.CreditBureauResponse
+	var plaidData *providers.PlaidAccountSummary
+	runParallel(
+		// Fetch credit bureau data
+		func() {
+			var err error
+			creditData, _, err = a.CreditReport(ctx, userID)
+			if err != nil {
+				logger.Error("Failed to fetch credit bureau data", zap.Error(err))
+				// Continue with partial data
+			}
+		},
+		// Fetch Plaid banking data. Access tokens are passed per request, so without
+		// one only mock data can be served here.
+		func() {
+			plaidData, _, _ = a.BankData(ctx, userID, "")
+		},
+	)
 
-			// Calculate bank account history score
-			metrics.BankAccountHistory = a.calculateBankScore(plaidData)
-		}
-	} else {
-		// Note: In production, you'd get the Plaid access token from your database
-		// For now, we'll use mock data
-		logger.Warn("Plaid requires access token - using mock data")
-		plaidData := a.plaidProvider.MockPlaidData(userID)
-		if plaidData.IncomeData != nil {
-			metrics.IncomeVerified = plaidData.IncomeData.IncomeVerified
-			metrics.IncomeLevel = a.categorizeIncome(plaidData.IncomeData.AnnualIncome)
-			metrics.BankAccountHistory = a.calculateBankScore(plaidData)
-		}
+	if creditData != nil {
+		a.applyCreditReport(metrics, creditData)
 	}
+	if plaidData != nil {
+		a.ApplyBankData(metrics, plaidData)
+	}
+	a.ApplyIncome(metrics, a.TriangulateIncome(creditData, plaidData, nil, nil))
 
 	metrics.LastVerified = time.Now()
 	metrics.UpdatedAt = time.Now()
 
 	logger.Info("Enhanced off-chain metrics fetched successfully",
-		zap.Uint16("creditScore", metrics.TraditionalCreditScore),
+		zap.Uint16("creditScore", metrics.TraditionalCreditScore.Uint16()),
 		zap.Bool("incomeVerified", metrics.IncomeVerified),
 		zap.String("incomeLevel", metrics.IncomeLevel),
 	)
@@ -99,19 +111,194 @@ func (a *EnhancedOffChainAggregator) FetchMetrics(ctx context.Context, userID, a
 	return metrics, nil
 }
 
+// CreditReport pulls a consumer's bureau report according to the bureau's mock mode.
+// mocked reports whether the report is mock data; err is the failed pull, returned
+// even when mock data is served in its place.
+func (a *EnhancedOffChainAggregator) CreditReport(ctx context.Context, userID string) (report *providers.CreditBureauResponse, mocked bool, err error) {
+	if a.mock.Bureau == providers.MockAlways {
+		logger.Info("Using mock credit bureau data")
+		return a.creditBureauProvider.MockCreditBureauData(userID), true, nil
+	}
+
+	callCtx, cancel := callContext(ctx, a.callTimeout)
+	defer cancel()
+	report, err = a.creditBureauProvider.GetCreditReport(callCtx, userID)
+	if err == nil {
+		return report, false, nil
+	}
+	if a.mock.Bureau == providers.MockFallback {
+		logger.Warn("Credit bureau unavailable - using mock data", zap.Error(err))
+		return a.creditBureauProvider.MockCreditBureauData(userID), true, err
+	}
+	return nil, false, err
+}
+
+// BankData pulls a Plaid account summary according to Plaid's mock mode. Without an
+// access token there is no account to call Plaid for, so only the always mode serves
+// data. mocked and err are as for CreditReport.
+func (a *EnhancedOffChainAggregator) BankData(ctx context.Context, userID, accessToken string) (summary *providers.PlaidAccountSummary, mocked bool, err error) {
+	if a.mock.Plaid == providers.MockAlways {
+		logger.Info("Using mock Plaid data")
+		return a.plaidProvider.MockPlaidData(userID), true, nil
+	}
+	if accessToken == "" {
+		logger.Info("No Plaid access token provided, skipping bank data")
+		return nil, false, nil
+	}
+
+	callCtx, cancel := callContext(ctx, a.callTimeout)
+	defer cancel()
+	summary, err = a.plaidProvider.GetAccountSummary(callCtx, accessToken)
+	if err == nil {
+		return summary, false, nil
+	}
+	if a.mock.Plaid == providers.MockFallback {
+		logger.Warn("Plaid unavailable - using mock data", zap.Error(err))
+		return a.plaidProvider.MockPlaidData(userID), true, err
+	}
+	return nil, false, err
+}
+
+// RefreshCreditReport re-pulls the bureau report for a consumer and applies it to
+// existing metrics, leaving bank, employment, and income data untouched
+func (a *EnhancedOffChainAggregator) RefreshCreditReport(ctx context.Context, metrics *models.OffChainMetrics, userID string) error {
+	var creditData *providers.CreditBureauResponse
+	if a.mock.Bureau == providers.MockAlways {
+		creditData = a.creditBureauProvider.MockCreditBureauData(userID)
+	} else {
+		var err error
+		creditData, err = a.creditBureauProvider.GetCreditReport(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to refresh credit report: %w", err)
+		}
+	}
+
+	a.applyCreditReport(metrics, creditData)
+	metrics.LastVerified = time.Now()
+
+	return nil
+}
+
+// applyCreditReport copies bureau data into metrics, normalizing the score and DTI
+// from the bureau's native scale into the engine's internal scale
+func (a *EnhancedOffChainAggregator) applyCreditReport(metrics *models.OffChainMetrics, creditData *providers.CreditBureauResponse) {
+	provider := a.creditBureauProvider.Name()
+	region := creditData.Region
+
+	metrics.RawBureauScore = creditData.CreditScore
+	metrics.BureauRegion = region
+	metrics.TraditionalCreditScore = a.normalizer.NormalizeScore(provider, region, creditData.ScoreRange, creditData.CreditScore)
+	metrics.DebtToIncomeRatio = a.normalizer.NormalizeDTI(provider, region, creditData.DebtToIncomeRatio)
+	metrics.EmploymentStatus = creditData.EmploymentStatus
+	metrics.DataSource = creditData.DataSource
+
+	if int(metrics.TraditionalCreditScore) != creditData.CreditScore {
+		logger.Info("Normalized bureau score",
+			zap.String("provider", provider),
+			zap.String("region", region),
+			zap.Int("rawScore", creditData.CreditScore),
+			zap.Uint16("normalizedScore", metrics.TraditionalCreditScore.Uint16()),
+		)
+	}
+}
+
+// ApplyEmploymentVerification overrides self-reported employment data with payroll-verified
+// data from an employment provider. Unverified or terminated records only update the status.
+func (a *EnhancedOffChainAggregator) ApplyEmploymentVerification(metrics *models.OffChainMetrics, employment *providers.EmploymentVerification) {
+	if employment == nil {
+		return
+	}
+
+	metrics.EmploymentStatus = employment.EmploymentStatus
+	metrics.EmploymentVerified = employment.Verified
+	if employment.Verified && employment.TenureMonths > 0 {
+		metrics.EmploymentTenure = uint32(employment.TenureMonths)
+	} else {
+		metrics.EmploymentTenure = 0
+	}
+
+	if metrics.DataSource == "" {
+		metrics.DataSource = employment.DataSource
+	} else {
+		metrics.DataSource += "," + employment.DataSource
+	}
+}
+
+// ApplyBankData copies Plaid income into metrics and scores bank account history,
+// discounting balances that look like temporary deposits
+func (a *EnhancedOffChainAggregator) ApplyBankData(metrics *models.OffChainMetrics, plaidData *providers.PlaidAccountSummary) {
+	if plaidData.IncomeData == nil {
+		return
+	}
+
+	metrics.IncomeVerified = plaidData.IncomeData.IncomeVerified
+	metrics.IncomeLevel = a.categorizeIncome(plaidData.IncomeData.AnnualIncome)
+	metrics.IncomeSource = models.IncomeSourcePlaid
+	metrics.EstimatedAnnualIncome = plaidData.IncomeData.AnnualIncome
+
+	netFlow := AnalyzeNetFlows(plaidFlows(plaidData.Transactions), plaidData.TotalBalance, time.Now())
+	metrics.TemporaryDiscount = netFlow.Discount
+	if netFlow.Discount > 0 {
+		logger.Info("Discounting temporary bank deposits",
+			zap.Float64("recentInflowShare", netFlow.RecentInflowShare),
+			zap.Float64("discount", netFlow.Discount),
+		)
+	}
+
+	// Calculate bank account history score
+	metrics.BankAccountHistory = a.calculateBankScore(plaidData, netFlow.Discount)
+}
+
+// TriangulateIncome reconciles the income reported by each source, resolving
+// conflicts by the income precedence. Any source may be nil.
+func (a *EnhancedOffChainAggregator) TriangulateIncome(
+	bureau *providers.CreditBureauResponse,
+	plaid *providers.PlaidAccountSummary,
+	employment *providers.EmploymentVerification,
+	payroll *PayrollDetection,
+) *IncomeTriangulation {
+	return a.income.Triangulate(bureau, plaid, employment, payroll)
+}
+
+// ApplyIncome makes a triangulation's reconciled income the figure the engine scores.
+// Metrics keep their income when no source in the precedence reported one.
+func (a *EnhancedOffChainAggregator) ApplyIncome(metrics *models.OffChainMetrics, income *IncomeTriangulation) bool {
+	if income == nil || income.Source == "" {
+		return false
+	}
+
+	metrics.IncomeLevel = a.categorizeIncome(income.AnnualIncome)
+	metrics.IncomeSource = income.Source
+	metrics.EstimatedAnnualIncome = income.AnnualIncome
+	metrics.IncomeVerified = income.Verified
+	metrics.IncomeConfidence = income.Confidence
+
+	// Payroll is not a provider, so it is added to the data lineage here
+	if income.Source == models.IncomeSourceOnChainPayroll && !strings.Contains(metrics.DataSource, models.IncomeSourceOnChainPayroll) {
+		if metrics.DataSource == "" {
+			metrics.DataSource = models.IncomeSourceOnChainPayroll
+		} else {
+			metrics.DataSource += "," + models.IncomeSourceOnChainPayroll
+		}
+	}
+
+	return true
+}
+
 // categorizeIncome categorizes annual income into levels
-func (a *EnhancedOffChainAggregator) categorizeIncome(annualIncome float64) string {
-	if annualIncome >= 100000 {
+func (a *EnhancedOffChainAggregator) categorizeIncome(annualIncome units.Decimal) string {
+	if annualIncome.Cmp(units.DecimalFromInt(100000)) >= 0 {
 		return "high"
-	} else if annualIncome >= 50000 {
+	} else if annualIncome.Cmp(units.DecimalFromInt(50000)) >= 0 {
 		return "medium"
 	}
 	return "low"
 }
 
 // calculateBankScore creates a bank account history score (0-100)
-func (a *EnhancedOffChainAggregator) calculateBankScore(plaidData *providers.PlaidAccountSummary) uint8 {
+func (a *EnhancedOffChainAggregator) calculateBankScore(plaidData *providers.PlaidAccountSummary, balanceDiscount float64) uint8 {
 	score := 0.0
+	averageBalance := plaidData.AverageBalance.Mul(units.DecimalFromFloat(1 - balanceDiscount))
 
 	// Account age (30 points)
 	if plaidData.AccountAgeMonths >= 36 {
@@ -121,10 +308,10 @@ func (a *EnhancedOffChainAggregator) calculateBankScore(plaidData *providers.Pla
 	}
 
 	// Average balance (25 points)
-	if plaidData.AverageBalance >= 5000 {
+	if averageBalance.Cmp(units.DecimalFromInt(5000)) >= 0 {
 		score += 25
 	} else {
-		score += (plaidData.AverageBalance / 5000.0) * 25
+		score += (averageBalance.Float64() / 5000.0) * 25
 	}
 
 	// Transaction activity (20 points)
@@ -135,8 +322,8 @@ func (a *EnhancedOffChainAggregator) calculateBankScore(plaidData *providers.Pla
 	}
 
 	// Savings rate (25 points)
-	if plaidData.IncomeData != nil && plaidData.IncomeData.MonthlyIncome > 0 {
-		savingsRate := (plaidData.AverageBalance - plaidData.AverageMonthlySpend) / plaidData.IncomeData.MonthlyIncome
+	if plaidData.IncomeData != nil && plaidData.IncomeData.MonthlyIncome.Sign() > 0 {
+		savingsRate := averageBalance.Sub(plaidData.AverageMonthlySpend).Div(plaidData.IncomeData.MonthlyIncome).Float64()
 		if savingsRate >= 0.20 { // 20% savings rate
 			score += 25
 		} else if savingsRate > 0 {
@@ -153,18 +340,20 @@ func (a *EnhancedOffChainAggregator) calculateBankScore(plaidData *providers.Pla
 
 // HealthCheck verifies all providers are healthy
 func (a *EnhancedOffChainAggregator) HealthCheck(ctx context.Context) error {
-	if a.useMockData {
-		return nil // Mock data always healthy
-	}
+	// Providers serving mock data are always healthy
 
 	// Check credit bureau
-	if err := a.creditBureauProvider.HealthCheck(ctx); err != nil {
-		return fmt.Errorf("credit bureau unhealthy: %w", err)
+	if a.mock.Bureau != providers.MockAlways {
+		if err := a.creditBureauProvider.HealthCheck(ctx); err != nil {
+			return fmt.Errorf("credit bureau unhealthy: %w", err)
+		}
 	}
 
 	// Check Plaid
-	if err := a.plaidProvider.HealthCheck(ctx); err != nil {
-		return fmt.Errorf("plaid unhealthy: %w", err)
+	if a.mock.Plaid != providers.MockAlways {
+		if err := a.plaidProvider.HealthCheck(ctx); err != nil {
+			return fmt.Errorf("plaid unhealthy: %w", err)
+		}
 	}
 
 	return nil
@@ -175,10 +364,14 @@ type EnhancedOnChainAggregator struct {
 	blockchainProvider *providers.BlockchainDataProvider
 	blockscoutProvider *providers.BlockscoutProvider
 	ethClient          *OnChainAggregator // Fallback to direct RPC
-	useMockData        bool
-	preferBlockscout   bool     // Prefer Blockscout over other providers
-	enableMultiChain   bool     // Enable multi-chain data fetching
-	targetChains       []string // Target chains to fetch from
+	preferBlockscout   bool               // Prefer Blockscout over other providers
+	enableMultiChain   bool               // Enable multi-chain data fetching
+	targetChains       []string           // Target chains to fetch from
+	labelRegistry      *labels.Registry   // Known exchange, mixer, bridge, scam, payroll, protocol addresses
+	tokenFilter        *providers.TokenFilter
+	balanceMonths      int           // Months of balance history to sample (0 disables)
+	callTimeout        time.Duration // Bounds each provider call
+	chainIndex         ChainIndex    // Self-hosted index replacing the explorer providers (optional)
 }
 
 // NewEnhancedOnChainAggregator creates an enhanced on-chain aggregator
@@ -186,124 +379,494 @@ func NewEnhancedOnChainAggregator(
 	blockchainProvider *providers.BlockchainDataProvider,
 	blockscoutProvider *providers.BlockscoutProvider,
 	ethClient *OnChainAggregator,
-	useMockData bool,
 	preferBlockscout bool,
 	enableMultiChain bool,
 	targetChains []string,
+	labelRegistry *labels.Registry,
+	tokenFilter *providers.TokenFilter,
+	balanceMonths int,
 ) *EnhancedOnChainAggregator {
+	if labelRegistry == nil {
+		labelRegistry = labels.NewRegistry(labels.DefaultLabels())
+	}
+
 	return &EnhancedOnChainAggregator{
 		blockchainProvider: blockchainProvider,
 		blockscoutProvider: blockscoutProvider,
 		ethClient:          ethClient,
-		useMockData:        useMockData,
 		preferBlockscout:   preferBlockscout,
 		enableMultiChain:   enableMultiChain,
 		targetChains:       targetChains,
+		labelRegistry:      labelRegistry,
+		tokenFilter:        tokenFilter,
+		balanceMonths:      balanceMonths,
+		callTimeout:        DefaultCallTimeout,
 	}
 }
 
+// SetCallTimeout sets how long each provider call may take. Zero bounds calls only by
+// the request.
+func (a *EnhancedOnChainAggregator) SetCallTimeout(timeout time.Duration) {
+	a.callTimeout = timeout
+}
+
+// SetChainIndex reads summaries and transfers from a self-hosted chain index instead of
+// the explorer providers, for a chain they don't cover
+func (a *EnhancedOnChainAggregator) SetChainIndex(index ChainIndex) {
+	a.chainIndex = index
+}
+
 // FetchMetrics gathers enhanced on-chain metrics
 func (a *EnhancedOnChainAggregator) FetchMetrics(ctx context.Context, address string) (*models.OnChainMetrics, error) {
 	logger.Info("Fetching enhanced on-chain metrics",
 		zap.String("address", address),
-		zap.Bool("mockData", a.useMockData),
 		zap.Bool("preferBlockscout", a.preferBlockscout),
 		zap.Bool("multiChain", a.enableMultiChain),
 		zap.Strings("targetChains", a.targetChains),
 	)
 
+	// The summary, the transfers and the balance history come from independent
+	// provider calls, so they are fetched concurrently
 	var blockchainData *providers.BlockchainSummary
-	var err error
+	var transfers *transferHistory
+	var balances *balanceHistory
+	runParallel(
+		func() { blockchainData = a.fetchSummary(ctx, address) },
+		func() { transfers = a.fetchTransfers(ctx, address) },
+		func() { balances = a.fetchBalanceHistory(ctx, address) },
+	)
+
+	// Final fallback to direct RPC if all providers failed
+	if blockchainData == nil {
+		logger.Warn("All blockchain providers failed, falling back to direct RPC")
+		return a.ethClient.FetchMetrics(ctx, address)
+	}
+
+	// NOTE: On-chain data is ALWAYS real, never mock data (see providers.MockSettings)
+	// If all blockchain data sources fail, the direct RPC fallback above will handle it
+
+	// Deposit/withdraw cycles and round-trip swaps only inflate the DeFi history, so
+	// they count towards neither DeFi interactions nor repayments
+	wash := a.detectWashActivity(address, blockchainData.DeFiActivities, transfers)
+	activities := wash.Genuine(blockchainData.DeFiActivities)
+
+	// Convert blockchain summary to OnChainMetrics
+	metrics := &models.OnChainMetrics{
+		UserAddress:         address,
+		WalletAge:           uint32(blockchainData.WalletAge),
+		TotalTransactions:   uint32(blockchainData.TotalTransactions),
+		AvgTransactionValue: units.DecimalFromFloat(blockchainData.AverageTransactionSize),
+		DeFiInteractions:    uint32(providers.SuccessfulDeFiActivities(activities)),
+		WashActivities:      wash.Count(),
+		CollateralValue:     units.DecimalFromFloat(blockchainData.TotalPortfolioValue),
+		LastActivity:        blockchainData.LastTransaction,
+		UpdatedAt:           time.Now(),
+	}
+
+	a.applyRepayments(metrics, activities, blockchainData.LendingPositions)
+	metrics.LiquidationEvents = uint32(len(blockchainData.LiquidationEvents))
+
+	lineage := a.traceBridgedFunds(ctx, address, blockchainData, transfers)
+	a.applyTransferAnalyses(metrics, transfers, lineage)
+	a.applyBalanceHistory(metrics, balances)
+
+	logger.Info("Enhanced on-chain metrics fetched successfully",
+		zap.Int("spamTokensExcluded", blockchainData.SpamTokensExcluded),
+		zap.Uint32("walletAge", metrics.WalletAge),
+		zap.Uint32("transactions", metrics.TotalTransactions),
+		zap.Uint32("defiInteractions", metrics.DeFiInteractions),
+	)
+
+	return metrics, nil
+}
+
+// fetchSummary fetches the wallet summary from the first provider that has it:
+// multi-chain Blockscout, single-chain Blockscout, then Covalent/Moralis. With a chain
+// index it is the only source, since the providers cover other chains. It returns nil
+// if every provider failed.
+func (a *EnhancedOnChainAggregator) fetchSummary(ctx context.Context, address string) *providers.BlockchainSummary {
+	if a.chainIndex != nil {
+		return a.fetchIndexedSummary(ctx, address)
+	}
 
 	// MULTI-CHAIN FETCHING: Aggregate data from multiple EVM chains
 	if a.enableMultiChain && a.blockscoutProvider != nil {
 		logger.Info("Fetching from multiple chains", zap.Strings("chains", a.targetChains))
-		multiChainData, err := providers.GetMultiChainAnalytics(ctx, address, a.targetChains)
+		callCtx, cancel := callContext(ctx, a.callTimeout)
+		multiChainData, err := providers.GetMultiChainAnalytics(callCtx, address, a.targetChains, a.tokenFilter)
+		cancel()
 		if err != nil {
 			logger.Error("Failed to fetch multi-chain data", zap.Error(err))
 		} else if multiChainData.TotalTransactions > 0 {
-			blockchainData = providers.ConvertMultiChainToBlockchainSummary(multiChainData)
 			logger.Info("Multi-chain data fetched successfully",
 				zap.Int("activeChains", multiChainData.TotalChains),
 				zap.Strings("chains", multiChainData.ActiveChains),
 				zap.Int("totalTxs", multiChainData.TotalTransactions),
 			)
+			return providers.ConvertMultiChainToBlockchainSummary(multiChainData)
 		}
 	}
 
 	// SINGLE CHAIN FALLBACK: Try Blockscout for single chain if multi-chain failed
-	if blockchainData == nil && a.preferBlockscout && a.blockscoutProvider != nil {
+	if a.preferBlockscout && a.blockscoutProvider != nil {
 		logger.Info("Fetching from Blockscout (single chain)")
-		blockscoutData, err := a.blockscoutProvider.GetAnalytics(ctx, address)
+		callCtx, cancel := callContext(ctx, a.callTimeout)
+		blockscoutData, err := a.blockscoutProvider.GetAnalytics(callCtx, address)
+		cancel()
 		if err != nil {
 			logger.Error("Failed to fetch from Blockscout, trying alternative provider", zap.Error(err))
 		} else {
-			blockchainData = a.blockscoutProvider.ConvertToBlockchainSummary(blockscoutData)
+			return a.blockscoutProvider.ConvertToBlockchainSummary(blockscoutData)
 		}
 	}
 
 	// Fallback to Covalent/Moralis if Blockscout failed or not preferred
-	if blockchainData == nil {
-		logger.Info("Fetching from blockchain data provider (Covalent/Moralis)")
-		blockchainData, err = a.blockchainProvider.GetBlockchainSummary(ctx, address, "1") // Ethereum mainnet
+	logger.Info("Fetching from blockchain data provider (Covalent/Moralis)")
+	callCtx, cancel := callContext(ctx, a.callTimeout)
+	defer cancel()
+	blockchainData, err := a.blockchainProvider.GetBlockchainSummary(callCtx, address, "1") // Ethereum mainnet
+	if err != nil {
+		logger.Error("Failed to fetch from blockchain provider, trying direct RPC", zap.Error(err))
+		return nil
+	}
+	return blockchainData
+}
+
+// transferHistory is the Blockscout data behind the transfer analyses
+type transferHistory struct {
+	txs       []providers.BlockscoutTransaction
+	transfers []providers.BlockscoutTokenTransfer
+	info      *providers.BlockscoutAddressInfo // nil if the balance could not be fetched
+	native    chains.NativeAsset               // Coin of the chain the history is on
+}
+
+// fetchTransfers fetches the wallet's transactions, token transfers and balance
+// concurrently, from the chain index when there is one. It returns nil if neither
+// Blockscout nor a chain index is configured or the transactions could not be fetched.
+func (a *EnhancedOnChainAggregator) fetchTransfers(ctx context.Context, address string) *transferHistory {
+	if a.chainIndex != nil {
+		return a.fetchIndexedTransfers(ctx, address)
+	}
+	if a.blockscoutProvider == nil {
+		return nil
+	}
+
+	history := &transferHistory{native: a.blockscoutProvider.NativeAsset()}
+	var txErr error
+	runParallel(
+		func() {
+			callCtx, cancel := callContext(ctx, a.callTimeout)
+			defer cancel()
+			history.txs, txErr = a.blockscoutProvider.GetTransactions(callCtx, address, 1, 500)
+		},
+		func() {
+			callCtx, cancel := callContext(ctx, a.callTimeout)
+			defer cancel()
+			transfers, err := a.blockscoutProvider.GetTokenTransfers(callCtx, address, 1, 500)
+			if err != nil {
+				logger.Warn("Failed to fetch token transfers for transfer analysis", zap.Error(err))
+			}
+			history.transfers = transfers
+		},
+		func() {
+			callCtx, cancel := callContext(ctx, a.callTimeout)
+			defer cancel()
+			info, err := a.blockscoutProvider.GetAddressInfo(callCtx, address)
+			if err != nil {
+				logger.Warn("Failed to fetch balance for net-flow analysis", zap.Error(err))
+				return
+			}
+			history.info = info
+		},
+	)
+
+	if txErr != nil {
+		logger.Warn("Failed to fetch transactions for transfer analysis", zap.Error(txErr))
+		return nil
+	}
+	return history
+}
+
+// applyTransferAnalyses runs the counterparty, bridge and net-flow analyses over the
+// wallet's transfers. Bridge deposits of the wallet's own funds aren't new deposits to
+// the net-flow analysis.
+func (a *EnhancedOnChainAggregator) applyTransferAnalyses(metrics *models.OnChainMetrics, history *transferHistory, lineage *BridgeLineage) {
+	if history == nil {
+		return
+	}
+
+	a.applyFundingProfile(metrics, history.txs, history.transfers)
+	a.applyBridgeLineage(metrics, lineage)
+	if history.info != nil {
+		a.applyNetFlow(metrics, lineage.WithoutCarried(history.txs), history.info, history.native)
+	}
+}
+
+// traceBridgedFunds links the wallet's bridge deposits to its activity on the chains
+// they came from. With multi-chain fetching, source chains the summary didn't query are
+// looked up on Blockscout. It returns nil without transfers.
+func (a *EnhancedOnChainAggregator) traceBridgedFunds(ctx context.Context, address string, summary *providers.BlockchainSummary, history *transferHistory) *BridgeLineage {
+	if history == nil {
+		return nil
+	}
+	deposits := DetectBridgeDeposits(address, history.txs, history.transfers, a.labelRegistry)
+	if len(deposits) == 0 {
+		return nil
+	}
+
+	// A multi-chain summary covers every chain it queried, active or not
+	queried := func(chain string) bool {
+		if summary.Chains == nil {
+			return false
+		}
+		if len(a.targetChains) == 0 {
+			return true
+		}
+		for _, target := range a.targetChains {
+			if target == chain {
+				return true
+			}
+		}
+		return false
+	}
+
+	var missing []string
+	if a.enableMultiChain && a.blockscoutProvider != nil && a.chainIndex == nil {
+		supported := providers.GetSupportedBlockscoutChains()
+		seen := make(map[string]bool)
+		for _, deposit := range deposits {
+			chain := deposit.SourceChain
+			if _, ok := supported[chain]; !ok || seen[chain] || queried(chain) {
+				continue
+			}
+			seen[chain] = true
+			missing = append(missing, chain)
+		}
+	}
+
+	fetched := make(map[string]providers.ChainActivity)
+	if len(missing) > 0 {
+		callCtx, cancel := callContext(ctx, a.callTimeout)
+		analytics, err := providers.GetMultiChainAnalytics(callCtx, address, missing, a.tokenFilter)
+		cancel()
 		if err != nil {
-			logger.Error("Failed to fetch from blockchain provider, trying direct RPC", zap.Error(err))
+			logger.Warn("Failed to fetch source-chain activity for bridged funds", zap.Strings("chains", missing), zap.Error(err))
+		} else {
+			for chain, data := range analytics.ChainData {
+				fetched[chain] = data.Activity()
+			}
 		}
 	}
 
-	// Final fallback to direct RPC if all providers failed
-	if blockchainData == nil {
-		logger.Warn("All blockchain providers failed, falling back to direct RPC")
-		return a.ethClient.FetchMetrics(ctx, address)
+	return NewBridgeLineage(deposits, summary.Chains, fetched)
+}
+
+// applyBridgeLineage records where bridged funds came from and credits the wallet with
+// its age and activity on those chains
+func (a *EnhancedOnChainAggregator) applyBridgeLineage(metrics *models.OnChainMetrics, lineage *BridgeLineage) {
+	if lineage == nil {
+		return
 	}
 
-	// NOTE: On-chain data should ALWAYS be real, never use mock data
-	// useMockData flag only applies to off-chain APIs (Plaid, Credit Bureau)
-	// If all blockchain data sources fail, the direct RPC fallback above will handle it
+	metrics.BridgedFrom = strings.Join(lineage.SourceChains, ",")
+	for _, activity := range lineage.Attributed {
+		if age := uint32(activity.WalletAge); age > metrics.WalletAge {
+			metrics.WalletAge = age
+		}
+		metrics.TotalTransactions += uint32(activity.TotalTransactions)
+	}
 
-	// Convert blockchain summary to OnChainMetrics
-	metrics := &models.OnChainMetrics{
-		UserAddress:         address,
-		WalletAge:           uint32(blockchainData.WalletAge),
-		TotalTransactions:   uint32(blockchainData.TotalTransactions),
-		AvgTransactionValue: blockchainData.AverageTransactionSize,
-		DeFiInteractions:    uint32(len(blockchainData.DeFiActivities)),
-		CollateralValue:     blockchainData.TotalPortfolioValue,
-		LastActivity:        blockchainData.LastTransaction,
-		UpdatedAt:           time.Now(),
+	logger.Info("Bridged funds traced to source chains",
+		zap.String("address", metrics.UserAddress),
+		zap.Int("deposits", len(lineage.Deposits)),
+		zap.Strings("sourceChains", lineage.SourceChains),
+		zap.Int("carriedDeposits", lineage.CarriedCount()),
+		zap.Int("attributedChains", len(lineage.Attributed)),
+	)
+}
+
+// detectWashActivity finds the wallet's self-referential DeFi loops. Round-trip swaps
+// are only found when the token transfers could be fetched.
+func (a *EnhancedOnChainAggregator) detectWashActivity(address string, activities []providers.DeFiActivity, history *transferHistory) *WashActivity {
+	var transfers []providers.BlockscoutTokenTransfer
+	if history != nil {
+		transfers = history.transfers
+	}
+
+	wash := DetectWashActivity(address, activities, transfers)
+	if wash.Count() > 0 {
+		logger.Info("Wash activity excluded from DeFi metrics",
+			zap.String("address", address),
+			zap.Uint32("positionCycles", wash.PositionCycles),
+			zap.Uint32("swapRoundTrips", wash.SwapRoundTrips),
+			zap.Uint32("transactions", wash.Count()),
+		)
 	}
+	return wash
+}
 
-	// Calculate borrowing metrics from lending positions
-	borrowCount := 0
-	repayCount := 0
-	for _, pos := range blockchainData.LendingPositions {
+// applyRepayments derives borrowing and repayment history by matching repayments to
+// borrows. Open positions the provider reports count as outstanding loans even when
+// their borrows predate the transaction history.
+func (a *EnhancedOnChainAggregator) applyRepayments(metrics *models.OnChainMetrics, activities []providers.DeFiActivity, positions []providers.LendingPosition) {
+	repayments := MatchRepayments(activities)
+	metrics.BorrowingHistory = repayments.Borrows
+	metrics.RepaymentHistory = repayments.Repaid
+	metrics.RepaymentRatio = repayments.RepaymentRatio
+	metrics.AvgDaysToRepay = repayments.AvgDaysToRepay
+	metrics.OutstandingLoans = repayments.OutstandingLoans
+
+	var openPositions uint32
+	for _, pos := range positions {
 		if pos.BorrowedAmount > 0 {
-			borrowCount++
-			if pos.HealthFactor > 1.5 { // Healthy position = good repayment
-				repayCount++
-			}
+			openPositions++
 		}
 	}
+	if openPositions > metrics.OutstandingLoans {
+		metrics.OutstandingLoans = openPositions
+	}
 
-	metrics.BorrowingHistory = uint32(borrowCount)
-	metrics.RepaymentHistory = uint32(repayCount)
-	metrics.LiquidationEvents = uint32(len(blockchainData.LiquidationEvents))
+	if repayments.Borrows > 0 {
+		logger.Info("Repayments matched to borrows",
+			zap.String("address", metrics.UserAddress),
+			zap.Uint32("borrows", repayments.Borrows),
+			zap.Uint32("repaid", repayments.Repaid),
+			zap.Float64("repaymentRatio", repayments.RepaymentRatio),
+			zap.Float64("avgDaysToRepay", repayments.AvgDaysToRepay),
+		)
+	}
+}
 
-	logger.Info("Enhanced on-chain metrics fetched successfully",
-		zap.Uint32("walletAge", metrics.WalletAge),
-		zap.Uint32("transactions", metrics.TotalTransactions),
-		zap.Uint32("defiInteractions", metrics.DeFiInteractions),
+// applyNetFlow discounts collateral that looks like a temporary deposit
+func (a *EnhancedOnChainAggregator) applyNetFlow(metrics *models.OnChainMetrics, txs []providers.BlockscoutTransaction, info *providers.BlockscoutAddressInfo, native chains.NativeAsset) {
+	balance, err := providers.ParseTokenAmount(info.Balance, native.Decimals)
+	if err != nil {
+		logger.Warn("Invalid balance for net-flow analysis", zap.String("address", metrics.UserAddress), zap.Error(err))
+		return
+	}
+
+	netFlow := AnalyzeNetFlows(nativeFlows(metrics.UserAddress, txs, native), balance, time.Now())
+	metrics.TemporaryDeposits = netFlow.TemporaryDepositCount
+	metrics.TemporaryDiscount = netFlow.Discount
+
+	if netFlow.Discount > 0 {
+		logger.Info("Discounting temporary on-chain deposits",
+			zap.String("address", metrics.UserAddress),
+			zap.Float64("recentInflowShare", netFlow.RecentInflowShare),
+			zap.Uint32("pastTemporaryDeposits", netFlow.TemporaryDepositCount),
+			zap.Float64("discount", netFlow.Discount),
+		)
+	}
+}
+
+// applyFundingProfile classifies inbound funds against known exchange and mixer addresses
+func (a *EnhancedOnChainAggregator) applyFundingProfile(metrics *models.OnChainMetrics, txs []providers.BlockscoutTransaction, transfers []providers.BlockscoutTokenTransfer) {
+	profile := AnalyzeFundingSources(metrics.UserAddress, txs, transfers, a.labelRegistry)
+	metrics.TotalInflows = profile.TotalInflows
+	metrics.CEXInflows = profile.CEXInflows
+	metrics.CEXActiveMonths = profile.CEXActiveMonths
+	metrics.MixerInflows = profile.MixerInflows
+	metrics.BridgeInflows = profile.BridgeInflows
+	metrics.ScamInteractions = profile.ScamInteractions
+
+	logger.Info("Funding sources analyzed",
+		zap.String("address", metrics.UserAddress),
+		zap.Uint32("cexInflows", profile.CEXInflows),
+		zap.Uint32("mixerInflows", profile.MixerInflows),
+		zap.Uint32("scamInteractions", profile.ScamInteractions),
+		zap.Strings("exchanges", profile.Exchanges),
+		zap.Strings("protocols", profile.Protocols),
 	)
+}
 
-	return metrics, nil
+// balanceHistory is a wallet's monthly balance samples and where they came from
+type balanceHistory struct {
+	samples []providers.BalanceSample
+	source  string
 }
 
-// HealthCheck verifies blockchain provider is healthy
-func (a *EnhancedOnChainAggregator) HealthCheck(ctx context.Context) error {
-	if a.useMockData {
+// fetchBalanceHistory samples monthly balances. Covalent portfolio history is
+// preferred; the archive node is the fallback. It returns nil if balance history is
+// disabled or the archive node could not be sampled.
+func (a *EnhancedOnChainAggregator) fetchBalanceHistory(ctx context.Context, address string) *balanceHistory {
+	if a.balanceMonths <= 0 {
 		return nil
 	}
 
+	history := &balanceHistory{source: "covalent"}
+	if a.blockchainProvider != nil && a.blockchainProvider.SupportsHistoricalBalances() {
+		callCtx, cancel := callContext(ctx, a.callTimeout)
+		samples, err := a.blockchainProvider.GetHistoricalBalances(callCtx, address, "1", a.balanceMonths)
+		cancel()
+		if err != nil {
+			logger.Warn("Failed to fetch historical balances from provider", zap.Error(err))
+		}
+		history.samples = samples
+	}
+
+	if len(history.samples) == 0 && a.ethClient != nil {
+		history.source = "archive_node"
+		callCtx, cancel := callContext(ctx, a.callTimeout)
+		defer cancel()
+		samples, err := a.ethClient.SampleBalances(callCtx, address, a.balanceMonths)
+		if err != nil {
+			logger.Warn("Failed to sample historical balances from archive node", zap.Error(err))
+			return nil
+		}
+		history.samples = samples
+	}
+
+	return history
+}
+
+// applyBalanceHistory derives the balance-stability metric from sampled balances
+func (a *EnhancedOnChainAggregator) applyBalanceHistory(metrics *models.OnChainMetrics, history *balanceHistory) {
+	if history == nil {
+		return
+	}
+
+	metrics.BalanceSamples = uint32(len(history.samples))
+	metrics.BalanceStability = CalculateBalanceStability(history.samples)
+
+	logger.Info("Balance history sampled",
+		zap.String("address", metrics.UserAddress),
+		zap.String("source", history.source),
+		zap.Int("samples", len(history.samples)),
+		zap.Float64("stability", metrics.BalanceStability),
+	)
+}
+
+// DetectPayrollIncome scans stablecoin transfers for recurring payroll inflows
+func (a *EnhancedOnChainAggregator) DetectPayrollIncome(ctx context.Context, address string) (*PayrollDetection, error) {
+	if a.blockscoutProvider == nil {
+		return nil, fmt.Errorf("blockscout provider not configured")
+	}
+
+	transfers, err := a.blockscoutProvider.GetTokenTransfers(ctx, address, 1, 500)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token transfers: %w", err)
+	}
+
+	detection := DetectStablecoinPayroll(address, transfers, time.Now())
+
+	// Attach known payroll provider names to matching streams
+	for i := range detection.Streams {
+		if label, ok := a.labelRegistry.Lookup(detection.Streams[i].Sender); ok && label.Category == models.LabelCategoryPayroll {
+			detection.Streams[i].SenderLabel = label.Name
+		}
+	}
+
+	logger.Info("Stablecoin payroll detection completed",
+		zap.String("address", address),
+		zap.Int("streams", len(detection.Streams)),
+		zap.Stringer("annualizedIncome", detection.AnnualizedIncome),
+	)
+
+	return detection, nil
+}
+
+// HealthCheck verifies blockchain provider is healthy
+func (a *EnhancedOnChainAggregator) HealthCheck(ctx context.Context) error {
 	// Check Blockscout if available
 	if a.blockscoutProvider != nil {
 		if err := a.blockscoutProvider.HealthCheck(ctx); err != nil {
@@ -0,0 +1,124 @@
+package aggregator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+)
+
+// redirectTransport sends every request to the test server instead of its own host
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newMockModeAggregator serves the bureau and Plaid from one test server that
+// answers with 503 when fail is set
+func newMockModeAggregator(t *testing.T, mode providers.MockMode, fail bool, calls *int32) *EnhancedOffChainAggregator {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		if fail {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/credit-reports/user-1":
+			w.Write([]byte(`{"user_id":"user-1","credit_score":640,"score_range":"300-850"}`))
+		case "/accounts/balance/get":
+			w.Write([]byte(`{"accounts":[{"account_id":"acc_1","type":"depository","balances":{"current":"120.00","available":"120.00","iso_currency_code":"USD"}}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	target, _ := url.Parse(server.URL)
+	plaid := providers.NewPlaidProvider("client", "secret", "sandbox")
+	plaid.SetTransport(redirectTransport{target: target})
+	return NewEnhancedOffChainAggregator(
+		providers.NewCreditBureauProvider("experian", "US", server.URL, "key"),
+		plaid,
+		nil,
+		providers.MockSettings{Bureau: mode, Plaid: mode, Employment: providers.MockNever},
+	)
+}
+
+func TestMockModes(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       providers.MockMode
+		fail       bool
+		wantCalls  bool
+		wantData   bool
+		wantMocked bool
+		wantErr    bool
+	}{
+		{"Always", providers.MockAlways, false, false, true, true, false},
+		{"Fallback serves the provider", providers.MockFallback, false, true, true, false, false},
+		{"Fallback serves mock data on failure", providers.MockFallback, true, true, true, true, true},
+		{"Never serves the provider", providers.MockNever, false, true, true, false, false},
+		{"Never serves nothing on failure", providers.MockNever, true, true, false, false, true},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := func(provider string, calls int32, found, mocked bool, err error) {
+				t.Helper()
+				if (calls > 0) != tt.wantCalls {
+					t.Errorf("%s: expected called=%v, got %d calls", provider, tt.wantCalls, calls)
+				}
+				if found != tt.wantData || mocked != tt.wantMocked || (err != nil) != tt.wantErr {
+					t.Errorf("%s: got data=%v mocked=%v err=%v", provider, found, mocked, err)
+				}
+			}
+
+			var calls int32
+			agg := newMockModeAggregator(t, tt.mode, tt.fail, &calls)
+			report, mocked, err := agg.CreditReport(ctx, "user-1")
+			check("bureau", atomic.SwapInt32(&calls, 0), report != nil, mocked, err)
+			if report != nil && !mocked && report.CreditScore != 640 {
+				t.Errorf("bureau: expected the provider's report, got score %d", report.CreditScore)
+			}
+
+			summary, mocked, err := agg.BankData(ctx, "user-1", "access-token")
+			check("plaid", atomic.SwapInt32(&calls, 0), summary != nil, mocked, err)
+			if summary != nil && !mocked && len(summary.Accounts) != 1 {
+				t.Errorf("plaid: expected the provider's accounts, got %d", len(summary.Accounts))
+			}
+
+			// Without an access token Plaid can't be called, so only always serves data
+			summary, mocked, err = agg.BankData(ctx, "user-1", "")
+			if atomic.LoadInt32(&calls) != 0 || err != nil || (summary != nil) != (tt.mode == providers.MockAlways) || mocked != (tt.mode == providers.MockAlways) {
+				t.Errorf("plaid without token: got data=%v mocked=%v err=%v after %d calls", summary != nil, mocked, err, calls)
+			}
+		})
+	}
+}
+
+func TestFetchMetricsMockFallback(t *testing.T) {
+	var calls int32
+	agg := newMockModeAggregator(t, providers.MockFallback, true, &calls)
+
+	metrics, err := agg.FetchMetrics(context.Background(), "user-1", "0xabc")
+	if err != nil {
+		t.Fatalf("FetchMetrics failed: %v", err)
+	}
+	if calls == 0 {
+		t.Error("Expected the bureau to be called before falling back")
+	}
+	if metrics.TraditionalCreditScore == 0 {
+		t.Error("Expected mock bureau data in place of the failed call")
+	}
+}
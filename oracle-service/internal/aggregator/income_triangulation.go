@@ -0,0 +1,196 @@
+package aggregator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// DefaultIncomePrecedence is the order income sources are trusted in when their
+// figures conflict: payroll records first, self-reported and inferred income last
+var DefaultIncomePrecedence = []string{
+	models.IncomeSourceEmployment,
+	models.IncomeSourcePlaid,
+	models.IncomeSourceBureau,
+	models.IncomeSourceOnChainPayroll,
+}
+
+// Confidence adjustments for corroboration between income sources
+const (
+	incomeAgreementBonus      = 10 // Per other source reporting a similar figure
+	incomeDisagreementPenalty = 15 // Per other source reporting a different figure
+)
+
+// Pay cycles per year by employment provider pay frequency
+var payCyclesPerYear = map[string]int64{
+	"weekly":       52,
+	"bi-weekly":    26,
+	"semi-monthly": 24,
+	"monthly":      12,
+}
+
+// IncomeSourceEstimate is one source's annual income figure and how far it is trusted
+type IncomeSourceEstimate struct {
+	Source       string           `json:"source"`
+	AnnualIncome units.Decimal    `json:"annual_income"`
+	Verified     bool             `json:"verified"`   // Backed by a bank or payroll record
+	Confidence   units.Confidence `json:"confidence"` // After corroboration
+	Agreeing     []string         `json:"agreeing,omitempty"`
+	Conflicting  []string         `json:"conflicting,omitempty"`
+}
+
+// IncomeTriangulation is the reconciled income estimate and the estimates it was
+// reconciled from, in precedence order
+type IncomeTriangulation struct {
+	AnnualIncome units.Decimal          `json:"annual_income"`
+	Source       string                 `json:"source"` // Source whose figure was used
+	Verified     bool                   `json:"verified"`
+	Confidence   units.Confidence       `json:"confidence"`
+	Sources      []IncomeSourceEstimate `json:"sources"`
+}
+
+// IncomeTriangulator reconciles the income reported by Plaid, the credit bureau, an
+// employment provider and on-chain payroll into one estimate
+type IncomeTriangulator struct {
+	precedence []string
+}
+
+// NewIncomeTriangulator creates a triangulator that uses the figure of the first
+// source in precedence that reported one. Sources left out of precedence are still
+// used to corroborate, but their figures are never used.
+func NewIncomeTriangulator(precedence []string) *IncomeTriangulator {
+	if len(precedence) == 0 {
+		precedence = DefaultIncomePrecedence
+	}
+	return &IncomeTriangulator{precedence: precedence}
+}
+
+// ParseIncomePrecedence validates an ordered list of income sources. An empty list
+// is the default precedence.
+func ParseIncomePrecedence(sources []string) ([]string, error) {
+	if len(sources) == 0 {
+		return DefaultIncomePrecedence, nil
+	}
+
+	known := make(map[string]bool, len(DefaultIncomePrecedence))
+	for _, source := range DefaultIncomePrecedence {
+		known[source] = true
+	}
+	seen := make(map[string]bool, len(sources))
+	precedence := make([]string, 0, len(sources))
+	for _, source := range sources {
+		source = strings.ToLower(strings.TrimSpace(source))
+		if !known[source] {
+			return nil, fmt.Errorf("unknown income source %q, expected one of %s", source, strings.Join(DefaultIncomePrecedence, ", "))
+		}
+		if seen[source] {
+			return nil, fmt.Errorf("income source %q listed twice", source)
+		}
+		seen[source] = true
+		precedence = append(precedence, source)
+	}
+	return precedence, nil
+}
+
+// Triangulate reconciles the sources' annual incomes. Any input may be nil; it
+// returns nil when no source reported an income.
+func (t *IncomeTriangulator) Triangulate(
+	bureau *providers.CreditBureauResponse,
+	plaid *providers.PlaidAccountSummary,
+	employment *providers.EmploymentVerification,
+	payroll *PayrollDetection,
+) *IncomeTriangulation {
+	estimates := make(map[string]*IncomeSourceEstimate)
+	add := func(source string, income units.Decimal, verified bool) {
+		if income.Sign() > 0 {
+			estimates[source] = &IncomeSourceEstimate{Source: source, AnnualIncome: income, Verified: verified}
+		}
+	}
+
+	if employment != nil && isEmployed(employment.EmploymentStatus) {
+		if cycles, ok := payCyclesPerYear[strings.ToLower(employment.PayFrequency)]; ok {
+			add(models.IncomeSourceEmployment, employment.GrossPayPerCycle.Mul(units.DecimalFromInt(cycles)), employment.Verified)
+		}
+	}
+	if plaid != nil && plaid.IncomeData != nil {
+		add(models.IncomeSourcePlaid, plaid.IncomeData.AnnualIncome, plaid.IncomeData.IncomeVerified)
+	}
+	if bureau != nil {
+		add(models.IncomeSourceBureau, bureau.TotalIncome, false) // Stated income, not verified
+	}
+	if payroll != nil {
+		add(models.IncomeSourceOnChainPayroll, payroll.AnnualizedIncome, false)
+	}
+	if len(estimates) == 0 {
+		return nil
+	}
+
+	// Every estimate is checked against every other, whatever the precedence
+	ordered := orderIncomeSources(estimates, t.precedence)
+	for _, estimate := range ordered {
+		confidence := baseIncomeConfidence(estimate.Source, estimate.Verified)
+		for _, other := range ordered {
+			if other == estimate {
+				continue
+			}
+			if incomesAgree(estimate.AnnualIncome, other.AnnualIncome) {
+				estimate.Agreeing = append(estimate.Agreeing, other.Source)
+				confidence += incomeAgreementBonus
+			} else {
+				estimate.Conflicting = append(estimate.Conflicting, other.Source)
+				confidence -= incomeDisagreementPenalty
+			}
+		}
+		estimate.Confidence = units.ClampConfidence(float64(confidence))
+	}
+
+	result := &IncomeTriangulation{Sources: make([]IncomeSourceEstimate, 0, len(ordered))}
+	for _, estimate := range ordered {
+		result.Sources = append(result.Sources, *estimate)
+	}
+	for _, source := range t.precedence {
+		if chosen, ok := estimates[source]; ok {
+			result.AnnualIncome = chosen.AnnualIncome
+			result.Source = chosen.Source
+			result.Verified = chosen.Verified
+			result.Confidence = chosen.Confidence
+			return result
+		}
+	}
+	return result // Only sources outside the precedence reported an income
+}
+
+// orderIncomeSources lists the estimates in precedence order, followed by sources
+// left out of the precedence in default order
+func orderIncomeSources(estimates map[string]*IncomeSourceEstimate, precedence []string) []*IncomeSourceEstimate {
+	ordered := make([]*IncomeSourceEstimate, 0, len(estimates))
+	listed := make(map[string]bool, len(estimates))
+	for _, sources := range [][]string{precedence, DefaultIncomePrecedence} {
+		for _, source := range sources {
+			if estimate, ok := estimates[source]; ok && !listed[source] {
+				listed[source] = true
+				ordered = append(ordered, estimate)
+			}
+		}
+	}
+	return ordered
+}
+
+// baseIncomeConfidence is how far a source's income figure is trusted on its own
+func baseIncomeConfidence(source string, verified bool) int {
+	switch {
+	case source == models.IncomeSourceEmployment && verified:
+		return 90
+	case source == models.IncomeSourcePlaid && verified:
+		return 85
+	case source == models.IncomeSourceEmployment, source == models.IncomeSourcePlaid:
+		return 60
+	case source == models.IncomeSourceBureau:
+		return 55
+	default:
+		return 45 // Inferred from on-chain transfers
+	}
+}
@@ -0,0 +1,104 @@
+package aggregator
+
+import (
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+func TestTriangulateIncomeUsesPrecedence(t *testing.T) {
+	employment := &providers.EmploymentVerification{
+		EmploymentStatus: "full-time",
+		PayFrequency:     "bi-weekly",
+		GrossPayPerCycle: units.DecimalFromInt(3000),
+		Verified:         true,
+	}
+	plaid := &providers.PlaidAccountSummary{IncomeData: &providers.PlaidIncomeData{
+		AnnualIncome:   units.DecimalFromInt(80000),
+		IncomeVerified: true,
+	}}
+	bureau := &providers.CreditBureauResponse{TotalIncome: units.DecimalFromInt(120000)}
+
+	income := NewIncomeTriangulator(nil).Triangulate(bureau, plaid, employment, nil)
+	if income == nil {
+		t.Fatal("Expected a triangulated income")
+	}
+	if income.Source != models.IncomeSourceEmployment {
+		t.Errorf("Expected the employment figure, got %s", income.Source)
+	}
+	if income.AnnualIncome.Cmp(units.DecimalFromInt(3000*26)) != 0 {
+		t.Errorf("Expected annualized pay %d, got %s", 3000*26, income.AnnualIncome)
+	}
+	if len(income.Sources) != 3 {
+		t.Fatalf("Expected 3 source estimates, got %d", len(income.Sources))
+	}
+
+	// Employment and Plaid corroborate each other, the stated bureau income conflicts
+	if income.Confidence != 90+incomeAgreementBonus-incomeDisagreementPenalty {
+		t.Errorf("Expected employment confidence %d, got %d", 90+incomeAgreementBonus-incomeDisagreementPenalty, income.Confidence)
+	}
+	bureauEstimate := income.Sources[2]
+	if bureauEstimate.Source != models.IncomeSourceBureau || len(bureauEstimate.Conflicting) != 2 {
+		t.Errorf("Expected the bureau to conflict with both sources, got %+v", bureauEstimate)
+	}
+	if bureauEstimate.Confidence != 55-2*incomeDisagreementPenalty {
+		t.Errorf("Expected bureau confidence %d, got %d", 55-2*incomeDisagreementPenalty, bureauEstimate.Confidence)
+	}
+
+	// A custom precedence picks the bureau figure, whatever its corroboration
+	income = NewIncomeTriangulator([]string{models.IncomeSourceBureau}).Triangulate(bureau, plaid, employment, nil)
+	if income.Source != models.IncomeSourceBureau || income.Verified {
+		t.Errorf("Expected the unverified bureau figure, got %s (verified %v)", income.Source, income.Verified)
+	}
+}
+
+func TestTriangulateIncomeSkipsUnusableSources(t *testing.T) {
+	triangulator := NewIncomeTriangulator(nil)
+
+	if income := triangulator.Triangulate(nil, nil, nil, nil); income != nil {
+		t.Errorf("Expected no income without sources, got %+v", income)
+	}
+
+	terminated := &providers.EmploymentVerification{
+		EmploymentStatus: "terminated",
+		PayFrequency:     "monthly",
+		GrossPayPerCycle: units.DecimalFromInt(5000),
+		Verified:         true,
+	}
+	payroll := &PayrollDetection{AnnualizedIncome: units.DecimalFromInt(52000)}
+	income := triangulator.Triangulate(nil, nil, terminated, payroll)
+	if income == nil || income.Source != models.IncomeSourceOnChainPayroll {
+		t.Fatalf("Expected payroll income for a terminated employee, got %+v", income)
+	}
+	if income.Confidence != 45 || len(income.Sources) != 1 {
+		t.Errorf("Expected an uncorroborated payroll estimate, got %+v", income)
+	}
+
+	// Sources left out of the precedence corroborate but are never used
+	income = NewIncomeTriangulator([]string{models.IncomeSourcePlaid}).Triangulate(nil, nil, nil, payroll)
+	if income == nil || income.Source != "" || len(income.Sources) != 1 {
+		t.Errorf("Expected no chosen figure, got %+v", income)
+	}
+}
+
+func TestParseIncomePrecedence(t *testing.T) {
+	precedence, err := ParseIncomePrecedence([]string{" Plaid", "employment"})
+	if err != nil {
+		t.Fatalf("Failed to parse precedence: %v", err)
+	}
+	if len(precedence) != 2 || precedence[0] != models.IncomeSourcePlaid {
+		t.Errorf("Expected plaid first, got %v", precedence)
+	}
+
+	if precedence, _ := ParseIncomePrecedence(nil); len(precedence) != len(DefaultIncomePrecedence) {
+		t.Errorf("Expected the default precedence, got %v", precedence)
+	}
+	if _, err := ParseIncomePrecedence([]string{"payslip"}); err == nil {
+		t.Error("Expected an unknown source to be rejected")
+	}
+	if _, err := ParseIncomePrecedence([]string{"plaid", "plaid"}); err == nil {
+		t.Error("Expected a duplicate source to be rejected")
+	}
+}
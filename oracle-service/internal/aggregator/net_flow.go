@@ -0,0 +1,141 @@
+package aggregator
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/chains"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// Net-flow thresholds
+const (
+	temporaryDepositWindow = 7 * 24 * time.Hour // Deposits that leave within a week are temporary
+	largeDepositShare      = 0.25               // Inflows under 25% of the balance are ignored
+	roundTripOutflowShare  = 0.80               // Share of a deposit that must leave to count as a round trip
+	maxTemporaryDiscount   = 0.90
+)
+
+// Flow is a signed balance movement: positive for inflows, negative for outflows
+type Flow struct {
+	Amount    units.Decimal
+	Timestamp time.Time
+}
+
+// NetFlowAnalysis describes how much of a balance looks like a temporary deposit
+type NetFlowAnalysis struct {
+	RecentInflowShare     float64 `json:"recent_inflow_share"`     // Share of the current balance that arrived within the window
+	TemporaryDepositCount uint32  `json:"temporary_deposit_count"` // Past large deposits that left again within the window
+	Discount              float64 `json:"discount"`                // Share of the balance to disregard (0-0.9)
+}
+
+// AnalyzeNetFlows finds large deposits that arrived shortly before now and past deposits
+// that left again shortly after arriving, and derives a discount for the current balance
+func AnalyzeNetFlows(flows []Flow, currentBalance units.Decimal, now time.Time) NetFlowAnalysis {
+	var analysis NetFlowAnalysis
+	if currentBalance.Sign() <= 0 || len(flows) == 0 {
+		return analysis
+	}
+
+	sorted := append([]Flow(nil), flows...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	// Net inflow that arrived inside the window before the score request
+	var recentNet units.Decimal
+	for _, flow := range sorted {
+		if now.Sub(flow.Timestamp) <= temporaryDepositWindow {
+			recentNet = recentNet.Add(flow.Amount)
+		}
+	}
+	if recentNet.Sign() > 0 {
+		analysis.RecentInflowShare = math.Min(recentNet.Div(currentBalance).Float64(), 1)
+	}
+	largeDeposit := currentBalance.Mul(units.DecimalFromFloat(largeDepositShare))
+
+	// Past round trips: a large deposit mostly withdrawn within the window
+	for i, flow := range sorted {
+		if flow.Amount.Sign() <= 0 || flow.Amount.Cmp(largeDeposit) < 0 {
+			continue
+		}
+		if now.Sub(flow.Timestamp) <= temporaryDepositWindow {
+			continue // Still inside the window; counted as recent instead
+		}
+
+		var withdrawn units.Decimal
+		for _, later := range sorted[i+1:] {
+			if later.Timestamp.Sub(flow.Timestamp) > temporaryDepositWindow {
+				break
+			}
+			if later.Amount.Sign() < 0 {
+				withdrawn = withdrawn.Sub(later.Amount)
+			}
+		}
+		if withdrawn.Cmp(flow.Amount.Mul(units.DecimalFromFloat(roundTripOutflowShare))) >= 0 {
+			analysis.TemporaryDepositCount++
+		}
+	}
+
+	analysis.Discount = temporaryBalanceDiscount(analysis.RecentInflowShare, analysis.TemporaryDepositCount)
+	return analysis
+}
+
+// temporaryBalanceDiscount discounts a large recent inflow, more so for wallets with a
+// history of parking funds briefly
+func temporaryBalanceDiscount(recentShare float64, roundTrips uint32) float64 {
+	discount := 0.0
+	if recentShare >= largeDepositShare {
+		discount = recentShare
+		if roundTrips > 0 {
+			discount = math.Min(recentShare*1.5, 1)
+		}
+	}
+	if roundTrips >= 2 {
+		discount += 0.25
+	}
+	return math.Min(discount, maxTemporaryDiscount)
+}
+
+// nativeFlows converts native-token transactions into signed flows in whole coins
+func nativeFlows(address string, txs []providers.BlockscoutTransaction, native chains.NativeAsset) []Flow {
+	flows := make([]Flow, 0, len(txs))
+	for _, tx := range txs {
+		value, err := tx.ValueWei()
+		if err != nil || value.Sign() == 0 {
+			continue
+		}
+		timestamp, err := tx.Time()
+		if err != nil {
+			continue
+		}
+
+		amount := native.FromBaseUnits(value)
+		if strings.EqualFold(tx.From, address) {
+			amount = amount.Neg()
+		} else if !strings.EqualFold(tx.To, address) {
+			continue
+		}
+		flows = append(flows, Flow{Amount: amount, Timestamp: timestamp})
+	}
+	return flows
+}
+
+// plaidFlows converts Plaid transactions (positive amounts are debits) into signed flows
+func plaidFlows(transactions []providers.PlaidTransaction) []Flow {
+	flows := make([]Flow, 0, len(transactions))
+	for _, tx := range transactions {
+		if tx.Pending {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", tx.Date)
+		if err != nil {
+			continue
+		}
+		flows = append(flows, Flow{Amount: tx.Amount.Neg(), Timestamp: date})
+	}
+	return flows
+}
@@ -0,0 +1,62 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+func TestAnalyzeNetFlows(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	// Long-held balance with small regular activity
+	steady := []Flow{
+		{Amount: units.DecimalFromInt(10), Timestamp: now.Add(-300 * day)},
+		{Amount: units.MustDecimal("-0.5"), Timestamp: now.Add(-100 * day)},
+		{Amount: units.MustDecimal("0.2"), Timestamp: now.Add(-2 * day)},
+	}
+	if got := AnalyzeNetFlows(steady, units.MustDecimal("9.7"), now); got.Discount != 0 {
+		t.Errorf("Expected no discount for a long-held balance, got %+v", got)
+	}
+
+	// Most of the balance arrived yesterday
+	recent := []Flow{
+		{Amount: units.DecimalFromInt(1), Timestamp: now.Add(-200 * day)},
+		{Amount: units.DecimalFromInt(9), Timestamp: now.Add(-1 * day)},
+	}
+	got := AnalyzeNetFlows(recent, units.DecimalFromInt(10), now)
+	if got.RecentInflowShare != 0.9 || got.Discount < 0.9*0.99 {
+		t.Errorf("Expected ~0.9 recent share and discount, got %+v", got)
+	}
+
+	// Habitual round trips: deposits withdrawn within days
+	roundTrips := []Flow{
+		{Amount: units.DecimalFromInt(1), Timestamp: now.Add(-200 * day)},
+		{Amount: units.DecimalFromInt(5), Timestamp: now.Add(-90 * day)},
+		{Amount: units.DecimalFromInt(-5), Timestamp: now.Add(-87 * day)},
+		{Amount: units.DecimalFromInt(5), Timestamp: now.Add(-60 * day)},
+		{Amount: units.MustDecimal("-4.5"), Timestamp: now.Add(-58 * day)},
+	}
+	got = AnalyzeNetFlows(roundTrips, units.MustDecimal("1.5"), now)
+	if got.TemporaryDepositCount != 2 {
+		t.Errorf("Expected 2 past temporary deposits, got %d", got.TemporaryDepositCount)
+	}
+	if got.Discount <= 0 {
+		t.Errorf("Expected a discount for habitual round trips, got %f", got.Discount)
+	}
+}
+
+func TestPlaidFlows(t *testing.T) {
+	flows := plaidFlows([]providers.PlaidTransaction{
+		{Amount: units.DecimalFromInt(-2500), Date: "2024-06-28"}, // Credit
+		{Amount: units.DecimalFromInt(120), Date: "2024-06-29"},   // Debit
+		{Amount: units.DecimalFromInt(50), Date: "2024-06-29", Pending: true},
+	})
+
+	if len(flows) != 2 || flows[0].Amount.Cmp(units.DecimalFromInt(2500)) != 0 || flows[1].Amount.Cmp(units.DecimalFromInt(-120)) != 0 {
+		t.Errorf("Expected signed flows [2500 -120], got %+v", flows)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
 	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -18,7 +20,7 @@ type OffChainAggregator struct {
 	httpClient    *http.Client
 	creditBureauURL string
 	bankAPIURL      string
-	apiKey          string
+	apiKey          *providers.Credential
 }
 
 // NewOffChainAggregator creates a new off-chain data aggregator
@@ -29,23 +31,35 @@ func NewOffChainAggregator(creditBureauURL, bankAPIURL, apiKey string) *OffChain
 		},
 		creditBureauURL: creditBureauURL,
 		bankAPIURL:      bankAPIURL,
-		apiKey:          apiKey,
+		apiKey:          providers.NewCredential(apiKey),
 	}
 }
 
+// SetTransport sends the aggregator's HTTP calls through transport, e.g. a proxy the
+// bureau allow-lists
+func (a *OffChainAggregator) SetTransport(transport http.RoundTripper) {
+	a.httpClient.Transport = transport
+}
+
+// SetAPIKey shares a credential with another client of the credit bureau API, so
+// rotating it there applies here too
+func (a *OffChainAggregator) SetAPIKey(apiKey *providers.Credential) {
+	a.apiKey = apiKey
+}
+
 // CreditBureauResponse represents credit bureau API response
 type CreditBureauResponse struct {
-	CreditScore      uint16  `json:"credit_score"`
-	DebtToIncome     float64 `json:"debt_to_income_ratio"`
-	EmploymentStatus string  `json:"employment_status"`
-	IncomeLevel      string  `json:"income_level"`
+	CreditScore      uint16        `json:"credit_score"`
+	DebtToIncome     units.Decimal `json:"debt_to_income_ratio"`
+	EmploymentStatus string        `json:"employment_status"`
+	IncomeLevel      string        `json:"income_level"`
 }
 
 // BankDataResponse represents bank API response
 type BankDataResponse struct {
 	AccountHistory   uint8  `json:"account_history_score"`
 	IncomeVerified   bool   `json:"income_verified"`
-	AverageBalance   float64 `json:"average_balance"`
+	AverageBalance   units.Decimal `json:"average_balance"`
 }
 
 // FetchMetrics gathers off-chain metrics for a user
@@ -60,7 +74,9 @@ func (a *OffChainAggregator) FetchMetrics(ctx context.Context, userID, address s
 		logger.Error("Failed to fetch credit bureau data", zap.Error(err))
 		// Don't fail completely, just log and continue with partial data
 	} else {
-		metrics.TraditionalCreditScore = creditData.CreditScore
+		if creditData.CreditScore > 0 {
+			metrics.TraditionalCreditScore = units.ClampScore(float64(creditData.CreditScore))
+		}
 		metrics.DebtToIncomeRatio = creditData.DebtToIncome
 		metrics.EmploymentStatus = creditData.EmploymentStatus
 		metrics.IncomeLevel = creditData.IncomeLevel
@@ -94,7 +110,7 @@ func (a *OffChainAggregator) fetchCreditBureauData(ctx context.Context, userID s
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+a.apiKey)
+	req.Header.Set("Authorization", "Bearer "+a.apiKey.Get())
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := a.httpClient.Do(req)
@@ -128,7 +144,7 @@ func (a *OffChainAggregator) fetchBankData(ctx context.Context, userID string) (
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+a.apiKey)
+	req.Header.Set("Authorization", "Bearer "+a.apiKey.Get())
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := a.httpClient.Do(req)
@@ -159,7 +175,7 @@ func (a *OffChainAggregator) FetchMockMetrics(address string) *models.OffChainMe
 		IncomeVerified:         true,
 		IncomeLevel:            "medium",
 		EmploymentStatus:       "full-time",
-		DebtToIncomeRatio:      0.28,
+		DebtToIncomeRatio:      units.MustDecimal("0.28"),
 		DataSource:             "mock",
 		LastVerified:           time.Now(),
 		CreatedAt:              time.Now(),
@@ -4,19 +4,30 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/chains"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
 	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
 	"go.uber.org/zap"
 )
 
+// averageBlockSeconds approximates post-merge Ethereum block time for block estimation
+const averageBlockSeconds = 12
+
 // OnChainAggregator fetches and aggregates on-chain data
 type OnChainAggregator struct {
-	client *ethclient.Client
-	rpcURL string
+	client     *ethclient.Client
+	rpcURL     string
+	chainIndex ChainIndex // Indexed history of the chain, used instead of estimates (optional)
+
+	nativeMu sync.Mutex
+	native   *chains.NativeAsset // Coin of the node's chain; nil until the node is asked
 }
 
 // NewOnChainAggregator creates a new on-chain data aggregator
@@ -32,8 +43,41 @@ func NewOnChainAggregator(rpcURL string) (*OnChainAggregator, error) {
 	}, nil
 }
 
+// SetChainIndex takes the metrics of indexed addresses from a self-hosted chain index
+// instead of estimating them from the account nonce
+func (a *OnChainAggregator) SetChainIndex(index ChainIndex) {
+	a.chainIndex = index
+}
+
+// nativeAsset returns the coin of the node's chain, asking the node for its chain ID
+// once. Until the node answers, a coin with 18 decimals is assumed.
+func (a *OnChainAggregator) nativeAsset(ctx context.Context) chains.NativeAsset {
+	a.nativeMu.Lock()
+	defer a.nativeMu.Unlock()
+
+	if a.native == nil {
+		chainID, err := a.client.ChainID(ctx)
+		if err != nil {
+			logger.Warn("Failed to get chain ID, assuming 18 decimals", zap.Error(err))
+			return chains.UnknownNative
+		}
+		native := chains.Native(chainID.String())
+		a.native = &native
+	}
+	return *a.native
+}
+
 // FetchMetrics gathers on-chain metrics for a user address
 func (a *OnChainAggregator) FetchMetrics(ctx context.Context, address string) (*models.OnChainMetrics, error) {
+	if a.chainIndex != nil {
+		summary, err := a.chainIndex.Summary(ctx, address)
+		if err != nil {
+			logger.Error("Failed to read chain index, estimating metrics", zap.Error(err))
+		} else if summary != nil {
+			return indexedMetrics(address, summary), nil
+		}
+	}
+
 	addr := common.HexToAddress(address)
 
 	metrics := &models.OnChainMetrics{
@@ -62,13 +106,7 @@ func (a *OnChainAggregator) FetchMetrics(ctx context.Context, address string) (*
 	if err != nil {
 		logger.Error("Failed to get balance", zap.Error(err))
 	} else {
-		// Convert wei to ETH
-		ethBalance := new(big.Float).Quo(
-			new(big.Float).SetInt(balance),
-			big.NewFloat(1e18),
-		)
-		ethValue, _ := ethBalance.Float64()
-		metrics.CollateralValue = ethValue
+		metrics.CollateralValue = a.nativeAsset(ctx).FromBaseUnits(balance)
 	}
 
 	// Fetch DeFi interactions (would need specific contract calls)
@@ -87,6 +125,41 @@ func (a *OnChainAggregator) FetchMetrics(ctx context.Context, address string) (*
 	return metrics, nil
 }
 
+// SampleBalances reads the native balance at roughly monthly intervals over the past
+// number of months, oldest first. Requires an archive node for historical state.
+func (a *OnChainAggregator) SampleBalances(ctx context.Context, address string, months int) ([]providers.BalanceSample, error) {
+	addr := common.HexToAddress(address)
+
+	head, err := a.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	native := a.nativeAsset(ctx)
+	samples := make([]providers.BalanceSample, 0, months+1)
+	for m := months; m >= 0; m-- {
+		// Estimate the block 30*m days ago from the average block time
+		blocksBack := uint64(m*30*24*60*60) / averageBlockSeconds
+		if blocksBack > head.Number.Uint64() {
+			continue
+		}
+		blockNum := new(big.Int).SetUint64(head.Number.Uint64() - blocksBack)
+
+		balance, err := a.client.BalanceAt(ctx, addr, blockNum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get balance at block %s (archive node required): %w", blockNum, err)
+		}
+
+		value := native.FromBaseUnits(balance).Float64()
+		samples = append(samples, providers.BalanceSample{
+			Timestamp: time.Unix(int64(head.Time), 0).AddDate(0, 0, -30*m),
+			Value:     value,
+		})
+	}
+
+	return samples, nil
+}
+
 // getWalletAge calculates wallet age in days
 func (a *OnChainAggregator) getWalletAge(ctx context.Context, address common.Address) (uint32, error) {
 	// In a real implementation, you would:
@@ -122,11 +195,11 @@ func (a *OnChainAggregator) getWalletAge(ctx context.Context, address common.Add
 }
 
 // getTransactionStats calculates transaction statistics
-func (a *OnChainAggregator) getTransactionStats(ctx context.Context, address common.Address) (uint32, float64, error) {
+func (a *OnChainAggregator) getTransactionStats(ctx context.Context, address common.Address) (uint32, units.Decimal, error) {
 	// Get transaction count
 	nonce, err := a.client.NonceAt(ctx, address, nil)
 	if err != nil {
-		return 0, 0, err
+		return 0, units.Decimal{}, err
 	}
 
 	txCount := uint32(nonce)
@@ -138,18 +211,14 @@ func (a *OnChainAggregator) getTransactionStats(ctx context.Context, address com
 
 	balance, err := a.client.BalanceAt(ctx, address, nil)
 	if err != nil {
-		return txCount, 0, err
+		return txCount, units.Decimal{}, err
 	}
 
 	// Simple average estimation
-	avgValue := 0.0
+	var avgValue units.Decimal
 	if txCount > 0 {
-		ethBalance := new(big.Float).Quo(
-			new(big.Float).SetInt(balance),
-			big.NewFloat(1e18),
-		)
-		avgValue, _ = ethBalance.Float64()
-		avgValue = avgValue / float64(txCount) * 2 // Rough estimation
+		nativeBalance := a.nativeAsset(ctx).FromBaseUnits(balance)
+		avgValue = nativeBalance.Div(units.DecimalFromInt(int64(txCount))).Mul(units.DecimalFromInt(2)) // Rough estimation
 	}
 
 	return txCount, avgValue, nil
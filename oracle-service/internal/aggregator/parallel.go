@@ -0,0 +1,39 @@
+package aggregator
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultCallTimeout bounds each provider call made while fetching metrics
+const DefaultCallTimeout = 20 * time.Second
+
+// maxParallelCalls bounds how many provider calls one fetch makes at a time
+const maxParallelCalls = 4
+
+// runParallel runs independent provider calls concurrently and waits for all of them.
+// Each call keeps its own result and error, so a failing provider leaves its part of
+// the metrics empty without cancelling the others.
+func runParallel(calls ...func()) {
+	var group errgroup.Group
+	group.SetLimit(maxParallelCalls)
+	for _, call := range calls {
+		call := call
+		group.Go(func() error {
+			call()
+			return nil
+		})
+	}
+	group.Wait()
+}
+
+// callContext bounds a single provider call by timeout. A timeout of zero leaves
+// the call bounded only by ctx.
+func callContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,107 @@
+package aggregator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+)
+
+// newSlowBlockscout serves every Blockscout account action after delay. The hung
+// action never answers before the request is cancelled.
+func newSlowBlockscout(delay time.Duration, hung string, calls *int32) *httptest.Server {
+	now := time.Now().Unix()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		action := r.URL.Query().Get("action")
+		wait := delay
+		if action == hung {
+			wait = 10 * time.Second
+		}
+		select {
+		case <-time.After(wait):
+		case <-r.Context().Done():
+			return
+		}
+
+		result := "[]"
+		switch action {
+		case "balance":
+			result = `"1000000000000000000"`
+		case "txlist":
+			result = fmt.Sprintf(`[{"hash":"0x1","timestamp":"%s","from":"0xabc","to":"0xdef","value":"500000000000000000"},`+
+				`{"hash":"0x2","timestamp":"%s","from":"0xdef","to":"0xabc","value":"2000000000000000000"}]`,
+				strconv.FormatInt(now-86400, 10), strconv.FormatInt(now-400*86400, 10))
+		}
+		fmt.Fprintf(w, `{"status":"1","message":"OK","result":%s}`, result)
+	}))
+}
+
+func newTestOnChainAggregator(blockscoutURL string) *EnhancedOnChainAggregator {
+	return NewEnhancedOnChainAggregator(
+		providers.NewBlockchainDataProvider("covalent", "http://127.0.0.1:1", ""),
+		providers.NewBlockscoutProvider(blockscoutURL, "ethereum"),
+		nil,
+		true,
+		false,
+		nil,
+		nil,
+		nil,
+		0,
+	)
+}
+
+func TestFetchMetricsCallsProvidersConcurrently(t *testing.T) {
+	const delay = 100 * time.Millisecond
+	var calls int32
+	server := newSlowBlockscout(delay, "", &calls)
+	defer server.Close()
+
+	agg := newTestOnChainAggregator(server.URL)
+	start := time.Now()
+	metrics, err := agg.FetchMetrics(context.Background(), "0xabc")
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("FetchMetrics failed: %v", err)
+	}
+
+	// Eight Blockscout calls one after another would take 800ms
+	if calls != 8 {
+		t.Errorf("Expected 8 Blockscout calls, got %d", calls)
+	}
+	if elapsed > 4*delay {
+		t.Errorf("Expected concurrent provider calls, took %s", elapsed)
+	}
+	if metrics.TotalTransactions != 2 || metrics.WalletAge == 0 {
+		t.Errorf("Expected the summary in the metrics, got %+v", metrics)
+	}
+}
+
+func TestFetchMetricsCallTimeout(t *testing.T) {
+	var calls int32
+	server := newSlowBlockscout(10*time.Millisecond, "tokentx", &calls)
+	defer server.Close()
+
+	agg := newTestOnChainAggregator(server.URL)
+	agg.SetCallTimeout(200 * time.Millisecond)
+
+	start := time.Now()
+	metrics, err := agg.FetchMetrics(context.Background(), "0xabc")
+	if err != nil {
+		t.Fatalf("FetchMetrics failed: %v", err)
+	}
+
+	// The hung token transfer call is cut off without losing the other providers' data
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the hung call to time out, took %s", elapsed)
+	}
+	if metrics.TotalTransactions != 2 || metrics.TotalInflows == 0 {
+		t.Errorf("Expected the summary and funding profile despite the hung call, got %+v", metrics)
+	}
+}
@@ -0,0 +1,200 @@
+package aggregator
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// Stablecoins recognised as payroll currencies
+var payrollStablecoins = map[string]bool{
+	"USDC":   true,
+	"USDT":   true,
+	"DAI":    true,
+	"PYUSD":  true,
+	"USDP":   true,
+	"GUSD":   true,
+	"TUSD":   true,
+	"FRAX":   true,
+	"USDBC":  true,
+	"USDC.E": true,
+}
+
+// Payroll detection thresholds
+const (
+	minPayrollPayments     = 3    // Need at least three payments to establish a cadence
+	payrollAmountTolerance = 0.05 // Payments within 5% of the median count as "same amount"
+	payrollCadenceMatch    = 0.75 // Share of intervals that must fit the cadence
+	minPayrollAmountUSD    = 100  // Ignore dust and small reimbursements
+)
+
+// payrollCadence describes an accepted pay frequency
+type payrollCadence struct {
+	name            string
+	minDays         float64
+	maxDays         float64
+	paymentsPerYear int64
+}
+
+var payrollCadences = []payrollCadence{
+	{name: "weekly", minDays: 6, maxDays: 8, paymentsPerYear: 52},
+	{name: "bi-weekly", minDays: 13, maxDays: 16, paymentsPerYear: 26},
+	{name: "monthly", minDays: 27, maxDays: 32, paymentsPerYear: 12},
+}
+
+// PayrollStream is a recurring same-amount stablecoin inflow from one sender
+type PayrollStream struct {
+	Sender           string        `json:"sender"`
+	SenderLabel      string        `json:"sender_label,omitempty"` // Registry name when the sender is a known payroll provider
+	TokenSymbol      string        `json:"token_symbol"`
+	Cadence          string        `json:"cadence"`
+	PaymentAmount    units.Decimal `json:"payment_amount"`
+	PaymentCount     int           `json:"payment_count"`
+	LastPayment      time.Time     `json:"last_payment"`
+	AnnualizedIncome units.Decimal `json:"annualized_income"`
+}
+
+// PayrollDetection is the result of scanning a wallet for crypto payroll
+type PayrollDetection struct {
+	Address          string          `json:"address"`
+	Streams          []PayrollStream `json:"streams"`
+	AnnualizedIncome units.Decimal   `json:"annualized_income"`
+	DetectedAt       time.Time       `json:"detected_at"`
+}
+
+type stablecoinInflow struct {
+	amount    units.Decimal
+	timestamp time.Time
+}
+
+// DetectStablecoinPayroll finds recurring same-amount stablecoin inflows from a consistent
+// sender at a weekly, bi-weekly, or monthly cadence and annualizes them as income
+func DetectStablecoinPayroll(address string, transfers []providers.BlockscoutTokenTransfer, now time.Time) *PayrollDetection {
+	detection := &PayrollDetection{
+		Address:    address,
+		Streams:    []PayrollStream{},
+		DetectedAt: now,
+	}
+
+	// Group inbound stablecoin transfers by sender and token
+	groups := make(map[string][]stablecoinInflow)
+	for _, transfer := range transfers {
+		if !strings.EqualFold(transfer.To, address) {
+			continue
+		}
+		symbol := strings.ToUpper(transfer.TokenSymbol)
+		if !payrollStablecoins[symbol] {
+			continue
+		}
+
+		amount, err := transfer.Amount()
+		if err != nil || amount.Cmp(units.DecimalFromInt(minPayrollAmountUSD)) < 0 {
+			continue
+		}
+		timestamp, err := transfer.Time()
+		if err != nil {
+			continue
+		}
+
+		key := strings.ToLower(transfer.From) + "|" + symbol
+		groups[key] = append(groups[key], stablecoinInflow{amount: amount, timestamp: timestamp})
+	}
+
+	for key, inflows := range groups {
+		stream, ok := detectPayrollStream(inflows, now)
+		if !ok {
+			continue
+		}
+		parts := strings.SplitN(key, "|", 2)
+		stream.Sender = parts[0]
+		stream.TokenSymbol = parts[1]
+
+		detection.Streams = append(detection.Streams, stream)
+		detection.AnnualizedIncome = detection.AnnualizedIncome.Add(stream.AnnualizedIncome)
+	}
+
+	// Largest income stream first for stable output
+	sort.Slice(detection.Streams, func(i, j int) bool {
+		return detection.Streams[i].AnnualizedIncome.Cmp(detection.Streams[j].AnnualizedIncome) > 0
+	})
+
+	return detection
+}
+
+// detectPayrollStream checks whether one sender's inflows form a regular payroll stream
+func detectPayrollStream(inflows []stablecoinInflow, now time.Time) (PayrollStream, bool) {
+	if len(inflows) < minPayrollPayments {
+		return PayrollStream{}, false
+	}
+
+	sort.Slice(inflows, func(i, j int) bool {
+		return inflows[i].timestamp.Before(inflows[j].timestamp)
+	})
+
+	// Keep only payments close to the median amount
+	amounts := make([]units.Decimal, len(inflows))
+	for i, inflow := range inflows {
+		amounts[i] = inflow.amount
+	}
+	median := medianAmount(amounts)
+	tolerance := median.Mul(units.DecimalFromFloat(payrollAmountTolerance))
+
+	var regular []stablecoinInflow
+	for _, inflow := range inflows {
+		if inflow.amount.Sub(median).Abs().Cmp(tolerance) <= 0 {
+			regular = append(regular, inflow)
+		}
+	}
+	if len(regular) < minPayrollPayments {
+		return PayrollStream{}, false
+	}
+
+	// Classify the interval between payments
+	intervals := make([]float64, 0, len(regular)-1)
+	for i := 1; i < len(regular); i++ {
+		intervals = append(intervals, regular[i].timestamp.Sub(regular[i-1].timestamp).Hours()/24)
+	}
+
+	for _, cadence := range payrollCadences {
+		matches := 0
+		for _, days := range intervals {
+			if days >= cadence.minDays && days <= cadence.maxDays {
+				matches++
+			}
+		}
+		if float64(matches)/float64(len(intervals)) < payrollCadenceMatch {
+			continue
+		}
+
+		// A stream that stopped paying more than two cycles ago is no longer income
+		last := regular[len(regular)-1].timestamp
+		if now.Sub(last).Hours()/24 > 2*cadence.maxDays {
+			return PayrollStream{}, false
+		}
+
+		return PayrollStream{
+			Cadence:          cadence.name,
+			PaymentAmount:    median,
+			PaymentCount:     len(regular),
+			LastPayment:      last,
+			AnnualizedIncome: median.Mul(units.DecimalFromInt(cadence.paymentsPerYear)),
+		}, true
+	}
+
+	return PayrollStream{}, false
+}
+
+func medianAmount(values []units.Decimal) units.Decimal {
+	sorted := append([]units.Decimal(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return sorted[mid-1].Add(sorted[mid]).Div(units.DecimalFromInt(2))
+	}
+	return sorted[mid]
+}
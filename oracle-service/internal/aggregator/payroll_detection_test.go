@@ -0,0 +1,77 @@
+package aggregator
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+const testWallet = "0x1234567890123456789012345678901234567890"
+
+func payrollTransfer(from, symbol, value string, at time.Time) providers.BlockscoutTokenTransfer {
+	return providers.BlockscoutTokenTransfer{
+		From:         from,
+		To:           testWallet,
+		Value:        value,
+		TokenSymbol:  symbol,
+		TokenDecimal: "6",
+		TimeStamp:    strconv.FormatInt(at.Unix(), 10),
+	}
+}
+
+func TestDetectStablecoinPayroll(t *testing.T) {
+	now := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	employer := "0xEmployer"
+
+	var transfers []providers.BlockscoutTokenTransfer
+	for i := 0; i < 6; i++ {
+		// 2,500 USDC every 14 days
+		transfers = append(transfers, payrollTransfer(employer, "USDC", "2500000000", now.AddDate(0, 0, -14*i-2)))
+	}
+	// Irregular transfers from another sender must not form a stream
+	transfers = append(transfers,
+		payrollTransfer("0xFriend", "USDC", "300000000", now.AddDate(0, 0, -3)),
+		payrollTransfer("0xFriend", "USDC", "1200000000", now.AddDate(0, 0, -40)),
+	)
+
+	detection := DetectStablecoinPayroll(testWallet, transfers, now)
+
+	if len(detection.Streams) != 1 {
+		t.Fatalf("Expected 1 payroll stream, got %d", len(detection.Streams))
+	}
+
+	stream := detection.Streams[0]
+	if stream.Cadence != "bi-weekly" {
+		t.Errorf("Expected bi-weekly cadence, got %s", stream.Cadence)
+	}
+	if stream.Sender != "0xemployer" {
+		t.Errorf("Expected sender 0xemployer, got %s", stream.Sender)
+	}
+	if detection.AnnualizedIncome.Cmp(units.DecimalFromInt(2500*26)) != 0 {
+		t.Errorf("Expected annualized income %d, got %s", 2500*26, detection.AnnualizedIncome)
+	}
+}
+
+func TestDetectStablecoinPayrollIgnoresStaleAndNonStable(t *testing.T) {
+	now := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	var transfers []providers.BlockscoutTokenTransfer
+	for i := 0; i < 4; i++ {
+		// Monthly payroll that stopped six months ago
+		transfers = append(transfers, payrollTransfer("0xOldEmployer", "USDC", "4000000000", now.AddDate(0, -6-i, 0)))
+		// Monthly transfers of a volatile token
+		transfers = append(transfers, payrollTransfer("0xExchange", "WETH", "4000000000", now.AddDate(0, -i, 0)))
+	}
+
+	detection := DetectStablecoinPayroll(testWallet, transfers, now)
+
+	if len(detection.Streams) != 0 {
+		t.Errorf("Expected no payroll streams, got %d", len(detection.Streams))
+	}
+	if !detection.AnnualizedIncome.IsZero() {
+		t.Errorf("Expected no annualized income, got %s", detection.AnnualizedIncome)
+	}
+}
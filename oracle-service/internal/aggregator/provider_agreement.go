@@ -0,0 +1,104 @@
+package aggregator
+
+import (
+	"strings"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// Thresholds for two providers to count as agreeing
+const (
+	incomeAgreementTolerance = 0.25 // Annual incomes within 25% of the larger one agree
+	tenureAgreementMonths    = 12   // Tenures within a year agree
+)
+
+// Provider names used in comparisons
+const (
+	ProviderCreditBureau = "credit_bureau"
+	ProviderPlaid        = "plaid"
+	ProviderEmployment   = "employment"
+)
+
+// ProviderComparison is one field reported by two providers
+type ProviderComparison struct {
+	Field     string    `json:"field"` // income, employment_status, employment_tenure
+	Providers [2]string `json:"providers"`
+	Agreed    bool      `json:"agreed"`
+}
+
+// CompareProviders checks the fields that more than one provider reports for the same
+// consumer. Any of the inputs may be nil; fields only one provider reports are skipped.
+func CompareProviders(
+	bureau *providers.CreditBureauResponse,
+	plaid *providers.PlaidAccountSummary,
+	employment *providers.EmploymentVerification,
+) []ProviderComparison {
+	var comparisons []ProviderComparison
+	compare := func(field, a, b string, agreed bool) {
+		comparisons = append(comparisons, ProviderComparison{Field: field, Providers: [2]string{a, b}, Agreed: agreed})
+	}
+
+	var plaidIncome *providers.PlaidIncomeData
+	if plaid != nil {
+		plaidIncome = plaid.IncomeData
+	}
+
+	if bureau != nil && plaidIncome != nil && bureau.TotalIncome.Sign() > 0 && plaidIncome.AnnualIncome.Sign() > 0 {
+		compare("income", ProviderCreditBureau, ProviderPlaid,
+			incomesAgree(bureau.TotalIncome, plaidIncome.AnnualIncome))
+	}
+
+	// Employment status: only employed versus not employed counts, since providers
+	// classify part-time and contract work differently
+	statuses := map[string]string{}
+	if bureau != nil && bureau.EmploymentStatus != "" {
+		statuses[ProviderCreditBureau] = bureau.EmploymentStatus
+	}
+	if plaidIncome != nil && plaidIncome.EmploymentStatus != "" {
+		statuses[ProviderPlaid] = plaidIncome.EmploymentStatus
+	}
+	if employment != nil && employment.EmploymentStatus != "" {
+		statuses[ProviderEmployment] = employment.EmploymentStatus
+	}
+	pairs := [][2]string{
+		{ProviderCreditBureau, ProviderPlaid},
+		{ProviderCreditBureau, ProviderEmployment},
+		{ProviderPlaid, ProviderEmployment},
+	}
+	for _, pair := range pairs {
+		a, okA := statuses[pair[0]]
+		b, okB := statuses[pair[1]]
+		if okA && okB {
+			compare("employment_status", pair[0], pair[1], isEmployed(a) == isEmployed(b))
+		}
+	}
+
+	// Tenure is only trusted from a verified payroll record
+	if bureau != nil && employment != nil && employment.Verified && bureau.EmploymentLength > 0 && employment.TenureMonths > 0 {
+		diff := bureau.EmploymentLength - employment.TenureMonths
+		compare("employment_tenure", ProviderCreditBureau, ProviderEmployment,
+			diff <= tenureAgreementMonths && diff >= -tenureAgreementMonths)
+	}
+
+	return comparisons
+}
+
+// incomesAgree reports whether two annual incomes are within tolerance of each other
+func incomesAgree(a, b units.Decimal) bool {
+	larger := a
+	if b.Cmp(a) > 0 {
+		larger = b
+	}
+	return a.Sub(b).Abs().Cmp(larger.Mul(units.DecimalFromFloat(incomeAgreementTolerance))) <= 0
+}
+
+// isEmployed classifies a provider's employment status
+func isEmployed(status string) bool {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "terminated", "unemployed", "inactive", "none", "retired":
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,65 @@
+package aggregator
+
+import (
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+func TestCompareProviders(t *testing.T) {
+	bureau := &providers.CreditBureauResponse{
+		TotalIncome:      units.DecimalFromInt(85000),
+		EmploymentStatus: "full-time",
+		EmploymentLength: 48,
+	}
+	plaid := &providers.PlaidAccountSummary{
+		IncomeData: &providers.PlaidIncomeData{AnnualIncome: units.DecimalFromInt(75000), EmploymentStatus: "full-time"},
+	}
+	employment := &providers.EmploymentVerification{
+		EmploymentStatus: "part-time",
+		TenureMonths:     40,
+		Verified:         true,
+	}
+
+	comparisons := CompareProviders(bureau, plaid, employment)
+	if len(comparisons) != 5 {
+		t.Fatalf("Expected 5 comparisons, got %d: %+v", len(comparisons), comparisons)
+	}
+	for _, comparison := range comparisons {
+		if !comparison.Agreed {
+			t.Errorf("Expected %s from %v to agree", comparison.Field, comparison.Providers)
+		}
+	}
+
+	// Plaid reports half the bureau's income and the payroll record is terminated
+	plaid.IncomeData.AnnualIncome = units.DecimalFromInt(40000)
+	employment.EmploymentStatus = "terminated"
+	employment.TenureMonths = 6
+
+	conflicts := map[string]int{}
+	for _, comparison := range CompareProviders(bureau, plaid, employment) {
+		if !comparison.Agreed {
+			conflicts[comparison.Field]++
+		}
+	}
+	if conflicts["income"] != 1 || conflicts["employment_status"] != 2 || conflicts["employment_tenure"] != 1 {
+		t.Errorf("Unexpected conflicts: %v", conflicts)
+	}
+}
+
+func TestCompareProvidersSkipsSingleSources(t *testing.T) {
+	if comparisons := CompareProviders(&providers.CreditBureauResponse{TotalIncome: units.DecimalFromInt(85000)}, nil, nil); len(comparisons) != 0 {
+		t.Errorf("Expected no comparisons with one provider, got %+v", comparisons)
+	}
+
+	// Unverified payroll tenure is not compared
+	comparisons := CompareProviders(
+		&providers.CreditBureauResponse{EmploymentLength: 48},
+		nil,
+		&providers.EmploymentVerification{TenureMonths: 2},
+	)
+	if len(comparisons) != 0 {
+		t.Errorf("Expected unverified tenure to be skipped, got %+v", comparisons)
+	}
+}
@@ -0,0 +1,146 @@
+package aggregator
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// RepaymentAnalysis is the result of matching a wallet's repayments to its borrows
+type RepaymentAnalysis struct {
+	Borrows          uint32                   `json:"borrows"`
+	Repaid           uint32                   `json:"repaid"`            // Borrows repaid in full
+	RepaymentRatio   float64                  `json:"repayment_ratio"`   // Average share of each borrow repaid (0-1)
+	AvgDaysToRepay   float64                  `json:"avg_days_to_repay"` // Over borrows repaid in full
+	OutstandingLoans uint32                   `json:"outstanding_loans"`
+	OutstandingDebt  map[string]units.Decimal `json:"outstanding_debt"` // Unrepaid base units per protocol and asset, where amounts are known
+}
+
+// loan is one borrow and what is left of it
+type loan struct {
+	borrowedAt time.Time
+	amount     *big.Int // nil when the amount is unknown
+	remaining  *big.Int
+	repaidAt   time.Time // Zero while outstanding
+}
+
+func (l *loan) repay(at time.Time) {
+	if l.amount != nil {
+		l.remaining.SetInt64(0)
+	}
+	l.repaidAt = at
+}
+
+// MatchRepayments pairs each repayment with the earlier borrows of the same protocol
+// and asset, oldest first. A repayment larger than a borrow, such as one including
+// interest, repays it and carries over to the next; a repayment of unknown amount
+// repays the oldest borrow in full. Repayments of borrows made before the history
+// starts are ignored, and failed transactions are skipped.
+func MatchRepayments(activities []providers.DeFiActivity) RepaymentAnalysis {
+	sorted := make([]providers.DeFiActivity, 0, len(activities))
+	for _, activity := range activities {
+		if activity.Status == providers.ActivityFailed {
+			continue
+		}
+		if activity.ActivityType == providers.ActivityBorrow || activity.ActivityType == providers.ActivityRepay {
+			sorted = append(sorted, activity)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	var loans []*loan
+	open := make(map[string][]*loan) // Outstanding loans per protocol and asset, oldest first
+	for _, activity := range sorted {
+		key := activity.Protocol + ":" + activity.Asset
+		amount, err := providers.ParseQuantity(activity.RawAmount)
+		if err != nil {
+			amount = nil
+		}
+
+		if activity.ActivityType == providers.ActivityBorrow {
+			l := &loan{borrowedAt: activity.Timestamp, amount: amount}
+			if amount != nil {
+				l.remaining = new(big.Int).Set(amount)
+			}
+			loans = append(loans, l)
+			open[key] = append(open[key], l)
+			continue
+		}
+
+		queue := open[key]
+		switch {
+		case activity.ClosesPosition:
+			for _, l := range queue {
+				l.repay(activity.Timestamp)
+			}
+			queue = nil
+		case amount == nil:
+			if len(queue) > 0 {
+				queue[0].repay(activity.Timestamp)
+				queue = queue[1:]
+			}
+		default:
+			left := new(big.Int).Set(amount)
+			for len(queue) > 0 && left.Sign() > 0 {
+				l := queue[0]
+				if l.amount == nil {
+					// Can't tell how much of an unknown borrow this covers; assume all
+					l.repay(activity.Timestamp)
+					queue = queue[1:]
+					break
+				}
+				if left.Cmp(l.remaining) >= 0 {
+					left.Sub(left, l.remaining)
+					l.repay(activity.Timestamp)
+					queue = queue[1:]
+					continue
+				}
+				l.remaining.Sub(l.remaining, left)
+				left.SetInt64(0)
+			}
+		}
+		open[key] = queue
+	}
+
+	analysis := RepaymentAnalysis{
+		Borrows:         uint32(len(loans)),
+		OutstandingDebt: make(map[string]units.Decimal),
+	}
+	if len(loans) == 0 {
+		return analysis
+	}
+
+	var repaidShare, daysToRepay float64
+	for _, l := range loans {
+		if !l.repaidAt.IsZero() {
+			analysis.Repaid++
+			repaidShare++
+			daysToRepay += l.repaidAt.Sub(l.borrowedAt).Hours() / 24
+			continue
+		}
+		analysis.OutstandingLoans++
+		if l.amount != nil && l.amount.Sign() > 0 {
+			repaid := new(big.Int).Sub(l.amount, l.remaining)
+			share, _ := new(big.Rat).SetFrac(repaid, l.amount).Float64()
+			repaidShare += share
+		}
+	}
+	for key, queue := range open {
+		for _, l := range queue {
+			if l.amount != nil {
+				analysis.OutstandingDebt[key] = analysis.OutstandingDebt[key].Add(units.DecimalFromBigInt(l.remaining))
+			}
+		}
+	}
+
+	analysis.RepaymentRatio = repaidShare / float64(len(loans))
+	if analysis.Repaid > 0 {
+		analysis.AvgDaysToRepay = daysToRepay / float64(analysis.Repaid)
+	}
+	return analysis
+}
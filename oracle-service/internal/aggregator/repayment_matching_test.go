@@ -0,0 +1,81 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+)
+
+func TestMatchRepayments(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	event := func(activity, protocol, asset, amount string, at time.Duration) providers.DeFiActivity {
+		return providers.DeFiActivity{
+			Protocol:     protocol,
+			ActivityType: activity,
+			Asset:        asset,
+			RawAmount:    amount,
+			Timestamp:    start.Add(at),
+			Status:       providers.ActivitySucceeded,
+		}
+	}
+	const usdc, dai = "0xusdc", "0xdai"
+
+	activities := []providers.DeFiActivity{
+		// Repaid with interest after 10 days; the excess carries over to the next USDC borrow
+		event(providers.ActivityBorrow, "aave", usdc, "1000", 0),
+		event(providers.ActivityBorrow, "aave", usdc, "500", 5*day),
+		event(providers.ActivityRepay, "aave", usdc, "1100", 10*day),
+		// Same asset on another protocol is a separate position
+		event(providers.ActivityRepay, "compound", usdc, "400", 11*day),
+		// Repaid in full with "repay everything" after 20 days
+		event(providers.ActivityBorrow, "aave", dai, "2000", 20*day),
+		{ActivityType: providers.ActivityRepay, Protocol: "aave", Asset: dai, ClosesPosition: true, Timestamp: start.Add(40 * day)},
+		// A reverted borrow doesn't count
+		{ActivityType: providers.ActivityBorrow, Protocol: "aave", Asset: dai, RawAmount: "1", Timestamp: start.Add(41 * day), Status: providers.ActivityFailed},
+		// Unrelated activity
+		event(providers.ActivitySwap, "uniswap", "", "", 12*day),
+	}
+
+	got := MatchRepayments(activities)
+	if got.Borrows != 3 || got.Repaid != 2 || got.OutstandingLoans != 1 {
+		t.Fatalf("Expected 3 borrows, 2 repaid and 1 outstanding, got %+v", got)
+	}
+	// (1 + 100/500 + 1) / 3
+	if got.RepaymentRatio < 0.733 || got.RepaymentRatio > 0.734 {
+		t.Errorf("Expected a repayment ratio of 0.733, got %f", got.RepaymentRatio)
+	}
+	if got.AvgDaysToRepay != 15 {
+		t.Errorf("Expected 15 days to repay on average, got %f", got.AvgDaysToRepay)
+	}
+	if debt := got.OutstandingDebt["aave:"+usdc]; debt.String() != "400" {
+		t.Errorf("Expected 400 USDC outstanding on aave, got %s", debt)
+	}
+}
+
+func TestMatchRepaymentsUnknownAmounts(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	activities := []providers.DeFiActivity{
+		// A repayment of a borrow made before the history starts
+		{ActivityType: providers.ActivityRepay, Protocol: "compound", Asset: "0xceth", Timestamp: start},
+		{ActivityType: providers.ActivityBorrow, Protocol: "compound", Asset: "0xceth", Timestamp: start.Add(time.Hour)},
+		{ActivityType: providers.ActivityBorrow, Protocol: "compound", Asset: "0xceth", Timestamp: start.Add(2 * time.Hour)},
+		{ActivityType: providers.ActivityRepay, Protocol: "compound", Asset: "0xceth", Timestamp: start.Add(49 * time.Hour)},
+	}
+
+	got := MatchRepayments(activities)
+	if got.Borrows != 2 || got.Repaid != 1 || got.OutstandingLoans != 1 {
+		t.Fatalf("Expected the repayment to close the oldest borrow, got %+v", got)
+	}
+	if got.AvgDaysToRepay != 2 || got.RepaymentRatio != 0.5 {
+		t.Errorf("Expected 2 days to repay and a ratio of 0.5, got %+v", got)
+	}
+	if len(got.OutstandingDebt) != 0 {
+		t.Errorf("Expected no outstanding debt of known amount, got %v", got.OutstandingDebt)
+	}
+
+	if got := MatchRepayments(nil); got.Borrows != 0 || got.RepaymentRatio != 0 {
+		t.Errorf("Expected no history, got %+v", got)
+	}
+}
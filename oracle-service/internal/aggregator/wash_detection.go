@@ -0,0 +1,195 @@
+package aggregator
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+)
+
+// Wash activity thresholds
+const (
+	washWindow    = 24 * time.Hour // Funds back in the wallet this soon had no economic use
+	washTolerance = 0.03           // Amounts this close are the same funds, less fees and interest
+)
+
+// WashActivity is the DeFi activity of a wallet that only cycles its own funds to
+// inflate its DeFi history: deposits withdrawn or borrows repaid within a day, and
+// swaps whose proceeds are swapped or sent straight back
+type WashActivity struct {
+	PositionCycles uint32          `json:"position_cycles"`
+	SwapRoundTrips uint32          `json:"swap_round_trips"`
+	Transactions   map[string]bool `json:"-"` // Lowercase hashes of the transactions involved
+}
+
+// Count is the number of transactions found to be wash activity
+func (w *WashActivity) Count() uint32 {
+	return uint32(len(w.Transactions))
+}
+
+// Genuine returns the activities that are not wash activity
+func (w *WashActivity) Genuine(activities []providers.DeFiActivity) []providers.DeFiActivity {
+	genuine := make([]providers.DeFiActivity, 0, len(activities))
+	for _, activity := range activities {
+		if !w.Transactions[strings.ToLower(activity.TransactionHash)] {
+			genuine = append(genuine, activity)
+		}
+	}
+	return genuine
+}
+
+// positionOpenedBy maps each position-closing activity to the activity it undoes
+var positionOpenedBy = map[string]string{
+	providers.ActivityWithdraw: providers.ActivityLend,
+	providers.ActivityRepay:    providers.ActivityBorrow,
+}
+
+// DetectWashActivity finds self-referential DeFi loops in a wallet's activities and
+// token transfers. A deposit or borrow counts as a cycle when the same amount of the
+// same asset is withdrawn or repaid within washWindow; amounts that are unknown are
+// never matched. A swap is a round trip when about the amount of a token it sold comes
+// back to the wallet within washWindow, whether through another swap or a transfer from
+// a wallet it swapped to.
+func DetectWashActivity(address string, activities []providers.DeFiActivity, transfers []providers.BlockscoutTokenTransfer) *WashActivity {
+	wash := &WashActivity{Transactions: make(map[string]bool)}
+
+	var successful []providers.DeFiActivity
+	for _, activity := range activities {
+		if activity.Status != providers.ActivityFailed && !activity.Timestamp.IsZero() {
+			successful = append(successful, activity)
+		}
+	}
+	sort.SliceStable(successful, func(i, j int) bool {
+		return successful[i].Timestamp.Before(successful[j].Timestamp)
+	})
+
+	wash.findPositionCycles(successful)
+	wash.findSwapRoundTrips(address, successful, transfers)
+	return wash
+}
+
+// openedPosition is a deposit or borrow awaiting a matching withdrawal or repayment
+type openedPosition struct {
+	activity providers.DeFiActivity
+	amount   *big.Int
+}
+
+func (w *WashActivity) findPositionCycles(activities []providers.DeFiActivity) {
+	open := make(map[string][]openedPosition) // Per activity type, protocol and asset, oldest first
+	key := func(activityType string, activity providers.DeFiActivity) string {
+		return activityType + ":" + activity.Protocol + ":" + activity.Asset
+	}
+
+	for _, activity := range activities {
+		amount, err := providers.ParseQuantity(activity.RawAmount)
+		if err != nil {
+			amount = nil
+		}
+
+		opener, closes := positionOpenedBy[activity.ActivityType]
+		if !closes {
+			if activity.ActivityType == providers.ActivityLend || activity.ActivityType == providers.ActivityBorrow {
+				k := key(activity.ActivityType, activity)
+				open[k] = append(open[k], openedPosition{activity: activity, amount: amount})
+			}
+			continue
+		}
+
+		k := key(opener, activity)
+		queue := open[k]
+		for i, opened := range queue {
+			if activity.Timestamp.Sub(opened.activity.Timestamp) > washWindow {
+				continue
+			}
+			if !activity.ClosesPosition && !sameAmount(opened.amount, amount) {
+				continue
+			}
+			w.PositionCycles++
+			w.Transactions[strings.ToLower(opened.activity.TransactionHash)] = true
+			w.Transactions[strings.ToLower(activity.TransactionHash)] = true
+			open[k] = append(queue[:i:i], queue[i+1:]...)
+			break
+		}
+	}
+}
+
+// tokenFlow is a token transfer into or out of the wallet
+type tokenFlow struct {
+	hash   string
+	token  string
+	amount *big.Int
+	at     time.Time
+}
+
+func (w *WashActivity) findSwapRoundTrips(address string, activities []providers.DeFiActivity, transfers []providers.BlockscoutTokenTransfer) {
+	var outflows, inflows []tokenFlow
+	for _, transfer := range transfers {
+		amount, err := providers.ParseQuantity(transfer.Value)
+		if err != nil || amount.Sign() == 0 {
+			continue
+		}
+		at, err := transfer.Time()
+		if err != nil {
+			continue
+		}
+		flow := tokenFlow{
+			hash:   strings.ToLower(transfer.Hash),
+			token:  strings.ToLower(transfer.ContractAddress),
+			amount: amount,
+			at:     at,
+		}
+		if strings.EqualFold(transfer.From, address) {
+			outflows = append(outflows, flow)
+		} else if strings.EqualFold(transfer.To, address) {
+			inflows = append(inflows, flow)
+		}
+	}
+	sort.SliceStable(inflows, func(i, j int) bool { return inflows[i].at.Before(inflows[j].at) })
+
+	swaps := make(map[string]bool)
+	for _, activity := range activities {
+		if activity.ActivityType == providers.ActivitySwap {
+			swaps[strings.ToLower(activity.TransactionHash)] = true
+		}
+	}
+
+	used := make(map[int]bool) // Inflows already matched to a swap
+	for _, sold := range outflows {
+		if !swaps[sold.hash] || w.Transactions[sold.hash] {
+			continue
+		}
+		for i, back := range inflows {
+			if used[i] || back.hash == sold.hash || back.token != sold.token {
+				continue
+			}
+			if !back.at.After(sold.at) || back.at.Sub(sold.at) > washWindow {
+				continue
+			}
+			if !sameAmount(sold.amount, back.amount) {
+				continue
+			}
+			used[i] = true
+			w.SwapRoundTrips++
+			w.Transactions[sold.hash] = true
+			if swaps[back.hash] {
+				w.Transactions[back.hash] = true
+			}
+			break
+		}
+	}
+}
+
+// sameAmount reports whether two known amounts are within washTolerance of each other
+func sameAmount(a, b *big.Int) bool {
+	if a == nil || b == nil || a.Sign() == 0 || b.Sign() == 0 {
+		return false
+	}
+	larger, smaller := a, b
+	if a.Cmp(b) < 0 {
+		larger, smaller = b, a
+	}
+	ratio, _ := new(big.Rat).SetFrac(smaller, larger).Float64()
+	return ratio >= 1-washTolerance
+}
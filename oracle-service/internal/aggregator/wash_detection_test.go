@@ -0,0 +1,106 @@
+package aggregator
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+)
+
+func TestDetectWashActivityPositionCycles(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	event := func(hash, activity, asset, amount string, at time.Duration) providers.DeFiActivity {
+		return providers.DeFiActivity{
+			Protocol:        "aave",
+			ActivityType:    activity,
+			Asset:           asset,
+			RawAmount:       amount,
+			TransactionHash: hash,
+			Timestamp:       start.Add(at),
+			Status:          providers.ActivitySucceeded,
+		}
+	}
+	const usdc, dai = "0xusdc", "0xdai"
+
+	activities := []providers.DeFiActivity{
+		// Supplied and withdrawn an hour later
+		event("0x1", providers.ActivityLend, usdc, "1000000", 0),
+		event("0x2", providers.ActivityWithdraw, usdc, "1000100", time.Hour),
+		// Borrowed and repaid in full within minutes
+		event("0x3", providers.ActivityBorrow, dai, "5000", 2*time.Hour),
+		{ActivityType: providers.ActivityRepay, Protocol: "aave", Asset: dai, ClosesPosition: true, TransactionHash: "0x4", Timestamp: start.Add(2*time.Hour + 5*time.Minute)},
+		// Withdrawn after a week: a real position
+		event("0x5", providers.ActivityLend, dai, "7000", 3*time.Hour),
+		event("0x6", providers.ActivityWithdraw, dai, "7000", 7*24*time.Hour),
+		// Partly withdrawn the same day
+		event("0x7", providers.ActivityLend, usdc, "9000", 8*24*time.Hour),
+		event("0x8", providers.ActivityWithdraw, usdc, "3000", 8*24*time.Hour+time.Hour),
+		// Unknown amounts are never matched
+		event("0x9", providers.ActivityBorrow, usdc, "", 9*24*time.Hour),
+		event("0xa", providers.ActivityRepay, usdc, "", 9*24*time.Hour+time.Minute),
+	}
+
+	wash := DetectWashActivity("0xwallet", activities, nil)
+	if wash.PositionCycles != 2 || wash.Count() != 4 {
+		t.Fatalf("Expected 2 cycles over 4 transactions, got %+v", wash)
+	}
+	for _, hash := range []string{"0x1", "0x2", "0x3", "0x4"} {
+		if !wash.Transactions[hash] {
+			t.Errorf("Expected %s to be wash activity", hash)
+		}
+	}
+
+	genuine := wash.Genuine(activities)
+	if len(genuine) != len(activities)-4 {
+		t.Errorf("Expected %d genuine activities, got %d", len(activities)-4, len(genuine))
+	}
+}
+
+func TestDetectWashActivitySwapRoundTrips(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	const wallet, other, router = "0xwallet", "0xother", "0xrouter"
+	const usdc, weth = "0xusdc", "0xweth"
+	transfer := func(hash, from, to, token, value string, at time.Duration) providers.BlockscoutTokenTransfer {
+		return providers.BlockscoutTokenTransfer{
+			Hash:            hash,
+			From:            from,
+			To:              to,
+			ContractAddress: token,
+			Value:           value,
+			TimeStamp:       strconv.FormatInt(start.Add(at).Unix(), 10),
+		}
+	}
+	swap := func(hash string, at time.Duration) providers.DeFiActivity {
+		return providers.DeFiActivity{
+			Protocol:        "uniswap",
+			ActivityType:    providers.ActivitySwap,
+			TransactionHash: hash,
+			Timestamp:       start.Add(at),
+			Status:          providers.ActivitySucceeded,
+		}
+	}
+
+	activities := []providers.DeFiActivity{swap("0x1", 0), swap("0x2", time.Hour), swap("0x3", 2*time.Hour), swap("0x4", 48*time.Hour)}
+	transfers := []providers.BlockscoutTokenTransfer{
+		// USDC swapped to WETH and straight back
+		transfer("0x1", wallet, router, usdc, "1000000000", 0),
+		transfer("0x1", router, wallet, weth, "500000000000000000", 0),
+		transfer("0x2", wallet, router, weth, "500000000000000000", time.Hour),
+		transfer("0x2", router, wallet, usdc, "998000000", time.Hour),
+		// WETH swapped with the proceeds sent to another wallet, which sends them back
+		transfer("0x3", wallet, router, weth, "2000000000000000000", 2*time.Hour),
+		transfer("0x5", other, wallet, weth, "1990000000000000000", 3*time.Hour),
+		// A swap kept for days
+		transfer("0x4", wallet, router, usdc, "3000000000", 48*time.Hour),
+		transfer("0x4", router, wallet, weth, "1000000000000000000", 48*time.Hour),
+	}
+
+	wash := DetectWashActivity(wallet, activities, transfers)
+	if wash.SwapRoundTrips != 2 || wash.Count() != 3 {
+		t.Fatalf("Expected 2 round trips over 3 swaps, got %+v", wash)
+	}
+	if wash.Transactions["0x4"] || wash.Transactions["0x5"] {
+		t.Errorf("Expected only the round-trip swaps flagged, got %v", wash.Transactions)
+	}
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Endpoint classes with their own cache max-age
+const (
+	CacheClassScore       = "score"       // Current score and explanation
+	CacheClassHistory     = "history"     // Score history and lifecycle events
+	CacheClassStats       = "stats"       // Service statistics (admin only, never cached by shared caches)
+	CacheClassCredentials = "credentials" // Revocation status lists and the issuer DID document
+	CacheClassPublic      = "public"      // Anonymized public statistics
+)
+
+// privateCacheClasses may only be cached by the client, not by CDNs or proxies
+var privateCacheClasses = map[string]bool{
+	CacheClassStats: true,
+}
+
+// cachePolicyKey is the context key of the route's cache policy
+const cachePolicyKey = "cache_policy"
+
+type cachePolicy struct {
+	maxAge  int
+	private bool
+}
+
+// CacheControl lets successful responses of a read endpoint in the given class be
+// cached for maxAge seconds. With a maxAge of zero, clients must revalidate every
+// time.
+func CacheControl(class string, maxAge int) gin.HandlerFunc {
+	policy := cachePolicy{maxAge: maxAge, private: privateCacheClasses[class]}
+	return func(c *gin.Context) {
+		c.Set(cachePolicyKey, policy)
+		c.Next()
+	}
+}
+
+// cacheable sets the caching headers of a successful response whose data last
+// changed at lastModified (zero if unknown). If the client's copy is still current,
+// it responds 304 Not Modified and returns true.
+func cacheable(c *gin.Context, lastModified time.Time) bool {
+	if !cacheControl(c) || lastModified.IsZero() {
+		return false
+	}
+	c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+
+	// HTTP dates have second precision
+	since, err := http.ParseTime(c.GetHeader("If-Modified-Since"))
+	if err == nil && !lastModified.Truncate(time.Second).After(since) {
+		c.Status(http.StatusNotModified)
+		return true
+	}
+	return false
+}
+
+// cacheControl sets the Cache-Control header of a successful response from the
+// route's max-age. It returns false if the route is not cacheable.
+func cacheControl(c *gin.Context) bool {
+	value, ok := c.Get(cachePolicyKey)
+	if !ok {
+		return false
+	}
+	policy := value.(cachePolicy)
+
+	scope := "public"
+	if policy.private {
+		scope = "private"
+	}
+	if policy.maxAge > 0 {
+		c.Header("Cache-Control", fmt.Sprintf("%s, max-age=%d", scope, policy.maxAge))
+	} else {
+		c.Header("Cache-Control", scope+", no-cache")
+	}
+	return true
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Compress gzip-compresses responses of at least minSize bytes for clients that
+// accept gzip. Smaller responses are sent as-is, since compressing them costs more
+// than it saves. A minSize of zero disables compression. Brotli isn't offered,
+// since the standard library has no encoder for it.
+func Compress(minSize int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if minSize <= 0 {
+			c.Next()
+			return
+		}
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsEncoding(c.GetHeader("Accept-Encoding"), "gzip") {
+			c.Next()
+			return
+		}
+
+		writer := &gzipResponseWriter{ResponseWriter: c.Writer, minSize: minSize}
+		c.Writer = writer
+		defer writer.finish()
+		c.Next()
+	}
+}
+
+// DecompressRequest accepts gzip- or deflate-encoded request bodies, so large batch
+// requests can be sent compressed. Bodies that decompress to more than maxSize bytes
+// are rejected.
+func DecompressRequest(maxSize int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body io.ReadCloser
+		var err error
+		switch strings.ToLower(strings.TrimSpace(c.GetHeader("Content-Encoding"))) {
+		case "", "identity":
+			c.Next()
+			return
+		case "gzip", "x-gzip":
+			body, err = gzip.NewReader(c.Request.Body)
+		case "deflate":
+			body, err = zlib.NewReader(c.Request.Body)
+		default:
+			c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, ErrorResponse{
+				Error:   tr(c, "Unsupported content encoding"),
+				Message: tr(c, "Request bodies may be gzip or deflate encoded"),
+			})
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrorResponse{
+				Error:   tr(c, "Invalid request"),
+				Message: trError(c, err),
+			})
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, body, maxSize)
+		c.Request.ContentLength = -1
+		c.Request.Header.Del("Content-Encoding")
+		c.Next()
+	}
+}
+
+// gzipResponseWriter buffers a response until it reaches minSize, then streams it
+// gzip-compressed. Responses that never reach minSize are written uncompressed.
+type gzipResponseWriter struct {
+	gin.ResponseWriter
+	minSize     int
+	buf         []byte
+	gz          *gzip.Writer
+	passthrough bool
+}
+
+func (w *gzipResponseWriter) Write(data []byte) (int, error) {
+	if w.gz != nil {
+		return w.gz.Write(data)
+	}
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buf = append(w.buf, data...)
+	if len(w.buf) < w.minSize {
+		return len(data), nil
+	}
+	if err := w.start(); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func (w *gzipResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *gzipResponseWriter) Flush() {
+	if w.gz == nil && !w.passthrough {
+		if err := w.start(); err != nil {
+			return
+		}
+	}
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// start writes the buffered body, compressing it unless the handler already encoded
+// the response
+func (w *gzipResponseWriter) start() error {
+	buf := w.buf
+	w.buf = nil
+
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" {
+		w.passthrough = true
+		_, err := w.ResponseWriter.Write(buf)
+		return err
+	}
+
+	header.Set("Content-Encoding", "gzip")
+	header.Del("Content-Length")
+	w.gz = gzip.NewWriter(w.ResponseWriter)
+	_, err := w.gz.Write(buf)
+	return err
+}
+
+// finish writes what is left of the response once the handler returns
+func (w *gzipResponseWriter) finish() {
+	if w.gz != nil {
+		w.gz.Close()
+		return
+	}
+	if len(w.buf) > 0 {
+		w.ResponseWriter.Write(w.buf)
+	}
+}
+
+// acceptsEncoding reports whether an Accept-Encoding header allows encoding
+func acceptsEncoding(acceptEncoding, encoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.TrimSpace(name)
+		if !strings.EqualFold(name, encoding) && name != "*" {
+			continue
+		}
+
+		params = strings.TrimSpace(params)
+		if strings.HasPrefix(params, "q=") {
+			q, err := strconv.ParseFloat(strings.TrimPrefix(params, "q="), 64)
+			if err != nil || q == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// CredentialHandler handles Verifiable Credential issuance and revocation
+type CredentialHandler struct {
+	service *service.OracleService
+}
+
+// NewCredentialHandler creates a new credential handler
+func NewCredentialHandler(service *service.OracleService) *CredentialHandler {
+	return &CredentialHandler{
+		service: service,
+	}
+}
+
+// IssueCredentialRequest is a wallet-signed request for a score credential, signed
+// over the wallet message with action "issue_credential"
+type IssueCredentialRequest struct {
+	Timestamp int64  `json:"timestamp" binding:"required"`
+	Signature string `json:"signature" binding:"required"`
+}
+
+// RevokeCredentialRequest gives the reason a credential is revoked
+type RevokeCredentialRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// IssueCredential issues the address's score as a Verifiable Credential
+// @Summary Issue score credential
+// @Description Package the address's current score as a W3C Verifiable Credential signed by the oracle's did:web, returned both as JSON-LD and as a VC-JWT for identity wallets
+// @Tags credentials
+// @Accept json
+// @Produce json
+// @Param address path string true "Ethereum address"
+// @Param request body IssueCredentialRequest true "Signed issue request"
+// @Success 201 {object} service.IssuedScoreCredential
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 423 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/credit-score/{address}/credentials [post]
+func (h *CredentialHandler) IssueCredential(c *gin.Context) {
+	var req IssueCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   tr(c, "Invalid request"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	issued, err := h.service.IssueScoreCredential(c.Request.Context(), c.Param("address"), req.Timestamp, req.Signature, requester(c))
+	if err != nil {
+		h.respondError(c, "Failed to issue credential", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, issued)
+}
+
+// RevokeCredential revokes an issued credential
+// @Summary Revoke score credential
+// @Description Set the credential's bit in its revocation status list
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Issued credential ID"
+// @Param request body RevokeCredentialRequest true "Revocation reason"
+// @Success 200 {object} models.IssuedCredential
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/credentials/{id}/revoke [post]
+func (h *CredentialHandler) RevokeCredential(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   tr(c, "Invalid credential ID"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	var req RevokeCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   tr(c, "Invalid request"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	record, err := h.service.RevokeCredential(c.Request.Context(), uint(id), req.Reason)
+	if err != nil {
+		h.respondError(c, "Failed to revoke credential", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, record)
+}
+
+// GetStatusList returns a revocation status list credential
+// @Summary Get credential status list
+// @Description Signed StatusList2021 revocation list (VC-JWT) referenced by issued credentials
+// @Tags credentials
+// @Produce application/jwt
+// @Param list path int true "Status list number"
+// @Success 200 {string} string
+// @Failure 400 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/credentials/status/{list} [get]
+func (h *CredentialHandler) GetStatusList(c *gin.Context) {
+	list, err := strconv.Atoi(c.Param("list"))
+	if err != nil || list < 1 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   tr(c, "Invalid status list"),
+			Message: tr(c, "list must be a positive integer"),
+		})
+		return
+	}
+
+	jwt, err := h.service.CredentialStatusList(c.Request.Context(), list)
+	if err != nil {
+		h.respondError(c, "Failed to build status list", err)
+		return
+	}
+
+	cacheControl(c)
+	c.Data(http.StatusOK, "application/jwt", []byte(jwt))
+}
+
+// GetDIDDocument returns the issuer's did:web document
+// @Summary Get issuer DID document
+// @Description did:web document holding the public key that signs credentials
+// @Tags credentials
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 503 {object} ErrorResponse
+// @Router /.well-known/did.json [get]
+func (h *CredentialHandler) GetDIDDocument(c *gin.Context) {
+	document, err := h.service.IssuerDIDDocument()
+	if err != nil {
+		h.respondError(c, "Failed to get DID document", err)
+		return
+	}
+
+	cacheControl(c)
+	c.JSON(http.StatusOK, document)
+}
+
+func (h *CredentialHandler) respondError(c *gin.Context, message string, err error) {
+	status := errorStatus(err)
+	switch {
+	case errors.Is(err, service.ErrCredentialsNotConfigured):
+		status = http.StatusServiceUnavailable
+	case errors.Is(err, service.ErrInvalidWalletSignature), errors.Is(err, service.ErrStaleWalletSignature):
+		status = http.StatusUnauthorized
+	case errors.Is(err, service.ErrProfileFrozen):
+		status = http.StatusLocked
+	case errors.Is(err, service.ErrScoreProvisional):
+		status = http.StatusConflict
+	}
+
+	logger.Error(message, zap.Error(err))
+	c.JSON(status, ErrorResponse{
+		Error:   tr(c, message),
+		Message: trError(c, err),
+	})
+}
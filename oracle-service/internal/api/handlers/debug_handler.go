@@ -0,0 +1,362 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/debugtrace"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/identity"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// APIKeyHeader carries the caller's API key, as forwarded by the API gateway
+const APIKeyHeader = "X-API-Key"
+
+// debugTraceRecordTimeout bounds how long storing a trace may delay a response
+const debugTraceRecordTimeout = 5 * time.Second
+
+// DebugHandler records debug traces and handles their admin management
+type DebugHandler struct {
+	service *service.DebugService
+}
+
+// NewDebugHandler creates a new debug handler
+func NewDebugHandler(service *service.DebugService) *DebugHandler {
+	return &DebugHandler{
+		service: service,
+	}
+}
+
+// AddDebugTargetRequest represents a request to start tracing an address or API key
+type AddDebugTargetRequest struct {
+	Address  string `json:"address"`
+	APIKey   string `json:"api_key"`
+	Note     string `json:"note"`
+	TTLHours int    `json:"ttl_hours"` // Defaults to 24, at most 168
+}
+
+// ListDebugTargetsResponse represents a list of active debug targets
+type ListDebugTargetsResponse struct {
+	Targets []*models.DebugTarget `json:"targets"`
+	Count   int                   `json:"count"`
+}
+
+// ListDebugTracesResponse represents a list of debug traces
+type ListDebugTracesResponse struct {
+	Traces []*models.DebugTrace `json:"traces"`
+	Count  int                  `json:"count"`
+}
+
+// Trace records the requests of active debug targets: the request, every provider
+// call made while serving it, and the response. Requests that no target covers pass
+// through untouched.
+func (h *DebugHandler) Trace() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Reading traces must not trace the read
+		if !h.service.Enabled(c.Request.Context()) || strings.Contains(c.FullPath(), "/admin/debug/") {
+			c.Next()
+			return
+		}
+
+		// Compressed bodies are decoded by their route, so only plain bodies are recorded
+		var body []byte
+		if c.Request.Body != nil && c.GetHeader("Content-Encoding") == "" {
+			var err error
+			body, err = io.ReadAll(c.Request.Body)
+			c.Request.Body.Close()
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, ErrorResponse{
+					Error:   tr(c, "Invalid request"),
+					Message: err.Error(),
+				})
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		address := requestAddress(c, body)
+		target := h.service.Match(c.Request.Context(), address, c.GetHeader(APIKeyHeader), c.GetHeader(AdminKeyHeader))
+		if target == nil {
+			c.Next()
+			return
+		}
+
+		recorder := debugtrace.NewRecorder()
+		c.Request = c.Request.WithContext(debugtrace.WithRecorder(c.Request.Context(), recorder))
+		writer := &traceWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		start := time.Now()
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+		trace := &models.DebugTrace{
+			TargetID:     target.ID,
+			Address:      address,
+			APIKeyHash:   apiKeyHash(c),
+			Method:       c.Request.Method,
+			Path:         c.Request.URL.Path,
+			Query:        debugtrace.RedactQuery(c.Request.URL.RawQuery),
+			RequestBody:  debugtrace.RedactBody(body),
+			Status:       writer.Status(),
+			ResponseBody: debugtrace.RedactBody(writer.body.Bytes()),
+			DurationMs:   time.Since(start).Milliseconds(),
+		}
+
+		// The trace is stored even if the request was cancelled
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), debugTraceRecordTimeout)
+		defer cancel()
+		h.service.RecordTrace(ctx, trace, recorder.Calls())
+	}
+}
+
+// AddTarget starts tracing an address or API key
+// @Summary Add debug target
+// @Description Record full request, provider call and response traces for an address or API key until the target expires
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body AddDebugTargetRequest true "Address or API key to trace"
+// @Success 201 {object} models.DebugTarget
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/debug/targets [post]
+func (h *DebugHandler) AddTarget(c *gin.Context) {
+	var req AddDebugTargetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+	if req.Address != "" && !resolveAddresses(c, &req.Address) {
+		return
+	}
+
+	target, err := h.service.AddTarget(
+		c.Request.Context(),
+		req.Address,
+		req.APIKey,
+		req.Note,
+		time.Duration(req.TTLHours)*time.Hour,
+	)
+	if err != nil {
+		h.respondError(c, "Failed to add debug target", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, target)
+}
+
+// ListTargets lists the active debug targets
+// @Summary List debug targets
+// @Description List the addresses and API keys currently being traced
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} ListDebugTargetsResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/debug/targets [get]
+func (h *DebugHandler) ListTargets(c *gin.Context) {
+	targets, err := h.service.ListTargets(c.Request.Context())
+	if err != nil {
+		h.respondError(c, "Failed to list debug targets", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ListDebugTargetsResponse{
+		Targets: targets,
+		Count:   len(targets),
+	})
+}
+
+// RemoveTarget stops tracing a debug target
+// @Summary Remove debug target
+// @Description Stop tracing a debug target; its recorded traces are kept
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Debug target ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/debug/targets/{id} [delete]
+func (h *DebugHandler) RemoveTarget(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	deleted, err := h.service.RemoveTarget(c.Request.Context(), id)
+	if err != nil {
+		h.respondError(c, "Failed to remove debug target", err)
+		return
+	}
+
+	if !deleted {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "No debug target found with this ID",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"removed": true, "id": id})
+}
+
+// ListTraces lists recorded debug traces
+// @Summary List debug traces
+// @Description List recorded traces, newest first, without bodies or provider calls
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param address query string false "Traced address"
+// @Param target_id query int false "Debug target ID"
+// @Param limit query int false "Number of records to return" default(50)
+// @Success 200 {object} ListDebugTracesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/debug/traces [get]
+func (h *DebugHandler) ListTraces(c *gin.Context) {
+	var targetID uint64
+	if value := c.Query("target_id"); value != "" {
+		var err error
+		targetID, err = strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request",
+				Message: "target_id must be a positive integer",
+			})
+			return
+		}
+	}
+	address := c.Query("address")
+	if address != "" && !resolveAddresses(c, &address) {
+		return
+	}
+
+	traces, err := h.service.ListTraces(c.Request.Context(), address, uint(targetID), queryLimit(c))
+	if err != nil {
+		h.respondError(c, "Failed to list debug traces", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ListDebugTracesResponse{
+		Traces: traces,
+		Count:  len(traces),
+	})
+}
+
+// GetTrace retrieves a debug trace
+// @Summary Get debug trace
+// @Description Get a recorded request with its response and every provider call made while serving it
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Debug trace ID"
+// @Success 200 {object} service.DebugTraceDetail
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/debug/traces/{id} [get]
+func (h *DebugHandler) GetTrace(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	trace, err := h.service.GetTrace(c.Request.Context(), id)
+	if err != nil {
+		h.respondError(c, "Failed to retrieve debug trace", err)
+		return
+	}
+
+	if trace == nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "No debug trace found with this ID",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, trace)
+}
+
+func (h *DebugHandler) respondError(c *gin.Context, message string, err error) {
+	logger.Error(message, zap.Error(err))
+	c.JSON(errorStatus(err), ErrorResponse{
+		Error:   message,
+		Message: err.Error(),
+	})
+}
+
+// requestAddress returns the address a request is about: the :address path parameter,
+// or else the address field of a JSON body
+func requestAddress(c *gin.Context, body []byte) string {
+	if address := c.Param("address"); address != "" {
+		return address
+	}
+
+	var fields struct {
+		Address string `json:"address"`
+	}
+	if len(body) == 0 || json.Unmarshal(body, &fields) != nil {
+		return ""
+	}
+	if address, err := identity.ResolveAddress(fields.Address); err == nil {
+		return address
+	}
+	return fields.Address
+}
+
+// apiKeyHash returns the hash of the API key a request was made with, if any
+func apiKeyHash(c *gin.Context) string {
+	for _, header := range []string{APIKeyHeader, AdminKeyHeader} {
+		if key := c.GetHeader(header); key != "" {
+			return service.HashAPIKey(key)
+		}
+	}
+	return ""
+}
+
+// traceWriter keeps a copy of the response body, up to the size recorded in a trace
+type traceWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *traceWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *traceWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *traceWriter) capture(data []byte) {
+	if remaining := debugtrace.MaxBodyBytes + 1 - w.body.Len(); remaining > 0 {
+		if len(data) > remaining {
+			data = data[:remaining]
+		}
+		w.body.Write(data)
+	}
+}
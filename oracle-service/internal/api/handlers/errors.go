@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+)
+
+// errorStatus maps an error to the HTTP status for its kind. A passed deadline is a
+// gateway timeout whatever failed; unclassified errors are internal errors.
+func errorStatus(err error) int {
+	if errors.IsTimeout(err) {
+		return http.StatusGatewayTimeout
+	}
+
+	switch errors.KindOf(err) {
+	case errors.ErrValidation:
+		return http.StatusBadRequest
+	case errors.ErrNotFound:
+		return http.StatusNotFound
+	case errors.ErrProviderUnavailable:
+		return http.StatusServiceUnavailable
+	case errors.ErrBlockchain:
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// FreezeHandler handles borrower-requested data freezes
+type FreezeHandler struct {
+	service *service.OracleService
+}
+
+// NewFreezeHandler creates a new freeze handler
+func NewFreezeHandler(service *service.OracleService) *FreezeHandler {
+	return &FreezeHandler{
+		service: service,
+	}
+}
+
+// FreezeRequest is a wallet-signed freeze or lift request. Signature is the
+// personal_sign signature of the wallet message for the action, address and timestamp.
+type FreezeRequest struct {
+	Timestamp int64  `json:"timestamp" binding:"required"` // Unix seconds, within 5 minutes of the server clock
+	Signature string `json:"signature" binding:"required"`
+}
+
+// GetFreeze returns an address's freeze state
+// @Summary Get data freeze
+// @Description Report whether an address's profile is frozen
+// @Tags credit-score
+// @Produce json
+// @Param address path string true "Ethereum address"
+// @Success 200 {object} models.DataFreeze
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/credit-score/{address}/freeze [get]
+func (h *FreezeHandler) GetFreeze(c *gin.Context) {
+	freeze, err := h.service.GetFreezeStatus(c.Request.Context(), c.Param("address"))
+	if err != nil {
+		logger.Error("Failed to get data freeze", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to get data freeze"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, freeze)
+}
+
+// Freeze freezes an address's profile
+// @Summary Freeze profile
+// @Description Stop provider data pulls and score publishing for an address until the freeze is lifted. The request must be signed by the address's wallet over "P2P-Lend wallet authorization\nAction: freeze\nAddress: <lowercase address>\nTimestamp: <timestamp>".
+// @Tags credit-score
+// @Accept json
+// @Produce json
+// @Param address path string true "Ethereum address"
+// @Param request body FreezeRequest true "Signed freeze request"
+// @Success 200 {object} models.DataFreeze
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/credit-score/{address}/freeze [post]
+func (h *FreezeHandler) Freeze(c *gin.Context) {
+	h.setFreeze(c, models.FreezeActionFreeze)
+}
+
+// LiftFreeze lifts an address's freeze
+// @Summary Lift profile freeze
+// @Description Resume provider data pulls and score publishing for an address. The request must be signed by the address's wallet over the wallet message with action "lift".
+// @Tags credit-score
+// @Accept json
+// @Produce json
+// @Param address path string true "Ethereum address"
+// @Param request body FreezeRequest true "Signed lift request"
+// @Success 200 {object} models.DataFreeze
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/credit-score/{address}/freeze/lift [post]
+func (h *FreezeHandler) LiftFreeze(c *gin.Context) {
+	h.setFreeze(c, models.FreezeActionLift)
+}
+
+func (h *FreezeHandler) setFreeze(c *gin.Context, action string) {
+	var req FreezeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   tr(c, "Invalid request"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	address := c.Param("address")
+	var freeze *models.DataFreeze
+	var err error
+	if action == models.FreezeActionFreeze {
+		freeze, err = h.service.FreezeProfile(c.Request.Context(), address, req.Timestamp, req.Signature)
+	} else {
+		freeze, err = h.service.LiftFreeze(c.Request.Context(), address, req.Timestamp, req.Signature)
+	}
+
+	if errors.Is(err, service.ErrInvalidWalletSignature) || errors.Is(err, service.ErrStaleWalletSignature) {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   tr(c, "Invalid signature"),
+			Message: trError(c, err),
+		})
+		return
+	}
+	if err != nil {
+		logger.Error("Failed to update data freeze", zap.String("action", action), zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to update data freeze"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, freeze)
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/i18n"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/identity"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+)
+
+// languageKey is the context key of the negotiated response language
+const languageKey = "language"
+
+// localizedErrors are the service errors whose messages are translated for borrowers
+var localizedErrors = []error{
+	service.ErrProfileFrozen,
+	service.ErrInvalidWalletSignature,
+	service.ErrStaleWalletSignature,
+	service.ErrShareNotFound,
+	service.ErrShareInactive,
+	service.ErrInvalidShareExpiry,
+	service.ErrCredentialsNotConfigured,
+	service.ErrCredentialNotFound,
+	service.ErrScoreNotFound,
+	service.ErrScoreProvisional,
+	service.ErrPublishEstimateUnavailable,
+	service.ErrUpdateRateLimited,
+	service.ErrDistributionDisabled,
+	service.ErrResearchExportDisabled,
+	service.ErrUnknownResearchQuery,
+	service.ErrResearchBudgetExhausted,
+	identity.ErrInvalidIdentifier,
+}
+
+// Localize picks the response language from the Accept-Language header
+func Localize() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		language := i18n.Negotiate(c.GetHeader("Accept-Language"))
+		c.Set(languageKey, language)
+		c.Header("Content-Language", language)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
+
+// language returns the request's response language
+func language(c *gin.Context) string {
+	if language := c.GetString(languageKey); language != "" {
+		return language
+	}
+	return i18n.DefaultLanguage
+}
+
+// tr translates a message into the request's language
+func tr(c *gin.Context, message string) string {
+	return i18n.T(language(c), message)
+}
+
+// trError translates a known service error into the request's language, keeping any
+// detail it was wrapped with. Other errors, such as request validation failures, are
+// returned as-is.
+func trError(c *gin.Context, err error) string {
+	message := err.Error()
+	lang := language(c)
+	if lang == i18n.English {
+		return message
+	}
+	for _, known := range localizedErrors {
+		if errors.Is(err, known) {
+			return strings.Replace(message, known.Error(), i18n.T(lang, known.Error()), 1)
+		}
+	}
+	return message
+}
+
+// localizeExplanation translates an explanation's adjustment reasons and
+// recommendations into the request's language
+func localizeExplanation(c *gin.Context, explanation *scoring.Explanation) *scoring.Explanation {
+	lang := language(c)
+	if explanation == nil || lang == i18n.English {
+		return explanation
+	}
+
+	localized := *explanation
+	localized.Adjustments = make([]scoring.Adjustment, len(explanation.Adjustments))
+	for i, adjustment := range explanation.Adjustments {
+		adjustment.Description = i18n.T(lang, adjustment.Description)
+		adjustment.Recommendation = i18n.T(lang, adjustment.Recommendation)
+		localized.Adjustments[i] = adjustment
+	}
+	return &localized
+}
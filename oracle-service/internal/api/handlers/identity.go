@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/identity"
+)
+
+// ResolveAddressParam replaces a did:pkh or did:ethr in the :address path parameter
+// with the wallet address it identifies, so handlers only ever see addresses
+func ResolveAddressParam() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for i, param := range c.Params {
+			if param.Key != "address" || !identity.IsDID(param.Value) {
+				continue
+			}
+			address, err := identity.ResolveAddress(param.Value)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, ErrorResponse{
+					Error:   tr(c, "Invalid address"),
+					Message: trError(c, err),
+				})
+				return
+			}
+			c.Params[i].Value = address
+		}
+		c.Next()
+	}
+}
+
+// resolveAddresses replaces DIDs in request fields with the wallet addresses they
+// identify. It responds with 400 and returns false if one cannot be resolved.
+func resolveAddresses(c *gin.Context, addresses ...*string) bool {
+	for _, address := range addresses {
+		resolved, err := identity.ResolveAddress(*address)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   tr(c, "Invalid address"),
+				Message: trError(c, err),
+			})
+			return false
+		}
+		*address = resolved
+	}
+	return true
+}
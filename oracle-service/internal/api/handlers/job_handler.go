@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// JobHandler handles the history of scheduled job runs
+type JobHandler struct {
+	recorder *service.JobRecorder
+}
+
+// NewJobHandler creates a new job handler
+func NewJobHandler(recorder *service.JobRecorder) *JobHandler {
+	return &JobHandler{
+		recorder: recorder,
+	}
+}
+
+// ListJobRuns lists the latest run of every scheduled job and the recent runs
+// @Summary List scheduled job runs
+// @Description List the latest run of every scheduled job (scheduled_updates, publish_queue, confirmations, stats_refresh, health_monitor, retention) and the recent runs, newest first, with their item counts and first errors. A run still "running" long after it started was interrupted.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param job query string false "Only list runs of this job"
+// @Param limit query int false "Number of runs to return" default(50)
+// @Success 200 {object} service.JobRunsReport
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/jobs [get]
+func (h *JobHandler) ListJobRuns(c *gin.Context) {
+	report, err := h.recorder.Report(c.Request.Context(), c.Query("job"), queryLimit(c))
+	if err != nil {
+		logger.Error("Failed to list job runs", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   "Failed to list job runs",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/labels"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// LabelHandler handles admin management of address labels
+type LabelHandler struct {
+	service *service.LabelService
+}
+
+// NewLabelHandler creates a new label handler
+func NewLabelHandler(service *service.LabelService) *LabelHandler {
+	return &LabelHandler{
+		service: service,
+	}
+}
+
+// UpsertLabelRequest represents a request to create or update an address label
+type UpsertLabelRequest struct {
+	Address     string `json:"address" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	Category    string `json:"category" binding:"required"` // exchange, mixer, bridge, scam, payroll, protocol
+	SourceChain string `json:"source_chain"`                // Bridges only: chain the bridged funds come from
+	Notes       string `json:"notes"`
+}
+
+// ListLabelsResponse represents a list of address labels
+type ListLabelsResponse struct {
+	Labels []*models.AddressLabel `json:"labels"`
+	Count  int                    `json:"count"`
+}
+
+// ListLabels lists address labels
+// @Summary List address labels
+// @Description List known counterparty labels, optionally filtered by category
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param category query string false "Label category"
+// @Success 200 {object} ListLabelsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/labels [get]
+func (h *LabelHandler) ListLabels(c *gin.Context) {
+	result, err := h.service.ListLabels(c.Request.Context(), c.Query("category"))
+	if err != nil {
+		h.respondError(c, "Failed to list address labels", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ListLabelsResponse{
+		Labels: result,
+		Count:  len(result),
+	})
+}
+
+// GetLabel retrieves the label for an address
+// @Summary Get address label
+// @Description Get the label for a counterparty address
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param address path string true "Blockchain address"
+// @Success 200 {object} models.AddressLabel
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/labels/{address} [get]
+func (h *LabelHandler) GetLabel(c *gin.Context) {
+	label, err := h.service.GetLabel(c.Request.Context(), c.Param("address"))
+	if err != nil {
+		h.respondError(c, "Failed to retrieve address label", err)
+		return
+	}
+
+	if label == nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "No label found for this address",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, label)
+}
+
+// UpsertLabel creates or updates an address label
+// @Summary Create or update address label
+// @Description Create or update the label for a counterparty address
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body UpsertLabelRequest true "Label"
+// @Success 200 {object} models.AddressLabel
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/labels [put]
+func (h *LabelHandler) UpsertLabel(c *gin.Context) {
+	var req UpsertLabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	label := &models.AddressLabel{
+		Address:     req.Address,
+		Name:        req.Name,
+		Category:    req.Category,
+		SourceChain: req.SourceChain,
+		Notes:       req.Notes,
+	}
+
+	if err := h.service.UpsertLabel(c.Request.Context(), label); err != nil {
+		h.respondError(c, "Failed to save address label", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, label)
+}
+
+// DeleteLabel removes an address label
+// @Summary Delete address label
+// @Description Remove the label for a counterparty address
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param address path string true "Blockchain address"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/labels/{address} [delete]
+func (h *LabelHandler) DeleteLabel(c *gin.Context) {
+	deleted, err := h.service.DeleteLabel(c.Request.Context(), c.Param("address"))
+	if err != nil {
+		h.respondError(c, "Failed to delete address label", err)
+		return
+	}
+
+	if !deleted {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "No label found for this address",
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondError maps validation failures to 400 and other errors by their kind
+func (h *LabelHandler) respondError(c *gin.Context, message string, err error) {
+	if errors.Is(err, labels.ErrInvalidLabel) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	logger.Error(message, zap.Error(err))
+	c.JSON(errorStatus(err), ErrorResponse{
+		Error:   message,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// LiquidationHandler handles liquidation reports for the fast-path rescore and its SLA
+type LiquidationHandler struct {
+	service *service.OracleService
+}
+
+// NewLiquidationHandler creates a new liquidation handler
+func NewLiquidationHandler(service *service.OracleService) *LiquidationHandler {
+	return &LiquidationHandler{
+		service: service,
+	}
+}
+
+// ReportLiquidationRequest reports a liquidation detected outside the oracle, e.g. by
+// a chain listener
+type ReportLiquidationRequest struct {
+	Address    string    `json:"address" binding:"required"`
+	Protocol   string    `json:"protocol"`
+	TxHash     string    `json:"tx_hash"`
+	DetectedAt time.Time `json:"detected_at"` // When the liquidation was detected; defaults to now
+}
+
+// ReportLiquidation queues a liquidated address for the fast path
+// @Summary Report liquidation
+// @Description Queue a liquidated address for a fast-path rescore, which recalculates its score from the providers and publishes it outside the scheduler and publish window. An address with a rescore already waiting returns that rescore.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body ReportLiquidationRequest true "Liquidation"
+// @Success 202 {object} models.LiquidationRescore
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/liquidations [post]
+func (h *LiquidationHandler) ReportLiquidation(c *gin.Context) {
+	var req ReportLiquidationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+	if !resolveAddresses(c, &req.Address) {
+		return
+	}
+
+	rescore, err := h.service.ReportLiquidation(c.Request.Context(), service.LiquidationReport{
+		Address:    req.Address,
+		Protocol:   req.Protocol,
+		TxHash:     req.TxHash,
+		Source:     models.LiquidationSourceReported,
+		DetectedAt: req.DetectedAt,
+	})
+	if err != nil {
+		h.respondError(c, "Failed to report liquidation", err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, rescore)
+}
+
+// GetSLA reports the fast path's detection-to-publish latency
+// @Summary Get liquidation rescore SLA
+// @Description Get fast-path rescores detected over a recent window with their detection-to-publish latency, and how many were published within the SLA
+// @Tags admin
+// @Produce json
+// @Param hours query int false "Window in hours" default(24)
+// @Success 200 {object} service.LiquidationSLAReport
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/liquidations/sla [get]
+func (h *LiquidationHandler) GetSLA(c *gin.Context) {
+	hours, err := strconv.Atoi(c.DefaultQuery("hours", "24"))
+	if err != nil || hours < 1 {
+		hours = 24
+	}
+
+	report, err := h.service.LiquidationSLA(c.Request.Context(), time.Duration(hours)*time.Hour)
+	if err != nil {
+		h.respondError(c, "Failed to retrieve liquidation SLA", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *LiquidationHandler) respondError(c *gin.Context, message string, err error) {
+	if errorStatus(err) >= http.StatusInternalServerError {
+		logger.Error(message, zap.Error(err))
+	}
+	c.JSON(errorStatus(err), ErrorResponse{
+		Error:   message,
+		Message: err.Error(),
+	})
+}
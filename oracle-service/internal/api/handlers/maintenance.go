@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+)
+
+// maintenanceChecker reports whether the API is in maintenance mode
+type maintenanceChecker interface {
+	Maintenance(ctx context.Context) service.MaintenanceStatus
+}
+
+// Maintenance makes the API read-only while maintenance mode is on: requests other
+// than GET, HEAD and OPTIONS are rejected with 503 and a Retry-After header. Requests
+// with one of the admin keys and routes under any of the exempt path prefixes, such
+// as the admin routes that end maintenance, are always served.
+func Maintenance(checker maintenanceChecker, retryAfter time.Duration, adminKeys []string, exempt ...string) gin.HandlerFunc {
+	retrySecs := int(retryAfter.Seconds())
+	if retrySecs < 1 {
+		retrySecs = 1
+	}
+
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		if isAdmin(c, adminKeys) {
+			c.Next()
+			return
+		}
+		for _, prefix := range exempt {
+			if strings.HasPrefix(c.FullPath(), prefix) {
+				c.Next()
+				return
+			}
+		}
+		if !checker.Maintenance(c.Request.Context()).Enabled {
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", strconv.Itoa(retrySecs))
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   tr(c, "Service under maintenance"),
+			Message: tr(c, "The API is read-only during maintenance"),
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// prometheusContentType is the Prometheus text exposition format
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// MetricsHandler exposes the score pipeline's latency for monitoring
+type MetricsHandler struct {
+	service *service.OracleService
+}
+
+// NewMetricsHandler creates a new metrics handler
+func NewMetricsHandler(service *service.OracleService) *MetricsHandler {
+	return &MetricsHandler{
+		service: service,
+	}
+}
+
+// GetPipelineSLO reports the pipeline stage latencies against their objectives
+// @Summary Get pipeline latency SLO
+// @Description Get the p50, p95 and p99 latency of each score pipeline stage and the full update over the SLO window, and whether each meets its p95 objective
+// @Tags admin
+// @Produce json
+// @Success 200 {object} service.PipelineSLOReport
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/pipeline/slo [get]
+func (h *MetricsHandler) GetPipelineSLO(c *gin.Context) {
+	report, err := h.service.PipelineSLO(c.Request.Context())
+	if err != nil {
+		logger.Error("Failed to retrieve pipeline SLO", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to retrieve pipeline SLO"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetMetrics serves the pipeline latency SLO in the Prometheus text format
+// @Summary Prometheus metrics
+// @Description Pipeline stage latency quantiles, objectives and compliance over the SLO window, in the Prometheus text exposition format
+// @Tags health
+// @Produce plain
+// @Success 200 {string} string
+// @Failure 500 {string} string
+// @Router /metrics [get]
+func (h *MetricsHandler) GetMetrics(c *gin.Context) {
+	report, err := h.service.PipelineSLO(c.Request.Context())
+	if err != nil {
+		logger.Error("Failed to retrieve pipeline SLO", zap.Error(err))
+		c.String(errorStatus(err), "failed to retrieve pipeline SLO\n")
+		return
+	}
+
+	c.Data(http.StatusOK, prometheusContentType, []byte(formatPipelineMetrics(report)))
+}
+
+// formatPipelineMetrics renders the report as gauges, since the quantiles are computed
+// over the SLO window rather than accumulated by the process
+func formatPipelineMetrics(report *service.PipelineSLOReport) string {
+	var b strings.Builder
+	seconds := func(ms int64) float64 { return float64(ms) / 1000 }
+
+	gauge(&b, "oracle_pipeline_calculations", "Score calculations started within the SLO window")
+	fmt.Fprintf(&b, "oracle_pipeline_calculations{status=\"succeeded\"} %d\n", report.Calculations-report.Failed)
+	fmt.Fprintf(&b, "oracle_pipeline_calculations{status=\"failed\"} %d\n", report.Failed)
+
+	gauge(&b, "oracle_pipeline_stage_calculations", "Calculations within the SLO window that ran the stage")
+	for _, stage := range report.Stages {
+		fmt.Fprintf(&b, "oracle_pipeline_stage_calculations{stage=%q} %d\n", stage.Stage, stage.Count)
+	}
+
+	gauge(&b, "oracle_pipeline_stage_latency_seconds", "Stage latency quantiles over the SLO window")
+	for _, stage := range report.Stages {
+		if stage.Count == 0 {
+			continue
+		}
+		for _, q := range []struct {
+			quantile string
+			ms       int64
+		}{{"0.5", stage.P50Ms}, {"0.95", stage.P95Ms}, {"0.99", stage.P99Ms}} {
+			fmt.Fprintf(&b, "oracle_pipeline_stage_latency_seconds{stage=%q,quantile=%q} %g\n", stage.Stage, q.quantile, seconds(q.ms))
+		}
+	}
+
+	gauge(&b, "oracle_pipeline_slo_objective_seconds", "p95 latency objective of the stage")
+	for _, stage := range report.Stages {
+		if stage.ObjectiveMs > 0 {
+			fmt.Fprintf(&b, "oracle_pipeline_slo_objective_seconds{stage=%q} %g\n", stage.Stage, seconds(stage.ObjectiveMs))
+		}
+	}
+
+	gauge(&b, "oracle_pipeline_slo_within_objective_ratio", "Share of the stage's calculations at or under its objective")
+	for _, stage := range report.Stages {
+		if stage.ObjectiveMs > 0 && stage.Count > 0 {
+			fmt.Fprintf(&b, "oracle_pipeline_slo_within_objective_ratio{stage=%q} %g\n", stage.Stage, float64(stage.WithinTarget)/float64(stage.Count))
+		}
+	}
+
+	gauge(&b, "oracle_pipeline_slo_compliant", "1 if the stage's p95 latency is at or under its objective")
+	for _, stage := range report.Stages {
+		if stage.Compliant != nil {
+			compliant := 0
+			if *stage.Compliant {
+				compliant = 1
+			}
+			fmt.Fprintf(&b, "oracle_pipeline_slo_compliant{stage=%q} %d\n", stage.Stage, compliant)
+		}
+	}
+
+	return b.String()
+}
+
+func gauge(b *strings.Builder, name, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
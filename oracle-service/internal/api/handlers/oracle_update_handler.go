@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Batch publish request limits
+const (
+	defaultPublishBatchLimit = 100
+	maxPublishBatchSize      = 1000
+)
+
+// OracleUpdateHandler handles publishing scores to the oracle contract
+type OracleUpdateHandler struct {
+	service      *service.OracleService
+	environments map[string]*service.EnhancedOracleService // Non-production environments, e.g. the sandbox
+}
+
+// NewOracleUpdateHandler creates a new oracle update handler
+func NewOracleUpdateHandler(service *service.OracleService) *OracleUpdateHandler {
+	return &OracleUpdateHandler{
+		service: service,
+	}
+}
+
+// AddEnvironment includes a non-production environment, such as the sandbox, in the
+// oracle identity report
+func (h *OracleUpdateHandler) AddEnvironment(name string, svc *service.EnhancedOracleService) {
+	if h.environments == nil {
+		h.environments = make(map[string]*service.EnhancedOracleService)
+	}
+	h.environments[name] = svc
+}
+
+// PublishBatchRequest represents a request to publish many scores at once
+type PublishBatchRequest struct {
+	Addresses []string `json:"addresses"` // Publish these addresses or DIDs; empty publishes unpublished scores
+	Limit     int      `json:"limit"`     // Maximum unpublished scores to publish when no addresses are given
+	Urgent    bool     `json:"urgent"`    // Publish now even if the publish window is closed
+}
+
+// OracleIdentityResponse represents the signer and contracts of every environment
+type OracleIdentityResponse struct {
+	Contracts []*service.ContractIdentity `json:"contracts"`
+}
+
+// ListOracleUpdatesResponse represents a list of oracle updates
+type ListOracleUpdatesResponse struct {
+	Updates       []*models.OracleUpdate      `json:"updates"`
+	Count         int                         `json:"count"`
+	PublishWindow service.PublishWindowStatus `json:"publish_window"`
+}
+
+// ListOracleUpdates lists oracle updates, such as publications queued for the publish window
+// @Summary List oracle updates
+// @Description List oracle updates filtered by status (queued, pending, confirmed, failed) along with the current publish window
+// @Tags oracle
+// @Accept json
+// @Produce json
+// @Param status query string false "Update status"
+// @Param limit query int false "Number of records to return" default(100)
+// @Success 200 {object} ListOracleUpdatesResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/oracle-updates [get]
+func (h *OracleUpdateHandler) ListOracleUpdates(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || limit < 1 || limit > maxPublishBatchSize {
+		limit = defaultPublishBatchLimit
+	}
+
+	updates, err := h.service.ListOracleUpdates(c.Request.Context(), c.Query("status"), limit)
+	if err != nil {
+		logger.Error("Failed to list oracle updates", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   "Failed to list oracle updates",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListOracleUpdatesResponse{
+		Updates:       updates,
+		Count:         len(updates),
+		PublishWindow: h.service.PublishWindowStatus(c.Request.Context()),
+	})
+}
+
+// PublishBatch publishes many scores in as few transactions as possible
+// @Summary Publish scores in batch
+// @Description Publish the given addresses' scores, or pending unpublished scores, using the contract's batch update when available. Non-urgent scores are queued while the publish window is closed.
+// @Tags oracle
+// @Accept json
+// @Produce json
+// @Param request body PublishBatchRequest false "Addresses to publish"
+// @Success 200 {object} service.BatchPublishResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/oracle-updates/publish-batch [post]
+func (h *OracleUpdateHandler) PublishBatch(c *gin.Context) {
+	var req PublishBatchRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logger.Error("Invalid request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	if len(req.Addresses) > maxPublishBatchSize {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: "too many addresses in one batch",
+		})
+		return
+	}
+	for i := range req.Addresses {
+		if !resolveAddresses(c, &req.Addresses[i]) {
+			return
+		}
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultPublishBatchLimit
+	}
+	if limit > maxPublishBatchSize {
+		limit = maxPublishBatchSize
+	}
+
+	result, err := h.service.PublishBatch(c.Request.Context(), req.Addresses, limit, req.Urgent)
+	if err != nil {
+		logger.Error("Failed to publish score batch", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   "Failed to publish score batch",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetCanaryReport compares canary and primary contract publications
+// @Summary Get canary publishing report
+// @Description Compare publication success on the canary contract with the primary contract over a recent window, before cutting over to the canary
+// @Tags oracle
+// @Accept json
+// @Produce json
+// @Param hours query int false "Window in hours" default(24)
+// @Success 200 {object} service.CanaryReport
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/oracle-updates/canary [get]
+func (h *OracleUpdateHandler) GetCanaryReport(c *gin.Context) {
+	hours, err := strconv.Atoi(c.DefaultQuery("hours", "24"))
+	if err != nil || hours < 1 {
+		hours = 24
+	}
+
+	report, err := h.service.CanaryReport(c.Request.Context(), time.Duration(hours)*time.Hour)
+	if err != nil {
+		logger.Error("Failed to build canary report", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   "Failed to build canary report",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetOracleIdentity reports the oracle signer and the contracts it publishes to
+// @Summary Get oracle identity
+// @Description Report, per environment and contract, the chain, the signer address derived from the private key, its nonce and native balance, and whether the contract is deployed, paused and grants the signer the oracle operator role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} OracleIdentityResponse
+// @Router /api/v1/admin/oracle-identity [get]
+func (h *OracleUpdateHandler) GetOracleIdentity(c *gin.Context) {
+	ctx := c.Request.Context()
+	contracts := h.service.OracleIdentity(ctx)
+
+	names := make([]string, 0, len(h.environments))
+	for name := range h.environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, identity := range h.environments[name].OracleIdentity(ctx) {
+			identity.Environment = name
+			contracts = append(contracts, identity)
+		}
+	}
+
+	c.JSON(http.StatusOK, OracleIdentityResponse{Contracts: contracts})
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// maxPrescreenAddresses bounds the addresses pre-screened in one request
+const maxPrescreenAddresses = 1000
+
+// PrescreenRequest represents a request to pre-screen many addresses
+type PrescreenRequest struct {
+	Addresses []string `json:"addresses" binding:"required"` // Wallet addresses or DIDs
+}
+
+// Prescreen places many addresses in quick score tiers
+// @Summary Pre-screen addresses
+// @Description Get quick tiers (A: 700+, B: 600-699, C: below 600, or unknown) for up to 1000 addresses from stored scores, or estimates from stored metrics, without pulling provider data
+// @Tags credit-score
+// @Accept json
+// @Produce json
+// @Param request body PrescreenRequest true "Addresses to pre-screen"
+// @Success 200 {object} service.PrescreenResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/credit-score/prescreen [post]
+func (h *ScoreHandler) Prescreen(c *gin.Context) {
+	var req PrescreenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   tr(c, "Invalid request"),
+			Message: trError(c, err),
+		})
+		return
+	}
+	if len(req.Addresses) > maxPrescreenAddresses {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   tr(c, "Invalid request"),
+			Message: tr(c, "too many addresses in one request"),
+		})
+		return
+	}
+	for i := range req.Addresses {
+		if !resolveAddresses(c, &req.Addresses[i]) {
+			return
+		}
+	}
+
+	result, err := h.service.Prescreen(c.Request.Context(), req.Addresses)
+	if err != nil {
+		logger.Error("Failed to pre-screen addresses", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to pre-screen addresses"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -1,17 +1,27 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/aggregator"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/config"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
 	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
 	"go.uber.org/zap"
 )
 
+// EnvironmentHeader selects the provider environment of a request
+const EnvironmentHeader = "X-Provider-Environment"
+
 // ProviderHandler handles requests related to 3rd party data providers
 type ProviderHandler struct {
-	service *service.EnhancedOracleService
+	service      *service.EnhancedOracleService
+	environments map[string]*service.EnhancedOracleService // Non-production environments, e.g. the sandbox
+	adminKeys    []string
 }
 
 // NewProviderHandler creates a new provider handler
@@ -21,45 +31,75 @@ func NewProviderHandler(service *service.EnhancedOracleService) *ProviderHandler
 	}
 }
 
+// SetAdminKeys sets the admin keys allowed to select a non-production environment.
+// Their updates are also exempt from the per-address update interval.
+func (h *ProviderHandler) SetAdminKeys(keys []string) {
+	h.adminKeys = keys
+}
+
+// AddEnvironment makes a non-production provider environment, such as the sandbox,
+// selectable per request by admins
+func (h *ProviderHandler) AddEnvironment(name string, svc *service.EnhancedOracleService) {
+	if h.environments == nil {
+		h.environments = make(map[string]*service.EnhancedOracleService)
+	}
+	h.environments[name] = svc
+}
+
 // UpdateWithProvidersRequest represents request to update score using 3rd party providers
 type UpdateWithProvidersRequest struct {
 	Address           string `json:"address" binding:"required"`
 	BureauUserID      string `json:"bureau_user_id"`     // Credit Bureau user ID (SSN or similar)
 	PlaidUserID       string `json:"plaid_user_id"`      // Plaid user identifier
 	PlaidAccessToken  string `json:"plaid_access_token"` // Plaid access token
+	EmploymentAccount string `json:"employment_account"` // Payroll provider account ID
 	Publish           bool   `json:"publish"`
+	Urgent            bool   `json:"urgent"`              // Publish now even if the publish window is closed
 	FetchCreditBureau bool   `json:"fetch_credit_bureau"` // Fetch from credit bureau
 	FetchPlaid        bool   `json:"fetch_plaid"`         // Fetch from Plaid
+	FetchEmployment   bool   `json:"fetch_employment"`    // Fetch from employment verification provider
 	FetchBlockchain   bool   `json:"fetch_blockchain"`    // Fetch from blockchain providers
 }
 
 // ProviderDataResponse shows what data was fetched from each provider
 type ProviderDataResponse struct {
-	Address      string            `json:"address"`
-	Score        uint16            `json:"score"`
-	Confidence   uint8             `json:"confidence"`
-	DataSources  []string          `json:"data_sources"`
-	CreditBureau *CreditBureauData `json:"credit_bureau,omitempty"`
-	Plaid        *PlaidData        `json:"plaid,omitempty"`
-	Blockchain   *BlockchainData   `json:"blockchain,omitempty"`
-	LastUpdated  string            `json:"last_updated"`
+	Address      string                          `json:"address"`
+	Score        units.Score                     `json:"score"`
+	Confidence   units.Confidence                `json:"confidence"`
+	DataSources  []string                        `json:"data_sources"`
+	CreditBureau *CreditBureauData               `json:"credit_bureau,omitempty"`
+	Plaid        *PlaidData                      `json:"plaid,omitempty"`
+	Employment   *EmploymentData                 `json:"employment,omitempty"`
+	Blockchain   *BlockchainData                 `json:"blockchain,omitempty"`
+	Income       *aggregator.IncomeTriangulation `json:"income,omitempty"` // Income reconciled from every source reporting one
+	LastUpdated  string                          `json:"last_updated"`
+	Environment  string                          `json:"environment"` // Provider environment the score was calculated in
 }
 
 type CreditBureauData struct {
-	CreditScore       int     `json:"credit_score"`
-	DebtToIncomeRatio float64 `json:"debt_to_income_ratio"`
-	PaymentHistory    string  `json:"payment_history"`
-	Delinquencies     int     `json:"delinquencies"`
-	Provider          string  `json:"provider"`
+	CreditScore       int           `json:"credit_score"`
+	DebtToIncomeRatio units.Decimal `json:"debt_to_income_ratio"`
+	PaymentHistory    string        `json:"payment_history"`
+	Delinquencies     int           `json:"delinquencies"`
+	Provider          string        `json:"provider"`
 }
 
 type PlaidData struct {
-	TotalBalance   float64 `json:"total_balance"`
-	AverageBalance float64 `json:"average_balance"`
-	AccountAge     int     `json:"account_age_months"`
-	IncomeVerified bool    `json:"income_verified"`
-	AnnualIncome   float64 `json:"annual_income"`
-	AccountsCount  int     `json:"accounts_count"`
+	TotalBalance   units.Decimal `json:"total_balance"`
+	AverageBalance units.Decimal `json:"average_balance"`
+	AccountAge     int           `json:"account_age_months"`
+	IncomeVerified bool          `json:"income_verified"`
+	AnnualIncome   units.Decimal `json:"annual_income"`
+	AccountsCount  int           `json:"accounts_count"`
+}
+
+type EmploymentData struct {
+	Employer         string `json:"employer"`
+	EmploymentStatus string `json:"employment_status"`
+	TenureMonths     int    `json:"employment_tenure_months"`
+	PayFrequency     string `json:"pay_frequency"`
+	Verified         bool   `json:"verified"`
+	Provider         string `json:"provider"`
 }
 
 type BlockchainData struct {
@@ -77,20 +117,37 @@ type BlockchainData struct {
 // @Accept json
 // @Produce json
 // @Param request body UpdateWithProvidersRequest true "Update request with provider options"
+// @Param X-Provider-Environment header string false "Provider environment, e.g. sandbox (admin keys only)"
+// @Param X-Admin-Key header string false "Admin API key"
 // @Success 200 {object} ProviderDataResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 423 {object} ErrorResponse
+// @Failure 429 {object} UpdateRateLimitedResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/credit-score/update-with-providers [post]
 func (h *ProviderHandler) UpdateWithProviders(c *gin.Context) {
+	environment, svc := h.environment(c)
+	if svc == nil {
+		return
+	}
+
 	var req UpdateWithProvidersRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Error("Invalid request", zap.Error(err))
 		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Invalid request",
-			Message: err.Error(),
+			Error:   tr(c, "Invalid request"),
+			Message: trError(c, err),
 		})
 		return
 	}
+	if !resolveAddresses(c, &req.Address) {
+		return
+	}
+	release, ok := reserveUpdate(c, svc, h.adminKeys, req.Address)
+	if !ok {
+		return
+	}
 
 	logger.Info("Updating credit score with providers",
 		zap.String("address", req.Address),
@@ -98,35 +155,51 @@ func (h *ProviderHandler) UpdateWithProviders(c *gin.Context) {
 		zap.String("plaidUserID", req.PlaidUserID),
 		zap.Bool("creditBureau", req.FetchCreditBureau),
 		zap.Bool("plaid", req.FetchPlaid),
+		zap.Bool("employment", req.FetchEmployment),
 		zap.Bool("blockchain", req.FetchBlockchain),
 	)
 
 	// Calculate score using selected providers
-	score, providerData, err := h.service.CalculateWithProviders(
+	score, providerData, err := svc.CalculateWithProviders(
 		c.Request.Context(),
 		req.Address,
 		req.BureauUserID,
 		req.PlaidUserID,
 		req.PlaidAccessToken,
+		req.EmploymentAccount,
 		req.FetchCreditBureau,
 		req.FetchPlaid,
+		req.FetchEmployment,
 		req.FetchBlockchain,
 	)
+	if err != nil {
+		release()
+	}
 
+	if errors.Is(err, service.ErrProfileFrozen) {
+		c.JSON(http.StatusLocked, ErrorResponse{
+			Error:   tr(c, "Profile frozen"),
+			Message: trError(c, err),
+		})
+		return
+	}
 	if err != nil {
 		logger.Error("Failed to calculate score with providers", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to calculate credit score",
-			Message: err.Error(),
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to calculate credit score"),
+			Message: trError(c, err),
 		})
 		return
 	}
 
 	// Publish to blockchain if requested
 	if req.Publish {
-		if err := h.service.PublishScoreToBlockchain(c.Request.Context(), req.Address); err != nil {
+		queued, err := svc.RequestPublish(c.Request.Context(), req.Address, req.Urgent)
+		if err != nil {
 			logger.Error("Failed to publish to blockchain", zap.Error(err))
 			// Don't fail the request, just log
+		} else if queued {
+			logger.Info("Score publish queued for publish window", zap.String("address", req.Address))
 		}
 	}
 
@@ -136,7 +209,9 @@ func (h *ProviderHandler) UpdateWithProviders(c *gin.Context) {
 		Score:       score.Score,
 		Confidence:  score.Confidence,
 		DataSources: providerData.Sources,
+		Income:      providerData.Income,
 		LastUpdated: score.LastUpdated.Format("2006-01-02T15:04:05Z"),
+		Environment: environment,
 	}
 
 	// Add provider-specific data
@@ -163,6 +238,17 @@ func (h *ProviderHandler) UpdateWithProviders(c *gin.Context) {
 		}
 	}
 
+	if providerData.EmploymentData != nil {
+		response.Employment = &EmploymentData{
+			Employer:         providerData.EmploymentData.Employer,
+			EmploymentStatus: providerData.EmploymentData.EmploymentStatus,
+			TenureMonths:     providerData.EmploymentData.TenureMonths,
+			PayFrequency:     providerData.EmploymentData.PayFrequency,
+			Verified:         providerData.EmploymentData.Verified,
+			Provider:         providerData.EmploymentData.DataSource,
+		}
+	}
+
 	if providerData.BlockchainData != nil {
 		response.Blockchain = &BlockchainData{
 			WalletAge:         providerData.BlockchainData.WalletAge,
@@ -182,13 +268,119 @@ func (h *ProviderHandler) UpdateWithProviders(c *gin.Context) {
 // @Tags providers
 // @Accept json
 // @Produce json
+// @Param X-Provider-Environment header string false "Provider environment, e.g. sandbox (admin keys only)"
+// @Param X-Admin-Key header string false "Admin API key"
 // @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Router /api/v1/providers/status [get]
 func (h *ProviderHandler) GetProviderStatus(c *gin.Context) {
-	status := h.service.GetProviderStatus(c.Request.Context())
+	_, svc := h.environment(c)
+	if svc == nil {
+		return
+	}
+
+	status := svc.GetProviderStatus(c.Request.Context())
 	c.JSON(http.StatusOK, status)
 }
 
+// RotateCredentialRequest represents a request to replace a provider's API key or secret
+type RotateCredentialRequest struct {
+	Credential string `json:"credential" binding:"required"`
+}
+
+// RotateCredentialResponse identifies the credential now in use
+type RotateCredentialResponse struct {
+	Provider    string `json:"provider"`
+	Environment string `json:"environment"`
+	Fingerprint string `json:"fingerprint"` // First 8 hex characters of the credential's SHA-256
+}
+
+// RotateCredential replaces a provider credential without a restart
+// @Summary Rotate provider credential
+// @Description Replace the credit bureau API key (credit_bureau), Plaid secret (plaid) or Covalent API key (blockchain_data). The provider must accept the new credential in a health check before it is used; otherwise the old one stays in use. Both outcomes are audited.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param name path string true "Provider name"
+// @Param request body RotateCredentialRequest true "New credential"
+// @Param X-Provider-Environment header string false "Provider environment, e.g. sandbox"
+// @Param X-Admin-Key header string true "Admin API key"
+// @Success 200 {object} RotateCredentialResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/admin/providers/{name}/credentials [post]
+func (h *ProviderHandler) RotateCredential(c *gin.Context) {
+	if !isAdmin(c, h.adminKeys) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   tr(c, "Admin key required"),
+			Message: tr(c, "Only admin keys may rotate provider credentials"),
+		})
+		return
+	}
+	environment, svc := h.environment(c)
+	if svc == nil {
+		return
+	}
+
+	var req RotateCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   tr(c, "Invalid request"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	provider := c.Param("name")
+	if err := svc.RotateProviderCredential(c.Request.Context(), provider, req.Credential, requester(c)); err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to rotate credential"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, RotateCredentialResponse{
+		Provider:    provider,
+		Environment: environment,
+		Fingerprint: providers.Fingerprint(req.Credential),
+	})
+}
+
+// environment returns the provider environment selected by the X-Provider-Environment
+// header and its service. Only admin keys may select an environment other than
+// production. If the selection is refused, it responds with an error and returns a
+// nil service.
+func (h *ProviderHandler) environment(c *gin.Context) (string, *service.EnhancedOracleService) {
+	name := c.GetHeader(EnvironmentHeader)
+	if name == "" || name == config.EnvironmentProduction {
+		return config.EnvironmentProduction, h.service
+	}
+
+	if !isAdmin(c, h.adminKeys) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   tr(c, "Admin key required"),
+			Message: tr(c, "Only admin keys may select a provider environment"),
+		})
+		return "", nil
+	}
+
+	svc, ok := h.environments[name]
+	if !ok {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   tr(c, "Unknown provider environment"),
+			Message: name,
+		})
+		return "", nil
+	}
+
+	c.Header(EnvironmentHeader, name)
+	return name, svc
+}
+
 // ListAvailableProviders returns list of available providers and their capabilities
 // @Summary List available providers
 // @Description Get list of all available 3rd party data providers
@@ -222,6 +414,22 @@ func (h *ProviderHandler) ListAvailableProviders(c *gin.Context) {
 				"requires":      "access_token",
 			},
 		},
+		"employment": []map[string]interface{}{
+			{
+				"name":          "argyle",
+				"description":   "Argyle - Payroll-connected employment verification",
+				"data_provided": []string{"employer", "employment_tenure", "pay_frequency"},
+				"available":     true,
+				"requires":      "employment_account",
+			},
+			{
+				"name":          "pinwheel",
+				"description":   "Pinwheel - Payroll-connected employment verification",
+				"data_provided": []string{"employer", "employment_tenure", "pay_frequency"},
+				"available":     true,
+				"requires":      "employment_account",
+			},
+		},
 		"blockchain": []map[string]interface{}{
 			{
 				"name":          "covalent",
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// UpdateRateLimitedResponse is returned with 429 when an address was updated too
+// recently to update again
+type UpdateRateLimitedResponse struct {
+	Error         string `json:"error"`
+	Message       string `json:"message"`
+	NextAllowedAt string `json:"next_allowed_at"`
+}
+
+// updateReserver spaces out requested updates of an address
+type updateReserver interface {
+	ReserveUpdate(ctx context.Context, address string) (time.Time, error)
+	ReleaseUpdate(address string, reserved time.Time)
+}
+
+// reserveUpdate checks that an address may be updated now, responding with 429 and
+// when the next update is allowed if it may not. Admin requests are never limited.
+// The returned release gives the reservation back and must be called if the update
+// fails before storing a score.
+func reserveUpdate(c *gin.Context, svc updateReserver, adminKeys []string, address string) (release func(), ok bool) {
+	if isAdmin(c, adminKeys) {
+		return func() {}, true
+	}
+
+	nextAllowed, err := svc.ReserveUpdate(c.Request.Context(), address)
+	if errors.Is(err, service.ErrUpdateRateLimited) {
+		retryAfter := int(math.Ceil(time.Until(nextAllowed).Seconds()))
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.JSON(http.StatusTooManyRequests, UpdateRateLimitedResponse{
+			Error:         tr(c, "Too many updates"),
+			Message:       trError(c, err),
+			NextAllowedAt: nextAllowed.UTC().Format("2006-01-02T15:04:05Z"),
+		})
+		return nil, false
+	}
+	if err != nil {
+		logger.Error("Failed to check update rate limit", zap.String("address", address), zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to update credit score"),
+			Message: trError(c, err),
+		})
+		return nil, false
+	}
+	return func() { svc.ReleaseUpdate(address, nextAllowed) }, true
+}
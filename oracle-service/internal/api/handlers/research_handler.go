@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ResearchHandler serves differentially private aggregate queries to analytics
+// partners, who authenticate with their API key (X-API-Key)
+type ResearchHandler struct {
+	service  *service.OracleService
+	partners map[string]string // Partner -> API key
+}
+
+// NewResearchHandler creates a new research handler for the partners' API keys
+func NewResearchHandler(service *service.OracleService, partners map[string]string) *ResearchHandler {
+	return &ResearchHandler{
+		service:  service,
+		partners: partners,
+	}
+}
+
+// partner returns the partner whose API key the request carries
+func (h *ResearchHandler) partner(c *gin.Context) (string, bool) {
+	key := c.GetHeader(APIKeyHeader)
+	if key == "" {
+		return "", false
+	}
+
+	// Every key is compared, so the time taken doesn't tell which partner matched
+	names := make([]string, 0, len(h.partners))
+	for name := range h.partners {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var match string
+	for _, name := range names {
+		if subtle.ConstantTimeCompare([]byte(h.partners[name]), []byte(key)) == 1 {
+			match = name
+		}
+	}
+	return match, match != ""
+}
+
+// Export runs a research query
+// @Summary Export research dataset
+// @Description Run an aggregate query for an analytics partner. Counts carry Laplace noise for differential privacy and noisy counts below the minimum bucket size are suppressed. Each query spends epsilon from the partner's daily privacy budget.
+// @Tags research
+// @Accept json
+// @Produce json
+// @Param X-API-Key header string true "Partner API key"
+// @Param query query string true "score_distribution or confidence_distribution"
+// @Success 200 {object} service.ResearchDataset
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/research/export [get]
+func (h *ResearchHandler) Export(c *gin.Context) {
+	partner, ok := h.partner(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   tr(c, "Invalid API key"),
+			Message: tr(c, "A research partner API key is required"),
+		})
+		return
+	}
+
+	dataset, err := h.service.ExportResearch(c.Request.Context(), partner, c.Query("query"), requester(c))
+	if errors.Is(err, service.ErrResearchBudgetExhausted) {
+		// Budgets reset at midnight UTC
+		now := time.Now().UTC()
+		reset := now.Truncate(24 * time.Hour).Add(24 * time.Hour)
+		c.Header("Retry-After", strconv.Itoa(int(reset.Sub(now).Seconds())+1))
+		c.JSON(http.StatusTooManyRequests, ErrorResponse{
+			Error:   tr(c, "Privacy budget exhausted"),
+			Message: trError(c, err),
+		})
+		return
+	}
+	if err != nil {
+		if !errors.Is(err, service.ErrUnknownResearchQuery) && !errors.Is(err, service.ErrResearchExportDisabled) {
+			logger.Error("Failed to export research dataset", zap.String("partner", partner), zap.Error(err))
+		}
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to export research dataset"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dataset)
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/credentials"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ResponseSignatureHeader carries the detached JWS of a signed response body
+const ResponseSignatureHeader = "X-JWS-Signature"
+
+// SetResponseSigner signs the score responses of requests made with one of apiKeys
+// (X-API-Key), so consumers that cache scores can verify them later. A nil signer
+// or no keys signs nothing.
+func (h *ScoreHandler) SetResponseSigner(signer *credentials.ResponseSigner, apiKeys []string) {
+	if signer == nil || len(apiKeys) == 0 {
+		h.signer, h.signedKeys = nil, nil
+		return
+	}
+	h.signer = signer
+	h.signedKeys = apiKeys
+}
+
+// signsResponses reports whether the request's API key asks for signed responses
+func (h *ScoreHandler) signsResponses(c *gin.Context) bool {
+	key := c.GetHeader(APIKeyHeader)
+	if h.signer == nil || key == "" {
+		return false
+	}
+	for _, k := range h.signedKeys {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// varyBySigning marks the response as depending on the API key while responses are
+// signed, so shared caches don't hand a signed response's key an unsigned copy
+func (h *ScoreHandler) varyBySigning(c *gin.Context) {
+	if h.signer != nil {
+		c.Writer.Header().Add("Vary", APIKeyHeader)
+	}
+}
+
+// signedJSON responds with data as JSON, with the body's detached JWS in
+// ResponseSignatureHeader when the request's API key asks for signed responses
+func (h *ScoreHandler) signedJSON(c *gin.Context, status int, data interface{}) {
+	if !h.signsResponses(c) {
+		c.JSON(status, data)
+		return
+	}
+
+	body, err := json.Marshal(data)
+	var signature string
+	if err == nil {
+		signature, err = h.signer.Sign(body)
+	}
+	if err != nil {
+		// A consumer relying on signatures must not be handed unsigned data
+		logger.Error("Failed to sign response", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   tr(c, "Failed to sign response"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	c.Header(ResponseSignatureHeader, signature)
+	c.Data(status, "application/json; charset=utf-8", body)
+}
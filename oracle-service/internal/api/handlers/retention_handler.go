@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// RetentionHandler handles on-demand enforcement of data retention policies
+type RetentionHandler struct {
+	service *service.RetentionService
+}
+
+// NewRetentionHandler creates a new retention handler
+func NewRetentionHandler(service *service.RetentionService) *RetentionHandler {
+	return &RetentionHandler{
+		service: service,
+	}
+}
+
+// RetentionRunResponse reports what each retention policy deleted
+type RetentionRunResponse struct {
+	Policies []*models.RetentionStat `json:"policies"`
+}
+
+// RunRetention deletes expired rows now instead of waiting for the scheduled run
+// @Summary Enforce retention policies
+// @Description Delete rows older than each configured retention policy, archiving them first when a snapshot store is configured
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} RetentionRunResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/retention/run [post]
+func (h *RetentionHandler) RunRetention(c *gin.Context) {
+	stats, err := h.service.Run(c.Request.Context())
+	if err != nil {
+		logger.Error("Failed to enforce retention policies", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   "Failed to enforce retention policies",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, RetentionRunResponse{Policies: stats})
+}
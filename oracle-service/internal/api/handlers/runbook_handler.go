@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// RunbookHandler handles operator recovery actions that each run as one audited call
+type RunbookHandler struct {
+	service *service.OracleService
+}
+
+// NewRunbookHandler creates a new runbook handler
+func NewRunbookHandler(service *service.OracleService) *RunbookHandler {
+	return &RunbookHandler{
+		service: service,
+	}
+}
+
+// RequeueFailedPublishesRequest selects the failed publishes to retry
+type RequeueFailedPublishesRequest struct {
+	Hours  int  `json:"hours" binding:"required,min=1,max=168"` // How far back to look for failures
+	DryRun bool `json:"dry_run"`                                // List the addresses without publishing
+}
+
+// RefreshOutageScoresRequest selects the scores calculated during a provider outage
+type RefreshOutageScoresRequest struct {
+	Provider string    `json:"provider" binding:"required"` // credit_bureau, plaid, employment, blockchain_data, blockscout or chain_index
+	From     time.Time `json:"from" binding:"required"`
+	To       time.Time `json:"to" binding:"required"`
+	Limit    int       `json:"limit"` // Scores refreshed per run; default 200, max 1000
+	DryRun   bool      `json:"dry_run"`
+}
+
+// RequeueFailedPublishes retries the publishes that failed recently
+// @Summary Requeue failed publishes
+// @Description Republish the current scores of addresses whose publish failed or was blocked within the last N hours and wasn't retried since. Scores are published now if the publish window is open and queued otherwise. The run is recorded in the audit log.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body RequeueFailedPublishesRequest true "Failures to retry"
+// @Success 200 {object} service.RunbookResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/admin/runbooks/requeue-failed-publishes [post]
+func (h *RunbookHandler) RequeueFailedPublishes(c *gin.Context) {
+	var req RequeueFailedPublishesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	window := time.Duration(req.Hours) * time.Hour
+	result, err := h.service.RequeueFailedPublishes(c.Request.Context(), window, req.DryRun, requester(c))
+	if err != nil {
+		h.respondError(c, "Failed to requeue failed publishes", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RefreshOutageScores refreshes the scores calculated during a provider outage
+// @Summary Refresh scores after a provider outage
+// @Description Recalculate from fresh provider data the scores last calculated between from and to, while the provider was down, and publish them. An off-chain provider's outage only selects addresses with off-chain data. A truncated run can be repeated to refresh the rest. The run is recorded in the audit log.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body RefreshOutageScoresRequest true "Outage window"
+// @Success 200 {object} service.RunbookResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/runbooks/refresh-outage-scores [post]
+func (h *RunbookHandler) RefreshOutageScores(c *gin.Context) {
+	var req RefreshOutageScoresRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.service.RefreshOutageScores(c.Request.Context(), req.Provider, req.From, req.To, req.Limit, req.DryRun, requester(c))
+	if err != nil {
+		h.respondError(c, "Failed to refresh outage scores", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RecomputeStats recomputes the materialized stats
+// @Summary Recompute stats
+// @Description Recompute the materialized dashboard stats now and return them. The run is recorded in the audit log.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} service.RunbookResult
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/runbooks/recompute-stats [post]
+func (h *RunbookHandler) RecomputeStats(c *gin.Context) {
+	result, err := h.service.RecomputeStats(c.Request.Context(), requester(c))
+	if err != nil {
+		h.respondError(c, "Failed to recompute stats", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *RunbookHandler) respondError(c *gin.Context, message string, err error) {
+	if errorStatus(err) >= http.StatusInternalServerError {
+		logger.Error(message, zap.Error(err))
+	}
+	c.JSON(errorStatus(err), ErrorResponse{
+		Error:   message,
+		Message: err.Error(),
+	})
+}
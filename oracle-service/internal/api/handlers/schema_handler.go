@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/schemas"
+)
+
+// SchemaHandler serves the JSON Schemas of webhook and event payloads
+type SchemaHandler struct{}
+
+// NewSchemaHandler creates a new schema handler
+func NewSchemaHandler() *SchemaHandler {
+	return &SchemaHandler{}
+}
+
+// ListSchemasResponse represents every published payload schema
+type ListSchemasResponse struct {
+	Schemas []*schemas.Entry `json:"schemas"`
+	Count   int              `json:"count"`
+}
+
+// ListSchemas lists the payload schemas
+// @Summary List payload schemas
+// @Description List every version of the JSON Schemas of webhook and message bus event payloads. Published versions never change; payloads are sent as the current version of their type.
+// @Tags schemas
+// @Produce json
+// @Success 200 {object} ListSchemasResponse
+// @Router /api/v1/schemas [get]
+func (h *SchemaHandler) ListSchemas(c *gin.Context) {
+	entries := schemas.All()
+	c.JSON(http.StatusOK, ListSchemasResponse{
+		Schemas: entries,
+		Count:   len(entries),
+	})
+}
+
+// GetSchema gets one version of a payload schema
+// @Summary Get a payload schema
+// @Description Get a JSON Schema document by channel (webhook or event), event type and version (v1, v2, ...)
+// @Tags schemas
+// @Produce json
+// @Param channel path string true "Channel: webhook or event"
+// @Param type path string true "Event type, e.g. score.changed"
+// @Param version path string true "Schema version, e.g. v1"
+// @Success 200 {object} schemas.Schema
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/schemas/{channel}/{type}/{version} [get]
+func (h *SchemaHandler) GetSchema(c *gin.Context) {
+	version, err := strconv.Atoi(strings.TrimPrefix(c.Param("version"), "v"))
+	var entry *schemas.Entry
+	if err == nil {
+		entry = schemas.Get(c.Param("channel"), c.Param("type"), version)
+	}
+	if entry == nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   tr(c, "Not found"),
+			Message: tr(c, "No schema found for this channel, type and version"),
+		})
+		return
+	}
+
+	c.Header("Content-Type", "application/schema+json")
+	c.JSON(http.StatusOK, entry.Schema)
+}
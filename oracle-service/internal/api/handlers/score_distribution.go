@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// GetScoreDistribution returns the anonymized distribution of credit scores
+// @Summary Get public score distribution
+// @Description Get the number of active scores per 50-point bucket, the average confidence and the addresses published per chain. Groups of fewer than min_group_size addresses are suppressed; no per-address data is returned.
+// @Tags public
+// @Accept json
+// @Produce json
+// @Success 200 {object} service.ScoreDistribution
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/public/score-distribution [get]
+func (h *ScoreHandler) GetScoreDistribution(c *gin.Context) {
+	distribution, err := h.service.GetScoreDistribution(c.Request.Context())
+	if err != nil {
+		if !errors.Is(err, service.ErrDistributionDisabled) {
+			logger.Error("Failed to get score distribution", zap.Error(err))
+		}
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to get score distribution"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	if cacheable(c, distribution.ComputedAt) {
+		return
+	}
+
+	c.JSON(http.StatusOK, distribution)
+}
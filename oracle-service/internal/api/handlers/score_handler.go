@@ -1,18 +1,28 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/credentials"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
 	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
 	"go.uber.org/zap"
 )
 
 // ScoreHandler handles credit score API requests
 type ScoreHandler struct {
-	service *service.OracleService
+	service    *service.OracleService
+	adminKeys  []string
+	signer     *credentials.ResponseSigner // Signs score responses for signedKeys
+	signedKeys []string
 }
 
 // NewScoreHandler creates a new score handler
@@ -22,6 +32,12 @@ func NewScoreHandler(service *service.OracleService) *ScoreHandler {
 	}
 }
 
+// SetAdminKeys sets the admin keys whose updates are exempt from the per-address
+// update interval
+func (h *ScoreHandler) SetAdminKeys(keys []string) {
+	h.adminKeys = keys
+}
+
 // GetCreditScoreRequest represents the request to get a credit score
 type GetCreditScoreRequest struct {
 	Address string `uri:"address" binding:"required"`
@@ -29,32 +45,78 @@ type GetCreditScoreRequest struct {
 
 // UpdateCreditScoreRequest represents the request to update a credit score
 type UpdateCreditScoreRequest struct {
-	Address string `json:"address" binding:"required"`
+	Address string `json:"address" binding:"required"` // Wallet address, did:pkh or did:ethr
 	UserID  string `json:"user_id"`
 	Publish bool   `json:"publish"`
+	Urgent  bool   `json:"urgent"` // Publish now even if the publish window is closed
+}
+
+// PreviewCreditScoreRequest represents the request to preview a credit score update
+type PreviewCreditScoreRequest struct {
+	Address string `json:"address" binding:"required"` // Wallet address, did:pkh or did:ethr
+	UserID  string `json:"user_id"`
 }
 
-// GetCreditScoreResponse represents the credit score response
+// GetCreditScoreResponse represents the credit score response. Provisional scores,
+// based on too little data to be meaningful, carry the next steps to a final score
+// instead of the score values.
 type GetCreditScoreResponse struct {
-	Address       string `json:"address"`
-	Score         uint16 `json:"score"`
-	Confidence    uint8  `json:"confidence"`
-	OnChainScore  uint16 `json:"on_chain_score"`
-	OffChainScore uint16 `json:"off_chain_score"`
-	HybridScore   uint16 `json:"hybrid_score"`
-	DataHash      string `json:"data_hash"`
-	LastUpdated   string `json:"last_updated"`
-	NextUpdateDue string `json:"next_update_due"`
-	UpdateCount   uint32 `json:"update_count"`
+	Address       string             `json:"address"`
+	Score         units.Score        `json:"score,omitempty"`
+	Confidence    units.Confidence   `json:"confidence"`
+	OnChainScore  units.Score        `json:"on_chain_score,omitempty"`
+	OffChainScore units.Score        `json:"off_chain_score,omitempty"`
+	HybridScore   units.Score        `json:"hybrid_score,omitempty"`
+	Provisional   bool               `json:"provisional"`
+	NextSteps     []scoring.NextStep `json:"next_steps,omitempty"` // Any one of these makes the score final
+	DataHash      string             `json:"data_hash"`
+	LastUpdated   string             `json:"last_updated"`
+	NextUpdateDue string             `json:"next_update_due"`
+	UpdateCount   uint32             `json:"update_count"`
+	PublishStatus string             `json:"publish_status"` // never_published, pending, on_chain or stale_on_chain
+
+	// AppliedPolicies are the tenant's floors and ceilings that changed the score
+	AppliedPolicies []scoring.AppliedClamp `json:"applied_policies,omitempty"`
+}
+
+func newCreditScoreResponse(c *gin.Context, score *models.CreditScore) GetCreditScoreResponse {
+	response := GetCreditScoreResponse{
+		Address:       score.UserAddress,
+		Score:         score.Score,
+		Confidence:    score.Confidence,
+		OnChainScore:  score.OnChainScore,
+		OffChainScore: score.OffChainScore,
+		HybridScore:   score.HybridScore,
+		Provisional:   score.Provisional,
+		DataHash:      score.DataHash,
+		LastUpdated:   score.LastUpdated.Format("2006-01-02T15:04:05Z"),
+		NextUpdateDue: score.NextUpdateDue.Format("2006-01-02T15:04:05Z"),
+		UpdateCount:   score.UpdateCount,
+		PublishStatus: score.PublishStatus,
+	}
+	if !score.Provisional {
+		return response
+	}
+
+	response.Score, response.OnChainScore, response.OffChainScore, response.HybridScore = 0, 0, 0, 0
+	if score.MissingData != "" {
+		response.NextSteps = scoring.NextStepsFor(strings.Split(score.MissingData, ","))
+		for i := range response.NextSteps {
+			response.NextSteps[i].Description = tr(c, response.NextSteps[i].Description)
+		}
+	}
+	return response
 }
 
 // GetCreditScore retrieves a credit score for an address
 // @Summary Get credit score
-// @Description Get the current credit score for a blockchain address
+// @Description Get the current credit score for a blockchain address, clamped by the tenant's score policy. Responses to API keys configured for signing carry the body's detached JWS (ES256K, oracle key) in X-JWS-Signature.
 // @Tags credit-score
 // @Accept json
 // @Produce json
 // @Param address path string true "Blockchain address"
+// @Param X-Tenant-ID header string false "Tenant whose score policy applies"
+// @Param X-API-Key header string false "API key, as forwarded by the API gateway"
 // @Success 200 {object} GetCreditScoreResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -64,8 +126,8 @@ func (h *ScoreHandler) GetCreditScore(c *gin.Context) {
 	if err := c.ShouldBindUri(&req); err != nil {
 		logger.Error("Invalid request", zap.Error(err))
 		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Invalid request",
-			Message: err.Error(),
+			Error:   tr(c, "Invalid request"),
+			Message: trError(c, err),
 		})
 		return
 	}
@@ -73,46 +135,48 @@ func (h *ScoreHandler) GetCreditScore(c *gin.Context) {
 	score, err := h.service.GetScore(c.Request.Context(), req.Address)
 	if err != nil {
 		logger.Error("Failed to get credit score", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to retrieve credit score",
-			Message: err.Error(),
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to retrieve credit score"),
+			Message: trError(c, err),
 		})
 		return
 	}
 
 	if score == nil {
 		c.JSON(http.StatusNotFound, ErrorResponse{
-			Error:   "Credit score not found",
-			Message: "No credit score exists for this address",
+			Error:   tr(c, "Credit score not found"),
+			Message: tr(c, "No credit score exists for this address"),
 		})
 		return
 	}
 
-	response := GetCreditScoreResponse{
-		Address:       score.UserAddress,
-		Score:         score.Score,
-		Confidence:    score.Confidence,
-		OnChainScore:  score.OnChainScore,
-		OffChainScore: score.OffChainScore,
-		HybridScore:   score.HybridScore,
-		DataHash:      score.DataHash,
-		LastUpdated:   score.LastUpdated.Format("2006-01-02T15:04:05Z"),
-		NextUpdateDue: score.NextUpdateDue.Format("2006-01-02T15:04:05Z"),
-		UpdateCount:   score.UpdateCount,
+	score, applied, ok := h.tenantScore(c, score)
+	if !ok {
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.varyBySigning(c)
+	if cacheable(c, score.LastUpdated) {
+		return
+	}
+
+	response := newCreditScoreResponse(c, score)
+	response.AppliedPolicies = applied
+	h.signedJSON(c, http.StatusOK, response)
 }
 
 // UpdateCreditScore calculates and updates a credit score
 // @Summary Update credit score
-// @Description Calculate and update credit score for an address
+// @Description Calculate and update credit score for an address, returned clamped by the tenant's score policy
 // @Tags credit-score
 // @Accept json
 // @Produce json
+// @Param X-Tenant-ID header string false "Tenant whose score policy applies"
 // @Param request body UpdateCreditScoreRequest true "Update request"
 // @Success 200 {object} GetCreditScoreResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 423 {object} ErrorResponse
+// @Failure 429 {object} UpdateRateLimitedResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/credit-score/update [post]
 func (h *ScoreHandler) UpdateCreditScore(c *gin.Context) {
@@ -120,61 +184,393 @@ func (h *ScoreHandler) UpdateCreditScore(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Error("Invalid request", zap.Error(err))
 		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Invalid request",
-			Message: err.Error(),
+			Error:   tr(c, "Invalid request"),
+			Message: trError(c, err),
 		})
 		return
 	}
+	if !resolveAddresses(c, &req.Address) {
+		return
+	}
+	release, ok := reserveUpdate(c, h.service, h.adminKeys, req.Address)
+	if !ok {
+		return
+	}
 
 	// Calculate and update score
 	score, err := h.service.CalculateAndUpdateScore(c.Request.Context(), req.Address, req.UserID)
+	if err != nil {
+		release()
+	}
+	if errors.Is(err, service.ErrProfileFrozen) {
+		c.JSON(http.StatusLocked, ErrorResponse{
+			Error:   tr(c, "Profile frozen"),
+			Message: trError(c, err),
+		})
+		return
+	}
 	if err != nil {
 		logger.Error("Failed to update credit score", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to update credit score",
-			Message: err.Error(),
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to update credit score"),
+			Message: trError(c, err),
 		})
 		return
 	}
 
 	// Publish to blockchain if requested
 	if req.Publish {
-		if err := h.service.PublishScoreToBlockchain(c.Request.Context(), req.Address); err != nil {
+		queued, err := h.service.RequestPublish(c.Request.Context(), req.Address, req.Urgent)
+		if err != nil {
 			logger.Error("Failed to publish to blockchain", zap.Error(err))
 			// Don't fail the request, just log the error
+		} else if queued {
+			logger.Info("Score publish queued for publish window", zap.String("address", req.Address))
 		}
 	}
 
-	response := GetCreditScoreResponse{
-		Address:       score.UserAddress,
-		Score:         score.Score,
-		Confidence:    score.Confidence,
-		OnChainScore:  score.OnChainScore,
-		OffChainScore: score.OffChainScore,
-		HybridScore:   score.HybridScore,
-		DataHash:      score.DataHash,
-		LastUpdated:   score.LastUpdated.Format("2006-01-02T15:04:05Z"),
-		NextUpdateDue: score.NextUpdateDue.Format("2006-01-02T15:04:05Z"),
-		UpdateCount:   score.UpdateCount,
+	score, applied, ok := h.tenantScore(c, score)
+	if !ok {
+		return
 	}
 
+	response := newCreditScoreResponse(c, score)
+	response.AppliedPolicies = applied
 	c.JSON(http.StatusOK, response)
 }
 
+// PreviewCreditScore previews a credit score update without storing it
+// @Summary Preview credit score update
+// @Description Fetch fresh data and calculate the score an update would produce, without storing anything, next to the stored score with the changes in each component and factor
+// @Tags credit-score
+// @Accept json
+// @Produce json
+// @Param request body PreviewCreditScoreRequest true "Preview request"
+// @Success 200 {object} service.ScorePreview
+// @Failure 400 {object} ErrorResponse
+// @Failure 423 {object} ErrorResponse
+// @Failure 429 {object} UpdateRateLimitedResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/credit-score/preview [post]
+func (h *ScoreHandler) PreviewCreditScore(c *gin.Context) {
+	var req PreviewCreditScoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   tr(c, "Invalid request"),
+			Message: trError(c, err),
+		})
+		return
+	}
+	if !resolveAddresses(c, &req.Address) {
+		return
+	}
+
+	// A preview pulls from the providers like an update, so it is limited like one
+	release, ok := reserveUpdate(c, h.service, h.adminKeys, req.Address)
+	if !ok {
+		return
+	}
+
+	preview, err := h.service.PreviewScore(c.Request.Context(), req.Address, req.UserID)
+	if err != nil {
+		release()
+	}
+	if errors.Is(err, service.ErrProfileFrozen) {
+		c.JSON(http.StatusLocked, ErrorResponse{
+			Error:   tr(c, "Profile frozen"),
+			Message: trError(c, err),
+		})
+		return
+	}
+	if err != nil {
+		logger.Error("Failed to preview credit score", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to preview credit score"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// GetScoreExplanation explains how a credit score was derived
+// @Summary Get credit score explanation
+// @Description Get component scores and the adjustments applied, such as temporary-deposit discounts
+// @Tags credit-score
+// @Accept json
+// @Produce json
+// @Param address path string true "Blockchain address"
+// @Success 200 {object} scoring.Explanation
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/credit-score/{address}/explanation [get]
+func (h *ScoreHandler) GetScoreExplanation(c *gin.Context) {
+	address := c.Param("address")
+
+	explanation, err := h.service.ExplainScore(c.Request.Context(), address)
+	if err != nil {
+		logger.Error("Failed to explain credit score", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to explain credit score"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	if explanation == nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   tr(c, "Not found"),
+			Message: tr(c, "No scoring data found for this address"),
+		})
+		return
+	}
+
+	var lastUpdated time.Time
+	if score, err := h.service.GetScore(c.Request.Context(), address); err == nil && score != nil {
+		lastUpdated = score.LastUpdated
+	}
+	if cacheable(c, lastUpdated) {
+		return
+	}
+
+	c.JSON(http.StatusOK, localizeExplanation(c, explanation))
+}
+
+// GetScoreComponents retrieves the stored factor scores of a credit score
+// @Summary Get credit score components
+// @Description Get the factor scores, such as wallet age or debt-to-income, and their weights from an address's latest score calculation
+// @Tags credit-score
+// @Accept json
+// @Produce json
+// @Param address path string true "Blockchain address"
+// @Success 200 {array} models.ScoreComponent
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/credit-score/{address}/components [get]
+func (h *ScoreHandler) GetScoreComponents(c *gin.Context) {
+	address := c.Param("address")
+
+	components, err := h.service.GetScoreComponents(c.Request.Context(), address)
+	if err != nil {
+		if !errors.Is(err, service.ErrScoreNotFound) {
+			logger.Error("Failed to get score components", zap.Error(err))
+		}
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to get score components"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	if cacheable(c, components[0].CalculatedAt) {
+		return
+	}
+
+	c.JSON(http.StatusOK, components)
+}
+
+// GetScoreTrend summarizes an address's credit scores per day
+// @Summary Get credit score trend
+// @Description Get the average, lowest and highest score and the number of calculations of each UTC day an address was scored on
+// @Tags credit-score
+// @Accept json
+// @Produce json
+// @Param address path string true "Blockchain address"
+// @Param days query int false "Number of days to cover (max 365)" default(30)
+// @Success 200 {object} ScoreTrendResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/credit-score/{address}/trend [get]
+func (h *ScoreHandler) GetScoreTrend(c *gin.Context) {
+	address := c.Param("address")
+	days := trendDays(c)
+
+	trend, err := h.service.GetScoreTrend(c.Request.Context(), address, days)
+	if err != nil {
+		if !errors.Is(err, service.ErrScoreNotFound) {
+			logger.Error("Failed to get score trend", zap.Error(err))
+		}
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to get score trend"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, ScoreTrendResponse{Address: address, Days: days, Points: trend})
+}
+
+// GetPositionHistory returns an address's lending position snapshots
+// @Summary Get lending position history
+// @Description Get the supplied and borrowed value and health factor of an address's Aave and Compound positions at each scheduled snapshot
+// @Tags credit-score
+// @Accept json
+// @Produce json
+// @Param address path string true "Blockchain address"
+// @Param days query int false "Number of days to cover (max 365)" default(30)
+// @Success 200 {object} PositionHistoryResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/credit-score/{address}/positions [get]
+func (h *ScoreHandler) GetPositionHistory(c *gin.Context) {
+	address := c.Param("address")
+	days := trendDays(c)
+
+	snapshots, err := h.service.GetPositionHistory(c.Request.Context(), address, days)
+	if err != nil {
+		if !errors.Is(err, service.ErrScoreNotFound) && !errors.Is(err, service.ErrPositionSnapshotsDisabled) {
+			logger.Error("Failed to get position history", zap.Error(err))
+		}
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to get position history"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, PositionHistoryResponse{Address: address, Days: days, Snapshots: snapshots})
+}
+
+// CompareModels scores an address under several model versions
+// @Summary Compare credit score across model versions
+// @Description Calculate an address's score from its stored metrics under each requested model version, side by side with the current model. Nothing is saved or published.
+// @Tags credit-score
+// @Accept json
+// @Produce json
+// @Param address path string true "Blockchain address"
+// @Param models query string false "Comma-separated model versions (default: every registered version)"
+// @Success 200 {object} service.ScoreComparison
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/credit-score/{address}/compare [get]
+func (h *ScoreHandler) CompareModels(c *gin.Context) {
+	address := c.Param("address")
+
+	var versions []string
+	for _, version := range strings.Split(c.Query("models"), ",") {
+		if version = strings.TrimSpace(version); version != "" {
+			versions = append(versions, version)
+		}
+	}
+
+	comparison, err := h.service.CompareModels(c.Request.Context(), address, versions)
+	if err != nil {
+		if errorStatus(err) == http.StatusInternalServerError {
+			logger.Error("Failed to compare model versions", zap.Error(err))
+		}
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to compare model versions"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, comparison)
+}
+
+// GetPopulationTrend summarizes the credit scores of every address per day
+// @Summary Get population score trend
+// @Description Get the average, lowest and highest score, the number of calculations and the number of addresses scored on each UTC day
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param days query int false "Number of days to cover (max 365)" default(30)
+// @Success 200 {object} ScoreTrendResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/stats/trend [get]
+func (h *ScoreHandler) GetPopulationTrend(c *gin.Context) {
+	days := trendDays(c)
+
+	trend, err := h.service.GetPopulationTrend(c.Request.Context(), days)
+	if err != nil {
+		logger.Error("Failed to get population trend", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to get score trend"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, ScoreTrendResponse{Days: days, Points: trend})
+}
+
+// trendDays reads the days query parameter of the trend endpoints
+func trendDays(c *gin.Context) int {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 1 || days > 365 {
+		days = 30
+	}
+	return days
+}
+
+// GetPublishEstimate estimates the cost of publishing a score on-chain
+// @Summary Estimate publish cost
+// @Description Get the estimated gas, fee in native token and USD, and network congestion for publishing an address's current score
+// @Tags oracle
+// @Accept json
+// @Produce json
+// @Param address query string true "Blockchain address"
+// @Success 200 {object} service.PublishEstimate
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/oracle/publish-estimate [get]
+func (h *ScoreHandler) GetPublishEstimate(c *gin.Context) {
+	address := c.Query("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   tr(c, "Invalid request"),
+			Message: tr(c, "address query parameter is required"),
+		})
+		return
+	}
+
+	estimate, err := h.service.EstimatePublishCost(c.Request.Context(), address)
+	if errors.Is(err, service.ErrPublishEstimateUnavailable) {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   tr(c, "Blockchain client unavailable"),
+			Message: trError(c, err),
+		})
+		return
+	}
+	if err != nil {
+		logger.Error("Failed to estimate publish cost", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to estimate publish cost"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	if estimate == nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   tr(c, "Not found"),
+			Message: tr(c, "No credit score found for this address"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, estimate)
+}
+
 // GetScoreHistory retrieves credit score history
 // @Summary Get credit score history
-// @Description Get historical credit scores for an address
+// @Description Get historical credit scores for an address, streamed as JSON or CSV
 // @Tags credit-score
 // @Accept json
-// @Produce json
+// @Produce json,text/csv
 // @Param address path string true "Blockchain address"
 // @Param limit query int false "Number of records to return" default(10)
+// @Param format query string false "Response format: json or csv" default(json)
 // @Success 200 {array} ScoreHistoryResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/credit-score/{address}/history [get]
 func (h *ScoreHandler) GetScoreHistory(c *gin.Context) {
-	address := c.Param("address")
 	limitStr := c.DefaultQuery("limit", "10")
 
 	limit, err := strconv.Atoi(limitStr)
@@ -182,32 +578,228 @@ func (h *ScoreHandler) GetScoreHistory(c *gin.Context) {
 		limit = 10
 	}
 
-	history, err := h.service.GetScoreHistory(c.Request.Context(), address, limit)
-	if err != nil {
-		logger.Error("Failed to get score history", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to retrieve score history",
-			Message: err.Error(),
+	h.streamHistory(c, limit, "")
+}
+
+// ExportScoreHistory exports an address's full credit score history
+// @Summary Export credit score history
+// @Description Download every historical credit score of an address, newest first, streamed as JSON or CSV
+// @Tags credit-score
+// @Accept json
+// @Produce json,text/csv
+// @Param address path string true "Blockchain address"
+// @Param format query string false "Response format: json or csv" default(json)
+// @Success 200 {array} ScoreHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/credit-score/{address}/history/export [get]
+func (h *ScoreHandler) ExportScoreHistory(c *gin.Context) {
+	h.streamHistory(c, 0, "score-history-"+c.Param("address"))
+}
+
+// ExportScores exports every active credit score
+// @Summary Export credit scores
+// @Description Download every active credit score, oldest first, streamed as JSON or CSV
+// @Tags admin
+// @Accept json
+// @Produce json,text/csv
+// @Param format query string false "Response format: json or csv" default(json)
+// @Success 200 {array} GetCreditScoreResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/scores/export [get]
+func (h *ScoreHandler) ExportScores(c *gin.Context) {
+	format, ok := streamFormat(c)
+	if !ok {
+		return
+	}
+
+	stream := newRowStream(c, format, "credit-scores", []string{
+		"address", "score", "confidence", "on_chain_score", "off_chain_score", "hybrid_score",
+		"data_hash", "last_updated", "next_update_due", "update_count", "provisional",
+	})
+	err := h.service.ExportScores(c.Request.Context(), func(score *models.CreditScore) error {
+		response := newCreditScoreResponse(c, score)
+		return stream.Write(response, []string{
+			response.Address,
+			strconv.FormatUint(uint64(response.Score), 10),
+			strconv.FormatUint(uint64(response.Confidence), 10),
+			strconv.FormatUint(uint64(response.OnChainScore), 10),
+			strconv.FormatUint(uint64(response.OffChainScore), 10),
+			strconv.FormatUint(uint64(response.HybridScore), 10),
+			response.DataHash,
+			response.LastUpdated,
+			response.NextUpdateDue,
+			strconv.FormatUint(uint64(response.UpdateCount), 10),
+			strconv.FormatBool(response.Provisional),
 		})
+	})
+	if err != nil {
+		h.failStream(c, stream, "Failed to export credit scores", err)
+		return
+	}
+	stream.Close()
+}
+
+// streamHistory streams up to limit of an address's history records, or all of them
+// if limit is zero. Responses with a filename are sent as attachments.
+func (h *ScoreHandler) streamHistory(c *gin.Context, limit int, filename string) {
+	address := c.Param("address")
+	format, ok := streamFormat(c)
+	if !ok {
 		return
 	}
 
-	response := make([]ScoreHistoryResponse, len(history))
-	for i, h := range history {
-		response[i] = ScoreHistoryResponse{
-			Score:      h.Score,
-			Confidence: h.Confidence,
-			DataHash:   h.DataHash,
-			Timestamp:  h.Timestamp.Format("2006-01-02T15:04:05Z"),
+	stream := newRowStream(c, format, filename, []string{
+		"score", "confidence", "data_hash", "change_reason", "timestamp",
+	})
+	err := h.service.StreamScoreHistory(c.Request.Context(), address, limit, func(h *models.ScoreHistory) error {
+		// The newest record comes first and dates the whole history
+		if !stream.Started() && cacheable(c, h.Timestamp) {
+			return errNotModified
 		}
+
+		response := ScoreHistoryResponse{
+			Score:        h.Score,
+			Confidence:   h.Confidence,
+			DataHash:     h.DataHash,
+			ChangeReason: h.ChangeReason,
+			Timestamp:    h.Timestamp.Format("2006-01-02T15:04:05Z"),
+		}
+		return stream.Write(response, []string{
+			strconv.FormatUint(uint64(response.Score), 10),
+			strconv.FormatUint(uint64(response.Confidence), 10),
+			response.DataHash,
+			response.ChangeReason,
+			response.Timestamp,
+		})
+	})
+	if errors.Is(err, errNotModified) {
+		return
 	}
+	if err != nil {
+		h.failStream(c, stream, "Failed to retrieve score history", err)
+		return
+	}
+	if !stream.Started() {
+		cacheable(c, time.Time{})
+	}
+	stream.Close()
+}
 
-	c.JSON(http.StatusOK, response)
+// failStream reports an error from a streamed query. Until the first row is sent the
+// client gets an error response; after that the response can only be cut short.
+func (h *ScoreHandler) failStream(c *gin.Context, stream *rowStream, message string, err error) {
+	logger.Error(message, zap.Error(err))
+	if stream.Started() {
+		c.Abort()
+		return
+	}
+	c.JSON(errorStatus(err), ErrorResponse{
+		Error:   tr(c, message),
+		Message: trError(c, err),
+	})
+}
+
+// ScoreEventsResponse represents a page of an address's score lifecycle events
+type ScoreEventsResponse struct {
+	Events []*models.ScoreEvent `json:"events"`
+	Count  int                  `json:"count"`
+}
+
+// GetScoreEvents retrieves an address's score lifecycle event stream
+// @Summary Get score lifecycle events
+// @Description Get the append-only stream of metrics fetched, score calculated, published, disputed and overridden events for an address
+// @Tags credit-score
+// @Accept json
+// @Produce json
+// @Param address path string true "Blockchain address"
+// @Param after query int false "Return events after this sequence number" default(0)
+// @Param limit query int false "Number of events to return" default(100)
+// @Success 200 {object} ScoreEventsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/credit-score/{address}/events [get]
+func (h *ScoreHandler) GetScoreEvents(c *gin.Context) {
+	address := c.Param("address")
+
+	after, err := strconv.ParseUint(c.DefaultQuery("after", "0"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   tr(c, "Invalid request"),
+			Message: tr(c, "after must be a sequence number"),
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || limit < 1 || limit > 1000 {
+		limit = 100
+	}
+
+	events, err := h.service.GetScoreEvents(c.Request.Context(), address, after, limit)
+	if err != nil {
+		logger.Error("Failed to get score events", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to retrieve score events"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	var lastModified time.Time
+	if len(events) > 0 {
+		lastModified = events[len(events)-1].CreatedAt
+	}
+	if cacheable(c, lastModified) {
+		return
+	}
+
+	c.JSON(http.StatusOK, ScoreEventsResponse{
+		Events: events,
+		Count:  len(events),
+	})
+}
+
+// GetScoreState rebuilds an address's score state from its event stream
+// @Summary Rebuild score state from events
+// @Description Replay an address's score lifecycle events and compare the result with the stored score
+// @Tags credit-score
+// @Accept json
+// @Produce json
+// @Param address path string true "Blockchain address"
+// @Success 200 {object} service.ScoreStateReport
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/credit-score/{address}/state [get]
+func (h *ScoreHandler) GetScoreState(c *gin.Context) {
+	address := c.Param("address")
+
+	report, err := h.service.RebuildScoreState(c.Request.Context(), address)
+	if err != nil {
+		logger.Error("Failed to rebuild score state", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to rebuild score state"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	if report == nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   tr(c, "Not found"),
+			Message: tr(c, "No score events recorded for this address"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
 }
 
 // GetStats retrieves oracle service statistics
 // @Summary Get service statistics
-// @Description Get statistics about the oracle service
+// @Description Get statistics about the oracle service. Stats are precomputed periodically; computed_at shows how fresh they are.
 // @Tags admin
 // @Accept json
 // @Produce json
@@ -218,9 +810,37 @@ func (h *ScoreHandler) GetStats(c *gin.Context) {
 	stats, err := h.service.GetStats(c.Request.Context())
 	if err != nil {
 		logger.Error("Failed to get stats", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to retrieve statistics",
-			Message: err.Error(),
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to retrieve statistics"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	computedAt, _ := stats["computed_at"].(time.Time)
+	if cacheable(c, computedAt) {
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// RefreshStats recomputes the materialized statistics immediately
+// @Summary Refresh service statistics
+// @Description Recompute the dashboard statistics now instead of waiting for the next scheduled refresh
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} StatsResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/stats/refresh [post]
+func (h *ScoreHandler) RefreshStats(c *gin.Context) {
+	stats, err := h.service.RefreshStats(c.Request.Context())
+	if err != nil {
+		logger.Error("Failed to refresh stats", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to refresh statistics"),
+			Message: trError(c, err),
 		})
 		return
 	}
@@ -252,9 +872,13 @@ func (h *ScoreHandler) HealthCheck(c *gin.Context) {
 		status = http.StatusServiceUnavailable
 	}
 
+	// Paused subsystems and maintenance mode are reported but don't make the service
+	// unhealthy
 	c.JSON(status, HealthResponse{
-		Status:     map[bool]string{true: "healthy", false: "unhealthy"}[allHealthy],
-		Components: health,
+		Status:      map[bool]string{true: "healthy", false: "unhealthy"}[allHealthy],
+		Components:  health,
+		Subsystems:  h.service.SubsystemStates(c.Request.Context()),
+		Maintenance: h.service.InMaintenance(c.Request.Context()),
 	})
 }
 
@@ -266,10 +890,23 @@ type ErrorResponse struct {
 }
 
 type ScoreHistoryResponse struct {
-	Score      uint16 `json:"score"`
-	Confidence uint8  `json:"confidence"`
-	DataHash   string `json:"data_hash"`
-	Timestamp  string `json:"timestamp"`
+	Score        units.Score      `json:"score"`
+	Confidence   units.Confidence `json:"confidence"`
+	DataHash     string           `json:"data_hash"`
+	ChangeReason string           `json:"change_reason,omitempty"`
+	Timestamp    string           `json:"timestamp"`
+}
+
+type ScoreTrendResponse struct {
+	Address string                    `json:"address,omitempty"`
+	Days    int                       `json:"days"`
+	Points  []*models.ScoreTrendPoint `json:"points"` // Oldest first; days without a calculation are omitted
+}
+
+type PositionHistoryResponse struct {
+	Address   string                     `json:"address"`
+	Days      int                        `json:"days"`
+	Snapshots []*models.PositionSnapshot `json:"snapshots"` // Oldest first
 }
 
 type StatsResponse struct {
@@ -277,9 +914,14 @@ type StatsResponse struct {
 	AverageScore          float64 `json:"average_score"`
 	DueForUpdate          int64   `json:"due_for_update"`
 	PendingOracleUpdates  int64   `json:"pending_oracle_updates"`
+	QueuedOracleUpdates   int64   `json:"queued_oracle_updates"`
+	ComputedAt            string  `json:"computed_at"`  // When the aggregates were computed
+	Materialized          bool    `json:"materialized"` // Served from the periodically refreshed stats table
 }
 
 type HealthResponse struct {
-	Status     string          `json:"status"`
-	Components map[string]bool `json:"components"`
+	Status      string            `json:"status"`
+	Components  map[string]bool   `json:"components"`
+	Subsystems  map[string]string `json:"subsystems,omitempty"` // running or paused
+	Maintenance bool              `json:"maintenance"`          // Mutating endpoints are rejected
 }
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// TenantHeader identifies the tenant whose score policy applies, as forwarded by the
+// API gateway
+const TenantHeader = "X-Tenant-ID"
+
+// tenantScore clamps a score by the requesting tenant's score policy. It writes an
+// error response and returns false if the policy can't be applied.
+func (h *ScoreHandler) tenantScore(c *gin.Context, score *models.CreditScore) (*models.CreditScore, []scoring.AppliedClamp, bool) {
+	// Responses differ per tenant, so shared caches must key on the header
+	c.Writer.Header().Add("Vary", TenantHeader)
+
+	tenant := c.GetHeader(TenantHeader)
+	if tenant == "" {
+		return score, nil, true
+	}
+
+	clamped, applied, err := h.service.ApplyScorePolicy(c.Request.Context(), tenant, score)
+	if err != nil {
+		logger.Error("Failed to apply score policy", zap.String("tenant", tenant), zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to apply score policy"),
+			Message: trError(c, err),
+		})
+		return nil, nil, false
+	}
+	return clamped, applied, true
+}
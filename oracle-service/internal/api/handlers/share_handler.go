@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ShareHandler handles score share links
+type ShareHandler struct {
+	service *service.OracleService
+}
+
+// NewShareHandler creates a new share handler
+func NewShareHandler(service *service.OracleService) *ShareHandler {
+	return &ShareHandler{
+		service: service,
+	}
+}
+
+// CreateShareRequest is a wallet-signed request for a share link, signed over the
+// wallet message with action "share"
+type CreateShareRequest struct {
+	Label          string `json:"label"`            // Who the link is for, e.g. "landlord"
+	ExpiresInHours int    `json:"expires_in_hours"` // Defaults to 168 (7 days), at most 2160 (90 days)
+	Timestamp      int64  `json:"timestamp" binding:"required"`
+	Signature      string `json:"signature" binding:"required"`
+}
+
+// RevokeShareRequest is a wallet-signed request to revoke a share link, signed over
+// the wallet message with action "revoke_share:<id>"
+type RevokeShareRequest struct {
+	Timestamp int64  `json:"timestamp" binding:"required"`
+	Signature string `json:"signature" binding:"required"`
+}
+
+// AuditLogResponse represents a page of the audit log
+type AuditLogResponse struct {
+	Entries []*models.AuditLog `json:"entries"`
+	Count   int                `json:"count"`
+}
+
+// CreateShare creates a time-limited share link for a score
+// @Summary Create score share link
+// @Description Create a revocable token that lets a third party read the address's score and explanation at /api/v1/shared/{token} until it expires. The token is only returned once.
+// @Tags credit-score
+// @Accept json
+// @Produce json
+// @Param address path string true "Ethereum address"
+// @Param request body CreateShareRequest true "Signed share request"
+// @Success 201 {object} service.CreatedShare
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/credit-score/{address}/shares [post]
+func (h *ShareHandler) CreateShare(c *gin.Context) {
+	var req CreateShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   tr(c, "Invalid request"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	share, err := h.service.CreateScoreShare(
+		c.Request.Context(),
+		c.Param("address"),
+		req.Label,
+		time.Duration(req.ExpiresInHours)*time.Hour,
+		req.Timestamp,
+		req.Signature,
+		requester(c),
+	)
+	if errors.Is(err, service.ErrInvalidShareExpiry) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   tr(c, "Invalid request"),
+			Message: trError(c, err),
+		})
+		return
+	}
+	if errors.Is(err, service.ErrInvalidWalletSignature) || errors.Is(err, service.ErrStaleWalletSignature) {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   tr(c, "Invalid signature"),
+			Message: trError(c, err),
+		})
+		return
+	}
+	if err != nil {
+		logger.Error("Failed to create share link", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to create share link"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, share)
+}
+
+// RevokeShare revokes a share link
+// @Summary Revoke score share link
+// @Description Stop a share link from working before it expires
+// @Tags credit-score
+// @Accept json
+// @Produce json
+// @Param address path string true "Ethereum address"
+// @Param id path int true "Share link ID"
+// @Param request body RevokeShareRequest true "Signed revoke request"
+// @Success 200 {object} models.ScoreShare
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/credit-score/{address}/shares/{id}/revoke [post]
+func (h *ShareHandler) RevokeShare(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   tr(c, "Invalid share link ID"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	var req RevokeShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   tr(c, "Invalid request"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	share, err := h.service.RevokeScoreShare(c.Request.Context(), c.Param("address"), uint(id), req.Timestamp, req.Signature, requester(c))
+	if errors.Is(err, service.ErrInvalidWalletSignature) || errors.Is(err, service.ErrStaleWalletSignature) {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   tr(c, "Invalid signature"),
+			Message: trError(c, err),
+		})
+		return
+	}
+	if errors.Is(err, service.ErrShareNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   tr(c, "Share link not found"),
+			Message: trError(c, err),
+		})
+		return
+	}
+	if err != nil {
+		logger.Error("Failed to revoke share link", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to revoke share link"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, share)
+}
+
+// GetSharedScore returns the score behind a share link
+// @Summary Read shared score
+// @Description Read the score and explanation a borrower shared. Every access is recorded in the audit log.
+// @Tags credit-score
+// @Produce json
+// @Param token path string true "Share token"
+// @Success 200 {object} service.SharedScore
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/shared/{token} [get]
+func (h *ShareHandler) GetSharedScore(c *gin.Context) {
+	shared, err := h.service.GetSharedScore(c.Request.Context(), c.Param("token"), requester(c))
+	if errors.Is(err, service.ErrShareNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   tr(c, "Share link not found"),
+			Message: trError(c, err),
+		})
+		return
+	}
+	if errors.Is(err, service.ErrShareInactive) {
+		c.JSON(http.StatusGone, ErrorResponse{
+			Error:   tr(c, "Share link expired"),
+			Message: trError(c, err),
+		})
+		return
+	}
+	if err != nil {
+		logger.Error("Failed to read shared score", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to read shared score"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	// Every access is audited, so shared scores must not be served from a cache
+	c.Header("Cache-Control", "private, no-store")
+	shared.Explanation = localizeExplanation(c, shared.Explanation)
+	c.JSON(http.StatusOK, shared)
+}
+
+// ListAuditLog lists audit log entries
+// @Summary List audit log
+// @Description List profile freezes and share link activity, newest first
+// @Tags admin
+// @Produce json
+// @Param address query string false "Filter by user address"
+// @Param action query string false "Filter by action (e.g. share.accessed)"
+// @Param limit query int false "Maximum entries to return" default(100)
+// @Success 200 {object} AuditLogResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/audit-log [get]
+func (h *ShareHandler) ListAuditLog(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || limit < 1 || limit > 1000 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   tr(c, "Invalid limit"),
+			Message: tr(c, "limit must be between 1 and 1000"),
+		})
+		return
+	}
+
+	address := c.Query("address")
+	if !resolveAddresses(c, &address) {
+		return
+	}
+
+	entries, err := h.service.ListAuditLog(c.Request.Context(), address, c.Query("action"), limit)
+	if err != nil {
+		logger.Error("Failed to list audit log", zap.Error(err))
+		c.JSON(errorStatus(err), ErrorResponse{
+			Error:   tr(c, "Failed to list audit log"),
+			Message: trError(c, err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, AuditLogResponse{
+		Entries: entries,
+		Count:   len(entries),
+	})
+}
+
+// requester identifies the caller for the audit log
+func requester(c *gin.Context) service.Requester {
+	return service.Requester{
+		RemoteAddr: c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+		Operator:   c.GetString(adminOperatorKey),
+	}
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/snapshot"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// SnapshotHandler handles disaster recovery snapshots of the oracle's state
+type SnapshotHandler struct {
+	service *service.SnapshotService
+}
+
+// NewSnapshotHandler creates a new snapshot handler
+func NewSnapshotHandler(service *service.SnapshotService) *SnapshotHandler {
+	return &SnapshotHandler{
+		service: service,
+	}
+}
+
+// ListSnapshotsResponse represents a list of snapshots
+type ListSnapshotsResponse struct {
+	Snapshots []*snapshot.Manifest `json:"snapshots"`
+	Count     int                  `json:"count"`
+}
+
+// RestoreSnapshotRequest represents a request to restore a snapshot
+type RestoreSnapshotRequest struct {
+	Replace bool `json:"replace"` // Overwrite existing scores; without it restore only runs on an empty database
+}
+
+// CreateSnapshot exports the oracle's state to the snapshot store
+// @Summary Create snapshot
+// @Description Export scores, metrics, history, oracle updates and score events to a versioned, checksummed snapshot in object storage
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 201 {object} snapshot.Manifest
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/admin/snapshots [post]
+func (h *SnapshotHandler) CreateSnapshot(c *gin.Context) {
+	manifest, err := h.service.CreateSnapshot(c.Request.Context())
+	if err != nil {
+		h.respondError(c, "Failed to create snapshot", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, manifest)
+}
+
+// ListSnapshots lists complete snapshots
+// @Summary List snapshots
+// @Description List complete snapshots in the snapshot store, newest first
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} ListSnapshotsResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/admin/snapshots [get]
+func (h *SnapshotHandler) ListSnapshots(c *gin.Context) {
+	manifests, err := h.service.ListSnapshots(c.Request.Context())
+	if err != nil {
+		h.respondError(c, "Failed to list snapshots", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ListSnapshotsResponse{
+		Snapshots: manifests,
+		Count:     len(manifests),
+	})
+}
+
+// VerifySnapshot checks a snapshot's integrity without restoring it
+// @Summary Verify snapshot
+// @Description Read a snapshot back and check every table against the manifest checksums and row counts
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Snapshot ID"
+// @Success 200 {object} snapshot.Manifest
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/admin/snapshots/{id}/verify [get]
+func (h *SnapshotHandler) VerifySnapshot(c *gin.Context) {
+	manifest, err := h.service.VerifySnapshot(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, "Snapshot verification failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, manifest)
+}
+
+// RestoreSnapshot replaces the oracle's state with a snapshot
+// @Summary Restore snapshot
+// @Description Verify a snapshot and restore scores, metrics, history, oracle updates and score events from it. Set replace to overwrite a database that already has scores.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Snapshot ID"
+// @Param request body RestoreSnapshotRequest false "Restore options"
+// @Success 200 {object} service.SnapshotRestoreResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/admin/snapshots/{id}/restore [post]
+func (h *SnapshotHandler) RestoreSnapshot(c *gin.Context) {
+	var req RestoreSnapshotRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	result, err := h.service.RestoreSnapshot(c.Request.Context(), c.Param("id"), req.Replace)
+	if err != nil {
+		h.respondError(c, "Failed to restore snapshot", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *SnapshotHandler) respondError(c *gin.Context, message string, err error) {
+	status := errorStatus(err)
+	switch {
+	case errors.Is(err, service.ErrSnapshotsNotConfigured):
+		status = http.StatusServiceUnavailable
+	case errors.Is(err, snapshot.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, repository.ErrStateNotEmpty):
+		status = http.StatusConflict
+	case errors.Is(err, snapshot.ErrChecksumMismatch), errors.Is(err, snapshot.ErrUnsupportedVersion):
+		status = http.StatusUnprocessableEntity
+	}
+
+	logger.Error(message, zap.Error(err))
+	c.JSON(status, ErrorResponse{
+		Error:   message,
+		Message: err.Error(),
+	})
+}
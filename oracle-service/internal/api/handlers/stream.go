@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Formats of streamed responses, chosen with the format query parameter
+const (
+	StreamFormatJSON = "json"
+	StreamFormatCSV  = "csv"
+)
+
+// streamFlushRows is how many rows are written between flushes to the client
+const streamFlushRows = 100
+
+// errNotModified stops a stream whose client already has the current data
+var errNotModified = errors.New("not modified")
+
+// streamFormat returns the format a streamed response was requested in, responding
+// with 400 if it is neither json nor csv
+func streamFormat(c *gin.Context) (string, bool) {
+	format := c.DefaultQuery("format", StreamFormatJSON)
+	if format != StreamFormatJSON && format != StreamFormatCSV {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   tr(c, "Invalid request"),
+			Message: tr(c, "format must be json or csv"),
+		})
+		return "", false
+	}
+	return format, true
+}
+
+// rowStream writes a response one row at a time, as a JSON array or as CSV with a
+// header line. Nothing is sent before the first row, so a handler whose query fails
+// straight away can still respond with an error.
+type rowStream struct {
+	c        *gin.Context
+	format   string
+	filename string // Sent as an attachment filename, if set
+	columns  []string
+	csv      *csv.Writer
+	rows     int
+	started  bool
+}
+
+func newRowStream(c *gin.Context, format, filename string, columns []string) *rowStream {
+	return &rowStream{
+		c:        c,
+		format:   format,
+		filename: filename,
+		columns:  columns,
+	}
+}
+
+// Write sends one row: value as an element of the JSON array, or record as a CSV line
+func (s *rowStream) Write(value interface{}, record []string) error {
+	if err := s.start(); err != nil {
+		return err
+	}
+
+	if s.format == StreamFormatCSV {
+		if err := s.csv.Write(record); err != nil {
+			return err
+		}
+	} else {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if s.rows > 0 {
+			data = append([]byte{','}, data...)
+		}
+		if _, err := s.c.Writer.Write(data); err != nil {
+			return err
+		}
+	}
+
+	s.rows++
+	if s.rows%streamFlushRows == 0 {
+		return s.flush()
+	}
+	return nil
+}
+
+// Started reports whether the response has begun, after which an error can only cut
+// it short
+func (s *rowStream) Started() bool {
+	return s.started
+}
+
+// Close ends the response, which is an empty list if no row was written
+func (s *rowStream) Close() error {
+	if err := s.start(); err != nil {
+		return err
+	}
+	if s.format == StreamFormatJSON {
+		if _, err := s.c.Writer.WriteString("]"); err != nil {
+			return err
+		}
+	}
+	return s.flush()
+}
+
+func (s *rowStream) start() error {
+	if s.started {
+		return nil
+	}
+	s.started = true
+
+	if s.filename != "" {
+		s.c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", s.filename+"."+s.format))
+	}
+	s.c.Status(http.StatusOK)
+	if s.format == StreamFormatCSV {
+		s.c.Header("Content-Type", "text/csv; charset=utf-8")
+		s.csv = csv.NewWriter(s.c.Writer)
+		return s.csv.Write(s.columns)
+	}
+
+	s.c.Header("Content-Type", "application/json; charset=utf-8")
+	_, err := s.c.Writer.WriteString("[")
+	return err
+}
+
+func (s *rowStream) flush() error {
+	if s.csv != nil {
+		s.csv.Flush()
+		if err := s.csv.Error(); err != nil {
+			return err
+		}
+	}
+	s.c.Writer.Flush()
+	return nil
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// SubsystemHandler handles pausing and resuming subsystems at runtime
+type SubsystemHandler struct {
+	service *service.SubsystemService
+}
+
+// NewSubsystemHandler creates a new subsystem handler
+func NewSubsystemHandler(service *service.SubsystemService) *SubsystemHandler {
+	return &SubsystemHandler{
+		service: service,
+	}
+}
+
+// PauseSubsystemRequest represents a request to pause a subsystem
+type PauseSubsystemRequest struct {
+	Reason string `json:"reason"`
+}
+
+// SetMaintenanceRequest represents a request to switch maintenance mode on or off
+type SetMaintenanceRequest struct {
+	Enabled *bool  `json:"enabled" binding:"required"`
+	Reason  string `json:"reason"`
+}
+
+// ListSubsystemsResponse represents the state of every subsystem
+type ListSubsystemsResponse struct {
+	Subsystems []*models.SubsystemState `json:"subsystems"`
+}
+
+// ListSubsystems lists every subsystem and whether it is paused
+// @Summary List subsystems
+// @Description List the scheduler, publishing and each provider with whether they are paused
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} ListSubsystemsResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/subsystems [get]
+func (h *SubsystemHandler) ListSubsystems(c *gin.Context) {
+	states, err := h.service.States(c.Request.Context())
+	if err != nil {
+		h.respondError(c, "Failed to list subsystems", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ListSubsystemsResponse{Subsystems: states})
+}
+
+// PauseSubsystem pauses a subsystem until it is resumed
+// @Summary Pause subsystem
+// @Description Pause the scheduler, publishing or a provider without restarting the service. Paused providers are skipped as if unavailable.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param name path string true "Subsystem name"
+// @Param request body PauseSubsystemRequest false "Why the subsystem is paused"
+// @Success 200 {object} models.SubsystemState
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/subsystems/{name}/pause [post]
+func (h *SubsystemHandler) PauseSubsystem(c *gin.Context) {
+	var req PauseSubsystemRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	state, err := h.service.Pause(c.Request.Context(), c.Param("name"), req.Reason)
+	if err != nil {
+		h.respondError(c, "Failed to pause subsystem", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, state)
+}
+
+// ResumeSubsystem resumes a paused subsystem
+// @Summary Resume subsystem
+// @Description Resume a paused scheduler, publishing or provider
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param name path string true "Subsystem name"
+// @Success 200 {object} models.SubsystemState
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/subsystems/{name}/resume [post]
+func (h *SubsystemHandler) ResumeSubsystem(c *gin.Context) {
+	state, err := h.service.Resume(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		h.respondError(c, "Failed to resume subsystem", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, state)
+}
+
+// GetMaintenance reports whether the API is in maintenance mode
+// @Summary Get maintenance mode
+// @Description Report whether mutating endpoints are rejected for maintenance
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} service.MaintenanceStatus
+// @Router /api/v1/admin/maintenance [get]
+func (h *SubsystemHandler) GetMaintenance(c *gin.Context) {
+	c.JSON(http.StatusOK, h.service.Maintenance(c.Request.Context()))
+}
+
+// SetMaintenance switches maintenance mode on or off
+// @Summary Set maintenance mode
+// @Description Make the API read-only, rejecting mutating endpoints with 503 and Retry-After, or make it writable again. Admin endpoints stay available.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body SetMaintenanceRequest true "Maintenance mode"
+// @Success 200 {object} service.MaintenanceStatus
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/maintenance [put]
+func (h *SubsystemHandler) SetMaintenance(c *gin.Context) {
+	var req SetMaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	status, err := h.service.SetMaintenance(c.Request.Context(), *req.Enabled, req.Reason)
+	if err != nil {
+		h.respondError(c, "Failed to set maintenance mode", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+func (h *SubsystemHandler) respondError(c *gin.Context, message string, err error) {
+	logger.Error(message, zap.Error(err))
+	c.JSON(errorStatus(err), ErrorResponse{
+		Error:   message,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Endpoint classes with their own request timeout
+const (
+	TimeoutClassRead      = "read"      // Lookups served from the database
+	TimeoutClassUpdate    = "update"    // Score updates and publishes
+	TimeoutClassProviders = "providers" // Updates that call the 3rd party providers
+	TimeoutClassAdmin     = "admin"     // Snapshots, retention runs and other bulk operations
+)
+
+// Timeout bounds how long a route may take. The request context gets a deadline, so
+// provider calls, database queries and node calls made with it are cancelled when it
+// passes; if the handler has not started its response by then, whatever it writes
+// afterwards is discarded and the client gets a 504. A limit of zero disables the
+// timeout.
+func Timeout(limit time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), limit)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		writer := &timeoutWriter{
+			ResponseWriter: c.Writer,
+			ctx:            ctx,
+			header:         c.Writer.Header().Clone(),
+		}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		if !writer.expired() {
+			writer.syncHeader()
+			return
+		}
+
+		logger.Warn("Request timed out",
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.FullPath()),
+			zap.Duration("limit", limit),
+		)
+		c.AbortWithStatusJSON(http.StatusGatewayTimeout, ErrorResponse{
+			Error:   tr(c, "Request timed out"),
+			Message: tr(c, "The request took too long and was cancelled"),
+		})
+	}
+}
+
+// timeoutWriter passes a handler's response through until the request deadline. A
+// response the handler starts after the deadline is dropped, so the 504 can replace
+// it. The handler gets its own header map, copied to the response only when it is
+// sent, so headers of a dropped response don't leak into the 504.
+type timeoutWriter struct {
+	gin.ResponseWriter
+	ctx      context.Context
+	header   http.Header
+	timedOut bool
+}
+
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	if w.expired() {
+		return
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	if w.expired() {
+		return
+	}
+	w.syncHeader()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	if w.expired() {
+		return len(data), nil
+	}
+	w.syncHeader()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *timeoutWriter) Flush() {
+	if w.expired() {
+		return
+	}
+	w.syncHeader()
+	w.ResponseWriter.Flush()
+}
+
+// expired reports whether the deadline passed before the response was started
+func (w *timeoutWriter) expired() bool {
+	if !w.timedOut && !w.ResponseWriter.Written() && w.ctx.Err() == context.DeadlineExceeded {
+		w.timedOut = true
+	}
+	return w.timedOut
+}
+
+// syncHeader copies the handler's headers to the response until it is sent
+func (w *timeoutWriter) syncHeader() {
+	if w.ResponseWriter.Written() {
+		return
+	}
+	header := w.ResponseWriter.Header()
+	for key := range header {
+		if _, ok := w.header[key]; !ok {
+			header.Del(key)
+		}
+	}
+	for key, values := range w.header {
+		header[key] = values
+	}
+}
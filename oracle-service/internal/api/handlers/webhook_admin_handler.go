@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/webhooks"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// WebhookAdminHandler handles operator inspection and replay of outbound webhooks
+type WebhookAdminHandler struct {
+	service *service.WebhookService
+}
+
+// NewWebhookAdminHandler creates a new webhook admin handler
+func NewWebhookAdminHandler(service *service.WebhookService) *WebhookAdminHandler {
+	return &WebhookAdminHandler{
+		service: service,
+	}
+}
+
+// ListWebhookDeliveriesResponse represents a list of outbound webhook deliveries
+type ListWebhookDeliveriesResponse struct {
+	Deliveries []*models.WebhookDelivery `json:"deliveries"`
+	Count      int                       `json:"count"`
+}
+
+// ListDeadLettersResponse represents a list of dead-lettered webhook deliveries
+type ListDeadLettersResponse struct {
+	DeadLetters []*models.WebhookDeadLetter `json:"dead_letters"`
+	Count       int                         `json:"count"`
+}
+
+// ListDeliveries lists outbound webhook deliveries
+// @Summary List webhook deliveries
+// @Description List outbound webhook deliveries, newest first, filtered by status (delivered, retrying, dead)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param status query string false "Delivery status"
+// @Param limit query int false "Number of records to return" default(50)
+// @Success 200 {object} ListWebhookDeliveriesResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/webhooks/deliveries [get]
+func (h *WebhookAdminHandler) ListDeliveries(c *gin.Context) {
+	deliveries, err := h.service.ListDeliveries(c.Request.Context(), c.Query("status"), queryLimit(c))
+	if err != nil {
+		h.respondError(c, "Failed to list webhook deliveries", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ListWebhookDeliveriesResponse{
+		Deliveries: deliveries,
+		Count:      len(deliveries),
+	})
+}
+
+// GetDelivery retrieves an outbound webhook delivery with its attempts
+// @Summary Get webhook delivery
+// @Description Get an outbound webhook delivery with every attempt's status code and latency
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Delivery ID"
+// @Success 200 {object} service.WebhookDeliveryDetail
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/webhooks/deliveries/{id} [get]
+func (h *WebhookAdminHandler) GetDelivery(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.service.GetDelivery(c.Request.Context(), id)
+	if err != nil {
+		h.respondError(c, "Failed to retrieve webhook delivery", err)
+		return
+	}
+
+	if delivery == nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "No webhook delivery found with this ID",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+// ListDeadLetters lists dead-lettered webhook deliveries
+// @Summary List webhook dead letters
+// @Description List outbound webhook deliveries that exhausted their retries
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param include_replayed query bool false "Include dead letters that were replayed successfully"
+// @Param limit query int false "Number of records to return" default(50)
+// @Success 200 {object} ListDeadLettersResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/webhooks/dead-letters [get]
+func (h *WebhookAdminHandler) ListDeadLetters(c *gin.Context) {
+	includeReplayed := c.Query("include_replayed") == "true"
+
+	deadLetters, err := h.service.ListDeadLetters(c.Request.Context(), includeReplayed, queryLimit(c))
+	if err != nil {
+		h.respondError(c, "Failed to list webhook dead letters", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ListDeadLettersResponse{
+		DeadLetters: deadLetters,
+		Count:       len(deadLetters),
+	})
+}
+
+// ReplayDeadLetter sends a dead-lettered delivery again
+// @Summary Replay webhook dead letter
+// @Description Send a dead-lettered delivery to its endpoint again with a fresh signature
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Dead letter ID"
+// @Success 200 {object} models.WebhookDelivery
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/admin/webhooks/dead-letters/{id}/replay [post]
+func (h *WebhookAdminHandler) ReplayDeadLetter(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.service.ReplayDeadLetter(c.Request.Context(), id)
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, delivery)
+	case errors.Is(err, webhooks.ErrNotDeadLettered):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: err.Error(),
+		})
+	case errors.Is(err, service.ErrWebhooksNotConfigured):
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "Webhooks not configured",
+			Message: err.Error(),
+		})
+	case delivery != nil:
+		// The endpoint rejected the replay again
+		logger.Warn("Webhook replay failed", zap.Uint("deadLetterID", id), zap.Error(err))
+		c.JSON(http.StatusBadGateway, ErrorResponse{
+			Error:   "Replay failed",
+			Message: err.Error(),
+		})
+	default:
+		h.respondError(c, "Failed to replay webhook", err)
+	}
+}
+
+// GetStats summarizes outbound webhook deliveries
+// @Summary Get webhook delivery stats
+// @Description Get delivery counts by status, attempt latency, and pending dead letters over a recent window
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param hours query int false "Window in hours" default(24)
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/webhooks/stats [get]
+func (h *WebhookAdminHandler) GetStats(c *gin.Context) {
+	hours, err := strconv.Atoi(c.DefaultQuery("hours", "24"))
+	if err != nil || hours < 1 {
+		hours = 24
+	}
+
+	stats, err := h.service.GetStats(c.Request.Context(), time.Duration(hours)*time.Hour)
+	if err != nil {
+		h.respondError(c, "Failed to retrieve webhook stats", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func (h *WebhookAdminHandler) respondError(c *gin.Context, message string, err error) {
+	logger.Error(message, zap.Error(err))
+	c.JSON(errorStatus(err), ErrorResponse{
+		Error:   message,
+		Message: err.Error(),
+	})
+}
+
+// queryLimit parses the limit query parameter, defaulting to 50 and capping at 500
+func queryLimit(c *gin.Context) int {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 {
+		return 50
+	}
+	if limit > 500 {
+		return 500
+	}
+	return limit
+}
+
+// pathID parses the numeric id path parameter, responding with 400 when it is invalid
+func pathID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: "id must be a positive integer",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
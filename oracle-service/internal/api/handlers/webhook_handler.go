@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/webhooks"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// maxWebhookBodyBytes bounds webhook payloads read into memory
+const maxWebhookBodyBytes = 1 << 20
+
+// WebhookHandler receives pushed events from 3rd party data providers
+type WebhookHandler struct {
+	service *service.EnhancedOracleService
+	bureau  *webhooks.Receiver // nil when no bureau webhook secret is configured
+	plaid   *webhooks.Receiver // nil when no Plaid client ID is configured
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(service *service.EnhancedOracleService, bureau, plaid *webhooks.Receiver) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+		bureau:  bureau,
+		plaid:   plaid,
+	}
+}
+
+// WebhookAckResponse acknowledges a webhook delivery
+type WebhookAckResponse struct {
+	DeliveryID string `json:"delivery_id"`
+	EventType  string `json:"event_type,omitempty"`
+	Status     string `json:"status"`
+	Duplicate  bool   `json:"duplicate"` // The delivery was already processed
+}
+
+// BureauAlert receives a credit bureau monitoring alert and rescores the affected users
+// @Summary Receive credit bureau alert
+// @Description Receive a bureau monitoring alert (new delinquency, bankruptcy, ...) signed in the X-Webhook-Signature header. Users linked to the alert's consumer have their bureau data refreshed and their score recalculated. Redelivered or replayed deliveries, identified by a hash of the body, are acknowledged without reprocessing.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param X-Webhook-Signature header string true "Signature of the body"
+// @Success 200 {object} service.BureauAlertResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/webhooks/bureau-alerts [post]
+func (h *WebhookHandler) BureauAlert(c *gin.Context) {
+	body, delivery, ok := h.accept(c, h.bureau, "bureau")
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+
+	event, err := providers.ParseBureauAlert(body)
+	if err != nil {
+		logger.Error("Invalid bureau alert", zap.Error(err))
+		h.bureau.Finish(ctx, delivery, "", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid alert",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.service.HandleBureauAlert(ctx, event)
+	if finishErr := h.bureau.Finish(ctx, delivery, event.AlertType, err); finishErr != nil {
+		logger.Error("Failed to record webhook delivery", zap.Error(finishErr))
+	}
+	if err != nil {
+		logger.Error("Failed to handle bureau alert", zap.String("alertID", event.AlertID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to handle bureau alert",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// PlaidWebhook receives a Plaid item webhook
+// @Summary Receive Plaid webhook
+// @Description Receive a Plaid item webhook, verified by the ES256 JWT Plaid sends in the Plaid-Verification header. Deliveries are recorded and acknowledged once; replays are ignored.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param Plaid-Verification header string true "JWT signed by Plaid with the body's SHA-256"
+// @Success 200 {object} WebhookAckResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/webhooks/plaid [post]
+func (h *WebhookHandler) PlaidWebhook(c *gin.Context) {
+	body, delivery, ok := h.accept(c, h.plaid, "plaid")
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+
+	var webhook providers.PlaidWebhook
+	if err := json.Unmarshal(body, &webhook); err != nil || webhook.WebhookType == "" {
+		if err == nil {
+			err = errors.New("missing webhook_type")
+		}
+		h.plaid.Finish(ctx, delivery, "", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid webhook",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Received Plaid webhook",
+		zap.String("event", webhook.EventType()),
+		zap.String("itemID", webhook.ItemID),
+	)
+
+	if err := h.plaid.Finish(ctx, delivery, webhook.EventType(), nil); err != nil {
+		logger.Error("Failed to record webhook delivery", zap.Error(err))
+	}
+
+	c.JSON(http.StatusOK, WebhookAckResponse{
+		DeliveryID: delivery.DeliveryID,
+		EventType:  webhook.EventType(),
+		Status:     delivery.Status,
+	})
+}
+
+// accept reads and authenticates a webhook delivery. It writes the response itself
+// and returns ok=false when the delivery must not be processed, including when it
+// was already processed.
+func (h *WebhookHandler) accept(c *gin.Context, receiver *webhooks.Receiver, source string) ([]byte, *models.WebhookDelivery, bool) {
+	if receiver == nil {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "Webhook not configured",
+			Message: source + " webhooks are not configured",
+		})
+		return nil, nil, false
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodyBytes))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return nil, nil, false
+	}
+
+	delivery, duplicate, err := receiver.Accept(c.Request.Context(), body, c.Request.Header)
+	if err != nil {
+		if errors.Is(err, webhooks.ErrMissingSignature) || errors.Is(err, webhooks.ErrInvalidSignature) || errors.Is(err, webhooks.ErrStaleTimestamp) {
+			logger.Warn("Rejected webhook", zap.String("source", source), zap.Error(err))
+			c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Error:   "Invalid signature",
+				Message: err.Error(),
+			})
+			return nil, nil, false
+		}
+		logger.Error("Failed to accept webhook", zap.String("source", source), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to accept webhook",
+			Message: err.Error(),
+		})
+		return nil, nil, false
+	}
+
+	if duplicate {
+		c.JSON(http.StatusOK, WebhookAckResponse{
+			DeliveryID: delivery.DeliveryID,
+			EventType:  delivery.EventType,
+			Status:     delivery.Status,
+			Duplicate:  true,
+		})
+		return nil, nil, false
+	}
+
+	return body, delivery, true
+}
@@ -0,0 +1,51 @@
+package routes
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/chainindex"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/config"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/pause"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// startChainIndex starts indexing the blocks of the configured node in the background
+// and points the stack's on-chain aggregators at the index. Indexing stays disabled if
+// the node can't be reached.
+func startChainIndex(cfg *config.Config, db *gorm.DB, stack *providerStack, pauses pause.Checker) {
+	source, err := chainindex.NewRPCSource(cfg.ChainIndexRPC)
+	if err != nil {
+		logger.Error("Failed to connect to chain index node, chain index disabled", zap.Error(err))
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	chainID, err := source.ChainID(ctx)
+	cancel()
+	if err != nil {
+		logger.Error("Failed to identify chain to index, chain index disabled", zap.Error(err))
+		source.Close()
+		return
+	}
+
+	repo := repository.NewChainIndexRepository(db)
+	indexer := chainindex.NewIndexer(source, repo, chainID)
+	if cfg.ChainIndexStartBlock > 0 {
+		indexer.SetStartBlock(uint64(cfg.ChainIndexStartBlock))
+	}
+	indexer.SetConcurrency(cfg.ChainIndexWorkers, cfg.ChainIndexBatchSize)
+	indexer.SetConfirmations(uint64(cfg.ChainIndexConfirmations))
+	indexer.SetPauses(pauses)
+	go indexer.Run(context.Background(), time.Duration(cfg.ChainIndexPollSecs)*time.Second)
+
+	reader := chainindex.NewReader(repo, chainID, source)
+	stack.onChainAgg.SetChainIndex(reader)
+	stack.enhancedOnChainAgg.SetChainIndex(reader)
+	logger.Info("Indexing chain from its blocks",
+		zap.String("chain", chainID),
+		zap.Int("workers", cfg.ChainIndexWorkers),
+	)
+}
@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/config"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// newEgressTransport returns the transport a provider's calls leave the network
+// through: http.DefaultTransport, or a copy of it sending calls through egress's proxy
+// and presenting its client certificate. Without a proxy of its own, the copy still
+// honors HTTPS_PROXY and friends.
+func newEgressTransport(provider string, egress config.Egress) (http.RoundTripper, error) {
+	if egress == (config.Egress{}) {
+		return http.DefaultTransport, nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if egress.ProxyURL != "" {
+		proxyURL, err := url.Parse(egress.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s proxy URL: %w", provider, err)
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, fmt.Errorf("unsupported %s proxy scheme %q", provider, proxyURL.Scheme)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+		logger.Info("Provider calls sent through proxy",
+			zap.String("provider", provider),
+			zap.String("proxy", proxyURL.Redacted()),
+		)
+	}
+
+	if egress.TLSCertFile != "" || egress.TLSKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(egress.TLSCertFile, egress.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s client certificate: %w", provider, err)
+		}
+		transport.TLSClientConfig = &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{cert},
+		}
+	}
+
+	if egress.TLSCAFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		pem, err := os.ReadFile(egress.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s CA file: %w", provider, err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s CA file %s", provider, egress.TLSCAFile)
+		}
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	return transport, nil
+}
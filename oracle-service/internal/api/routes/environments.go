@@ -0,0 +1,296 @@
+package routes
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/aggregator"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/blockchain"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/config"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/debugtrace"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/labels"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/pause"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providerhealth"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// providerStack is the 3rd party providers and aggregators of one environment
+type providerStack struct {
+	onChainAgg          *aggregator.OnChainAggregator
+	offChainAgg         *aggregator.OffChainAggregator
+	enhancedOnChainAgg  *aggregator.EnhancedOnChainAggregator
+	enhancedOffChainAgg *aggregator.EnhancedOffChainAggregator
+	creditBureau        *providers.CreditBureauProvider
+	plaid               *providers.PlaidProvider
+	employment          *providers.EmploymentProvider
+	blockchain          *providers.BlockchainDataProvider
+	blockscout          *providers.BlockscoutProvider
+	mock                providers.MockSettings   // Which off-chain providers serve mock data
+	blockchainClient    service.BlockchainClient // Set by the caller with newPublisher; nil when publishing is not configured
+}
+
+// newProviderStack connects to an environment's providers using its credentials.
+// Providers that pauses reports as paused are not called; a nil pauses never pauses
+// them. Provider calls are recorded in health's history unless it is nil, and leave
+// through each provider's egress settings. It fails if the environment's Ethereum node
+// is unreachable or mock data or egress settings are invalid.
+func newProviderStack(
+	cfg *config.Config,
+	env config.ProviderEnvironment,
+	labelRegistry *labels.Registry,
+	tokenFilter *providers.TokenFilter,
+	bureauNormalizer *scoring.BureauNormalizer,
+	pauses pause.Checker,
+	health *providerhealth.Monitor,
+) (*providerStack, error) {
+	// Mock data is chosen per off-chain provider; on-chain data is never mocked
+	mock, err := providers.ParseMockSettings(cfg.MockData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MOCK_DATA: %w", err)
+	}
+	incomePrecedence, err := aggregator.ParseIncomePrecedence(cfg.IncomePrecedence)
+	if err != nil {
+		return nil, fmt.Errorf("invalid INCOME_PRECEDENCE: %w", err)
+	}
+
+	// Initialize basic aggregators (for fallback)
+	onChainAgg, err := aggregator.NewOnChainAggregator(env.EthereumRPC)
+	if err != nil {
+		return nil, err
+	}
+
+	stack := &providerStack{
+		mock:       mock,
+		onChainAgg: onChainAgg,
+		offChainAgg: aggregator.NewOffChainAggregator(
+			env.CreditBureauURL,
+			"", // bankAPIURL - not used in basic mode
+			env.CreditBureauAPIKey,
+		),
+		creditBureau: providers.NewCreditBureauProvider(
+			cfg.CreditBureauProvider,
+			cfg.CreditBureauRegion,
+			env.CreditBureauURL,
+			env.CreditBureauAPIKey,
+		),
+		plaid: providers.NewPlaidProvider(
+			env.PlaidClientID,
+			env.PlaidSecret,
+			env.PlaidEnv,
+		),
+		employment: providers.NewEmploymentProvider(
+			cfg.EmploymentProvider,
+			env.EmploymentAPIURL,
+			env.EmploymentAPIKey,
+		),
+		// Covalent blockchain data
+		blockchain: providers.NewBlockchainDataProvider(
+			"covalent",
+			env.CovalentBaseURL,
+			env.CovalentAPIKey,
+		),
+		blockscout: providers.NewBlockscoutProvider(
+			env.BlockscoutBaseURL,
+			env.BlockscoutChain,
+		),
+	}
+	// The basic aggregator calls the same bureau API, so a rotated key applies to both
+	stack.offChainAgg.SetAPIKey(stack.creditBureau.APIKey())
+	stack.blockchain.SetTokenFilter(tokenFilter)
+	stack.blockscout.SetTokenFilter(tokenFilter)
+
+	// Provider calls leave through the provider's proxy and client certificate, if any
+	egress := make(map[string]http.RoundTripper)
+	for _, name := range []string{pause.CreditBureau, pause.Plaid, pause.Employment, pause.BlockchainData, pause.Blockscout} {
+		transport, err := newEgressTransport(name, cfg.ProviderEgress[name])
+		if err != nil {
+			return nil, err
+		}
+		egress[name] = transport
+	}
+	stack.offChainAgg.SetTransport(egress[pause.CreditBureau])
+
+	// Provider calls of requests with debug tracing on are recorded in their trace,
+	// every call is recorded in the provider's health history, and paused providers
+	// are not called
+	pausable := func(name string, chain func(*http.Request) string) http.RoundTripper {
+		recorded := providerhealth.NewTransport(health, name, chain, debugtrace.NewTransport(egress[name]))
+		if pauses == nil {
+			return recorded
+		}
+		return pause.NewTransport(name, pauses, recorded)
+	}
+	blockscoutChain := func(*http.Request) string { return env.BlockscoutChain }
+	stack.creditBureau.SetTransport(pausable(pause.CreditBureau, nil))
+	stack.plaid.SetTransport(pausable(pause.Plaid, nil))
+	stack.employment.SetTransport(pausable(pause.Employment, nil))
+	stack.blockchain.SetTransport(pausable(pause.BlockchainData, stack.blockchain.RequestChain))
+	stack.blockscout.SetTransport(pausable(pause.Blockscout, blockscoutChain))
+
+	stack.enhancedOffChainAgg = aggregator.NewEnhancedOffChainAggregator(
+		stack.creditBureau,
+		stack.plaid,
+		bureauNormalizer,
+		mock,
+	)
+	stack.enhancedOnChainAgg = aggregator.NewEnhancedOnChainAggregator(
+		stack.blockchain,
+		stack.blockscout,
+		onChainAgg,
+		cfg.PreferBlockscout,
+		cfg.EnableMultiChain,
+		cfg.TargetChains,
+		labelRegistry,
+		tokenFilter,
+		cfg.BalanceHistoryMonths,
+	)
+	callTimeout := time.Duration(cfg.ProviderCallTimeoutSecs) * time.Second
+	stack.enhancedOffChainAgg.SetCallTimeout(callTimeout)
+	stack.enhancedOnChainAgg.SetCallTimeout(callTimeout)
+	stack.enhancedOffChainAgg.SetIncomePrecedence(incomePrecedence)
+
+	return stack, nil
+}
+
+// healthChecks are the probes of the stack's providers, with Blockscout's attributed
+// to chain
+func (s *providerStack) healthChecks(chain string) []providerhealth.Check {
+	checks := []providerhealth.Check{
+		{Provider: pause.CreditBureau, Check: s.creditBureau.HealthCheck},
+		{Provider: pause.Plaid, Check: s.plaid.HealthCheck},
+		{Provider: pause.BlockchainData, Check: s.blockchain.HealthCheck},
+		{Provider: pause.Blockscout, Chain: chain, Check: s.blockscout.HealthCheck},
+	}
+	if s.employment.IsConfigured() {
+		checks = append(checks, providerhealth.Check{Provider: pause.Employment, Check: s.employment.HealthCheck})
+	}
+	return checks
+}
+
+// newOracleClient connects to an oracle contract of the given ABI version on the
+// environment's network. It returns nil if publishing is not configured or the client
+// cannot be created.
+func newOracleClient(cfg *config.Config, env config.ProviderEnvironment, contractAddress string, contractVersion int) *blockchain.OracleClient {
+	if env.EthereumRPC == "" || contractAddress == "" || cfg.PrivateKey == "" {
+		return nil
+	}
+
+	oracleClient, err := blockchain.NewOracleClient(
+		env.EthereumRPC,
+		contractAddress,
+		cfg.PrivateKey,
+	)
+	if err != nil {
+		logger.Error("Failed to initialize blockchain client",
+			zap.String("environment", env.Name),
+			zap.String("contract", contractAddress),
+			zap.Error(err),
+		)
+		return nil
+	}
+	if contractVersion < 0 || contractVersion > math.MaxUint8 || oracleClient.SetPayloadVersion(uint8(contractVersion)) != nil {
+		logger.Error("Unsupported oracle contract version, publishing disabled",
+			zap.String("environment", env.Name),
+			zap.String("contract", contractAddress),
+			zap.Int("version", contractVersion),
+		)
+		oracleClient.Close()
+		return nil
+	}
+	oracleClient.SetBatchLimits(uint64(cfg.OracleBatchGasLimit), cfg.OracleBatchSize)
+	return oracleClient
+}
+
+// publisher is a blockchain client encoding for one oracle contract ABI version
+type publisher interface {
+	service.BlockchainClient
+	service.PayloadVersioner
+}
+
+// newPublisher returns the publisher of an oracle contract on the environment's
+// network: a client of the contract, or with the null publisher configured, a null
+// publisher recording its would-be transactions in repo without contacting the chain.
+// It returns nil if publishing is not configured or the publisher cannot be created.
+func newPublisher(cfg *config.Config, env config.ProviderEnvironment, repo *repository.ScoreRepository, contractAddress string, contractVersion int) publisher {
+	if cfg.BlockchainPublisher != config.PublisherNull {
+		// Leave the interface nil (not a typed nil pointer) when the client is unavailable
+		if oracleClient := newOracleClient(cfg, env, contractAddress, contractVersion); oracleClient != nil {
+			return oracleClient
+		}
+		return nil
+	}
+
+	nullPublisher := blockchain.NewNullPublisher(
+		repo,
+		contractAddress,
+		cfg.ChainID,
+		time.Duration(cfg.NullPublisherLatencyMs)*time.Millisecond,
+		time.Duration(cfg.NullPublisherJitterMs)*time.Millisecond,
+	)
+	if contractVersion < 0 || contractVersion > math.MaxUint8 || nullPublisher.SetPayloadVersion(uint8(contractVersion)) != nil {
+		logger.Error("Unsupported oracle contract version, publishing disabled",
+			zap.String("environment", env.Name),
+			zap.String("contract", contractAddress),
+			zap.Int("version", contractVersion),
+		)
+		return nil
+	}
+	nullPublisher.SetBatchLimits(uint64(cfg.OracleBatchGasLimit), cfg.OracleBatchSize)
+	return nullPublisher
+}
+
+// newEnvironmentService builds a self-contained oracle for a non-production
+// environment. Its scores are kept in the environment's own database, so test runs
+// against sandbox providers never touch production scores.
+func newEnvironmentService(
+	cfg *config.Config,
+	env config.ProviderEnvironment,
+	labelRegistry *labels.Registry,
+	tokenFilter *providers.TokenFilter,
+	bureauNormalizer *scoring.BureauNormalizer,
+) (*service.EnhancedOracleService, error) {
+	db, err := initDatabase(env.DatabaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize %s database: %w", env.Name, err)
+	}
+
+	// Pausing a provider doesn't pause its sandbox
+	stack, err := newProviderStack(cfg, env, labelRegistry, tokenFilter, bureauNormalizer, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize %s providers: %w", env.Name, err)
+	}
+	repo := repository.NewScoreRepository(db)
+	stack.blockchainClient = newPublisher(cfg, env, repo, env.ContractAddress, env.ContractVersion)
+
+	baseService := service.NewOracleService(
+		repo,
+		scoring.NewEngine(),
+		stack.onChainAgg,
+		stack.offChainAgg,
+		stack.blockchainClient,
+	)
+	baseService.SetPriceSource(stack.blockscout)
+	baseService.SetExpiryMargins(
+		time.Duration(cfg.ScoreExpiryGraceHours)*time.Hour,
+		time.Duration(cfg.ScoreMaxValidityHours)*time.Hour,
+	)
+
+	return service.NewEnhancedOracleService(
+		baseService,
+		stack.enhancedOnChainAgg,
+		stack.enhancedOffChainAgg,
+		stack.creditBureau,
+		stack.plaid,
+		stack.employment,
+		stack.blockchain,
+		repository.NewBureauRepository(db),
+		stack.mock,
+	), nil
+}
@@ -1,160 +1,706 @@
 package routes
 
 import (
+	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/glebarez/sqlite"
-	"github.com/yourusername/p2p-lend/oracle-service/internal/aggregator"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/adminsig"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/api/handlers"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/blockchain"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/cache"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/config"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/credentials"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/events"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/labels"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providerhealth"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/snapshot"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/webhooks"
 	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func Setup(router *gin.Engine, cfg *config.Config) {
+// Setup wires the service and registers its routes. The returned function stops the
+// background workers, waiting for any pass in progress, then writes out queued history
+// records and events; call it once the server has stopped.
+func Setup(router *gin.Engine, cfg *config.Config) (shutdown func()) {
+	// Background writers drained on shutdown
+	var closers []func() error
+
+	// Background workers run until shutdown
+	workers, stopWorkers := context.WithCancel(context.Background())
+	var running sync.WaitGroup
+	background := func(worker func(ctx context.Context)) {
+		running.Add(1)
+		go func() {
+			defer running.Done()
+			worker(workers)
+		}()
+	}
+
 	// Initialize database
-	db, err := initDatabase(cfg)
+	db, err := initDatabase(cfg.DatabaseURL)
 	if err != nil {
 		logger.Fatal("Failed to initialize database", zap.Error(err))
 	}
 
 	// Initialize components
 	repo := repository.NewScoreRepository(db)
+
+	// Trend queries read daily rollups instead of scanning the raw history
+	if cfg.MetricsBackend == repository.MetricsBackendTimescale {
+		if err := repository.EnableTimescale(db); err != nil {
+			logger.Fatal("Failed to set up TimescaleDB metrics backend", zap.Error(err))
+		}
+		logger.Info("Storing score history in TimescaleDB")
+	}
+	if err := repo.SetMetricsBackend(cfg.MetricsBackend); err != nil {
+		logger.Fatal("Invalid metrics backend", zap.Error(err))
+	}
+
+	// Read-heavy queries go to the replica while it keeps up with the primary
+	if cfg.DatabaseReplicaURL != "" {
+		replica, err := gorm.Open(postgres.Open(cfg.DatabaseReplicaURL), &gorm.Config{})
+		if err != nil {
+			logger.Error("Failed to connect to read replica, reading from primary", zap.Error(err))
+		} else {
+			repo.SetReadReplica(replica, time.Duration(cfg.ReplicaMaxLagSecs)*time.Second)
+			background(func(ctx context.Context) { repo.RunReplicaLagMonitor(ctx, 0) })
+			logger.Info("Routing read-heavy queries to read replica")
+		}
+	}
 	scoringEngine := scoring.NewEngine()
 
-	// Initialize basic aggregators (for fallback)
-	basicOnChainAgg, err := aggregator.NewOnChainAggregator(cfg.EthereumRPC)
+	// Address labels (exchanges, mixers, bridges, ...) consumed by counterparty analysis
+	labelRegistry := labels.NewRegistry(labels.DefaultLabels())
+	labelService := service.NewLabelService(repository.NewLabelRepository(db), labelRegistry)
+
+	var fileLabels []models.AddressLabel
+	if cfg.AddressLabelsFile != "" {
+		fileLabels, err = labels.LoadFile(cfg.AddressLabelsFile)
+		if err != nil {
+			logger.Error("Failed to load address labels file", zap.Error(err))
+		}
+	}
+	if err := labelService.Initialize(context.Background(), fileLabels); err != nil {
+		logger.Error("Failed to initialize address labels, using built-in labels", zap.Error(err))
+	}
+
+	// Keep spam/airdrop tokens out of portfolio valuation
+	tokenFilter := providers.NewTokenFilter(cfg.TokenAllowlist, cfg.TokenBlocklist)
+
+	// Normalizes non-US bureau scales onto the engine's 300-850 range
+	bureauNormalizer := scoring.NewBureauNormalizer(cfg.BureauScoreRanges, cfg.BureauDTIPercent)
+
+	// Operators can pause the scheduler, publishing or a provider during an incident
+	subsystemService := service.NewSubsystemService(repository.NewSubsystemRepository(db))
+	if cfg.MaintenanceMode {
+		subsystemService.ForceMaintenance()
+		logger.Warn("Starting in maintenance mode, mutating endpoints are disabled")
+	}
+
+	// Provider calls and health probes build a rolling health history per provider
+	// and chain
+	providerHealth := providerhealth.NewMonitor(repository.NewProviderHealthRepository(db))
+	providerHealth.SetPauses(subsystemService)
+	closers = append(closers, providerHealth.Close)
+
+	// Initialize 3rd party providers and aggregators
+	stack, err := newProviderStack(cfg, cfg.Production(), labelRegistry, tokenFilter, bureauNormalizer, subsystemService, providerHealth)
 	if err != nil {
-		logger.Fatal("Failed to initialize on-chain aggregator", zap.Error(err))
+		logger.Fatal("Failed to initialize providers", zap.Error(err))
+	}
+	if stack.mock.AnyAlways() {
+		logger.Warn("Mock data enabled for off-chain providers", zap.Stringer("mockData", stack.mock))
+	}
+	if cfg.ProviderProbeSecs > 0 {
+		background(func(ctx context.Context) {
+			providerHealth.RunProbes(ctx, time.Duration(cfg.ProviderProbeSecs)*time.Second, stack.healthChecks(cfg.BlockscoutChain))
+		})
 	}
 
-	basicOffChainAgg := aggregator.NewOffChainAggregator(
-		cfg.CreditBureauURL,
-		"", // bankAPIURL - not used in basic mode
-		cfg.CreditBureauAPIKey,
-	)
+	// Private and consortium chains without an explorer provider are indexed from
+	// their blocks
+	if cfg.ChainIndexEnabled {
+		startChainIndex(cfg, db, stack, subsystemService)
+	}
 
-	// Initialize 3rd party providers
-	creditBureauProvider := providers.NewCreditBureauProvider(
-		cfg.CreditBureauProvider,
-		cfg.CreditBureauURL,
-		cfg.CreditBureauAPIKey,
-	)
+	// Misconfigured dependencies fail the boot rather than the first request
+	if cfg.StartupSelfTest {
+		runSelfTest(cfg, db, stack, subsystemService)
+	}
 
-	plaidProvider := providers.NewPlaidProvider(
-		cfg.PlaidClientID,
-		cfg.PlaidSecret,
-		cfg.PlaidEnv,
-	)
+	// Scores are published to the oracle contract, or for soak tests recorded by the
+	// null publisher without chain access
+	stack.blockchainClient = newPublisher(cfg, cfg.Production(), repo, cfg.ContractAddress, cfg.ContractVersion)
+	if cfg.BlockchainPublisher == config.PublisherNull {
+		logger.Warn("Null publisher enabled, scores are recorded instead of published on-chain")
+	}
 
-	// Initialize blockchain data provider (Covalent)
-	blockchainProvider := providers.NewBlockchainDataProvider(
-		"covalent",
-		cfg.CovalentBaseURL,
-		cfg.CovalentAPIKey,
+	// Initialize base oracle service
+	baseService := service.NewOracleService(
+		repo,
+		scoringEngine,
+		stack.onChainAgg,
+		stack.offChainAgg,
+		stack.blockchainClient,
 	)
+	// Publish cost estimates are quoted in USD using Blockscout's native coin price
+	baseService.SetPriceSource(stack.blockscout)
+	baseService.SetSubsystems(subsystemService)
 
-	blockscoutProvider := providers.NewBlockscoutProvider(
-		cfg.BlockscoutBaseURL,
-		cfg.BlockscoutChain,
+	// Scores published in v2 payloads carry an expiry so the oracle contract rejects
+	// them once stale; v1 contracts have no expiry
+	if cfg.ContractVersion == int(blockchain.PayloadV1) {
+		logger.Warn("Oracle contract version 1 has no score expiry, set CONTRACT_VERSION=2 to publish expiries")
+	}
+	baseService.SetExpiryMargins(
+		time.Duration(cfg.ScoreExpiryGraceHours)*time.Hour,
+		time.Duration(cfg.ScoreMaxValidityHours)*time.Hour,
 	)
 
-	// Initialize enhanced aggregators
-	enhancedOffChainAgg := aggregator.NewEnhancedOffChainAggregator(
-		creditBureauProvider,
-		plaidProvider,
-		cfg.UseMockData,
-	)
+	// A share of publications can go to a new oracle contract ahead of an upgrade
+	if cfg.CanaryContractAddress != "" && cfg.CanaryPercent > 0 {
+		canaryClient := newPublisher(cfg, cfg.Production(), repo, cfg.CanaryContractAddress, cfg.CanaryContractVersion)
+		if canaryClient != nil {
+			baseService.SetCanary(canaryClient, cfg.CanaryPercent)
+			logger.Info("Canary publishing enabled",
+				zap.String("contract", cfg.CanaryContractAddress),
+				zap.Int("percent", cfg.CanaryPercent),
+				zap.Uint8("payloadVersion", canaryClient.PayloadVersion()),
+			)
+		}
+	}
 
-	enhancedOnChainAgg := aggregator.NewEnhancedOnChainAggregator(
-		blockchainProvider,
-		blockscoutProvider,
-		basicOnChainAgg,
-		cfg.UseMockData,
-		cfg.PreferBlockscout,
-		cfg.EnableMultiChain,
-		cfg.TargetChains,
-	)
+	// Score lifecycle events for the rest of the lending platform
+	eventPublisher, err := events.NewPublisher(cfg.EventBus, cfg.EventBusURL, cfg.EventTopicPrefix)
+	if err != nil {
+		logger.Error("Invalid event bus configuration, events disabled", zap.Error(err))
+	} else if eventPublisher != nil {
+		asyncPublisher := events.NewAsyncPublisher(eventPublisher, cfg.EventBufferSize)
+		baseService.SetEventPublisher(asyncPublisher)
+		closers = append(closers, asyncPublisher.Close)
+		logger.Info("Publishing events", zap.String("bus", cfg.EventBus))
+	}
+
+	// Requested updates of an address are spaced out, since each one pulls from the
+	// providers; admins are not limited
+	baseService.SetUpdateMinInterval(time.Duration(cfg.UpdateMinIntervalSecs) * time.Second)
 
-	var blockchainClient *blockchain.OracleClient
-	if cfg.EthereumRPC != "" && cfg.ContractAddress != "" && cfg.PrivateKey != "" {
-		blockchainClient, err = blockchain.NewOracleClient(
-			cfg.EthereumRPC,
-			cfg.ContractAddress,
+	// Scores based on too little data are provisional rather than a misleadingly low
+	// final score
+	if len(cfg.MinDataRequirements) != 1 || cfg.MinDataRequirements[0] != "none" {
+		dataPolicy, err := scoring.NewDataPolicy(cfg.MinDataRequirements, cfg.MinOnChainHistoryDays)
+		if err != nil {
+			logger.Error("Invalid minimum data policy, every score is final", zap.Error(err))
+		} else {
+			baseService.SetDataPolicy(dataPolicy)
+		}
+	}
+
+	// Tenants can clamp the scores they read, e.g. no score above 800 without verified income
+	if cfg.ScorePoliciesFile != "" {
+		scorePolicies, err := scoring.LoadScorePolicies(cfg.ScorePoliciesFile)
+		if err != nil {
+			logger.Error("Failed to load tenant score policies, scores are not clamped", zap.Error(err))
+		} else {
+			baseService.SetScorePolicies(scorePolicies)
+		}
+	}
+
+	// Candidate model versions can be compared with the current model before migrating
+	if len(cfg.ScoringModels) > 0 {
+		scoringModels, err := scoring.ParseModels(scoringEngine, cfg.ScoringModels)
+		if err != nil {
+			logger.Error("Invalid scoring models, only the current model can be compared", zap.Error(err))
+		} else {
+			baseService.SetScoringModels(scoringModels)
+			logger.Info("Registered scoring models", zap.Strings("versions", scoringModels.Versions()))
+		}
+	}
+
+	// Score history is inserted in batches in the background unless every record must
+	// be written with its score
+	if !cfg.HistorySyncWrites {
+		historyWriter := repository.NewHistoryWriter(
+			repo,
+			cfg.HistoryBufferSize,
+			cfg.HistoryBatchSize,
+			time.Duration(cfg.HistoryFlushIntervalMs)*time.Millisecond,
+		)
+		baseService.SetHistoryWriter(historyWriter)
+		closers = append(closers, historyWriter.Close)
+	}
+
+	// Freezes and share link activity are recorded in the audit log
+	baseService.SetAuditLog(repository.NewAuditRepository(db))
+
+	// Runs of the scheduled jobs are recorded, so operators can see whether they completed
+	jobRecorder := service.NewJobRecorder(repository.NewJobRunRepository(db))
+	baseService.SetJobRecorder(jobRecorder)
+
+	// Scores can be issued as Verifiable Credentials signed with the oracle key
+	if cfg.CredentialIssuerURL != "" && cfg.PrivateKey != "" {
+		issuer, err := credentials.NewIssuer(
+			cfg.CredentialIssuerURL,
 			cfg.PrivateKey,
+			cfg.CredentialChainID,
+			time.Duration(cfg.CredentialValidityHours)*time.Hour,
 		)
 		if err != nil {
-			logger.Error("Failed to initialize blockchain client", zap.Error(err))
+			logger.Error("Invalid credential issuer configuration, credentials disabled", zap.Error(err))
+		} else {
+			baseService.SetCredentialIssuer(issuer)
+			logger.Info("Issuing score credentials", zap.String("did", issuer.DID()))
 		}
 	}
 
-	// Initialize base oracle service
-	baseService := service.NewOracleService(
-		repo,
-		scoringEngine,
-		basicOnChainAgg,
-		basicOffChainAgg,
-		blockchainClient,
-	)
+	// Dashboard stats are precomputed rather than aggregated on every request
+	if cfg.StatsRefreshIntervalSecs > 0 {
+		statsInterval := time.Duration(cfg.StatsRefreshIntervalSecs) * time.Second
+		baseService.SetStatsRefresh(statsInterval)
+		background(func(ctx context.Context) { baseService.RunStatsRefresh(ctx, statsInterval) })
+	}
+
+	// Every write of an address's score or metrics, however it was pushed, drops the
+	// address's cached score and prescreen tier and marks the stats for a refresh
+	invalidations := cache.NewBus()
+	repo.SetInvalidationBus(invalidations)
+	baseService.SetInvalidationBus(invalidations, time.Duration(cfg.CacheTTLSecs)*time.Second)
+	if cfg.CacheTTLSecs > 0 {
+		logger.Info("Caching scores and prescreen tiers", zap.Int("ttlSeconds", cfg.CacheTTLSecs))
+	}
+	baseService.SetPublicDistribution(cfg.PublicDistributionMinGroupSize)
+
+	// Analytics partners get noisy aggregates under a daily privacy budget
+	if len(cfg.ResearchAPIKeys) > 0 {
+		research, err := service.NewResearchExport(cfg.ResearchEpsilon, cfg.ResearchDailyBudget, cfg.ResearchMinBucketSize)
+		if err != nil {
+			logger.Error("Invalid research export configuration, research export disabled", zap.Error(err))
+		} else {
+			baseService.SetResearchExport(research)
+		}
+	}
+
+	// Hold non-urgent publications for cheap gas or configured hours
+	publishWindow, err := service.NewPublishWindow(cfg.PublishMaxBaseFeeGwei, cfg.PublishHours)
+	if err != nil {
+		logger.Error("Invalid publish window, publishing immediately", zap.Error(err))
+	} else if publishWindow != nil {
+		baseService.SetPublishWindow(publishWindow)
+		background(func(ctx context.Context) {
+			baseService.RunPublishQueue(ctx, time.Duration(cfg.PublishQueueIntervalMins)*time.Minute, cfg.OracleBatchSize)
+		})
+	}
+
+	// Submitted publications are confirmed from their receipts, so score publish
+	// statuses show which scores contracts can read
+	if stack.blockchainClient != nil && cfg.PublishConfirmIntervalSecs > 0 {
+		background(func(ctx context.Context) {
+			baseService.RunConfirmations(ctx, time.Duration(cfg.PublishConfirmIntervalSecs)*time.Second, cfg.OracleBatchSize)
+		})
+	}
+
+	// Signed webhooks: inbound deliveries are verified and recorded once, and score
+	// changes are pushed to subscribers
+	webhookRepo := repository.NewWebhookRepository(db)
+	webhookTolerance := time.Duration(cfg.WebhookToleranceSecs) * time.Second
+	newReceiver := func(source, secret string) *webhooks.Receiver {
+		if secret == "" {
+			return nil
+		}
+		signer, err := webhooks.NewSigner(cfg.WebhookSignatureScheme, secret, webhookTolerance)
+		if err != nil {
+			logger.Error("Invalid webhook configuration", zap.String("source", source), zap.Error(err))
+			return nil
+		}
+		return webhooks.NewReceiver(source, signer, webhookRepo)
+	}
+	bureauReceiver := newReceiver(stack.creditBureau.Name(), cfg.BureauWebhookSecret)
+
+	// Plaid signs webhooks with its own keys, fetched with the Plaid credentials
+	var plaidReceiver *webhooks.Receiver
+	if cfg.PlaidClientID != "" {
+		plaidReceiver = webhooks.NewReceiver("plaid", webhooks.NewPlaidVerifier(stack.plaid.WebhookVerificationKey), webhookRepo)
+	}
+
+	// Failed score webhooks are retried with backoff, then dead-lettered for replay
+	var dispatcher *webhooks.Dispatcher
+	if len(cfg.ScoreWebhookURLs) > 0 {
+		signer, err := webhooks.NewSigner(cfg.WebhookSignatureScheme, cfg.ScoreWebhookSecret, webhookTolerance)
+		if err != nil {
+			logger.Error("Invalid score webhook configuration, score webhooks disabled", zap.Error(err))
+		} else {
+			dispatcher = webhooks.NewDispatcher(cfg.ScoreWebhookURLs, signer, webhookRepo)
+			dispatcher.SetRetryPolicy(cfg.WebhookMaxAttempts, time.Duration(cfg.WebhookRetryDelaySecs)*time.Second)
+			baseService.SetWebhookDispatcher(dispatcher)
+			background(func(ctx context.Context) { dispatcher.RunRetries(ctx, 0) })
+		}
+	}
+	webhookService := service.NewWebhookService(webhookRepo, dispatcher)
+
+	// Health factors of borrowing addresses are checked between score refreshes; positions
+	// near liquidation lower confidence and send position.at_risk alerts
+	var positionSources []service.HealthFactorSource
+	if cfg.AavePoolAddress != "" && cfg.EthereumRPC != "" {
+		pool, err := blockchain.NewAavePool(cfg.EthereumRPC, cfg.AavePoolAddress)
+		if err != nil {
+			logger.Error("Invalid Aave pool configuration, health monitoring disabled", zap.Error(err))
+		} else {
+			baseService.SetHealthMonitor(pool, cfg.HealthFactorThreshold)
+			background(func(ctx context.Context) {
+				baseService.RunHealthMonitor(ctx, time.Duration(cfg.HealthMonitorIntervalMins)*time.Minute)
+			})
+			logger.Info("Monitoring lending position health", zap.Float64("threshold", cfg.HealthFactorThreshold))
+			positionSources = append(positionSources, pool)
+		}
+	}
+
+	// Open lending positions of every scored address are snapshotted on a schedule, so
+	// their trend can be followed between score refreshes
+	if cfg.CompoundCometAddress != "" && cfg.EthereumRPC != "" {
+		market, err := blockchain.NewCompoundComet(cfg.EthereumRPC, cfg.CompoundCometAddress)
+		if err != nil {
+			logger.Error("Invalid Compound Comet configuration, Compound positions not snapshotted", zap.Error(err))
+		} else {
+			positionSources = append(positionSources, market)
+		}
+	}
+	if len(positionSources) > 0 && cfg.PositionSnapshotIntervalMins > 0 {
+		baseService.SetPositionSources(positionSources...)
+		background(func(ctx context.Context) {
+			baseService.RunPositionSnapshots(ctx, time.Duration(cfg.PositionSnapshotIntervalMins)*time.Minute)
+		})
+		logger.Info("Snapshotting lending positions", zap.Int("protocols", len(positionSources)))
+	}
+
+	// Liquidated addresses are rescored and published at once rather than at their next
+	// scheduled refresh
+	if cfg.LiquidationFastPath {
+		baseService.SetLiquidationFastPath(time.Duration(cfg.LiquidationSLASecs) * time.Second)
+		background(func(ctx context.Context) { baseService.RunLiquidationFastPath(ctx) })
+	}
+
+	// Every calculation's stages are timed and reported against the latency objectives
+	if len(cfg.PipelineSLOMs) > 0 {
+		objectives, err := service.ParsePipelineObjectives(cfg.PipelineSLOMs)
+		if err != nil {
+			logger.Error("Invalid pipeline latency objectives, using the default", zap.Error(err))
+		} else {
+			baseService.SetPipelineSLO(objectives, time.Duration(cfg.PipelineSLOWindowHours)*time.Hour)
+		}
+	}
+
+	// Disaster recovery snapshots of the score state
+	snapshotStore, err := snapshot.NewStore(cfg.SnapshotStoreURL, snapshot.S3Config{
+		Endpoint:  cfg.SnapshotS3Endpoint,
+		Region:    cfg.SnapshotS3Region,
+		AccessKey: cfg.SnapshotS3AccessKey,
+		SecretKey: cfg.SnapshotS3SecretKey,
+	})
+	if err != nil {
+		logger.Error("Invalid snapshot store configuration, snapshots disabled", zap.Error(err))
+	}
+	snapshotService := service.NewSnapshotService(repo, snapshotStore)
+
+	// Expired rows are deleted per retention policy, archived to the snapshot store
+	// first when one is configured
+	retentionService, err := service.NewRetentionService(repository.NewRetentionRepository(db), cfg.RetentionPolicies, snapshotStore)
+	if err != nil {
+		logger.Error("Invalid retention policies, retention disabled", zap.Error(err))
+		retentionService, _ = service.NewRetentionService(repository.NewRetentionRepository(db), nil, nil)
+	}
+	retentionService.SetJobRecorder(jobRecorder)
+	baseService.SetRetention(retentionService)
+	background(func(ctx context.Context) {
+		retentionService.RunSchedule(ctx, time.Duration(cfg.RetentionIntervalHours)*time.Hour)
+	})
 
 	// Initialize enhanced oracle service
 	enhancedService := service.NewEnhancedOracleService(
 		baseService,
-		enhancedOnChainAgg,
-		enhancedOffChainAgg,
-		creditBureauProvider,
-		plaidProvider,
-		blockchainProvider,
-		cfg.UseMockData,
+		stack.enhancedOnChainAgg,
+		stack.enhancedOffChainAgg,
+		stack.creditBureau,
+		stack.plaid,
+		stack.employment,
+		stack.blockchain,
+		repository.NewBureauRepository(db),
+		stack.mock,
 	)
+	enhancedService.SetProviderHealth(providerHealth)
 
 	// Initialize handlers
 	scoreHandler := handlers.NewScoreHandler(baseService)
+	scoreHandler.SetAdminKeys(cfg.AdminAPIKeys)
+	if len(cfg.SignedResponseAPIKeys) > 0 {
+		// Off-chain consumers that cache scores can verify them with the oracle key
+		signer, err := credentials.NewResponseSigner(cfg.PrivateKey)
+		if err != nil {
+			logger.Fatal("Invalid PRIVATE_KEY for SIGNED_RESPONSE_API_KEYS", zap.Error(err))
+		}
+		scoreHandler.SetResponseSigner(signer, cfg.SignedResponseAPIKeys)
+		logger.Info("Signing score responses",
+			zap.String("signer", signer.Address().Hex()),
+			zap.Int("apiKeys", len(cfg.SignedResponseAPIKeys)),
+		)
+	}
 	providerHandler := handlers.NewProviderHandler(enhancedService)
+	providerHandler.SetAdminKeys(cfg.AdminAPIKeys)
+	oracleUpdateHandler := handlers.NewOracleUpdateHandler(baseService)
+
+	// Admins can run provider requests against sandbox providers and a testnet,
+	// isolated from production scores
+	if cfg.Sandbox != nil {
+		sandboxService, err := newEnvironmentService(cfg, *cfg.Sandbox, labelRegistry, tokenFilter, bureauNormalizer)
+		if err != nil {
+			logger.Error("Failed to initialize sandbox environment, sandbox disabled", zap.Error(err))
+		} else {
+			providerHandler.AddEnvironment(cfg.Sandbox.Name, sandboxService)
+			oracleUpdateHandler.AddEnvironment(cfg.Sandbox.Name, sandboxService)
+			logger.Info("Sandbox provider environment enabled")
+		}
+	}
+	labelHandler := handlers.NewLabelHandler(labelService)
+	webhookHandler := handlers.NewWebhookHandler(enhancedService, bureauReceiver, plaidReceiver)
+	webhookAdminHandler := handlers.NewWebhookAdminHandler(webhookService)
+	snapshotHandler := handlers.NewSnapshotHandler(snapshotService)
+	retentionHandler := handlers.NewRetentionHandler(retentionService)
+	jobHandler := handlers.NewJobHandler(jobRecorder)
+	freezeHandler := handlers.NewFreezeHandler(baseService)
+	shareHandler := handlers.NewShareHandler(baseService)
+	credentialHandler := handlers.NewCredentialHandler(baseService)
+	subsystemHandler := handlers.NewSubsystemHandler(subsystemService)
+	schemaHandler := handlers.NewSchemaHandler()
+	researchHandler := handlers.NewResearchHandler(baseService, cfg.ResearchAPIKeys)
+	liquidationHandler := handlers.NewLiquidationHandler(baseService)
+	runbookHandler := handlers.NewRunbookHandler(baseService)
+	metricsHandler := handlers.NewMetricsHandler(baseService)
+
+	// Admins can trace an address or API key to capture its requests with every
+	// provider call, without redeploying with more logging
+	debugHandler := handlers.NewDebugHandler(service.NewDebugService(repository.NewDebugRepository(db)))
+
+	// Destructive admin actions need an operator's signature on top of the admin key,
+	// and are disabled until operator keys are configured
+	var adminSignatures *adminsig.Verifier
+	if len(cfg.AdminSigningKeys) == 0 {
+		logger.Warn("No admin signing keys configured, destructive admin actions are disabled")
+	} else {
+		keys, err := adminsig.ParseKeys(cfg.AdminSigningKeys)
+		if err != nil {
+			logger.Fatal("Invalid admin signing keys", zap.Error(err))
+		}
+		adminSignatures = adminsig.NewVerifier(keys, time.Duration(cfg.AdminSignatureToleranceSecs)*time.Second)
+	}
+	signed := handlers.RequireAdminSignature(adminSignatures)
+
+	// Large responses such as history are gzip-compressed for clients that accept it
+	router.Use(handlers.Compress(cfg.CompressionMinBytes))
 
 	// Health check
 	router.GET("/health", scoreHandler.HealthCheck)
+	router.GET("/metrics", metricsHandler.GetMetrics)
+
+	// Read endpoints send Cache-Control and Last-Modified with a max-age per endpoint class
+	cache := func(class string) gin.HandlerFunc {
+		return handlers.CacheControl(class, cfg.CacheMaxAge[class])
+	}
+
+	// Requests are cancelled with a 504 after the timeout of their endpoint class, so a
+	// hung provider can't hold a connection open past the gateway's own timeout
+	timeout := func(class string) gin.HandlerFunc {
+		return handlers.Timeout(time.Duration(cfg.RequestTimeouts[class]) * time.Second)
+	}
+	read := timeout(handlers.TimeoutClassRead)
+	update := timeout(handlers.TimeoutClassUpdate)
+
+	// did:web document of the credential issuer
+	router.GET("/.well-known/did.json", cache(handlers.CacheClassCredentials), credentialHandler.GetDIDDocument)
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
+	// Error messages and score explanations follow Accept-Language, and borrowers may
+	// be identified by did:pkh or did:ethr instead of an address. Requests of debug
+	// targets are traced.
+	v1.Use(handlers.Localize(), handlers.ResolveAddressParam(), debugHandler.Trace())
+	// In maintenance mode only reads and admin requests are served
+	v1.Use(handlers.Maintenance(
+		subsystemService,
+		time.Duration(cfg.MaintenanceRetryAfterSecs)*time.Second,
+		cfg.AdminAPIKeys,
+		"/api/v1/admin/",
+	))
 	{
 		// Credit score routes
-		v1.GET("/credit-score/:address", scoreHandler.GetCreditScore)
-		v1.POST("/credit-score/update", scoreHandler.UpdateCreditScore)
-		v1.GET("/credit-score/:address/history", scoreHandler.GetScoreHistory)
+		v1.GET("/credit-score/:address", read, cache(handlers.CacheClassScore), scoreHandler.GetCreditScore)
+		v1.POST("/credit-score/update", update, scoreHandler.UpdateCreditScore)
+		v1.POST("/credit-score/preview", update, scoreHandler.PreviewCreditScore)
+		v1.POST("/credit-score/prescreen", read, scoreHandler.Prescreen)
+		v1.GET("/credit-score/:address/history", read, cache(handlers.CacheClassHistory), scoreHandler.GetScoreHistory)
+		v1.GET("/credit-score/:address/history/export", read, cache(handlers.CacheClassHistory), scoreHandler.ExportScoreHistory)
+		v1.GET("/credit-score/:address/explanation", read, cache(handlers.CacheClassScore), scoreHandler.GetScoreExplanation)
+		v1.GET("/credit-score/:address/components", read, cache(handlers.CacheClassScore), scoreHandler.GetScoreComponents)
+		v1.GET("/credit-score/:address/trend", read, cache(handlers.CacheClassHistory), scoreHandler.GetScoreTrend)
+		v1.GET("/credit-score/:address/positions", read, cache(handlers.CacheClassHistory), scoreHandler.GetPositionHistory)
+		v1.GET("/credit-score/:address/compare", read, cache(handlers.CacheClassScore), scoreHandler.CompareModels)
+		v1.GET("/credit-score/:address/events", read, cache(handlers.CacheClassHistory), scoreHandler.GetScoreEvents)
+		v1.GET("/credit-score/:address/state", read, scoreHandler.GetScoreState)
+
+		// Borrower data freeze, authenticated by wallet signature
+		v1.GET("/credit-score/:address/freeze", read, freezeHandler.GetFreeze)
+		v1.POST("/credit-score/:address/freeze", update, freezeHandler.Freeze)
+		v1.POST("/credit-score/:address/freeze/lift", update, freezeHandler.LiftFreeze)
+
+		// Score share links for third parties
+		v1.POST("/credit-score/:address/shares", update, shareHandler.CreateShare)
+		v1.POST("/credit-score/:address/shares/:id/revoke", update, shareHandler.RevokeShare)
+		v1.GET("/shared/:token", read, shareHandler.GetSharedScore)
+
+		// Verifiable Credentials
+		v1.POST("/credit-score/:address/credentials", update, credentialHandler.IssueCredential)
+		v1.GET("/credentials/status/:list", read, cache(handlers.CacheClassCredentials), credentialHandler.GetStatusList)
+
+		// Anonymized aggregates for the marketing site and researchers
+		v1.GET("/public/score-distribution", read, cache(handlers.CacheClassPublic), scoreHandler.GetScoreDistribution)
+
+		// Differentially private aggregates for analytics partners
+		v1.GET("/research/export", read, researchHandler.Export)
+
+		// JSON Schemas of webhook and event payloads
+		v1.GET("/schemas", read, schemaHandler.ListSchemas)
+		v1.GET("/schemas/:channel/:type/:version", read, schemaHandler.GetSchema)
 
 		// Enhanced credit score routes with 3rd party providers
-		v1.POST("/credit-score/update-with-providers", providerHandler.UpdateWithProviders)
+		v1.POST("/credit-score/update-with-providers", timeout(handlers.TimeoutClassProviders), providerHandler.UpdateWithProviders)
 
-		// Provider routes
-		providers := v1.Group("/providers")
+		// Oracle publishing routes
+		oracle := v1.Group("/oracle", read)
+		{
+			oracle.GET("/publish-estimate", scoreHandler.GetPublishEstimate)
+		}
+
+		// Oracle update routes
+		oracleUpdates := v1.Group("/oracle-updates")
+		{
+			oracleUpdates.GET("", read, oracleUpdateHandler.ListOracleUpdates)
+			oracleUpdates.POST("/publish-batch", update, handlers.DecompressRequest(cfg.MaxDecompressedBodyBytes), oracleUpdateHandler.PublishBatch)
+			oracleUpdates.GET("/canary", read, oracleUpdateHandler.GetCanaryReport)
+		}
+
+		// Provider routes; the status check calls every provider
+		providers := v1.Group("/providers", timeout(handlers.TimeoutClassProviders))
 		{
 			providers.GET("/status", providerHandler.GetProviderStatus)
 			providers.GET("/list", providerHandler.ListAvailableProviders)
 		}
 
-		// Admin routes
-		admin := v1.Group("/admin")
+		// Provider webhook routes; bureau alerts rescore with the providers
+		webhookRoutes := v1.Group("/webhooks", timeout(handlers.TimeoutClassProviders))
 		{
-			admin.GET("/stats", scoreHandler.GetStats)
+			webhookRoutes.POST("/bureau-alerts", webhookHandler.BureauAlert)
+			webhookRoutes.POST("/plaid", webhookHandler.PlaidWebhook)
+		}
+
+		// Admin routes, which all need an admin key; without ADMIN_API_KEYS they're closed
+		if len(cfg.AdminAPIKeys) == 0 {
+			logger.Warn("No admin API keys configured, admin endpoints are disabled")
+		}
+		admin := v1.Group("/admin", timeout(handlers.TimeoutClassAdmin), handlers.RequireAdmin(cfg.AdminAPIKeys))
+		{
+			admin.GET("/stats", cache(handlers.CacheClassStats), scoreHandler.GetStats)
+			admin.POST("/stats/refresh", scoreHandler.RefreshStats)
+			admin.GET("/stats/trend", cache(handlers.CacheClassStats), scoreHandler.GetPopulationTrend)
+			admin.GET("/scores/export", scoreHandler.ExportScores)
+			admin.GET("/audit-log", shareHandler.ListAuditLog)
+			admin.POST("/credentials/:id/revoke", signed, credentialHandler.RevokeCredential)
+
+			// Address label management
+			admin.GET("/labels", labelHandler.ListLabels)
+			admin.GET("/labels/:address", labelHandler.GetLabel)
+			admin.PUT("/labels", labelHandler.UpsertLabel)
+			admin.DELETE("/labels/:address", labelHandler.DeleteLabel)
+
+			// Outbound webhook deliveries and dead letters
+			admin.GET("/webhooks/deliveries", webhookAdminHandler.ListDeliveries)
+			admin.GET("/webhooks/deliveries/:id", webhookAdminHandler.GetDelivery)
+			admin.GET("/webhooks/dead-letters", webhookAdminHandler.ListDeadLetters)
+			admin.POST("/webhooks/dead-letters/:id/replay", webhookAdminHandler.ReplayDeadLetter)
+			admin.GET("/webhooks/stats", webhookAdminHandler.GetStats)
+
+			// Liquidation fast path: reports from chain listeners and the SLA
+			admin.POST("/liquidations", liquidationHandler.ReportLiquidation)
+			admin.GET("/liquidations/sla", liquidationHandler.GetSLA)
+			admin.GET("/pipeline/slo", metricsHandler.GetPipelineSLO)
+
+			// Runbook recovery actions, each audited with a summary of the rows it touched
+			admin.POST("/runbooks/requeue-failed-publishes", signed, runbookHandler.RequeueFailedPublishes)
+			admin.POST("/runbooks/refresh-outage-scores", signed, runbookHandler.RefreshOutageScores)
+			admin.POST("/runbooks/recompute-stats", signed, runbookHandler.RecomputeStats)
+
+			// Disaster recovery snapshots
+			admin.POST("/snapshots", snapshotHandler.CreateSnapshot)
+			admin.GET("/snapshots", snapshotHandler.ListSnapshots)
+			admin.GET("/snapshots/:id/verify", snapshotHandler.VerifySnapshot)
+			admin.POST("/snapshots/:id/restore", signed, snapshotHandler.RestoreSnapshot)
+
+			// Data retention
+			admin.POST("/retention/run", signed, retentionHandler.RunRetention)
+
+			// Scheduled job history
+			admin.GET("/jobs", jobHandler.ListJobRuns)
+
+			// Pausing and resuming subsystems
+			admin.GET("/subsystems", subsystemHandler.ListSubsystems)
+			admin.POST("/subsystems/:name/pause", subsystemHandler.PauseSubsystem)
+			admin.POST("/subsystems/:name/resume", subsystemHandler.ResumeSubsystem)
+
+			// Provider credential rotation, checked against the provider before use
+			admin.POST("/providers/:name/credentials", signed, providerHandler.RotateCredential)
+			admin.GET("/maintenance", subsystemHandler.GetMaintenance)
+			admin.PUT("/maintenance", subsystemHandler.SetMaintenance)
+
+			// Signer and contract configuration, for diagnosing failed publications
+			admin.GET("/oracle-identity", oracleUpdateHandler.GetOracleIdentity)
+
+			// Debug traces, which hold provider responses
+			debug := admin.Group("/debug")
+			{
+				debug.POST("/targets", debugHandler.AddTarget)
+				debug.GET("/targets", debugHandler.ListTargets)
+				debug.DELETE("/targets/:id", debugHandler.RemoveTarget)
+				debug.GET("/traces", debugHandler.ListTraces)
+				debug.GET("/traces/:id", debugHandler.GetTrace)
+			}
+		}
+	}
+
+	return func() {
+		stopWorkers()
+		running.Wait()
+		for _, drain := range closers {
+			if err := drain(); err != nil {
+				logger.Error("Failed to drain writer on shutdown", zap.Error(err))
+			}
 		}
 	}
 }
 
-func initDatabase(cfg *config.Config) (*gorm.DB, error) {
+func initDatabase(databaseURL string) (*gorm.DB, error) {
 	var db *gorm.DB
 	var err error
 
-	if cfg.DatabaseURL == "" {
+	if databaseURL == "" {
 		logger.Info("No database URL configured, using in-memory SQLite")
 		// Use pure Go SQLite (no CGO required)
 		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
@@ -163,12 +709,20 @@ func initDatabase(cfg *config.Config) (*gorm.DB, error) {
 		}
 	} else {
 		logger.Info("Connecting to PostgreSQL database")
-		db, err = gorm.Open(postgres.Open(cfg.DatabaseURL), &gorm.Config{})
+		db, err = gorm.Open(postgres.Open(databaseURL), &gorm.Config{})
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	// Oracle update tx hashes were unique until batch publishing let one
+	// transaction carry many updates
+	if db.Migrator().HasIndex(&models.OracleUpdate{}, "idx_oracle_updates_tx_hash") {
+		if err := db.Migrator().DropIndex(&models.OracleUpdate{}, "idx_oracle_updates_tx_hash"); err != nil {
+			return nil, fmt.Errorf("failed to drop unique tx hash index: %w", err)
+		}
+	}
+
 	// Auto-migrate models
 	err = db.AutoMigrate(
 		&models.CreditScore{},
@@ -176,11 +730,52 @@ func initDatabase(cfg *config.Config) (*gorm.DB, error) {
 		&models.OnChainMetrics{},
 		&models.OffChainMetrics{},
 		&models.OracleUpdate{},
+		&models.SimulatedTransaction{},
+		&models.AddressLabel{},
+		&models.BureauLink{},
+		&models.BureauAlert{},
+		&models.WebhookDelivery{},
+		&models.WebhookAttempt{},
+		&models.WebhookDeadLetter{},
+		&models.ScoreEvent{},
+		&models.ScoreStats{},
+		&models.RetentionStat{},
+		&models.DataFreeze{},
+		&models.WalletAuth{},
+		&models.ScoreShare{},
+		&models.AuditLog{},
+		&models.ResearchBudget{},
+		&models.IssuedCredential{},
+		&models.ProviderAgreement{},
+		&models.PositionHealth{},
+		&models.PositionSnapshot{},
+		&models.PipelineTiming{},
+		&models.LiquidationRescore{},
+		&models.DebugTarget{},
+		&models.DebugTrace{},
+		&models.SubsystemState{},
+		&models.ScoreComponent{},
+		&models.ChainIndexCheckpoint{},
+		&models.ChainIndexAddress{},
+		&models.ChainIndexTransaction{},
+		&models.ChainIndexTokenTransfer{},
+		&models.ProviderHealth{},
+		&models.JobRun{},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	// Addresses used to be stored in the case they were submitted in, so one wallet
+	// could have several scores
+	merged, err := repository.NormalizeAddresses(db)
+	if err != nil {
+		return nil, err
+	}
+	if merged > 0 {
+		logger.Info("Merged scores of addresses stored in more than one case", zap.Int("addresses", merged))
+	}
+
 	logger.Info("Database initialized successfully")
 	return db, nil
 }
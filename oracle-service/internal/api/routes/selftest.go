@@ -0,0 +1,108 @@
+package routes
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/config"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/pause"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/selftest"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// runSelfTest checks every configured dependency before the service takes traffic,
+// and exits listing the failed checks if any fails
+func runSelfTest(cfg *config.Config, db *gorm.DB, stack *providerStack, pauses pause.Checker) {
+	ctx := context.Background()
+	checks := []selftest.Check{
+		{Name: "database", Hint: "check DATABASE_URL", Run: selftest.Database(db)},
+	}
+
+	if cfg.RedisURL != "" {
+		checks = append(checks, selftest.Check{Name: "redis", Hint: "check REDIS_URL", Run: selftest.Redis(cfg.RedisURL)})
+	}
+
+	// The null publisher never contacts the chain, so soak tests run without a node
+	if cfg.EthereumRPC != "" && cfg.BlockchainPublisher != config.PublisherNull {
+		client, err := ethclient.DialContext(ctx, cfg.EthereumRPC)
+		if err != nil {
+			logger.Fatal("Startup self-test failed: invalid ETHEREUM_RPC_URL", zap.Error(err))
+		}
+		defer client.Close()
+
+		checks = append(checks, selftest.Check{Name: "ethereum_rpc", Hint: "check ETHEREUM_RPC_URL and CHAIN_ID", Run: selftest.ChainID(client, cfg.ChainID)})
+		if cfg.ContractAddress != "" {
+			checks = append(checks, selftest.Check{Name: "oracle_contract", Hint: "check CONTRACT_ADDRESS is deployed on the ETHEREUM_RPC_URL network", Run: selftest.ContractCode(client, cfg.ContractAddress)})
+		}
+		if cfg.CanaryContractAddress != "" && cfg.CanaryPercent > 0 {
+			checks = append(checks, selftest.Check{Name: "canary_contract", Hint: "check CANARY_CONTRACT_ADDRESS is deployed on the ETHEREUM_RPC_URL network", Run: selftest.ContractCode(client, cfg.CanaryContractAddress)})
+		}
+	}
+
+	if cfg.PrivateKey != "" {
+		checks = append(checks, selftest.Check{
+			Name: "signer",
+			Hint: "PRIVATE_KEY must be a hex secp256k1 key without 0x",
+			Run: func(ctx context.Context) error {
+				signer, err := selftest.Signer(cfg.PrivateKey)
+				if err == nil {
+					logger.Info("Oracle transactions are signed by", zap.String("address", signer.Hex()))
+				}
+				return err
+			},
+		})
+	}
+
+	// One cheap call per configured provider; paused providers and providers serving
+	// mock data are skipped
+	provider := func(name, hint string, healthCheck func(ctx context.Context) error) {
+		checks = append(checks, selftest.Check{
+			Name: name,
+			Hint: hint,
+			Run: func(ctx context.Context) error {
+				if pauses.Paused(ctx, name) {
+					return nil
+				}
+				return healthCheck(ctx)
+			},
+		})
+	}
+	if cfg.CreditBureauURL != "" && stack.mock.Bureau != providers.MockAlways {
+		provider(pause.CreditBureau, "check CREDIT_BUREAU_URL and CREDIT_BUREAU_API_KEY", stack.creditBureau.HealthCheck)
+	}
+	if cfg.PlaidClientID != "" && stack.mock.Plaid != providers.MockAlways {
+		provider(pause.Plaid, "check PLAID_CLIENT_ID, PLAID_SECRET and PLAID_ENV", stack.plaid.HealthCheck)
+	}
+	if stack.employment.IsConfigured() && stack.mock.Employment != providers.MockAlways {
+		provider(pause.Employment, "check EMPLOYMENT_API_URL and EMPLOYMENT_API_KEY", stack.employment.HealthCheck)
+	}
+	if cfg.CovalentAPIKey != "" {
+		provider(pause.BlockchainData, "check COVALENT_BASE_URL and COVALENT_API_KEY", stack.blockchain.HealthCheck)
+	}
+	if cfg.BlockscoutBaseURL != "" {
+		provider(pause.Blockscout, "check BLOCKSCOUT_BASE_URL", stack.blockscout.HealthCheck)
+	}
+
+	results, passed := selftest.Run(ctx, checks, time.Duration(cfg.SelfTestTimeoutSecs)*time.Second)
+	for _, result := range results {
+		if result.Passed() {
+			logger.Info("Self-test check passed", zap.String("check", result.Name), zap.Duration("duration", result.Duration))
+		} else {
+			logger.Error("Self-test check failed", zap.String("check", result.Name), zap.String("hint", result.Hint), zap.Error(result.Err))
+		}
+	}
+	if !passed {
+		var failed []string
+		for _, result := range results {
+			if !result.Passed() {
+				failed = append(failed, result.Error())
+			}
+		}
+		logger.Fatal("Startup self-test failed", zap.Strings("failures", failed))
+	}
+	logger.Info("Startup self-test passed", zap.Int("checks", len(results)))
+}
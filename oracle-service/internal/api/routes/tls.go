@@ -0,0 +1,64 @@
+package routes
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/config"
+)
+
+// Client certificate requirements of the server, selected with TLS_CLIENT_AUTH
+const (
+	ClientAuthNone     = "none"
+	ClientAuthOptional = "optional" // Certificates are verified when presented
+	ClientAuthRequire  = "require"  // Connections without a valid certificate are refused
+)
+
+// ServerTLSConfig returns the TLS settings of the API server, or nil to serve plain
+// HTTP when no certificate is configured. Client certificates are checked against
+// the client CA file when cfg asks for them.
+func ServerTLSConfig(cfg *config.Config) (*tls.Config, error) {
+	if cfg.TLSCertFile == "" && cfg.TLSKeyFile == "" {
+		if cfg.TLSClientAuth != ClientAuthNone && cfg.TLSClientAuth != "" {
+			return nil, fmt.Errorf("TLS_CLIENT_AUTH=%s needs TLS_CERT_FILE and TLS_KEY_FILE", cfg.TLSClientAuth)
+		}
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	switch cfg.TLSClientAuth {
+	case ClientAuthNone, "":
+		return tlsConfig, nil
+	case ClientAuthOptional:
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	case ClientAuthRequire:
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	default:
+		return nil, fmt.Errorf("unknown TLS_CLIENT_AUTH %q", cfg.TLSClientAuth)
+	}
+
+	if cfg.TLSClientCAFile == "" {
+		return nil, fmt.Errorf("TLS_CLIENT_AUTH=%s needs TLS_CLIENT_CA_FILE", cfg.TLSClientAuth)
+	}
+	pem, err := os.ReadFile(cfg.TLSClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in client CA file %s", cfg.TLSClientCAFile)
+	}
+	tlsConfig.ClientCAs = pool
+
+	return tlsConfig, nil
+}
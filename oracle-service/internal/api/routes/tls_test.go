@@ -0,0 +1,65 @@
+package routes
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/config"
+)
+
+func TestServerTLSConfig(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+
+	if tlsConfig, err := ServerTLSConfig(&config.Config{TLSClientAuth: ClientAuthNone}); err != nil || tlsConfig != nil {
+		t.Errorf("Expected plain HTTP without a certificate, got %v (%v)", tlsConfig, err)
+	}
+	if _, err := ServerTLSConfig(&config.Config{TLSClientAuth: ClientAuthRequire}); err == nil {
+		t.Error("Expected client certificates without a server certificate to be rejected")
+	}
+	if _, err := ServerTLSConfig(&config.Config{TLSCertFile: certFile, TLSKeyFile: keyFile, TLSClientAuth: ClientAuthRequire}); err == nil {
+		t.Error("Expected client certificates without a client CA to be rejected")
+	}
+
+	tlsConfig, err := ServerTLSConfig(&config.Config{
+		TLSCertFile:     certFile,
+		TLSKeyFile:      keyFile,
+		TLSClientCAFile: certFile,
+		TLSClientAuth:   ClientAuthRequire,
+	})
+	if err != nil {
+		t.Fatalf("Failed to build TLS settings: %v", err)
+	}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = tlsConfig
+	server.StartTLS()
+	defer server.Close()
+
+	get := func(certificates []tls.Certificate) error {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: true, // The test certificate names no host
+			Certificates:       certificates,
+		}}}
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	}
+
+	if err := get(nil); err == nil {
+		t.Error("Expected a client without a certificate to be refused")
+	}
+	clientCert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("Failed to load client certificate: %v", err)
+	}
+	if err := get([]tls.Certificate{clientCert}); err != nil {
+		t.Errorf("Expected a client with a trusted certificate to connect: %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Batch publishing defaults
+const (
+	defaultBatchGasLimit = 8_000_000 // Leaves room in a 30M gas block for other transactions
+	defaultMaxBatchSize  = 100       // Contract accepts at most 200 per call
+)
+
+// ScoreUpdate is one credit score to publish
+type ScoreUpdate struct {
+	UserAddress string
+	Score       units.Score
+	Confidence  units.Confidence
+	DataHash    string
+	ExpiresAt   time.Time // Required by v2 payloads
+}
+
+// PublishResult reports the outcome of one submitted transaction, or of updates that
+// could not be submitted
+type PublishResult struct {
+	Updates []ScoreUpdate
+	Tx      *types.Transaction
+	Batched bool // Submitted via the contract's batch function
+	Err     error
+}
+
+// scoreChunk is a group of updates that fits in one batch transaction
+type scoreChunk struct {
+	updates []ScoreUpdate
+	gas     uint64
+	err     error
+}
+
+// SetBatchLimits configures the gas ceiling and maximum size of a batch transaction
+func (oc *OracleClient) SetBatchLimits(gasLimit uint64, maxBatchSize int) {
+	if gasLimit > 0 {
+		oc.batchGasLimit = gasLimit
+	}
+	if maxBatchSize > 0 {
+		oc.maxBatchSize = maxBatchSize
+	}
+}
+
+// SupportsBatchUpdates reports whether the oracle contract exposes the batch function
+// of its payload version. The contract is probed once with an empty batch; older
+// deployments revert.
+func (oc *OracleClient) SupportsBatchUpdates(ctx context.Context) bool {
+	oc.batchMu.Lock()
+	defer oc.batchMu.Unlock()
+
+	if oc.batchSupport != nil {
+		return *oc.batchSupport
+	}
+
+	data, err := packScoreUpdates(oc.payloadVersion, nil)
+	if err != nil {
+		return false
+	}
+
+	_, err = oc.client.CallContract(ctx, ethereum.CallMsg{
+		From: oc.fromAddress(),
+		To:   &oc.contractAddress,
+		Data: data,
+	}, nil)
+	if err != nil && ctx.Err() != nil {
+		// Don't cache the answer when the probe itself was cut short
+		return false
+	}
+
+	supported := err == nil
+	oc.batchSupport = &supported
+
+	logger.Info("Probed oracle contract for batch updates",
+		zap.Uint8("payloadVersion", oc.payloadVersion),
+		zap.Bool("supported", supported),
+	)
+
+	return supported
+}
+
+// PublishScores publishes many score updates, batching them into batch function calls
+// chunked by gas limit when the contract supports it and falling back to one
+// transaction per score otherwise.
+func (oc *OracleClient) PublishScores(ctx context.Context, updates []ScoreUpdate) []PublishResult {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	if !oc.SupportsBatchUpdates(ctx) {
+		results := make([]PublishResult, 0, len(updates))
+		for _, update := range updates {
+			tx, err := oc.UpdateCreditScore(ctx, update)
+			results = append(results, PublishResult{Updates: []ScoreUpdate{update}, Tx: tx, Err: err})
+		}
+		return results
+	}
+
+	chunks := chunkUpdates(updates, oc.maxBatchSize, oc.batchGasLimit, func(chunk []ScoreUpdate) (uint64, error) {
+		data, err := packScoreUpdates(oc.payloadVersion, chunk)
+		if err != nil {
+			return 0, err
+		}
+		return oc.estimateCallGas(ctx, data)
+	})
+
+	results := make([]PublishResult, 0, len(chunks))
+	for _, chunk := range chunks {
+		result := PublishResult{Updates: chunk.updates, Batched: true, Err: chunk.err}
+		if chunk.err == nil {
+			data, err := packScoreUpdates(oc.payloadVersion, chunk.updates)
+			if err == nil {
+				result.Tx, err = oc.sendTransaction(ctx, data, chunk.gas)
+			}
+			result.Err = err
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// chunkUpdates splits updates into chunks of at most maxSize whose estimated gas fits
+// under gasLimit. A chunk over the limit shrinks in proportion to its excess gas; a chunk
+// that fails estimation is halved, which isolates individual updates the contract would reject.
+func chunkUpdates(
+	updates []ScoreUpdate,
+	maxSize int,
+	gasLimit uint64,
+	estimate func([]ScoreUpdate) (uint64, error),
+) []scoreChunk {
+	var chunks []scoreChunk
+
+	for len(updates) > 0 {
+		size := len(updates)
+		if maxSize > 0 && size > maxSize {
+			size = maxSize
+		}
+
+		for {
+			gas, err := estimate(updates[:size])
+			overLimit := err == nil && gas > gasLimit
+			if overLimit {
+				err = fmt.Errorf("estimated gas %d exceeds batch limit %d", gas, gasLimit)
+			}
+			if err == nil || size == 1 {
+				chunks = append(chunks, scoreChunk{updates: updates[:size], gas: gas, err: err})
+				break
+			}
+
+			if overLimit {
+				// Gas grows roughly linearly with batch size, so shrink proportionally
+				next := int(uint64(size) * gasLimit / gas)
+				if next >= size {
+					next = size - 1
+				}
+				size = next
+			} else {
+				size /= 2
+			}
+			if size < 1 {
+				size = 1
+			}
+		}
+
+		updates = updates[size:]
+	}
+
+	return chunks
+}
@@ -0,0 +1,129 @@
+package blockchain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func testUpdates(n int) []ScoreUpdate {
+	updates := make([]ScoreUpdate, n)
+	for i := range updates {
+		updates[i] = ScoreUpdate{
+			UserAddress: fmt.Sprintf("0x%040x", i+1),
+			Score:       700,
+			Confidence:  80,
+		}
+	}
+	return updates
+}
+
+func TestChunkUpdatesByGasLimit(t *testing.T) {
+	// 21k base + 50k per score: at most 5 scores fit under 300k gas
+	estimate := func(chunk []ScoreUpdate) (uint64, error) {
+		return 21000 + uint64(len(chunk))*50000, nil
+	}
+
+	chunks := chunkUpdates(testUpdates(12), 10, 300000, estimate)
+
+	var sizes []int
+	total := 0
+	for _, chunk := range chunks {
+		if chunk.err != nil {
+			t.Fatalf("Unexpected chunk error: %v", chunk.err)
+		}
+		if chunk.gas > 300000 {
+			t.Errorf("Chunk gas %d exceeds limit", chunk.gas)
+		}
+		sizes = append(sizes, len(chunk.updates))
+		total += len(chunk.updates)
+	}
+
+	if total != 12 {
+		t.Errorf("Expected all 12 updates to be chunked, got %d", total)
+	}
+	if len(chunks) != 3 || sizes[0] != 5 {
+		t.Errorf("Expected chunks of at most 5 updates, got %v", sizes)
+	}
+}
+
+func TestChunkUpdatesIsolatesRejectedUpdate(t *testing.T) {
+	updates := testUpdates(4)
+	updates[2].Score = 900 // Out of range, the contract reverts
+
+	estimate := func(chunk []ScoreUpdate) (uint64, error) {
+		for _, update := range chunk {
+			if update.Score > 850 {
+				return 0, errors.New("execution reverted: Invalid credit score range")
+			}
+		}
+		return uint64(len(chunk)) * 50000, nil
+	}
+
+	chunks := chunkUpdates(updates, 10, 1000000, estimate)
+
+	failed := 0
+	for _, chunk := range chunks {
+		if chunk.err != nil {
+			failed += len(chunk.updates)
+			if chunk.updates[0].Score != 900 {
+				t.Errorf("Expected only the invalid update to fail, got %+v", chunk.updates)
+			}
+		}
+	}
+	if failed != 1 {
+		t.Errorf("Expected exactly 1 failed update, got %d", failed)
+	}
+}
+
+func TestPackUpdateScores(t *testing.T) {
+	data, err := packScoreUpdates(PayloadV1, testUpdates(3))
+	if err != nil {
+		t.Fatalf("Failed to pack batch calldata: %v", err)
+	}
+
+	args, err := oracleABI.Methods["updateScores"].Inputs.Unpack(data[4:])
+	if err != nil {
+		t.Fatalf("Failed to unpack batch calldata: %v", err)
+	}
+	if scores := args[1].([]uint16); len(scores) != 3 || scores[0] != 700 {
+		t.Errorf("Unexpected scores in calldata: %v", scores)
+	}
+}
+
+func TestPackUpdateScoresWithExpiry(t *testing.T) {
+	updates := testUpdates(2)
+	expiresAt := time.Unix(1735689600, 0)
+	for i := range updates {
+		updates[i].ExpiresAt = expiresAt
+	}
+
+	data, err := packScoreUpdates(PayloadV2, updates)
+	if err != nil {
+		t.Fatalf("Failed to pack batch calldata: %v", err)
+	}
+
+	method := oracleABI.Methods["updateScoresWithExpiry"]
+	if !bytes.Equal(method.ID, crypto.Keccak256([]byte("updateScoresWithExpiry(address[],uint16[],uint8[],bytes[],uint64[])"))[:4]) {
+		t.Fatalf("ABI doesn't match the contract's updateScoresWithExpiry")
+	}
+	if !bytes.Equal(data[:4], method.ID) {
+		t.Fatalf("Expected selector %x, got %x", method.ID, data[:4])
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		t.Fatalf("Failed to unpack batch calldata: %v", err)
+	}
+	if expiries := args[4].([]uint64); len(expiries) != 2 || expiries[1] != 1735689600 {
+		t.Errorf("Unexpected expiries in calldata: %v", expiries)
+	}
+
+	// v2 payloads can't be published without an expiry
+	if _, err := packScoreUpdates(PayloadV2, testUpdates(1)); err == nil {
+		t.Error("Expected error for update without expiry")
+	}
+}
@@ -0,0 +1,278 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// cometABI is the subset of the Compound v3 Comet ABI used to read account positions
+const cometABI = `[
+	{"type": "function", "name": "decimals", "stateMutability": "view", "inputs": [], "outputs": [{"name": "", "type": "uint8"}]},
+	{"type": "function", "name": "baseTokenPriceFeed", "stateMutability": "view", "inputs": [], "outputs": [{"name": "", "type": "address"}]},
+	{"type": "function", "name": "numAssets", "stateMutability": "view", "inputs": [], "outputs": [{"name": "", "type": "uint8"}]},
+	{
+		"type": "function",
+		"name": "getAssetInfo",
+		"stateMutability": "view",
+		"inputs": [{"name": "i", "type": "uint8"}],
+		"outputs": [{
+			"name": "",
+			"type": "tuple",
+			"components": [
+				{"name": "offset", "type": "uint8"},
+				{"name": "asset", "type": "address"},
+				{"name": "priceFeed", "type": "address"},
+				{"name": "scale", "type": "uint64"},
+				{"name": "borrowCollateralFactor", "type": "uint64"},
+				{"name": "liquidateCollateralFactor", "type": "uint64"},
+				{"name": "liquidationFactor", "type": "uint64"},
+				{"name": "supplyCap", "type": "uint128"}
+			]
+		}]
+	},
+	{"type": "function", "name": "getPrice", "stateMutability": "view", "inputs": [{"name": "priceFeed", "type": "address"}], "outputs": [{"name": "", "type": "uint256"}]},
+	{"type": "function", "name": "balanceOf", "stateMutability": "view", "inputs": [{"name": "account", "type": "address"}], "outputs": [{"name": "", "type": "uint256"}]},
+	{"type": "function", "name": "borrowBalanceOf", "stateMutability": "view", "inputs": [{"name": "account", "type": "address"}], "outputs": [{"name": "", "type": "uint256"}]},
+	{"type": "function", "name": "collateralBalanceOf", "stateMutability": "view", "inputs": [{"name": "account", "type": "address"}, {"name": "asset", "type": "address"}], "outputs": [{"name": "", "type": "uint128"}]}
+]`
+
+var comet abi.ABI
+
+func init() {
+	var err error
+	comet, err = abi.JSON(strings.NewReader(cometABI))
+	if err != nil {
+		panic(fmt.Sprintf("invalid Comet ABI: %v", err))
+	}
+}
+
+// Comet prices have 8 decimals and collateral factors 18
+const (
+	cometPriceDecimals  = 8
+	cometFactorDecimals = 18
+)
+
+// cometAsset is a collateral asset of a Comet market, as returned by getAssetInfo
+type cometAsset struct {
+	Offset                    uint8
+	Asset                     common.Address
+	PriceFeed                 common.Address
+	Scale                     uint64
+	BorrowCollateralFactor    uint64
+	LiquidateCollateralFactor uint64
+	LiquidationFactor         uint64
+	SupplyCap                 *big.Int
+}
+
+// cometMarket is the configuration of a Comet market
+type cometMarket struct {
+	baseDecimals int
+	baseFeed     common.Address
+	assets       []cometAsset
+}
+
+// cometPosition is an account's balances in a Comet market and the prices they are
+// valued at. Collateral and prices are indexed like the market's assets.
+type cometPosition struct {
+	supplied        *big.Int
+	borrowed        *big.Int
+	basePrice       *big.Int
+	collateral      []*big.Int
+	collateralPrice []*big.Int // nil for assets the account holds none of
+}
+
+// CompoundComet reads account positions from a Compound v3 Comet market
+type CompoundComet struct {
+	client *ethclient.Client
+	comet  common.Address
+
+	mu     sync.Mutex
+	market *cometMarket // Read on first use; assets added later need a restart
+}
+
+// NewCompoundComet creates a reader for the Comet market at cometAddr
+func NewCompoundComet(rpcURL, cometAddr string) (*CompoundComet, error) {
+	if !common.IsHexAddress(cometAddr) {
+		return nil, fmt.Errorf("invalid Compound Comet address %q", cometAddr)
+	}
+
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Ethereum client: %w", err)
+	}
+
+	return &CompoundComet{
+		client: client,
+		comet:  common.HexToAddress(cometAddr),
+	}, nil
+}
+
+// AccountHealth reads the user's supplied balance and collateral, borrow balance and
+// health factor. The health factor is the collateral value weighted by the assets'
+// liquidation collateral factors over the debt, so it is liquidatable below 1 as on Aave.
+func (c *CompoundComet) AccountHealth(ctx context.Context, user string) (*AccountHealth, error) {
+	if !common.IsHexAddress(user) {
+		return nil, fmt.Errorf("invalid address %q", user)
+	}
+	account := common.HexToAddress(user)
+
+	market, err := c.loadMarket(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	position := &cometPosition{
+		collateral:      make([]*big.Int, len(market.assets)),
+		collateralPrice: make([]*big.Int, len(market.assets)),
+	}
+	if position.supplied, err = c.callUint(ctx, "balanceOf", account); err != nil {
+		return nil, err
+	}
+	if position.borrowed, err = c.callUint(ctx, "borrowBalanceOf", account); err != nil {
+		return nil, err
+	}
+	if position.basePrice, err = c.callUint(ctx, "getPrice", market.baseFeed); err != nil {
+		return nil, err
+	}
+	for i, asset := range market.assets {
+		if position.collateral[i], err = c.callUint(ctx, "collateralBalanceOf", account, asset.Asset); err != nil {
+			return nil, err
+		}
+		if position.collateral[i].Sign() == 0 {
+			continue
+		}
+		if position.collateralPrice[i], err = c.callUint(ctx, "getPrice", asset.PriceFeed); err != nil {
+			return nil, err
+		}
+	}
+
+	return market.accountHealth(position), nil
+}
+
+// loadMarket reads the market's base token and collateral assets once
+func (c *CompoundComet) loadMarket(ctx context.Context) (*cometMarket, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.market != nil {
+		return c.market, nil
+	}
+
+	values, err := c.call(ctx, "decimals")
+	if err != nil {
+		return nil, err
+	}
+	decimals, ok := values[0].(uint8)
+	if !ok {
+		return nil, fmt.Errorf("unexpected decimals result %v", values[0])
+	}
+
+	if values, err = c.call(ctx, "baseTokenPriceFeed"); err != nil {
+		return nil, err
+	}
+	baseFeed, ok := values[0].(common.Address)
+	if !ok {
+		return nil, fmt.Errorf("unexpected baseTokenPriceFeed result %v", values[0])
+	}
+
+	if values, err = c.call(ctx, "numAssets"); err != nil {
+		return nil, err
+	}
+	numAssets, ok := values[0].(uint8)
+	if !ok {
+		return nil, fmt.Errorf("unexpected numAssets result %v", values[0])
+	}
+
+	market := &cometMarket{baseDecimals: int(decimals), baseFeed: baseFeed}
+	for i := uint8(0); i < numAssets; i++ {
+		values, err := c.call(ctx, "getAssetInfo", i)
+		if err != nil {
+			return nil, err
+		}
+		asset := *abi.ConvertType(values[0], new(cometAsset)).(*cometAsset)
+		market.assets = append(market.assets, asset)
+	}
+
+	c.market = market
+	return market, nil
+}
+
+// call calls a Comet view function and decodes its return values
+func (c *CompoundComet) call(ctx context.Context, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := comet.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s call: %w", method, err)
+	}
+
+	result, err := c.client.CallContract(ctx, ethereum.CallMsg{To: &c.comet, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, err)
+	}
+
+	values, err := comet.Unpack(method, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+	if len(values) != 1 {
+		return nil, fmt.Errorf("unexpected %s result %v", method, values)
+	}
+	return values, nil
+}
+
+// callUint calls a Comet view function returning one unsigned integer
+func (c *CompoundComet) callUint(ctx context.Context, method string, args ...interface{}) (*big.Int, error) {
+	values, err := c.call(ctx, method, args...)
+	if err != nil {
+		return nil, err
+	}
+	amount, ok := values[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s result %v", method, values[0])
+	}
+	return amount, nil
+}
+
+// accountHealth values a position in USD. Supplied base tokens and collateral both
+// count as collateral; an account with debt has no supplied base tokens.
+func (m *cometMarket) accountHealth(position *cometPosition) *AccountHealth {
+	basePrice := weiToUnit(position.basePrice, cometPriceDecimals)
+	health := &AccountHealth{
+		Protocol:      "compound",
+		CollateralUSD: weiToUnit(position.supplied, m.baseDecimals) * basePrice,
+		DebtUSD:       weiToUnit(position.borrowed, m.baseDecimals) * basePrice,
+	}
+
+	liquidationValue := 0.0
+	for i, asset := range m.assets {
+		if position.collateralPrice[i] == nil {
+			continue
+		}
+		value := scaledAmount(position.collateral[i], asset.Scale) * weiToUnit(position.collateralPrice[i], cometPriceDecimals)
+		health.CollateralUSD += value
+		liquidationValue += value * weiToUnit(new(big.Int).SetUint64(asset.LiquidateCollateralFactor), cometFactorDecimals)
+	}
+	if health.HasDebt() {
+		health.HealthFactor = liquidationValue / health.DebtUSD
+	}
+
+	return health
+}
+
+// scaledAmount converts an asset amount to units with the asset's scale (10^decimals)
+func scaledAmount(amount *big.Int, scale uint64) float64 {
+	if scale == 0 {
+		return 0
+	}
+	value, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(amount),
+		new(big.Float).SetUint64(scale),
+	).Float64()
+	return value
+}
@@ -0,0 +1,80 @@
+package blockchain
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestDecodeCometAssetInfo(t *testing.T) {
+	info := cometAsset{
+		Offset:                    1,
+		Asset:                     common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
+		PriceFeed:                 common.HexToAddress("0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419"),
+		Scale:                     1e18,
+		BorrowCollateralFactor:    825e15,
+		LiquidateCollateralFactor: 895e15,
+		LiquidationFactor:         95e16,
+		SupplyCap:                 big.NewInt(350000),
+	}
+	result, err := comet.Methods["getAssetInfo"].Outputs.Pack(info)
+	if err != nil {
+		t.Fatalf("Failed to encode result: %v", err)
+	}
+
+	values, err := comet.Unpack("getAssetInfo", result)
+	if err != nil {
+		t.Fatalf("Failed to decode result: %v", err)
+	}
+	decoded := *abi.ConvertType(values[0], new(cometAsset)).(*cometAsset)
+	if decoded.Asset != info.Asset || decoded.PriceFeed != info.PriceFeed || decoded.LiquidateCollateralFactor != info.LiquidateCollateralFactor {
+		t.Errorf("Unexpected asset info: %+v", decoded)
+	}
+}
+
+func TestCometAccountHealth(t *testing.T) {
+	usdc := func(amount int64) *big.Int {
+		return new(big.Int).Mul(big.NewInt(amount), big.NewInt(1e6))
+	}
+	price := func(dollars int64) *big.Int {
+		return new(big.Int).Mul(big.NewInt(dollars), big.NewInt(1e8))
+	}
+	market := &cometMarket{
+		baseDecimals: 6,
+		assets: []cometAsset{
+			{Scale: 1e18, LiquidateCollateralFactor: 9e17},
+			{Scale: 1e8, LiquidateCollateralFactor: 8e17},
+		},
+	}
+
+	// 2 WETH at 3,000 against 4,500 USDC borrowed
+	weth, _ := new(big.Int).SetString("2000000000000000000", 10)
+	health := market.accountHealth(&cometPosition{
+		supplied:        big.NewInt(0),
+		borrowed:        usdc(4500),
+		basePrice:       price(1),
+		collateral:      []*big.Int{weth, big.NewInt(0)},
+		collateralPrice: []*big.Int{price(3000), nil},
+	})
+	if health.Protocol != "compound" || health.CollateralUSD != 6000 || health.DebtUSD != 4500 {
+		t.Errorf("Unexpected account health: %+v", health)
+	}
+	if math.Abs(health.HealthFactor-1.2) > 1e-9 {
+		t.Errorf("Expected health factor 1.2, got %f", health.HealthFactor)
+	}
+
+	// Supplying the base token only earns interest
+	health = market.accountHealth(&cometPosition{
+		supplied:        usdc(1000),
+		borrowed:        big.NewInt(0),
+		basePrice:       price(1),
+		collateral:      []*big.Int{big.NewInt(0), big.NewInt(0)},
+		collateralPrice: []*big.Int{nil, nil},
+	})
+	if health.HasDebt() || health.HealthFactor != 0 || health.CollateralUSD != 1000 {
+		t.Errorf("Expected a supply-only position, got %+v", health)
+	}
+}
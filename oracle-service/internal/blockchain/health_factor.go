@@ -0,0 +1,135 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// aavePoolABI is the subset of the Aave v3 Pool ABI used to read account health
+const aavePoolABI = `[
+	{
+		"type": "function",
+		"name": "getUserAccountData",
+		"stateMutability": "view",
+		"inputs": [{"name": "user", "type": "address"}],
+		"outputs": [
+			{"name": "totalCollateralBase", "type": "uint256"},
+			{"name": "totalDebtBase", "type": "uint256"},
+			{"name": "availableBorrowsBase", "type": "uint256"},
+			{"name": "currentLiquidationThreshold", "type": "uint256"},
+			{"name": "ltv", "type": "uint256"},
+			{"name": "healthFactor", "type": "uint256"}
+		]
+	}
+]`
+
+var aavePool abi.ABI
+
+func init() {
+	var err error
+	aavePool, err = abi.JSON(strings.NewReader(aavePoolABI))
+	if err != nil {
+		panic(fmt.Sprintf("invalid Aave pool ABI: %v", err))
+	}
+}
+
+// Aave v3 reports values in its USD base currency with 8 decimals, and the health
+// factor with 18
+const (
+	aaveBaseDecimals         = 8
+	aaveHealthFactorDecimals = 18
+)
+
+// AccountHealth is the state of an address's borrowing on a lending protocol
+type AccountHealth struct {
+	Protocol      string  `json:"protocol"`
+	CollateralUSD float64 `json:"collateral_usd"`
+	DebtUSD       float64 `json:"debt_usd"`
+	HealthFactor  float64 `json:"health_factor"` // Liquidatable below 1; 0 when there is no debt
+}
+
+// HasDebt reports whether the address is borrowing
+func (h *AccountHealth) HasDebt() bool {
+	return h.DebtUSD > 0
+}
+
+// AavePool reads account health from an Aave v3 Pool contract
+type AavePool struct {
+	client *ethclient.Client
+	pool   common.Address
+}
+
+// NewAavePool creates a reader for the Aave v3 Pool at poolAddr
+func NewAavePool(rpcURL, poolAddr string) (*AavePool, error) {
+	if !common.IsHexAddress(poolAddr) {
+		return nil, fmt.Errorf("invalid Aave pool address %q", poolAddr)
+	}
+
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Ethereum client: %w", err)
+	}
+
+	return &AavePool{
+		client: client,
+		pool:   common.HexToAddress(poolAddr),
+	}, nil
+}
+
+// AccountHealth reads the user's collateral, debt and health factor
+func (p *AavePool) AccountHealth(ctx context.Context, user string) (*AccountHealth, error) {
+	if !common.IsHexAddress(user) {
+		return nil, fmt.Errorf("invalid address %q", user)
+	}
+
+	data, err := aavePool.Pack("getUserAccountData", common.HexToAddress(user))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode getUserAccountData call: %w", err)
+	}
+
+	result, err := p.client.CallContract(ctx, ethereum.CallMsg{To: &p.pool, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account data: %w", err)
+	}
+
+	return decodeAccountHealth(result)
+}
+
+// decodeAccountHealth decodes getUserAccountData return data. Accounts without debt
+// report the largest uint256 as their health factor; they get 0 instead.
+func decodeAccountHealth(result []byte) (*AccountHealth, error) {
+	values, err := aavePool.Unpack("getUserAccountData", result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode getUserAccountData result: %w", err)
+	}
+	if len(values) != 6 {
+		return nil, fmt.Errorf("unexpected getUserAccountData result %v", values)
+	}
+
+	amounts := make([]*big.Int, len(values))
+	for i, value := range values {
+		amount, ok := value.(*big.Int)
+		if !ok {
+			return nil, fmt.Errorf("unexpected getUserAccountData result %v", value)
+		}
+		amounts[i] = amount
+	}
+
+	health := &AccountHealth{
+		Protocol:      "aave",
+		CollateralUSD: weiToUnit(amounts[0], aaveBaseDecimals),
+		DebtUSD:       weiToUnit(amounts[1], aaveBaseDecimals),
+	}
+	if health.HasDebt() {
+		health.HealthFactor = weiToUnit(amounts[5], aaveHealthFactorDecimals)
+	}
+
+	return health, nil
+}
@@ -0,0 +1,48 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestDecodeAccountHealth(t *testing.T) {
+	usd := func(dollars int64) *big.Int {
+		return new(big.Int).Mul(big.NewInt(dollars), big.NewInt(1e8))
+	}
+	healthFactor, _ := new(big.Int).SetString("1050000000000000000", 10)
+
+	result, err := aavePool.Methods["getUserAccountData"].Outputs.Pack(
+		usd(10000), usd(7000), usd(500), big.NewInt(8250), big.NewInt(8000), healthFactor,
+	)
+	if err != nil {
+		t.Fatalf("Failed to encode result: %v", err)
+	}
+
+	health, err := decodeAccountHealth(result)
+	if err != nil {
+		t.Fatalf("Failed to decode result: %v", err)
+	}
+	if health.Protocol != "aave" || health.CollateralUSD != 10000 || health.DebtUSD != 7000 || health.HealthFactor != 1.05 {
+		t.Errorf("Unexpected account health: %+v", health)
+	}
+
+	// Without debt the pool reports the largest uint256
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	result, err = aavePool.Methods["getUserAccountData"].Outputs.Pack(
+		usd(10000), big.NewInt(0), usd(8000), big.NewInt(8250), big.NewInt(8000), maxUint256,
+	)
+	if err != nil {
+		t.Fatalf("Failed to encode result: %v", err)
+	}
+	health, err = decodeAccountHealth(result)
+	if err != nil {
+		t.Fatalf("Failed to decode result: %v", err)
+	}
+	if health.HasDebt() || health.HealthFactor != 0 {
+		t.Errorf("Expected no debt and no health factor, got %+v", health)
+	}
+
+	if _, err := decodeAccountHealth([]byte{0x01}); err == nil {
+		t.Error("Expected an error for truncated return data")
+	}
+}
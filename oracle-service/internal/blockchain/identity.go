@@ -0,0 +1,123 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+)
+
+// Identity describes the key an oracle client signs with and the contract it publishes
+// to, as seen on chain. Each lookup fails independently; failures are listed in
+// Errors and leave their fields empty.
+type Identity struct {
+	ChainID          string   `json:"chain_id"`
+	Contract         string   `json:"contract"`
+	ContractDeployed bool     `json:"contract_deployed"`
+	ContractPaused   bool     `json:"contract_paused"`
+	PayloadVersion   uint8    `json:"payload_version"`
+	Signer           string   `json:"signer"`
+	Nonce            uint64   `json:"nonce"`       // Next nonce, including pending transactions
+	BalanceWei       string   `json:"balance_wei"` // Native balance paying for gas
+	Balance          float64  `json:"balance"`     // Same balance in the chain's native token
+	NativeSymbol     string   `json:"native_symbol"`
+	Authorized       bool     `json:"authorized"` // Signer holds ORACLE_OPERATOR_ROLE on the contract
+	Errors           []string `json:"errors,omitempty"`
+}
+
+// Identity looks up the signer's nonce and balance and whether the contract is
+// deployed, paused and accepts the signer as an oracle operator
+func (oc *OracleClient) Identity(ctx context.Context) *Identity {
+	signer := oc.fromAddress()
+	identity := &Identity{
+		ChainID:        oc.chainID.String(),
+		Contract:       oc.contractAddress.Hex(),
+		PayloadVersion: oc.payloadVersion,
+		Signer:         signer.Hex(),
+		NativeSymbol:   oc.native.Symbol,
+	}
+	fail := func(format string, args ...interface{}) {
+		identity.Errors = append(identity.Errors, fmt.Sprintf(format, args...))
+	}
+
+	if nonce, err := oc.client.PendingNonceAt(ctx, signer); err != nil {
+		fail("failed to get nonce: %v", err)
+	} else {
+		identity.Nonce = nonce
+	}
+
+	if balance, err := oc.client.BalanceAt(ctx, signer, nil); err != nil {
+		fail("failed to get balance: %v", err)
+	} else {
+		identity.BalanceWei = balance.String()
+		identity.Balance = weiToUnit(balance, oc.native.Decimals)
+	}
+
+	code, err := oc.client.CodeAt(ctx, oc.contractAddress, nil)
+	if err != nil {
+		fail("failed to get contract code: %v", err)
+		return identity
+	}
+	identity.ContractDeployed = len(code) > 0
+	if !identity.ContractDeployed {
+		fail("no contract code at %s", identity.Contract)
+		return identity
+	}
+
+	if authorized, err := oc.callBool(ctx, "hasRole", OracleOperatorRole, signer); err != nil {
+		fail("failed to check signer role: %v", err)
+	} else {
+		identity.Authorized = authorized
+	}
+
+	if paused, err := oc.callBool(ctx, "paused"); err != nil {
+		fail("failed to check whether the contract is paused: %v", err)
+	} else {
+		identity.ContractPaused = paused
+	}
+
+	return identity
+}
+
+// SignerAuthorized checks whether the contract still accepts the signer as an oracle
+// operator, so publications from a revoked key can be stopped before they revert
+func (oc *OracleClient) SignerAuthorized(ctx context.Context) (bool, error) {
+	authorized, err := oc.callBool(ctx, "hasRole", OracleOperatorRole, oc.fromAddress())
+	if err != nil {
+		return false, errors.Blockchain("failed to check signer role: %w", err)
+	}
+	return authorized, nil
+}
+
+// callBool calls a view function of the oracle contract that returns a bool
+func (oc *OracleClient) callBool(ctx context.Context, method string, args ...interface{}) (bool, error) {
+	data, err := oracleABI.Pack(method, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode %s call: %w", method, err)
+	}
+
+	result, err := oc.client.CallContract(ctx, ethereum.CallMsg{
+		From: oc.fromAddress(),
+		To:   &oc.contractAddress,
+		Data: data,
+	}, nil)
+	if err != nil {
+		return false, err
+	}
+
+	return unpackBool(method, result)
+}
+
+// unpackBool decodes the return data of a view function that returns a bool
+func unpackBool(method string, result []byte) (bool, error) {
+	values, err := oracleABI.Unpack(method, result)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+	value, ok := values[0].(bool)
+	if !ok {
+		return false, fmt.Errorf("unexpected %s result %v", method, values[0])
+	}
+	return value, nil
+}
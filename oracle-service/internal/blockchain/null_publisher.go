@@ -0,0 +1,206 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Simulated gas of a null publisher transaction: a base cost plus a cost per score,
+// in line with the oracle contract's
+const (
+	simulatedBaseGas  = 21_000
+	simulatedScoreGas = 50_000
+)
+
+// TransactionRecorder stores the transactions a NullPublisher would have sent
+type TransactionRecorder interface {
+	RecordSimulatedTransaction(ctx context.Context, tx *models.SimulatedTransaction) error
+	NextSimulatedNonce(ctx context.Context, contract string) (uint64, error)
+}
+
+// NullPublisher stands in for the oracle client in staging soak tests. It encodes and
+// validates score updates exactly as the oracle client does, waits a jittered latency
+// as if sending, and records the would-be transaction instead of sending it. It never
+// contacts a chain.
+type NullPublisher struct {
+	recorder        TransactionRecorder // nil only logs transactions
+	contractAddress common.Address
+	chainID         *big.Int
+	latency         time.Duration
+	jitter          time.Duration
+	payloadVersion  uint8
+	batchGasLimit   uint64
+	maxBatchSize    int
+
+	sendMu sync.Mutex // Serializes sends, as the oracle client does, so nonces are ordered
+	nonce  uint64     // Next nonce when there is no recorder
+	rand   *rand.Rand
+}
+
+// NewNullPublisher creates a null publisher for the contract at contractAddr. Each
+// send takes latency plus a random share of jitter.
+func NewNullPublisher(recorder TransactionRecorder, contractAddr string, chainID int64, latency, jitter time.Duration) *NullPublisher {
+	return &NullPublisher{
+		recorder:        recorder,
+		contractAddress: common.HexToAddress(contractAddr),
+		chainID:         big.NewInt(chainID),
+		latency:         latency,
+		jitter:          jitter,
+		payloadVersion:  PayloadV1,
+		batchGasLimit:   defaultBatchGasLimit,
+		maxBatchSize:    defaultMaxBatchSize,
+		rand:            rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// SetPayloadVersion selects the encoding of the contract's ABI version
+func (p *NullPublisher) SetPayloadVersion(version uint8) error {
+	if !ValidPayloadVersion(version) {
+		return fmt.Errorf("unsupported payload version %d", version)
+	}
+	p.payloadVersion = version
+	return nil
+}
+
+// PayloadVersion returns the encoding used for the contract
+func (p *NullPublisher) PayloadVersion() uint8 {
+	return p.payloadVersion
+}
+
+// SetBatchLimits configures the gas ceiling and maximum size of a batch transaction
+func (p *NullPublisher) SetBatchLimits(gasLimit uint64, maxBatchSize int) {
+	if gasLimit > 0 {
+		p.batchGasLimit = gasLimit
+	}
+	if maxBatchSize > 0 {
+		p.maxBatchSize = maxBatchSize
+	}
+}
+
+// UpdateCreditScore records the transaction that would publish one score
+func (p *NullPublisher) UpdateCreditScore(ctx context.Context, update ScoreUpdate) (*types.Transaction, error) {
+	data, err := packScoreUpdate(p.payloadVersion, update)
+	if err != nil {
+		return nil, err
+	}
+	return p.send(ctx, data, 1)
+}
+
+// PublishScores records the batch transactions that would publish the updates, split
+// as the oracle client splits them
+func (p *NullPublisher) PublishScores(ctx context.Context, updates []ScoreUpdate) []PublishResult {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	chunks := chunkUpdates(updates, p.maxBatchSize, p.batchGasLimit, func(chunk []ScoreUpdate) (uint64, error) {
+		if _, err := packScoreUpdates(p.payloadVersion, chunk); err != nil {
+			return 0, err
+		}
+		return simulatedGas(len(chunk)), nil
+	})
+
+	results := make([]PublishResult, 0, len(chunks))
+	for _, chunk := range chunks {
+		result := PublishResult{Updates: chunk.updates, Batched: true, Err: chunk.err}
+		if chunk.err == nil {
+			data, err := packScoreUpdates(p.payloadVersion, chunk.updates)
+			if err == nil {
+				result.Tx, err = p.send(ctx, data, len(chunk.updates))
+			}
+			result.Err = err
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// HealthCheck always succeeds, as there is no chain to reach
+func (p *NullPublisher) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+// GetTransactionReceipt reports every simulated transaction as mined, so publications
+// are confirmed as they would be on a chain
+func (p *NullPublisher) GetTransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: txHash}, nil
+}
+
+// send waits out the simulated latency and records the unsigned transaction
+func (p *NullPublisher) send(ctx context.Context, data []byte, scores int) (*types.Transaction, error) {
+	p.sendMu.Lock()
+	defer p.sendMu.Unlock()
+
+	nonce := p.nonce
+	if p.recorder != nil {
+		var err error
+		nonce, err = p.recorder.NextSimulatedNonce(ctx, p.contractAddress.Hex())
+		if err != nil {
+			return nil, errors.Blockchain("failed to get nonce: %w", err)
+		}
+	}
+
+	delay := p.latency
+	if p.jitter > 0 {
+		delay += time.Duration(p.rand.Int63n(int64(p.jitter)))
+	}
+	select {
+	case <-ctx.Done():
+		return nil, errors.Blockchain("failed to send transaction: %w", ctx.Err())
+	case <-time.After(delay):
+	}
+
+	gas := simulatedGas(scores)
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID: p.chainID,
+		Nonce:   nonce,
+		Gas:     gas,
+		To:      &p.contractAddress,
+		Value:   big.NewInt(0),
+		Data:    data,
+	})
+
+	record := &models.SimulatedTransaction{
+		TxHash:         tx.Hash().Hex(),
+		Contract:       p.contractAddress.Hex(),
+		Nonce:          nonce,
+		Scores:         scores,
+		PayloadVersion: p.payloadVersion,
+		Gas:            gas,
+		LatencyMs:      delay.Milliseconds(),
+	}
+	if method, err := oracleABI.MethodById(data); err == nil {
+		record.Method = method.Name
+	}
+	if p.recorder != nil {
+		if err := p.recorder.RecordSimulatedTransaction(ctx, record); err != nil {
+			return nil, errors.Blockchain("failed to send transaction: %w", err)
+		}
+	}
+	p.nonce = nonce + 1
+
+	logger.Info("Simulated oracle transaction",
+		zap.String("txHash", record.TxHash),
+		zap.String("method", record.Method),
+		zap.Int("scores", scores),
+		zap.Duration("latency", delay),
+	)
+	return tx, nil
+}
+
+// simulatedGas is the gas a transaction publishing the given number of scores is
+// assumed to use
+func simulatedGas(scores int) uint64 {
+	return simulatedBaseGas + uint64(scores)*simulatedScoreGas
+}
@@ -0,0 +1,88 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+type memoryRecorder struct {
+	txs []*models.SimulatedTransaction
+}
+
+func (r *memoryRecorder) RecordSimulatedTransaction(ctx context.Context, tx *models.SimulatedTransaction) error {
+	r.txs = append(r.txs, tx)
+	return nil
+}
+
+func (r *memoryRecorder) NextSimulatedNonce(ctx context.Context, contract string) (uint64, error) {
+	return uint64(len(r.txs)), nil
+}
+
+func TestNullPublisherRecordsTransactions(t *testing.T) {
+	ctx := context.Background()
+	recorder := &memoryRecorder{}
+	publisher := NewNullPublisher(recorder, "0x00000000000000000000000000000000000000aa", 1, 0, 0)
+
+	tx, err := publisher.UpdateCreditScore(ctx, testUpdates(1)[0])
+	if err != nil {
+		t.Fatalf("Failed to publish: %v", err)
+	}
+	if len(recorder.txs) != 1 || recorder.txs[0].TxHash != tx.Hash().Hex() {
+		t.Fatalf("Expected the transaction recorded, got %+v", recorder.txs)
+	}
+	if recorder.txs[0].Method != "updateCreditScore" || recorder.txs[0].Scores != 1 {
+		t.Errorf("Expected a single score update, got %+v", recorder.txs[0])
+	}
+
+	// Updates the contract would reject are rejected just the same
+	invalid := testUpdates(1)[0]
+	invalid.UserAddress = "not-an-address"
+	if _, err := publisher.UpdateCreditScore(ctx, invalid); err == nil {
+		t.Error("Expected an invalid address rejected")
+	}
+	if len(recorder.txs) != 1 {
+		t.Errorf("Expected nothing recorded for a rejected update, got %d transactions", len(recorder.txs))
+	}
+
+	publisher.SetBatchLimits(0, 2)
+	results := publisher.PublishScores(ctx, testUpdates(5))
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 batch transactions, got %d", len(results))
+	}
+	for i, result := range results {
+		if result.Err != nil || !result.Batched || result.Tx == nil {
+			t.Fatalf("Expected batch %d recorded, got %+v", i, result)
+		}
+	}
+	last := recorder.txs[len(recorder.txs)-1]
+	if last.Method != "updateScores" || last.Scores != 1 || last.Nonce != 3 {
+		t.Errorf("Expected the last batch of one score at nonce 3, got %+v", last)
+	}
+}
+
+func TestNullPublisherLatency(t *testing.T) {
+	publisher := NewNullPublisher(nil, "", 1, time.Hour, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := publisher.UpdateCreditScore(ctx, testUpdates(1)[0]); err == nil {
+		t.Fatal("Expected a send cut short by the context to fail")
+	}
+
+	// Without a recorder nonces are counted in memory
+	publisher = NewNullPublisher(nil, "", 1, time.Millisecond, 5*time.Millisecond)
+	first, err := publisher.UpdateCreditScore(context.Background(), testUpdates(1)[0])
+	if err != nil {
+		t.Fatalf("Failed to publish: %v", err)
+	}
+	second, err := publisher.UpdateCreditScore(context.Background(), testUpdates(1)[0])
+	if err != nil {
+		t.Fatalf("Failed to publish: %v", err)
+	}
+	if first.Nonce() != 0 || second.Nonce() != 1 || first.Hash() == second.Hash() {
+		t.Errorf("Expected consecutive nonces, got %d and %d", first.Nonce(), second.Nonce())
+	}
+}
@@ -5,12 +5,18 @@ import (
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
+	"sync"
+	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/chains"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
 	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -21,13 +27,25 @@ type OracleClient struct {
 	contractAddress common.Address
 	privateKey      *ecdsa.PrivateKey
 	chainID         *big.Int
+	native          chains.NativeAsset // Coin gas is paid in
+	sendMu          sync.Mutex
+	payloadVersion  uint8 // Encoding the contract accepts
+
+	// Batch publishing
+	batchGasLimit uint64
+	maxBatchSize  int
+	batchMu       sync.Mutex
+	batchSupport  *bool // nil until the contract has been probed for updateScores
 }
 
+// Gas limit for a transaction is the estimate plus 20% headroom
+const gasLimitHeadroomPercent = 120
+
 // NewOracleClient creates a new blockchain oracle client
 func NewOracleClient(rpcURL, contractAddr, privateKeyHex string) (*OracleClient, error) {
 	client, err := ethclient.Dial(rpcURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to ethereum node: %w", err)
+		return nil, errors.Blockchain("failed to connect to ethereum node: %w", err)
 	}
 
 	privateKey, err := crypto.HexToECDSA(privateKeyHex)
@@ -37,7 +55,7 @@ func NewOracleClient(rpcURL, contractAddr, privateKeyHex string) (*OracleClient,
 
 	chainID, err := client.ChainID(context.Background())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+		return nil, errors.Blockchain("failed to get chain ID: %w", err)
 	}
 
 	return &OracleClient{
@@ -45,74 +63,127 @@ func NewOracleClient(rpcURL, contractAddr, privateKeyHex string) (*OracleClient,
 		contractAddress: common.HexToAddress(contractAddr),
 		privateKey:      privateKey,
 		chainID:         chainID,
+		native:          chains.Native(chainID.String()),
+		payloadVersion:  PayloadV1,
+		batchGasLimit:   defaultBatchGasLimit,
+		maxBatchSize:    defaultMaxBatchSize,
 	}, nil
 }
 
+// SetPayloadVersion selects the encoding of the contract's ABI version. Contracts
+// deployed before v2 only accept v1 payloads.
+func (oc *OracleClient) SetPayloadVersion(version uint8) error {
+	if !ValidPayloadVersion(version) {
+		return fmt.Errorf("unsupported payload version %d", version)
+	}
+	oc.payloadVersion = version
+	return nil
+}
+
+// PayloadVersion returns the encoding used for the contract
+func (oc *OracleClient) PayloadVersion() uint8 {
+	return oc.payloadVersion
+}
+
+// ChainID returns the ID of the chain the contract is on
+func (oc *OracleClient) ChainID() string {
+	return oc.chainID.String()
+}
+
 // UpdateCreditScore submits a credit score update to the blockchain
-func (oc *OracleClient) UpdateCreditScore(
-	ctx context.Context,
-	userAddress string,
-	score uint16,
-	confidence uint8,
-	dataHash string,
-) (*types.Transaction, error) {
+func (oc *OracleClient) UpdateCreditScore(ctx context.Context, update ScoreUpdate) (*types.Transaction, error) {
+	data, err := packScoreUpdate(oc.payloadVersion, update)
+	if err != nil {
+		return nil, err
+	}
+
+	gasLimit, err := oc.estimateCallGas(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Submitting credit score update",
+		zap.String("user", update.UserAddress),
+		zap.Uint16("score", update.Score.Uint16()),
+		zap.Uint8("confidence", update.Confidence.Uint8()),
+		zap.String("dataHash", update.DataHash),
+		zap.Uint8("payloadVersion", oc.payloadVersion),
+	)
 
-	// Get the public address from private key
-	publicKey := oc.privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return nil, fmt.Errorf("error casting public key to ECDSA")
+	return oc.sendTransaction(ctx, data, gasLimit)
+}
+
+// estimateCallGas estimates gas for calling the oracle contract with the given calldata
+func (oc *OracleClient) estimateCallGas(ctx context.Context, data []byte) (uint64, error) {
+	gas, err := oc.client.EstimateGas(ctx, ethereum.CallMsg{
+		From: oc.fromAddress(),
+		To:   &oc.contractAddress,
+		Data: data,
+	})
+	if err != nil {
+		return 0, errors.Blockchain("failed to estimate gas: %w", err)
 	}
+	return gas, nil
+}
 
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
+// sendTransaction signs and submits an EIP-1559 transaction to the oracle contract.
+// Sends are serialized so concurrent publishes don't reuse a nonce.
+func (oc *OracleClient) sendTransaction(ctx context.Context, data []byte, estimatedGas uint64) (*types.Transaction, error) {
+	oc.sendMu.Lock()
+	defer oc.sendMu.Unlock()
 
-	// Get nonce
-	nonce, err := oc.client.PendingNonceAt(ctx, fromAddress)
+	nonce, err := oc.client.PendingNonceAt(ctx, oc.fromAddress())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get nonce: %w", err)
+		return nil, errors.Blockchain("failed to get nonce: %w", err)
 	}
 
-	// Get gas price
-	gasPrice, err := oc.client.SuggestGasPrice(ctx)
+	header, err := oc.client.HeaderByNumber(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get gas price: %w", err)
+		return nil, errors.Blockchain("failed to get latest block: %w", err)
 	}
 
-	// Create auth transactor
-	auth, err := bind.NewKeyedTransactorWithChainID(oc.privateKey, oc.chainID)
+	tip, err := oc.client.SuggestGasTipCap(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create transactor: %w", err)
+		return nil, errors.Blockchain("failed to get priority fee: %w", err)
 	}
 
-	auth.Nonce = big.NewInt(int64(nonce))
-	auth.Value = big.NewInt(0)
-	auth.GasLimit = uint64(300000)
-	auth.GasPrice = gasPrice
+	baseFee := header.BaseFee
+	if baseFee == nil {
+		baseFee = big.NewInt(0)
+	}
 
-	// In production, you would use the generated contract binding
-	// For now, we'll create a raw transaction
-	// This is a placeholder - actual implementation would use contract ABI
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   oc.chainID,
+		Nonce:     nonce,
+		GasTipCap: tip,
+		GasFeeCap: new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), tip),
+		Gas:       estimatedGas * gasLimitHeadroomPercent / 100,
+		To:        &oc.contractAddress,
+		Value:     big.NewInt(0),
+		Data:      data,
+	})
+
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(oc.chainID), oc.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
 
-	logger.Info("Submitting credit score update",
-		zap.String("user", userAddress),
-		zap.Uint16("score", score),
-		zap.Uint8("confidence", confidence),
-		zap.String("dataHash", dataHash),
-	)
+	if err := oc.client.SendTransaction(ctx, signedTx); err != nil {
+		return nil, errors.Blockchain("failed to send transaction: %w", err)
+	}
 
-	// TODO: Replace with actual contract call using generated bindings
-	// Example:
-	// contract, err := NewCreditScoreOracle(oc.contractAddress, oc.client)
-	// tx, err := contract.UpdateScore(auth, common.HexToAddress(userAddress), score, dataHash)
+	logger.Info("Oracle transaction submitted", zap.String("txHash", signedTx.Hash().Hex()))
 
-	// For now, return a mock transaction hash
-	logger.Info("Credit score update submitted (mock)")
+	return signedTx, nil
+}
 
-	return nil, nil // Placeholder
+// fromAddress returns the operator address derived from the signing key
+func (oc *OracleClient) fromAddress() common.Address {
+	return crypto.PubkeyToAddress(oc.privateKey.PublicKey)
 }
 
 // GetCreditScore retrieves a credit score from the blockchain
-func (oc *OracleClient) GetCreditScore(ctx context.Context, userAddress string) (uint16, uint8, string, error) {
+func (oc *OracleClient) GetCreditScore(ctx context.Context, userAddress string) (units.Score, units.Confidence, string, error) {
 	// In production, this would call the contract's view function
 	// Using the generated contract binding
 
@@ -129,52 +200,11 @@ func (oc *OracleClient) GetCreditScore(ctx context.Context, userAddress string)
 	return 0, 0, "", fmt.Errorf("not implemented - requires contract binding")
 }
 
-// SignData creates a cryptographic signature of the score data
-func (oc *OracleClient) SignData(userAddress string, score uint16, confidence uint8, dataHash string) ([]byte, error) {
-	// Create message to sign
-	message := fmt.Sprintf("%s:%d:%d:%s", userAddress, score, confidence, dataHash)
-	messageHash := crypto.Keccak256Hash([]byte(message))
-
-	// Sign the message
-	signature, err := crypto.Sign(messageHash.Bytes(), oc.privateKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign data: %w", err)
-	}
-
-	return signature, nil
-}
-
-// VerifySignature verifies a signature against oracle data
-func (oc *OracleClient) VerifySignature(
-	userAddress string,
-	score uint16,
-	confidence uint8,
-	dataHash string,
-	signature []byte,
-) (bool, error) {
-
-	// Recreate message
-	message := fmt.Sprintf("%s:%d:%d:%s", userAddress, score, confidence, dataHash)
-	messageHash := crypto.Keccak256Hash([]byte(message))
-
-	// Recover public key from signature
-	pubKey, err := crypto.SigToPub(messageHash.Bytes(), signature)
-	if err != nil {
-		return false, fmt.Errorf("failed to recover public key: %w", err)
-	}
-
-	// Get expected address
-	recoveredAddr := crypto.PubkeyToAddress(*pubKey)
-	expectedAddr := crypto.PubkeyToAddress(*oc.privateKey.Public().(*ecdsa.PublicKey))
-
-	return recoveredAddr == expectedAddr, nil
-}
-
 // GetTransactionReceipt gets the receipt for a transaction
 func (oc *OracleClient) GetTransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
 	receipt, err := oc.client.TransactionReceipt(ctx, txHash)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get transaction receipt: %w", err)
+		return nil, errors.Blockchain("failed to get transaction receipt: %w", err)
 	}
 	return receipt, nil
 }
@@ -183,11 +213,11 @@ func (oc *OracleClient) GetTransactionReceipt(ctx context.Context, txHash common
 func (oc *OracleClient) WaitForConfirmation(ctx context.Context, txHash common.Hash, confirmations uint64) error {
 	receipt, err := bind.WaitMined(ctx, oc.client, &types.Transaction{})
 	if err != nil {
-		return fmt.Errorf("transaction failed: %w", err)
+		return errors.Blockchain("transaction failed: %w", err)
 	}
 
 	if receipt.Status != types.ReceiptStatusSuccessful {
-		return fmt.Errorf("transaction failed with status: %d", receipt.Status)
+		return errors.Blockchain("transaction failed with status: %d", receipt.Status)
 	}
 
 	return nil
@@ -200,6 +230,123 @@ func (oc *OracleClient) EstimateGas(ctx context.Context) (uint64, error) {
 	return 200000, nil
 }
 
+// PublishCostEstimate is the expected cost of publishing one score update
+type PublishCostEstimate struct {
+	GasLimit        uint64    `json:"gas_limit"`
+	BaseFeeGwei     float64   `json:"base_fee_gwei"`
+	PriorityFeeGwei float64   `json:"priority_fee_gwei"`
+	MaxFeeGwei      float64   `json:"max_fee_gwei"`
+	FeeWei          string    `json:"fee_wei"`    // gas limit x (base fee + priority fee)
+	FeeNative       float64   `json:"fee_native"` // Same fee in the chain's native token
+	MaxFeeNative    float64   `json:"max_fee_native"`
+	NativeSymbol    string    `json:"native_symbol"`  // The chain's native token
+	GasUsedRatio    float64   `json:"gas_used_ratio"` // Latest block gas used / gas limit
+	Congestion      string    `json:"congestion"`
+	BlockNumber     uint64    `json:"block_number"`
+	EstimatedAt     time.Time `json:"estimated_at"`
+}
+
+// Network congestion levels derived from block fullness
+const (
+	CongestionLow      = "low"
+	CongestionModerate = "moderate"
+	CongestionHigh     = "high"
+)
+
+// CongestionLevel classifies block fullness. EIP-1559 targets half-full blocks, so the
+// base fee only starts rising above 50% and climbs quickly past 80%.
+func CongestionLevel(gasUsedRatio float64) string {
+	switch {
+	case gasUsedRatio > 0.8:
+		return CongestionHigh
+	case gasUsedRatio > 0.5:
+		return CongestionModerate
+	default:
+		return CongestionLow
+	}
+}
+
+// EstimatePublishCost estimates gas and fees for publishing one score
+func (oc *OracleClient) EstimatePublishCost(ctx context.Context, update ScoreUpdate) (*PublishCostEstimate, error) {
+	data, err := packScoreUpdate(oc.payloadVersion, update)
+	if err != nil {
+		return nil, err
+	}
+
+	gasLimit, err := oc.estimateCallGas(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := oc.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, errors.Blockchain("failed to get latest block: %w", err)
+	}
+
+	tip, err := oc.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, errors.Blockchain("failed to get priority fee: %w", err)
+	}
+
+	// Pre-London chains have no base fee; the legacy gas price covers the whole fee
+	baseFee := header.BaseFee
+	if baseFee == nil {
+		gasPrice, err := oc.client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, errors.Blockchain("failed to get gas price: %w", err)
+		}
+		baseFee = gasPrice
+		tip = big.NewInt(0)
+	}
+
+	// Same headroom geth's transactor uses: the base fee can double before the tx is priced out
+	maxFee := new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), tip)
+	expectedFee := new(big.Int).Mul(new(big.Int).Add(baseFee, tip), new(big.Int).SetUint64(gasLimit))
+	maxTotal := new(big.Int).Mul(maxFee, new(big.Int).SetUint64(gasLimit))
+
+	var gasUsedRatio float64
+	if header.GasLimit > 0 {
+		gasUsedRatio = float64(header.GasUsed) / float64(header.GasLimit)
+	}
+
+	return &PublishCostEstimate{
+		GasLimit:        gasLimit,
+		BaseFeeGwei:     weiToUnit(baseFee, 9),
+		PriorityFeeGwei: weiToUnit(tip, 9),
+		MaxFeeGwei:      weiToUnit(maxFee, 9),
+		FeeWei:          expectedFee.String(),
+		FeeNative:       weiToUnit(expectedFee, oc.native.Decimals),
+		MaxFeeNative:    weiToUnit(maxTotal, oc.native.Decimals),
+		NativeSymbol:    oc.native.Symbol,
+		GasUsedRatio:    gasUsedRatio,
+		Congestion:      CongestionLevel(gasUsedRatio),
+		BlockNumber:     header.Number.Uint64(),
+		EstimatedAt:     time.Now(),
+	}, nil
+}
+
+// CurrentBaseFeeGwei returns the latest block's base fee in gwei
+func (oc *OracleClient) CurrentBaseFeeGwei(ctx context.Context) (float64, error) {
+	header, err := oc.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, errors.Blockchain("failed to get latest block: %w", err)
+	}
+	if header.BaseFee == nil {
+		return 0, errors.Blockchain("chain does not report a base fee")
+	}
+	return weiToUnit(header.BaseFee, 9), nil
+}
+
+// weiToUnit converts a wei amount into gwei (decimals 9) or whole tokens (the token's
+// decimals)
+func weiToUnit(wei *big.Int, decimals int) float64 {
+	value, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(wei),
+		new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)),
+	).Float64()
+	return value
+}
+
 // GetBlockNumber gets the current block number
 func (oc *OracleClient) GetBlockNumber(ctx context.Context) (uint64, error) {
 	return oc.client.BlockNumber(ctx)
@@ -216,7 +363,7 @@ func (oc *OracleClient) Close() {
 func (oc *OracleClient) HealthCheck(ctx context.Context) error {
 	_, err := oc.client.BlockNumber(ctx)
 	if err != nil {
-		return fmt.Errorf("blockchain health check failed: %w", err)
+		return errors.Blockchain("blockchain health check failed: %w", err)
 	}
 	return nil
 }
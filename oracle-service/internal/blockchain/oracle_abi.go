@@ -0,0 +1,244 @@
+package blockchain
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// creditScoreOracleABI is the subset of the CreditScoreOracle contract ABI used by the service
+const creditScoreOracleABI = `[
+	{
+		"type": "function",
+		"name": "updateCreditScore",
+		"stateMutability": "nonpayable",
+		"inputs": [
+			{"name": "userAddress", "type": "address"},
+			{"name": "creditScore", "type": "uint256"},
+			{"name": "riskLevel", "type": "uint8"},
+			{"name": "additionalData", "type": "bytes"}
+		],
+		"outputs": []
+	},
+	{
+		"type": "function",
+		"name": "updateScores",
+		"stateMutability": "nonpayable",
+		"inputs": [
+			{"name": "userAddresses", "type": "address[]"},
+			{"name": "creditScores", "type": "uint16[]"},
+			{"name": "riskLevels", "type": "uint8[]"},
+			{"name": "additionalData", "type": "bytes[]"}
+		],
+		"outputs": []
+	},
+	{
+		"type": "function",
+		"name": "updateScoreWithExpiry",
+		"stateMutability": "nonpayable",
+		"inputs": [
+			{"name": "userAddress", "type": "address"},
+			{"name": "creditScore", "type": "uint16"},
+			{"name": "riskLevel", "type": "uint8"},
+			{"name": "additionalData", "type": "bytes"},
+			{"name": "expiresAt", "type": "uint64"}
+		],
+		"outputs": []
+	},
+	{
+		"type": "function",
+		"name": "updateScoresWithExpiry",
+		"stateMutability": "nonpayable",
+		"inputs": [
+			{"name": "userAddresses", "type": "address[]"},
+			{"name": "creditScores", "type": "uint16[]"},
+			{"name": "riskLevels", "type": "uint8[]"},
+			{"name": "additionalData", "type": "bytes[]"},
+			{"name": "expiresAt", "type": "uint64[]"}
+		],
+		"outputs": []
+	},
+	{
+		"type": "function",
+		"name": "hasRole",
+		"stateMutability": "view",
+		"inputs": [
+			{"name": "role", "type": "bytes32"},
+			{"name": "account", "type": "address"}
+		],
+		"outputs": [{"name": "", "type": "bool"}]
+	},
+	{
+		"type": "function",
+		"name": "paused",
+		"stateMutability": "view",
+		"inputs": [],
+		"outputs": [{"name": "", "type": "bool"}]
+	}
+]`
+
+// OracleOperatorRole is the contract role allowed to publish scores
+var OracleOperatorRole = crypto.Keccak256Hash([]byte("ORACLE_OPERATOR_ROLE"))
+
+// Oracle contract payload versions. Deployed contracts accept exactly one of them,
+// so the version is configured per contract.
+const (
+	PayloadV1 uint8 = 1 // updateCreditScore / updateScores
+	PayloadV2 uint8 = 2 // updateScoreWithExpiry / updateScoresWithExpiry, adds an expiry timestamp
+)
+
+// ValidPayloadVersion reports whether the payload version is supported
+func ValidPayloadVersion(version uint8) bool {
+	return version == PayloadV1 || version == PayloadV2
+}
+
+var (
+	oracleABI abi.ABI
+
+	// additionalData layout: abi.encode(uint8 confidence, bytes32 dataHash)
+	additionalDataArgs abi.Arguments
+)
+
+func init() {
+	var err error
+	oracleABI, err = abi.JSON(strings.NewReader(creditScoreOracleABI))
+	if err != nil {
+		panic(fmt.Sprintf("invalid oracle ABI: %v", err))
+	}
+
+	uint8Type, _ := abi.NewType("uint8", "", nil)
+	bytes32Type, _ := abi.NewType("bytes32", "", nil)
+	additionalDataArgs = abi.Arguments{{Type: uint8Type}, {Type: bytes32Type}}
+}
+
+// RiskLevelForScore maps a 300-850 credit score onto the contract's 1 (lowest) to 5 (highest) risk levels
+func RiskLevelForScore(score units.Score) uint8 {
+	switch {
+	case score >= 750:
+		return 1
+	case score >= 700:
+		return 2
+	case score >= 650:
+		return 3
+	case score >= 600:
+		return 4
+	default:
+		return 5
+	}
+}
+
+// packScoreUpdate builds the calldata for publishing one score in the given payload version
+func packScoreUpdate(version uint8, update ScoreUpdate) ([]byte, error) {
+	user, additionalData, err := encodeScoreUpdate(update)
+	if err != nil {
+		return nil, err
+	}
+
+	// Both versions take the validated score as a uint16; v1's updateCreditScore widens
+	// it to uint256
+	score := update.Score.Uint16()
+	switch version {
+	case PayloadV1:
+		return oracleABI.Pack(
+			"updateCreditScore",
+			user,
+			new(big.Int).SetUint64(uint64(score)),
+			RiskLevelForScore(update.Score),
+			additionalData,
+		)
+	case PayloadV2:
+		expiresAt, err := expiryTimestamp(update)
+		if err != nil {
+			return nil, err
+		}
+		return oracleABI.Pack(
+			"updateScoreWithExpiry",
+			user,
+			score,
+			RiskLevelForScore(update.Score),
+			additionalData,
+			expiresAt,
+		)
+	default:
+		return nil, fmt.Errorf("unsupported payload version %d", version)
+	}
+}
+
+// packScoreUpdates builds the calldata for the batch update function of the given
+// payload version
+func packScoreUpdates(version uint8, updates []ScoreUpdate) ([]byte, error) {
+	users := make([]common.Address, len(updates))
+	scores := make([]uint16, len(updates))
+	riskLevels := make([]uint8, len(updates))
+	additionalData := make([][]byte, len(updates))
+	expiries := make([]uint64, len(updates))
+
+	for i, update := range updates {
+		user, data, err := encodeScoreUpdate(update)
+		if err != nil {
+			return nil, err
+		}
+		users[i] = user
+		scores[i] = update.Score.Uint16()
+		riskLevels[i] = RiskLevelForScore(update.Score)
+		additionalData[i] = data
+
+		if version == PayloadV2 {
+			if expiries[i], err = expiryTimestamp(update); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	switch version {
+	case PayloadV1:
+		return oracleABI.Pack("updateScores", users, scores, riskLevels, additionalData)
+	case PayloadV2:
+		return oracleABI.Pack("updateScoresWithExpiry", users, scores, riskLevels, additionalData, expiries)
+	default:
+		return nil, fmt.Errorf("unsupported payload version %d", version)
+	}
+}
+
+// expiryTimestamp returns the update's expiry as a unix timestamp
+func expiryTimestamp(update ScoreUpdate) (uint64, error) {
+	if update.ExpiresAt.IsZero() {
+		return 0, fmt.Errorf("score update for %s has no expiry", update.UserAddress)
+	}
+	return uint64(update.ExpiresAt.Unix()), nil
+}
+
+// encodeScoreUpdate validates the update and encodes confidence and data hash as additionalData
+func encodeScoreUpdate(update ScoreUpdate) (common.Address, []byte, error) {
+	if !common.IsHexAddress(update.UserAddress) {
+		return common.Address{}, nil, errors.Validation("invalid user address: %s", update.UserAddress)
+	}
+	if !update.Score.Valid() {
+		return common.Address{}, nil, errors.Validation("score %d for %s is outside valid range [%d-%d]",
+			update.Score, update.UserAddress, units.MinScore, units.MaxScore)
+	}
+	if update.Confidence > units.MaxConfidence {
+		return common.Address{}, nil, errors.Validation("confidence %d for %s is above %d",
+			update.Confidence, update.UserAddress, units.MaxConfidence)
+	}
+
+	hashBytes := common.FromHex(update.DataHash)
+	if len(hashBytes) > 32 {
+		return common.Address{}, nil, errors.Validation("data hash longer than 32 bytes")
+	}
+	var hash [32]byte
+	copy(hash[32-len(hashBytes):], hashBytes)
+
+	additionalData, err := additionalDataArgs.Pack(update.Confidence.Uint8(), hash)
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("failed to encode additional data: %w", err)
+	}
+
+	return common.HexToAddress(update.UserAddress), additionalData, nil
+}
@@ -0,0 +1,141 @@
+package blockchain
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+func TestRiskLevelForScore(t *testing.T) {
+	tests := []struct {
+		score    units.Score
+		expected uint8
+	}{
+		{820, 1},
+		{750, 1},
+		{720, 2},
+		{660, 3},
+		{610, 4},
+		{450, 5},
+	}
+
+	for _, tt := range tests {
+		if got := RiskLevelForScore(tt.score); got != tt.expected {
+			t.Errorf("RiskLevelForScore(%d) = %d, expected %d", tt.score, got, tt.expected)
+		}
+	}
+}
+
+func TestCongestionLevel(t *testing.T) {
+	if got := CongestionLevel(0.3); got != CongestionLow {
+		t.Errorf("Expected low congestion, got %s", got)
+	}
+	if got := CongestionLevel(0.65); got != CongestionModerate {
+		t.Errorf("Expected moderate congestion, got %s", got)
+	}
+	if got := CongestionLevel(0.95); got != CongestionHigh {
+		t.Errorf("Expected high congestion, got %s", got)
+	}
+}
+
+func TestPackUpdateCreditScore(t *testing.T) {
+	update := ScoreUpdate{
+		UserAddress: "0x1234567890123456789012345678901234567890",
+		Score:       720,
+		Confidence:  85,
+		DataHash:    "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+	}
+	data, err := packScoreUpdate(PayloadV1, update)
+	if err != nil {
+		t.Fatalf("Failed to pack calldata: %v", err)
+	}
+
+	method := oracleABI.Methods["updateCreditScore"]
+	if !bytes.Equal(data[:4], method.ID) {
+		t.Errorf("Expected selector %x, got %x", method.ID, data[:4])
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		t.Fatalf("Failed to unpack calldata: %v", err)
+	}
+	if args[1].(*big.Int).Uint64() != 720 {
+		t.Errorf("Expected score 720 in calldata, got %v", args[1])
+	}
+
+	if _, err := packScoreUpdate(PayloadV1, ScoreUpdate{UserAddress: "not-an-address", Score: 720, Confidence: 85}); err == nil {
+		t.Error("Expected error for invalid user address")
+	}
+
+	address := "0x1234567890123456789012345678901234567890"
+	if _, err := packScoreUpdate(PayloadV1, ScoreUpdate{UserAddress: address, Score: 0, Confidence: 85}); err == nil {
+		t.Error("Expected error for a missing score")
+	}
+	if _, err := packScoreUpdate(PayloadV1, ScoreUpdate{UserAddress: address, Score: 720, Confidence: 185}); err == nil {
+		t.Error("Expected error for confidence above 100")
+	}
+}
+
+func TestPackUpdateScoreWithExpiry(t *testing.T) {
+	update := ScoreUpdate{
+		UserAddress: "0x1234567890123456789012345678901234567890",
+		Score:       720,
+		Confidence:  85,
+		ExpiresAt:   time.Unix(1735689600, 0),
+	}
+	data, err := packScoreUpdate(PayloadV2, update)
+	if err != nil {
+		t.Fatalf("Failed to pack calldata: %v", err)
+	}
+
+	// The ABI matches the contract's function
+	method := oracleABI.Methods["updateScoreWithExpiry"]
+	if !bytes.Equal(method.ID, crypto.Keccak256([]byte("updateScoreWithExpiry(address,uint16,uint8,bytes,uint64)"))[:4]) {
+		t.Fatalf("ABI doesn't match the contract's updateScoreWithExpiry")
+	}
+	if !bytes.Equal(data[:4], method.ID) {
+		t.Fatalf("Expected selector %x, got %x", method.ID, data[:4])
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		t.Fatalf("Failed to unpack calldata: %v", err)
+	}
+	if args[1].(uint16) != 720 || args[4].(uint64) != 1735689600 {
+		t.Errorf("Unexpected score or expiry in calldata: %v", args)
+	}
+
+	if _, err := packScoreUpdate(3, update); err == nil {
+		t.Error("Expected error for unsupported payload version")
+	}
+}
+
+func TestHasRoleCall(t *testing.T) {
+	if OracleOperatorRole != crypto.Keccak256Hash([]byte("ORACLE_OPERATOR_ROLE")) {
+		t.Fatal("Unexpected operator role")
+	}
+
+	signer := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	data, err := oracleABI.Pack("hasRole", OracleOperatorRole, signer)
+	if err != nil {
+		t.Fatalf("Failed to pack hasRole: %v", err)
+	}
+	// selector + role + address
+	if len(data) != 4+32+32 {
+		t.Fatalf("Expected 68 bytes of calldata, got %d", len(data))
+	}
+	if !bytes.Equal(data[:4], crypto.Keccak256([]byte("hasRole(bytes32,address)"))[:4]) {
+		t.Errorf("Unexpected selector %x", data[:4])
+	}
+
+	authorized, err := unpackBool("hasRole", common.LeftPadBytes([]byte{1}, 32))
+	if err != nil || !authorized {
+		t.Errorf("Expected true, got %v (%v)", authorized, err)
+	}
+	if _, err := unpackBool("paused", nil); err == nil {
+		t.Error("Expected an error for empty return data")
+	}
+}
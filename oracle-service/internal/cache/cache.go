@@ -0,0 +1,85 @@
+// Package cache keeps in-memory copies of per-address data and drops them as soon
+// as the address's stored data changes, so reads never outlive a data push.
+package cache
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Reasons an address's cached data is invalidated
+const (
+	ReasonOnChainMetrics  = "on_chain_metrics"  // On-chain metrics were upserted
+	ReasonOffChainMetrics = "off_chain_metrics" // Off-chain metrics were upserted
+	ReasonScore           = "score"             // The score or its publish status was written
+	ReasonRestore         = "restore"           // Every address's data was replaced
+)
+
+// Invalidation tells subscribers that an address's stored data changed. An empty
+// Address means every address.
+type Invalidation struct {
+	Address string
+	Reason  string
+	At      time.Time
+}
+
+// All reports whether the invalidation covers every address
+func (i Invalidation) All() bool {
+	return i.Address == ""
+}
+
+// Subscriber receives invalidations. It is called synchronously by the writer, so
+// it must not block.
+type Subscriber func(Invalidation)
+
+// Bus delivers invalidations to every subscriber before the write that caused them
+// returns. A nil Bus drops them.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers []Subscriber
+}
+
+// NewBus creates a bus without subscribers
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe adds a subscriber to every later invalidation
+func (b *Bus) Subscribe(fn Subscriber) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, fn)
+}
+
+// Invalidate tells subscribers an address's data changed
+func (b *Bus) Invalidate(address, reason string) {
+	if address = normalize(address); address == "" {
+		return
+	}
+	b.publish(Invalidation{Address: address, Reason: reason, At: time.Now().UTC()})
+}
+
+// InvalidateAll tells subscribers every address's data changed
+func (b *Bus) InvalidateAll(reason string) {
+	b.publish(Invalidation{Reason: reason, At: time.Now().UTC()})
+}
+
+func (b *Bus) publish(invalidation Invalidation) {
+	if b == nil {
+		return
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, fn := range b.subscribers {
+		fn(invalidation)
+	}
+}
+
+// normalize is the form addresses are keyed by, matching the repository's
+func normalize(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
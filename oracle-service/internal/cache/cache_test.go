@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStoreInvalidation(t *testing.T) {
+	bus := NewBus()
+	store := NewStore[int](time.Minute, bus)
+
+	var received []Invalidation
+	bus.Subscribe(func(invalidation Invalidation) {
+		received = append(received, invalidation)
+	})
+
+	store.Set("0xABC", 1, store.Version())
+	store.Set("0xdef", 2, store.Version())
+	if value, ok := store.Get("0xabc"); !ok || value != 1 {
+		t.Fatalf("Expected the value cached under any case of the address, got %d, %v", value, ok)
+	}
+
+	bus.Invalidate(" 0xAbc ", ReasonOnChainMetrics)
+	if _, ok := store.Get("0xabc"); ok {
+		t.Error("Expected the invalidated address to be dropped")
+	}
+	if _, ok := store.Get("0xdef"); !ok {
+		t.Error("Expected other addresses to stay cached")
+	}
+	if len(received) != 1 || received[0].Address != "0xabc" || received[0].Reason != ReasonOnChainMetrics || received[0].All() {
+		t.Errorf("Expected every subscriber to receive the invalidation, got %+v", received)
+	}
+
+	bus.InvalidateAll(ReasonRestore)
+	if store.Len() != 0 {
+		t.Errorf("Expected every address to be dropped, %d left", store.Len())
+	}
+	if len(received) != 2 || !received[1].All() {
+		t.Errorf("Expected an invalidation of every address, got %+v", received)
+	}
+}
+
+func TestStoreDiscardsValuesLoadedBeforeInvalidation(t *testing.T) {
+	bus := NewBus()
+	store := NewStore[string](time.Minute, bus)
+
+	// The data changes while the old value is being loaded
+	value, err := store.Load("0xabc", func() (string, error) {
+		bus.Invalidate("0xabc", ReasonOffChainMetrics)
+		return "stale", nil
+	})
+	if err != nil || value != "stale" {
+		t.Fatalf("Expected the loaded value to be returned, got %q, %v", value, err)
+	}
+	if _, ok := store.Get("0xabc"); ok {
+		t.Error("Expected a value loaded before an invalidation not to be cached")
+	}
+
+	if _, err := store.Load("0xabc", func() (string, error) { return "", errors.New("db down") }); err == nil {
+		t.Error("Expected the load error")
+	}
+	if _, ok := store.Get("0xabc"); ok {
+		t.Error("Expected errors not to be cached")
+	}
+
+	loads := 0
+	for i := 0; i < 2; i++ {
+		store.Load("0xabc", func() (string, error) {
+			loads++
+			return "fresh", nil
+		})
+	}
+	if loads != 1 {
+		t.Errorf("Expected one load, got %d", loads)
+	}
+}
+
+func TestStoreExpiryAndNil(t *testing.T) {
+	store := NewStore[int](time.Millisecond, nil)
+	store.Set("0xabc", 1, store.Version())
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := store.Get("0xabc"); ok {
+		t.Error("Expected the value to expire")
+	}
+
+	// A disabled store and bus do nothing
+	var disabled *Store[int]
+	if NewStore[int](0, nil) != nil || disabled.Set("0xabc", 1, 0) {
+		t.Error("Expected a zero TTL to disable caching")
+	}
+	if _, ok := disabled.Get("0xabc"); ok {
+		t.Error("Expected nothing cached")
+	}
+	var bus *Bus
+	bus.Subscribe(disabled.Invalidate)
+	bus.Invalidate("0xabc", ReasonScore)
+}
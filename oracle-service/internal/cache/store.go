@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// maxEntriesBeforePrune is how many entries a store holds before expired ones are
+// swept out on the next write
+const maxEntriesBeforePrune = 10000
+
+// Store caches a value per address for up to a TTL. Subscribed to a Bus, it drops
+// an address's value whenever the address's data changes. A nil Store caches nothing.
+//
+// A value loaded from the database can be stale by the time it is stored, if the
+// data changed in between. Callers take the store's Version before loading and pass
+// it to Set, which discards the value if anything was invalidated since.
+type Store[V any] struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]entry[V]
+	version uint64 // Incremented on every invalidation
+}
+
+type entry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// NewStore creates a store keeping values for ttl, subscribed to bus. A ttl of zero
+// or less returns nil, which caches nothing.
+func NewStore[V any](ttl time.Duration, bus *Bus) *Store[V] {
+	if ttl <= 0 {
+		return nil
+	}
+	s := &Store[V]{ttl: ttl, entries: make(map[string]entry[V])}
+	bus.Subscribe(s.Invalidate)
+	return s
+}
+
+// Get returns an address's cached value, if it has one that hasn't expired
+func (s *Store[V]) Get(address string) (V, bool) {
+	var zero V
+	if s == nil {
+		return zero, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cached, ok := s.entries[normalize(address)]
+	if !ok || time.Now().After(cached.expires) {
+		return zero, false
+	}
+	return cached.value, true
+}
+
+// Version returns the store's current version, to pass to Set after loading a value
+func (s *Store[V]) Version() uint64 {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.version
+}
+
+// Set caches an address's value loaded at version. It reports whether the value
+// was cached, which it isn't if any address was invalidated after version.
+func (s *Store[V]) Set(address string, value V, version uint64) bool {
+	if s == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if version != s.version {
+		return false
+	}
+
+	now := time.Now()
+	s.entries[normalize(address)] = entry[V]{value: value, expires: now.Add(s.ttl)}
+
+	// Forget entries nobody read again before they expired
+	if len(s.entries) > maxEntriesBeforePrune {
+		for address, cached := range s.entries {
+			if now.After(cached.expires) {
+				delete(s.entries, address)
+			}
+		}
+	}
+	return true
+}
+
+// Load returns an address's cached value, or calls load and caches its result.
+// Errors are not cached.
+func (s *Store[V]) Load(address string, load func() (V, error)) (V, error) {
+	if value, ok := s.Get(address); ok {
+		return value, nil
+	}
+
+	version := s.Version()
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	s.Set(address, value, version)
+	return value, nil
+}
+
+// Invalidate drops the cached values the invalidation covers. It is the store's
+// Bus subscriber.
+func (s *Store[V]) Invalidate(invalidation Invalidation) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.version++
+	if invalidation.All() {
+		s.entries = make(map[string]entry[V])
+		return
+	}
+	delete(s.entries, normalize(invalidation.Address))
+}
+
+// Len returns the number of cached values, including expired ones not yet swept out
+func (s *Store[V]) Len() int {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
@@ -0,0 +1,285 @@
+package chainindex
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/chains"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/pause"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// Indexing defaults
+const (
+	defaultBatchSize     = 100
+	defaultWorkers       = 4
+	defaultConfirmations = 12
+)
+
+// maxInputWords bounds the calldata kept per transaction: the selector and enough
+// leading arguments to classify DeFi calls and decode their market and amount
+const maxInputWords = 6
+
+// transferTopic is the topic of ERC-20 Transfer(address,address,uint256) logs
+var transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// Indexer ingests a chain's blocks into the chain index. Blocks are fetched by
+// concurrent workers a batch at a time, and each batch is saved with the checkpoint,
+// so an interrupted indexer resumes after the last saved batch without counting any
+// block twice.
+type Indexer struct {
+	source        BlockSource
+	repo          *repository.ChainIndexRepository
+	chainID       string
+	startBlock    uint64
+	batchSize     int
+	workers       int
+	confirmations uint64 // Blocks this close to the head are left until they are unlikely to be reorganized
+	pauses        pause.Checker
+}
+
+// NewIndexer creates an indexer of the chain read through source
+func NewIndexer(source BlockSource, repo *repository.ChainIndexRepository, chainID string) *Indexer {
+	return &Indexer{
+		source:        source,
+		repo:          repo,
+		chainID:       chainID,
+		batchSize:     defaultBatchSize,
+		workers:       defaultWorkers,
+		confirmations: defaultConfirmations,
+	}
+}
+
+// SetStartBlock sets the block indexing starts from when the chain has no checkpoint
+func (x *Indexer) SetStartBlock(block uint64) {
+	x.startBlock = block
+}
+
+// SetConcurrency sets how many blocks are fetched at once and how many blocks are saved
+// per batch
+func (x *Indexer) SetConcurrency(workers, batchSize int) {
+	if workers > 0 {
+		x.workers = workers
+	}
+	if batchSize > 0 {
+		x.batchSize = batchSize
+	}
+}
+
+// SetConfirmations sets how many blocks behind the head indexing stops
+func (x *Indexer) SetConfirmations(confirmations uint64) {
+	x.confirmations = confirmations
+}
+
+// SetPauses skips syncing while pauses reports pause.ChainIndex as paused
+func (x *Indexer) SetPauses(pauses pause.Checker) {
+	x.pauses = pauses
+}
+
+// Sync indexes the blocks from the checkpoint to the confirmed head and returns how
+// many blocks it indexed
+func (x *Indexer) Sync(ctx context.Context) (uint64, error) {
+	head, err := x.source.LatestBlock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if head < x.confirmations {
+		return 0, nil
+	}
+	target := head - x.confirmations
+
+	next := x.startBlock
+	checkpoint, err := x.repo.GetCheckpoint(ctx, x.chainID)
+	if err != nil {
+		return 0, err
+	}
+	if checkpoint != nil {
+		next = checkpoint.LastBlock + 1
+	}
+
+	var indexed uint64
+	for next <= target {
+		if err := ctx.Err(); err != nil {
+			return indexed, err
+		}
+		last := next + uint64(x.batchSize) - 1
+		if last > target {
+			last = target
+		}
+		if err := x.indexBatch(ctx, next, last); err != nil {
+			return indexed, err
+		}
+		indexed += last - next + 1
+		next = last + 1
+	}
+	return indexed, nil
+}
+
+// Run syncs the chain every interval until ctx is cancelled
+func (x *Indexer) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if x.pauses == nil || !x.pauses.Paused(ctx, pause.ChainIndex) {
+			indexed, err := x.Sync(ctx)
+			if err != nil && ctx.Err() == nil {
+				logger.Error("Failed to index chain", zap.String("chain", x.chainID), zap.Error(err))
+			} else if indexed > 0 {
+				logger.Info("Indexed chain blocks", zap.String("chain", x.chainID), zap.Uint64("blocks", indexed))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// indexBatch fetches the blocks from first to last concurrently and saves them as one batch
+func (x *Indexer) indexBatch(ctx context.Context, first, last uint64) error {
+	blocks := make([]*Block, last-first+1)
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(x.workers)
+	for i := range blocks {
+		i := i
+		group.Go(func() error {
+			block, err := x.source.Block(groupCtx, first+uint64(i))
+			blocks[i] = block
+			return err
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return err
+	}
+
+	return x.repo.SaveBatch(ctx, summarize(x.chainID, blocks))
+}
+
+// summarize turns consecutive blocks into a batch of rows and per-address activity
+func summarize(chainID string, blocks []*Block) *repository.ChainIndexBatch {
+	batch := &repository.ChainIndexBatch{
+		ChainID:   chainID,
+		FromBlock: blocks[0].Number,
+		ToBlock:   blocks[len(blocks)-1].Number,
+		BlockTime: blocks[len(blocks)-1].Time,
+	}
+
+	coin := chains.Native(chainID) // Values are summed in the chain's coin
+	activity := make(map[string]*models.ChainIndexAddress)
+	var order []string
+	touch := func(address string, block *Block) *models.ChainIndexAddress {
+		a, ok := activity[address]
+		if !ok {
+			a = &models.ChainIndexAddress{
+				ChainID:    chainID,
+				Address:    address,
+				FirstBlock: block.Number,
+				FirstSeen:  block.Time,
+			}
+			activity[address] = a
+			order = append(order, address)
+		}
+		a.LastSeen = block.Time
+		return a
+	}
+
+	for _, block := range blocks {
+		for _, tx := range block.Transactions {
+			value := tx.Value
+			if value == nil {
+				value = new(big.Int)
+			}
+			batch.Transactions = append(batch.Transactions, &models.ChainIndexTransaction{
+				ChainID:     chainID,
+				Hash:        tx.Hash,
+				BlockNumber: block.Number,
+				Timestamp:   block.Time,
+				From:        tx.From,
+				To:          tx.To,
+				Value:       value.String(),
+				Failed:      tx.Failed,
+				Input:       inputPrefix(tx.Input),
+			})
+
+			sender := touch(tx.From, block)
+			sender.Sent++
+			if tx.Failed {
+				sender.Failed++
+				continue
+			}
+			native := coin.FromBaseUnits(value)
+			sender.ValueSent = sender.ValueSent.Add(native)
+			if tx.To != "" {
+				recipient := touch(tx.To, block)
+				recipient.Received++
+				recipient.ValueReceived = recipient.ValueReceived.Add(native)
+			}
+
+			for _, log := range tx.Logs {
+				transfer := tokenTransfer(chainID, block, tx, log)
+				if transfer == nil {
+					continue
+				}
+				batch.TokenTransfers = append(batch.TokenTransfers, transfer)
+				touch(transfer.From, block).TokenTransfers++
+				if transfer.To != transfer.From {
+					touch(transfer.To, block).TokenTransfers++
+				}
+			}
+		}
+	}
+
+	for _, address := range order {
+		batch.Addresses = append(batch.Addresses, activity[address])
+	}
+	return batch
+}
+
+// tokenTransfer decodes an ERC-20 Transfer log. ERC-721 transfers, which index the
+// token ID as a fourth topic, and other logs return nil.
+func tokenTransfer(chainID string, block *Block, tx Transaction, log Log) *models.ChainIndexTokenTransfer {
+	if len(log.Topics) != 3 || log.Topics[0] != transferTopic || len(log.Data) != 32 {
+		return nil
+	}
+	return &models.ChainIndexTokenTransfer{
+		ChainID:     chainID,
+		TxHash:      tx.Hash,
+		LogIndex:    log.Index,
+		BlockNumber: block.Number,
+		Timestamp:   block.Time,
+		Token:       log.Address,
+		From:        topicAddress(log.Topics[1]),
+		To:          topicAddress(log.Topics[2]),
+		Value:       new(big.Int).SetBytes(log.Data).String(),
+	}
+}
+
+// topicAddress is the lowercase address in an indexed address topic
+func topicAddress(topic common.Hash) string {
+	return "0x" + hex.EncodeToString(topic[12:])
+}
+
+// inputPrefix is the hex calldata kept for a transaction
+func inputPrefix(input []byte) string {
+	if len(input) == 0 {
+		return ""
+	}
+	if limit := 4 + 32*maxInputWords; len(input) > limit {
+		input = input[:limit]
+	}
+	return "0x" + hex.EncodeToString(input)
+}
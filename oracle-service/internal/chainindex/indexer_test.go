@@ -0,0 +1,169 @@
+package chainindex
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const (
+	alice = "0x00000000000000000000000000000000000a11ce"
+	bob   = "0x0000000000000000000000000000000000000b0b"
+	token = "0x00000000000000000000000000000000000070c5"
+)
+
+// fakeChain serves one transaction from alice per block, and fails blocks in failing
+type fakeChain struct {
+	head    uint64
+	start   time.Time
+	failing map[uint64]bool
+}
+
+func (c *fakeChain) LatestBlock(ctx context.Context) (uint64, error) {
+	return c.head, nil
+}
+
+func (c *fakeChain) Block(ctx context.Context, number uint64) (*Block, error) {
+	if c.failing[number] {
+		return nil, errors.New("node unavailable")
+	}
+	hash := common.BigToHash(new(big.Int).SetUint64(number + 1)).Hex()
+	tx := Transaction{
+		Hash:  hash,
+		From:  alice,
+		To:    bob,
+		Value: big.NewInt(1e18),
+	}
+	// Even blocks also move 5 tokens from bob to alice
+	if number%2 == 0 {
+		tx.To = token
+		tx.Value = big.NewInt(0)
+		tx.Logs = []Log{{
+			Index:   3,
+			Address: token,
+			Topics:  []common.Hash{transferTopic, common.HexToHash(bob), common.HexToHash(alice)},
+			Data:    common.BigToHash(big.NewInt(5)).Bytes(),
+		}}
+	}
+	return &Block{
+		Number:       number,
+		Time:         c.start.Add(time.Duration(number) * time.Hour),
+		Transactions: []Transaction{tx},
+	}, nil
+}
+
+func setupIndex(t *testing.T) *repository.ChainIndexRepository {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(
+		&models.ChainIndexCheckpoint{},
+		&models.ChainIndexAddress{},
+		&models.ChainIndexTransaction{},
+		&models.ChainIndexTokenTransfer{},
+	); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+	return repository.NewChainIndexRepository(db)
+}
+
+func TestIndexerResumesFromCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	repo := setupIndex(t)
+	chain := &fakeChain{head: 22, start: time.Now().AddDate(0, 0, -30), failing: map[uint64]bool{7: true}}
+
+	indexer := NewIndexer(chain, repo, "1337")
+	indexer.SetStartBlock(1)
+	indexer.SetConcurrency(3, 4)
+	indexer.SetConfirmations(2)
+
+	// Blocks 1-4 are saved; the batch with block 7 is not saved at all
+	indexed, err := indexer.Sync(ctx)
+	if err == nil || indexed != 4 {
+		t.Fatalf("Expected the failing batch to stop syncing after 4 blocks, got %d, %v", indexed, err)
+	}
+	checkpoint, err := repo.GetCheckpoint(ctx, "1337")
+	if err != nil || checkpoint == nil || checkpoint.LastBlock != 4 {
+		t.Fatalf("Expected checkpoint at block 4, got %+v, %v", checkpoint, err)
+	}
+
+	// Resumed after the checkpoint, up to the head less the confirmations
+	delete(chain.failing, 7)
+	if indexed, err = indexer.Sync(ctx); err != nil || indexed != 16 {
+		t.Fatalf("Expected blocks 5-20 indexed, got %d, %v", indexed, err)
+	}
+	if indexed, err = indexer.Sync(ctx); err != nil || indexed != 0 {
+		t.Fatalf("Expected nothing left to index, got %d, %v", indexed, err)
+	}
+
+	// Blocks 1-20: 10 transfers of 1 coin to bob, 10 token calls moving tokens to alice
+	activity, err := repo.GetAddress(ctx, "1337", alice)
+	if err != nil || activity == nil {
+		t.Fatalf("Expected alice indexed, got %v", err)
+	}
+	if activity.Sent != 20 || activity.TokenTransfers != 10 || activity.FirstBlock != 1 {
+		t.Errorf("Unexpected activity for alice: %+v", activity)
+	}
+	if activity.ValueSent.String() != "10" {
+		t.Errorf("Expected 10 coins sent, got %s", activity.ValueSent)
+	}
+	if bobActivity, _ := repo.GetAddress(ctx, "1337", bob); bobActivity == nil || bobActivity.Received != 10 {
+		t.Errorf("Expected bob to have received 10 transactions, got %+v", bobActivity)
+	}
+
+	// Saving a batch that doesn't follow the checkpoint is refused
+	err = repo.SaveBatch(ctx, &repository.ChainIndexBatch{ChainID: "1337", FromBlock: 5, ToBlock: 5})
+	if !errors.Is(err, repository.ErrCheckpointMoved) {
+		t.Errorf("Expected ErrCheckpointMoved, got %v", err)
+	}
+}
+
+type fakeBalances struct{}
+
+func (fakeBalances) NativeBalance(ctx context.Context, address string) (*big.Int, error) {
+	return new(big.Int).Mul(big.NewInt(3), big.NewInt(1e18)), nil
+}
+
+func TestReaderServesIndexedAddress(t *testing.T) {
+	ctx := context.Background()
+	repo := setupIndex(t)
+	chain := &fakeChain{head: 10, start: time.Now().AddDate(0, 0, -30)}
+	indexer := NewIndexer(chain, repo, "1337")
+	indexer.SetConfirmations(0)
+	if _, err := indexer.Sync(ctx); err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	reader := NewReader(repo, "1337", fakeBalances{})
+	summary, err := reader.Summary(ctx, "0x00000000000000000000000000000000000A11CE")
+	if err != nil || summary == nil {
+		t.Fatalf("Expected a summary, got %v", err)
+	}
+	if summary.TotalTransactions != 11 || summary.WalletAge != 30 || summary.TotalPortfolioValue != 3 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+
+	txs, err := reader.Transactions(ctx, alice, 3)
+	if err != nil || len(txs) != 3 || txs[0].BlockNumber != "10" {
+		t.Fatalf("Expected the 3 newest transactions, got %+v, %v", txs, err)
+	}
+	transfers, err := reader.TokenTransfers(ctx, alice, 100)
+	if err != nil || len(transfers) != 6 || transfers[0].From != bob || transfers[0].Value != "5" {
+		t.Errorf("Expected 6 token transfers from bob, got %+v, %v", transfers, err)
+	}
+
+	if summary, err := reader.Summary(ctx, "0x0000000000000000000000000000000000000001"); err != nil || summary != nil {
+		t.Errorf("Expected no summary for an unseen address, got %+v, %v", summary, err)
+	}
+}
@@ -0,0 +1,174 @@
+package chainindex
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/chains"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+)
+
+// summaryTransactions bounds the recent transactions classified for a summary's DeFi
+// activity
+const summaryTransactions = 500
+
+// BalanceSource reads an address's current native balance
+type BalanceSource interface {
+	NativeBalance(ctx context.Context, address string) (*big.Int, error)
+}
+
+// Reader serves a chain's indexed address data in the shapes of the explorer providers
+type Reader struct {
+	repo     *repository.ChainIndexRepository
+	chainID  string
+	native   chains.NativeAsset
+	balances BalanceSource // nil leaves balances unknown
+}
+
+// NewReader creates a reader of the chain's index. Balances are read from balances,
+// which may be nil.
+func NewReader(repo *repository.ChainIndexRepository, chainID string, balances BalanceSource) *Reader {
+	return &Reader{repo: repo, chainID: chainID, native: chains.Native(chainID), balances: balances}
+}
+
+// Summary summarizes an address's indexed activity, or returns nil if the address was
+// never seen. Volumes and the portfolio value are in the chain's native coin, since
+// private chains' coins have no market price.
+func (r *Reader) Summary(ctx context.Context, address string) (*providers.BlockchainSummary, error) {
+	activity, err := r.repo.GetAddress(ctx, r.chainID, address)
+	if err != nil || activity == nil {
+		return nil, err
+	}
+	txs, err := r.Transactions(ctx, address, summaryTransactions)
+	if err != nil {
+		return nil, err
+	}
+
+	total := int(activity.Sent + activity.Received)
+	volume := activity.ValueSent.Add(activity.ValueReceived).Float64()
+	summary := &providers.BlockchainSummary{
+		Address:           activity.Address,
+		WalletAge:         int(time.Since(activity.FirstSeen).Hours() / 24),
+		FirstTransaction:  activity.FirstSeen,
+		LastTransaction:   activity.LastSeen,
+		TotalTransactions: total,
+		TotalVolume:       volume,
+		DeFiActivities:    providers.ClassifyDeFiActivities(sentBy(activity.Address, txs), r.native),
+		LendingPositions:  []providers.LendingPosition{},
+		LiquidationEvents: []providers.LiquidationEvent{},
+		TokenBalances:     map[string]float64{},
+		LastUpdated:       time.Now(),
+	}
+	if total > 0 {
+		summary.AverageTransactionSize = volume / float64(total)
+	}
+
+	if r.balances != nil {
+		balance, err := r.balances.NativeBalance(ctx, address)
+		if err != nil {
+			return nil, err
+		}
+		native := r.native.FromBaseUnits(balance).Float64()
+		summary.TokenBalances["native"] = native
+		summary.TotalPortfolioValue = native
+	}
+	return summary, nil
+}
+
+// NativeAsset returns the coin of the indexed chain
+func (r *Reader) NativeAsset() chains.NativeAsset {
+	return r.native
+}
+
+// AddressInfo returns an address's balance and transaction count, or nil if the
+// balance can't be read
+func (r *Reader) AddressInfo(ctx context.Context, address string) (*providers.BlockscoutAddressInfo, error) {
+	if r.balances == nil {
+		return nil, nil
+	}
+	balance, err := r.balances.NativeBalance(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	activity, err := r.repo.GetAddress(ctx, r.chainID, address)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &providers.BlockscoutAddressInfo{Hash: address, Balance: balance.String()}
+	if activity != nil {
+		info.TransactionsCount = int(activity.Sent + activity.Received)
+		info.TokenTransfersCount = int(activity.TokenTransfers)
+	}
+	return info, nil
+}
+
+// Transactions returns an address's most recent indexed transactions, newest first
+func (r *Reader) Transactions(ctx context.Context, address string, limit int) ([]providers.BlockscoutTransaction, error) {
+	rows, err := r.repo.ListTransactions(ctx, r.chainID, address, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]providers.BlockscoutTransaction, 0, len(rows))
+	for _, row := range rows {
+		txs = append(txs, explorerTransaction(row))
+	}
+	return txs, nil
+}
+
+// TokenTransfers returns an address's most recent indexed ERC-20 transfers, newest first
+func (r *Reader) TokenTransfers(ctx context.Context, address string, limit int) ([]providers.BlockscoutTokenTransfer, error) {
+	rows, err := r.repo.ListTokenTransfers(ctx, r.chainID, address, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := make([]providers.BlockscoutTokenTransfer, 0, len(rows))
+	for _, row := range rows {
+		transfers = append(transfers, providers.BlockscoutTokenTransfer{
+			Hash:            row.TxHash,
+			BlockNumber:     strconv.FormatUint(row.BlockNumber, 10),
+			TimeStamp:       strconv.FormatInt(row.Timestamp.Unix(), 10),
+			From:            row.From,
+			To:              row.To,
+			Value:           row.Value,
+			ContractAddress: row.Token,
+			LogIndex:        strconv.FormatUint(uint64(row.LogIndex), 10),
+		})
+	}
+	return transfers, nil
+}
+
+// explorerTransaction converts an indexed transaction to the Blockscout shape
+func explorerTransaction(row *models.ChainIndexTransaction) providers.BlockscoutTransaction {
+	status := "1"
+	if row.Failed {
+		status = "0"
+	}
+	return providers.BlockscoutTransaction{
+		Hash:        row.Hash,
+		BlockNumber: strconv.FormatUint(row.BlockNumber, 10),
+		TimeStamp:   strconv.FormatInt(row.Timestamp.Unix(), 10),
+		From:        row.From,
+		To:          row.To,
+		Value:       row.Value,
+		Status:      status,
+		Input:       row.Input,
+	}
+}
+
+// sentBy returns the transactions sent by address
+func sentBy(address string, txs []providers.BlockscoutTransaction) []providers.BlockscoutTransaction {
+	sent := make([]providers.BlockscoutTransaction, 0, len(txs))
+	for _, tx := range txs {
+		if tx.From == address {
+			sent = append(sent, tx)
+		}
+	}
+	return sent
+}
@@ -0,0 +1,140 @@
+// Package chainindex builds address analytics for chains without an explorer provider
+// by walking their blocks over JSON-RPC. An Indexer ingests blocks into the chain_index
+// tables, resuming from its checkpoint, and a Reader serves what was indexed in the
+// shapes of the explorer providers so the on-chain analyses run on it unchanged.
+package chainindex
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Block is a block with what the indexer keeps of its transactions
+type Block struct {
+	Number       uint64
+	Time         time.Time
+	Transactions []Transaction
+}
+
+// Transaction is a transaction of a block with its receipt's status and logs
+type Transaction struct {
+	Hash   string
+	From   string
+	To     string // Empty for contract creations
+	Value  *big.Int
+	Input  []byte
+	Failed bool
+	Logs   []Log
+}
+
+// Log is an event log emitted by a transaction
+type Log struct {
+	Index   uint
+	Address string
+	Topics  []common.Hash
+	Data    []byte
+}
+
+// BlockSource reads blocks from a chain
+type BlockSource interface {
+	LatestBlock(ctx context.Context) (uint64, error)
+	Block(ctx context.Context, number uint64) (*Block, error)
+}
+
+// RPCSource reads blocks, receipts and balances from an Ethereum JSON-RPC node
+type RPCSource struct {
+	client *ethclient.Client
+}
+
+// NewRPCSource connects to the node at rpcURL
+func NewRPCSource(rpcURL string) (*RPCSource, error) {
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to chain index node: %w", err)
+	}
+	return &RPCSource{client: client}, nil
+}
+
+// ChainID returns the chain ID the node reports
+func (s *RPCSource) ChainID(ctx context.Context) (string, error) {
+	id, err := s.client.ChainID(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	return id.String(), nil
+}
+
+// LatestBlock returns the number of the node's head block
+func (s *RPCSource) LatestBlock(ctx context.Context) (uint64, error) {
+	return s.client.BlockNumber(ctx)
+}
+
+// Block reads a block with the senders, statuses and logs of its transactions. Receipts
+// are read with eth_getBlockReceipts, or one by one from nodes without it.
+func (s *RPCSource) Block(ctx context.Context, number uint64) (*Block, error) {
+	block, err := s.client.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", number, err)
+	}
+
+	receipts, err := s.client.BlockReceipts(ctx, rpc.BlockNumberOrHashWithHash(block.Hash(), false))
+	if err != nil || len(receipts) != len(block.Transactions()) {
+		receipts = make([]*types.Receipt, len(block.Transactions()))
+		for i, tx := range block.Transactions() {
+			if receipts[i], err = s.client.TransactionReceipt(ctx, tx.Hash()); err != nil {
+				return nil, fmt.Errorf("failed to get receipt of %s: %w", tx.Hash().Hex(), err)
+			}
+		}
+	}
+
+	result := &Block{
+		Number:       number,
+		Time:         time.Unix(int64(block.Time()), 0).UTC(),
+		Transactions: make([]Transaction, 0, len(block.Transactions())),
+	}
+	for i, tx := range block.Transactions() {
+		from, err := s.client.TransactionSender(ctx, tx, block.Hash(), uint(i))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sender of %s: %w", tx.Hash().Hex(), err)
+		}
+
+		indexed := Transaction{
+			Hash:   strings.ToLower(tx.Hash().Hex()),
+			From:   strings.ToLower(from.Hex()),
+			Value:  tx.Value(),
+			Input:  tx.Data(),
+			Failed: receipts[i].Status == types.ReceiptStatusFailed,
+		}
+		if tx.To() != nil {
+			indexed.To = strings.ToLower(tx.To().Hex())
+		}
+		for _, log := range receipts[i].Logs {
+			indexed.Logs = append(indexed.Logs, Log{
+				Index:   log.Index,
+				Address: strings.ToLower(log.Address.Hex()),
+				Topics:  log.Topics,
+				Data:    log.Data,
+			})
+		}
+		result.Transactions = append(result.Transactions, indexed)
+	}
+	return result, nil
+}
+
+// NativeBalance returns an address's current balance in wei
+func (s *RPCSource) NativeBalance(ctx context.Context, address string) (*big.Int, error) {
+	return s.client.BalanceAt(ctx, common.HexToAddress(address), nil)
+}
+
+// Close closes the connection to the node
+func (s *RPCSource) Close() {
+	s.client.Close()
+}
@@ -0,0 +1,84 @@
+// Package chains is the registry of the EVM chains the oracle reads, with the native
+// coin of each, so native amounts are converted with the chain's decimals and valued
+// at the price of the right coin.
+package chains
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// NativeAsset is the coin a chain's balances, transaction values and gas are paid in
+type NativeAsset struct {
+	Symbol   string `json:"symbol"`
+	Decimals int    `json:"decimals"` // Base units per coin are 10^Decimals
+}
+
+// UnknownNative is assumed for chains missing from the registry. Nearly every EVM
+// chain's coin has 18 decimals; its symbol isn't known.
+var UnknownNative = NativeAsset{Symbol: "NATIVE", Decimals: 18}
+
+// FromBaseUnits converts an amount in base units (wei on Ethereum) into coins
+func (a NativeAsset) FromBaseUnits(amount *big.Int) units.Decimal {
+	return units.DecimalFromBigInt(amount).Shift(-a.Decimals)
+}
+
+// Chain is an EVM chain known to the oracle
+type Chain struct {
+	Name          string
+	ID            int64
+	BlockscoutURL string // Public Blockscout instance; "" if there is none
+	Native        NativeAsset
+}
+
+var (
+	eth  = NativeAsset{Symbol: "ETH", Decimals: 18}
+	bnb  = NativeAsset{Symbol: "BNB", Decimals: 18}
+	avax = NativeAsset{Symbol: "AVAX", Decimals: 18}
+)
+
+// registry lists the known chains. Names are the ones used in configuration
+// (BLOCKSCOUT_CHAIN, TARGET_CHAINS).
+var registry = []Chain{
+	{Name: "ethereum", ID: 1, BlockscoutURL: "https://eth.blockscout.com", Native: eth},
+	{Name: "optimism", ID: 10, BlockscoutURL: "https://optimism.blockscout.com", Native: eth},
+	{Name: "bsc", ID: 56, Native: bnb},
+	{Name: "gnosis", ID: 100, BlockscoutURL: "https://gnosis.blockscout.com", Native: NativeAsset{Symbol: "xDAI", Decimals: 18}},
+	{Name: "polygon", ID: 137, BlockscoutURL: "https://polygon.blockscout.com", Native: NativeAsset{Symbol: "POL", Decimals: 18}},
+	{Name: "zksync", ID: 324, BlockscoutURL: "https://zksync.blockscout.com", Native: eth},
+	{Name: "moonbeam", ID: 1284, BlockscoutURL: "https://moonbeam.blockscout.com", Native: NativeAsset{Symbol: "GLMR", Decimals: 18}},
+	{Name: "base", ID: 8453, BlockscoutURL: "https://base.blockscout.com", Native: eth},
+	{Name: "arbitrum", ID: 42161, BlockscoutURL: "https://arbitrum.blockscout.com", Native: eth},
+	{Name: "celo", ID: 42220, BlockscoutURL: "https://celo.blockscout.com", Native: NativeAsset{Symbol: "CELO", Decimals: 18}},
+	{Name: "avalanche", ID: 43114, Native: avax},
+	{Name: "scroll", ID: 534352, BlockscoutURL: "https://scroll.blockscout.com", Native: eth},
+	{Name: "sepolia", ID: 11155111, BlockscoutURL: "https://eth-sepolia.blockscout.com", Native: eth},
+}
+
+// All returns the known chains
+func All() []Chain {
+	return append([]Chain(nil), registry...)
+}
+
+// Lookup finds a chain by name or by decimal chain ID, as providers like Covalent and
+// the chain index identify chains
+func Lookup(chain string) (Chain, bool) {
+	id, err := strconv.ParseInt(chain, 10, 64)
+	for _, c := range registry {
+		if c.Name == chain || (err == nil && c.ID == id) {
+			return c, true
+		}
+	}
+	return Chain{}, false
+}
+
+// Native returns the native asset of a chain given by name or decimal chain ID, or
+// UnknownNative if the chain isn't known
+func Native(chain string) NativeAsset {
+	if c, ok := Lookup(chain); ok {
+		return c.Native
+	}
+	return UnknownNative
+}
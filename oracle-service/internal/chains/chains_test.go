@@ -0,0 +1,39 @@
+package chains
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	byName, ok := Lookup("bsc")
+	if !ok || byName.ID != 56 || byName.Native.Symbol != "BNB" {
+		t.Fatalf("Expected BSC by name, got %+v", byName)
+	}
+	byID, ok := Lookup("43114")
+	if !ok || byID.Name != "avalanche" || byID.Native.Symbol != "AVAX" {
+		t.Fatalf("Expected Avalanche by chain ID, got %+v", byID)
+	}
+	if _, ok := Lookup("unknown"); ok {
+		t.Error("Expected an unknown chain not found")
+	}
+
+	if native := Native("999999"); native != UnknownNative {
+		t.Errorf("Expected an unknown chain's coin assumed to have 18 decimals, got %+v", native)
+	}
+	if native := Native("gnosis"); native.Symbol != "xDAI" {
+		t.Errorf("Expected Gnosis to pay in xDAI, got %+v", native)
+	}
+}
+
+func TestFromBaseUnits(t *testing.T) {
+	wei, _ := new(big.Int).SetString("1500000000000000000", 10)
+	if got := Native("ethereum").FromBaseUnits(wei).String(); got != "1.5" {
+		t.Errorf("Expected 1.5 ETH, got %s", got)
+	}
+
+	sixDecimals := NativeAsset{Symbol: "TEST", Decimals: 6}
+	if got := sixDecimals.FromBaseUnits(big.NewInt(2500000)).String(); got != "2.5" {
+		t.Errorf("Expected 2.5 coins of a 6-decimal asset, got %s", got)
+	}
+}
@@ -9,28 +9,149 @@ type Config struct {
 	// Server Configuration
 	Port string
 
+	// Server TLS (mutual TLS authenticates internal callers at the transport layer)
+	TLSCertFile     string // PEM certificate served over HTTPS (empty serves plain HTTP)
+	TLSKeyFile      string
+	TLSClientCAFile string // PEM CAs client certificates must be issued by
+	TLSClientAuth   string // none, optional (verified when presented) or require
+
+	// HTTP Compression
+	CompressionMinBytes      int   // Responses at least this large are gzip-compressed (0 disables)
+	MaxDecompressedBodyBytes int64 // Largest decompressed request body accepted by batch endpoints
+
+	// HTTP Caching
+	CacheMaxAge map[string]int // Endpoint class -> Cache-Control max-age in seconds (0 forces revalidation)
+
+	// Request Timeouts
+	RequestTimeouts map[string]int // Endpoint class -> seconds before the request is cancelled with a 504 (0 disables)
+
 	// Database Configuration
-	DatabaseURL string
-	RedisURL    string
+	DatabaseURL        string
+	DatabaseReplicaURL string // Read replica for history, listings and stats (optional)
+	ReplicaMaxLagSecs  int    // Reads go to the primary while the replica lags more than this
+	MetricsBackend     string // Score history storage: postgres or timescale
+	RedisURL           string
 
 	// Blockchain Configuration
-	EthereumRPC     string
-	PrivateKey      string
-	ContractAddress string
+	EthereumRPC         string
+	PrivateKey          string
+	ContractAddress     string
+	ContractVersion     int   // Oracle contract ABI version: 1 (updateCreditScore) or 2 (updateScoreWithExpiry)
+	OracleBatchGasLimit int   // Gas ceiling for one updateScores transaction
+	OracleBatchSize     int   // Maximum scores per updateScores transaction
+	ChainID             int64 // Chain the Ethereum node must be on, checked by the startup self-test (0 = any)
+
+	// Null Publisher (records would-be oracle transactions instead of sending them)
+	BlockchainPublisher    string // oracle or null
+	NullPublisherLatencyMs int    // Simulated time to submit a transaction
+	NullPublisherJitterMs  int    // Random extra time of up to this much per transaction
+
+	// Canary Publishing (a share of publications go to a new oracle contract)
+	CanaryContractAddress string
+	CanaryPercent         int // Percent of addresses published to the canary contract
+	CanaryContractVersion int // ABI version of the canary contract
+
+	// Published Score Expiry (scores expire at their next update due plus a grace period)
+	ScoreExpiryGraceHours int // How long a score stays valid on-chain after its next update is due
+	ScoreMaxValidityHours int // Latest a score may expire, counted from publishing (0 = no cap)
+
+	// Publish Window (non-urgent publications wait until it opens)
+	PublishMaxBaseFeeGwei    float64 // Only publish while the base fee is below this (0 disables)
+	PublishHours             string  // UTC hour ranges allowed for publishing, e.g. "0-6,22-24" (empty = any hour)
+	PublishQueueIntervalMins int     // How often queued publications are retried
+
+	// Publication Confirmation (publish statuses follow transaction receipts)
+	PublishConfirmIntervalSecs int // How often pending publications are checked for receipts (0 disables)
+
+	// Update Rate Limiting
+	UpdateMinIntervalSecs int // Minimum seconds between non-admin updates of one address (0 disables)
+
+	// Startup Self-Test (dependencies are checked before serving)
+	StartupSelfTest     bool
+	SelfTestTimeoutSecs int // How long each check may take
+
+	// Maintenance Mode (mutating endpoints are rejected while reads stay available)
+	MaintenanceMode           bool // Start in maintenance mode; only a restart without it ends it
+	MaintenanceRetryAfterSecs int  // Retry-After sent with rejected requests
+
+	// Admin Stats
+	StatsRefreshIntervalSecs int // How often dashboard stats are materialized (0 computes them per request)
+
+	// Read Cache (scores and prescreen tiers, dropped when the address's data changes)
+	CacheTTLSecs int // How long a cached score or tier is served at most (0 disables the cache)
+
+	// Public Score Distribution
+	PublicDistributionMinGroupSize int // Groups of fewer addresses are suppressed (0 disables the endpoint)
+
+	// Research Export (differentially private aggregates for analytics partners)
+	ResearchAPIKeys       map[string]string // Partner -> API key (X-API-Key); empty disables the export
+	ResearchEpsilon       float64           // Privacy loss of one query; lower adds more noise
+	ResearchDailyBudget   float64           // Epsilon each partner may spend per UTC day
+	ResearchMinBucketSize int               // Noisy counts below this are suppressed
+
+	// Position Health Monitoring (health factors of borrowing addresses between score refreshes)
+	AavePoolAddress           string  // Aave v3 Pool read for health factors (empty disables monitoring)
+	HealthMonitorIntervalMins int     // How often borrowing addresses are checked
+	HealthFactorThreshold     float64 // Positions with a lower health factor are at risk of liquidation
+
+	// Position Snapshots (lending positions of every scored address, on Aave and Compound)
+	CompoundCometAddress         string // Compound v3 Comet market snapshotted alongside Aave (empty skips Compound)
+	PositionSnapshotIntervalMins int    // How often positions are snapshotted (0 disables snapshots)
+
+	// Liquidation Fast Path (liquidated addresses are rescored and published ahead of the scheduler)
+	LiquidationFastPath bool // Rescore and publish liquidated addresses as soon as the liquidation is detected
+	LiquidationSLASecs  int  // Target from detection to publication
+
+	// Pipeline Latency SLO (per-stage timings of every score calculation)
+	PipelineSLOMs          map[string]int // Stage or "total" -> p95 objective in milliseconds
+	PipelineSLOWindowHours int            // Window compliance is reported over
+
+	// Minimum Data Policy (scores meeting none of the requirements are provisional and never published)
+	MinDataRequirements   []string // Any one of onchain_history, bureau_file, verified_income ("none" disables)
+	MinOnChainHistoryDays int      // Wallet age that meets onchain_history
+
+	// Tenant Score Policies (floors and ceilings applied to scores as each tenant reads them)
+	ScorePoliciesFile string // JSON file mapping tenant IDs (X-Tenant-ID) to their clamps
+
+	// Scoring Models (other model versions scores can be compared under)
+	ScoringModels map[string]string // Model version -> on-chain/off-chain/hybrid weight percentages, e.g. "v2=50/30/20"
+
+	// Score History Writes (batched in the background unless synchronous writes are required)
+	HistorySyncWrites      bool // Write each history record with its score, for strict durability
+	HistoryBufferSize      int  // Records queued before writes fall back to synchronous
+	HistoryBatchSize       int  // Records per insert
+	HistoryFlushIntervalMs int  // Longest a queued record waits to be written
 
 	// Provider Configuration
-	UseMockData bool
+	MockData                map[string]string // Domain (offchain, onchain) or off-chain provider -> true, false or never
+	ProviderCallTimeoutSecs int               // Each provider call made while fetching metrics is cancelled after this long (0 disables)
+	ProviderProbeSecs       int               // How often provider health checks are probed for uptime history (0 disables)
+	IncomePrecedence        []string          // Income sources in the order their figures are trusted (empty for the default)
+
+	// Provider Egress (proxies and client certificates for partners that allow-list IPs)
+	ProviderEgress map[string]Egress // Provider subsystem name -> egress settings, in every environment
 
 	// Credit Bureau Configuration
 	CreditBureauProvider string
 	CreditBureauURL      string
 	CreditBureauAPIKey   string
+	CreditBureauRegion   string
+	BureauWebhookSecret  string // HMAC secret for bureau alert webhooks
+
+	// Bureau Score Normalization
+	BureauScoreRanges map[string][2]int // provider[_region] -> native min/max
+	BureauDTIPercent  []string          // Bureaus reporting DTI as a percentage
 
 	// Plaid Configuration
 	PlaidClientID string
 	PlaidSecret   string
 	PlaidEnv      string
 
+	// Employment Verification Configuration
+	EmploymentProvider string
+	EmploymentAPIURL   string
+	EmploymentAPIKey   string
+
 	// Covalent Configuration
 	CovalentAPIKey  string
 	CovalentBaseURL string
@@ -44,9 +165,132 @@ type Config struct {
 	BlockscoutChain   string
 	PreferBlockscout  bool
 
+	// Address Labels
+	AddressLabelsFile string // JSON file of extra labels seeded into the registry
+
+	// Balance History
+	BalanceHistoryMonths int // Months of monthly balance samples for stability scoring (0 disables)
+
+	// Token Filtering
+	TokenAllowlist []string // Token contracts always counted toward portfolio value
+	TokenBlocklist []string // Known scam/honeypot token contracts never counted
+
 	// Multi-Chain Support
 	EnableMultiChain bool     // Enable fetching from multiple chains
 	TargetChains     []string // List of chains to fetch from (empty = all supported)
+
+	// Self-Hosted Chain Index (blocks walked over RPC, for chains without Blockscout or Covalent)
+	ChainIndexEnabled       bool
+	ChainIndexRPC           string // Node whose chain is indexed
+	ChainIndexStartBlock    int    // First block indexed when the chain has no checkpoint
+	ChainIndexWorkers       int    // Blocks fetched concurrently
+	ChainIndexBatchSize     int    // Blocks saved per batch, together with the checkpoint
+	ChainIndexConfirmations int    // Blocks this close to the head are not indexed yet
+	ChainIndexPollSecs      int    // How often new blocks are indexed
+
+	// Webhooks
+	WebhookSignatureScheme string   // hmac-sha256 or hmac-sha256-timestamped
+	WebhookToleranceSecs   int      // Allowed clock drift for timestamped signatures
+	ScoreWebhookURLs       []string // Subscribers notified when a score changes
+	ScoreWebhookSecret     string   // HMAC secret for signing outbound webhooks
+	WebhookMaxAttempts     int      // Outbound attempts before a delivery is dead-lettered
+	WebhookRetryDelaySecs  int      // Delay before the first retry, doubled on each further retry
+
+	// Event Bus
+	EventBus         string // none, kafka, or nats
+	EventBusURL      string // Kafka REST proxy URL or nats:// server URL
+	EventTopicPrefix string // Topics/subjects are "<prefix>.<event type>"
+	EventBufferSize  int    // Events queued before new ones are dropped
+
+	// Disaster Recovery Snapshots
+	SnapshotStoreURL    string // s3://bucket/prefix or file:///path (empty disables snapshots)
+	SnapshotS3Endpoint  string // S3-compatible endpoint; defaults to AWS for the region
+	SnapshotS3Region    string
+	SnapshotS3AccessKey string
+	SnapshotS3SecretKey string
+
+	// Verifiable Credentials (issuance is disabled unless the issuer URL and PrivateKey are set)
+	CredentialIssuerURL     string // Public base URL; the issuer DID is did:web of its host
+	CredentialChainID       int64  // Chain of the did:pkh holder DIDs
+	CredentialValidityHours int    // How long an issued score credential is valid
+
+	// Response Signing (detached JWS of score responses, signed with PrivateKey)
+	SignedResponseAPIKeys []string // API keys (X-API-Key) whose score responses are signed; empty disables
+
+	// Data Retention
+	RetentionPolicies      map[string]int // Policy -> days to keep rows (0 keeps them forever)
+	RetentionIntervalHours int            // How often expired rows are deleted
+
+	// Provider Environments (the settings above are the production environment)
+	Sandbox      *ProviderEnvironment // Sandbox providers and testnet, nil unless SANDBOX_ENABLED
+	AdminAPIKeys []string             // Keys (X-Admin-Key) required by the admin API and allowed to select the sandbox per request
+
+	// Signed Admin Requests (destructive admin actions need an operator's signature too)
+	AdminSigningKeys            map[string]string // Operator -> base64 PKIX DER Ed25519 or ECDSA public key (empty disables destructive actions)
+	AdminSignatureToleranceSecs int               // How far a signed request's timestamp may be from now
+}
+
+// Egress is how calls to one provider leave the network
+type Egress struct {
+	ProxyURL    string // http, https, socks5 or socks5h proxy (empty connects directly)
+	TLSCertFile string // PEM client certificate presented to the provider
+	TLSKeyFile  string // PEM key of the client certificate
+	TLSCAFile   string // PEM CAs trusted for the provider besides the system's
+}
+
+// Provider environments
+const (
+	EnvironmentProduction = "production"
+	EnvironmentSandbox    = "sandbox"
+)
+
+// Blockchain publishers
+const (
+	PublisherOracle = "oracle" // Sends transactions to the oracle contract
+	PublisherNull   = "null"   // Records would-be transactions for soak tests, without chain access
+)
+
+// ProviderEnvironment holds the provider endpoints and credentials of one
+// environment, so sandbox and production credentials can coexist
+type ProviderEnvironment struct {
+	Name               string
+	DatabaseURL        string // Where the environment's scores are stored (empty uses in-memory SQLite)
+	EthereumRPC        string
+	ContractAddress    string
+	ContractVersion    int // ABI version of the oracle contract on this network
+	CreditBureauURL    string
+	CreditBureauAPIKey string
+	PlaidClientID      string
+	PlaidSecret        string
+	PlaidEnv           string
+	EmploymentAPIURL   string
+	EmploymentAPIKey   string
+	CovalentAPIKey     string
+	CovalentBaseURL    string
+	BlockscoutBaseURL  string
+	BlockscoutChain    string
+}
+
+// Production returns the production provider environment
+func (c *Config) Production() ProviderEnvironment {
+	return ProviderEnvironment{
+		Name:               EnvironmentProduction,
+		DatabaseURL:        c.DatabaseURL,
+		EthereumRPC:        c.EthereumRPC,
+		ContractAddress:    c.ContractAddress,
+		ContractVersion:    c.ContractVersion,
+		CreditBureauURL:    c.CreditBureauURL,
+		CreditBureauAPIKey: c.CreditBureauAPIKey,
+		PlaidClientID:      c.PlaidClientID,
+		PlaidSecret:        c.PlaidSecret,
+		PlaidEnv:           c.PlaidEnv,
+		EmploymentAPIURL:   c.EmploymentAPIURL,
+		EmploymentAPIKey:   c.EmploymentAPIKey,
+		CovalentAPIKey:     c.CovalentAPIKey,
+		CovalentBaseURL:    c.CovalentBaseURL,
+		BlockscoutBaseURL:  c.BlockscoutBaseURL,
+		BlockscoutChain:    c.BlockscoutChain,
+	}
 }
 
 func Load() *Config {
@@ -54,28 +298,149 @@ func Load() *Config {
 		// Server
 		Port: getEnv("PORT", "8080"),
 
+		// Server TLS
+		TLSCertFile:     os.Getenv("TLS_CERT_FILE"),
+		TLSKeyFile:      os.Getenv("TLS_KEY_FILE"),
+		TLSClientCAFile: os.Getenv("TLS_CLIENT_CA_FILE"),
+		TLSClientAuth:   getEnv("TLS_CLIENT_AUTH", "none"),
+
+		// HTTP Compression
+		CompressionMinBytes:      getIntEnv("COMPRESSION_MIN_BYTES", 1024),
+		MaxDecompressedBodyBytes: int64(getIntEnv("MAX_DECOMPRESSED_BODY_BYTES", 32<<20)),
+
+		// HTTP Caching
+		CacheMaxAge: getIntMapEnv("CACHE_MAX_AGE_SECONDS", "score=60,history=300,stats=30,credentials=300,public=3600"),
+
+		// Request Timeouts
+		RequestTimeouts: getIntMapEnv("REQUEST_TIMEOUT_SECONDS", "read=10,update=30,providers=55,admin=300"),
+
 		// Database
-		DatabaseURL: os.Getenv("DATABASE_URL"),
-		RedisURL:    os.Getenv("REDIS_URL"),
+		DatabaseURL:        os.Getenv("DATABASE_URL"),
+		DatabaseReplicaURL: os.Getenv("DATABASE_REPLICA_URL"),
+		ReplicaMaxLagSecs:  getIntEnv("REPLICA_MAX_LAG_SECONDS", 5),
+		MetricsBackend:     getEnv("METRICS_BACKEND", "postgres"),
+		RedisURL:           os.Getenv("REDIS_URL"),
 
 		// Blockchain
-		EthereumRPC:     os.Getenv("ETHEREUM_RPC_URL"),
-		PrivateKey:      os.Getenv("PRIVATE_KEY"),
-		ContractAddress: os.Getenv("CONTRACT_ADDRESS"),
+		EthereumRPC:         os.Getenv("ETHEREUM_RPC_URL"),
+		PrivateKey:          os.Getenv("PRIVATE_KEY"),
+		ContractAddress:     os.Getenv("CONTRACT_ADDRESS"),
+		ContractVersion:     getIntEnv("CONTRACT_VERSION", 1),
+		OracleBatchGasLimit: getIntEnv("ORACLE_BATCH_GAS_LIMIT", 8000000),
+		OracleBatchSize:     getIntEnv("ORACLE_BATCH_SIZE", 100),
+		ChainID:             int64(getIntEnv("CHAIN_ID", 0)),
+
+		// Null Publisher
+		BlockchainPublisher:    getEnv("BLOCKCHAIN_PUBLISHER", PublisherOracle),
+		NullPublisherLatencyMs: getIntEnv("NULL_PUBLISHER_LATENCY_MS", 800),
+		NullPublisherJitterMs:  getIntEnv("NULL_PUBLISHER_JITTER_MS", 400),
+
+		// Canary Publishing
+		CanaryContractAddress: os.Getenv("CANARY_CONTRACT_ADDRESS"),
+		CanaryPercent:         getIntEnv("CANARY_PERCENT", 0),
+		CanaryContractVersion: getIntEnv("CANARY_CONTRACT_VERSION", getIntEnv("CONTRACT_VERSION", 1)),
+
+		// Published Score Expiry
+		ScoreExpiryGraceHours: getIntEnv("SCORE_EXPIRY_GRACE_HOURS", 24),
+		ScoreMaxValidityHours: getIntEnv("SCORE_MAX_VALIDITY_HOURS", 1080),
+
+		// Publish Window
+		PublishMaxBaseFeeGwei:    getFloatEnv("PUBLISH_MAX_BASE_FEE_GWEI", 0),
+		PublishHours:             os.Getenv("PUBLISH_HOURS"),
+		PublishQueueIntervalMins: getIntEnv("PUBLISH_QUEUE_INTERVAL_MINUTES", 5),
+
+		// Publication Confirmation
+		PublishConfirmIntervalSecs: getIntEnv("PUBLISH_CONFIRM_INTERVAL_SECONDS", 60),
+
+		// Update Rate Limiting
+		UpdateMinIntervalSecs: getIntEnv("UPDATE_MIN_INTERVAL_SECONDS", 300),
+
+		// Startup Self-Test
+		StartupSelfTest:     getBoolEnv("STARTUP_SELF_TEST", false),
+		SelfTestTimeoutSecs: getIntEnv("SELF_TEST_TIMEOUT_SECONDS", 10),
+
+		// Maintenance Mode
+		MaintenanceMode:           getBoolEnv("MAINTENANCE_MODE", false),
+		MaintenanceRetryAfterSecs: getIntEnv("MAINTENANCE_RETRY_AFTER_SECONDS", 300),
+
+		// Admin Stats
+		StatsRefreshIntervalSecs: getIntEnv("STATS_REFRESH_INTERVAL_SECONDS", 300),
+
+		// Read Cache
+		CacheTTLSecs: getIntEnv("CACHE_TTL_SECONDS", 0),
+
+		// Public Score Distribution
+		PublicDistributionMinGroupSize: getIntEnv("PUBLIC_DISTRIBUTION_MIN_GROUP_SIZE", 10),
+
+		// Research Export
+		ResearchAPIKeys:       getStringMapEnv("RESEARCH_API_KEYS"),
+		ResearchEpsilon:       getFloatEnv("RESEARCH_EPSILON", 1.0),
+		ResearchDailyBudget:   getFloatEnv("RESEARCH_DAILY_EPSILON_BUDGET", 5.0),
+		ResearchMinBucketSize: getIntEnv("RESEARCH_MIN_BUCKET_SIZE", 10),
+
+		// Position Health Monitoring
+		AavePoolAddress:           os.Getenv("AAVE_POOL_ADDRESS"),
+		HealthMonitorIntervalMins: getIntEnv("HEALTH_MONITOR_INTERVAL_MINUTES", 15),
+		HealthFactorThreshold:     getFloatEnv("HEALTH_FACTOR_THRESHOLD", 1.1),
+
+		// Position Snapshots
+		CompoundCometAddress:         os.Getenv("COMPOUND_COMET_ADDRESS"),
+		PositionSnapshotIntervalMins: getIntEnv("POSITION_SNAPSHOT_INTERVAL_MINUTES", 60),
+
+		// Liquidation Fast Path
+		LiquidationFastPath: getBoolEnv("LIQUIDATION_FAST_PATH", true),
+		LiquidationSLASecs:  getIntEnv("LIQUIDATION_SLA_SECONDS", 300),
+
+		// Pipeline Latency SLO
+		PipelineSLOMs:          getIntMapEnv("PIPELINE_SLO_P95_MS", "total=10000"),
+		PipelineSLOWindowHours: getIntEnv("PIPELINE_SLO_WINDOW_HOURS", 24),
+
+		// Minimum Data Policy
+		MinDataRequirements:   getSliceEnv("MIN_DATA_REQUIREMENTS", []string{"onchain_history", "bureau_file", "verified_income"}),
+		MinOnChainHistoryDays: getIntEnv("MIN_ONCHAIN_HISTORY_DAYS", 90),
+
+		// Tenant Score Policies
+		ScorePoliciesFile: os.Getenv("SCORE_POLICIES_FILE"),
+
+		// Scoring Models
+		ScoringModels: getStringMapEnv("SCORING_MODELS"),
+
+		// Score History Writes
+		HistorySyncWrites:      getBoolEnv("HISTORY_SYNC_WRITES", false),
+		HistoryBufferSize:      getIntEnv("HISTORY_BUFFER_SIZE", 1000),
+		HistoryBatchSize:       getIntEnv("HISTORY_BATCH_SIZE", 100),
+		HistoryFlushIntervalMs: getIntEnv("HISTORY_FLUSH_INTERVAL_MS", 1000),
 
 		// Provider
-		UseMockData: getBoolEnv("USE_MOCK_DATA", false),
+		MockData:                loadMockData(),
+		ProviderCallTimeoutSecs: getIntEnv("PROVIDER_CALL_TIMEOUT_SECONDS", 20),
+		ProviderProbeSecs:       getIntEnv("PROVIDER_PROBE_INTERVAL_SECONDS", 60),
+		IncomePrecedence:        getSliceEnv("INCOME_PRECEDENCE", nil),
+
+		// Provider Egress
+		ProviderEgress: loadProviderEgress(),
 
 		// Credit Bureau
 		CreditBureauProvider: getEnv("CREDIT_BUREAU_PROVIDER", "experian"),
 		CreditBureauURL:      os.Getenv("CREDIT_BUREAU_URL"),
 		CreditBureauAPIKey:   os.Getenv("CREDIT_BUREAU_API_KEY"),
+		CreditBureauRegion:   getEnv("CREDIT_BUREAU_REGION", "us"),
+		BureauWebhookSecret:  os.Getenv("BUREAU_WEBHOOK_SECRET"),
+
+		// Bureau Score Normalization
+		BureauScoreRanges: getRangeMapEnv("BUREAU_SCORE_RANGES"),
+		BureauDTIPercent:  getSliceEnv("BUREAU_DTI_PERCENT", nil),
 
 		// Plaid
 		PlaidClientID: os.Getenv("PLAID_CLIENT_ID"),
 		PlaidSecret:   os.Getenv("PLAID_SECRET"),
 		PlaidEnv:      getEnv("PLAID_ENV", "sandbox"),
 
+		// Employment Verification
+		EmploymentProvider: getEnv("EMPLOYMENT_PROVIDER", "argyle"),
+		EmploymentAPIURL:   os.Getenv("EMPLOYMENT_API_URL"),
+		EmploymentAPIKey:   os.Getenv("EMPLOYMENT_API_KEY"),
+
 		// Covalent
 		CovalentAPIKey:  os.Getenv("COVALENT_API_KEY"),
 		CovalentBaseURL: getEnv("COVALENT_BASE_URL", "https://api.covalenthq.com/v1"),
@@ -89,12 +454,131 @@ func Load() *Config {
 		BlockscoutChain:   getEnv("BLOCKSCOUT_CHAIN", "ethereum"),
 		PreferBlockscout:  getBoolEnv("PREFER_BLOCKSCOUT", true),
 
+		// Address Labels
+		AddressLabelsFile: os.Getenv("ADDRESS_LABELS_FILE"),
+
+		// Balance History
+		BalanceHistoryMonths: getIntEnv("BALANCE_HISTORY_MONTHS", 12),
+
+		// Token Filtering
+		TokenAllowlist: getSliceEnv("TOKEN_ALLOWLIST", nil),
+		TokenBlocklist: getSliceEnv("TOKEN_BLOCKLIST", nil),
+
 		// Multi-Chain
 		EnableMultiChain: getBoolEnv("ENABLE_MULTI_CHAIN", true),
 		TargetChains:     getSliceEnv("TARGET_CHAINS", []string{"ethereum", "polygon", "arbitrum", "optimism", "base"}),
+
+		// Self-Hosted Chain Index
+		ChainIndexEnabled:       getBoolEnv("CHAIN_INDEX_ENABLED", false),
+		ChainIndexRPC:           getEnv("CHAIN_INDEX_RPC_URL", os.Getenv("ETHEREUM_RPC_URL")),
+		ChainIndexStartBlock:    getIntEnv("CHAIN_INDEX_START_BLOCK", 0),
+		ChainIndexWorkers:       getIntEnv("CHAIN_INDEX_WORKERS", 4),
+		ChainIndexBatchSize:     getIntEnv("CHAIN_INDEX_BATCH_SIZE", 100),
+		ChainIndexConfirmations: getIntEnv("CHAIN_INDEX_CONFIRMATIONS", 12),
+		ChainIndexPollSecs:      getIntEnv("CHAIN_INDEX_POLL_SECONDS", 15),
+
+		// Webhooks
+		WebhookSignatureScheme: getEnv("WEBHOOK_SIGNATURE_SCHEME", "hmac-sha256-timestamped"),
+		WebhookToleranceSecs:   getIntEnv("WEBHOOK_TIMESTAMP_TOLERANCE_SECONDS", 300),
+		ScoreWebhookURLs:       getSliceEnv("SCORE_WEBHOOK_URLS", nil),
+		ScoreWebhookSecret:     os.Getenv("SCORE_WEBHOOK_SECRET"),
+		WebhookMaxAttempts:     getIntEnv("WEBHOOK_MAX_ATTEMPTS", 6),
+		WebhookRetryDelaySecs:  getIntEnv("WEBHOOK_RETRY_DELAY_SECONDS", 30),
+
+		// Event Bus
+		EventBus:         getEnv("EVENT_BUS", "none"),
+		EventBusURL:      os.Getenv("EVENT_BUS_URL"),
+		EventTopicPrefix: getEnv("EVENT_TOPIC_PREFIX", "p2p-lend.oracle"),
+		EventBufferSize:  getIntEnv("EVENT_BUFFER_SIZE", 1000),
+
+		// Disaster Recovery Snapshots
+		SnapshotStoreURL:    os.Getenv("SNAPSHOT_STORE_URL"),
+		SnapshotS3Endpoint:  os.Getenv("SNAPSHOT_S3_ENDPOINT"),
+		SnapshotS3Region:    getEnv("SNAPSHOT_S3_REGION", "us-east-1"),
+		SnapshotS3AccessKey: os.Getenv("SNAPSHOT_S3_ACCESS_KEY"),
+		SnapshotS3SecretKey: os.Getenv("SNAPSHOT_S3_SECRET_KEY"),
+
+		// Verifiable Credentials
+		CredentialIssuerURL:     os.Getenv("CREDENTIAL_ISSUER_URL"),
+		CredentialChainID:       int64(getIntEnv("CREDENTIAL_CHAIN_ID", 1)),
+		CredentialValidityHours: getIntEnv("CREDENTIAL_VALIDITY_HOURS", 720),
+
+		// Response Signing
+		SignedResponseAPIKeys: getSliceEnv("SIGNED_RESPONSE_API_KEYS", nil),
+
+		// Data Retention
+		RetentionPolicies:      getIntMapEnv("RETENTION_POLICIES", "failed_oracle_updates=90,webhook_deliveries=90,bureau_alerts=365,debug_traces=14,job_runs=90,position_snapshots=365,pipeline_timings=30"),
+		RetentionIntervalHours: getIntEnv("RETENTION_INTERVAL_HOURS", 24),
+
+		// Provider Environments
+		Sandbox:      loadSandbox(),
+		AdminAPIKeys: getSliceEnv("ADMIN_API_KEYS", nil),
+
+		// Signed Admin Requests
+		AdminSigningKeys:            getStringMapEnv("ADMIN_SIGNING_KEYS"),
+		AdminSignatureToleranceSecs: getIntEnv("ADMIN_SIGNATURE_TOLERANCE_SECONDS", 300),
+	}
+}
+
+func loadSandbox() *ProviderEnvironment {
+	if !getBoolEnv("SANDBOX_ENABLED", false) {
+		return nil
+	}
+	return &ProviderEnvironment{
+		Name:               EnvironmentSandbox,
+		DatabaseURL:        os.Getenv("SANDBOX_DATABASE_URL"),
+		EthereumRPC:        os.Getenv("SANDBOX_ETHEREUM_RPC_URL"),
+		ContractAddress:    os.Getenv("SANDBOX_CONTRACT_ADDRESS"),
+		ContractVersion:    getIntEnv("SANDBOX_CONTRACT_VERSION", 1),
+		CreditBureauURL:    os.Getenv("SANDBOX_CREDIT_BUREAU_URL"),
+		CreditBureauAPIKey: os.Getenv("SANDBOX_CREDIT_BUREAU_API_KEY"),
+		PlaidClientID:      os.Getenv("SANDBOX_PLAID_CLIENT_ID"),
+		PlaidSecret:        os.Getenv("SANDBOX_PLAID_SECRET"),
+		PlaidEnv:           "sandbox",
+		EmploymentAPIURL:   os.Getenv("SANDBOX_EMPLOYMENT_API_URL"),
+		EmploymentAPIKey:   os.Getenv("SANDBOX_EMPLOYMENT_API_KEY"),
+		CovalentAPIKey:     os.Getenv("SANDBOX_COVALENT_API_KEY"),
+		CovalentBaseURL:    getEnv("SANDBOX_COVALENT_BASE_URL", "https://api.covalenthq.com/v1"),
+		BlockscoutBaseURL:  getEnv("SANDBOX_BLOCKSCOUT_BASE_URL", "https://eth-sepolia.blockscout.com"),
+		BlockscoutChain:    getEnv("SANDBOX_BLOCKSCOUT_CHAIN", "sepolia"),
 	}
 }
 
+// loadMockData reads MOCK_DATA. USE_MOCK_DATA=true, which predates it, mocks the
+// off-chain providers when MOCK_DATA is not set.
+func loadMockData() map[string]string {
+	modes := getStringMapEnv("MOCK_DATA")
+	if len(modes) == 0 && getBoolEnv("USE_MOCK_DATA", false) {
+		modes["offchain"] = "true"
+	}
+	return modes
+}
+
+// egressPrefixes are the environment variable prefixes of each provider's egress
+// settings, keyed by the provider's subsystem name
+var egressPrefixes = map[string]string{
+	"credit_bureau":   "CREDIT_BUREAU",
+	"plaid":           "PLAID",
+	"employment":      "EMPLOYMENT",
+	"blockchain_data": "COVALENT",
+	"blockscout":      "BLOCKSCOUT",
+}
+
+// loadProviderEgress reads <PREFIX>_PROXY_URL and <PREFIX>_TLS_{CERT,KEY,CA}_FILE per
+// provider. Providers without their own proxy use PROVIDER_PROXY_URL.
+func loadProviderEgress() map[string]Egress {
+	egress := make(map[string]Egress)
+	for provider, prefix := range egressPrefixes {
+		egress[provider] = Egress{
+			ProxyURL:    getEnv(prefix+"_PROXY_URL", os.Getenv("PROVIDER_PROXY_URL")),
+			TLSCertFile: os.Getenv(prefix + "_TLS_CERT_FILE"),
+			TLSKeyFile:  os.Getenv(prefix + "_TLS_KEY_FILE"),
+			TLSCAFile:   os.Getenv(prefix + "_TLS_CA_FILE"),
+		}
+	}
+	return egress
+}
+
 func getEnv(key, fallback string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -113,6 +597,28 @@ func getBoolEnv(key string, fallback bool) bool {
 	return fallback
 }
 
+func getIntEnv(key string, fallback int) int {
+	if value := os.Getenv(key); value != "" {
+		intVal, err := strconv.Atoi(value)
+		if err != nil {
+			return fallback
+		}
+		return intVal
+	}
+	return fallback
+}
+
+func getFloatEnv(key string, fallback float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		floatVal, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fallback
+		}
+		return floatVal
+	}
+	return fallback
+}
+
 func getSliceEnv(key string, fallback []string) []string {
 	if value := os.Getenv(key); value != "" {
 		// Support comma-separated values: "ethereum,polygon,arbitrum"
@@ -129,6 +635,70 @@ func getSliceEnv(key string, fallback []string) []string {
 	return fallback
 }
 
+func getRangeMapEnv(key string) map[string][2]int {
+	// Support comma-separated "name=min-max" pairs: "transunion_uk=0-710,crif_it=0-1000"
+	result := make(map[string][2]int)
+	for _, entry := range splitAndTrim(os.Getenv(key), ",") {
+		pair := splitAndTrim(entry, "=")
+		if len(pair) != 2 {
+			continue
+		}
+		bounds := splitAndTrim(pair[1], "-")
+		if len(bounds) != 2 {
+			continue
+		}
+		lo, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			continue
+		}
+		hi, err := strconv.Atoi(bounds[1])
+		if err != nil || hi <= lo {
+			continue
+		}
+		result[pair[0]] = [2]int{lo, hi}
+	}
+	return result
+}
+
+func getIntMapEnv(key, fallback string) map[string]int {
+	// Support comma-separated "name=value" pairs: "failed_oracle_updates=90,bureau_alerts=365"
+	value := os.Getenv(key)
+	if value == "" {
+		value = fallback
+	}
+	result := make(map[string]int)
+	for _, entry := range splitAndTrim(value, ",") {
+		pair := splitAndTrim(entry, "=")
+		if len(pair) != 2 {
+			continue
+		}
+		n, err := strconv.Atoi(pair[1])
+		if err != nil || n < 0 {
+			continue
+		}
+		result[pair[0]] = n
+	}
+	return result
+}
+
+func getStringMapEnv(key string) map[string]string {
+	// Support comma-separated "name=value" pairs split at the first "=", so values may
+	// end in base64 padding: "alice=MCowBQYDK2VwAyEA...=,bob=MFkwEwYHKoZI...=="
+	result := make(map[string]string)
+	for _, entry := range splitAndTrim(os.Getenv(key), ",") {
+		for i := 0; i < len(entry); i++ {
+			if entry[i] == '=' {
+				name, value := trimString(entry[:i]), trimString(entry[i+1:])
+				if name != "" && value != "" {
+					result[name] = value
+				}
+				break
+			}
+		}
+	}
+	return result
+}
+
 func splitAndTrim(s, sep string) []string {
 	var result []string
 	for _, v := range splitString(s, sep) {
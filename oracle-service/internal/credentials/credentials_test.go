@@ -0,0 +1,144 @@
+package credentials
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const testKey = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+func TestIssueScoreVerifies(t *testing.T) {
+	issuer, err := NewIssuer("https://oracle.example.com:8443", testKey, 1, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create issuer: %v", err)
+	}
+	if issuer.DID() != "did:web:oracle.example.com%3A8443" {
+		t.Errorf("Unexpected issuer DID %s", issuer.DID())
+	}
+
+	credential, jwt, err := issuer.IssueScore(5, ScoreClaims{
+		Address:    "0x1234567890123456789012345678901234567890",
+		Score:      712,
+		Confidence: 80,
+		DataHash:   "0xabc",
+		ScoredAt:   time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to issue credential: %v", err)
+	}
+
+	var claims struct {
+		Claims
+		VC struct {
+			Type              []string         `json:"type"`
+			CredentialSubject ScoreSubject     `json:"credentialSubject"`
+			CredentialStatus  CredentialStatus `json:"credentialStatus"`
+		} `json:"vc"`
+	}
+	if err := VerifyJWT(jwt, issuer.PublicKey(), &claims); err != nil {
+		t.Fatalf("Failed to verify credential JWT: %v", err)
+	}
+	if claims.Issuer != issuer.DID() || claims.ID != credential.ID {
+		t.Errorf("Unexpected JWT claims %+v", claims.Claims)
+	}
+	if claims.Subject != "did:pkh:eip155:1:0x1234567890123456789012345678901234567890" {
+		t.Errorf("Unexpected holder DID %s", claims.Subject)
+	}
+	if claims.VC.CredentialSubject.CreditScore != 712 {
+		t.Errorf("Expected score 712 in credential, got %d", claims.VC.CredentialSubject.CreditScore)
+	}
+	if claims.VC.CredentialStatus.StatusListIndex != "5" ||
+		claims.VC.CredentialStatus.StatusListCredential != "https://oracle.example.com:8443/api/v1/credentials/status/1" {
+		t.Errorf("Unexpected credential status %+v", claims.VC.CredentialStatus)
+	}
+
+	// A tampered payload must not verify
+	tampered := jwt[:len(jwt)-4] + "AAAA"
+	if err := VerifyJWT(tampered, issuer.PublicKey(), &claims); err != ErrInvalidSignature {
+		t.Errorf("Expected ErrInvalidSignature for tampered JWT, got %v", err)
+	}
+}
+
+func TestStatusList(t *testing.T) {
+	issuer, err := NewIssuer("https://oracle.example.com", testKey, 1, time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create issuer: %v", err)
+	}
+
+	_, jwt, err := issuer.StatusList(1, []int{3, 9})
+	if err != nil {
+		t.Fatalf("Failed to build status list: %v", err)
+	}
+
+	var claims struct {
+		VC struct {
+			CredentialSubject StatusListSubject `json:"credentialSubject"`
+		} `json:"vc"`
+	}
+	if err := VerifyJWT(jwt, issuer.PublicKey(), &claims); err != nil {
+		t.Fatalf("Failed to verify status list JWT: %v", err)
+	}
+
+	for index, expected := range map[int]bool{0: false, 3: true, 8: false, 9: true, StatusListSize - 1: false} {
+		revoked, err := StatusListBit(claims.VC.CredentialSubject.EncodedList, index)
+		if err != nil {
+			t.Fatalf("Failed to read status bit %d: %v", index, err)
+		}
+		if revoked != expected {
+			t.Errorf("Status bit %d: expected %v, got %v", index, expected, revoked)
+		}
+	}
+}
+
+func TestStatusLocation(t *testing.T) {
+	tests := []struct {
+		sequence uint
+		list     int
+		index    int
+	}{
+		{1, 1, 1},
+		{StatusListSize - 1, 1, StatusListSize - 1},
+		{StatusListSize, 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(int(tt.sequence)), func(t *testing.T) {
+			list, index := StatusLocation(tt.sequence)
+			if list != tt.list || index != tt.index {
+				t.Errorf("Expected list %d index %d, got %d and %d", tt.list, tt.index, list, index)
+			}
+		})
+	}
+}
+
+func TestResponseSignatureVerifies(t *testing.T) {
+	signer, err := NewResponseSigner("0x" + testKey)
+	if err != nil {
+		t.Fatalf("Failed to create response signer: %v", err)
+	}
+	body := []byte(`{"address":"0x1234567890123456789012345678901234567890","score":712}`)
+
+	signature, err := signer.Sign(body)
+	if err != nil {
+		t.Fatalf("Failed to sign response: %v", err)
+	}
+	if parts := strings.Split(signature, "."); len(parts) != 3 || parts[1] != "" {
+		t.Fatalf("Expected a detached JWS, got %s", signature)
+	}
+	if err := VerifyResponse(signature, body, signer.Address()); err != nil {
+		t.Fatalf("Failed to verify response signature: %v", err)
+	}
+
+	tampered := bytes.Replace(body, []byte("712"), []byte("812"), 1)
+	if err := VerifyResponse(signature, tampered, signer.Address()); err != ErrInvalidSignature {
+		t.Errorf("Expected ErrInvalidSignature for a tampered body, got %v", err)
+	}
+	other := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	if err := VerifyResponse(signature, body, other); err == nil {
+		t.Error("Expected a signature by another key to be rejected")
+	}
+}
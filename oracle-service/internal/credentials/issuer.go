@@ -0,0 +1,268 @@
+package credentials
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// JSON-LD contexts and types used by issued credentials
+const (
+	ContextCredentialsV1 = "https://www.w3.org/2018/credentials/v1"
+	ContextStatusList    = "https://w3id.org/vc/status-list/2021/v1"
+
+	TypeVerifiableCredential = "VerifiableCredential"
+	TypeCreditScore          = "CreditScoreCredential"
+	TypeStatusList           = "StatusList2021Credential"
+
+	statusPurposeRevocation = "revocation"
+)
+
+// Credential is a W3C Verifiable Credential (data model 1.1)
+type Credential struct {
+	Context           []string          `json:"@context"`
+	ID                string            `json:"id"`
+	Type              []string          `json:"type"`
+	Issuer            string            `json:"issuer"`
+	IssuanceDate      string            `json:"issuanceDate"`
+	ExpirationDate    string            `json:"expirationDate,omitempty"`
+	CredentialSubject interface{}       `json:"credentialSubject"`
+	CredentialStatus  *CredentialStatus `json:"credentialStatus,omitempty"`
+}
+
+// CredentialStatus points at the credential's bit in a revocation status list
+type CredentialStatus struct {
+	ID                   string `json:"id"`
+	Type                 string `json:"type"`
+	StatusPurpose        string `json:"statusPurpose"`
+	StatusListIndex      string `json:"statusListIndex"`
+	StatusListCredential string `json:"statusListCredential"`
+}
+
+// ScoreSubject is the credential subject of a credit score credential
+type ScoreSubject struct {
+	ID          string           `json:"id"` // Holder DID
+	CreditScore units.Score      `json:"creditScore"`
+	Confidence  units.Confidence `json:"confidence"`
+	ScoreRange  ScoreRange       `json:"scoreRange"`
+	DataHash    string           `json:"dataHash"`
+	ScoredAt    string           `json:"scoredAt"`
+}
+
+// ScoreRange is the range credit scores fall in
+type ScoreRange struct {
+	Min units.Score `json:"min"`
+	Max units.Score `json:"max"`
+}
+
+// StatusListSubject is the credential subject of a status list credential
+type StatusListSubject struct {
+	ID            string `json:"id"`
+	Type          string `json:"type"`
+	StatusPurpose string `json:"statusPurpose"`
+	EncodedList   string `json:"encodedList"`
+}
+
+// ScoreClaims is the score data packaged into a credential
+type ScoreClaims struct {
+	Address    string
+	Score      units.Score
+	Confidence units.Confidence
+	DataHash   string
+	ScoredAt   time.Time
+}
+
+// Claims are the JWT claims of a JWT-encoded credential (VC-JWT)
+type Claims struct {
+	Issuer     string      `json:"iss"`
+	Subject    string      `json:"sub,omitempty"`
+	ID         string      `json:"jti"`
+	NotBefore  int64       `json:"nbf"`
+	IssuedAt   int64       `json:"iat"`
+	Expiration int64       `json:"exp,omitempty"`
+	VC         *Credential `json:"vc"`
+}
+
+// Issuer signs credit score credentials and revocation status lists as a did:web
+// issuer, using the oracle's secp256k1 key
+type Issuer struct {
+	key      *ecdsa.PrivateKey
+	baseURL  string
+	did      string
+	chainID  int64
+	validity time.Duration
+	now      func() time.Time
+}
+
+// NewIssuer creates an issuer whose DID is did:web of baseURL's host. The DID
+// document must be served at baseURL/.well-known/did.json. Holders are identified by
+// did:pkh on chainID, and credentials are valid for validity.
+func NewIssuer(baseURL, privateKeyHex string, chainID int64, validity time.Duration) (*Issuer, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid credential issuer URL %q", baseURL)
+	}
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	return &Issuer{
+		key:      key,
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		did:      "did:web:" + strings.ReplaceAll(u.Host, ":", "%3A"),
+		chainID:  chainID,
+		validity: validity,
+		now:      time.Now,
+	}, nil
+}
+
+// DID returns the issuer's DID
+func (i *Issuer) DID() string {
+	return i.did
+}
+
+// KeyID returns the DID URL of the issuer's signing key
+func (i *Issuer) KeyID() string {
+	return i.did + "#oracle-key"
+}
+
+// PublicKey returns the issuer's public key
+func (i *Issuer) PublicKey() *ecdsa.PublicKey {
+	return &i.key.PublicKey
+}
+
+// HolderDID returns the did:pkh of a wallet address
+func (i *Issuer) HolderDID(address string) string {
+	return fmt.Sprintf("did:pkh:eip155:%d:%s", i.chainID, address)
+}
+
+// StatusListURL returns the URL of a revocation status list credential
+func (i *Issuer) StatusListURL(list int) string {
+	return fmt.Sprintf("%s/api/v1/credentials/status/%d", i.baseURL, list)
+}
+
+// DIDDocument returns the issuer's did:web document
+func (i *Issuer) DIDDocument() map[string]interface{} {
+	return map[string]interface{}{
+		"@context": []string{"https://www.w3.org/ns/did/v1", "https://w3id.org/security/suites/jws-2020/v1"},
+		"id":       i.did,
+		"verificationMethod": []map[string]interface{}{{
+			"id":           i.KeyID(),
+			"type":         "JsonWebKey2020",
+			"controller":   i.did,
+			"publicKeyJwk": publicJWK(&i.key.PublicKey),
+		}},
+		"assertionMethod": []string{i.KeyID()},
+	}
+}
+
+// IssueScore packages a score as a credential with the given sequence number, which
+// determines its position in the revocation status lists. It returns the credential
+// and its signed JWT.
+func (i *Issuer) IssueScore(sequence uint, claims ScoreClaims) (*Credential, string, error) {
+	id, err := newURNUUID()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := i.now().UTC()
+	expires := now.Add(i.validity)
+	list, index := StatusLocation(sequence)
+	listURL := i.StatusListURL(list)
+
+	credential := &Credential{
+		Context:        []string{ContextCredentialsV1, ContextStatusList},
+		ID:             id,
+		Type:           []string{TypeVerifiableCredential, TypeCreditScore},
+		Issuer:         i.did,
+		IssuanceDate:   now.Format(time.RFC3339),
+		ExpirationDate: expires.Format(time.RFC3339),
+		CredentialSubject: ScoreSubject{
+			ID:          i.HolderDID(claims.Address),
+			CreditScore: claims.Score,
+			Confidence:  claims.Confidence,
+			ScoreRange:  ScoreRange{Min: scoring.MinScore, Max: scoring.MaxScore},
+			DataHash:    claims.DataHash,
+			ScoredAt:    claims.ScoredAt.UTC().Format(time.RFC3339),
+		},
+		CredentialStatus: &CredentialStatus{
+			ID:                   fmt.Sprintf("%s#%d", listURL, index),
+			Type:                 "StatusList2021Entry",
+			StatusPurpose:        statusPurposeRevocation,
+			StatusListIndex:      fmt.Sprintf("%d", index),
+			StatusListCredential: listURL,
+		},
+	}
+
+	jwt, err := signJWT(i.key, i.KeyID(), Claims{
+		Issuer:     i.did,
+		Subject:    i.HolderDID(claims.Address),
+		ID:         id,
+		NotBefore:  now.Unix(),
+		IssuedAt:   now.Unix(),
+		Expiration: expires.Unix(),
+		VC:         credential,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return credential, jwt, nil
+}
+
+// StatusList returns a signed revocation status list credential with the given
+// indexes revoked
+func (i *Issuer) StatusList(list int, revoked []int) (*Credential, string, error) {
+	encoded, err := EncodeStatusList(revoked)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := i.now().UTC()
+	listURL := i.StatusListURL(list)
+	credential := &Credential{
+		Context:      []string{ContextCredentialsV1, ContextStatusList},
+		ID:           listURL,
+		Type:         []string{TypeVerifiableCredential, TypeStatusList},
+		Issuer:       i.did,
+		IssuanceDate: now.Format(time.RFC3339),
+		CredentialSubject: StatusListSubject{
+			ID:            listURL + "#list",
+			Type:          "StatusList2021",
+			StatusPurpose: statusPurposeRevocation,
+			EncodedList:   encoded,
+		},
+	}
+
+	jwt, err := signJWT(i.key, i.KeyID(), Claims{
+		Issuer:    i.did,
+		ID:        listURL,
+		NotBefore: now.Unix(),
+		IssuedAt:  now.Unix(),
+		VC:        credential,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return credential, jwt, nil
+}
+
+// newURNUUID returns a random (version 4) UUID URN
+func newURNUUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate credential ID: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("urn:uuid:%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
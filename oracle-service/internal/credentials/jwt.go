@@ -0,0 +1,105 @@
+package credentials
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// JWTAlgorithm is the JOSE algorithm credentials are signed with: ECDSA over
+// secp256k1 with SHA-256, the oracle's key type
+const JWTAlgorithm = "ES256K"
+
+// ErrInvalidSignature is returned when a JWT's signature does not verify
+var ErrInvalidSignature = errors.New("invalid JWT signature")
+
+type jwtHeader struct {
+	Alg string            `json:"alg"`
+	Typ string            `json:"typ,omitempty"`
+	Kid string            `json:"kid,omitempty"`
+	JWK map[string]string `json:"jwk,omitempty"`
+}
+
+// signJWT encodes claims as a compact JWS signed with key
+func signJWT(key *ecdsa.PrivateKey, kid string, claims interface{}) (string, error) {
+	header, err := encodeSegment(jwtHeader{Alg: JWTAlgorithm, Typ: "JWT", Kid: kid})
+	if err != nil {
+		return "", err
+	}
+	payload, err := encodeSegment(claims)
+	if err != nil {
+		return "", err
+	}
+
+	input := header + "." + payload
+	hash := sha256.Sum256([]byte(input))
+	sig, err := crypto.Sign(hash[:], key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT: %w", err)
+	}
+
+	// JOSE signatures are R || S without the recovery ID
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig[:64]), nil
+}
+
+// VerifyJWT checks an ES256K JWT against pub and decodes its claims into v
+func VerifyJWT(token string, pub *ecdsa.PublicKey, v interface{}) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("malformed JWT")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return err
+	}
+	if header.Alg != JWTAlgorithm {
+		return fmt.Errorf("unsupported JWT algorithm %q", header.Alg)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fmt.Errorf("malformed JWT signature: %w", err)
+	}
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if len(sig) != 64 || !crypto.VerifySignature(crypto.FromECDSAPub(pub), hash[:], sig) {
+		return ErrInvalidSignature
+	}
+
+	return decodeSegment(parts[1], v)
+}
+
+// publicJWK encodes a secp256k1 public key as a JWK
+func publicJWK(pub *ecdsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "EC",
+		"crv": "secp256k1",
+		"x":   base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, 32))),
+		"y":   base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func encodeSegment(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JWT segment: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("malformed JWT segment: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("malformed JWT segment: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package credentials
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ResponseSigner signs API response bodies with the oracle key as detached JWS
+// (RFC 7515 appendix F), so consumers that cache the data can check later that it
+// came from the oracle. The protected header names the signer's address as kid and
+// carries its public key as jwk.
+type ResponseSigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewResponseSigner creates a signer for the oracle's private key
+func NewResponseSigner(privateKeyHex string) (*ResponseSigner, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return &ResponseSigner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}, nil
+}
+
+// Address returns the address of the signing key
+func (s *ResponseSigner) Address() common.Address {
+	return s.address
+}
+
+// Sign returns the detached JWS of body: the compact serialization with the payload
+// left out
+func (s *ResponseSigner) Sign(body []byte) (string, error) {
+	header, err := encodeSegment(jwtHeader{Alg: JWTAlgorithm, Kid: s.address.Hex(), JWK: publicJWK(&s.key.PublicKey)})
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256([]byte(header + "." + base64.RawURLEncoding.EncodeToString(body)))
+	sig, err := crypto.Sign(hash[:], s.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign response: %w", err)
+	}
+	return header + ".." + base64.RawURLEncoding.EncodeToString(sig[:64]), nil
+}
+
+// VerifyResponse checks a detached JWS from ResponseSigner against the response body
+// and the oracle address the consumer trusts
+func VerifyResponse(signature string, body []byte, signer common.Address) error {
+	parts := strings.Split(signature, ".")
+	if len(parts) != 3 || parts[1] != "" {
+		return fmt.Errorf("malformed detached JWS")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return err
+	}
+	if header.Alg != JWTAlgorithm {
+		return fmt.Errorf("unsupported JWS algorithm %q", header.Alg)
+	}
+	pub, err := parseJWK(header.JWK)
+	if err != nil {
+		return err
+	}
+	if crypto.PubkeyToAddress(*pub) != signer {
+		return fmt.Errorf("JWS signed by %s, expected %s", crypto.PubkeyToAddress(*pub).Hex(), signer.Hex())
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fmt.Errorf("malformed JWS signature: %w", err)
+	}
+	hash := sha256.Sum256([]byte(parts[0] + "." + base64.RawURLEncoding.EncodeToString(body)))
+	if len(sig) != 64 || !crypto.VerifySignature(crypto.FromECDSAPub(pub), hash[:], sig) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// parseJWK decodes a secp256k1 public key JWK
+func parseJWK(jwk map[string]string) (*ecdsa.PublicKey, error) {
+	if jwk["kty"] != "EC" || jwk["crv"] != "secp256k1" {
+		return nil, fmt.Errorf("unsupported JWK %s %s", jwk["kty"], jwk["crv"])
+	}
+	x, errX := base64.RawURLEncoding.DecodeString(jwk["x"])
+	y, errY := base64.RawURLEncoding.DecodeString(jwk["y"])
+	if errX != nil || errY != nil || len(x) != 32 || len(y) != 32 {
+		return nil, fmt.Errorf("malformed JWK coordinates")
+	}
+
+	pub := &ecdsa.PublicKey{Curve: crypto.S256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+		return nil, fmt.Errorf("JWK is not a secp256k1 point")
+	}
+	return pub, nil
+}
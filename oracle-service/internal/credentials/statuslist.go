@@ -0,0 +1,64 @@
+package credentials
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+)
+
+// StatusListSize is the number of credentials one status list covers. It is the
+// minimum the StatusList2021 specification recommends, so a list does not reveal
+// which credential a verifier is checking.
+const StatusListSize = 131072
+
+// StatusLocation returns the status list and bit position of the credential with
+// the given sequence number (starting at 1)
+func StatusLocation(sequence uint) (list int, index int) {
+	return int(sequence/StatusListSize) + 1, int(sequence % StatusListSize)
+}
+
+// EncodeStatusList returns the gzipped, base64url-encoded bitstring with the given
+// indexes set. Index 0 is the most significant bit of the first byte.
+func EncodeStatusList(revoked []int) (string, error) {
+	bits := make([]byte, StatusListSize/8)
+	for _, index := range revoked {
+		if index < 0 || index >= StatusListSize {
+			return "", fmt.Errorf("status list index %d out of range", index)
+		}
+		bits[index/8] |= 0x80 >> (index % 8)
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(bits); err != nil {
+		return "", err
+	}
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// StatusListBit reports whether index is set in an encoded status list
+func StatusListBit(encoded string, index int) (bool, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return false, fmt.Errorf("malformed status list: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return false, fmt.Errorf("malformed status list: %w", err)
+	}
+	defer zr.Close()
+	bits, err := io.ReadAll(zr)
+	if err != nil {
+		return false, fmt.Errorf("malformed status list: %w", err)
+	}
+
+	if index < 0 || index/8 >= len(bits) {
+		return false, fmt.Errorf("status list index %d out of range", index)
+	}
+	return bits[index/8]&(0x80>>(index%8)) != 0, nil
+}
@@ -0,0 +1,226 @@
+// Package debugtrace records the 3rd party provider calls made while serving a
+// request, so a traced request can be replayed step by step when analyzing a scoring
+// discrepancy.
+//
+// A Recorder is attached to the request context. Providers send their HTTP calls
+// through a Transport, which records every call made with a context that carries a
+// recorder and passes all other calls through untouched. Bodies are truncated, and
+// credentials in query strings and JSON bodies are redacted before they are recorded.
+package debugtrace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxBodyBytes is the most recorded of any request or response body
+const MaxBodyBytes = 64 << 10
+
+// Redacted replaces credentials in recorded URLs and bodies
+const Redacted = "[REDACTED]"
+
+// sensitiveNames are substrings of query parameter and JSON field names whose values
+// are never recorded. A plain "key" or "token" is matched whole, since many response
+// fields merely contain the word (token_balances, for one).
+var sensitiveNames = []string{"secret", "password", "access_token", "refresh_token", "public_token", "api_key", "apikey", "client_id", "authorization"}
+
+// ProviderCall is one HTTP call to a provider
+type ProviderCall struct {
+	Method       string    `json:"method"`
+	URL          string    `json:"url"`
+	RequestBody  string    `json:"request_body,omitempty"`
+	Status       int       `json:"status,omitempty"`
+	ResponseBody string    `json:"response_body,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	DurationMs   int64     `json:"duration_ms"`
+	StartedAt    time.Time `json:"started_at"`
+}
+
+// Recorder collects the provider calls of one request. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []ProviderCall
+}
+
+// NewRecorder creates an empty recorder
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Add records a provider call
+func (r *Recorder) Add(call ProviderCall) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+// Calls returns the recorded calls in the order they finished
+func (r *Recorder) Calls() []ProviderCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]ProviderCall(nil), r.calls...)
+}
+
+type recorderKey struct{}
+
+// WithRecorder returns a context whose provider calls are recorded by r
+func WithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, r)
+}
+
+// FromContext returns the recorder attached to ctx, or nil if the request is not
+// traced
+func FromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return r
+}
+
+// Transport records the calls of traced requests before passing them to its base
+// round tripper
+type Transport struct {
+	base http.RoundTripper
+}
+
+// NewTransport wraps base, or http.DefaultTransport if base is nil
+func NewTransport(base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{base: base}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorder := FromContext(req.Context())
+	if recorder == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	call := ProviderCall{
+		Method:    req.Method,
+		URL:       RedactURL(req.URL),
+		StartedAt: time.Now().UTC(),
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		call.RequestBody = RedactBody(body)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		call.Error = err.Error()
+		call.DurationMs = time.Since(call.StartedAt).Milliseconds()
+		recorder.Add(call)
+		return nil, err
+	}
+
+	// The provider reads the response from the recorded copy
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	call.Status = resp.StatusCode
+	call.ResponseBody = RedactBody(body)
+	if err != nil {
+		call.Error = err.Error()
+	}
+	call.DurationMs = time.Since(call.StartedAt).Milliseconds()
+	recorder.Add(call)
+
+	return resp, nil
+}
+
+// RedactURL formats u with the values of credential query parameters and any user
+// info removed
+func RedactURL(u *url.URL) string {
+	redacted := *u
+	redacted.User = nil
+	redacted.RawQuery = RedactQuery(u.RawQuery)
+	return redacted.String()
+}
+
+// RedactQuery removes the values of credential parameters from a query string
+func RedactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return Redacted
+	}
+	for name := range query {
+		if isSensitive(name) {
+			query.Set(name, Redacted)
+		}
+	}
+	return query.Encode()
+}
+
+// RedactBody formats a body for recording. Credential fields of JSON bodies are
+// redacted, and bodies are truncated to MaxBodyBytes.
+func RedactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	// Numbers are kept as written, so wei amounts don't lose precision
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if decoder.Decode(&value) == nil {
+		if redacted, err := json.Marshal(redactValue(value)); err == nil {
+			body = redacted
+		}
+	}
+
+	if len(body) > MaxBodyBytes {
+		return string(body[:MaxBodyBytes]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+// redactValue replaces the values of credential fields anywhere in a decoded JSON
+// value. Only string values are credentials; objects such as a token balance's token
+// are kept.
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, field := range v {
+			if _, ok := field.(string); ok && isSensitive(name) {
+				v[name] = Redacted
+			} else {
+				v[name] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
+
+func isSensitive(name string) bool {
+	name = strings.ToLower(name)
+	if name == "key" || name == "token" {
+		return true
+	}
+	for _, sensitive := range sensitiveNames {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
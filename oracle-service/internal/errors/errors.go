@@ -0,0 +1,108 @@
+// Package errors classifies the oracle's errors into a small taxonomy, so the API can
+// pick a status code from what went wrong rather than from which handler it is in.
+//
+// An error is classified by wrapping it with one of the kinds below. Wrapping keeps the
+// error's message and chain, so errors.Is matches both the kind and any error it was
+// wrapped around. The standard library's New, Is, As, Join and Unwrap are re-exported
+// so callers need only one errors import.
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Error kinds
+var (
+	ErrNotFound            = errors.New("not found")
+	ErrProviderUnavailable = errors.New("provider unavailable")
+	ErrValidation          = errors.New("validation failed")
+	ErrBlockchain          = errors.New("blockchain error")
+)
+
+// kinds is every error kind, in the order KindOf checks them
+var kinds = []error{ErrValidation, ErrNotFound, ErrProviderUnavailable, ErrBlockchain}
+
+// kindError classifies an error without changing its message
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.err, e.kind}
+}
+
+// Wrap classifies err as kind. It returns nil if err is nil.
+func Wrap(kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &kindError{kind: kind, err: err}
+}
+
+// NotFound formats an error for a resource that does not exist
+func NotFound(format string, args ...interface{}) error {
+	return Wrap(ErrNotFound, fmt.Errorf(format, args...))
+}
+
+// ProviderUnavailable formats an error for a third party provider that could not be
+// reached or failed to answer
+func ProviderUnavailable(format string, args ...interface{}) error {
+	return Wrap(ErrProviderUnavailable, fmt.Errorf(format, args...))
+}
+
+// Validation formats an error for input that was rejected
+func Validation(format string, args ...interface{}) error {
+	return Wrap(ErrValidation, fmt.Errorf(format, args...))
+}
+
+// Blockchain formats an error for a failed call to the Ethereum node or oracle contract
+func Blockchain(format string, args ...interface{}) error {
+	return Wrap(ErrBlockchain, fmt.Errorf(format, args...))
+}
+
+// KindOf returns the kind err was classified as, or nil if it wasn't
+func KindOf(err error) error {
+	for _, kind := range kinds {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	return nil
+}
+
+// IsTimeout reports whether err is, or wraps, an exceeded context deadline
+func IsTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// New returns an error with the given text
+func New(text string) error {
+	return errors.New(text)
+}
+
+// Is reports whether any error in err's chain matches target
+func Is(err, target error) bool {
+	return errors.Is(err, target)
+}
+
+// As finds the first error in err's chain that matches target
+func As(err error, target interface{}) bool {
+	return errors.As(err, target)
+}
+
+// Join returns an error that wraps the given errors
+func Join(errs ...error) error {
+	return errors.Join(errs...)
+}
+
+// Unwrap returns the result of calling err's Unwrap method, if any
+func Unwrap(err error) error {
+	return errors.Unwrap(err)
+}
@@ -0,0 +1,57 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestWrapKeepsMessageAndChain(t *testing.T) {
+	cause := New("connection refused")
+	err := ProviderUnavailable("failed to fetch transactions: %w", cause)
+
+	if err.Error() != "failed to fetch transactions: connection refused" {
+		t.Errorf("Expected the message to be unchanged, got %q", err.Error())
+	}
+	if !Is(err, ErrProviderUnavailable) || !Is(err, cause) {
+		t.Error("Expected the error to match both its kind and its cause")
+	}
+
+	wrapped := fmt.Errorf("failed to fetch on-chain metrics: %w", err)
+	if KindOf(wrapped) != ErrProviderUnavailable {
+		t.Errorf("Expected the kind to survive further wrapping, got %v", KindOf(wrapped))
+	}
+
+	if Wrap(ErrNotFound, nil) != nil {
+		t.Error("Expected wrapping nil to return nil")
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{NotFound("no score found"), ErrNotFound},
+		{Validation("invalid address %s", "0x1"), ErrValidation},
+		{Blockchain("failed to send transaction: %w", New("nonce too low")), ErrBlockchain},
+		{New("disk full"), nil},
+		{nil, nil},
+	}
+
+	for _, tt := range tests {
+		if got := KindOf(tt.err); got != tt.expected {
+			t.Errorf("KindOf(%v) = %v, expected %v", tt.err, got, tt.expected)
+		}
+	}
+}
+
+func TestIsTimeout(t *testing.T) {
+	err := ProviderUnavailable("failed to execute request: %w", context.DeadlineExceeded)
+	if !IsTimeout(err) {
+		t.Error("Expected a wrapped deadline to be a timeout")
+	}
+	if IsTimeout(ProviderUnavailable("failed to execute request: %w", context.Canceled)) {
+		t.Error("Expected a cancellation not to be a timeout")
+	}
+}
@@ -0,0 +1,86 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// AsyncPublisher queues events and publishes them from a background goroutine so a
+// slow or unavailable bus never holds up scoring. Events are dropped, with a warning,
+// when the queue is full.
+type AsyncPublisher struct {
+	publisher Publisher
+	queue     chan Event
+	done      chan struct{}
+	mu        sync.RWMutex
+	closed    bool
+}
+
+// NewAsyncPublisher starts publishing queued events through publisher
+func NewAsyncPublisher(publisher Publisher, bufferSize int) *AsyncPublisher {
+	if bufferSize <= 0 {
+		bufferSize = 1000
+	}
+
+	a := &AsyncPublisher{
+		publisher: publisher,
+		queue:     make(chan Event, bufferSize),
+		done:      make(chan struct{}),
+	}
+	go a.run()
+
+	return a
+}
+
+// Publish queues the event without blocking. Events published after Close are dropped.
+func (a *AsyncPublisher) Publish(ctx context.Context, event Event) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.closed {
+		return nil
+	}
+
+	select {
+	case a.queue <- event:
+	default:
+		logger.Warn("Event queue full, dropping event",
+			zap.String("type", event.Type),
+			zap.String("subject", event.Subject),
+		)
+	}
+	return nil
+}
+
+// Close publishes the queued events and closes the underlying publisher
+func (a *AsyncPublisher) Close() error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return nil
+	}
+	a.closed = true
+	close(a.queue)
+	a.mu.Unlock()
+
+	<-a.done
+	return a.publisher.Close()
+}
+
+func (a *AsyncPublisher) run() {
+	defer close(a.done)
+	for event := range a.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		if err := a.publisher.Publish(ctx, event); err != nil {
+			logger.Error("Failed to publish event",
+				zap.String("type", event.Type),
+				zap.String("subject", event.Subject),
+				zap.Error(err),
+			)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,92 @@
+// Package events publishes score lifecycle events to a message bus so other
+// services can react without polling the REST API.
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Event types
+const (
+	ScoreCalculated    = "score.calculated"
+	ScorePublished     = "score.published"
+	ProviderFailed     = "provider.failed"
+	DisputeOpened      = "dispute.opened"
+	PositionAtRisk     = "position.at_risk"
+	SignerUnauthorized = "publisher.signer_unauthorized"
+)
+
+// Supported bus kinds
+const (
+	BusNone  = "none"
+	BusKafka = "kafka"
+	BusNATS  = "nats"
+)
+
+// Event is the envelope published for every event
+type Event struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	Source     string      `json:"source"`
+	Subject    string      `json:"subject,omitempty"` // Address the event concerns; used as the partition key
+	Time       time.Time   `json:"time"`
+	DataSchema string      `json:"dataschema,omitempty"` // API path of the JSON Schema Data conforms to
+	Data       interface{} `json:"data"`
+}
+
+// NewEvent creates an event with a random ID
+func NewEvent(eventType, subject string, data interface{}) Event {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return Event{
+		ID:      hex.EncodeToString(b),
+		Type:    eventType,
+		Source:  "oracle-service",
+		Subject: subject,
+		Time:    time.Now().UTC(),
+		Data:    data,
+	}
+}
+
+// Publisher sends events to a message bus
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+	Close() error
+}
+
+// NewPublisher creates a publisher for the configured bus. Kafka is reached through a
+// Kafka REST proxy at url; NATS through a nats:// server url. Topics (Kafka) and
+// subjects (NATS) are "<prefix>.<event type>".
+func NewPublisher(kind, url, prefix string) (Publisher, error) {
+	switch strings.ToLower(kind) {
+	case "", BusNone:
+		return nil, nil
+	case BusKafka:
+		publisher, err := NewKafkaPublisher(url, prefix)
+		if err != nil {
+			return nil, err
+		}
+		return publisher, nil
+	case BusNATS:
+		publisher, err := NewNATSPublisher(url, prefix)
+		if err != nil {
+			return nil, err
+		}
+		return publisher, nil
+	default:
+		return nil, fmt.Errorf("unknown event bus %q", kind)
+	}
+}
+
+// topicName joins the prefix and event type into a topic or subject name
+func topicName(prefix, eventType string) string {
+	if prefix == "" {
+		return eventType
+	}
+	return prefix + "." + eventType
+}
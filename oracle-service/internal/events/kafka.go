@@ -0,0 +1,99 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// KafkaPublisher publishes events through a Kafka REST proxy (v2 API). Events are keyed
+// by subject so each address's events stay ordered within a partition.
+type KafkaPublisher struct {
+	httpClient *http.Client
+	baseURL    string
+	prefix     string
+}
+
+type kafkaRecord struct {
+	Key   string `json:"key,omitempty"`
+	Value Event  `json:"value"`
+}
+
+type kafkaProduceRequest struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+// NewKafkaPublisher creates a publisher for the REST proxy at baseURL
+func NewKafkaPublisher(baseURL, prefix string) (*KafkaPublisher, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("kafka REST proxy url is required")
+	}
+	if _, err := url.Parse(baseURL); err != nil {
+		return nil, fmt.Errorf("invalid kafka REST proxy url: %w", err)
+	}
+
+	return &KafkaPublisher{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		baseURL: strings.TrimRight(baseURL, "/"),
+		prefix:  prefix,
+	}, nil
+}
+
+// Publish produces the event to the "<prefix>.<type>" topic
+func (p *KafkaPublisher) Publish(ctx context.Context, event Event) error {
+	body, err := json.Marshal(kafkaProduceRequest{
+		Records: []kafkaRecord{{Key: event.Subject, Value: event}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/topics/%s", p.baseURL, url.PathEscape(topicName(p.prefix, event.Type)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to produce event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("kafka REST proxy returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	// The proxy reports per-record failures in a 200 response
+	var result struct {
+		Offsets []struct {
+			Error string `json:"error"`
+		} `json:"offsets"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err == nil {
+		for _, offset := range result.Offsets {
+			if offset.Error != "" {
+				return fmt.Errorf("kafka rejected event: %s", offset.Error)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Close releases idle connections
+func (p *KafkaPublisher) Close() error {
+	p.httpClient.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,221 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultNATSPort = "4222"
+	natsTimeout     = 10 * time.Second
+)
+
+// NATSPublisher publishes events to a NATS server using the core text protocol. Every
+// publish is flushed with a PING so that errors surface to the caller, and a broken
+// connection is re-established once before giving up.
+type NATSPublisher struct {
+	addr     string
+	useTLS   bool
+	user     string
+	password string
+	token    string
+	prefix   string
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewNATSPublisher creates a publisher for a nats:// or tls:// server url. Credentials
+// may be given as user:password@ or token@ in the url.
+func NewNATSPublisher(serverURL, prefix string) (*NATSPublisher, error) {
+	if serverURL == "" {
+		return nil, fmt.Errorf("nats server url is required")
+	}
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nats url: %w", err)
+	}
+	if u.Scheme != "nats" && u.Scheme != "tls" {
+		return nil, fmt.Errorf("invalid nats url scheme %q: expected nats or tls", u.Scheme)
+	}
+
+	p := &NATSPublisher{
+		addr:   u.Host,
+		useTLS: u.Scheme == "tls",
+		prefix: prefix,
+	}
+	if u.Port() == "" {
+		p.addr = net.JoinHostPort(u.Hostname(), defaultNATSPort)
+	}
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			p.user = u.User.Username()
+			p.password = password
+		} else {
+			p.token = u.User.Username()
+		}
+	}
+
+	return p, nil
+}
+
+// Publish sends the event to the "<prefix>.<type>" subject
+func (p *NATSPublisher) Publish(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	subject := topicName(p.prefix, event.Type)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	err = p.publish(ctx, subject, payload)
+	if err != nil && p.conn != nil {
+		// The server may have dropped an idle connection; retry once on a fresh one
+		p.closeConn()
+		err = p.publish(ctx, subject, payload)
+	}
+	if err != nil {
+		p.closeConn()
+		return fmt.Errorf("failed to publish to nats: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the server connection
+func (p *NATSPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closeConn()
+	return nil
+}
+
+func (p *NATSPublisher) publish(ctx context.Context, subject string, payload []byte) error {
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			return err
+		}
+	}
+	p.setDeadline(ctx)
+
+	msg := fmt.Sprintf("PUB %s %d\r\n%s\r\nPING\r\n", subject, len(payload), payload)
+	if _, err := p.conn.Write([]byte(msg)); err != nil {
+		return err
+	}
+	return p.awaitPong()
+}
+
+func (p *NATSPublisher) connect(ctx context.Context) error {
+	dialer := &net.Dialer{Timeout: natsTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", p.addr, err)
+	}
+	p.conn = conn
+	p.reader = bufio.NewReader(conn)
+	p.setDeadline(ctx)
+
+	// The server greets with INFO before anything else
+	line, err := p.reader.ReadString('\n')
+	if err != nil {
+		p.closeConn()
+		return fmt.Errorf("failed to read server info: %w", err)
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		p.closeConn()
+		return fmt.Errorf("unexpected server greeting: %q", strings.TrimSpace(line))
+	}
+
+	if p.useTLS {
+		host, _, _ := net.SplitHostPort(p.addr)
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			p.closeConn()
+			return fmt.Errorf("tls handshake failed: %w", err)
+		}
+		p.conn = tlsConn
+		p.reader = bufio.NewReader(tlsConn)
+		p.setDeadline(ctx)
+	}
+
+	options := map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     "oracle-service",
+		"lang":     "go",
+		"version":  "1.0.0",
+		"protocol": 1,
+	}
+	if p.user != "" {
+		options["user"] = p.user
+		options["pass"] = p.password
+	}
+	if p.token != "" {
+		options["auth_token"] = p.token
+	}
+	connectJSON, err := json.Marshal(options)
+	if err != nil {
+		p.closeConn()
+		return err
+	}
+
+	if _, err := p.conn.Write([]byte("CONNECT " + string(connectJSON) + "\r\nPING\r\n")); err != nil {
+		p.closeConn()
+		return err
+	}
+	if err := p.awaitPong(); err != nil {
+		p.closeConn()
+		return err
+	}
+
+	return nil
+}
+
+// awaitPong reads until the server answers our PING, answering server PINGs and
+// surfacing -ERR responses
+func (p *NATSPublisher) awaitPong() error {
+	for {
+		line, err := p.reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := p.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("server error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+func (p *NATSPublisher) setDeadline(ctx context.Context) {
+	deadline := time.Now().Add(natsTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	p.conn.SetDeadline(deadline)
+}
+
+func (p *NATSPublisher) closeConn() {
+	if p.conn != nil {
+		p.conn.Close()
+		p.conn = nil
+		p.reader = nil
+	}
+}
@@ -0,0 +1,132 @@
+package i18n
+
+// spanish is the Spanish message catalog
+var spanish = map[string]string{
+	// Error titles
+	"Admin key required":                "Se requiere una clave de administrador",
+	"Blockchain client unavailable":     "Cliente de blockchain no disponible",
+	"Credit score not found":            "Puntaje crediticio no encontrado",
+	"Failed to build status list":       "No se pudo generar la lista de estado",
+	"Failed to calculate credit score":  "No se pudo calcular el puntaje crediticio",
+	"Failed to compare model versions":  "No se pudieron comparar las versiones del modelo",
+	"Failed to create share link":       "No se pudo crear el enlace para compartir",
+	"Failed to estimate publish cost":   "No se pudo estimar el costo de publicación",
+	"Failed to explain credit score":    "No se pudo explicar el puntaje crediticio",
+	"Failed to export credit scores":    "No se pudieron exportar los puntajes crediticios",
+	"Failed to export research dataset": "No se pudo exportar el conjunto de datos de investigación",
+	"Failed to get data freeze":         "No se pudo obtener el estado de congelamiento",
+	"Failed to get DID document":        "No se pudo obtener el documento DID",
+	"Failed to get score components":    "No se pudieron obtener los componentes del puntaje",
+	"Failed to get score distribution":  "No se pudo obtener la distribución de puntajes",
+	"Failed to get score trend":         "No se pudo obtener la tendencia del puntaje",
+	"Failed to get position history":    "No se pudo obtener el historial de posiciones",
+	"Failed to apply score policy":      "No se pudo aplicar la política de puntaje",
+	"Failed to issue credential":        "No se pudo emitir la credencial",
+	"Failed to rotate credential":       "No se pudo rotar la credencial",
+	"Failed to list audit log":          "No se pudo listar el registro de auditoría",
+	"Failed to pre-screen addresses":    "No se pudieron preevaluar las direcciones",
+	"Failed to preview credit score":    "No se pudo previsualizar el puntaje crediticio",
+	"Failed to read shared score":       "No se pudo leer el puntaje compartido",
+	"Failed to rebuild score state":     "No se pudo reconstruir el estado del puntaje",
+	"Failed to refresh statistics":      "No se pudieron actualizar las estadísticas",
+	"Failed to retrieve credit score":   "No se pudo obtener el puntaje crediticio",
+	"Failed to retrieve pipeline SLO":   "No se pudo obtener el SLO del pipeline",
+	"Failed to retrieve score events":   "No se pudieron obtener los eventos del puntaje",
+	"Failed to retrieve score history":  "No se pudo obtener el historial del puntaje",
+	"Failed to retrieve statistics":     "No se pudieron obtener las estadísticas",
+	"Failed to revoke credential":       "No se pudo revocar la credencial",
+	"Failed to revoke share link":       "No se pudo revocar el enlace para compartir",
+	"Failed to sign response":           "No se pudo firmar la respuesta",
+	"Failed to update credit score":     "No se pudo actualizar el puntaje crediticio",
+	"Failed to update data freeze":      "No se pudo actualizar el congelamiento",
+	"Invalid address":                   "Dirección no válida",
+	"Invalid admin signature":           "Firma de administrador no válida",
+	"Invalid API key":                   "Clave de API no válida",
+	"Invalid credential ID":             "ID de credencial no válido",
+	"Invalid limit":                     "Límite no válido",
+	"Invalid request":                   "Solicitud no válida",
+	"Invalid share link ID":             "ID de enlace para compartir no válido",
+	"Invalid signature":                 "Firma no válida",
+	"Invalid status list":               "Lista de estado no válida",
+	"Not found":                         "No encontrado",
+	"Privacy budget exhausted":          "Presupuesto de privacidad agotado",
+	"Profile frozen":                    "Perfil congelado",
+	"Request timed out":                 "La solicitud superó el tiempo de espera",
+	"Service under maintenance":         "Servicio en mantenimiento",
+	"Share link expired":                "Enlace para compartir vencido",
+	"Share link not found":              "Enlace para compartir no encontrado",
+	"Signing keys not configured":       "Claves de firma no configuradas",
+	"Too many updates":                  "Demasiadas actualizaciones",
+	"Unknown provider environment":      "Entorno de proveedores desconocido",
+	"Unsupported content encoding":      "Codificación de contenido no admitida",
+
+	// Error messages
+	"A research partner API key is required":                                "Se requiere la clave de API de un socio de investigación",
+	"Admin endpoints need one of the admin keys in X-Admin-Key":             "Los endpoints de administración requieren una de las claves de administrador en X-Admin-Key",
+	"Destructive admin requests are disabled without operator keys":         "Las solicitudes destructivas de administrador están deshabilitadas sin claves de operador",
+	"Destructive admin requests must be signed by an operator key":          "Las solicitudes destructivas de administrador deben firmarse con la clave de un operador",
+	"No credit score exists for this address":                               "No existe un puntaje crediticio para esta dirección",
+	"No credit score found for this address":                                "No se encontró un puntaje crediticio para esta dirección",
+	"No score events recorded for this address":                             "No hay eventos de puntaje registrados para esta dirección",
+	"No schema found for this channel, type and version":                    "No se encontró un esquema para este canal, tipo y versión",
+	"No scoring data found for this address":                                "No se encontraron datos de puntaje para esta dirección",
+	"Only admin keys may select a provider environment":                     "Solo las claves de administrador pueden seleccionar un entorno de proveedores",
+	"Only admin keys may rotate provider credentials":                       "Solo las claves de administrador pueden rotar las credenciales de los proveedores",
+	"Request bodies may be gzip or deflate encoded":                         "El cuerpo de la solicitud puede estar codificado con gzip o deflate",
+	"The API is read-only during maintenance":                               "La API es de solo lectura durante el mantenimiento",
+	"The request took too long and was cancelled":                           "La solicitud tardó demasiado y fue cancelada",
+	"address query parameter is required":                                   "el parámetro de consulta address es obligatorio",
+	"after must be a sequence number":                                       "after debe ser un número de secuencia",
+	"format must be json or csv":                                            "format debe ser json o csv",
+	"limit must be between 1 and 1000":                                      "limit debe estar entre 1 y 1000",
+	"list must be a positive integer":                                       "list debe ser un entero positivo",
+	"too many addresses in one request":                                     "demasiadas direcciones en una solicitud",
+	"unknown research query":                                                "consulta de investigación desconocida",
+	"credential issuance not configured":                                    "la emisión de credenciales no está configurada",
+	"daily privacy budget exhausted":                                        "el presupuesto diario de privacidad está agotado",
+	"credential not found":                                                  "credencial no encontrada",
+	"invalid borrower identifier":                                           "identificador de prestatario no válido",
+	"invalid share link expiry":                                             "vencimiento del enlace para compartir no válido",
+	"invalid wallet signature":                                              "firma de billetera no válida",
+	"no score found":                                                        "no se encontró un puntaje",
+	"profile is frozen":                                                     "el perfil está congelado",
+	"public score distribution is disabled":                                 "la distribución pública de puntajes está desactivada",
+	"research export is not configured":                                     "la exportación para investigación no está configurada",
+	"score is provisional: not enough data to publish":                      "el puntaje es provisional: no hay suficientes datos para publicarlo",
+	"share link expired or revoked":                                         "el enlace para compartir venció o fue revocado",
+	"score was updated too recently":                                        "el puntaje se actualizó hace muy poco",
+	"share link not found":                                                  "enlace para compartir no encontrado",
+	"signed request timestamp is stale or already used":                     "la marca de tiempo de la solicitud firmada es antigua o ya fue usada",
+	"publish cost estimation unavailable: blockchain client not configured": "estimación del costo de publicación no disponible: cliente de blockchain no configurado",
+
+	// Score adjustment reasons
+	"Collateral discounted: recent large deposits or a history of briefly parked funds":             "Garantía descontada: depósitos grandes recientes o historial de fondos depositados por poco tiempo",
+	"Balance stability from monthly historical balances":                                            "Estabilidad del saldo según los saldos mensuales históricos",
+	"DeFi transactions excluded: deposits withdrawn, borrows repaid or swaps reversed within a day": "Transacciones DeFi excluidas: depósitos retirados, préstamos pagados o intercambios revertidos en menos de un día",
+	"Wallet funded mostly through mixers":                                                           "Billetera financiada principalmente a través de mezcladores",
+	"Transfers with addresses labelled as scams":                                                    "Transferencias con direcciones etiquetadas como estafas",
+	"Withdrawals from known exchanges (months active)":                                              "Retiros desde exchanges conocidos (meses activos)",
+	"Funds bridged from other chains; wallet history there counts towards its age and activity":     "Fondos transferidos por puente desde otras cadenas; el historial de la billetera allí cuenta para su antigüedad y actividad",
+	"Bank balance discounted: recent large deposits":                                                "Saldo bancario descontado: depósitos grandes recientes",
+	"Income estimated from recurring stablecoin payroll":                                            "Ingresos estimados a partir de nóminas recurrentes en stablecoins",
+	"Employment tenure verified by payroll provider (months)":                                       "Antigüedad laboral verificada por el proveedor de nómina (meses)",
+	"Confidence reduced: data providers have repeatedly disagreed about this borrower":              "Confianza reducida: los proveedores de datos han discrepado repetidamente sobre este prestatario",
+	"No on-chain data: the score is weighted towards the other data and its confidence reduced":     "Sin datos on-chain: el puntaje se basa más en los demás datos y su confianza se reduce",
+	"No off-chain data: the score is weighted towards the other data and its confidence reduced":    "Sin datos off-chain: el puntaje se basa más en los demás datos y su confianza se reduce",
+
+	// Improvement recommendations
+	"Keep funds in your wallet for longer before applying for a loan":                  "Mantén los fondos en tu billetera por más tiempo antes de solicitar un préstamo",
+	"Keep a steady balance from month to month":                                        "Mantén un saldo estable de un mes a otro",
+	"Only lending and trading with lasting positions counts towards your DeFi history": "Solo los préstamos y las operaciones con posiciones duraderas cuentan para tu historial DeFi",
+	"Fund your wallet from exchanges or other traceable sources":                       "Financia tu billetera desde exchanges u otras fuentes rastreables",
+	"Avoid sending funds to or receiving funds from flagged addresses":                 "Evita enviar o recibir fondos de direcciones señaladas",
+	"Let recent large deposits settle before applying for a loan":                      "Deja que los depósitos grandes recientes se asienten antes de solicitar un préstamo",
+	"Connect a bank account or payroll provider to verify your income":                 "Conecta una cuenta bancaria o un proveedor de nómina para verificar tus ingresos",
+	"Make sure your providers report matching income and employment":                   "Asegúrate de que tus proveedores reporten los mismos ingresos y empleo",
+	"Score a wallet with on-chain history":                                             "Calcula el puntaje de una billetera con historial on-chain",
+	"Link your credit bureau file or connect a bank account":                           "Vincula tu expediente del buró de crédito o conecta una cuenta bancaria",
+
+	// Next steps to a final score
+	"Build a longer on-chain history with this wallet": "Construye un historial on-chain más largo con esta billetera",
+	"Link your credit bureau file":                     "Vincula tu expediente del buró de crédito",
+}
@@ -0,0 +1,94 @@
+// Package i18n localizes user-facing strings: error messages, score adjustment
+// reasons and improvement recommendations. Messages are keyed by their English text,
+// so untranslated strings fall back to English.
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Supported languages
+const (
+	English = "en"
+	Spanish = "es"
+
+	DefaultLanguage = English
+)
+
+// catalogs maps a language to its translations of English messages
+var catalogs = map[string]map[string]string{
+	Spanish: spanish,
+}
+
+// Supported lists the languages messages can be translated into
+func Supported() []string {
+	languages := []string{English}
+	for language := range catalogs {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages[1:])
+	return languages
+}
+
+// Negotiate picks the supported language that best matches an Accept-Language
+// header, falling back to DefaultLanguage. Region subtags are ignored, so "es-MX"
+// selects Spanish.
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		language string
+		quality  float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+			if err != nil || q < 0 || q > 1 {
+				q = 0
+			}
+			quality = q
+		}
+		if quality == 0 {
+			continue
+		}
+
+		language, _, _ := strings.Cut(tag, "-")
+		candidates = append(candidates, candidate{language: language, quality: quality})
+	}
+
+	// Stable, so equally weighted languages keep the client's order
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	for _, c := range candidates {
+		if c.language == "*" {
+			return DefaultLanguage
+		}
+		if c.language == English || catalogs[c.language] != nil {
+			return c.language
+		}
+	}
+	return DefaultLanguage
+}
+
+// T translates an English message into language. Messages without a translation
+// are returned unchanged.
+func T(language, message string) string {
+	if translated, ok := catalogs[language][message]; ok {
+		return translated
+	}
+	return message
+}
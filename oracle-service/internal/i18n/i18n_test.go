@@ -0,0 +1,51 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", English},
+		{"es", Spanish},
+		{"es-MX,es;q=0.9,en;q=0.8", Spanish},
+		{"en-US,en;q=0.9,es;q=0.8", English},
+		{"fr-FR,es;q=0.5", Spanish},
+		{"fr-FR,de;q=0.9", English},
+		{"en;q=0.4, ES;q=0.7", Spanish},
+		{"es;q=0,en", English},
+		{"*", English},
+		{"es;q=abc", English},
+	}
+
+	for _, tt := range tests {
+		if got := Negotiate(tt.header); got != tt.want {
+			t.Errorf("Negotiate(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	if got := T(Spanish, "Invalid request"); got != "Solicitud no válida" {
+		t.Errorf("expected Spanish translation, got %q", got)
+	}
+	if got := T(English, "Invalid request"); got != "Invalid request" {
+		t.Errorf("expected English message unchanged, got %q", got)
+	}
+	if got := T(Spanish, "untranslated message"); got != "untranslated message" {
+		t.Errorf("expected untranslated message to fall back to English, got %q", got)
+	}
+	if got := T("fr", "Invalid request"); got != "Invalid request" {
+		t.Errorf("expected unsupported language to fall back to English, got %q", got)
+	}
+}
+
+func TestSupported(t *testing.T) {
+	if got := Supported(); !reflect.DeepEqual(got, []string{English, Spanish}) {
+		t.Errorf("Supported() = %v", got)
+	}
+}
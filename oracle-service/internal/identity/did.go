@@ -0,0 +1,79 @@
+// Package identity resolves the decentralized identifiers (DIDs) borrowers may use in
+// place of wallet addresses
+package identity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+)
+
+// ErrInvalidIdentifier is returned for identifiers that are neither a wallet address
+// nor a supported DID
+var ErrInvalidIdentifier = errors.Validation("invalid borrower identifier")
+
+// IsDID reports whether id is a DID rather than a raw address
+func IsDID(id string) bool {
+	return strings.HasPrefix(id, "did:")
+}
+
+// ResolveAddress returns the wallet address an identifier refers to. Raw addresses
+// are returned unchanged. Supported DIDs are:
+//
+//	did:pkh:eip155:<chain id>:<address>
+//	did:ethr:<address>, did:ethr:<network>:<address>
+//	did:ethr:<compressed public key>, did:ethr:<network>:<compressed public key>
+//
+// The address is returned as written in the DID.
+func ResolveAddress(id string) (string, error) {
+	if !IsDID(id) {
+		return id, nil
+	}
+
+	parts := strings.Split(id, ":")
+	switch parts[1] {
+	case "pkh":
+		// did:pkh:eip155:<chain id>:<address>
+		if len(parts) != 5 || parts[2] != "eip155" || parts[3] == "" {
+			return "", fmt.Errorf("%w: %s is not an eip155 did:pkh", ErrInvalidIdentifier, id)
+		}
+		return hexAddress(id, parts[4])
+
+	case "ethr":
+		// did:ethr:[<network>:]<address or public key>
+		if len(parts) != 3 && len(parts) != 4 {
+			return "", fmt.Errorf("%w: malformed did:ethr %s", ErrInvalidIdentifier, id)
+		}
+		key := parts[len(parts)-1]
+		if len(key) == 2+2*33 {
+			return publicKeyAddress(id, key)
+		}
+		return hexAddress(id, key)
+
+	default:
+		return "", fmt.Errorf("%w: unsupported DID method in %s", ErrInvalidIdentifier, id)
+	}
+}
+
+func hexAddress(id, address string) (string, error) {
+	if !strings.HasPrefix(address, "0x") || !common.IsHexAddress(address) {
+		return "", fmt.Errorf("%w: %s does not contain a wallet address", ErrInvalidIdentifier, id)
+	}
+	return address, nil
+}
+
+func publicKeyAddress(id, key string) (string, error) {
+	compressed, err := hexutil.Decode(key)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s has a malformed public key", ErrInvalidIdentifier, id)
+	}
+	pub, err := crypto.DecompressPubkey(compressed)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s has an invalid public key", ErrInvalidIdentifier, id)
+	}
+	return crypto.PubkeyToAddress(*pub).Hex(), nil
+}
@@ -0,0 +1,56 @@
+package identity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestResolveAddress(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	keyAddress := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	publicKey := hexutil.Encode(crypto.CompressPubkey(&key.PublicKey))
+
+	const address = "0x1234567890123456789012345678901234567890"
+	tests := []struct {
+		name     string
+		id       string
+		expected string
+		invalid  bool
+	}{
+		{"raw address", address, address, false},
+		{"did:pkh mainnet", "did:pkh:eip155:1:" + address, address, false},
+		{"did:pkh polygon", "did:pkh:eip155:137:" + address, address, false},
+		{"did:ethr", "did:ethr:" + address, address, false},
+		{"did:ethr with network", "did:ethr:0x5:" + address, address, false},
+		{"did:ethr public key", "did:ethr:" + publicKey, keyAddress, false},
+		{"did:ethr public key with network", "did:ethr:sepolia:" + publicKey, keyAddress, false},
+		{"did:pkh non-EVM", "did:pkh:solana:4sGjMW1sUnHzSxGspuhpqLDx6wiyjNtZ:" + address, "", true},
+		{"did:pkh missing chain", "did:pkh:eip155:" + address, "", true},
+		{"did:ethr bad address", "did:ethr:0x1234", "", true},
+		{"unsupported method", "did:web:example.com", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := ResolveAddress(tt.id)
+			if tt.invalid {
+				if !errors.Is(err, ErrInvalidIdentifier) {
+					t.Errorf("Expected ErrInvalidIdentifier, got %v (%s)", err, resolved)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to resolve %s: %v", tt.id, err)
+			}
+			if resolved != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, resolved)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package labels
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+// ErrInvalidLabel is returned when a label fails validation
+var ErrInvalidLabel = errors.Validation("invalid address label")
+
+var addressPattern = regexp.MustCompile(`^0x[0-9a-f]{40}$`)
+
+var validCategories = map[string]bool{
+	models.LabelCategoryExchange: true,
+	models.LabelCategoryMixer:    true,
+	models.LabelCategoryBridge:   true,
+	models.LabelCategoryScam:     true,
+	models.LabelCategoryPayroll:  true,
+	models.LabelCategoryProtocol: true,
+}
+
+// Registry is an in-memory, concurrency-safe index of address labels used by the aggregators
+type Registry struct {
+	mu     sync.RWMutex
+	labels map[string]models.AddressLabel
+}
+
+// NewRegistry creates a registry pre-loaded with the given labels
+func NewRegistry(initial []models.AddressLabel) *Registry {
+	r := &Registry{
+		labels: make(map[string]models.AddressLabel, len(initial)),
+	}
+	r.Replace(initial)
+	return r
+}
+
+// NormalizeAddress lowercases and trims an address for lookup
+func NormalizeAddress(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
+
+// IsValidCategory reports whether category is a known label category
+func IsValidCategory(category string) bool {
+	return validCategories[category]
+}
+
+// Validate normalizes a label in place and checks its address and category
+func Validate(label *models.AddressLabel) error {
+	label.Address = NormalizeAddress(label.Address)
+	label.Category = strings.ToLower(strings.TrimSpace(label.Category))
+	label.Name = strings.TrimSpace(label.Name)
+	label.SourceChain = strings.ToLower(strings.TrimSpace(label.SourceChain))
+
+	if !addressPattern.MatchString(label.Address) {
+		return fmt.Errorf("%w: invalid address %q", ErrInvalidLabel, label.Address)
+	}
+	if !IsValidCategory(label.Category) {
+		return fmt.Errorf("%w: unknown category %q", ErrInvalidLabel, label.Category)
+	}
+	if label.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidLabel)
+	}
+	if label.SourceChain != "" && label.Category != models.LabelCategoryBridge {
+		return fmt.Errorf("%w: only bridges have a source chain", ErrInvalidLabel)
+	}
+	return nil
+}
+
+// Replace swaps the registry contents for the given labels
+func (r *Registry) Replace(labels []models.AddressLabel) {
+	index := make(map[string]models.AddressLabel, len(labels))
+	for _, label := range labels {
+		label.Address = NormalizeAddress(label.Address)
+		if label.Address != "" {
+			index[label.Address] = label
+		}
+	}
+
+	r.mu.Lock()
+	r.labels = index
+	r.mu.Unlock()
+}
+
+// Set adds or replaces a single label
+func (r *Registry) Set(label models.AddressLabel) {
+	label.Address = NormalizeAddress(label.Address)
+	if label.Address == "" {
+		return
+	}
+
+	r.mu.Lock()
+	r.labels[label.Address] = label
+	r.mu.Unlock()
+}
+
+// Remove deletes the label for an address
+func (r *Registry) Remove(address string) {
+	r.mu.Lock()
+	delete(r.labels, NormalizeAddress(address))
+	r.mu.Unlock()
+}
+
+// Lookup returns the label for an address, if known
+func (r *Registry) Lookup(address string) (models.AddressLabel, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	label, ok := r.labels[NormalizeAddress(address)]
+	return label, ok
+}
+
+// Len returns the number of labelled addresses
+func (r *Registry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.labels)
+}
+
+// LoadFile reads extra labels from a JSON array file
+func LoadFile(path string) ([]models.AddressLabel, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read address labels: %w", err)
+	}
+
+	var labels []models.AddressLabel
+	if err := json.Unmarshal(data, &labels); err != nil {
+		return nil, fmt.Errorf("failed to parse address labels: %w", err)
+	}
+
+	for i := range labels {
+		if err := Validate(&labels[i]); err != nil {
+			return nil, fmt.Errorf("label %d: %w", i, err)
+		}
+		labels[i].Source = models.LabelSourceFile
+	}
+
+	return labels, nil
+}
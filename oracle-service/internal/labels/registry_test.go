@@ -0,0 +1,57 @@
+package labels
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+func TestRegistryLookup(t *testing.T) {
+	registry := NewRegistry(DefaultLabels())
+
+	label, ok := registry.Lookup("0x28C6c06298d514Db089934071355E5743bf21d60")
+	if !ok || label.Category != models.LabelCategoryExchange {
+		t.Errorf("Expected case-insensitive lookup of built-in exchange label, got %+v (ok=%v)", label, ok)
+	}
+
+	registry.Set(models.AddressLabel{
+		Address:  "0x28c6c06298d514db089934071355e5743bf21d60",
+		Name:     "Relabelled",
+		Category: models.LabelCategoryScam,
+	})
+	if label, _ := registry.Lookup("0x28c6c06298d514db089934071355e5743bf21d60"); label.Category != models.LabelCategoryScam {
+		t.Errorf("Expected Set to override the seed label, got %s", label.Category)
+	}
+
+	registry.Remove("0x28c6c06298d514db089934071355e5743bf21d60")
+	if _, ok := registry.Lookup("0x28c6c06298d514db089934071355e5743bf21d60"); ok {
+		t.Error("Expected label to be removed")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := models.AddressLabel{
+		Address:  " 0xABCDEF0000000000000000000000000000000001 ",
+		Name:     "Payroll Co",
+		Category: "Payroll",
+	}
+	if err := Validate(&valid); err != nil {
+		t.Fatalf("Expected label to be valid, got %v", err)
+	}
+	if valid.Address != "0xabcdef0000000000000000000000000000000001" || valid.Category != models.LabelCategoryPayroll {
+		t.Errorf("Expected address and category to be normalized, got %+v", valid)
+	}
+
+	invalid := []models.AddressLabel{
+		{Address: "0x1234", Name: "Short", Category: models.LabelCategoryScam},
+		{Address: "0xabcdef0000000000000000000000000000000001", Name: "Unknown", Category: "casino"},
+		{Address: "0xabcdef0000000000000000000000000000000001", Name: " ", Category: models.LabelCategoryScam},
+		{Address: "0xabcdef0000000000000000000000000000000001", Name: "Hot Wallet", Category: models.LabelCategoryExchange, SourceChain: "base"},
+	}
+	for _, label := range invalid {
+		if err := Validate(&label); !errors.Is(err, ErrInvalidLabel) {
+			t.Errorf("Expected ErrInvalidLabel for %+v, got %v", label, err)
+		}
+	}
+}
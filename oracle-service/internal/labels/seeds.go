@@ -0,0 +1,86 @@
+package labels
+
+import (
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+// seedLabels are taken from public explorer label lists. Payroll and scam labels are
+// environment-specific and are managed through the admin API.
+var seedLabels = []struct {
+	address  string
+	name     string
+	category string
+}{
+	// Centralized exchange hot wallets
+	{"0x28c6c06298d514db089934071355e5743bf21d60", "Binance 14", models.LabelCategoryExchange},
+	{"0x21a31ee1afc51d94c2efccaa2092ad1028285549", "Binance 15", models.LabelCategoryExchange},
+	{"0xdfd5293d8e347dfe59e90efd55b2956a1343963d", "Binance 16", models.LabelCategoryExchange},
+	{"0x71660c4005ba85c37ccec55d0c4493e66fe775d3", "Coinbase 1", models.LabelCategoryExchange},
+	{"0x503828976d22510aad0201ac7ec88293211d23da", "Coinbase 2", models.LabelCategoryExchange},
+	{"0xa9d1e08c7793af67e9d92fe308d5697fb81d3e43", "Coinbase 10", models.LabelCategoryExchange},
+	{"0x2910543af39aba0cd09dbb2d50200b3e800a63d2", "Kraken 1", models.LabelCategoryExchange},
+	{"0x267be1c1d684f78cb4f6a176c4911b741e4ffdc0", "Kraken 4", models.LabelCategoryExchange},
+	{"0x6cc5f688a315f3dc28a7781717a9a798a59fda7b", "OKX", models.LabelCategoryExchange},
+	{"0xd24400ae8bfebb18ca49be86258a3c749cf46853", "Gemini 1", models.LabelCategoryExchange},
+
+	// Mixers
+	{"0x12d66f87a04a9e220743712ce6d9bb1b5616b8fc", "Tornado Cash 0.1 ETH", models.LabelCategoryMixer},
+	{"0x47ce0c6ed5b0ce3d3a51fdb1c52dc66a7c3c2936", "Tornado Cash 1 ETH", models.LabelCategoryMixer},
+	{"0x910cbd523d972eb0a6f4cae4618ad62622b39dbf", "Tornado Cash 10 ETH", models.LabelCategoryMixer},
+	{"0xa160cdab225685da1d56aa342ad8841c3b53f291", "Tornado Cash 100 ETH", models.LabelCategoryMixer},
+	{"0xd90e2f925da726b50c4ed8d0fb90ad053324f31b", "Tornado Cash Router", models.LabelCategoryMixer},
+
+	// Bridges
+	{"0x8315177ab297ba92a06054ce80a67ed4dbd7ed3a", "Arbitrum Bridge", models.LabelCategoryBridge},
+	{"0x99c9fc46f92e8a1c0dec1b1747d010903e884be1", "Optimism Gateway", models.LabelCategoryBridge},
+	{"0x40ec5b33f54e0e8a33a975908c5ba1c14e5bbbdf", "Polygon ERC20 Bridge", models.LabelCategoryBridge},
+	{"0x3154cf16ccdb4c6d922629664174b904d80f2c35", "Base Bridge", models.LabelCategoryBridge},
+
+	// Lending and DEX protocols
+	{"0x87870bca3f3fd6335c3f4ce8392d69350b4fa4e2", "Aave V3 Pool", models.LabelCategoryProtocol},
+	{"0x7d2768de32b0b80b7a3454c06bdac94a69ddc7a9", "Aave V2 Lending Pool", models.LabelCategoryProtocol},
+	{"0xc3d688b66703497daa19211eedff47f25384cdc3", "Compound V3 USDC", models.LabelCategoryProtocol},
+	{"0xbbbbbbbbbb9cc5e90e3b3af64bdaf62c37eeffcb", "Morpho Blue", models.LabelCategoryProtocol},
+	{"0x7a250d5630b4cf539739df2c5dacb4c659f2488d", "Uniswap V2 Router", models.LabelCategoryProtocol},
+	{"0xe592427a0aece92de3edee1f18e0157c05861564", "Uniswap V3 Router", models.LabelCategoryProtocol},
+}
+
+// seedBridgeChains are the chains the seed bridges release funds from. The bridge
+// contracts are on Ethereum, so funds arriving through them were withdrawn from the L2.
+var seedBridgeChains = map[string]string{
+	"0x8315177ab297ba92a06054ce80a67ed4dbd7ed3a": "arbitrum",
+	"0x99c9fc46f92e8a1c0dec1b1747d010903e884be1": "optimism",
+	"0x40ec5b33f54e0e8a33a975908c5ba1c14e5bbbdf": "polygon",
+	"0x3154cf16ccdb4c6d922629664174b904d80f2c35": "base",
+}
+
+// DefaultLabels returns the built-in seed labels
+func DefaultLabels() []models.AddressLabel {
+	labels := make([]models.AddressLabel, 0, len(seedLabels))
+	for _, seed := range seedLabels {
+		labels = append(labels, models.AddressLabel{
+			Address:     seed.address,
+			Name:        seed.name,
+			Category:    seed.category,
+			Source:      models.LabelSourceSeed,
+			SourceChain: seedBridgeChains[seed.address],
+		})
+	}
+	return labels
+}
+
+// SourceChain returns the chain funds arriving through a bridge were sent from, or ""
+// if the label isn't a bridge or the chain isn't known. Seed bridges stored before
+// labels had a source chain fall back to their seed's chain.
+func SourceChain(label models.AddressLabel) string {
+	if label.Category != models.LabelCategoryBridge {
+		return ""
+	}
+	if label.SourceChain != "" {
+		return label.SourceChain
+	}
+	if label.Source != models.LabelSourceSeed {
+		return ""
+	}
+	return seedBridgeChains[label.Address]
+}
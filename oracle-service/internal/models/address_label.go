@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// Address label categories
+const (
+	LabelCategoryExchange = "exchange"
+	LabelCategoryMixer    = "mixer"
+	LabelCategoryBridge   = "bridge"
+	LabelCategoryScam     = "scam"
+	LabelCategoryPayroll  = "payroll"
+	LabelCategoryProtocol = "protocol"
+)
+
+// Address label sources
+const (
+	LabelSourceSeed  = "seed"  // Built-in public lists
+	LabelSourceFile  = "file"  // ADDRESS_LABELS_FILE
+	LabelSourceAdmin = "admin" // Created or edited through the admin API
+)
+
+// AddressLabel maps a counterparty address to a known category
+type AddressLabel struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Address     string    `gorm:"uniqueIndex;not null" json:"address"` // Lowercase hex
+	Name        string    `gorm:"not null" json:"name"`
+	Category    string    `gorm:"index;not null" json:"category"`
+	Source      string    `gorm:"not null" json:"source"`
+	SourceChain string    `json:"source_chain,omitempty"` // Bridges only: the chain funds arriving through the bridge were sent from
+	Notes       string    `json:"notes"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
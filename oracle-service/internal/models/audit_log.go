@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// Audit log actions
+const (
+	AuditFreeze                     = "profile.frozen"
+	AuditFreezeLifted               = "profile.freeze_lifted"
+	AuditShareCreated               = "share.created"
+	AuditShareRevoked               = "share.revoked"
+	AuditShareAccessed              = "share.accessed"
+	AuditShareDenied                = "share.denied" // Expired or revoked token presented
+	AuditCredentialIssued           = "credential.issued"
+	AuditCredentialRevoked          = "credential.revoked"
+	AuditProviderCredentialRotated  = "provider.credential_rotated"
+	AuditProviderCredentialRejected = "provider.credential_rejected" // Failed validation; the old credential stays in use
+	AuditResearchExported           = "research.exported"            // Differentially private dataset released to a partner
+	AuditRunbookRequeuePublishes    = "runbook.requeue_failed_publishes"
+	AuditRunbookRefreshScores       = "runbook.refresh_outage_scores"
+	AuditRunbookRecomputeStats      = "runbook.recompute_stats"
+)
+
+// AuditLog records an access to or change of a user's data. Entries are append-only.
+type AuditLog struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Action      string    `gorm:"index;not null" json:"action"`
+	Actor       string    `gorm:"not null" json:"actor"` // Wallet address, share:<id> for share link holders, research:<partner>, or admin[:<operator>]
+	UserAddress string    `gorm:"index" json:"user_address"`
+	Detail      string    `json:"detail,omitempty"`
+	RemoteAddr  string    `json:"remote_addr,omitempty"`
+	UserAgent   string    `json:"user_agent,omitempty"`
+	CreatedAt   time.Time `gorm:"index" json:"created_at"`
+}
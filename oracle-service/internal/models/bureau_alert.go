@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+// Bureau alert types, normalized from each bureau's monitoring vocabulary
+const (
+	BureauAlertDelinquency  = "new_delinquency"
+	BureauAlertBankruptcy   = "bankruptcy"
+	BureauAlertCollection   = "collection"
+	BureauAlertPublicRecord = "public_record"
+	BureauAlertInquiry      = "inquiry"
+	BureauAlertNewAccount   = "new_account"
+	BureauAlertOther        = "other"
+)
+
+// Bureau alert processing statuses
+const (
+	BureauAlertProcessed = "processed" // Affected users were refreshed and rescored
+	BureauAlertUnmatched = "unmatched" // No user is linked to the alert's consumer
+	BureauAlertFailed    = "failed"
+)
+
+// BureauLink connects a bureau consumer to a wallet address so that monitoring
+// alerts can be routed to the right score. Only a hash of the consumer ID is stored.
+type BureauLink struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	Provider     string    `gorm:"uniqueIndex:idx_bureau_link;not null" json:"provider"`
+	ConsumerHash string    `gorm:"uniqueIndex:idx_bureau_link;not null" json:"-"`
+	UserAddress  string    `gorm:"uniqueIndex:idx_bureau_link;index;not null" json:"user_address"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// BureauAlert records a received bureau monitoring alert; AlertID makes webhook
+// deliveries idempotent
+type BureauAlert struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	AlertID          string    `gorm:"uniqueIndex;not null" json:"alert_id"`
+	Provider         string    `gorm:"not null" json:"provider"`
+	AlertType        string    `gorm:"index;not null" json:"alert_type"`
+	RawType          string    `json:"raw_type"` // Alert type as sent by the bureau
+	ConsumerHash     string    `gorm:"index;not null" json:"-"`
+	AffectedAccounts int       `json:"affected_accounts"`
+	Status           string    `gorm:"not null" json:"status"`
+	ErrorMessage     string    `json:"error_message"`
+	OccurredAt       time.Time `json:"occurred_at"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// ChainIndexCheckpoint is how far the block ingester has indexed a chain. Blocks are
+// indexed in batches saved together with the checkpoint, so ingestion resumes after
+// the last saved batch.
+type ChainIndexCheckpoint struct {
+	ChainID   string    `gorm:"primaryKey" json:"chain_id"`
+	LastBlock uint64    `gorm:"not null" json:"last_block"`
+	BlockTime time.Time `json:"block_time"` // Timestamp of LastBlock
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ChainIndexAddress is the activity of an address seen by the block ingester, as a
+// sender or recipient of transactions and token transfers
+type ChainIndexAddress struct {
+	ID             uint          `gorm:"primaryKey" json:"id"`
+	ChainID        string        `gorm:"uniqueIndex:idx_chain_index_address;not null" json:"chain_id"`
+	Address        string        `gorm:"uniqueIndex:idx_chain_index_address;not null" json:"address"`
+	FirstBlock     uint64        `json:"first_block"`
+	FirstSeen      time.Time     `json:"first_seen"`
+	LastSeen       time.Time     `json:"last_seen"`
+	Sent           uint32        `json:"sent"`     // Transactions sent
+	Received       uint32        `json:"received"` // Transactions received
+	Failed         uint32        `json:"failed"`   // Sent transactions that reverted
+	TokenTransfers uint32        `json:"token_transfers"`
+	ValueSent      units.Decimal `json:"value_sent"`     // Native coin, in whole units
+	ValueReceived  units.Decimal `json:"value_received"` // Native coin, in whole units
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+// ChainIndexTransaction is a transaction indexed by the block ingester
+type ChainIndexTransaction struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	ChainID     string    `gorm:"uniqueIndex:idx_chain_index_tx;not null" json:"chain_id"`
+	Hash        string    `gorm:"uniqueIndex:idx_chain_index_tx;not null" json:"hash"`
+	BlockNumber uint64    `gorm:"not null" json:"block_number"`
+	Timestamp   time.Time `gorm:"not null" json:"timestamp"`
+	From        string    `gorm:"column:from_address;index;not null" json:"from"`
+	To          string    `gorm:"column:to_address;index" json:"to"` // Empty for contract creations
+	Value       string    `json:"value"`                             // Wei
+	Failed      bool      `json:"failed"`
+	Input       string    `json:"input"` // Selector and leading arguments of the calldata
+}
+
+// ChainIndexTokenTransfer is an ERC-20 Transfer log indexed by the block ingester
+type ChainIndexTokenTransfer struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	ChainID     string    `gorm:"uniqueIndex:idx_chain_index_transfer;not null" json:"chain_id"`
+	TxHash      string    `gorm:"uniqueIndex:idx_chain_index_transfer;not null" json:"tx_hash"`
+	LogIndex    uint      `gorm:"uniqueIndex:idx_chain_index_transfer;not null" json:"log_index"`
+	BlockNumber uint64    `gorm:"not null" json:"block_number"`
+	Timestamp   time.Time `gorm:"not null" json:"timestamp"`
+	Token       string    `gorm:"not null" json:"token"`
+	From        string    `gorm:"column:from_address;index;not null" json:"from"`
+	To          string    `gorm:"column:to_address;index;not null" json:"to"`
+	Value       string    `json:"value"` // Base units of the token
+}
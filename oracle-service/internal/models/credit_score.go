@@ -2,83 +2,164 @@ package models
 
 import (
 	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
 )
 
 // CreditScore represents a user's credit score data
 type CreditScore struct {
-	ID              uint      `gorm:"primaryKey" json:"id"`
-	UserAddress     string    `gorm:"uniqueIndex;not null" json:"user_address"`
-	Score           uint16    `gorm:"not null" json:"score"`           // 300-850 range
-	Confidence      uint8     `gorm:"not null" json:"confidence"`      // 0-100
-	OnChainScore    uint16    `json:"on_chain_score"`                  // Component scores
-	OffChainScore   uint16    `json:"off_chain_score"`
-	HybridScore     uint16    `json:"hybrid_score"`
-	DataHash        string    `gorm:"not null" json:"data_hash"`       // Hash of source data
-	LastUpdated     time.Time `gorm:"not null" json:"last_updated"`
-	NextUpdateDue   time.Time `json:"next_update_due"`
-	UpdateCount     uint32    `json:"update_count"`
-	IsActive        bool      `gorm:"default:true" json:"is_active"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID            uint             `gorm:"primaryKey" json:"id"`
+	UserAddress   string           `gorm:"uniqueIndex;not null" json:"user_address"`
+	Score         units.Score      `gorm:"not null" json:"score"`      // 300-850 range
+	Confidence    units.Confidence `gorm:"not null" json:"confidence"` // 0-100
+	OnChainScore  units.Score      `json:"on_chain_score"`             // Component scores
+	OffChainScore units.Score      `json:"off_chain_score"`
+	HybridScore   units.Score      `json:"hybrid_score"`
+	DataHash      string           `gorm:"not null" json:"data_hash"` // Hash of source data
+	LastUpdated   time.Time        `gorm:"not null" json:"last_updated"`
+	NextUpdateDue time.Time        `json:"next_update_due"`
+	UpdateCount   uint32           `json:"update_count"`
+	IsActive      bool             `gorm:"default:true" json:"is_active"`
+	Provisional   bool             `gorm:"default:false" json:"provisional"`                      // Too little data for a final score; never published
+	MissingData   string           `json:"missing_data,omitempty"`                                // Comma-separated data requirements, any one of which would make the score final
+	PublishStatus string           `gorm:"default:'never_published';index" json:"publish_status"` // Whether contracts can read this score on-chain
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+
+	// Components are the factor scores of the calculation, stored separately
+	Components []ScoreComponent `gorm:"-" json:"-"`
 }
 
+// Score publish statuses
+const (
+	PublishStatusNeverPublished = "never_published" // No publication of the address has been confirmed or is in flight
+	PublishStatusPending        = "pending"         // A publication is queued or awaiting confirmation
+	PublishStatusOnChain        = "on_chain"        // The current score is confirmed on-chain and not expired
+	PublishStatusStaleOnChain   = "stale_on_chain"  // An older or expired score is on-chain
+)
+
 // ScoreHistory tracks historical credit scores
 type ScoreHistory struct {
-	ID          uint      `gorm:"primaryKey" json:"id"`
-	UserAddress string    `gorm:"index;not null" json:"user_address"`
-	Score       uint16    `gorm:"not null" json:"score"`
-	Confidence  uint8     `gorm:"not null" json:"confidence"`
-	DataHash    string    `gorm:"not null" json:"data_hash"`
-	Timestamp   time.Time `gorm:"not null;index" json:"timestamp"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID           uint             `gorm:"primaryKey" json:"id"`
+	UserAddress  string           `gorm:"index;not null" json:"user_address"`
+	Score        units.Score      `gorm:"not null" json:"score"`
+	Confidence   units.Confidence `gorm:"not null" json:"confidence"`
+	DataHash     string           `gorm:"not null" json:"data_hash"`
+	ChangeReason string           `json:"change_reason"` // Why the score was recalculated
+	Timestamp    time.Time        `gorm:"not null;index" json:"timestamp"`
+	CreatedAt    time.Time        `json:"created_at"`
 }
 
+// Reasons recorded in ScoreHistory.ChangeReason
+const (
+	ChangeReasonManual          = "manual"           // Requested through the score update API
+	ChangeReasonScheduled       = "scheduled"        // Periodic refresh of scores due for update
+	ChangeReasonProviderRefresh = "provider_refresh" // Recalculated with third-party provider data
+	ChangeReasonBureauAlert     = "bureau_alert"     // Triggered by a credit bureau monitoring alert
+	ChangeReasonPositionRisk    = "position_risk"    // A lending position became or stopped being at risk of liquidation
+	ChangeReasonLiquidation     = "liquidation"      // A lending position was liquidated
+	ChangeReasonOutageRecovery  = "outage_recovery"  // Refreshed by an operator after a provider outage
+)
+
+// Income sources recorded in OffChainMetrics.IncomeSource
+const (
+	IncomeSourcePlaid          = "plaid"
+	IncomeSourceBureau         = "credit_bureau"
+	IncomeSourceEmployment     = "employment"      // Gross pay from an employment verification provider
+	IncomeSourceOnChainPayroll = "onchain_payroll" // Derived from recurring stablecoin inflows
+)
+
 // OnChainMetrics stores on-chain activity data
 type OnChainMetrics struct {
-	ID                  uint      `gorm:"primaryKey" json:"id"`
-	UserAddress         string    `gorm:"uniqueIndex;not null" json:"user_address"`
-	WalletAge           uint32    `json:"wallet_age"`              // Days since first transaction
-	TotalTransactions   uint32    `json:"total_transactions"`
-	AvgTransactionValue float64   `json:"avg_transaction_value"`
-	DeFiInteractions    uint32    `json:"defi_interactions"`
-	BorrowingHistory    uint32    `json:"borrowing_history"`
-	RepaymentHistory    uint32    `json:"repayment_history"`
-	LiquidationEvents   uint32    `json:"liquidation_events"`
-	CollateralValue     float64   `json:"collateral_value"`
-	LastActivity        time.Time `json:"last_activity"`
-	CreatedAt           time.Time `json:"created_at"`
-	UpdatedAt           time.Time `json:"updated_at"`
+	ID                  uint          `gorm:"primaryKey" json:"id"`
+	UserAddress         string        `gorm:"uniqueIndex;not null" json:"user_address"`
+	WalletAge           uint32        `json:"wallet_age"` // Days since first transaction
+	TotalTransactions   uint32        `json:"total_transactions"`
+	AvgTransactionValue units.Decimal `json:"avg_transaction_value"`
+	DeFiInteractions    uint32        `json:"defi_interactions"`
+	WashActivities      uint32        `json:"wash_activities"` // DeFi transactions left out of scoring as self-referential loops
+	BorrowingHistory    uint32        `json:"borrowing_history"`
+	RepaymentHistory    uint32        `json:"repayment_history"` // Borrows repaid in full
+	RepaymentRatio      float64       `json:"repayment_ratio"`   // Average share of each borrow repaid (0-1)
+	AvgDaysToRepay      float64       `json:"avg_days_to_repay"`
+	OutstandingLoans    uint32        `json:"outstanding_loans"`
+	LiquidationEvents   uint32        `json:"liquidation_events"`
+	CollateralValue     units.Decimal `json:"collateral_value"`
+	CEXInflows          uint32        `json:"cex_inflows"`        // Inbound transfers from labelled exchange hot wallets
+	CEXActiveMonths     uint32        `json:"cex_active_months"`  // Distinct months with exchange withdrawals
+	MixerInflows        uint32        `json:"mixer_inflows"`      // Inbound transfers from mixer contracts
+	ScamInteractions    uint32        `json:"scam_interactions"`  // Transfers with addresses labelled as scams
+	BridgeInflows       uint32        `json:"bridge_inflows"`     // Inbound transfers through canonical bridges
+	BridgedFrom         string        `json:"bridged_from"`       // Comma-separated chains bridged funds were sent from
+	BalanceSamples      uint32        `json:"balance_samples"`    // Monthly historical balances sampled
+	BalanceStability    float64       `json:"balance_stability"`  // 0-1, how consistently the balance was held
+	TemporaryDeposits   uint32        `json:"temporary_deposits"` // Past large deposits withdrawn within a week
+	TemporaryDiscount   float64       `json:"temporary_discount"` // Share of collateral disregarded as a temporary deposit
+	TotalInflows        uint32        `json:"total_inflows"`
+	LastActivity        time.Time     `json:"last_activity"`
+	CreatedAt           time.Time     `json:"created_at"`
+	UpdatedAt           time.Time     `json:"updated_at"`
 }
 
+// MinBalanceSamples is the fewest monthly balance samples that say anything about
+// holding behavior; with fewer, OnChainMetrics.BalanceStability is zero and unscored
+const MinBalanceSamples = 3
+
 // OffChainMetrics stores off-chain/external data
 type OffChainMetrics struct {
-	ID                    uint      `gorm:"primaryKey" json:"id"`
-	UserAddress           string    `gorm:"uniqueIndex;not null" json:"user_address"`
-	TraditionalCreditScore uint16   `json:"traditional_credit_score"` // 300-850
-	BankAccountHistory    uint8     `json:"bank_account_history"`     // Score 0-100
-	IncomeVerified        bool      `json:"income_verified"`
-	IncomeLevel           string    `json:"income_level"`             // low/medium/high
-	EmploymentStatus      string    `json:"employment_status"`
-	DebtToIncomeRatio     float64   `json:"debt_to_income_ratio"`
-	DataSource            string    `json:"data_source"`
-	LastVerified          time.Time `json:"last_verified"`
-	CreatedAt             time.Time `json:"created_at"`
-	UpdatedAt             time.Time `json:"updated_at"`
+	ID                     uint             `gorm:"primaryKey" json:"id"`
+	UserAddress            string           `gorm:"uniqueIndex;not null" json:"user_address"`
+	TraditionalCreditScore units.Score      `json:"traditional_credit_score"` // 300-850
+	RawBureauScore         int              `json:"raw_bureau_score"`         // Score on the bureau's native scale
+	BureauRegion           string           `json:"bureau_region"`
+	BankAccountHistory     uint8            `json:"bank_account_history"` // Score 0-100
+	IncomeVerified         bool             `json:"income_verified"`
+	IncomeLevel            string           `json:"income_level"`  // low/medium/high
+	IncomeSource           string           `json:"income_source"` // Lineage of the income figure
+	EstimatedAnnualIncome  units.Decimal    `json:"estimated_annual_income"`
+	IncomeConfidence       units.Confidence `json:"income_confidence"` // How far the income sources corroborate the figure
+	EmploymentStatus       string           `json:"employment_status"`
+	EmploymentTenure       uint32           `json:"employment_tenure"`   // Months with current employer
+	EmploymentVerified     bool             `json:"employment_verified"` // Confirmed by a payroll provider
+	DebtToIncomeRatio      units.Decimal    `json:"debt_to_income_ratio"`
+	TemporaryDiscount      float64          `json:"temporary_discount"` // Share of bank balance disregarded as a temporary deposit
+	DataSource             string           `json:"data_source"`
+	LastVerified           time.Time        `json:"last_verified"`
+	CreatedAt              time.Time        `json:"created_at"`
+	UpdatedAt              time.Time        `json:"updated_at"`
 }
 
+// Oracle update statuses
+const (
+	OracleUpdateQueued    = "queued"  // Waiting for the publish window
+	OracleUpdatePending   = "pending" // Submitted, awaiting confirmation
+	OracleUpdateConfirmed = "confirmed"
+	OracleUpdateFailed    = "failed"
+	OracleUpdateBlocked   = "blocked" // Not sent: the contract no longer accepts the signer
+)
+
+// Contracts an oracle update can be published to
+const (
+	PublishTargetPrimary = "primary" // The production oracle contract
+	PublishTargetCanary  = "canary"  // The contract or payload being rolled out
+)
+
 // OracleUpdate tracks oracle updates sent to blockchain
 type OracleUpdate struct {
-	ID              uint      `gorm:"primaryKey" json:"id"`
-	UserAddress     string    `gorm:"index;not null" json:"user_address"`
-	Score           uint16    `gorm:"not null" json:"score"`
-	Confidence      uint8     `gorm:"not null" json:"confidence"`
-	DataHash        string    `gorm:"not null" json:"data_hash"`
-	TxHash          string    `gorm:"uniqueIndex" json:"tx_hash"`
-	BlockNumber     uint64    `json:"block_number"`
-	Status          string    `gorm:"default:'pending'" json:"status"` // pending/confirmed/failed
-	GasUsed         uint64    `json:"gas_used"`
-	ErrorMessage    string    `json:"error_message"`
-	RetryCount      uint8     `json:"retry_count"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID             uint             `gorm:"primaryKey" json:"id"`
+	UserAddress    string           `gorm:"index;not null" json:"user_address"`
+	Score          units.Score      `gorm:"not null" json:"score"`
+	Confidence     units.Confidence `gorm:"not null" json:"confidence"`
+	DataHash       string           `gorm:"not null" json:"data_hash"`
+	TxHash         string           `gorm:"index:idx_oracle_updates_tx" json:"tx_hash"` // Shared by every score in a batch transaction
+	BlockNumber    uint64           `json:"block_number"`
+	Status         string           `gorm:"default:'pending'" json:"status"` // queued/pending/confirmed/failed/blocked
+	GasUsed        uint64           `json:"gas_used"`
+	ErrorMessage   string           `json:"error_message"`
+	RetryCount     uint8            `json:"retry_count"`
+	Target         string           `gorm:"index;default:'primary'" json:"target"` // primary/canary
+	PayloadVersion uint8            `gorm:"default:1" json:"payload_version"`      // Contract ABI version the update was encoded for
+	ExpiresAt      *time.Time       `json:"expires_at,omitempty"`                  // When consumers should stop trusting the score (v2 payloads)
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
 }
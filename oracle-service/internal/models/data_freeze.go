@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// Data freeze actions a wallet can sign
+const (
+	FreezeActionFreeze = "freeze"
+	FreezeActionLift   = "lift"
+)
+
+// DataFreeze records a borrower's credit freeze. While frozen, no provider data is
+// pulled for the address and its score is not published. UserAddress is stored in
+// lowercase.
+type DataFreeze struct {
+	ID          uint       `gorm:"primaryKey" json:"-"`
+	UserAddress string     `gorm:"uniqueIndex;not null" json:"user_address"`
+	Frozen      bool       `gorm:"not null" json:"frozen"`
+	FrozenAt    *time.Time `json:"frozen_at,omitempty"`
+	LiftedAt    *time.Time `json:"lifted_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// DebugTarget turns on debug tracing for requests about an address or made with an
+// API key until it expires
+type DebugTarget struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	Address    string    `gorm:"index" json:"address,omitempty"`
+	APIKeyHash string    `gorm:"index" json:"api_key_hash,omitempty"` // SHA-256 of the key; keys are never stored
+	Note       string    `json:"note,omitempty"`
+	ExpiresAt  time.Time `gorm:"index;not null" json:"expires_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// DebugTrace records a traced request with the provider calls made while serving it
+type DebugTrace struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	TargetID      uint      `gorm:"index;not null" json:"target_id"` // DebugTarget.ID
+	Address       string    `gorm:"index" json:"address,omitempty"`
+	APIKeyHash    string    `json:"api_key_hash,omitempty"`
+	Method        string    `json:"method"`
+	Path          string    `json:"path"`
+	Query         string    `json:"query,omitempty"`
+	RequestBody   string    `gorm:"type:text" json:"request_body,omitempty"`
+	Status        int       `json:"status"`
+	ResponseBody  string    `gorm:"type:text" json:"response_body,omitempty"`
+	ProviderCalls string    `gorm:"type:text" json:"-"` // JSON array of debugtrace.ProviderCall
+	DurationMs    int64     `json:"duration_ms"`
+	CreatedAt     time.Time `gorm:"index" json:"created_at"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// IssuedCredential records a credit score credential issued to a borrower. Its ID is
+// the credential's sequence number, which fixes its revocation status list entry.
+type IssuedCredential struct {
+	ID               uint             `gorm:"primaryKey" json:"id"`
+	CredentialID     string           `gorm:"uniqueIndex" json:"credential_id"` // urn:uuid of the credential
+	UserAddress      string           `gorm:"index;not null" json:"user_address"`
+	Score            units.Score      `json:"score"`
+	Confidence       units.Confidence `json:"confidence"`
+	DataHash         string           `json:"data_hash"`
+	StatusList       int              `gorm:"index" json:"status_list"`
+	StatusIndex      int              `json:"status_index"`
+	ExpiresAt        time.Time        `json:"expires_at"`
+	RevokedAt        *time.Time       `json:"revoked_at,omitempty"`
+	RevocationReason string           `json:"revocation_reason,omitempty"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+}
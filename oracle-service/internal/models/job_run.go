@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// Scheduled jobs whose runs are recorded
+const (
+	JobScheduledUpdates = "scheduled_updates" // Recalculation and publication of scores due for update
+	JobPublishQueue     = "publish_queue"     // Publication of queued scores when the publish window is open
+	JobConfirmations    = "confirmations"     // Confirmation of submitted publications from their receipts
+	JobStatsRefresh     = "stats_refresh"     // Materialization of the dashboard stats
+	JobHealthMonitor    = "health_monitor"    // Position health checks of borrowing addresses
+	JobPositionSnapshot = "position_snapshot" // Snapshots of the lending positions of scored addresses
+	JobRetention        = "retention"         // Deletion of expired rows
+)
+
+// Job run statuses
+const (
+	JobRunRunning   = "running"   // Started and not finished; a run left running was interrupted
+	JobRunSucceeded = "succeeded" // Every item succeeded
+	JobRunPartial   = "partial"   // Finished with some items failed
+	JobRunFailed    = "failed"    // Stopped by an error
+	JobRunSkipped   = "skipped"   // Nothing to do, e.g. while paused
+)
+
+// JobRun records one run of a scheduled job
+type JobRun struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	Job          string     `gorm:"index:idx_job_runs_job;not null" json:"job"`
+	Status       string     `gorm:"not null" json:"status"`
+	StartedAt    time.Time  `gorm:"index:idx_job_runs_job;index;not null" json:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+	DurationMs   int64      `json:"duration_ms"`
+	Processed    int        `json:"processed"` // Items the run worked on
+	Succeeded    int        `json:"succeeded"`
+	Failed       int        `json:"failed"`
+	Error        string     `json:"error,omitempty"`    // Why the run failed or was skipped
+	ErrorSamples string     `gorm:"type:text" json:"-"` // JSON array of the first item errors
+	CreatedAt    time.Time  `json:"-"`
+	UpdatedAt    time.Time  `json:"-"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// Liquidation rescore statuses
+const (
+	LiquidationRescorePending   = "pending"   // Waiting for the fast path
+	LiquidationRescorePublished = "published" // Recalculated and published
+	LiquidationRescoreSkipped   = "skipped"   // Nothing to publish: no score, a frozen profile or a provisional score
+	LiquidationRescoreFailed    = "failed"
+)
+
+// Where liquidations are detected
+const (
+	LiquidationSourceHealthMonitor = "health_monitor" // A monitored position was liquidated between checks
+	LiquidationSourceProvider      = "provider"       // A provider reported a new liquidation event
+	LiquidationSourceReported      = "reported"       // Reported through the admin API, e.g. by a chain listener
+)
+
+// LiquidationRescore tracks a detected liquidation of a scored address through the
+// fast path that recalculates and publishes its score. LatencyMs, from detection to
+// publication, is the fast path's SLA metric.
+type LiquidationRescore struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	UserAddress  string     `gorm:"index;not null" json:"user_address"`
+	Protocol     string     `json:"protocol,omitempty"`
+	TxHash       string     `json:"tx_hash,omitempty"` // The liquidation transaction, when known
+	Source       string     `gorm:"not null" json:"source"`
+	Status       string     `gorm:"index;not null" json:"status"`
+	DetectedAt   time.Time  `gorm:"index;not null" json:"detected_at"`
+	RescoredAt   *time.Time `json:"rescored_at,omitempty"`
+	PublishedAt  *time.Time `json:"published_at,omitempty"`
+	LatencyMs    int64      `json:"latency_ms,omitempty"` // Detection to publication
+	ErrorMessage string     `json:"error_message,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
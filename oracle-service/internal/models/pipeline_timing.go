@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+)
+
+// Stages of the score pipeline, timed on every calculation
+const (
+	StageFetchOnChain  = "fetch_on_chain"  // On-chain metrics from the chain or blockchain providers
+	StageFetchOffChain = "fetch_off_chain" // Off-chain metrics from the bureau, bank and employment providers
+	StageScore         = "score"           // Scoring the metrics
+	StagePersist       = "persist"         // Saving the metrics, score, history and components
+	StagePublish       = "publish"         // Submitting the score to the oracle contract
+	StageTotal         = "total"           // Every stage timed, the full update
+)
+
+// PipelineStages are the stages in the order they run
+var PipelineStages = []string{StageFetchOnChain, StageFetchOffChain, StageScore, StagePersist, StagePublish}
+
+// PipelineTiming records how long each stage of one score calculation took, so a
+// slow update can be attributed to a stage and its providers. Stages the calculation
+// didn't run, such as the fetches of a rescore from stored metrics or the stages
+// after a failed one, are nil. Publishing happens separately from the calculation,
+// often in a batch; its time is added once the calculated score is submitted.
+type PipelineTiming struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	UserAddress     string    `gorm:"index;not null" json:"user_address"`
+	Reason          string    `json:"reason"`              // Why the score was recalculated
+	DataHash        string    `json:"data_hash,omitempty"` // Of the calculated score; empty if the calculation failed
+	FetchOnChainMs  *int64    `json:"fetch_on_chain_ms,omitempty"`
+	FetchOffChainMs *int64    `json:"fetch_off_chain_ms,omitempty"`
+	ScoreMs         *int64    `json:"score_ms,omitempty"`
+	PersistMs       *int64    `json:"persist_ms,omitempty"`
+	PublishMs       *int64    `json:"publish_ms,omitempty"` // Set once the score is submitted
+	TotalMs         int64     `json:"total_ms"`             // Sum of the stages timed
+	FailedStage     string    `json:"failed_stage,omitempty"`
+	StartedAt       time.Time `gorm:"index;not null" json:"started_at"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"-"`
+}
+
+// StageMs returns how long a stage took, or false if the calculation didn't run it
+func (t *PipelineTiming) StageMs(stage string) (int64, bool) {
+	var ms *int64
+	switch stage {
+	case StageFetchOnChain:
+		ms = t.FetchOnChainMs
+	case StageFetchOffChain:
+		ms = t.FetchOffChainMs
+	case StageScore:
+		ms = t.ScoreMs
+	case StagePersist:
+		ms = t.PersistMs
+	case StagePublish:
+		ms = t.PublishMs
+	case StageTotal:
+		return t.TotalMs, true
+	}
+	if ms == nil {
+		return 0, false
+	}
+	return *ms, true
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+)
+
+// PositionHealth is the latest health check of an address's borrowing on a lending
+// protocol. Positions near liquidation are at risk and lower the confidence of the
+// address's score until they recover.
+type PositionHealth struct {
+	ID            uint       `gorm:"primaryKey" json:"-"`
+	UserAddress   string     `gorm:"uniqueIndex:idx_position_health;not null" json:"user_address"`
+	Protocol      string     `gorm:"uniqueIndex:idx_position_health;not null" json:"protocol"`
+	HealthFactor  float64    `json:"health_factor"` // 0 when there is no debt
+	CollateralUSD float64    `json:"collateral_usd"`
+	DebtUSD       float64    `json:"debt_usd"`
+	AtRisk        bool       `gorm:"index" json:"at_risk"`
+	CheckedAt     time.Time  `json:"checked_at"`
+	AtRiskSince   *time.Time `json:"at_risk_since,omitempty"` // When the position last became at risk
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// PositionSnapshot is an address's open position on a lending protocol at one point in
+// time. Snapshots of every scored address are taken on a schedule, independently of
+// score refreshes, so position trends can be followed between them.
+type PositionSnapshot struct {
+	ID           uint      `gorm:"primaryKey" json:"-"`
+	UserAddress  string    `gorm:"index:idx_position_snapshots;not null" json:"user_address"`
+	Protocol     string    `gorm:"index:idx_position_snapshots;not null" json:"protocol"`
+	SuppliedUSD  float64   `json:"supplied_usd"` // Supplied assets, collateral included
+	BorrowedUSD  float64   `json:"borrowed_usd"`
+	HealthFactor float64   `json:"health_factor"` // 0 when there is no debt
+	TakenAt      time.Time `gorm:"index:idx_position_snapshots;index;not null" json:"taken_at"`
+	CreatedAt    time.Time `json:"-"`
+}
+
+// Closed reports whether the snapshot shows no position
+func (p *PositionSnapshot) Closed() bool {
+	return p.SuppliedUSD == 0 && p.BorrowedUSD == 0
+}
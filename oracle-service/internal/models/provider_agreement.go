@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// ProviderAgreement tracks how often an address's data providers agree on the fields
+// they both report, such as income and employment status. Chronic disagreement lowers
+// the confidence of the address's score.
+type ProviderAgreement struct {
+	ID             uint      `gorm:"primaryKey" json:"-"`
+	UserAddress    string    `gorm:"uniqueIndex;not null" json:"user_address"`
+	Comparisons    uint32    `json:"comparisons"`
+	Disagreements  uint32    `json:"disagreements"`
+	LastConflicts  string    `json:"last_conflicts,omitempty"` // Comma-separated fields that disagreed in the latest conflicting fetch
+	LastComparedAt time.Time `json:"last_compared_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+)
+
+// Kinds of provider health observations
+const (
+	ProviderCall  = "call"  // A provider API call made while collecting metrics
+	ProviderProbe = "probe" // A health check, made periodically and by the provider status endpoint
+)
+
+// ProviderHealth is the outcome of one call to a 3rd party provider. Rolling success
+// rates, latency and uptime are computed from these rows.
+type ProviderHealth struct {
+	ID        uint      `gorm:"primaryKey" json:"-"`
+	Provider  string    `gorm:"index:idx_provider_health_checked;not null" json:"provider"` // pause.CreditBureau, pause.Plaid, ...
+	Chain     string    `json:"chain,omitempty"`                                            // Chain the call was for; empty for off-chain providers
+	Kind      string    `gorm:"not null" json:"kind"`
+	Success   bool      `gorm:"not null" json:"success"`
+	LatencyMs int64     `json:"latency_ms"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `gorm:"index:idx_provider_health_checked;not null" json:"checked_at"`
+}
+
+// TableName keeps observations in provider_health rather than the pluralized default
+func (ProviderHealth) TableName() string {
+	return "provider_health"
+}
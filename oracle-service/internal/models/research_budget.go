@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// ResearchBudget is the differential privacy budget an analytics partner has spent on
+// research exports in one UTC day. It is persisted so restarts and other replicas
+// can't hand out a fresh budget.
+type ResearchBudget struct {
+	ID        uint      `gorm:"primaryKey" json:"-"`
+	Partner   string    `gorm:"uniqueIndex:idx_research_budget;not null" json:"partner"`
+	Day       string    `gorm:"uniqueIndex:idx_research_budget;not null" json:"day"` // UTC, 2006-01-02
+	Spent     float64   `gorm:"not null" json:"spent"`                               // Epsilon spent
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
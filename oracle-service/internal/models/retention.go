@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// Retention policies, each covering the rows of one table that may expire
+const (
+	RetentionFailedOracleUpdates = "failed_oracle_updates" // Oracle updates whose publication failed or was blocked
+	RetentionWebhookDeliveries   = "webhook_deliveries"    // Finished webhook deliveries with their attempts; dead letters are kept
+	RetentionBureauAlerts        = "bureau_alerts"         // Received bureau monitoring alerts
+	RetentionScoreHistory        = "score_history"         // Historical scores; current scores are never removed
+	RetentionScoreComponents     = "score_components"      // Factor scores of past calculations
+	RetentionDebugTraces         = "debug_traces"          // Recorded debug traces
+	RetentionJobRuns             = "job_runs"              // Recorded runs of scheduled jobs
+	RetentionPositionSnapshots   = "position_snapshots"    // Snapshots of lending positions
+	RetentionPipelineTimings     = "pipeline_timings"      // Stage timings of score calculations
+)
+
+// RetentionStat records what a retention policy has removed
+type RetentionStat struct {
+	ID             uint      `gorm:"primaryKey" json:"-"`
+	Policy         string    `gorm:"uniqueIndex;not null" json:"policy"`
+	RetentionDays  int       `json:"retention_days"`
+	TotalDeleted   int64     `json:"total_deleted"`
+	LastDeleted    int64     `json:"last_deleted"`
+	LastArchiveKey string    `json:"last_archive_key,omitempty"` // Where the last run's rows were archived
+	LastError      string    `json:"last_error,omitempty"`
+	LastRunAt      time.Time `json:"last_run_at"`
+	CreatedAt      time.Time `json:"-"`
+	UpdatedAt      time.Time `json:"-"`
+}
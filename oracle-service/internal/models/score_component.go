@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// ScoreComponent is one factor score of a score calculation, such as wallet age or
+// debt-to-income, stored so explanations, diffs and feature extraction can read the
+// factors instead of recomputing them. All components of a calculation share its
+// address, data hash and calculation time.
+type ScoreComponent struct {
+	ID           uint      `gorm:"primaryKey" json:"-"`
+	UserAddress  string    `gorm:"index:idx_score_component_calc;not null" json:"user_address"`
+	CalculatedAt time.Time `gorm:"index:idx_score_component_calc;not null" json:"calculated_at"`
+	DataHash     string    `gorm:"not null" json:"data_hash"`
+	Component    string    `gorm:"not null" json:"component"` // on_chain, off_chain or hybrid
+	Factor       string    `gorm:"not null" json:"factor"`
+	Score        float64   `json:"score"`  // 0-1; hybrid factors are signed bonuses and penalties
+	Weight       float64   `json:"weight"` // Share of the component score
+	CreatedAt    time.Time `json:"-"`
+}
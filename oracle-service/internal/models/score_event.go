@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// Score lifecycle event types
+const (
+	ScoreEventMetricsFetched = "metrics_fetched"  // On-chain and off-chain metrics were fetched and stored
+	ScoreEventCalculated     = "score_calculated" // A score was calculated and saved
+	ScoreEventPublished      = "published"        // A score update was submitted on-chain
+	ScoreEventDisputed       = "disputed"         // The borrower disputed the score
+	ScoreEventOverridden     = "overridden"       // An operator replaced the calculated score
+)
+
+// ScoreEvent is one entry in an address's append-only score lifecycle stream.
+// Sequence numbers are contiguous per address, starting at 1.
+type ScoreEvent struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	UserAddress string    `gorm:"uniqueIndex:idx_score_event_seq;not null" json:"user_address"`
+	Sequence    uint64    `gorm:"uniqueIndex:idx_score_event_seq;not null" json:"sequence"`
+	EventType   string    `gorm:"index;not null" json:"event_type"`
+	Payload     string    `gorm:"type:text;not null" json:"payload"` // JSON-encoded event data
+	CreatedAt   time.Time `gorm:"index" json:"created_at"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// ScoreShare is a time-limited, revocable link that lets a third party read a
+// borrower's score and explanation. Only a hash of the token is stored.
+type ScoreShare struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	TokenHash      string     `gorm:"uniqueIndex;not null" json:"-"`
+	UserAddress    string     `gorm:"index;not null" json:"user_address"`
+	Label          string     `json:"label,omitempty"` // Who the link was made for, as noted by the borrower
+	ExpiresAt      time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	AccessCount    int        `json:"access_count"`
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// Active reports whether the share can still be used at t
+func (s *ScoreShare) Active(t time.Time) bool {
+	return s.RevokedAt == nil && t.Before(s.ExpiresAt)
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// StatsDashboard names the materialized stats row behind the admin stats endpoint
+const StatsDashboard = "dashboard"
+
+// ScoreStats holds precomputed dashboard aggregates so the stats endpoint does not
+// scan the score tables on every call
+type ScoreStats struct {
+	ID                   uint      `gorm:"primaryKey" json:"-"`
+	Name                 string    `gorm:"uniqueIndex;not null" json:"-"`
+	TotalActiveScores    int64     `json:"total_active_scores"`
+	AverageScore         float64   `json:"average_score"`
+	DueForUpdate         int64     `json:"due_for_update"`
+	PendingOracleUpdates int64     `json:"pending_oracle_updates"`
+	QueuedOracleUpdates  int64     `json:"queued_oracle_updates"`
+	ComputedAt           time.Time `gorm:"not null" json:"computed_at"`
+	RefreshMs            int64     `json:"refresh_ms"` // How long the aggregate queries took
+	CreatedAt            time.Time `json:"-"`
+	UpdatedAt            time.Time `json:"-"`
+}
+
+// Map returns the stats keyed as in the stats API response
+func (s *ScoreStats) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"total_active_scores":    s.TotalActiveScores,
+		"average_score":          s.AverageScore,
+		"due_for_update":         s.DueForUpdate,
+		"pending_oracle_updates": s.PendingOracleUpdates,
+		"queued_oracle_updates":  s.QueuedOracleUpdates,
+		"computed_at":            s.ComputedAt,
+	}
+}
+
+// ScoreTrendPoint summarizes the scores calculated on one UTC day
+type ScoreTrendPoint struct {
+	Day          time.Time   `json:"day"`
+	AverageScore float64     `json:"average_score"`
+	MinScore     units.Score `json:"min_score"`
+	MaxScore     units.Score `json:"max_score"`
+	Updates      int64       `json:"updates"`   // Score calculations on the day
+	Addresses    int64       `json:"addresses"` // Distinct addresses scored on the day
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// SimulatedTransaction is an oracle transaction the null publisher would have sent.
+// Soak tests run the full publishing pipeline against it without chain access.
+type SimulatedTransaction struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	TxHash         string    `gorm:"index;not null" json:"tx_hash"`
+	Contract       string    `gorm:"index:idx_simulated_tx_nonce" json:"contract"`
+	Nonce          uint64    `gorm:"index:idx_simulated_tx_nonce" json:"nonce"`
+	Method         string    `json:"method"` // Contract function called, e.g. updateScores
+	Scores         int       `json:"scores"`
+	PayloadVersion uint8     `json:"payload_version"`
+	Gas            uint64    `json:"gas"`        // Simulated gas limit
+	LatencyMs      int64     `json:"latency_ms"` // Simulated time to submit
+	CreatedAt      time.Time `json:"created_at"`
+}
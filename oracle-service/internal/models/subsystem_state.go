@@ -0,0 +1,14 @@
+package models
+
+import (
+	"time"
+)
+
+// SubsystemState records whether an operator has paused a subsystem. Subsystems
+// without a row are running.
+type SubsystemState struct {
+	Name      string    `gorm:"primaryKey" json:"name"` // pause.Scheduler, pause.Plaid, ...
+	Paused    bool      `gorm:"not null;default:false" json:"paused"`
+	Reason    string    `json:"reason,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
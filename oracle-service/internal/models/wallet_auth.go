@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+)
+
+// WalletAuth tracks the last wallet-signed request accepted for an address, so a
+// signed request cannot be replayed. UserAddress is stored in lowercase.
+type WalletAuth struct {
+	ID           uint      `gorm:"primaryKey"`
+	UserAddress  string    `gorm:"uniqueIndex;not null"`
+	LastSignedAt time.Time `gorm:"not null"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+)
+
+// Webhook delivery directions
+const (
+	WebhookInbound  = "inbound"  // Received from a provider
+	WebhookOutbound = "outbound" // Sent to a subscriber
+)
+
+// Webhook delivery statuses
+const (
+	WebhookReceived  = "received"  // Inbound delivery accepted, processing not finished
+	WebhookProcessed = "processed" // Inbound delivery fully handled
+	WebhookDelivered = "delivered" // Outbound delivery acknowledged with a 2xx response
+	WebhookRetrying  = "retrying"  // Outbound delivery failed and is scheduled for another attempt
+	WebhookDead      = "dead"      // Outbound delivery exhausted its retries and was dead-lettered
+	WebhookFailed    = "failed"
+)
+
+// WebhookDelivery records a webhook delivery so that redeliveries and replays are
+// recognized. Source is the sending provider for inbound deliveries and the endpoint
+// URL for outbound deliveries.
+type WebhookDelivery struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	Direction     string     `gorm:"uniqueIndex:idx_webhook_delivery;not null" json:"direction"`
+	Source        string     `gorm:"uniqueIndex:idx_webhook_delivery;not null" json:"source"`
+	DeliveryID    string     `gorm:"uniqueIndex:idx_webhook_delivery;not null" json:"delivery_id"`
+	EventType     string     `gorm:"index" json:"event_type"`
+	Status        string     `gorm:"index;not null" json:"status"`
+	Attempts      int        `json:"attempts"`
+	ResponseCode  int        `json:"response_code,omitempty"`
+	LatencyMs     int64      `json:"latency_ms,omitempty"` // Latency of the last outbound attempt
+	ErrorMessage  string     `json:"error_message,omitempty"`
+	Payload       string     `gorm:"type:text" json:"-"`                     // Outbound body, kept for retries and replays
+	NextAttemptAt *time.Time `gorm:"index" json:"next_attempt_at,omitempty"` // Set while retrying
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// WebhookAttempt records one outbound delivery attempt
+type WebhookAttempt struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	DeliveryID   uint      `gorm:"index;not null" json:"delivery_id"` // WebhookDelivery.ID
+	Attempt      int       `json:"attempt"`
+	Success      bool      `json:"success"`
+	ResponseCode int       `json:"response_code,omitempty"`
+	LatencyMs    int64     `json:"latency_ms"`
+	ErrorMessage string    `json:"error_message,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// WebhookDeadLetter holds an outbound delivery that exhausted its retries until it
+// is replayed by an operator
+type WebhookDeadLetter struct {
+	ID                uint       `gorm:"primaryKey" json:"id"`
+	WebhookDeliveryID uint       `gorm:"uniqueIndex;not null" json:"webhook_delivery_id"`
+	Endpoint          string     `gorm:"not null" json:"endpoint"`
+	EventID           string     `gorm:"not null" json:"event_id"`
+	EventType         string     `gorm:"index" json:"event_type"`
+	Attempts          int        `json:"attempts"`
+	LastResponseCode  int        `json:"last_response_code,omitempty"`
+	LastError         string     `json:"last_error"`
+	ReplayCount       int        `json:"replay_count"`
+	ReplayedAt        *time.Time `gorm:"index" json:"replayed_at,omitempty"` // Set once a replay is delivered
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,73 @@
+// Package pause names the subsystems operators can pause at runtime, such as
+// publishing or a provider during its outage, and stops paused providers' HTTP calls.
+//
+// Whether a subsystem is paused is answered by a Checker. Providers send their HTTP
+// calls through a Transport, which fails calls of a paused provider with a provider
+// unavailable error instead of sending them, so scoring carries on without the
+// provider just as it does during an outage.
+package pause
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+)
+
+// Subsystems that can be paused
+const (
+	Scheduler      = "scheduler"       // Scheduled refreshes of scores due for update
+	Publishing     = "publishing"      // Publishing scores to the oracle contract
+	CreditBureau   = "credit_bureau"   // Credit bureau reports
+	Plaid          = "plaid"           // Bank accounts and transactions
+	Employment     = "employment"      // Payroll employment verification
+	BlockchainData = "blockchain_data" // Covalent portfolio data
+	Blockscout     = "blockscout"      // Blockscout explorer data
+	ChainIndex     = "chain_index"     // Block ingestion of the self-hosted chain index
+)
+
+// Subsystems lists every subsystem that can be paused
+var Subsystems = []string{Scheduler, Publishing, CreditBureau, Plaid, Employment, BlockchainData, Blockscout, ChainIndex}
+
+// Valid reports whether name is a subsystem that can be paused
+func Valid(name string) bool {
+	for _, subsystem := range Subsystems {
+		if subsystem == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Checker reports whether a subsystem is paused
+type Checker interface {
+	Paused(ctx context.Context, name string) bool
+}
+
+// Transport fails a provider's HTTP calls while the provider is paused and passes
+// them through otherwise
+type Transport struct {
+	name    string
+	checker Checker
+	base    http.RoundTripper
+}
+
+// NewTransport returns a transport for the provider subsystem name, sending calls
+// through base. A nil base uses http.DefaultTransport.
+func NewTransport(name string, checker Checker, base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{name: name, checker: checker, base: base}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.checker.Paused(req.Context(), t.name) {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, errors.ProviderUnavailable("%s is paused", t.name)
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,62 @@
+package pause
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+)
+
+type checker map[string]bool
+
+func (c checker) Paused(ctx context.Context, name string) bool {
+	return c[name]
+}
+
+func TestTransport(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	paused := checker{}
+	client := &http.Client{Transport: NewTransport(Plaid, paused, nil)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Expected the call to pass through, got %v", err)
+	}
+	resp.Body.Close()
+
+	paused[Plaid] = true
+	_, err = client.Get(server.URL)
+	if !errors.Is(err, errors.ErrProviderUnavailable) {
+		t.Errorf("Expected a provider unavailable error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected the paused call not to be sent, got %d calls", calls)
+	}
+
+	// Other providers keep running
+	paused[Plaid] = false
+	paused[CreditBureau] = true
+	resp, err = client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Expected the call to pass through, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestValid(t *testing.T) {
+	for _, name := range Subsystems {
+		if !Valid(name) {
+			t.Errorf("Expected %s to be valid", name)
+		}
+	}
+	if Valid("mainframe") {
+		t.Error("Expected an unknown subsystem to be invalid")
+	}
+}
@@ -0,0 +1,380 @@
+// Package providerhealth keeps a rolling health history of the 3rd party providers,
+// per provider and per chain, so outages and slowdowns show up in the provider status
+// endpoint instead of only the result of one health check.
+//
+// Providers send their HTTP calls through a Transport, which records the outcome and
+// latency of every call. Health checks are recorded as probes by Probe and
+// RunProbes; uptime is the share of probes that passed. Observations are buffered in
+// memory and written to the provider_health table in batches, and rows older than the
+// longest window are deleted.
+package providerhealth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/pause"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Windows are the periods health history is summarized over, by name
+var Windows = []struct {
+	Name     string
+	Duration time.Duration
+}{
+	{"24h", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+}
+
+// Monitor defaults
+const (
+	defaultFlushInterval = 5 * time.Second
+	maxBuffered          = 10000 // Observations kept while the database is unreachable
+	pruneInterval        = time.Hour
+)
+
+// WindowStats summarizes a provider's observations over one window
+type WindowStats struct {
+	Calls        int64      `json:"calls"`
+	SuccessRate  *float64   `json:"success_rate"` // Share of calls that succeeded; null without calls
+	AvgLatencyMs float64    `json:"avg_latency_ms"`
+	Probes       int64      `json:"probes"`
+	Uptime       *float64   `json:"uptime"` // Share of health probes that passed; null without probes
+	LastError    string     `json:"last_error,omitempty"`
+	LastErrorAt  *time.Time `json:"last_error_at,omitempty"`
+
+	succeeded, passed int64
+	latencyMs         float64 // Sum over all calls
+}
+
+// History is the health of one provider over each window, overall and per chain
+type History struct {
+	Windows map[string]*WindowStats            `json:"windows"`
+	Chains  map[string]map[string]*WindowStats `json:"chains,omitempty"` // Chain -> window -> stats
+}
+
+// Monitor records provider health observations and summarizes them. A nil Monitor
+// records nothing.
+type Monitor struct {
+	repo   *repository.ProviderHealthRepository
+	pauses pause.Checker
+	now    func() time.Time
+
+	mu       sync.Mutex
+	buffered []*models.ProviderHealth
+	done     chan struct{}
+	stopped  chan struct{}
+}
+
+// NewMonitor starts writing observations through repo every few seconds
+func NewMonitor(repo *repository.ProviderHealthRepository) *Monitor {
+	return newMonitor(repo, time.Now, defaultFlushInterval)
+}
+
+func newMonitor(repo *repository.ProviderHealthRepository, now func() time.Time, flushInterval time.Duration) *Monitor {
+	m := &Monitor{
+		repo:    repo,
+		now:     now,
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go m.run(flushInterval)
+	return m
+}
+
+// SetPauses skips probing providers that pauses reports as paused, so a pause doesn't
+// count against their uptime
+func (m *Monitor) SetPauses(pauses pause.Checker) {
+	m.pauses = pauses
+}
+
+// Record buffers the outcome of a call to a provider
+func (m *Monitor) Record(provider, chain, kind string, latency time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	observation := &models.ProviderHealth{
+		Provider:  provider,
+		Chain:     chain,
+		Kind:      kind,
+		Success:   err == nil,
+		LatencyMs: latency.Milliseconds(),
+		CheckedAt: m.now().UTC(),
+	}
+	if err != nil {
+		observation.Error = err.Error()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.buffered) >= maxBuffered {
+		m.buffered = m.buffered[1:]
+	}
+	m.buffered = append(m.buffered, observation)
+}
+
+type probeKey struct{}
+
+// Probe runs a provider's health check and records it as a probe. The check's own
+// HTTP calls are not recorded as calls. Checks of paused providers are run but not
+// recorded.
+func (m *Monitor) Probe(ctx context.Context, provider, chain string, check func(context.Context) error) error {
+	if m == nil || (m.pauses != nil && m.pauses.Paused(ctx, provider)) {
+		return check(ctx)
+	}
+
+	started := m.now()
+	err := check(context.WithValue(ctx, probeKey{}, true))
+	m.Record(provider, chain, models.ProviderProbe, m.now().Sub(started), err)
+	return err
+}
+
+// Check is a provider health check run by RunProbes
+type Check struct {
+	Provider string
+	Chain    string
+	Check    func(context.Context) error
+}
+
+// RunProbes runs every check each interval until ctx is cancelled
+func (m *Monitor) RunProbes(ctx context.Context, interval time.Duration, checks []Check) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, check := range checks {
+			probeCtx, cancel := context.WithTimeout(ctx, interval)
+			m.Probe(probeCtx, check.Provider, check.Chain, check.Check)
+			cancel()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// History summarizes the observations of every provider over each window, including
+// those not written yet
+func (m *Monitor) History(ctx context.Context) (map[string]*History, error) {
+	if err := m.flush(ctx); err != nil {
+		return nil, err
+	}
+
+	histories := make(map[string]*History)
+	stats := func(provider, chain, window string) *WindowStats {
+		history, ok := histories[provider]
+		if !ok {
+			history = &History{Windows: make(map[string]*WindowStats)}
+			histories[provider] = history
+		}
+		windows := history.Windows
+		if chain != "" {
+			if history.Chains == nil {
+				history.Chains = make(map[string]map[string]*WindowStats)
+			}
+			if history.Chains[chain] == nil {
+				history.Chains[chain] = make(map[string]*WindowStats)
+			}
+			windows = history.Chains[chain]
+		}
+		if windows[window] == nil {
+			windows[window] = &WindowStats{}
+		}
+		return windows[window]
+	}
+
+	now := m.now()
+	for _, window := range Windows {
+		since := now.Add(-window.Duration)
+		totals, err := m.repo.Totals(ctx, since)
+		if err != nil {
+			return nil, err
+		}
+		// Chain totals are added to the provider's overall totals too
+		for _, total := range totals {
+			targets := []*WindowStats{stats(total.Provider, "", window.Name)}
+			if total.Chain != "" {
+				targets = append(targets, stats(total.Provider, total.Chain, window.Name))
+			}
+			for _, target := range targets {
+				target.add(total)
+			}
+		}
+
+		failures, err := m.repo.LastErrors(ctx, since)
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(failures, func(i, j int) bool { return failures[i].CheckedAt.Before(failures[j].CheckedAt) })
+		for _, failure := range failures {
+			stats(failure.Provider, "", window.Name).setLastError(failure)
+			if failure.Chain != "" {
+				stats(failure.Provider, failure.Chain, window.Name).setLastError(failure)
+			}
+		}
+	}
+
+	// Windows without observations are reported empty
+	for provider, history := range histories {
+		for _, window := range Windows {
+			stats(provider, "", window.Name)
+			for chain := range history.Chains {
+				stats(provider, chain, window.Name)
+			}
+		}
+	}
+
+	return histories, nil
+}
+
+// add counts a window's totals of one kind into the stats
+func (s *WindowStats) add(total *repository.ProviderHealthTotals) {
+	switch total.Kind {
+	case models.ProviderCall:
+		s.Calls += total.Total
+		s.succeeded += total.Succeeded
+		s.latencyMs += total.AvgLatencyMs * float64(total.Total)
+		if s.Calls > 0 {
+			rate := float64(s.succeeded) / float64(s.Calls)
+			s.SuccessRate = &rate
+			s.AvgLatencyMs = s.latencyMs / float64(s.Calls)
+		}
+	case models.ProviderProbe:
+		s.Probes += total.Total
+		s.passed += total.Succeeded
+		if s.Probes > 0 {
+			uptime := float64(s.passed) / float64(s.Probes)
+			s.Uptime = &uptime
+		}
+	}
+}
+
+// setLastError keeps the failure as the last error; failures are set oldest first
+func (s *WindowStats) setLastError(failure *models.ProviderHealth) {
+	at := failure.CheckedAt
+	s.LastError = failure.Error
+	s.LastErrorAt = &at
+}
+
+// Close writes the buffered observations and stops the monitor
+func (m *Monitor) Close() error {
+	close(m.done)
+	<-m.stopped
+	return nil
+}
+
+func (m *Monitor) run(flushInterval time.Duration) {
+	defer close(m.stopped)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	lastPruned := m.now()
+
+	for {
+		select {
+		case <-m.done:
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			if err := m.flush(ctx); err != nil {
+				logger.Error("Failed to write provider health observations", zap.Error(err))
+			}
+			cancel()
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		if err := m.flush(ctx); err != nil {
+			logger.Error("Failed to write provider health observations", zap.Error(err))
+		}
+		if m.now().Sub(lastPruned) >= pruneInterval {
+			lastPruned = m.now()
+			longest := Windows[len(Windows)-1].Duration
+			if _, err := m.repo.DeleteBefore(ctx, lastPruned.Add(-longest)); err != nil {
+				logger.Error("Failed to prune provider health observations", zap.Error(err))
+			}
+		}
+		cancel()
+	}
+}
+
+// flush writes the buffered observations. They are kept for the next flush if the
+// write fails.
+func (m *Monitor) flush(ctx context.Context) error {
+	m.mu.Lock()
+	batch := m.buffered
+	m.buffered = nil
+	m.mu.Unlock()
+
+	if err := m.repo.CreateObservations(ctx, batch); err != nil {
+		m.mu.Lock()
+		m.buffered = append(batch, m.buffered...)
+		if len(m.buffered) > maxBuffered {
+			m.buffered = m.buffered[len(m.buffered)-maxBuffered:]
+		}
+		m.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Transport records the outcome and latency of a provider's HTTP calls. Calls fail
+// when they get no response, are rate limited or unauthorized, or the provider
+// answers with a server error; other client errors are the caller's and count as
+// successful calls.
+type Transport struct {
+	monitor  *Monitor
+	provider string
+	chain    func(*http.Request) string
+	base     http.RoundTripper
+}
+
+// NewTransport returns a transport recording the calls of provider, attributed to
+// the chain returned by chain (nil for off-chain providers), and sending them through
+// base. A nil base uses http.DefaultTransport; a nil monitor records nothing.
+func NewTransport(monitor *Monitor, provider string, chain func(*http.Request) string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if monitor == nil {
+		return base
+	}
+	return &Transport{monitor: monitor, provider: provider, chain: chain, base: base}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if probing, _ := req.Context().Value(probeKey{}).(bool); probing {
+		return t.base.RoundTrip(req)
+	}
+
+	started := t.monitor.now()
+	resp, err := t.base.RoundTrip(req)
+	latency := t.monitor.now().Sub(started)
+
+	var chain string
+	if t.chain != nil {
+		chain = t.chain(req)
+	}
+	failure := err
+	if err == nil && failedStatus(resp.StatusCode) {
+		failure = fmt.Errorf("provider responded %s", resp.Status)
+	}
+	t.monitor.Record(t.provider, chain, models.ProviderCall, latency, failure)
+
+	return resp, err
+}
+
+// failedStatus reports whether a response status means the provider failed the call
+func failedStatus(code int) bool {
+	return code >= 500 || code == http.StatusTooManyRequests ||
+		code == http.StatusUnauthorized || code == http.StatusForbidden
+}
@@ -0,0 +1,155 @@
+package providerhealth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupMonitor(t *testing.T, now func() time.Time) (*Monitor, *repository.ProviderHealthRepository) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(&models.ProviderHealth{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+
+	repo := repository.NewProviderHealthRepository(db)
+	monitor := newMonitor(repo, now, time.Hour)
+	t.Cleanup(func() { monitor.Close() })
+	return monitor, repo
+}
+
+// pausedChecker reports the named providers as paused
+type pausedChecker map[string]bool
+
+func (p pausedChecker) Paused(ctx context.Context, name string) bool {
+	return p[name]
+}
+
+func TestMonitorHistory(t *testing.T) {
+	var mu sync.Mutex
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	setNow := func(at time.Time) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = at
+	}
+	monitor, repo := setupMonitor(t, clock)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/1/address/0xabc/balances_v2/":
+			w.WriteHeader(http.StatusOK)
+		case "/v1/137/address/0xabc/balances_v2/":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	chain := func(req *http.Request) string {
+		switch req.URL.Path {
+		case "/v1/1/address/0xabc/balances_v2/":
+			return "1"
+		case "/v1/137/address/0xabc/balances_v2/":
+			return "137"
+		}
+		return ""
+	}
+	client := &http.Client{Transport: NewTransport(monitor, "blockchain_data", chain, nil)}
+	get := func(ctx context.Context, path string) {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	// A failure three days ago only counts in the 7d window
+	setNow(now.Add(-3 * 24 * time.Hour))
+	get(context.Background(), "/v1/137/address/0xabc/balances_v2/")
+	setNow(time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC))
+
+	get(context.Background(), "/v1/1/address/0xabc/balances_v2/")
+	get(context.Background(), "/v1/1/address/0xabc/balances_v2/")
+	// Unknown resources are the caller's error, not the provider's
+	get(context.Background(), "/v1/unknown")
+
+	// Health checks are probes; their own calls aren't recorded as calls
+	monitor.Probe(context.Background(), "blockchain_data", "", func(ctx context.Context) error {
+		get(ctx, "/v1/chains/status/")
+		return nil
+	})
+	monitor.Probe(context.Background(), "blockchain_data", "", func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+
+	// Paused providers are checked but not recorded
+	monitor.SetPauses(pausedChecker{"plaid": true})
+	if err := monitor.Probe(context.Background(), "plaid", "", func(ctx context.Context) error {
+		return errors.New("plaid is paused")
+	}); err == nil {
+		t.Error("Expected the paused provider's check error to be returned")
+	}
+
+	history, err := monitor.History(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to get history: %v", err)
+	}
+	if _, ok := history["plaid"]; ok {
+		t.Error("Expected no history for a paused provider")
+	}
+
+	provider := history["blockchain_data"]
+	if provider == nil {
+		t.Fatal("Expected blockchain_data history")
+	}
+	day := provider.Windows["24h"]
+	if day.Calls != 3 || day.SuccessRate == nil || *day.SuccessRate != 1 {
+		t.Errorf("Expected 3 successful calls in 24h, got %+v", day)
+	}
+	if day.Probes != 2 || day.Uptime == nil || *day.Uptime != 0.5 || day.LastError != "connection refused" {
+		t.Errorf("Expected 50%% uptime over 2 probes in 24h, got %+v", day)
+	}
+
+	week := provider.Windows["7d"]
+	if week.Calls != 4 || *week.SuccessRate != 0.75 {
+		t.Errorf("Expected 3 of 4 calls successful in 7d, got %+v", week)
+	}
+	polygon := provider.Chains["137"]
+	if polygon["24h"].Calls != 0 || polygon["24h"].SuccessRate != nil {
+		t.Errorf("Expected no chain 137 calls in 24h, got %+v", polygon["24h"])
+	}
+	if polygon["7d"].Calls != 1 || *polygon["7d"].SuccessRate != 0 || polygon["7d"].LastError == "" {
+		t.Errorf("Expected one failed chain 137 call in 7d, got %+v", polygon["7d"])
+	}
+	if provider.Chains["1"]["24h"].Calls != 2 {
+		t.Errorf("Expected 2 chain 1 calls in 24h, got %+v", provider.Chains["1"]["24h"])
+	}
+
+	// Observations older than the longest window are deleted
+	deleted, err := repo.DeleteBefore(context.Background(), time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC))
+	if err != nil || deleted != 1 {
+		t.Errorf("Expected the old observation deleted, got %d (%v)", deleted, err)
+	}
+}
@@ -5,8 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
 	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -14,10 +17,11 @@ import (
 // BlockchainDataProvider integrates with blockchain analytics providers
 // (The Graph, Dune Analytics, Covalent, Moralis)
 type BlockchainDataProvider struct {
-	httpClient *http.Client
-	apiKey     string
-	baseURL    string
-	provider   string // "covalent", "moralis", "thegraph"
+	httpClient  *http.Client
+	apiKey      *Credential
+	baseURL     string
+	provider    string // "covalent", "moralis", "thegraph"
+	tokenFilter *TokenFilter
 }
 
 // DeFiActivity represents DeFi protocol interaction data
@@ -29,6 +33,12 @@ type DeFiActivity struct {
 	TransactionHash string    `json:"transaction_hash"`
 	Timestamp       time.Time `json:"timestamp"`
 	Status          string    `json:"status"` // "success", "failed"
+
+	// Borrows, repayments, deposits and withdrawals, for matching repayments to
+	// borrows and spotting deposit/withdraw cycles
+	Asset          string `json:"asset,omitempty"`           // Token or market borrowed from or supplied to
+	RawAmount      string `json:"raw_amount,omitempty"`      // Amount in the asset's base units, when known
+	ClosesPosition bool   `json:"closes_position,omitempty"` // Repays or withdraws everything in the market
 }
 
 // LendingPosition represents lending/borrowing position
@@ -56,20 +66,35 @@ type LiquidationEvent struct {
 
 // BlockchainSummary represents comprehensive on-chain data
 type BlockchainSummary struct {
-	Address                string             `json:"address"`
-	WalletAge              int                `json:"wallet_age_days"`
-	FirstTransaction       time.Time          `json:"first_transaction"`
-	LastTransaction        time.Time          `json:"last_transaction"`
-	TotalTransactions      int                `json:"total_transactions"`
-	TotalVolume            float64            `json:"total_volume"` // USD value
-	AverageTransactionSize float64            `json:"average_transaction_size"`
-	DeFiActivities         []DeFiActivity     `json:"defi_activities"`
-	LendingPositions       []LendingPosition  `json:"lending_positions"`
-	LiquidationEvents      []LiquidationEvent `json:"liquidation_events"`
-	NFTHoldings            int                `json:"nft_holdings"`
-	TokenBalances          map[string]float64 `json:"token_balances"` // token -> balance
-	TotalPortfolioValue    float64            `json:"total_portfolio_value"`
-	LastUpdated            time.Time          `json:"last_updated"`
+	Address                string                   `json:"address"`
+	WalletAge              int                      `json:"wallet_age_days"`
+	FirstTransaction       time.Time                `json:"first_transaction"`
+	LastTransaction        time.Time                `json:"last_transaction"`
+	TotalTransactions      int                      `json:"total_transactions"`
+	TotalVolume            float64                  `json:"total_volume"` // USD value
+	AverageTransactionSize float64                  `json:"average_transaction_size"`
+	DeFiActivities         []DeFiActivity           `json:"defi_activities"`
+	LendingPositions       []LendingPosition        `json:"lending_positions"`
+	LiquidationEvents      []LiquidationEvent       `json:"liquidation_events"`
+	NFTHoldings            int                      `json:"nft_holdings"`
+	TokenBalances          map[string]float64       `json:"token_balances"` // token -> balance
+	TotalPortfolioValue    float64                  `json:"total_portfolio_value"`
+	SpamTokensExcluded     int                      `json:"spam_tokens_excluded"` // Held tokens left out of balances and valuation
+	Chains                 map[string]ChainActivity `json:"chains,omitempty"`     // Per-chain activity, for multi-chain summaries
+	LastUpdated            time.Time                `json:"last_updated"`
+}
+
+// ChainActivity is a wallet's activity on one chain
+type ChainActivity struct {
+	FirstTransaction  time.Time `json:"first_transaction"`
+	WalletAge         int       `json:"wallet_age_days"`
+	TotalTransactions int       `json:"total_transactions"`
+}
+
+// BalanceSample is a wallet balance observed at a point in time
+type BalanceSample struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"` // USD for provider history, native units for archive-node samples
 }
 
 // NewBlockchainDataProvider creates a new blockchain data provider
@@ -78,12 +103,39 @@ func NewBlockchainDataProvider(provider, baseURL, apiKey string) *BlockchainData
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		apiKey:   apiKey,
+		apiKey:   NewCredential(apiKey),
 		baseURL:  baseURL,
 		provider: provider,
 	}
 }
 
+// SetTokenFilter sets the filter used to exclude spam tokens from portfolio metrics
+func (p *BlockchainDataProvider) SetTokenFilter(filter *TokenFilter) {
+	p.tokenFilter = filter
+}
+
+// SetTransport sends the provider's HTTP calls through transport, e.g. to record them
+// for debug traces
+func (p *BlockchainDataProvider) SetTransport(transport http.RoundTripper) {
+	p.httpClient.Transport = transport
+}
+
+// RequestChain returns the chain ID a request to the provider is for, read from
+// chain-scoped paths such as /<chain>/address/<address>/balances_v2/. Requests that
+// are not for one chain return "".
+func (p *BlockchainDataProvider) RequestChain(req *http.Request) string {
+	base, err := url.Parse(p.baseURL)
+	if err != nil {
+		return ""
+	}
+	rest := strings.TrimPrefix(req.URL.Path, strings.TrimSuffix(base.Path, "/"))
+	segments := strings.Split(strings.Trim(rest, "/"), "/")
+	if len(segments) < 2 || segments[1] != "address" {
+		return ""
+	}
+	return segments[0]
+}
+
 // GetBlockchainSummary fetches comprehensive blockchain data
 func (p *BlockchainDataProvider) GetBlockchainSummary(ctx context.Context, address string, chainID string) (*BlockchainSummary, error) {
 	logger.Info("Fetching blockchain summary",
@@ -112,27 +164,31 @@ func (p *BlockchainDataProvider) fetchFromCovalent(ctx context.Context, address,
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+p.apiKey)
-	req.SetBasicAuth(p.apiKey, "")
+	apiKey := p.apiKey.Get()
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.SetBasicAuth(apiKey, "")
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch from Covalent: %w", err)
+		return nil, errors.ProviderUnavailable("failed to fetch from Covalent: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Covalent API returned status %d", resp.StatusCode)
+		return nil, statusError(resp.StatusCode, fmt.Errorf("Covalent API returned status %d", resp.StatusCode))
 	}
 
 	var result struct {
 		Data struct {
 			Address string `json:"address"`
 			Items   []struct {
-				ContractName   string  `json:"contract_name"`
-				ContractTicker string  `json:"contract_ticker_symbol"`
-				Balance        string  `json:"balance"`
-				Quote          float64 `json:"quote"`
+				ContractName    string   `json:"contract_name"`
+				ContractTicker  string   `json:"contract_ticker_symbol"`
+				ContractAddress string   `json:"contract_address"`
+				Balance         string   `json:"balance"`
+				Quote           float64  `json:"quote"`
+				QuoteRate       *float64 `json:"quote_rate"`
+				IsSpam          bool     `json:"is_spam"`
 			} `json:"items"`
 		} `json:"data"`
 	}
@@ -141,11 +197,24 @@ func (p *BlockchainDataProvider) fetchFromCovalent(ctx context.Context, address,
 		return nil, err
 	}
 
-	// Build summary
+	// Build summary, leaving spam and illiquid tokens out of the valuation
+	filter := tokenFilterOrDefault(p.tokenFilter)
 	tokenBalances := make(map[string]float64)
 	totalValue := 0.0
+	excluded := 0
 
 	for _, item := range result.Data.Items {
+		if !filter.IsLegitimate(TokenInfo{
+			ContractAddress: item.ContractAddress,
+			Symbol:          item.ContractTicker,
+			Name:            item.ContractName,
+			HasPrice:        item.QuoteRate != nil && *item.QuoteRate > 0,
+			PriceKnown:      true,
+			ProviderSpam:    item.IsSpam,
+		}) {
+			excluded++
+			continue
+		}
 		tokenBalances[item.ContractTicker] = item.Quote
 		totalValue += item.Quote
 	}
@@ -154,6 +223,7 @@ func (p *BlockchainDataProvider) fetchFromCovalent(ctx context.Context, address,
 		Address:             address,
 		TokenBalances:       tokenBalances,
 		TotalPortfolioValue: totalValue,
+		SpamTokensExcluded:  excluded,
 		LastUpdated:         time.Now(),
 	}, nil
 }
@@ -168,43 +238,150 @@ func (p *BlockchainDataProvider) fetchFromMoralis(ctx context.Context, address,
 		return nil, err
 	}
 
-	req.Header.Set("X-API-Key", p.apiKey)
+	req.Header.Set("X-API-Key", p.apiKey.Get())
 	q := req.URL.Query()
 	q.Add("chain", chainID)
 	req.URL.RawQuery = q.Encode()
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch from Moralis: %w", err)
+		return nil, errors.ProviderUnavailable("failed to fetch from Moralis: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Moralis API returned status %d", resp.StatusCode)
+		return nil, statusError(resp.StatusCode, fmt.Errorf("Moralis API returned status %d", resp.StatusCode))
 	}
 
 	var tokens []struct {
-		Symbol  string `json:"symbol"`
-		Balance string `json:"balance"`
+		TokenAddress string `json:"token_address"`
+		Symbol       string `json:"symbol"`
+		Name         string `json:"name"`
+		Decimals     int    `json:"decimals"`
+		Balance      string `json:"balance"`
+		PossibleSpam bool   `json:"possible_spam"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
 		return nil, err
 	}
 
+	filter := tokenFilterOrDefault(p.tokenFilter)
 	tokenBalances := make(map[string]float64)
+	excluded := 0
 	for _, token := range tokens {
-		// Would need to convert balance from wei and get USD value
-		tokenBalances[token.Symbol] = 0.0
+		if !filter.IsLegitimate(TokenInfo{
+			ContractAddress: token.TokenAddress,
+			Symbol:          token.Symbol,
+			Name:            token.Name,
+			ProviderSpam:    token.PossibleSpam,
+		}) {
+			excluded++
+			continue
+		}
+		// Balances are in whole tokens, each with its own decimals; Moralis doesn't
+		// price them here
+		decimals := token.Decimals
+		if decimals <= 0 || decimals >= maxQuantityDigits {
+			decimals = 18
+		}
+		balance, err := ParseTokenAmount(token.Balance, decimals)
+		if err != nil {
+			logger.Warn("Invalid token balance from Moralis",
+				zap.String("token", token.TokenAddress),
+				zap.Error(err),
+			)
+		}
+		tokenBalances[token.Symbol] = balance.Float64()
 	}
 
 	return &BlockchainSummary{
-		Address:       address,
-		TokenBalances: tokenBalances,
-		LastUpdated:   time.Now(),
+		Address:            address,
+		TokenBalances:      tokenBalances,
+		SpamTokensExcluded: excluded,
+		LastUpdated:        time.Now(),
 	}, nil
 }
 
+// SupportsHistoricalBalances reports whether the provider can serve balance history
+func (p *BlockchainDataProvider) SupportsHistoricalBalances() bool {
+	return p.provider == "covalent" && p.apiKey.Get() != ""
+}
+
+// GetHistoricalBalances returns monthly portfolio values (USD) for the past number of months,
+// oldest first, using the Covalent historical portfolio API
+func (p *BlockchainDataProvider) GetHistoricalBalances(ctx context.Context, address, chainID string, months int) ([]BalanceSample, error) {
+	if !p.SupportsHistoricalBalances() {
+		return nil, fmt.Errorf("historical balances not supported by provider %s", p.provider)
+	}
+
+	url := fmt.Sprintf("%s/%s/address/%s/portfolio_v2/?days=%d", p.baseURL, chainID, address, months*30)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(p.apiKey.Get(), "")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.ProviderUnavailable("failed to fetch historical balances: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(resp.StatusCode, fmt.Errorf("Covalent API returned status %d", resp.StatusCode))
+	}
+
+	var result struct {
+		Data struct {
+			Items []struct {
+				ContractName    string `json:"contract_name"`
+				ContractTicker  string `json:"contract_ticker_symbol"`
+				ContractAddress string `json:"contract_address"`
+				Holdings        []struct {
+					Timestamp time.Time `json:"timestamp"`
+					Close     struct {
+						Quote float64 `json:"quote"`
+					} `json:"close"`
+				} `json:"holdings"`
+			} `json:"items"`
+		} `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	// Sum daily holdings across legitimate tokens
+	filter := tokenFilterOrDefault(p.tokenFilter)
+	daily := make(map[time.Time]float64)
+	for _, item := range result.Data.Items {
+		if !filter.IsLegitimate(TokenInfo{
+			ContractAddress: item.ContractAddress,
+			Symbol:          item.ContractTicker,
+			Name:            item.ContractName,
+		}) {
+			continue
+		}
+		for _, h := range item.Holdings {
+			daily[h.Timestamp.UTC().Truncate(24*time.Hour)] += h.Close.Quote
+		}
+	}
+
+	// Keep one sample per 30-day step back from today
+	now := time.Now().UTC().Truncate(24 * time.Hour)
+	samples := make([]BalanceSample, 0, months+1)
+	for m := months; m >= 0; m-- {
+		day := now.AddDate(0, 0, -30*m)
+		if value, ok := daily[day]; ok {
+			samples = append(samples, BalanceSample{Timestamp: day, Value: value})
+		}
+	}
+
+	return samples, nil
+}
+
 // GetDeFiActivities fetches DeFi protocol interactions
 func (p *BlockchainDataProvider) GetDeFiActivities(ctx context.Context, address string, protocols []string) ([]DeFiActivity, error) {
 	// This would query The Graph subgraphs for specific protocols
@@ -284,7 +461,48 @@ func (p *BlockchainDataProvider) MockBlockchainData(address string) *BlockchainS
 	}
 }
 
-// HealthCheck verifies API connectivity
+// HealthCheck verifies API connectivity and the API key
 func (p *BlockchainDataProvider) HealthCheck(ctx context.Context) error {
+	// Only Covalent is checked, and only with an API key
+	apiKey := p.apiKey.Get()
+	if p.provider != "covalent" || apiKey == "" {
+		return nil
+	}
+	return p.healthCheck(ctx, apiKey)
+}
+
+// RotateAPIKey switches to a new Covalent API key once a health check made with it
+// succeeds
+func (p *BlockchainDataProvider) RotateAPIKey(ctx context.Context, apiKey string) error {
+	if p.provider != "covalent" {
+		return errors.Validation("%s does not use an API key", p.provider)
+	}
+	if err := p.healthCheck(ctx, apiKey); err != nil {
+		return err
+	}
+	p.apiKey.Set(apiKey)
+	return nil
+}
+
+func (p *BlockchainDataProvider) healthCheck(ctx context.Context, apiKey string) error {
+	url := fmt.Sprintf("%s/chains/status/", p.baseURL)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	req.SetBasicAuth(apiKey, "")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return errors.ProviderUnavailable("Covalent health check failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return statusError(resp.StatusCode, fmt.Errorf("Covalent API returned status %d", resp.StatusCode))
+	}
+
 	return nil
 }
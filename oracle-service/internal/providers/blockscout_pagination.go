@@ -0,0 +1,80 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Blockscout account lists are paginated. Full histories are fetched a page at a time
+// until a page comes back short, capped so a very active address can't turn one
+// score update into hundreds of requests.
+const (
+	blockscoutPageSize = 100
+	blockscoutMaxPages = 10
+)
+
+// fetchAllPages calls fetch for consecutive pages until a page comes back short or
+// blockscoutMaxPages have been fetched. Pages fetched before an error are returned
+// with it.
+func fetchAllPages[T any](ctx context.Context, fetch func(ctx context.Context, page, offset int) ([]T, error)) ([]T, error) {
+	var all []T
+	for page := 1; page <= blockscoutMaxPages; page++ {
+		items, err := fetch(ctx, page, blockscoutPageSize)
+		if err != nil {
+			return all, fmt.Errorf("failed to fetch page %d: %w", page, err)
+		}
+		all = append(all, items...)
+		if len(items) < blockscoutPageSize {
+			break
+		}
+	}
+	return all, nil
+}
+
+// dedupe drops items with the same key as an earlier item, keeping the order
+func dedupe[T any](items []T, key func(T) string) []T {
+	seen := make(map[string]bool, len(items))
+	unique := make([]T, 0, len(items))
+	for _, item := range items {
+		k := key(item)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		unique = append(unique, item)
+	}
+	return unique
+}
+
+// key identifies an internal transaction. One transaction can make several calls, so
+// the parent hash alone is not enough.
+func (tx BlockscoutInternalTx) key() string {
+	return strings.ToLower(strings.Join([]string{tx.TransactionHash, tx.From, tx.To, tx.Value, tx.Type}, "|"))
+}
+
+// key identifies a token transfer by its log, or by its contents when Blockscout
+// doesn't report the log index
+func (t BlockscoutTokenTransfer) key() string {
+	if t.LogIndex != "" {
+		return strings.ToLower(t.Hash + "|" + t.LogIndex)
+	}
+	return strings.ToLower(strings.Join([]string{t.Hash, t.ContractAddress, t.From, t.To, t.Value}, "|"))
+}
+
+// excludeListedTransactions drops internal transactions made by one of txs. Those are
+// calls the address itself triggered and are already counted as its transactions.
+func excludeListedTransactions(internalTxs []BlockscoutInternalTx, txs []BlockscoutTransaction) []BlockscoutInternalTx {
+	listed := make(map[string]bool, len(txs))
+	for _, tx := range txs {
+		listed[strings.ToLower(tx.Hash)] = true
+	}
+
+	remaining := make([]BlockscoutInternalTx, 0, len(internalTxs))
+	for _, internalTx := range internalTxs {
+		if !listed[strings.ToLower(internalTx.TransactionHash)] {
+			remaining = append(remaining, internalTx)
+		}
+	}
+	return remaining
+}
@@ -0,0 +1,120 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// newPagedBlockscout serves internalCount internal transactions and transferCount token
+// transfers in pages. Each page repeats the last item of the previous page, as
+// happens when a new transaction arrives between requests. failPage, if set, fails.
+func newPagedBlockscout(internalCount, transferCount, failPage int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		page, _ := strconv.Atoi(query.Get("page"))
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		if page == failPage {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		var total int
+		var item func(i int) string
+		switch query.Get("action") {
+		case "txlist":
+			fmt.Fprint(w, `{"status":"1","message":"OK","result":[{"hash":"0xaaa","timestamp":"1709294400","value":"0"}]}`)
+			return
+		case "txlistinternal":
+			total = internalCount
+			item = func(i int) string {
+				hash := fmt.Sprintf("0x%x", i)
+				if i == 0 {
+					hash = "0xAAA" // Made by a listed transaction
+				}
+				return fmt.Sprintf(`{"transaction_hash":"%s","from":"0xc","to":"0xabc","value":"1","type":"call"}`, hash)
+			}
+		case "tokentx":
+			total = transferCount
+			item = func(i int) string {
+				return fmt.Sprintf(`{"hash":"0x%x","logIndex":"%d","from":"0xc","to":"0xabc","value":"1","tokenDecimal":"6"}`, i/2, i%2)
+			}
+		default:
+			fmt.Fprint(w, `{"status":"1","message":"OK","result":[]}`)
+			return
+		}
+
+		start := (page-1)*offset - (page - 1) // Overlap the previous page by one
+		var items []string
+		for i := start; i < total && len(items) < offset; i++ {
+			items = append(items, item(i))
+		}
+		if len(items) == 0 {
+			fmt.Fprint(w, `{"status":"0","message":"No transactions found","result":[]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"1","message":"OK","result":[%s]}`, strings.Join(items, ","))
+	}))
+}
+
+func TestGetAllInternalTransactions(t *testing.T) {
+	server := newPagedBlockscout(250, 0, 0)
+	defer server.Close()
+
+	internalTxs, err := NewBlockscoutProvider(server.URL, "ethereum").GetAllInternalTransactions(context.Background(), "0xabc")
+	if err != nil {
+		t.Fatalf("Failed to fetch internal transactions: %v", err)
+	}
+	if len(internalTxs) != 250 {
+		t.Errorf("Expected 250 unique internal transactions across pages, got %d", len(internalTxs))
+	}
+}
+
+func TestGetAllInternalTransactionsReturnsPagesBeforeError(t *testing.T) {
+	server := newPagedBlockscout(250, 0, 2)
+	defer server.Close()
+
+	internalTxs, err := NewBlockscoutProvider(server.URL, "ethereum").GetAllInternalTransactions(context.Background(), "0xabc")
+	if err == nil {
+		t.Fatal("Expected the failed page to be reported")
+	}
+	if len(internalTxs) != blockscoutPageSize {
+		t.Errorf("Expected the first page, got %d internal transactions", len(internalTxs))
+	}
+}
+
+func TestGetAllTokenTransfersCapsPages(t *testing.T) {
+	server := newPagedBlockscout(0, 5000, 0)
+	defer server.Close()
+
+	transfers, err := NewBlockscoutProvider(server.URL, "ethereum").GetAllTokenTransfers(context.Background(), "0xabc")
+	if err != nil {
+		t.Fatalf("Failed to fetch token transfers: %v", err)
+	}
+	// Ten pages of 100 overlapping by one
+	expected := blockscoutMaxPages*blockscoutPageSize - (blockscoutMaxPages - 1)
+	if len(transfers) != expected {
+		t.Errorf("Expected %d token transfers, got %d", expected, len(transfers))
+	}
+}
+
+func TestAnalyticsCountsTransfersAndInternalTransactions(t *testing.T) {
+	server := newPagedBlockscout(30, 12, 0)
+	defer server.Close()
+
+	analytics, err := NewBlockscoutProvider(server.URL, "ethereum").GetAnalytics(context.Background(), "0xabc")
+	if err != nil {
+		t.Fatalf("Failed to fetch analytics: %v", err)
+	}
+	// Two transfers share each transaction hash but have their own log index
+	if analytics.TotalTokenTransfers != 12 {
+		t.Errorf("Expected 12 token transfers, got %d", analytics.TotalTokenTransfers)
+	}
+	if analytics.TotalInternalTxs != 29 {
+		t.Errorf("Expected 29 internal transactions besides the listed transaction's, got %d", analytics.TotalInternalTxs)
+	}
+}
@@ -5,19 +5,27 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
+	"sort"
 	"strconv"
 	"time"
 
+	"github.com/yourusername/p2p-lend/oracle-service/internal/chains"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
 	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 )
 
 // BlockscoutProvider integrates with Blockscout API for blockchain data
 type BlockscoutProvider struct {
-	httpClient *http.Client
-	baseURL    string
-	chainName  string // "ethereum", "polygon", "optimism", etc.
+	httpClient  *http.Client
+	baseURL     string
+	chainName   string             // "ethereum", "polygon", "optimism", etc.
+	native      chains.NativeAsset // The chain's coin, from the chain registry
+	tokenFilter *TokenFilter
 }
 
 // BlockscoutAddressInfo represents address information from Blockscout
@@ -45,6 +53,7 @@ type BlockscoutTransaction struct {
 	Status           string    `json:"status"`
 	MethodID         string    `json:"method_id"`
 	FunctionName     string    `json:"function_name"`
+	Input            string    `json:"input"` // Calldata; its first 4 bytes are the method selector
 	ConfirmationTime time.Time `json:"confirmation_time"`
 }
 
@@ -72,10 +81,26 @@ type BlockscoutInternalTx struct {
 	GasUsed         string `json:"gas_used"`
 }
 
+// BlockscoutTokenTransfer represents an ERC20 transfer event (tokentx)
+type BlockscoutTokenTransfer struct {
+	Hash            string `json:"hash"`
+	BlockNumber     string `json:"blockNumber"`
+	TimeStamp       string `json:"timeStamp"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	Value           string `json:"value"`
+	ContractAddress string `json:"contractAddress"`
+	TokenName       string `json:"tokenName"`
+	TokenSymbol     string `json:"tokenSymbol"`
+	TokenDecimal    string `json:"tokenDecimal"`
+	LogIndex        string `json:"logIndex"`
+}
+
 // BlockscoutAnalytics represents aggregated analytics
 type BlockscoutAnalytics struct {
 	Address                string                   `json:"address"`
-	Balance                float64                  `json:"balance_eth"`
+	Balance                float64                  `json:"balance_eth"` // In the chain's native coin
+	NativeSymbol           string                   `json:"native_symbol"`
 	BalanceUSD             float64                  `json:"balance_usd"`
 	FirstTransactionDate   time.Time                `json:"first_transaction_date"`
 	LastTransactionDate    time.Time                `json:"last_transaction_date"`
@@ -87,8 +112,10 @@ type BlockscoutAnalytics struct {
 	AverageTransactionSize float64                  `json:"average_transaction_size"`
 	Tokens                 []BlockscoutTokenBalance `json:"tokens"`
 	NFTCount               int                      `json:"nft_count"`
+	SpamTokenCount         int                      `json:"spam_token_count"` // Held tokens excluded as spam
 	IsContract             bool                     `json:"is_contract"`
-	DeFiInteractionCount   int                      `json:"defi_interaction_count"`
+	DeFiInteractionCount   int                      `json:"defi_interaction_count"` // Successful DeFi activities
+	DeFiActivities         []DeFiActivity           `json:"defi_activities"`
 	UniqueContractsCount   int                      `json:"unique_contracts_count"`
 	LastUpdated            time.Time                `json:"last_updated"`
 }
@@ -101,9 +128,26 @@ func NewBlockscoutProvider(baseURL, chainName string) *BlockscoutProvider {
 		},
 		baseURL:   baseURL,
 		chainName: chainName,
+		native:    chains.Native(chainName),
 	}
 }
 
+// NativeAsset returns the coin of the provider's chain
+func (p *BlockscoutProvider) NativeAsset() chains.NativeAsset {
+	return p.native
+}
+
+// SetTokenFilter sets the filter used to exclude spam tokens from analytics
+func (p *BlockscoutProvider) SetTokenFilter(filter *TokenFilter) {
+	p.tokenFilter = filter
+}
+
+// SetTransport sends the provider's HTTP calls through transport, e.g. to record them
+// for debug traces
+func (p *BlockscoutProvider) SetTransport(transport http.RoundTripper) {
+	p.httpClient.Transport = transport
+}
+
 // GetAddressInfo fetches basic address information
 func (p *BlockscoutProvider) GetAddressInfo(ctx context.Context, address string) (*BlockscoutAddressInfo, error) {
 	url := fmt.Sprintf("%s/api?module=account&action=balance&address=%s", p.baseURL, address)
@@ -115,13 +159,13 @@ func (p *BlockscoutProvider) GetAddressInfo(ctx context.Context, address string)
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch address info: %w", err)
+		return nil, errors.ProviderUnavailable("failed to fetch address info: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Blockscout API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, statusError(resp.StatusCode, fmt.Errorf("Blockscout API returned status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result struct {
@@ -161,13 +205,13 @@ func (p *BlockscoutProvider) GetTransactions(ctx context.Context, address string
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch transactions: %w", err)
+		return nil, errors.ProviderUnavailable("failed to fetch transactions: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Blockscout API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, statusError(resp.StatusCode, fmt.Errorf("Blockscout API returned status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result struct {
@@ -202,13 +246,13 @@ func (p *BlockscoutProvider) GetTokenBalances(ctx context.Context, address strin
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch token balances: %w", err)
+		return nil, errors.ProviderUnavailable("failed to fetch token balances: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Blockscout API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, statusError(resp.StatusCode, fmt.Errorf("Blockscout API returned status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result struct {
@@ -243,12 +287,13 @@ func (p *BlockscoutProvider) GetInternalTransactions(ctx context.Context, addres
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch internal transactions: %w", err)
+		return nil, errors.ProviderUnavailable("failed to fetch internal transactions: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return []BlockscoutInternalTx{}, nil // Return empty on error
+		body, _ := io.ReadAll(resp.Body)
+		return nil, statusError(resp.StatusCode, fmt.Errorf("Blockscout API returned status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result struct {
@@ -258,16 +303,81 @@ func (p *BlockscoutProvider) GetInternalTransactions(ctx context.Context, addres
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return []BlockscoutInternalTx{}, nil
+		return nil, err
 	}
 
 	if result.Status != "1" {
-		return []BlockscoutInternalTx{}, nil
+		if result.Message == "No internal transactions found" || result.Message == "No transactions found" {
+			return []BlockscoutInternalTx{}, nil
+		}
+		return nil, fmt.Errorf("Blockscout API error: %s", result.Message)
 	}
 
 	return result.Result, nil
 }
 
+// GetAllInternalTransactions fetches every page of an address's internal transactions,
+// up to blockscoutMaxPages, dropping duplicates returned on consecutive pages as new
+// transactions shift the page boundaries. Pages fetched before an error are returned
+// with it.
+func (p *BlockscoutProvider) GetAllInternalTransactions(ctx context.Context, address string) ([]BlockscoutInternalTx, error) {
+	internalTxs, err := fetchAllPages(ctx, func(ctx context.Context, page, offset int) ([]BlockscoutInternalTx, error) {
+		return p.GetInternalTransactions(ctx, address, page, offset)
+	})
+	return dedupe(internalTxs, BlockscoutInternalTx.key), err
+}
+
+// GetTokenTransfers fetches ERC20 transfer events for an address
+func (p *BlockscoutProvider) GetTokenTransfers(ctx context.Context, address string, page, offset int) ([]BlockscoutTokenTransfer, error) {
+	url := fmt.Sprintf("%s/api?module=account&action=tokentx&address=%s&page=%d&offset=%d&sort=desc",
+		p.baseURL, address, page, offset)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.ProviderUnavailable("failed to fetch token transfers: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, statusError(resp.StatusCode, fmt.Errorf("Blockscout API returned status %d: %s", resp.StatusCode, string(body)))
+	}
+
+	var result struct {
+		Status  string                    `json:"status"`
+		Message string                    `json:"message"`
+		Result  []BlockscoutTokenTransfer `json:"result"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	if result.Status != "1" {
+		if result.Message == "No token transfers found" || result.Message == "No transactions found" {
+			return []BlockscoutTokenTransfer{}, nil
+		}
+		return nil, fmt.Errorf("Blockscout API error: %s", result.Message)
+	}
+
+	return result.Result, nil
+}
+
+// GetAllTokenTransfers fetches every page of an address's token transfers, up to
+// blockscoutMaxPages, without duplicates. Pages fetched before an error are returned
+// with it.
+func (p *BlockscoutProvider) GetAllTokenTransfers(ctx context.Context, address string) ([]BlockscoutTokenTransfer, error) {
+	transfers, err := fetchAllPages(ctx, func(ctx context.Context, page, offset int) ([]BlockscoutTokenTransfer, error) {
+		return p.GetTokenTransfers(ctx, address, page, offset)
+	})
+	return dedupe(transfers, BlockscoutTokenTransfer.key), err
+}
+
 // GetAnalytics fetches comprehensive analytics for an address
 func (p *BlockscoutProvider) GetAnalytics(ctx context.Context, address string) (*BlockscoutAnalytics, error) {
 	logger.Info("Fetching comprehensive analytics from Blockscout",
@@ -276,25 +386,59 @@ func (p *BlockscoutProvider) GetAnalytics(ctx context.Context, address string) (
 	)
 
 	analytics := &BlockscoutAnalytics{
-		Address:     address,
-		LastUpdated: time.Now(),
+		Address:      address,
+		NativeSymbol: p.native.Symbol,
+		LastUpdated:  time.Now(),
 	}
 
+	// Address info, transactions, token balances, internal transactions and token
+	// transfers are independent requests, so they are made concurrently
+	var addressInfo *BlockscoutAddressInfo
+	var transactions []BlockscoutTransaction
+	var tokens []BlockscoutTokenBalance
+	var internalTxs []BlockscoutInternalTx
+	var transfers []BlockscoutTokenTransfer
+	var infoErr, txErr, tokenErr, internalErr, transferErr error
+
+	var group errgroup.Group
+	group.Go(func() error {
+		addressInfo, infoErr = p.GetAddressInfo(ctx, address)
+		return nil
+	})
+	group.Go(func() error {
+		transactions, txErr = p.GetTransactions(ctx, address, 1, 100)
+		return nil
+	})
+	group.Go(func() error {
+		tokens, tokenErr = p.GetTokenBalances(ctx, address)
+		return nil
+	})
+	group.Go(func() error {
+		internalTxs, internalErr = p.GetAllInternalTransactions(ctx, address)
+		return nil
+	})
+	group.Go(func() error {
+		transfers, transferErr = p.GetAllTokenTransfers(ctx, address)
+		return nil
+	})
+	group.Wait()
+
 	// Get basic address info
-	addressInfo, err := p.GetAddressInfo(ctx, address)
-	if err != nil {
-		logger.Error("Failed to get address info", zap.Error(err))
+	if infoErr != nil {
+		logger.Error("Failed to get address info", zap.Error(infoErr))
 	} else {
-		// Convert balance from wei to ETH
-		balanceWei, _ := strconv.ParseFloat(addressInfo.Balance, 64)
-		analytics.Balance = balanceWei / 1e18
+		// Convert balance from base units to the native coin
+		balance, err := ParseTokenAmount(addressInfo.Balance, p.native.Decimals)
+		if err != nil {
+			logger.Warn("Invalid balance from Blockscout", zap.String("address", address), zap.Error(err))
+		}
+		analytics.Balance = balance.Float64()
 		analytics.IsContract = addressInfo.IsContract
 	}
 
 	// Get transactions (first 100)
-	transactions, err := p.GetTransactions(ctx, address, 1, 100)
-	if err != nil {
-		logger.Error("Failed to get transactions", zap.Error(err))
+	if txErr != nil {
+		logger.Error("Failed to get transactions", zap.Error(txErr))
 	} else {
 		analytics.TotalTransactions = len(transactions)
 
@@ -304,49 +448,74 @@ func (p *BlockscoutProvider) GetAnalytics(ctx context.Context, address string) (
 			firstTx := transactions[len(transactions)-1]
 			lastTx := transactions[0]
 
-			firstTime, _ := strconv.ParseInt(firstTx.TimeStamp, 10, 64)
-			lastTime, _ := strconv.ParseInt(lastTx.TimeStamp, 10, 64)
-
-			analytics.FirstTransactionDate = time.Unix(firstTime, 0)
-			analytics.LastTransactionDate = time.Unix(lastTime, 0)
-			analytics.WalletAgeDays = int(time.Since(analytics.FirstTransactionDate).Hours() / 24)
+			// A transaction with a malformed timestamp leaves its date unset rather
+			// than dating the wallet to 1970
+			if firstTime, err := firstTx.Time(); err == nil {
+				analytics.FirstTransactionDate = firstTime
+				analytics.WalletAgeDays = int(time.Since(firstTime).Hours() / 24)
+			}
+			if lastTime, err := lastTx.Time(); err == nil {
+				analytics.LastTransactionDate = lastTime
+			}
 
-			// Calculate average transaction size and total gas used
-			totalValue := 0.0
-			totalGas := 0.0
+			// Calculate average transaction size and total gas used. Amounts are
+			// summed exactly; wei values routinely exceed float64 precision.
+			totalValue := new(big.Int)
+			totalGas := new(big.Int)
 			contractInteractions := make(map[string]bool)
 
 			for _, tx := range transactions {
-				// Convert value from wei to ETH
-				value, _ := strconv.ParseFloat(tx.Value, 64)
-				totalValue += value / 1e18
+				if value, err := tx.ValueWei(); err == nil {
+					totalValue.Add(totalValue, value)
+				} else {
+					logger.Warn("Skipping invalid transaction value", zap.String("tx", tx.Hash), zap.Error(err))
+				}
 
 				// Track gas used
-				gasUsed, _ := strconv.ParseFloat(tx.GasUsed, 64)
-				totalGas += gasUsed
+				if gasUsed, err := ParseQuantity(tx.GasUsed); err == nil {
+					totalGas.Add(totalGas, gasUsed)
+				}
 
-				// Count DeFi interactions (contract calls with function names)
+				// Track contracts called
 				if tx.To != "" && tx.FunctionName != "" {
 					contractInteractions[tx.To] = true
-					analytics.DeFiInteractionCount++
 				}
 			}
 
+			// Only calls to known DeFi functions count as DeFi activity
+			analytics.DeFiActivities = ClassifyDeFiActivities(transactions, p.native)
+			analytics.DeFiInteractionCount = SuccessfulDeFiActivities(analytics.DeFiActivities)
+
 			if analytics.TotalTransactions > 0 {
-				analytics.AverageTransactionSize = totalValue / float64(analytics.TotalTransactions)
+				analytics.AverageTransactionSize = p.native.FromBaseUnits(totalValue).
+					Div(units.DecimalFromInt(int64(analytics.TotalTransactions))).Float64()
 			}
-			analytics.TotalGasUsed = totalGas
+			analytics.TotalGasUsed = units.DecimalFromBigInt(totalGas).Float64()
 			analytics.UniqueContractsCount = len(contractInteractions)
 		}
 	}
 
 	// Get token balances
-	tokens, err := p.GetTokenBalances(ctx, address)
-	if err != nil {
-		logger.Error("Failed to get token balances", zap.Error(err))
+	if tokenErr != nil {
+		logger.Error("Failed to get token balances", zap.Error(tokenErr))
 	} else {
+		// Drop airdropped spam so it cannot inflate token or NFT counts
+		filter := tokenFilterOrDefault(p.tokenFilter)
+		legitimate := make([]BlockscoutTokenBalance, 0, len(tokens))
+		for _, token := range tokens {
+			if filter.IsLegitimate(TokenInfo{
+				ContractAddress: token.TokenAddress,
+				Symbol:          token.TokenSymbol,
+				Name:            token.TokenName,
+			}) {
+				legitimate = append(legitimate, token)
+			} else {
+				analytics.SpamTokenCount++
+			}
+		}
+		tokens = legitimate
+
 		analytics.Tokens = tokens
-		analytics.TotalTokenTransfers = len(tokens)
 
 		// Count NFTs (ERC-721 and ERC-1155)
 		for _, token := range tokens {
@@ -356,13 +525,18 @@ func (p *BlockscoutProvider) GetAnalytics(ctx context.Context, address string) (
 		}
 	}
 
-	// Get internal transactions
-	internalTxs, err := p.GetInternalTransactions(ctx, address, 1, 100)
-	if err != nil {
-		logger.Error("Failed to get internal transactions", zap.Error(err))
-	} else {
-		analytics.TotalInternalTxs = len(internalTxs)
+	// Internal transactions and token transfers count whatever pages were fetched
+	// before an error. Internal calls made by the address's own transactions are
+	// already counted as transactions.
+	if internalErr != nil {
+		logger.Error("Failed to get internal transactions", zap.Error(internalErr))
+	}
+	analytics.TotalInternalTxs = len(excludeListedTransactions(internalTxs, transactions))
+
+	if transferErr != nil {
+		logger.Error("Failed to get token transfers", zap.Error(transferErr))
 	}
+	analytics.TotalTokenTransfers = len(transfers)
 
 	logger.Info("Blockscout analytics fetched successfully",
 		zap.String("address", address),
@@ -380,18 +554,12 @@ func (p *BlockscoutProvider) ConvertToBlockchainSummary(analytics *BlockscoutAna
 
 	for _, token := range analytics.Tokens {
 		if token.TokenType == "ERC-20" {
-			// Convert token balance based on decimals
-			balance, _ := strconv.ParseFloat(token.Balance, 64)
-			decimals := float64(token.TokenDecimals)
-			if decimals == 0 {
-				decimals = 18 // Default to 18 decimals
-			}
-			tokenBalances[token.TokenSymbol] = balance / (1e18 / decimals)
+			tokenBalances[token.TokenSymbol] = tokenBalance(token)
 		}
 	}
 
-	// Add ETH balance
-	tokenBalances["ETH"] = analytics.Balance
+	// Add the native coin balance
+	tokenBalances[p.native.Symbol] += analytics.Balance
 
 	return &BlockchainSummary{
 		Address:                analytics.Address,
@@ -401,16 +569,72 @@ func (p *BlockscoutProvider) ConvertToBlockchainSummary(analytics *BlockscoutAna
 		TotalTransactions:      analytics.TotalTransactions,
 		TotalVolume:            analytics.AverageTransactionSize * float64(analytics.TotalTransactions),
 		AverageTransactionSize: analytics.AverageTransactionSize,
-		DeFiActivities:         []DeFiActivity{}, // Would need to parse transactions for this
+		DeFiActivities:         defiActivitiesOrEmpty(analytics.DeFiActivities),
 		LendingPositions:       []LendingPosition{},
 		LiquidationEvents:      []LiquidationEvent{},
 		NFTHoldings:            analytics.NFTCount,
 		TokenBalances:          tokenBalances,
 		TotalPortfolioValue:    analytics.BalanceUSD,
+		SpamTokensExcluded:     analytics.SpamTokenCount,
 		LastUpdated:            analytics.LastUpdated,
 	}
 }
 
+// BlockscoutNetworkStats holds chain-wide market and usage statistics
+type BlockscoutNetworkStats struct {
+	CoinPriceUSD       float64 `json:"coin_price_usd"`
+	NetworkUtilization float64 `json:"network_utilization_percentage"`
+}
+
+// GetNetworkStats fetches native coin price and network utilization from the v2 stats API
+func (p *BlockscoutProvider) GetNetworkStats(ctx context.Context) (*BlockscoutNetworkStats, error) {
+	url := fmt.Sprintf("%s/api/v2/stats", p.baseURL)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.ProviderUnavailable("failed to fetch network stats: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, statusError(resp.StatusCode, fmt.Errorf("Blockscout API returned status %d: %s", resp.StatusCode, string(body)))
+	}
+
+	var result struct {
+		CoinPrice          *string `json:"coin_price"`
+		NetworkUtilization float64 `json:"network_utilization_percentage"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	stats := &BlockscoutNetworkStats{NetworkUtilization: result.NetworkUtilization}
+	if result.CoinPrice != nil {
+		stats.CoinPriceUSD, _ = strconv.ParseFloat(*result.CoinPrice, 64)
+	}
+
+	return stats, nil
+}
+
+// GetNativeTokenPriceUSD returns the chain's native coin price in USD
+func (p *BlockscoutProvider) GetNativeTokenPriceUSD(ctx context.Context) (float64, error) {
+	stats, err := p.GetNetworkStats(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if stats.CoinPriceUSD <= 0 {
+		return 0, fmt.Errorf("no %s coin price available", p.chainName)
+	}
+	return stats.CoinPriceUSD, nil
+}
+
 // HealthCheck verifies Blockscout API is accessible
 func (p *BlockscoutProvider) HealthCheck(ctx context.Context) error {
 	// Try to get info for a known address (null address)
@@ -423,37 +647,42 @@ func (p *BlockscoutProvider) HealthCheck(ctx context.Context) error {
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("Blockscout health check failed: %w", err)
+		return errors.ProviderUnavailable("Blockscout health check failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Blockscout returned status %d", resp.StatusCode)
+		return statusError(resp.StatusCode, fmt.Errorf("Blockscout returned status %d", resp.StatusCode))
 	}
 
 	return nil
 }
 
-// GetSupportedChains returns list of Blockscout instances for different chains
+// Activity is the wallet's activity on the analytics' chain
+func (a *BlockscoutAnalytics) Activity() ChainActivity {
+	return ChainActivity{
+		FirstTransaction:  a.FirstTransactionDate,
+		WalletAge:         a.WalletAgeDays,
+		TotalTransactions: a.TotalTransactions,
+	}
+}
+
+// GetSupportedBlockscoutChains returns the Blockscout instances of the registry's
+// mainnets, by chain name
 func GetSupportedBlockscoutChains() map[string]string {
-	return map[string]string{
-		"ethereum": "https://eth.blockscout.com",
-		"polygon":  "https://polygon.blockscout.com",
-		"gnosis":   "https://gnosis.blockscout.com",
-		"optimism": "https://optimism.blockscout.com",
-		"base":     "https://base.blockscout.com",
-		"arbitrum": "https://arbitrum.blockscout.com",
-		"zksync":   "https://zksync.blockscout.com",
-		"scroll":   "https://scroll.blockscout.com",
-		"celo":     "https://celo.blockscout.com",
-		"moonbeam": "https://moonbeam.blockscout.com",
+	supported := make(map[string]string)
+	for _, chain := range chains.All() {
+		if chain.BlockscoutURL != "" && chain.Name != "sepolia" {
+			supported[chain.Name] = chain.BlockscoutURL
+		}
 	}
+	return supported
 }
 
 // MultiChainAnalytics represents aggregated data from multiple chains
 type MultiChainAnalytics struct {
 	Address           string                          `json:"address"`
-	TotalBalance      float64                         `json:"total_balance_eth_equivalent"`
+	NativeBalances    map[string]float64              `json:"native_balances"` // By coin symbol; coins of different chains aren't added up
 	TotalBalanceUSD   float64                         `json:"total_balance_usd"`
 	ChainData         map[string]*BlockscoutAnalytics `json:"chain_data"`
 	TotalTransactions int                             `json:"total_transactions"`
@@ -463,6 +692,7 @@ type MultiChainAnalytics struct {
 	LastTransaction   time.Time                       `json:"last_transaction_date"`
 	TotalDeFiInteract int                             `json:"total_defi_interactions"`
 	TotalNFTs         int                             `json:"total_nfts"`
+	TotalSpamTokens   int                             `json:"total_spam_tokens"`
 	TotalGasUsed      float64                         `json:"total_gas_used"`
 	UniqueContracts   int                             `json:"unique_contracts"`
 	ActiveChains      []string                        `json:"active_chains"`
@@ -470,7 +700,7 @@ type MultiChainAnalytics struct {
 }
 
 // GetMultiChainAnalytics fetches and aggregates data from multiple chains
-func GetMultiChainAnalytics(ctx context.Context, address string, chains []string) (*MultiChainAnalytics, error) {
+func GetMultiChainAnalytics(ctx context.Context, address string, chains []string, tokenFilter *TokenFilter) (*MultiChainAnalytics, error) {
 	logger.Info("Fetching multi-chain analytics",
 		zap.String("address", address),
 		zap.Strings("chains", chains),
@@ -486,10 +716,11 @@ func GetMultiChainAnalytics(ctx context.Context, address string, chains []string
 	}
 
 	result := &MultiChainAnalytics{
-		Address:      address,
-		ChainData:    make(map[string]*BlockscoutAnalytics),
-		ActiveChains: []string{},
-		LastUpdated:  time.Now(),
+		Address:        address,
+		NativeBalances: make(map[string]float64),
+		ChainData:      make(map[string]*BlockscoutAnalytics),
+		ActiveChains:   []string{},
+		LastUpdated:    time.Now(),
 	}
 
 	// Channel to collect results from parallel fetches
@@ -510,6 +741,7 @@ func GetMultiChainAnalytics(ctx context.Context, address string, chains []string
 
 		go func(chainName, url string) {
 			provider := NewBlockscoutProvider(url, chainName)
+			provider.SetTokenFilter(tokenFilter)
 			analytics, err := provider.GetAnalytics(ctx, address)
 			resultsChan <- chainResult{
 				chain:     chainName,
@@ -540,10 +772,11 @@ func GetMultiChainAnalytics(ctx context.Context, address string, chains []string
 
 				// Aggregate statistics
 				result.TotalTransactions += res.analytics.TotalTransactions
-				result.TotalBalance += res.analytics.Balance
+				result.NativeBalances[res.analytics.NativeSymbol] += res.analytics.Balance
 				result.TotalBalanceUSD += res.analytics.BalanceUSD
 				result.TotalDeFiInteract += res.analytics.DeFiInteractionCount
 				result.TotalNFTs += res.analytics.NFTCount
+				result.TotalSpamTokens += res.analytics.SpamTokenCount
 				result.TotalGasUsed += res.analytics.TotalGasUsed
 				result.UniqueContracts += res.analytics.UniqueContractsCount
 
@@ -581,29 +814,33 @@ func GetMultiChainAnalytics(ctx context.Context, address string, chains []string
 
 // ConvertMultiChainToBlockchainSummary converts multi-chain analytics to BlockchainSummary
 func ConvertMultiChainToBlockchainSummary(analytics *MultiChainAnalytics) *BlockchainSummary {
-	// Aggregate all token balances across chains
+	// Aggregate all token balances and DeFi activities across chains
 	tokenBalances := make(map[string]float64)
+	defiActivities := []DeFiActivity{}
+	chains := make(map[string]ChainActivity, len(analytics.ChainData))
 
 	for chain, chainData := range analytics.ChainData {
-		// Add native token with chain prefix
-		nativeSymbol := getNativeTokenSymbol(chain)
-		tokenBalances[nativeSymbol] += chainData.Balance
+		defiActivities = append(defiActivities, chainData.DeFiActivities...)
+		chains[chain] = chainData.Activity()
+
+		// Add the chain's native coin; chains sharing a coin add up
+		tokenBalances[chainData.NativeSymbol] += chainData.Balance
 
 		// Add ERC20 tokens
 		for _, token := range chainData.Tokens {
 			if token.TokenType == "ERC-20" {
-				balance, _ := strconv.ParseFloat(token.Balance, 64)
-				decimals := float64(token.TokenDecimals)
-				if decimals == 0 {
-					decimals = 18
-				}
 				// Use token symbol with chain prefix to avoid conflicts
 				tokenKey := fmt.Sprintf("%s-%s", chain, token.TokenSymbol)
-				tokenBalances[tokenKey] = balance / (1e18 / decimals)
+				tokenBalances[tokenKey] = tokenBalance(token)
 			}
 		}
 	}
 
+	// Newest first, as each chain lists them
+	sort.SliceStable(defiActivities, func(i, j int) bool {
+		return defiActivities[i].Timestamp.After(defiActivities[j].Timestamp)
+	})
+
 	return &BlockchainSummary{
 		Address:                analytics.Address,
 		WalletAge:              analytics.OldestWalletAge,
@@ -612,34 +849,43 @@ func ConvertMultiChainToBlockchainSummary(analytics *MultiChainAnalytics) *Block
 		TotalTransactions:      analytics.TotalTransactions,
 		TotalVolume:            analytics.TotalBalanceUSD,
 		AverageTransactionSize: analytics.TotalBalanceUSD / float64(max(analytics.TotalTransactions, 1)),
-		DeFiActivities:         []DeFiActivity{},
+		DeFiActivities:         defiActivities,
 		LendingPositions:       []LendingPosition{},
 		LiquidationEvents:      []LiquidationEvent{},
 		NFTHoldings:            analytics.TotalNFTs,
 		TokenBalances:          tokenBalances,
 		TotalPortfolioValue:    analytics.TotalBalanceUSD,
+		SpamTokensExcluded:     analytics.TotalSpamTokens,
+		Chains:                 chains,
 		LastUpdated:            analytics.LastUpdated,
 	}
 }
 
-// getNativeTokenSymbol returns the native token symbol for a chain
-func getNativeTokenSymbol(chain string) string {
-	nativeTokens := map[string]string{
-		"ethereum": "ETH",
-		"polygon":  "MATIC",
-		"arbitrum": "ETH",
-		"optimism": "ETH",
-		"base":     "ETH",
-		"gnosis":   "xDAI",
-		"zksync":   "ETH",
-		"scroll":   "ETH",
-		"celo":     "CELO",
-		"moonbeam": "GLMR",
+// defiActivitiesOrEmpty returns activities, or an empty list so the summary encodes
+// as [] rather than null
+func defiActivitiesOrEmpty(activities []DeFiActivity) []DeFiActivity {
+	if activities == nil {
+		return []DeFiActivity{}
 	}
-	if symbol, ok := nativeTokens[chain]; ok {
-		return symbol
+	return activities
+}
+
+// tokenBalance converts an ERC-20 balance from base units into whole tokens. Tokens
+// without decimals are assumed to use 18; malformed balances count as zero.
+func tokenBalance(token BlockscoutTokenBalance) float64 {
+	decimals := token.TokenDecimals
+	if decimals <= 0 || decimals >= maxQuantityDigits {
+		decimals = 18
+	}
+	balance, err := ParseTokenAmount(token.Balance, decimals)
+	if err != nil {
+		logger.Warn("Invalid token balance from Blockscout",
+			zap.String("token", token.TokenAddress),
+			zap.Error(err),
+		)
+		return 0
 	}
-	return "NATIVE"
+	return balance.Float64()
 }
 
 // Helper function to get max of two integers
@@ -658,6 +904,7 @@ func (p *BlockscoutProvider) MockBlockscoutData(address string) *BlockscoutAnaly
 	return &BlockscoutAnalytics{
 		Address:                address,
 		Balance:                2.5,
+		NativeSymbol:           p.native.Symbol,
 		BalanceUSD:             5000.00,
 		FirstTransactionDate:   firstTx,
 		LastTransactionDate:    now.AddDate(0, 0, -2),
@@ -0,0 +1,40 @@
+package providers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+)
+
+// Credential is a provider API key or secret that can be replaced at runtime. Calls
+// already sent keep the value they were sent with.
+type Credential struct {
+	mu    sync.RWMutex
+	value string
+}
+
+// NewCredential holds value
+func NewCredential(value string) *Credential {
+	return &Credential{value: value}
+}
+
+// Get returns the current value
+func (c *Credential) Get() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.value
+}
+
+// Set replaces the value for every later call
+func (c *Credential) Set(value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.value = value
+}
+
+// Fingerprint identifies a credential in logs without revealing it: the first 8 hex
+// characters of its SHA-256 hash
+func Fingerprint(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:4])
+}
@@ -0,0 +1,64 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+)
+
+func TestRotateAPIKey(t *testing.T) {
+	down := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case down:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.Header.Get("Authorization") == "Bearer new-key":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewCreditBureauProvider("experian", "us", server.URL, "old-key")
+	ctx := context.Background()
+
+	// A rejected key is not used
+	err := provider.RotateAPIKey(ctx, "wrong-key")
+	if err == nil {
+		t.Fatal("Expected the rejected key to fail validation")
+	}
+	if errors.KindOf(err) != nil {
+		t.Errorf("Expected a rejection to be unclassified, got %v", errors.KindOf(err))
+	}
+	if provider.APIKey().Get() != "old-key" {
+		t.Errorf("Expected the old key kept, got %q", provider.APIKey().Get())
+	}
+
+	// An unreachable provider says nothing about the key
+	down = true
+	if err := provider.RotateAPIKey(ctx, "new-key"); !errors.Is(err, errors.ErrProviderUnavailable) {
+		t.Errorf("Expected provider unavailable, got %v", err)
+	}
+	down = false
+
+	if err := provider.RotateAPIKey(ctx, "new-key"); err != nil {
+		t.Fatalf("Failed to rotate key: %v", err)
+	}
+	if provider.APIKey().Get() != "new-key" {
+		t.Errorf("Expected the new key in use, got %q", provider.APIKey().Get())
+	}
+	if err := provider.HealthCheck(ctx); err != nil {
+		t.Errorf("Expected health check with the new key to pass: %v", err)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	// First 8 hex characters of sha256("secret")
+	if got := Fingerprint("secret"); got != "2bb80d53" {
+		t.Errorf("Fingerprint = %q, want 2bb80d53", got)
+	}
+}
@@ -0,0 +1,118 @@
+package providers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+// BureauAlertEvent is a monitoring alert pushed by a credit bureau
+type BureauAlertEvent struct {
+	AlertID    string
+	ConsumerID string // Bureau's identifier for the consumer
+	AlertType  string // Normalized alert type (models.BureauAlert*)
+	RawType    string
+	OccurredAt time.Time
+}
+
+// Bureaus name the same fields differently; these are the keys accepted for each
+var (
+	alertIDKeys    = []string{"alert_id", "alertId", "event_id", "eventId", "id"}
+	consumerIDKeys = []string{"consumer_id", "consumerId", "user_id", "userId", "subscriber_id", "subscriberId"}
+	alertTypeKeys  = []string{"alert_type", "alertType", "event_type", "eventType", "type"}
+	occurredAtKeys = []string{"occurred_at", "occurredAt", "event_date", "eventDate", "timestamp"}
+)
+
+// alertTypeKeywords maps keywords in a bureau's alert type onto normalized types.
+// Order matters: bankruptcy filings are also public records.
+var alertTypeKeywords = []struct {
+	keyword   string
+	alertType string
+}{
+	{"bankrupt", models.BureauAlertBankruptcy},
+	{"delinq", models.BureauAlertDelinquency},
+	{"late", models.BureauAlertDelinquency},
+	{"past_due", models.BureauAlertDelinquency},
+	{"collection", models.BureauAlertCollection},
+	{"public_record", models.BureauAlertPublicRecord},
+	{"lien", models.BureauAlertPublicRecord},
+	{"judgment", models.BureauAlertPublicRecord},
+	{"inquiry", models.BureauAlertInquiry},
+	{"new_account", models.BureauAlertNewAccount},
+	{"new_tradeline", models.BureauAlertNewAccount},
+}
+
+// ParseBureauAlert extracts an alert from a bureau webhook payload. Fields may be at
+// the top level or nested under "alert", "event", or "data".
+func ParseBureauAlert(body []byte) (*BureauAlertEvent, error) {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("invalid alert payload: %w", err)
+	}
+
+	for _, key := range []string{"alert", "event", "data"} {
+		if nested, ok := payload[key].(map[string]interface{}); ok {
+			payload = nested
+			break
+		}
+	}
+
+	event := &BureauAlertEvent{
+		AlertID:    firstString(payload, alertIDKeys),
+		ConsumerID: firstString(payload, consumerIDKeys),
+		RawType:    firstString(payload, alertTypeKeys),
+		OccurredAt: time.Now(),
+	}
+	if event.AlertID == "" {
+		return nil, fmt.Errorf("alert payload missing alert id")
+	}
+	if event.ConsumerID == "" {
+		return nil, fmt.Errorf("alert payload missing consumer id")
+	}
+
+	event.AlertType = NormalizeBureauAlertType(event.RawType)
+
+	if occurred := firstString(payload, occurredAtKeys); occurred != "" {
+		if t, err := time.Parse(time.RFC3339, occurred); err == nil {
+			event.OccurredAt = t
+		}
+	}
+
+	return event, nil
+}
+
+// NormalizeBureauAlertType maps a bureau-specific alert type onto a models.BureauAlert* type
+func NormalizeBureauAlertType(rawType string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "_", " ", "_").Replace(rawType))
+	for _, kw := range alertTypeKeywords {
+		if strings.Contains(normalized, kw.keyword) {
+			return kw.alertType
+		}
+	}
+	return models.BureauAlertOther
+}
+
+// HashConsumerID hashes a bureau consumer identifier so raw IDs are never stored
+func HashConsumerID(provider, consumerID string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(provider) + ":" + strings.TrimSpace(consumerID)))
+	return hex.EncodeToString(sum[:])
+}
+
+func firstString(payload map[string]interface{}, keys []string) string {
+	for _, key := range keys {
+		switch v := payload[key].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case float64:
+			return fmt.Sprintf("%.0f", v)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+func TestParseBureauAlert(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		alertID    string
+		consumerID string
+		alertType  string
+		wantErr    bool
+	}{
+		{"Top-level fields", `{"alert_id":"a1","consumer_id":"c1","alert_type":"NEW_DELINQUENCY"}`, "a1", "c1", models.BureauAlertDelinquency, false},
+		{"Nested camelCase fields", `{"event":{"eventId":"a2","subscriberId":"c2","eventType":"Bankruptcy Filed"}}`, "a2", "c2", models.BureauAlertBankruptcy, false},
+		{"Numeric consumer id", `{"id":"a3","user_id":12345,"type":"collection-account"}`, "a3", "12345", models.BureauAlertCollection, false},
+		{"Unknown type", `{"id":"a4","consumer_id":"c4","type":"address_change"}`, "a4", "c4", models.BureauAlertOther, false},
+		{"Missing consumer", `{"id":"a5","type":"late_payment"}`, "", "", "", true},
+		{"Invalid JSON", `not json`, "", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := ParseBureauAlert([]byte(tt.body))
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if event.AlertID != tt.alertID || event.ConsumerID != tt.consumerID || event.AlertType != tt.alertType {
+				t.Errorf("Got %+v, want id=%s consumer=%s type=%s", event, tt.alertID, tt.consumerID, tt.alertType)
+			}
+		})
+	}
+}
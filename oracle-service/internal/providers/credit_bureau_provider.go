@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
 	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -15,44 +17,63 @@ import (
 // CreditBureauProvider integrates with credit bureau APIs (Experian, Equifax, TransUnion)
 type CreditBureauProvider struct {
 	httpClient *http.Client
-	apiKey     string
+	apiKey     *Credential
 	baseURL    string
 	provider   string // "experian", "equifax", "transunion"
+	region     string // "us", "uk", "ca", etc.
 }
 
 // CreditBureauResponse represents the standardized response from credit bureaus
 type CreditBureauResponse struct {
-	UserID            string    `json:"user_id"`
-	CreditScore       int       `json:"credit_score"`
-	ScoreRange        string    `json:"score_range"` // "300-850"
-	DebtToIncomeRatio float64   `json:"debt_to_income_ratio"`
-	TotalDebt         float64   `json:"total_debt"`
-	TotalIncome       float64   `json:"total_income"`
-	PaymentHistory    string    `json:"payment_history"`    // "excellent", "good", "fair", "poor"
-	CreditUtilization float64   `json:"credit_utilization"` // Percentage
-	NumberOfAccounts  int       `json:"number_of_accounts"`
-	OldestAccountAge  int       `json:"oldest_account_age"` // Months
-	RecentInquiries   int       `json:"recent_inquiries"`   // Last 6 months
-	Delinquencies     int       `json:"delinquencies"`
-	PublicRecords     int       `json:"public_records"` // Bankruptcies, liens, etc.
-	EmploymentStatus  string    `json:"employment_status"`
-	EmploymentLength  int       `json:"employment_length"` // Months
-	LastUpdated       time.Time `json:"last_updated"`
-	DataSource        string    `json:"data_source"`
+	UserID            string        `json:"user_id"`
+	CreditScore       int           `json:"credit_score"`
+	ScoreRange        string        `json:"score_range"` // "300-850"
+	Region            string        `json:"region"`
+	DebtToIncomeRatio units.Decimal `json:"debt_to_income_ratio"`
+	TotalDebt         units.Decimal `json:"total_debt"`
+	TotalIncome       units.Decimal `json:"total_income"`
+	PaymentHistory    string        `json:"payment_history"`    // "excellent", "good", "fair", "poor"
+	CreditUtilization float64       `json:"credit_utilization"` // Percentage
+	NumberOfAccounts  int           `json:"number_of_accounts"`
+	OldestAccountAge  int           `json:"oldest_account_age"` // Months
+	RecentInquiries   int           `json:"recent_inquiries"`   // Last 6 months
+	Delinquencies     int           `json:"delinquencies"`
+	PublicRecords     int           `json:"public_records"` // Bankruptcies, liens, etc.
+	EmploymentStatus  string        `json:"employment_status"`
+	EmploymentLength  int           `json:"employment_length"` // Months
+	LastUpdated       time.Time     `json:"last_updated"`
+	DataSource        string        `json:"data_source"`
 }
 
 // NewCreditBureauProvider creates a new credit bureau provider
-func NewCreditBureauProvider(provider, baseURL, apiKey string) *CreditBureauProvider {
+func NewCreditBureauProvider(provider, region, baseURL, apiKey string) *CreditBureauProvider {
 	return &CreditBureauProvider{
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		apiKey:   apiKey,
+		apiKey:   NewCredential(apiKey),
 		baseURL:  baseURL,
 		provider: provider,
+		region:   region,
 	}
 }
 
+// SetTransport sends the provider's HTTP calls through transport, e.g. to record them
+// for debug traces
+func (p *CreditBureauProvider) SetTransport(transport http.RoundTripper) {
+	p.httpClient.Transport = transport
+}
+
+// Name returns the bureau identifier (e.g. "experian")
+func (p *CreditBureauProvider) Name() string {
+	return p.provider
+}
+
+// Region returns the bureau region the provider is configured for
+func (p *CreditBureauProvider) Region() string {
+	return p.region
+}
+
 // GetCreditReport fetches credit report for a user
 func (p *CreditBureauProvider) GetCreditReport(ctx context.Context, userID string) (*CreditBureauResponse, error) {
 	logger.Info("Fetching credit report",
@@ -69,21 +90,21 @@ func (p *CreditBureauProvider) GetCreditReport(ctx context.Context, userID strin
 	}
 
 	// Set headers
-	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("Authorization", "Bearer "+p.apiKey.Get())
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
 	// Execute request
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, errors.ProviderUnavailable("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("credit bureau API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, statusError(resp.StatusCode, fmt.Errorf("credit bureau API returned status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	// Parse response
@@ -93,6 +114,9 @@ func (p *CreditBureauProvider) GetCreditReport(ctx context.Context, userID strin
 	}
 
 	creditData.DataSource = p.provider
+	if creditData.Region == "" {
+		creditData.Region = p.region
+	}
 	creditData.LastUpdated = time.Now()
 
 	logger.Info("Credit report fetched successfully",
@@ -113,18 +137,18 @@ func (p *CreditBureauProvider) GetCreditScore(ctx context.Context, userID string
 		return 0, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("Authorization", "Bearer "+p.apiKey.Get())
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return 0, fmt.Errorf("failed to execute request: %w", err)
+		return 0, errors.ProviderUnavailable("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return 0, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return 0, statusError(resp.StatusCode, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result struct {
@@ -139,6 +163,25 @@ func (p *CreditBureauProvider) GetCreditScore(ctx context.Context, userID string
 
 // HealthCheck verifies the credit bureau API is accessible
 func (p *CreditBureauProvider) HealthCheck(ctx context.Context) error {
+	return p.healthCheck(ctx, p.apiKey.Get())
+}
+
+// RotateAPIKey switches to a new API key once a health check made with it succeeds
+func (p *CreditBureauProvider) RotateAPIKey(ctx context.Context, apiKey string) error {
+	if err := p.healthCheck(ctx, apiKey); err != nil {
+		return err
+	}
+	p.apiKey.Set(apiKey)
+	return nil
+}
+
+// APIKey returns the provider's API key, for other clients of the same bureau API to
+// share so a rotated key applies to them too
+func (p *CreditBureauProvider) APIKey() *Credential {
+	return p.apiKey
+}
+
+func (p *CreditBureauProvider) healthCheck(ctx context.Context, apiKey string) error {
 	url := fmt.Sprintf("%s/health", p.baseURL)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -146,16 +189,16 @@ func (p *CreditBureauProvider) HealthCheck(ctx context.Context) error {
 		return err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("health check failed: %w", err)
+		return errors.ProviderUnavailable("health check failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("health check returned status %d", resp.StatusCode)
+		return statusError(resp.StatusCode, fmt.Errorf("health check returned status %d", resp.StatusCode))
 	}
 
 	return nil
@@ -170,9 +213,10 @@ func (p *CreditBureauProvider) MockCreditBureauData(userID string) *CreditBureau
 		UserID:            userID,
 		CreditScore:       score,
 		ScoreRange:        "300-850",
-		DebtToIncomeRatio: 0.35,
-		TotalDebt:         45000,
-		TotalIncome:       85000,
+		Region:            p.region,
+		DebtToIncomeRatio: units.MustDecimal("0.35"),
+		TotalDebt:         units.DecimalFromInt(45000),
+		TotalIncome:       units.DecimalFromInt(85000),
 		PaymentHistory:    "good",
 		CreditUtilization: 0.42,
 		NumberOfAccounts:  8,
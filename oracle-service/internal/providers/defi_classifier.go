@@ -0,0 +1,294 @@
+package providers
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/chains"
+)
+
+// DeFi activity types
+const (
+	ActivityBorrow      = "borrow"
+	ActivityRepay       = "repay"
+	ActivityLend        = "lend" // Deposit or supply into a lending pool
+	ActivityWithdraw    = "withdraw"
+	ActivitySwap        = "swap"
+	ActivityStake       = "stake"
+	ActivityUnstake     = "unstake"
+	ActivityLiquidation = "liquidation" // Liquidating someone else's position
+)
+
+// DeFi activity statuses
+const (
+	ActivitySucceeded = "success"
+	ActivityFailed    = "failed"
+)
+
+// MethodSignature is a known DeFi contract function
+type MethodSignature struct {
+	Signature string // Canonical signature, e.g. "borrow(uint256)"
+	Protocol  string // Empty when the signature is shared by many protocols
+	Activity  string
+}
+
+// defiSignatures are the functions of the major lending, DEX and staking contracts.
+// Selectors are derived from the signatures, so a typo can't silently map the wrong
+// selector.
+var defiSignatures = []MethodSignature{
+	// Aave v2 and v3 pools
+	{"deposit(address,uint256,address,uint16)", "aave", ActivityLend},
+	{"supply(address,uint256,address,uint16)", "aave", ActivityLend},
+	{"borrow(address,uint256,uint256,uint16,address)", "aave", ActivityBorrow},
+	{"repay(address,uint256,uint256,address)", "aave", ActivityRepay},
+	{"repayWithATokens(address,uint256,uint256)", "aave", ActivityRepay},
+	{"withdraw(address,uint256,address)", "aave", ActivityWithdraw},
+	{"liquidationCall(address,address,address,uint256,bool)", "aave", ActivityLiquidation},
+
+	// Compound v2 cTokens and Compound v3 markets. cToken mint() and mint(uint256)
+	// are left out: NFT contracts use the same selectors.
+	{"borrow(uint256)", "compound", ActivityBorrow},
+	{"repayBorrow(uint256)", "compound", ActivityRepay},
+	{"repayBorrow()", "compound", ActivityRepay},
+	{"redeem(uint256)", "compound", ActivityWithdraw},
+	{"redeemUnderlying(uint256)", "compound", ActivityWithdraw},
+	{"liquidateBorrow(address,uint256,address)", "compound", ActivityLiquidation},
+	{"supply(address,uint256)", "compound", ActivityLend},
+	{"withdraw(address,uint256)", "compound", ActivityWithdraw},
+
+	// Liquity troves
+	{"openTrove(uint256,uint256,address,address)", "liquity", ActivityBorrow},
+	{"withdrawLUSD(uint256,uint256,address,address)", "liquity", ActivityBorrow},
+	{"repayLUSD(uint256,address,address)", "liquity", ActivityRepay},
+	{"closeTrove()", "liquity", ActivityRepay},
+
+	// Uniswap v2 and v3 routers, and the universal router
+	{"swapExactTokensForTokens(uint256,uint256,address[],address,uint256)", "uniswap", ActivitySwap},
+	{"swapTokensForExactTokens(uint256,uint256,address[],address,uint256)", "uniswap", ActivitySwap},
+	{"swapExactETHForTokens(uint256,address[],address,uint256)", "uniswap", ActivitySwap},
+	{"swapETHForExactTokens(uint256,address[],address,uint256)", "uniswap", ActivitySwap},
+	{"swapExactTokensForETH(uint256,uint256,address[],address,uint256)", "uniswap", ActivitySwap},
+	{"swapTokensForExactETH(uint256,uint256,address[],address,uint256)", "uniswap", ActivitySwap},
+	{"exactInputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))", "uniswap", ActivitySwap},
+	{"exactInput((bytes,address,uint256,uint256,uint256))", "uniswap", ActivitySwap},
+	{"exactOutputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))", "uniswap", ActivitySwap},
+	{"exactOutput((bytes,address,uint256,uint256,uint256))", "uniswap", ActivitySwap},
+	{"execute(bytes,bytes[],uint256)", "uniswap", ActivitySwap},
+	{"execute(bytes,bytes[])", "uniswap", ActivitySwap},
+
+	// Staking: Lido, the beacon chain deposit contract and common staking pools.
+	// deposit() is left out: it is also how ETH is wrapped into WETH.
+	{"submit(address)", "lido", ActivityStake},
+	{"requestWithdrawals(uint256[],address)", "lido", ActivityUnstake},
+	{"deposit(bytes,bytes,bytes,bytes32)", "beacon-deposit", ActivityStake},
+	{"stake(uint256)", "", ActivityStake},
+	{"unstake(uint256)", "", ActivityUnstake},
+}
+
+// defiFunctionNames classify transactions by function name when Blockscout reports
+// the decoded name but no selector. Names as generic as deposit, withdraw and mint
+// are left out; WETH and NFT contracts use them too.
+var defiFunctionNames = map[string]string{
+	"supply":             ActivityLend,
+	"borrow":             ActivityBorrow,
+	"openTrove":          ActivityBorrow,
+	"repay":              ActivityRepay,
+	"repayBorrow":        ActivityRepay,
+	"repayLUSD":          ActivityRepay,
+	"redeem":             ActivityWithdraw,
+	"redeemUnderlying":   ActivityWithdraw,
+	"liquidationCall":    ActivityLiquidation,
+	"liquidateBorrow":    ActivityLiquidation,
+	"exactInput":         ActivitySwap,
+	"exactInputSingle":   ActivitySwap,
+	"exactOutput":        ActivitySwap,
+	"exactOutputSingle":  ActivitySwap,
+	"swap":               ActivitySwap,
+	"submit":             ActivityStake,
+	"stake":              ActivityStake,
+	"unstake":            ActivityUnstake,
+	"requestWithdrawals": ActivityUnstake,
+}
+
+// positionArg locates the asset and amount of a call that opens or closes a lending
+// position in its calldata. An asset index of -1 means the called contract is the
+// market, as with Compound cTokens; an amount index of -1 means the amount is the
+// native value sent.
+type positionArg struct {
+	asset     int
+	amount    int
+	closesAll bool // Repays every open borrow in the market
+}
+
+var positionArgs = map[string]positionArg{
+	"borrow(address,uint256,uint256,uint16,address)": {asset: 0, amount: 1},
+	"repay(address,uint256,uint256,address)":         {asset: 0, amount: 1},
+	"repayWithATokens(address,uint256,uint256)":      {asset: 0, amount: 1},
+	"deposit(address,uint256,address,uint16)":        {asset: 0, amount: 1},
+	"supply(address,uint256,address,uint16)":         {asset: 0, amount: 1},
+	"withdraw(address,uint256,address)":              {asset: 0, amount: 1},
+	"borrow(uint256)":                                {asset: -1, amount: 0},
+	"repayBorrow(uint256)":                           {asset: -1, amount: 0},
+	"repayBorrow()":                                  {asset: -1, amount: -1},
+	"redeemUnderlying(uint256)":                      {asset: -1, amount: 0},
+	"supply(address,uint256)":                        {asset: 0, amount: 1},
+	"withdraw(address,uint256)":                      {asset: 0, amount: 1},
+	"openTrove(uint256,uint256,address,address)":     {asset: -1, amount: 1},
+	"withdrawLUSD(uint256,uint256,address,address)":  {asset: -1, amount: 1},
+	"repayLUSD(uint256,address,address)":             {asset: -1, amount: 0},
+	"closeTrove()":                                   {asset: -1, amount: -1, closesAll: true},
+}
+
+// maxUint256 is the amount lending pools accept as "repay everything" or "withdraw
+// everything"
+var maxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// defiSelectors maps 0x-prefixed 4-byte selectors to their signature
+var defiSelectors = make(map[string]MethodSignature, len(defiSignatures))
+
+func init() {
+	for _, sig := range defiSignatures {
+		defiSelectors[selector(sig.Signature)] = sig
+	}
+}
+
+// selector returns the 0x-prefixed 4-byte selector of a canonical function signature
+func selector(signature string) string {
+	return "0x" + hex.EncodeToString(crypto.Keccak256([]byte(signature))[:4])
+}
+
+// ClassifyTransaction identifies the DeFi activity of a contract call from its
+// selector, taken from the method ID or the calldata, or failing that from its
+// decoded function name. It reports false for transfers and unknown calls.
+func ClassifyTransaction(tx BlockscoutTransaction) (MethodSignature, bool) {
+	if tx.To == "" {
+		return MethodSignature{}, false // Contract creation
+	}
+
+	methodID := strings.ToLower(tx.MethodID)
+	if methodID == "" && len(tx.Input) >= 10 {
+		methodID = strings.ToLower(tx.Input[:10])
+	}
+	if methodID != "" && !strings.HasPrefix(methodID, "0x") {
+		methodID = "0x" + methodID
+	}
+	if sig, ok := defiSelectors[methodID]; ok {
+		return sig, true
+	}
+
+	name := tx.FunctionName
+	if i := strings.Index(name, "("); i >= 0 {
+		name = name[:i]
+	}
+	name = strings.TrimSpace(name)
+	if activity, ok := defiFunctionNames[name]; ok {
+		return MethodSignature{Signature: tx.FunctionName, Activity: activity}, true
+	}
+	// Routers have many swap variants, e.g. swapExactTokensForETHSupportingFeeOnTransferTokens
+	if strings.HasPrefix(name, "swap") {
+		return MethodSignature{Signature: tx.FunctionName, Activity: ActivitySwap}, true
+	}
+
+	return MethodSignature{}, false
+}
+
+// ClassifyDeFiActivities converts the DeFi calls among txs into activity records.
+// Amounts are the native value sent with the call, in the chain's native coin; token
+// amounts moved by the contract are not part of the transaction.
+func ClassifyDeFiActivities(txs []BlockscoutTransaction, native chains.NativeAsset) []DeFiActivity {
+	activities := []DeFiActivity{}
+	for _, tx := range txs {
+		sig, ok := ClassifyTransaction(tx)
+		if !ok {
+			continue
+		}
+
+		activity := DeFiActivity{
+			Protocol:        sig.Protocol,
+			ActivityType:    sig.Activity,
+			TransactionHash: tx.Hash,
+			Status:          ActivitySucceeded,
+		}
+		if tx.Status == "0" || strings.EqualFold(tx.Status, "error") {
+			activity.Status = ActivityFailed
+		}
+		if timestamp, err := tx.Time(); err == nil {
+			activity.Timestamp = timestamp
+		}
+		value, err := tx.ValueWei()
+		if err == nil && value.Sign() > 0 {
+			activity.Amount = native.FromBaseUnits(value).Float64()
+			activity.TokenSymbol = native.Symbol
+		}
+		if args, ok := positionArgs[sig.Signature]; ok {
+			decodePositionArgs(&activity, tx, args, value)
+		} else if changesPosition(sig.Activity) {
+			activity.Asset = strings.ToLower(tx.To)
+		}
+		activities = append(activities, activity)
+	}
+	return activities
+}
+
+// changesPosition reports whether an activity opens or closes a lending position
+func changesPosition(activity string) bool {
+	switch activity {
+	case ActivityBorrow, ActivityRepay, ActivityLend, ActivityWithdraw:
+		return true
+	}
+	return false
+}
+
+// decodePositionArgs records the market and base-unit amount of a call that opens or
+// closes a lending position, so repayments can be matched to borrows and withdrawals
+// to deposits. Arguments that can't be decoded leave the amount unknown.
+func decodePositionArgs(activity *DeFiActivity, tx BlockscoutTransaction, args positionArg, value *big.Int) {
+	activity.Asset = strings.ToLower(tx.To)
+	activity.ClosesPosition = args.closesAll
+
+	input := strings.TrimPrefix(strings.ToLower(tx.Input), "0x")
+	word := func(i int) (string, bool) {
+		start := 8 + 64*i // After the selector
+		if i < 0 || len(input) < start+64 {
+			return "", false
+		}
+		return input[start : start+64], true
+	}
+
+	if args.asset >= 0 {
+		if w, ok := word(args.asset); ok {
+			activity.Asset = "0x" + w[24:]
+		}
+	}
+
+	var amount *big.Int
+	if args.amount >= 0 {
+		if w, ok := word(args.amount); ok {
+			amount, _ = new(big.Int).SetString(w, 16)
+		}
+	} else if value != nil && value.Sign() > 0 {
+		amount = value
+	}
+	if amount == nil {
+		return
+	}
+	closing := activity.ActivityType == ActivityRepay || activity.ActivityType == ActivityWithdraw
+	if closing && amount.Cmp(maxUint256) == 0 {
+		activity.ClosesPosition = true
+		return
+	}
+	activity.RawAmount = amount.String()
+}
+
+// SuccessfulDeFiActivities counts the activities whose transaction succeeded
+func SuccessfulDeFiActivities(activities []DeFiActivity) int {
+	count := 0
+	for _, activity := range activities {
+		if activity.Status != ActivityFailed {
+			count++
+		}
+	}
+	return count
+}
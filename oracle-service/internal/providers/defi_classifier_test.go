@@ -0,0 +1,135 @@
+package providers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/chains"
+)
+
+func TestSelector(t *testing.T) {
+	tests := map[string]string{
+		"transfer(address,uint256)":              "0xa9059cbb",
+		"supply(address,uint256,address,uint16)": "0x617ba037",
+		"borrow(uint256)":                        "0xc5ebeaec",
+		"swapExactTokensForTokens(uint256,uint256,address[],address,uint256)": "0x38ed1739",
+	}
+	for signature, expected := range tests {
+		if got := selector(signature); got != expected {
+			t.Errorf("selector(%s) = %s, expected %s", signature, got, expected)
+		}
+	}
+}
+
+func TestClassifyTransaction(t *testing.T) {
+	tests := []struct {
+		name     string
+		tx       BlockscoutTransaction
+		activity string // empty expects no DeFi activity
+		protocol string
+	}{
+		{"Aave supply by method ID", BlockscoutTransaction{To: "0xpool", MethodID: "0x617ba037"}, ActivityLend, "aave"},
+		{"Compound borrow from calldata", BlockscoutTransaction{To: "0xctoken", Input: "0xC5EBEAEC000000000000000000000000000000000000000000000000000000000000000a"}, ActivityBorrow, "compound"},
+		{"Method ID without prefix", BlockscoutTransaction{To: "0xrouter", MethodID: "38ed1739"}, ActivitySwap, "uniswap"},
+		{"Decoded name only", BlockscoutTransaction{To: "0xpool", FunctionName: "repay(address asset, uint256 amount, uint256 interestRateMode, address onBehalfOf)"}, ActivityRepay, ""},
+		{"Router swap variant", BlockscoutTransaction{To: "0xrouter", FunctionName: "swapExactTokensForETHSupportingFeeOnTransferTokens"}, ActivitySwap, ""},
+		{"Token transfer", BlockscoutTransaction{To: "0xtoken", MethodID: "0xa9059cbb", FunctionName: "transfer(address to, uint256 amount)"}, "", ""},
+		{"WETH wrap", BlockscoutTransaction{To: "0xweth", MethodID: "0xd0e30db0", FunctionName: "deposit()"}, "", ""},
+		{"Plain ETH transfer", BlockscoutTransaction{To: "0xfriend"}, "", ""},
+		{"Contract creation", BlockscoutTransaction{MethodID: "0x617ba037"}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig, ok := ClassifyTransaction(tt.tx)
+			if tt.activity == "" {
+				if ok {
+					t.Errorf("Expected no DeFi activity, got %+v", sig)
+				}
+				return
+			}
+			if !ok || sig.Activity != tt.activity || sig.Protocol != tt.protocol {
+				t.Errorf("ClassifyTransaction() = (%+v, %v), expected %s on %q", sig, ok, tt.activity, tt.protocol)
+			}
+		})
+	}
+}
+
+func TestClassifyDeFiActivities(t *testing.T) {
+	txs := []BlockscoutTransaction{
+		{Hash: "0x1", To: "0xrouter", MethodID: "0x7ff36ab5", Value: "500000000000000000", TimeStamp: "1709294400"}, // swapExactETHForTokens
+		{Hash: "0x2", To: "0xpool", MethodID: "0x617ba037", Status: "0"},
+		{Hash: "0x3", To: "0xfriend", Value: "1000000000000000000"},
+	}
+
+	activities := ClassifyDeFiActivities(txs, chains.Native("ethereum"))
+	if len(activities) != 2 {
+		t.Fatalf("Expected 2 DeFi activities, got %d", len(activities))
+	}
+
+	swap := activities[0]
+	if swap.ActivityType != ActivitySwap || swap.Amount != 0.5 || swap.TokenSymbol != "ETH" || swap.Timestamp.Unix() != 1709294400 {
+		t.Errorf("Unexpected swap activity %+v", swap)
+	}
+	if activities[1].Status != ActivityFailed {
+		t.Errorf("Expected the reverted supply to be failed, got %s", activities[1].Status)
+	}
+	if got := SuccessfulDeFiActivities(activities); got != 1 {
+		t.Errorf("Expected 1 successful activity, got %d", got)
+	}
+
+	// Values are in the coin of the chain the transactions are on
+	bsc := ClassifyDeFiActivities(txs, chains.Native("bsc"))
+	if bsc[0].TokenSymbol != "BNB" || bsc[0].Amount != 0.5 {
+		t.Errorf("Expected the swap valued in BNB, got %+v", bsc[0])
+	}
+	sixDecimals := ClassifyDeFiActivities(txs, chains.NativeAsset{Symbol: "TEST", Decimals: 6})
+	if sixDecimals[0].Amount != 500000000000 {
+		t.Errorf("Expected the value converted with the coin's decimals, got %v", sixDecimals[0].Amount)
+	}
+}
+
+func TestClassifyDeFiActivitiesDecodesDebt(t *testing.T) {
+	word := func(hex string) string {
+		return strings.Repeat("0", 64-len(hex)) + hex
+	}
+	usdc := "a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+	borrow := selector("borrow(address,uint256,uint256,uint16,address)") + word(usdc) + word("3b9aca00") + word("2") + word("0") + word("abc")
+	repayAll := selector("repay(address,uint256,uint256,address)") + word(usdc) + strings.Repeat("f", 64) + word("2") + word("abc")
+
+	activities := ClassifyDeFiActivities([]BlockscoutTransaction{
+		{Hash: "0x1", To: "0xPool", Input: borrow},
+		{Hash: "0x2", To: "0xPool", Input: repayAll},
+		{Hash: "0x3", To: "0xCEther", MethodID: selector("repayBorrow()"), Value: "5000"},
+		{Hash: "0x4", To: "0xPool", Input: selector("borrow(address,uint256,uint256,uint16,address)")}, // Truncated calldata
+	}, chains.Native("ethereum"))
+	if len(activities) != 4 {
+		t.Fatalf("Expected 4 activities, got %d", len(activities))
+	}
+
+	if a := activities[0]; a.Asset != "0x"+usdc || a.RawAmount != "1000000000" || a.ClosesPosition {
+		t.Errorf("Unexpected borrow %+v", a)
+	}
+	if a := activities[1]; a.Asset != "0x"+usdc || a.RawAmount != "" || !a.ClosesPosition {
+		t.Errorf("Expected a repay of everything, got %+v", a)
+	}
+	if a := activities[2]; a.Asset != "0xcether" || a.RawAmount != "5000" {
+		t.Errorf("Expected the value sent as the repaid amount, got %+v", a)
+	}
+	if a := activities[3]; a.Asset != "0xpool" || a.RawAmount != "" {
+		t.Errorf("Expected an unknown amount for truncated calldata, got %+v", a)
+	}
+
+	supply := selector("supply(address,uint256,address,uint16)") + word(usdc) + word("3b9aca00") + word("abc") + word("0")
+	withdrawAll := selector("withdraw(address,uint256,address)") + word(usdc) + strings.Repeat("f", 64) + word("abc")
+	activities = ClassifyDeFiActivities([]BlockscoutTransaction{
+		{Hash: "0x5", To: "0xPool", Input: supply},
+		{Hash: "0x6", To: "0xPool", Input: withdrawAll},
+	}, chains.Native("ethereum"))
+	if a := activities[0]; a.ActivityType != ActivityLend || a.Asset != "0x"+usdc || a.RawAmount != "1000000000" {
+		t.Errorf("Unexpected supply %+v", a)
+	}
+	if a := activities[1]; a.ActivityType != ActivityWithdraw || a.RawAmount != "" || !a.ClosesPosition {
+		t.Errorf("Expected a withdrawal of everything, got %+v", a)
+	}
+}
@@ -0,0 +1,211 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// EmploymentProvider integrates with payroll-connected employment verification APIs (Argyle, Pinwheel)
+type EmploymentProvider struct {
+	httpClient *http.Client
+	apiKey     string
+	baseURL    string
+	provider   string // "argyle", "pinwheel"
+}
+
+// EmploymentVerification represents employment data confirmed from a payroll connection
+type EmploymentVerification struct {
+	UserID           string        `json:"user_id"`
+	Employer         string        `json:"employer"`
+	JobTitle         string        `json:"job_title"`
+	EmploymentStatus string        `json:"employment_status"` // "full-time", "part-time", "contractor", "self-employed", "terminated"
+	StartDate        time.Time     `json:"start_date"`
+	TenureMonths     int           `json:"tenure_months"`
+	PayFrequency     string        `json:"pay_frequency"` // "weekly", "bi-weekly", "semi-monthly", "monthly"
+	GrossPayPerCycle units.Decimal `json:"gross_pay_per_cycle"`
+	Verified         bool          `json:"verified"`
+	LastUpdated      time.Time     `json:"last_updated"`
+	DataSource       string        `json:"data_source"`
+}
+
+// NewEmploymentProvider creates a new employment verification provider
+func NewEmploymentProvider(provider, baseURL, apiKey string) *EmploymentProvider {
+	return &EmploymentProvider{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		apiKey:   apiKey,
+		baseURL:  baseURL,
+		provider: provider,
+	}
+}
+
+// SetTransport sends the provider's HTTP calls through transport, e.g. to record them
+// for debug traces
+func (p *EmploymentProvider) SetTransport(transport http.RoundTripper) {
+	p.httpClient.Transport = transport
+}
+
+// Name returns the provider identifier (e.g. "argyle")
+func (p *EmploymentProvider) Name() string {
+	return p.provider
+}
+
+// IsConfigured reports whether the provider has an endpoint to call
+func (p *EmploymentProvider) IsConfigured() bool {
+	return p.baseURL != "" && p.apiKey != ""
+}
+
+// GetEmploymentVerification fetches the verified employment record for a payroll account
+func (p *EmploymentProvider) GetEmploymentVerification(ctx context.Context, userID string) (*EmploymentVerification, error) {
+	logger.Info("Fetching employment verification",
+		zap.String("provider", p.provider),
+		zap.String("userID", userID),
+	)
+
+	query := url.Values{"account": {userID}}
+	endpoint := fmt.Sprintf("%s/v1/employments?%s", p.baseURL, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.ProviderUnavailable("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, statusError(resp.StatusCode, fmt.Errorf("employment API returned status %d: %s", resp.StatusCode, string(body)))
+	}
+
+	var result struct {
+		Results []struct {
+			Employer     string        `json:"employer"`
+			JobTitle     string        `json:"job_title"`
+			Status       string        `json:"status"`
+			Type         string        `json:"type"`
+			HireDate     string        `json:"hire_datetime"`
+			PayFrequency string        `json:"pay_cycle"`
+			BasePay      units.Decimal `json:"base_pay_amount"`
+		} `json:"results"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(result.Results) == 0 {
+		return nil, errors.NotFound("no employment records found for account %s", userID)
+	}
+
+	// The first record is the most recent employment
+	record := result.Results[0]
+	verification := &EmploymentVerification{
+		UserID:           userID,
+		Employer:         record.Employer,
+		JobTitle:         record.JobTitle,
+		EmploymentStatus: normalizeEmploymentStatus(record.Status, record.Type),
+		PayFrequency:     record.PayFrequency,
+		GrossPayPerCycle: record.BasePay,
+		Verified:         record.Status == "active",
+		LastUpdated:      time.Now(),
+		DataSource:       p.provider,
+	}
+
+	if hireDate, err := time.Parse(time.RFC3339, record.HireDate); err == nil {
+		verification.StartDate = hireDate
+		verification.TenureMonths = int(time.Since(hireDate).Hours() / 24 / 30)
+	}
+
+	logger.Info("Employment verification fetched successfully",
+		zap.String("provider", p.provider),
+		zap.String("employer", verification.Employer),
+		zap.Int("tenureMonths", verification.TenureMonths),
+	)
+
+	return verification, nil
+}
+
+// normalizeEmploymentStatus maps payroll API status/type pairs onto the statuses used in scoring
+func normalizeEmploymentStatus(status, employmentType string) string {
+	if status != "active" {
+		return "terminated"
+	}
+
+	switch employmentType {
+	case "full-time", "full_time", "W2":
+		return "full-time"
+	case "part-time", "part_time":
+		return "part-time"
+	case "contractor", "gig", "1099":
+		return "contractor"
+	case "self-employed", "self_employed":
+		return "self-employed"
+	default:
+		return "full-time"
+	}
+}
+
+// HealthCheck verifies the employment API is accessible
+func (p *EmploymentProvider) HealthCheck(ctx context.Context) error {
+	if !p.IsConfigured() {
+		return fmt.Errorf("employment provider not configured")
+	}
+
+	endpoint := fmt.Sprintf("%s/health", p.baseURL)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return errors.ProviderUnavailable("health check failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return statusError(resp.StatusCode, fmt.Errorf("health check returned status %d", resp.StatusCode))
+	}
+
+	return nil
+}
+
+// MockEmploymentData generates mock data for testing
+func (p *EmploymentProvider) MockEmploymentData(userID string) *EmploymentVerification {
+	startDate := time.Now().AddDate(-3, -2, 0) // 3 years 2 months ago
+
+	return &EmploymentVerification{
+		UserID:           userID,
+		Employer:         "Tech Corp Inc",
+		JobTitle:         "Software Engineer",
+		EmploymentStatus: "full-time",
+		StartDate:        startDate,
+		TenureMonths:     38,
+		PayFrequency:     "bi-weekly",
+		GrossPayPerCycle: units.MustDecimal("2884.62"),
+		Verified:         true,
+		LastUpdated:      time.Now(),
+		DataSource:       p.provider + "_mock",
+	}
+}
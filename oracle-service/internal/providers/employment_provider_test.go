@@ -0,0 +1,85 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+)
+
+func TestGetEmploymentVerification(t *testing.T) {
+	hired := time.Now().AddDate(-2, 0, 0).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/employments" || r.Header.Get("Authorization") != "Bearer key" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		switch account := r.URL.Query().Get("account"); account {
+		case "acc 1&status=active":
+			w.Write([]byte(`{"results":[{"employer":"Acme","status":"active","type":"W2","hire_datetime":"` + hired + `","pay_cycle":"monthly","base_pay_amount":"5000.00"}]}`))
+		case "unknown":
+			w.Write([]byte(`{"results":[]}`))
+		case "forbidden":
+			http.Error(w, "account not linked", http.StatusForbidden)
+		case "throttled":
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+		case "broken":
+			http.Error(w, "internal error", http.StatusInternalServerError)
+		case "hung":
+			<-r.Context().Done()
+		default:
+			t.Errorf("Unexpected account %q", account)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewEmploymentProvider("argyle", server.URL, "key")
+	ctx := context.Background()
+
+	// The account is sent as one query value, however it is spelled
+	verification, err := provider.GetEmploymentVerification(ctx, "acc 1&status=active")
+	if err != nil {
+		t.Fatalf("Failed to get employment verification: %v", err)
+	}
+	if !verification.Verified || verification.Employer != "Acme" || verification.EmploymentStatus != "full-time" {
+		t.Errorf("Unexpected verification %+v", verification)
+	}
+	if verification.TenureMonths < 23 || verification.TenureMonths > 25 {
+		t.Errorf("Expected about 24 months of tenure, got %d", verification.TenureMonths)
+	}
+
+	tests := []struct {
+		name        string
+		account     string
+		kind        error
+		unavailable bool
+	}{
+		{"No records", "unknown", errors.ErrNotFound, false},
+		{"Client error", "forbidden", nil, false},
+		{"Rate limited", "throttled", errors.ErrProviderUnavailable, true},
+		{"Server error", "broken", errors.ErrProviderUnavailable, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := provider.GetEmploymentVerification(ctx, tt.account)
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if tt.kind != nil && !errors.Is(err, tt.kind) {
+				t.Errorf("Expected %v, got %v", tt.kind, err)
+			}
+			if got := errors.Is(err, errors.ErrProviderUnavailable); got != tt.unavailable {
+				t.Errorf("Expected unavailable=%v, got %v", tt.unavailable, err)
+			}
+		})
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := provider.GetEmploymentVerification(callCtx, "hung"); !errors.IsTimeout(err) || !errors.Is(err, errors.ErrProviderUnavailable) {
+		t.Errorf("Expected an unavailable timeout, got %v", err)
+	}
+}
@@ -0,0 +1,17 @@
+package providers
+
+import (
+	"net/http"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+)
+
+// statusError classifies an error for a non-200 response from a provider API. Server
+// errors and rate limiting mean the provider is unavailable; other statuses are left
+// unclassified, as they point at a bad request rather than an outage.
+func statusError(statusCode int, err error) error {
+	if statusCode >= http.StatusInternalServerError || statusCode == http.StatusTooManyRequests {
+		return errors.Wrap(errors.ErrProviderUnavailable, err)
+	}
+	return err
+}
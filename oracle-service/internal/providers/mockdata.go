@@ -0,0 +1,103 @@
+package providers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MockMode is whether a provider's data may be replaced by mock data
+type MockMode string
+
+// Mock data modes
+const (
+	MockAlways   MockMode = "true"  // Serve mock data without calling the provider
+	MockFallback MockMode = "false" // Call the provider, serving mock data if the call fails
+	MockNever    MockMode = "never" // Call the provider and never serve mock data
+)
+
+// Mock data domains, each setting the mode of its providers that aren't set individually
+const (
+	MockDomainOffChain = "offchain" // The credit bureau, Plaid and the employment provider
+	MockDomainOnChain  = "onchain"  // Explorer providers and the Ethereum node; only never is valid
+)
+
+// Off-chain providers whose mode can be set individually
+const (
+	MockProviderBureau     = "bureau"
+	MockProviderPlaid      = "plaid"
+	MockProviderEmployment = "employment"
+)
+
+// MockSettings holds the mock data mode of each off-chain provider. On-chain data is
+// never mocked: scores published on-chain must reflect the wallet's real history.
+type MockSettings struct {
+	Bureau     MockMode
+	Plaid      MockMode
+	Employment MockMode // Fallback is treated as never: mock employment would count as verified
+}
+
+// DefaultMockSettings calls every provider, substituting mock bureau and Plaid data
+// where they are unavailable
+func DefaultMockSettings() MockSettings {
+	return MockSettings{Bureau: MockFallback, Plaid: MockFallback, Employment: MockNever}
+}
+
+// ParseMockSettings reads modes keyed by domain (offchain, onchain) or off-chain
+// provider (bureau, plaid, employment). A provider's own mode overrides its domain's.
+// Unknown keys and modes fail, as does any onchain mode but never.
+func ParseMockSettings(modes map[string]string) (MockSettings, error) {
+	settings := DefaultMockSettings()
+
+	keys := make([]string, 0, len(modes))
+	for key := range modes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var domain *MockMode
+	providers := make(map[string]MockMode)
+	for _, key := range keys {
+		mode := MockMode(strings.ToLower(modes[key]))
+		if mode != MockAlways && mode != MockFallback && mode != MockNever {
+			return MockSettings{}, fmt.Errorf("invalid mock mode %q for %s: use true, false or never", modes[key], key)
+		}
+
+		switch key {
+		case MockDomainOnChain:
+			if mode != MockNever {
+				return MockSettings{}, fmt.Errorf("on-chain data cannot be mocked: %s must be never", key)
+			}
+		case MockDomainOffChain:
+			domain = &mode
+		case MockProviderBureau, MockProviderPlaid, MockProviderEmployment:
+			providers[key] = mode
+		default:
+			return MockSettings{}, fmt.Errorf("unknown mock data domain or provider %q", key)
+		}
+	}
+
+	if domain != nil {
+		settings = MockSettings{Bureau: *domain, Plaid: *domain, Employment: *domain}
+	}
+	if mode, ok := providers[MockProviderBureau]; ok {
+		settings.Bureau = mode
+	}
+	if mode, ok := providers[MockProviderPlaid]; ok {
+		settings.Plaid = mode
+	}
+	if mode, ok := providers[MockProviderEmployment]; ok {
+		settings.Employment = mode
+	}
+	return settings, nil
+}
+
+// AnyAlways reports whether any provider serves mock data without being called
+func (s MockSettings) AnyAlways() bool {
+	return s.Bureau == MockAlways || s.Plaid == MockAlways || s.Employment == MockAlways
+}
+
+// String lists the modes, for logging
+func (s MockSettings) String() string {
+	return fmt.Sprintf("bureau=%s,plaid=%s,employment=%s,onchain=%s", s.Bureau, s.Plaid, s.Employment, MockNever)
+}
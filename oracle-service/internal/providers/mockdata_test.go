@@ -0,0 +1,44 @@
+package providers
+
+import (
+	"testing"
+)
+
+func TestParseMockSettings(t *testing.T) {
+	defaults, err := ParseMockSettings(nil)
+	if err != nil || defaults != DefaultMockSettings() {
+		t.Fatalf("Expected the defaults without settings, got %+v (%v)", defaults, err)
+	}
+
+	// A provider's own mode overrides its domain's
+	settings, err := ParseMockSettings(map[string]string{"offchain": "true", "bureau": "never", "onchain": "never"})
+	if err != nil {
+		t.Fatalf("Failed to parse mock settings: %v", err)
+	}
+	want := MockSettings{Bureau: MockNever, Plaid: MockAlways, Employment: MockAlways}
+	if settings != want {
+		t.Errorf("Expected %+v, got %+v", want, settings)
+	}
+	if !settings.AnyAlways() {
+		t.Error("Expected mock data to be reported as enabled")
+	}
+
+	settings, err = ParseMockSettings(map[string]string{"plaid": "TRUE"})
+	if err != nil || settings.Plaid != MockAlways || settings.Bureau != MockFallback {
+		t.Errorf("Expected only Plaid mocked, got %+v (%v)", settings, err)
+	}
+}
+
+func TestParseMockSettingsRejectsOnChainMocks(t *testing.T) {
+	invalid := []map[string]string{
+		{"onchain": "true"},
+		{"onchain": "false"},
+		{"blockscout": "true"},
+		{"plaid": "sometimes"},
+	}
+	for _, modes := range invalid {
+		if _, err := ParseMockSettings(modes); err == nil {
+			t.Errorf("Expected %v to be rejected", modes)
+		}
+	}
+}
@@ -3,12 +3,18 @@ package providers
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"time"
 
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
 	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -17,62 +23,75 @@ import (
 type PlaidProvider struct {
 	httpClient  *http.Client
 	clientID    string
-	secret      string
+	secret      *Credential
 	baseURL     string
 	environment string // "sandbox", "development", "production"
 }
 
 // PlaidBankAccount represents bank account information
 type PlaidBankAccount struct {
-	AccountID        string    `json:"account_id"`
-	Name             string    `json:"name"`
-	Type             string    `json:"type"` // "checking", "savings", "credit"
-	Subtype          string    `json:"subtype"`
-	CurrentBalance   float64   `json:"current_balance"`
-	AvailableBalance float64   `json:"available_balance"`
-	CurrencyCode     string    `json:"currency_code"`
-	LastUpdated      time.Time `json:"last_updated"`
+	AccountID        string        `json:"account_id"`
+	Name             string        `json:"name"`
+	Type             string        `json:"type"` // "checking", "savings", "credit"
+	Subtype          string        `json:"subtype"`
+	CurrentBalance   units.Decimal `json:"current_balance"`
+	AvailableBalance units.Decimal `json:"available_balance"`
+	CurrencyCode     string        `json:"currency_code"`
+	LastUpdated      time.Time     `json:"last_updated"`
 }
 
 // PlaidTransaction represents a transaction
 type PlaidTransaction struct {
-	TransactionID string   `json:"transaction_id"`
-	AccountID     string   `json:"account_id"`
-	Amount        float64  `json:"amount"`
-	Date          string   `json:"date"`
-	Name          string   `json:"name"`
-	Category      []string `json:"category"`
-	Pending       bool     `json:"pending"`
+	TransactionID string        `json:"transaction_id"`
+	AccountID     string        `json:"account_id"`
+	Amount        units.Decimal `json:"amount"`
+	Date          string        `json:"date"`
+	Name          string        `json:"name"`
+	Category      []string      `json:"category"`
+	Pending       bool          `json:"pending"`
 }
 
 // PlaidIncomeData represents income verification data
 type PlaidIncomeData struct {
-	UserID             string    `json:"user_id"`
-	AnnualIncome       float64   `json:"annual_income"`
-	MonthlyIncome      float64   `json:"monthly_income"`
-	IncomeVerified     bool      `json:"income_verified"`
-	EmploymentStatus   string    `json:"employment_status"`
-	Employer           string    `json:"employer"`
-	LastPayDate        string    `json:"last_pay_date"`
-	PayFrequency       string    `json:"pay_frequency"`
-	VerificationSource string    `json:"verification_source"`
-	LastUpdated        time.Time `json:"last_updated"`
+	UserID             string        `json:"user_id"`
+	AnnualIncome       units.Decimal `json:"annual_income"`
+	MonthlyIncome      units.Decimal `json:"monthly_income"`
+	IncomeVerified     bool          `json:"income_verified"`
+	EmploymentStatus   string        `json:"employment_status"`
+	Employer           string        `json:"employer"`
+	LastPayDate        string        `json:"last_pay_date"`
+	PayFrequency       string        `json:"pay_frequency"`
+	VerificationSource string        `json:"verification_source"`
+	LastUpdated        time.Time     `json:"last_updated"`
 }
 
 // PlaidAccountSummary represents summarized account data
 type PlaidAccountSummary struct {
 	UserID              string             `json:"user_id"`
 	Accounts            []PlaidBankAccount `json:"accounts"`
-	TotalBalance        float64            `json:"total_balance"`
-	AverageBalance      float64            `json:"average_balance"`
+	TotalBalance        units.Decimal      `json:"total_balance"`
+	AverageBalance      units.Decimal      `json:"average_balance"`
 	AccountAgeMonths    int                `json:"account_age_months"`
 	TransactionCount    int                `json:"transaction_count"`
-	AverageMonthlySpend float64            `json:"average_monthly_spend"`
+	AverageMonthlySpend units.Decimal      `json:"average_monthly_spend"`
+	Transactions        []PlaidTransaction `json:"transactions,omitempty"` // Recent transactions used for net-flow analysis
 	IncomeData          *PlaidIncomeData   `json:"income_data"`
 	CreditUtilization   float64            `json:"credit_utilization"`
 	LastUpdated         time.Time          `json:"last_updated"`
 }
 
+// PlaidWebhook is a webhook pushed by Plaid when an item's data changes
+type PlaidWebhook struct {
+	WebhookType string `json:"webhook_type"` // e.g. "TRANSACTIONS", "ITEM"
+	WebhookCode string `json:"webhook_code"` // e.g. "DEFAULT_UPDATE", "ERROR"
+	ItemID      string `json:"item_id"`
+}
+
+// EventType returns the webhook's "<type>.<code>" identifier
+func (w *PlaidWebhook) EventType() string {
+	return w.WebhookType + "." + w.WebhookCode
+}
+
 // NewPlaidProvider creates a new Plaid provider
 func NewPlaidProvider(clientID, secret, environment string) *PlaidProvider {
 	baseURL := "https://sandbox.plaid.com"
@@ -87,12 +106,18 @@ func NewPlaidProvider(clientID, secret, environment string) *PlaidProvider {
 			Timeout: 30 * time.Second,
 		},
 		clientID:    clientID,
-		secret:      secret,
+		secret:      NewCredential(secret),
 		baseURL:     baseURL,
 		environment: environment,
 	}
 }
 
+// SetTransport sends the provider's HTTP calls through transport, e.g. to record them
+// for debug traces
+func (p *PlaidProvider) SetTransport(transport http.RoundTripper) {
+	p.httpClient.Transport = transport
+}
+
 // GetAccountSummary fetches comprehensive account summary
 func (p *PlaidProvider) GetAccountSummary(ctx context.Context, accessToken string) (*PlaidAccountSummary, error) {
 	logger.Info("Fetching Plaid account summary")
@@ -122,7 +147,7 @@ func (p *PlaidProvider) GetAccountSummary(ctx context.Context, accessToken strin
 
 	logger.Info("Plaid account summary fetched successfully",
 		zap.Int("accounts", len(accounts)),
-		zap.Float64("totalBalance", summary.TotalBalance),
+		zap.Stringer("totalBalance", summary.TotalBalance),
 	)
 
 	return summary, nil
@@ -134,7 +159,7 @@ func (p *PlaidProvider) getAccounts(ctx context.Context, accessToken string) ([]
 
 	reqBody := map[string]string{
 		"client_id":    p.clientID,
-		"secret":       p.secret,
+		"secret":       p.secret.Get(),
 		"access_token": accessToken,
 	}
 
@@ -148,13 +173,13 @@ func (p *PlaidProvider) getAccounts(ctx context.Context, accessToken string) ([]
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(errors.ErrProviderUnavailable, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Plaid API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, statusError(resp.StatusCode, fmt.Errorf("Plaid API returned status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result struct {
@@ -164,9 +189,9 @@ func (p *PlaidProvider) getAccounts(ctx context.Context, accessToken string) ([]
 			Type      string `json:"type"`
 			Subtype   string `json:"subtype"`
 			Balances  struct {
-				Current   float64 `json:"current"`
-				Available float64 `json:"available"`
-				Currency  string  `json:"iso_currency_code"`
+				Current   units.Decimal `json:"current"`
+				Available units.Decimal `json:"available"`
+				Currency  string        `json:"iso_currency_code"`
 			} `json:"balances"`
 		} `json:"accounts"`
 	}
@@ -202,7 +227,7 @@ func (p *PlaidProvider) getTransactions(ctx context.Context, accessToken string,
 
 	reqBody := map[string]interface{}{
 		"client_id":    p.clientID,
-		"secret":       p.secret,
+		"secret":       p.secret.Get(),
 		"access_token": accessToken,
 		"start_date":   startDate,
 		"end_date":     endDate,
@@ -218,13 +243,13 @@ func (p *PlaidProvider) getTransactions(ctx context.Context, accessToken string,
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(errors.ErrProviderUnavailable, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Plaid API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, statusError(resp.StatusCode, fmt.Errorf("Plaid API returned status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result struct {
@@ -244,7 +269,7 @@ func (p *PlaidProvider) getIncomeData(ctx context.Context, accessToken string) (
 
 	reqBody := map[string]string{
 		"client_id":    p.clientID,
-		"secret":       p.secret,
+		"secret":       p.secret.Get(),
 		"access_token": accessToken,
 	}
 
@@ -258,23 +283,23 @@ func (p *PlaidProvider) getIncomeData(ctx context.Context, accessToken string) (
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(errors.ErrProviderUnavailable, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Plaid API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, statusError(resp.StatusCode, fmt.Errorf("Plaid API returned status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result struct {
 		Income struct {
-			LastYearIncome                      float64 `json:"last_year_income"`
-			ProjectedYearlyIncome               float64 `json:"projected_yearly_income"`
-			MaxNumberOfOverlappingIncomeStreams int     `json:"max_number_of_overlapping_income_streams"`
+			LastYearIncome                      units.Decimal `json:"last_year_income"`
+			ProjectedYearlyIncome               units.Decimal `json:"projected_yearly_income"`
+			MaxNumberOfOverlappingIncomeStreams int           `json:"max_number_of_overlapping_income_streams"`
 			IncomeStreams                       []struct {
-				MonthlyIncome float64 `json:"monthly_income"`
-				Confidence    float64 `json:"confidence"`
+				MonthlyIncome units.Decimal `json:"monthly_income"`
+				Confidence    float64       `json:"confidence"`
 			} `json:"income_streams"`
 		} `json:"income"`
 	}
@@ -283,7 +308,7 @@ func (p *PlaidProvider) getIncomeData(ctx context.Context, accessToken string) (
 		return nil, err
 	}
 
-	monthlyIncome := 0.0
+	var monthlyIncome units.Decimal
 	if len(result.Income.IncomeStreams) > 0 {
 		monthlyIncome = result.Income.IncomeStreams[0].MonthlyIncome
 	}
@@ -291,7 +316,7 @@ func (p *PlaidProvider) getIncomeData(ctx context.Context, accessToken string) (
 	return &PlaidIncomeData{
 		AnnualIncome:       result.Income.ProjectedYearlyIncome,
 		MonthlyIncome:      monthlyIncome,
-		IncomeVerified:     result.Income.ProjectedYearlyIncome > 0,
+		IncomeVerified:     result.Income.ProjectedYearlyIncome.Sign() > 0,
 		VerificationSource: "plaid",
 		LastUpdated:        time.Now(),
 	}, nil
@@ -299,24 +324,24 @@ func (p *PlaidProvider) getIncomeData(ctx context.Context, accessToken string) (
 
 // calculateSummary creates summary statistics
 func (p *PlaidProvider) calculateSummary(accounts []PlaidBankAccount, transactions []PlaidTransaction, incomeData *PlaidIncomeData) *PlaidAccountSummary {
-	totalBalance := 0.0
+	var totalBalance units.Decimal
 	for _, acc := range accounts {
-		totalBalance += acc.CurrentBalance
+		totalBalance = totalBalance.Add(acc.CurrentBalance)
 	}
 
-	avgBalance := 0.0
+	var avgBalance units.Decimal
 	if len(accounts) > 0 {
-		avgBalance = totalBalance / float64(len(accounts))
+		avgBalance = totalBalance.Div(units.DecimalFromInt(int64(len(accounts))))
 	}
 
 	// Calculate average monthly spend
-	totalSpend := 0.0
+	var totalSpend units.Decimal
 	for _, tx := range transactions {
-		if tx.Amount > 0 { // Positive amounts are debits
-			totalSpend += tx.Amount
+		if tx.Amount.Sign() > 0 { // Positive amounts are debits
+			totalSpend = totalSpend.Add(tx.Amount)
 		}
 	}
-	avgMonthlySpend := totalSpend / 3 // Assuming 90 days of transactions
+	avgMonthlySpend := totalSpend.Div(units.DecimalFromInt(3)) // Assuming 90 days of transactions
 
 	return &PlaidAccountSummary{
 		Accounts:            accounts,
@@ -325,6 +350,7 @@ func (p *PlaidProvider) calculateSummary(accounts []PlaidBankAccount, transactio
 		AccountAgeMonths:    24, // Would need to calculate from oldest account
 		TransactionCount:    len(transactions),
 		AverageMonthlySpend: avgMonthlySpend,
+		Transactions:        transactions,
 		IncomeData:          incomeData,
 		CreditUtilization:   0.0, // Would calculate from credit accounts
 		LastUpdated:         time.Now(),
@@ -341,8 +367,8 @@ func (p *PlaidProvider) MockPlaidData(userID string) *PlaidAccountSummary {
 				Name:             "Checking Account",
 				Type:             "depository",
 				Subtype:          "checking",
-				CurrentBalance:   5420.50,
-				AvailableBalance: 5420.50,
+				CurrentBalance:   units.MustDecimal("5420.50"),
+				AvailableBalance: units.MustDecimal("5420.50"),
 				CurrencyCode:     "USD",
 				LastUpdated:      time.Now(),
 			},
@@ -351,21 +377,21 @@ func (p *PlaidProvider) MockPlaidData(userID string) *PlaidAccountSummary {
 				Name:             "Savings Account",
 				Type:             "depository",
 				Subtype:          "savings",
-				CurrentBalance:   12350.00,
-				AvailableBalance: 12350.00,
+				CurrentBalance:   units.MustDecimal("12350.00"),
+				AvailableBalance: units.MustDecimal("12350.00"),
 				CurrencyCode:     "USD",
 				LastUpdated:      time.Now(),
 			},
 		},
-		TotalBalance:        17770.50,
-		AverageBalance:      8885.25,
+		TotalBalance:        units.MustDecimal("17770.50"),
+		AverageBalance:      units.MustDecimal("8885.25"),
 		AccountAgeMonths:    36,
 		TransactionCount:    245,
-		AverageMonthlySpend: 3200.00,
+		AverageMonthlySpend: units.MustDecimal("3200.00"),
 		IncomeData: &PlaidIncomeData{
 			UserID:             userID,
-			AnnualIncome:       75000,
-			MonthlyIncome:      6250,
+			AnnualIncome:       units.DecimalFromInt(75000),
+			MonthlyIncome:      units.DecimalFromInt(6250),
 			IncomeVerified:     true,
 			EmploymentStatus:   "full-time",
 			Employer:           "Tech Corp Inc",
@@ -379,9 +405,125 @@ func (p *PlaidProvider) MockPlaidData(userID string) *PlaidAccountSummary {
 	}
 }
 
-// HealthCheck verifies Plaid API connectivity
+// WebhookVerificationKey fetches the P-256 public key Plaid signs webhooks with. It
+// returns nil if Plaid doesn't know the key or it has expired.
+func (p *PlaidProvider) WebhookVerificationKey(ctx context.Context, keyID string) (*ecdsa.PublicKey, error) {
+	url := fmt.Sprintf("%s/webhook_verification_key/get", p.baseURL)
+
+	reqBody := map[string]string{
+		"client_id": p.clientID,
+		"secret":    p.secret.Get(),
+		"key_id":    keyID,
+	}
+
+	bodyBytes, _ := json.Marshal(reqBody)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrProviderUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	// Plaid answers 400 for key IDs it never issued
+	if resp.StatusCode == http.StatusBadRequest {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, statusError(resp.StatusCode, fmt.Errorf("Plaid API returned status %d: %s", resp.StatusCode, string(body)))
+	}
+
+	var result struct {
+		Key struct {
+			Kty       string `json:"kty"`
+			Crv       string `json:"crv"`
+			X         string `json:"x"`
+			Y         string `json:"y"`
+			ExpiredAt *int64 `json:"expired_at"`
+		} `json:"key"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	jwk := result.Key
+	if jwk.ExpiredAt != nil && time.Unix(*jwk.ExpiredAt, 0).Before(time.Now()) {
+		return nil, nil
+	}
+	if jwk.Kty != "EC" || jwk.Crv != "P-256" {
+		return nil, fmt.Errorf("unsupported Plaid verification key type %s %s", jwk.Kty, jwk.Crv)
+	}
+	x, errX := base64.RawURLEncoding.DecodeString(jwk.X)
+	y, errY := base64.RawURLEncoding.DecodeString(jwk.Y)
+	if errX != nil || errY != nil {
+		return nil, fmt.Errorf("invalid Plaid verification key coordinates")
+	}
+	key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	if !key.Curve.IsOnCurve(key.X, key.Y) {
+		return nil, fmt.Errorf("Plaid verification key is not on P-256")
+	}
+	return key, nil
+}
+
+// HealthCheck verifies Plaid API connectivity and credentials
 func (p *PlaidProvider) HealthCheck(ctx context.Context) error {
-	// Plaid doesn't have a dedicated health endpoint
-	// We can verify credentials are valid
+	// Without credentials there is nothing to check
+	if p.clientID == "" {
+		return nil
+	}
+	return p.healthCheck(ctx, p.secret.Get())
+}
+
+// RotateSecret switches to a new secret once a health check made with it succeeds
+func (p *PlaidProvider) RotateSecret(ctx context.Context, secret string) error {
+	if p.clientID == "" {
+		return errors.Validation("Plaid client ID is not configured")
+	}
+	if err := p.healthCheck(ctx, secret); err != nil {
+		return err
+	}
+	p.secret.Set(secret)
+	return nil
+}
+
+func (p *PlaidProvider) healthCheck(ctx context.Context, secret string) error {
+
+	// Plaid doesn't have a dedicated health endpoint; looking up a single
+	// institution is the cheapest call that needs valid credentials
+	url := fmt.Sprintf("%s/institutions/get", p.baseURL)
+
+	reqBody := map[string]interface{}{
+		"client_id":     p.clientID,
+		"secret":        secret,
+		"count":         1,
+		"offset":        0,
+		"country_codes": []string{"US"},
+	}
+
+	bodyBytes, _ := json.Marshal(reqBody)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return errors.ProviderUnavailable("Plaid health check failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return statusError(resp.StatusCode, fmt.Errorf("Plaid API returned status %d: %s", resp.StatusCode, string(body)))
+	}
+
 	return nil
 }
@@ -0,0 +1,138 @@
+package providers
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// maxQuantityDigits is the number of decimal digits of the largest uint256, the widest
+// quantity the EVM has
+const maxQuantityDigits = 78
+
+// maxUnixSeconds separates timestamps in seconds from timestamps in milliseconds:
+// as seconds it is past the year 5000, as milliseconds it is in 1973
+const maxUnixSeconds = 100_000_000_000
+
+// ParseQuantity parses a whole, non-negative on-chain quantity such as a wei value, a
+// gas amount or a block number. Blockscout sends quantities as decimal strings, but
+// proxied JSON-RPC results use 0x-prefixed hex and some endpoints send large values in
+// exponent notation ("1.5e+21"). Values are parsed exactly; fractions, negative values
+// and anything else are rejected rather than silently rounded.
+func ParseQuantity(value string) (*big.Int, error) {
+	s := strings.TrimSpace(value)
+	if s == "" {
+		return nil, fmt.Errorf("empty quantity")
+	}
+
+	var n *big.Int
+	var ok bool
+	switch {
+	case strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X"):
+		digits := s[2:]
+		if digits == "" {
+			// JSON-RPC encodes an empty value as a bare prefix
+			return new(big.Int), nil
+		}
+		n, ok = new(big.Int).SetString(digits, 16)
+	case strings.ContainsAny(s, ".eE") && !strings.Contains(s, "/"):
+		if i := strings.IndexAny(s, "eE"); i >= 0 {
+			// Bound the exponent so a hostile payload can't make us build a huge number
+			if exp, err := strconv.Atoi(s[i+1:]); err != nil || exp > maxQuantityDigits || exp < -maxQuantityDigits {
+				return nil, fmt.Errorf("invalid quantity %q", value)
+			}
+		}
+		var r *big.Rat
+		if r, ok = new(big.Rat).SetString(s); ok {
+			if !r.IsInt() {
+				return nil, fmt.Errorf("quantity %q is not a whole number", value)
+			}
+			n = r.Num()
+		}
+	default:
+		n, ok = new(big.Int).SetString(s, 10)
+	}
+	if !ok || n == nil {
+		return nil, fmt.Errorf("invalid quantity %q", value)
+	}
+	if n.Sign() < 0 {
+		return nil, fmt.Errorf("negative quantity %q", value)
+	}
+	return n, nil
+}
+
+// ParseUint64 parses a quantity that must fit in a uint64, such as a block number
+func ParseUint64(value string) (uint64, error) {
+	n, err := ParseQuantity(value)
+	if err != nil {
+		return 0, err
+	}
+	if !n.IsUint64() {
+		return 0, fmt.Errorf("quantity %q overflows uint64", value)
+	}
+	return n.Uint64(), nil
+}
+
+// ParseTimestamp parses a block timestamp in unix seconds or milliseconds, as a
+// decimal or hex quantity, or an RFC 3339 time as the Blockscout v2 API sends it
+func ParseTimestamp(value string) (time.Time, error) {
+	s := strings.TrimSpace(value)
+	if strings.ContainsAny(s, "T:") {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid timestamp %q: %w", value, err)
+		}
+		return t, nil
+	}
+
+	n, err := ParseUint64(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q: %w", value, err)
+	}
+	if n >= maxUnixSeconds {
+		if n/1000 >= maxUnixSeconds {
+			return time.Time{}, fmt.Errorf("timestamp %q is out of range", value)
+		}
+		return time.UnixMilli(int64(n)), nil
+	}
+	return time.Unix(int64(n), 0), nil
+}
+
+// ParseTokenAmount converts an amount in a token's base units, such as wei, into
+// whole tokens without rounding
+func ParseTokenAmount(value string, decimals int) (units.Decimal, error) {
+	n, err := ParseQuantity(value)
+	if err != nil {
+		return units.Decimal{}, err
+	}
+	return units.DecimalFromBigInt(n).Shift(-decimals), nil
+}
+
+// ValueWei returns the transaction's native value in wei
+func (tx BlockscoutTransaction) ValueWei() (*big.Int, error) {
+	return ParseQuantity(tx.Value)
+}
+
+// Time returns when the transaction was mined
+func (tx BlockscoutTransaction) Time() (time.Time, error) {
+	return ParseTimestamp(tx.TimeStamp)
+}
+
+// Time returns when the transfer was mined
+func (t BlockscoutTokenTransfer) Time() (time.Time, error) {
+	return ParseTimestamp(t.TimeStamp)
+}
+
+// Amount returns the transferred amount in whole tokens. Transfers without token
+// decimals are assumed to use 18, like most ERC-20 tokens.
+func (t BlockscoutTokenTransfer) Amount() (units.Decimal, error) {
+	decimals, err := ParseUint64(t.TokenDecimal)
+	if err != nil || decimals >= maxQuantityDigits {
+		decimals = 18
+	}
+	return ParseTokenAmount(t.Value, int(decimals))
+}
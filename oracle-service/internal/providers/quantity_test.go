@@ -0,0 +1,105 @@
+package providers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string // empty expects an error
+	}{
+		{"Decimal", "1000000000000000000", "1000000000000000000"},
+		{"Beyond float64 precision", "123456789012345678901234567", "123456789012345678901234567"},
+		{"Max uint256", "115792089237316195423570985008687907853269984665640564039457584007913129639935", "115792089237316195423570985008687907853269984665640564039457584007913129639935"},
+		{"Hex", "0x1bc16d674ec80000", "2000000000000000000"},
+		{"Upper case hex", "0X1F", "31"},
+		{"Bare hex prefix", "0x", "0"},
+		{"Exponent notation", "1.5e+21", "1500000000000000000000"},
+		{"Padded", " 42 ", "42"},
+		{"Zero", "0", "0"},
+		{"Empty", "", ""},
+		{"Negative", "-5", ""},
+		{"Fraction", "1.5", ""},
+		{"Fractional exponent", "15e-1", ""},
+		{"Huge exponent", "1e999999999", ""},
+		{"Ratio", "3/1", ""},
+		{"Invalid hex", "0xzz", ""},
+		{"Garbage", "12abc", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQuantity(tt.value)
+			if tt.expected == "" {
+				if err == nil {
+					t.Errorf("ParseQuantity(%q) = %s, expected an error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseQuantity(%q) failed: %v", tt.value, err)
+			}
+			if got.String() != tt.expected {
+				t.Errorf("ParseQuantity(%q) = %s, expected %s", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseUint64(t *testing.T) {
+	if n, err := ParseUint64("0x12d687"); err != nil || n != 1234567 {
+		t.Errorf("Expected block 1234567, got %d (%v)", n, err)
+	}
+	if _, err := ParseUint64("18446744073709551616"); err == nil {
+		t.Error("Expected an error for a value above uint64")
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	expected := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, value := range []string{"1709294400", "0x65e1c340", "1709294400000", "2024-03-01T12:00:00.000000Z"} {
+		got, err := ParseTimestamp(value)
+		if err != nil {
+			t.Errorf("ParseTimestamp(%q) failed: %v", value, err)
+			continue
+		}
+		if !got.Equal(expected) {
+			t.Errorf("ParseTimestamp(%q) = %s, expected %s", value, got.UTC(), expected)
+		}
+	}
+
+	for _, value := range []string{"", "yesterday", "-1", "99999999999999999999"} {
+		if _, err := ParseTimestamp(value); err == nil {
+			t.Errorf("Expected an error for timestamp %q", value)
+		}
+	}
+}
+
+func TestTokenAmounts(t *testing.T) {
+	transfer := BlockscoutTokenTransfer{Value: "2500000000", TokenDecimal: "6"}
+	if amount, err := transfer.Amount(); err != nil || amount.String() != "2500" {
+		t.Errorf("Expected 2500 USDC, got %s (%v)", amount, err)
+	}
+
+	// Missing decimals default to 18
+	transfer = BlockscoutTokenTransfer{Value: "0xde0b6b3a7640000"}
+	if amount, err := transfer.Amount(); err != nil || amount.String() != "1" {
+		t.Errorf("Expected 1 token, got %s (%v)", amount, err)
+	}
+
+	if got := tokenBalance(BlockscoutTokenBalance{Balance: "1500000", TokenDecimals: 6}); got != 1.5 {
+		t.Errorf("Expected a balance of 1.5, got %v", got)
+	}
+	if got := tokenBalance(BlockscoutTokenBalance{Balance: "not a number", TokenDecimals: 6}); got != 0 {
+		t.Errorf("Expected a malformed balance to count as zero, got %v", got)
+	}
+
+	tx := BlockscoutTransaction{Value: "0x3635c9adc5dea00000"}
+	if wei, err := tx.ValueWei(); err != nil || wei.String() != "1000000000000000000000" {
+		t.Errorf("Expected 1000 ETH in wei, got %s (%v)", wei, err)
+	}
+}
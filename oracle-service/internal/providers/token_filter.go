@@ -0,0 +1,116 @@
+package providers
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Token classification reasons
+const (
+	TokenReasonAllowlisted  = "allowlisted"
+	TokenReasonBlocklisted  = "blocklisted"
+	TokenReasonProviderSpam = "provider_flagged_spam"
+	TokenReasonHoneypot     = "honeypot"
+	TokenReasonSpamName     = "spam_name"
+	TokenReasonNoLiquidity  = "no_liquidity"
+	TokenReasonOK           = "ok"
+)
+
+// spamNameWords are words typical of airdropped phishing tokens. They are matched as
+// whole words, so names like "Rewardable" or "Revisit" aren't flagged.
+var spamNameWords = map[string]bool{
+	"claim": true, "visit": true, "reward": true, "airdrop": true, "voucher": true, "bonus": true,
+}
+
+// spamNameLink matches the links phishing tokens put in their names to lure holders
+var spamNameLink = regexp.MustCompile(`https?:|\bwww\.|[a-z0-9-]\.(com|io|org|net|xyz|site)\b`)
+
+// TokenInfo is the provider-neutral view of a held token used for spam filtering
+type TokenInfo struct {
+	ContractAddress string
+	Symbol          string
+	Name            string
+	HasPrice        bool // A market price or quote exists for the token
+	PriceKnown      bool // The provider reports pricing at all (false for providers without quotes)
+	ProviderSpam    bool // Flagged as spam by the data provider
+	Honeypot        bool // Flagged as a honeypot (transfers out blocked)
+}
+
+// TokenFilter decides which held tokens count toward portfolio metrics
+type TokenFilter struct {
+	allowlist map[string]bool
+	blocklist map[string]bool
+}
+
+// NewTokenFilter creates a filter from allowlisted and blocklisted contract addresses
+func NewTokenFilter(allowlist, blocklist []string) *TokenFilter {
+	f := &TokenFilter{
+		allowlist: make(map[string]bool, len(allowlist)),
+		blocklist: make(map[string]bool, len(blocklist)),
+	}
+	for _, addr := range allowlist {
+		f.allowlist[strings.ToLower(strings.TrimSpace(addr))] = true
+	}
+	for _, addr := range blocklist {
+		f.blocklist[strings.ToLower(strings.TrimSpace(addr))] = true
+	}
+	return f
+}
+
+// Classify reports whether a token is legitimate and why. The allowlist wins over
+// every heuristic; the blocklist and provider/honeypot flags always exclude.
+func (f *TokenFilter) Classify(token TokenInfo) (bool, string) {
+	contract := strings.ToLower(strings.TrimSpace(token.ContractAddress))
+
+	if contract != "" && f.allowlist[contract] {
+		return true, TokenReasonAllowlisted
+	}
+	if contract != "" && f.blocklist[contract] {
+		return false, TokenReasonBlocklisted
+	}
+	if token.Honeypot {
+		return false, TokenReasonHoneypot
+	}
+	if token.ProviderSpam {
+		return false, TokenReasonProviderSpam
+	}
+	if looksLikeSpamName(token.Symbol) || looksLikeSpamName(token.Name) {
+		return false, TokenReasonSpamName
+	}
+	if token.PriceKnown && !token.HasPrice {
+		return false, TokenReasonNoLiquidity
+	}
+
+	return true, TokenReasonOK
+}
+
+// IsLegitimate is a convenience wrapper around Classify
+func (f *TokenFilter) IsLegitimate(token TokenInfo) bool {
+	ok, _ := f.Classify(token)
+	return ok
+}
+
+func looksLikeSpamName(s string) bool {
+	s = strings.ToLower(s)
+	if spamNameLink.MatchString(s) {
+		return true
+	}
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if spamNameWords[word] {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenFilterOrDefault returns the filter, or a heuristics-only filter when nil
+func tokenFilterOrDefault(f *TokenFilter) *TokenFilter {
+	if f == nil {
+		return NewTokenFilter(nil, nil)
+	}
+	return f
+}
@@ -0,0 +1,42 @@
+package providers
+
+import (
+	"testing"
+)
+
+func TestTokenFilterClassify(t *testing.T) {
+	filter := NewTokenFilter(
+		[]string{"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"},
+		[]string{"0x000000000000000000000000000000000000bad1"},
+	)
+
+	tests := []struct {
+		name       string
+		token      TokenInfo
+		legitimate bool
+		reason     string
+	}{
+		{"Allowlist wins over missing price", TokenInfo{ContractAddress: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", Symbol: "USDC", PriceKnown: true}, true, TokenReasonAllowlisted},
+		{"Blocklisted contract", TokenInfo{ContractAddress: "0x000000000000000000000000000000000000BAD1", Symbol: "WETH", HasPrice: true, PriceKnown: true}, false, TokenReasonBlocklisted},
+		{"Honeypot flag", TokenInfo{Symbol: "MOON", Honeypot: true}, false, TokenReasonHoneypot},
+		{"Provider spam flag", TokenInfo{Symbol: "FREE", ProviderSpam: true}, false, TokenReasonProviderSpam},
+		{"Phishing name", TokenInfo{Symbol: "Visit usdc-claim.com", Name: "USDC Reward"}, false, TokenReasonSpamName},
+		{"Phishing link", TokenInfo{Symbol: "USDC", Name: "https://usdc-gift.xyz"}, false, TokenReasonSpamName},
+		{"Phishing word", TokenInfo{Symbol: "$ AIRDROP", Name: "Ethereum"}, false, TokenReasonSpamName},
+		{"Marker inside a word", TokenInfo{Symbol: "RWD", Name: "Rewardable Staked Bonusly", HasPrice: true, PriceKnown: true}, true, TokenReasonOK},
+		{"Marker inside a name", TokenInfo{Symbol: "VISITOR", Name: "Claimable Compound Iotex", HasPrice: true, PriceKnown: true}, true, TokenReasonOK},
+		{"Dotted symbol", TokenInfo{Symbol: "USDC.e", Name: "Bridged USDC.e (Avalanche)", HasPrice: true, PriceKnown: true}, true, TokenReasonOK},
+		{"No liquidity", TokenInfo{Symbol: "XYZ", PriceKnown: true}, false, TokenReasonNoLiquidity},
+		{"Priced token", TokenInfo{Symbol: "LINK", HasPrice: true, PriceKnown: true}, true, TokenReasonOK},
+		{"Provider without prices", TokenInfo{Symbol: "LINK"}, true, TokenReasonOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, reason := filter.Classify(tt.token)
+			if ok != tt.legitimate || reason != tt.reason {
+				t.Errorf("Classify() = (%v, %s), expected (%v, %s)", ok, reason, tt.legitimate, tt.reason)
+			}
+		})
+	}
+}
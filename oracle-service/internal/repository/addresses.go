@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// normalizeAddress is the form addresses are stored and looked up in, so one wallet
+// maps to one row whatever case it was written in
+func normalizeAddress(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
+
+// addressIndexes are case-insensitive unique indexes on the tables holding one row per
+// address, backing up normalization at the repository boundary
+var addressIndexes = map[string]string{
+	"idx_credit_scores_user_address_lower":       "credit_scores",
+	"idx_on_chain_metrics_user_address_lower":    "on_chain_metrics",
+	"idx_off_chain_metrics_user_address_lower":   "off_chain_metrics",
+	"idx_provider_agreements_user_address_lower": "provider_agreements",
+}
+
+// NormalizeAddresses lowercases the stored addresses of every score table, merging the
+// rows of addresses stored in more than one case, and then adds case-insensitive unique
+// indexes so no address can be stored twice again. Merged addresses keep the most
+// recently updated score, metrics and provider agreement, the update counts of all their scores, and all
+// of their history, oracle updates and events; events are renumbered in the order they
+// were recorded. It returns the number of addresses whose rows were merged, and is a
+// no-op once addresses are normalized.
+func NormalizeAddresses(db *gorm.DB) (int, error) {
+	var merged int
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var addresses []string
+		if err := tx.Model(&models.CreditScore{}).
+			Distinct("LOWER(user_address)").
+			Where("user_address <> LOWER(user_address)").
+			Pluck("LOWER(user_address)", &addresses).Error; err != nil {
+			return err
+		}
+
+		for _, address := range addresses {
+			duplicated, err := mergeScores(tx, address)
+			if err != nil {
+				return fmt.Errorf("address %s: %w", address, err)
+			}
+			if duplicated {
+				merged++
+			}
+		}
+
+		for _, model := range []interface{}{&models.OnChainMetrics{}, &models.OffChainMetrics{}, &models.ProviderAgreement{}} {
+			if err := keepLatestPerAddress(tx, model, "user_address"); err != nil {
+				return err
+			}
+		}
+		if err := keepLatestPerAddress(tx, &models.PositionHealth{}, "user_address", "protocol"); err != nil {
+			return err
+		}
+		if err := keepLatestPerAddress(tx, &models.BureauLink{}, "user_address", "provider", "consumer_hash"); err != nil {
+			return err
+		}
+		if err := renumberScoreEvents(tx); err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{
+			&models.ScoreHistory{},
+			&models.OracleUpdate{},
+			&models.ScoreShare{},
+			&models.IssuedCredential{},
+		} {
+			if err := tx.Model(model).
+				Where("user_address <> LOWER(user_address)").
+				Update("user_address", gorm.Expr("LOWER(user_address)")).Error; err != nil {
+				return err
+			}
+		}
+
+		for name, table := range addressIndexes {
+			if err := tx.Exec(fmt.Sprintf(
+				"CREATE UNIQUE INDEX IF NOT EXISTS %s ON %s (LOWER(user_address))", name, table,
+			)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to normalize addresses: %w", err)
+	}
+
+	return merged, nil
+}
+
+// mergeScores merges the credit scores stored under any case of address into one
+// lowercase row. It reports whether there was more than one row.
+func mergeScores(tx *gorm.DB, address string) (bool, error) {
+	var scores []*models.CreditScore
+	if err := tx.Where("LOWER(user_address) = ?", address).
+		Order("is_active DESC, last_updated DESC, id DESC").
+		Find(&scores).Error; err != nil {
+		return false, err
+	}
+	if len(scores) == 0 {
+		return false, nil
+	}
+
+	kept := scores[0]
+	var duplicates []uint
+	for _, score := range scores[1:] {
+		kept.UpdateCount += score.UpdateCount
+		if score.CreatedAt.Before(kept.CreatedAt) {
+			kept.CreatedAt = score.CreatedAt
+		}
+		duplicates = append(duplicates, score.ID)
+	}
+	if len(duplicates) > 0 {
+		if err := tx.Delete(&models.CreditScore{}, duplicates).Error; err != nil {
+			return false, err
+		}
+	}
+
+	kept.UserAddress = address
+	err := tx.Model(kept).Updates(map[string]interface{}{
+		"user_address": kept.UserAddress,
+		"update_count": kept.UpdateCount,
+		"created_at":   kept.CreatedAt,
+	}).Error
+	return len(duplicates) > 0, err
+}
+
+// keepLatestPerAddress lowercases the addresses of a table holding one row per address
+// and the given key columns, deleting all but the most recently updated row of keys
+// stored under more than one case of an address
+func keepLatestPerAddress(tx *gorm.DB, model interface{}, columns ...string) error {
+	type row struct {
+		ID       uint
+		MergeKey string
+	}
+	key := "LOWER(" + columns[0] + ")"
+	for _, column := range columns[1:] {
+		key += " || ':' || " + column
+	}
+
+	mixed := tx.Model(model).Select(key).Where("user_address <> LOWER(user_address)")
+	var rows []row
+	if err := tx.Model(model).
+		Select("id, "+key+" AS merge_key").
+		Where(key+" IN (?)", mixed).
+		Order("updated_at DESC, id DESC").
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	var stale []uint
+	for _, r := range rows {
+		if seen[r.MergeKey] {
+			stale = append(stale, r.ID)
+			continue
+		}
+		seen[r.MergeKey] = true
+	}
+	if len(stale) > 0 {
+		if err := tx.Where("id IN ?", stale).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Model(model).
+		Where("user_address <> LOWER(user_address)").
+		Update("user_address", gorm.Expr("LOWER(user_address)")).Error
+}
+
+// renumberScoreEvents merges the event streams of addresses stored in more than one
+// case, renumbering each merged stream in the order its events were recorded
+func renumberScoreEvents(tx *gorm.DB) error {
+	var addresses []string
+	if err := tx.Model(&models.ScoreEvent{}).
+		Distinct("LOWER(user_address)").
+		Where("user_address <> LOWER(user_address)").
+		Pluck("LOWER(user_address)", &addresses).Error; err != nil {
+		return err
+	}
+
+	for _, address := range addresses {
+		var events []*models.ScoreEvent
+		if err := tx.Where("LOWER(user_address) = ?", address).
+			Order("created_at ASC, id ASC").
+			Find(&events).Error; err != nil {
+			return err
+		}
+
+		// Moved past every existing sequence first so no step collides with an event
+		// that has not been renumbered yet
+		var offset uint64
+		for _, event := range events {
+			if event.Sequence > offset {
+				offset = event.Sequence
+			}
+		}
+		for _, shift := range []uint64{offset, 0} {
+			for i, event := range events {
+				if err := tx.Model(event).Updates(map[string]interface{}{
+					"user_address": address,
+					"sequence":     shift + uint64(i) + 1,
+				}).Error; err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
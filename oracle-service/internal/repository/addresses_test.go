@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+func TestNormalizeAddressesMergesDuplicates(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewScoreRepository(db)
+	ctx := context.Background()
+
+	checksummed := "0xAbCdEf0123456789aBcDeF0123456789AbCdEf01"
+	lower := "0xabcdef0123456789abcdef0123456789abcdef01"
+	older := time.Now().Add(-48 * time.Hour)
+
+	// Rows written before addresses were normalized, bypassing the repository
+	rows := []interface{}{
+		&models.CreditScore{UserAddress: checksummed, Score: 610, Confidence: 70, DataHash: "old", LastUpdated: older, UpdateCount: 3, IsActive: true},
+		&models.CreditScore{UserAddress: lower, Score: 700, Confidence: 80, DataHash: "new", LastUpdated: time.Now(), UpdateCount: 2, IsActive: true},
+		&models.ScoreHistory{UserAddress: checksummed, Score: 610, Confidence: 70, DataHash: "old", Timestamp: older},
+		&models.ScoreHistory{UserAddress: lower, Score: 700, Confidence: 80, DataHash: "new", Timestamp: time.Now()},
+		&models.OnChainMetrics{UserAddress: checksummed, WalletAge: 10},
+		&models.OnChainMetrics{UserAddress: lower, WalletAge: 12},
+		&models.ProviderAgreement{UserAddress: checksummed, Comparisons: 4, UpdatedAt: older},
+		&models.ProviderAgreement{UserAddress: lower, Comparisons: 9},
+		&models.ScoreEvent{UserAddress: checksummed, Sequence: 1, EventType: models.ScoreEventCalculated, Payload: "{}", CreatedAt: older},
+		&models.ScoreEvent{UserAddress: lower, Sequence: 1, EventType: models.ScoreEventCalculated, Payload: "{}"},
+		&models.ScoreEvent{UserAddress: lower, Sequence: 2, EventType: models.ScoreEventPublished, Payload: "{}"},
+	}
+	for _, row := range rows {
+		if err := db.Create(row).Error; err != nil {
+			t.Fatalf("Failed to create row: %v", err)
+		}
+	}
+
+	merged, err := NormalizeAddresses(db)
+	if err != nil {
+		t.Fatalf("Failed to normalize addresses: %v", err)
+	}
+	if merged != 1 {
+		t.Errorf("Expected 1 merged address, got %d", merged)
+	}
+
+	var scores []models.CreditScore
+	db.Find(&scores)
+	if len(scores) != 1 || scores[0].UserAddress != lower || scores[0].Score != 700 || scores[0].UpdateCount != 5 {
+		t.Fatalf("Expected the latest score with both update counts, got %+v", scores)
+	}
+
+	history, _ := repo.GetHistory(ctx, checksummed, 10)
+	if len(history) != 2 {
+		t.Errorf("Expected both history records, got %d", len(history))
+	}
+	metrics, _ := repo.GetOnChainMetrics(ctx, checksummed)
+	if metrics == nil || metrics.WalletAge != 12 {
+		t.Errorf("Expected the latest on-chain metrics, got %+v", metrics)
+	}
+	agreement, _ := repo.GetProviderAgreement(ctx, checksummed)
+	if agreement == nil || agreement.Comparisons != 9 {
+		t.Errorf("Expected the latest provider agreement, got %+v", agreement)
+	}
+	var agreements int64
+	db.Model(&models.ProviderAgreement{}).Count(&agreements)
+	if agreements != 1 {
+		t.Errorf("Expected one provider agreement, got %d", agreements)
+	}
+	events, _ := repo.ListScoreEvents(ctx, checksummed, 0, 0)
+	if len(events) != 3 || events[0].Sequence != 1 || events[2].Sequence != 3 || events[2].EventType != models.ScoreEventPublished {
+		t.Errorf("Expected one renumbered event stream, got %+v", events)
+	}
+
+	// The database rejects another case of the address
+	duplicate := &models.CreditScore{UserAddress: checksummed, Score: 650, Confidence: 75, DataHash: "dup", LastUpdated: time.Now()}
+	if err := db.Create(duplicate).Error; err == nil {
+		t.Error("Expected a unique index violation for a mixed-case duplicate")
+	}
+	if err := db.Create(&models.ProviderAgreement{UserAddress: checksummed}).Error; err == nil {
+		t.Error("Expected a unique index violation for a mixed-case provider agreement")
+	}
+
+	// Running again changes nothing
+	if merged, err := NormalizeAddresses(db); err != nil || merged != 0 {
+		t.Errorf("Expected a no-op, got %d, %v", merged, err)
+	}
+}
+
+func TestRepositoryNormalizesAddresses(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewScoreRepository(db)
+	ctx := context.Background()
+
+	score := &models.CreditScore{
+		UserAddress: "0xAbCdEf0123456789aBcDeF0123456789AbCdEf01",
+		Score:       700,
+		Confidence:  80,
+		DataHash:    "hash",
+		LastUpdated: time.Now(),
+		IsActive:    true,
+	}
+	if err := repo.Create(ctx, score); err != nil {
+		t.Fatalf("Failed to create score: %v", err)
+	}
+	if score.UserAddress != "0xabcdef0123456789abcdef0123456789abcdef01" {
+		t.Errorf("Expected a lowercase address, got %s", score.UserAddress)
+	}
+
+	found, err := repo.GetByAddress(ctx, "0xABCDEF0123456789ABCDEF0123456789ABCDEF01")
+	if err != nil || found == nil || found.ID != score.ID {
+		t.Errorf("Expected the score whatever the case of the lookup, got %+v, %v", found, err)
+	}
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// AuditRepository handles database operations for the audit log
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository creates a new audit repository
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Record appends an entry to the audit log
+func (r *AuditRepository) Record(ctx context.Context, entry *models.AuditLog) error {
+	if err := r.db.WithContext(ctx).Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to record audit log entry: %w", err)
+	}
+	return nil
+}
+
+// List retrieves audit log entries, newest first, optionally filtered by user address
+// and action
+func (r *AuditRepository) List(ctx context.Context, address, action string, limit int) ([]*models.AuditLog, error) {
+	query := r.db.WithContext(ctx).Order("id DESC").Limit(limit)
+	if address != "" {
+		query = query.Where("LOWER(user_address) = ?", strings.ToLower(address))
+	}
+	if action != "" {
+		query = query.Where("action = ?", action)
+	}
+
+	var entries []*models.AuditLog
+	if err := query.Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to list audit log: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// BureauRepository handles database operations for bureau consumer links and alerts
+type BureauRepository struct {
+	db *gorm.DB
+}
+
+// NewBureauRepository creates a new bureau repository
+func NewBureauRepository(db *gorm.DB) *BureauRepository {
+	return &BureauRepository{db: db}
+}
+
+// LinkConsumer records that a bureau consumer owns the given address. Existing links are kept.
+func (r *BureauRepository) LinkConsumer(ctx context.Context, provider, consumerHash, address string) error {
+	address = normalizeAddress(address)
+	link := models.BureauLink{
+		Provider:     provider,
+		ConsumerHash: consumerHash,
+		UserAddress:  address,
+	}
+
+	err := r.db.WithContext(ctx).
+		Where(models.BureauLink{Provider: provider, ConsumerHash: consumerHash, UserAddress: address}).
+		FirstOrCreate(&link).Error
+	if err != nil {
+		return fmt.Errorf("failed to link bureau consumer: %w", err)
+	}
+
+	return nil
+}
+
+// GetLinkedAddresses retrieves the addresses linked to a bureau consumer
+func (r *BureauRepository) GetLinkedAddresses(ctx context.Context, provider, consumerHash string) ([]string, error) {
+	var addresses []string
+	err := r.db.WithContext(ctx).
+		Model(&models.BureauLink{}).
+		Where("provider = ? AND consumer_hash = ?", provider, consumerHash).
+		Order("user_address ASC").
+		Pluck("user_address", &addresses).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get linked addresses: %w", err)
+	}
+
+	return addresses, nil
+}
+
+// GetAlert retrieves a previously received alert by the bureau's alert ID
+func (r *BureauRepository) GetAlert(ctx context.Context, alertID string) (*models.BureauAlert, error) {
+	var alert models.BureauAlert
+	err := r.db.WithContext(ctx).
+		Where("alert_id = ?", alertID).
+		First(&alert).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bureau alert: %w", err)
+	}
+
+	return &alert, nil
+}
+
+// CreateAlert records a received alert
+func (r *BureauRepository) CreateAlert(ctx context.Context, alert *models.BureauAlert) error {
+	return r.db.WithContext(ctx).Create(alert).Error
+}
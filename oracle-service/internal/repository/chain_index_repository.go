@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrCheckpointMoved is returned when a batch does not start right after the stored
+// checkpoint, e.g. because another ingester indexed the same blocks
+var ErrCheckpointMoved = errors.New("chain index checkpoint moved")
+
+// chainIndexChunk bounds the rows written per insert and the addresses per IN query
+const chainIndexChunk = 500
+
+// ChainIndexBatch is what the block ingester found in a run of consecutive blocks
+type ChainIndexBatch struct {
+	ChainID        string
+	FromBlock      uint64
+	ToBlock        uint64
+	BlockTime      time.Time // Timestamp of ToBlock
+	Transactions   []*models.ChainIndexTransaction
+	TokenTransfers []*models.ChainIndexTokenTransfer
+	Addresses      []*models.ChainIndexAddress // Activity in these blocks, added to the stored activity
+}
+
+// ChainIndexRepository handles database operations for the self-hosted chain index
+type ChainIndexRepository struct {
+	db *gorm.DB
+}
+
+// NewChainIndexRepository creates a new chain index repository
+func NewChainIndexRepository(db *gorm.DB) *ChainIndexRepository {
+	return &ChainIndexRepository{db: db}
+}
+
+// GetCheckpoint retrieves how far a chain is indexed, or nil if indexing hasn't started
+func (r *ChainIndexRepository) GetCheckpoint(ctx context.Context, chainID string) (*models.ChainIndexCheckpoint, error) {
+	var checkpoint models.ChainIndexCheckpoint
+	err := r.db.WithContext(ctx).Where("chain_id = ?", chainID).First(&checkpoint).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get chain index checkpoint: %w", err)
+	}
+	return &checkpoint, nil
+}
+
+// SaveBatch stores a batch of indexed blocks and moves the checkpoint to its last block
+// in one transaction, so a batch is either indexed completely or not at all. The batch
+// must start right after the checkpoint, or at any block when there is none yet.
+func (r *ChainIndexRepository) SaveBatch(ctx context.Context, batch *ChainIndexBatch) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var checkpoint models.ChainIndexCheckpoint
+		err := tx.Where("chain_id = ?", batch.ChainID).First(&checkpoint).Error
+		switch {
+		case err == gorm.ErrRecordNotFound:
+		case err != nil:
+			return err
+		case checkpoint.LastBlock+1 != batch.FromBlock:
+			return fmt.Errorf("%w: indexed to block %d, batch starts at %d", ErrCheckpointMoved, checkpoint.LastBlock, batch.FromBlock)
+		}
+
+		// Rows of a batch that was written but not checkpointed are skipped
+		ignore := clause.OnConflict{DoNothing: true}
+		if len(batch.Transactions) > 0 {
+			if err := tx.Clauses(ignore).CreateInBatches(batch.Transactions, chainIndexChunk).Error; err != nil {
+				return err
+			}
+		}
+		if len(batch.TokenTransfers) > 0 {
+			if err := tx.Clauses(ignore).CreateInBatches(batch.TokenTransfers, chainIndexChunk).Error; err != nil {
+				return err
+			}
+		}
+		if err := mergeChainIndexAddresses(tx, batch.ChainID, batch.Addresses); err != nil {
+			return err
+		}
+
+		return tx.Save(&models.ChainIndexCheckpoint{
+			ChainID:   batch.ChainID,
+			LastBlock: batch.ToBlock,
+			BlockTime: batch.BlockTime,
+		}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save chain index batch: %w", err)
+	}
+	return nil
+}
+
+// mergeChainIndexAddresses adds the activity of a batch to the stored activity of its
+// addresses
+func mergeChainIndexAddresses(tx *gorm.DB, chainID string, activity []*models.ChainIndexAddress) error {
+	for start := 0; start < len(activity); start += chainIndexChunk {
+		chunk := activity[start:min(start+chainIndexChunk, len(activity))]
+		addresses := make([]string, len(chunk))
+		for i, a := range chunk {
+			addresses[i] = a.Address
+		}
+
+		var stored []*models.ChainIndexAddress
+		if err := tx.Where("chain_id = ? AND address IN ?", chainID, addresses).Find(&stored).Error; err != nil {
+			return err
+		}
+		byAddress := make(map[string]*models.ChainIndexAddress, len(stored))
+		for _, s := range stored {
+			byAddress[s.Address] = s
+		}
+
+		for _, a := range chunk {
+			s, ok := byAddress[a.Address]
+			if !ok {
+				if err := tx.Create(a).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if a.FirstBlock < s.FirstBlock {
+				s.FirstBlock, s.FirstSeen = a.FirstBlock, a.FirstSeen
+			}
+			if a.LastSeen.After(s.LastSeen) {
+				s.LastSeen = a.LastSeen
+			}
+			s.Sent += a.Sent
+			s.Received += a.Received
+			s.Failed += a.Failed
+			s.TokenTransfers += a.TokenTransfers
+			s.ValueSent = s.ValueSent.Add(a.ValueSent)
+			s.ValueReceived = s.ValueReceived.Add(a.ValueReceived)
+			if err := tx.Save(s).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GetAddress retrieves the indexed activity of an address, or nil if it was never seen
+func (r *ChainIndexRepository) GetAddress(ctx context.Context, chainID, address string) (*models.ChainIndexAddress, error) {
+	var activity models.ChainIndexAddress
+	err := r.db.WithContext(ctx).
+		Where("chain_id = ? AND address = ?", chainID, normalizeAddress(address)).
+		First(&activity).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get indexed address: %w", err)
+	}
+	return &activity, nil
+}
+
+// ListTransactions retrieves the most recent indexed transactions sent or received by
+// an address, newest first
+func (r *ChainIndexRepository) ListTransactions(ctx context.Context, chainID, address string, limit int) ([]*models.ChainIndexTransaction, error) {
+	address = normalizeAddress(address)
+	var txs []*models.ChainIndexTransaction
+	err := r.db.WithContext(ctx).
+		Where("chain_id = ? AND (from_address = ? OR to_address = ?)", chainID, address, address).
+		Order("block_number DESC, id DESC").
+		Limit(limit).
+		Find(&txs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexed transactions: %w", err)
+	}
+	return txs, nil
+}
+
+// ListTokenTransfers retrieves the most recent indexed token transfers from or to an
+// address, newest first
+func (r *ChainIndexRepository) ListTokenTransfers(ctx context.Context, chainID, address string, limit int) ([]*models.ChainIndexTokenTransfer, error) {
+	address = normalizeAddress(address)
+	var transfers []*models.ChainIndexTokenTransfer
+	err := r.db.WithContext(ctx).
+		Where("chain_id = ? AND (from_address = ? OR to_address = ?)", chainID, address, address).
+		Order("block_number DESC, log_index DESC").
+		Limit(limit).
+		Find(&transfers).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexed token transfers: %w", err)
+	}
+	return transfers, nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// DebugRepository handles database operations for debug targets and traces
+type DebugRepository struct {
+	db *gorm.DB
+}
+
+// NewDebugRepository creates a new debug repository
+func NewDebugRepository(db *gorm.DB) *DebugRepository {
+	return &DebugRepository{db: db}
+}
+
+// CreateTarget saves a new debug target
+func (r *DebugRepository) CreateTarget(ctx context.Context, target *models.DebugTarget) error {
+	if err := r.db.WithContext(ctx).Create(target).Error; err != nil {
+		return fmt.Errorf("failed to create debug target: %w", err)
+	}
+	return nil
+}
+
+// ListActiveTargets retrieves the targets that have not expired at now, newest first
+func (r *DebugRepository) ListActiveTargets(ctx context.Context, now time.Time) ([]*models.DebugTarget, error) {
+	var targets []*models.DebugTarget
+	err := r.db.WithContext(ctx).
+		Where("expires_at > ?", now).
+		Order("id DESC").
+		Find(&targets).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list debug targets: %w", err)
+	}
+
+	return targets, nil
+}
+
+// DeleteTarget removes a debug target. It reports whether the target existed.
+func (r *DebugRepository) DeleteTarget(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).Delete(&models.DebugTarget{}, id)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to delete debug target: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CreateTrace saves a recorded trace
+func (r *DebugRepository) CreateTrace(ctx context.Context, trace *models.DebugTrace) error {
+	if err := r.db.WithContext(ctx).Create(trace).Error; err != nil {
+		return fmt.Errorf("failed to create debug trace: %w", err)
+	}
+	return nil
+}
+
+// ListTraces retrieves traces, newest first, optionally filtered by address and
+// target. Bodies and provider calls are left out.
+func (r *DebugRepository) ListTraces(ctx context.Context, address string, targetID uint, limit int) ([]*models.DebugTrace, error) {
+	query := r.db.WithContext(ctx).
+		Omit("request_body", "response_body", "provider_calls").
+		Order("id DESC").
+		Limit(limit)
+	if address != "" {
+		query = query.Where("LOWER(address) = ?", strings.ToLower(address))
+	}
+	if targetID != 0 {
+		query = query.Where("target_id = ?", targetID)
+	}
+
+	var traces []*models.DebugTrace
+	if err := query.Find(&traces).Error; err != nil {
+		return nil, fmt.Errorf("failed to list debug traces: %w", err)
+	}
+
+	return traces, nil
+}
+
+// GetTrace retrieves a trace by ID. It returns nil if the trace does not exist.
+func (r *DebugRepository) GetTrace(ctx context.Context, id uint) (*models.DebugTrace, error) {
+	var trace models.DebugTrace
+	err := r.db.WithContext(ctx).First(&trace, id).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debug trace: %w", err)
+	}
+
+	return &trace, nil
+}
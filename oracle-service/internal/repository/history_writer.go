@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// HistoryWriter queues score history records and inserts them in batches from a
+// background goroutine, so a batch refresh doesn't pay for a history insert per
+// score. A record is written synchronously instead when the queue is full or the
+// writer is closed, so history is never dropped; records queued when the process
+// dies without closing the writer are lost.
+type HistoryWriter struct {
+	repo          *ScoreRepository
+	queue         chan *models.ScoreHistory
+	batchSize     int
+	flushInterval time.Duration
+	done          chan struct{}
+	mu            sync.RWMutex
+	closed        bool
+}
+
+// NewHistoryWriter starts writing queued history records through repo, in batches of
+// up to batchSize and at least every flushInterval
+func NewHistoryWriter(repo *ScoreRepository, bufferSize, batchSize int, flushInterval time.Duration) *HistoryWriter {
+	if bufferSize <= 0 {
+		bufferSize = 1000
+	}
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+
+	w := &HistoryWriter{
+		repo:          repo,
+		queue:         make(chan *models.ScoreHistory, bufferSize),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		done:          make(chan struct{}),
+	}
+	go w.run()
+
+	return w
+}
+
+// Write queues a history record without blocking, writing it synchronously if the
+// queue is full or the writer is closed
+func (w *HistoryWriter) Write(ctx context.Context, history *models.ScoreHistory) error {
+	w.mu.RLock()
+	if !w.closed {
+		select {
+		case w.queue <- history:
+			w.mu.RUnlock()
+			return nil
+		default:
+		}
+	}
+	w.mu.RUnlock()
+
+	return w.repo.CreateHistory(ctx, history)
+}
+
+// Close writes the queued records and stops the writer. Later writes are synchronous.
+func (w *HistoryWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.queue)
+	w.mu.Unlock()
+
+	<-w.done
+	return nil
+}
+
+func (w *HistoryWriter) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*models.ScoreHistory, 0, w.batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		if err := w.repo.CreateHistoryBatch(ctx, batch); err != nil {
+			logger.Error("Failed to write score history batch", zap.Int("records", len(batch)), zap.Error(err))
+		}
+		cancel()
+		batch = make([]*models.ScoreHistory, 0, w.batchSize)
+	}
+
+	for {
+		select {
+		case history, ok := <-w.queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, history)
+			if len(batch) >= w.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+func TestHistoryWriterBatchesAndFlushesOnClose(t *testing.T) {
+	db := setupTestDB(t)
+	// Each connection to an in-memory database is a separate database
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	repo := NewScoreRepository(db)
+	ctx := context.Background()
+	address := "0x1234567890123456789012345678901234567890"
+
+	// A long flush interval leaves the last partial batch to Close
+	writer := NewHistoryWriter(repo, 100, 10, time.Hour)
+	for i := 0; i < 25; i++ {
+		err := writer.Write(ctx, &models.ScoreHistory{
+			UserAddress: address,
+			Score:       700,
+			Confidence:  80,
+			DataHash:    "hash",
+			Timestamp:   time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to queue history: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close writer: %v", err)
+	}
+
+	history, err := repo.GetHistory(ctx, address, 100)
+	if err != nil || len(history) != 25 {
+		t.Fatalf("Expected 25 history records after close, got %d, %v", len(history), err)
+	}
+
+	// Writes after Close go straight to the database
+	if err := writer.Write(ctx, &models.ScoreHistory{UserAddress: address, Score: 710, Confidence: 80, DataHash: "hash", Timestamp: time.Now()}); err != nil {
+		t.Fatalf("Failed to write history: %v", err)
+	}
+	if history, _ := repo.GetHistory(ctx, address, 100); len(history) != 26 {
+		t.Errorf("Expected a synchronous write after close, got %d records", len(history))
+	}
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// JobRunRepository handles database operations for the runs of scheduled jobs
+type JobRunRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRunRepository creates a new job run repository
+func NewJobRunRepository(db *gorm.DB) *JobRunRepository {
+	return &JobRunRepository{db: db}
+}
+
+// CreateJobRun records the start of a job run
+func (r *JobRunRepository) CreateJobRun(ctx context.Context, run *models.JobRun) error {
+	if err := r.db.WithContext(ctx).Create(run).Error; err != nil {
+		return fmt.Errorf("failed to create job run: %w", err)
+	}
+	return nil
+}
+
+// SaveJobRun saves a job run's outcome
+func (r *JobRunRepository) SaveJobRun(ctx context.Context, run *models.JobRun) error {
+	if err := r.db.WithContext(ctx).Save(run).Error; err != nil {
+		return fmt.Errorf("failed to save job run: %w", err)
+	}
+	return nil
+}
+
+// ListJobRuns lists up to limit runs, newest first, optionally of one job
+func (r *JobRunRepository) ListJobRuns(ctx context.Context, job string, limit int) ([]*models.JobRun, error) {
+	query := r.db.WithContext(ctx)
+	if job != "" {
+		query = query.Where("job = ?", job)
+	}
+
+	var runs []*models.JobRun
+	err := query.
+		Order("started_at DESC").
+		Order("id DESC").
+		Limit(limit).
+		Find(&runs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job runs: %w", err)
+	}
+	return runs, nil
+}
+
+// LatestJobRuns returns the latest run of every job that has run, by job name
+func (r *JobRunRepository) LatestJobRuns(ctx context.Context) ([]*models.JobRun, error) {
+	latest := r.db.Model(&models.JobRun{}).
+		Select("MAX(id)").
+		Group("job")
+
+	var runs []*models.JobRun
+	err := r.db.WithContext(ctx).
+		Where("id IN (?)", latest).
+		Order("job ASC").
+		Find(&runs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest job runs: %w", err)
+	}
+	return runs, nil
+}
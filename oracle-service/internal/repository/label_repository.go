@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// LabelRepository handles database operations for address labels
+type LabelRepository struct {
+	db *gorm.DB
+}
+
+// NewLabelRepository creates a new label repository
+func NewLabelRepository(db *gorm.DB) *LabelRepository {
+	return &LabelRepository{db: db}
+}
+
+// List retrieves labels, optionally filtered by category
+func (r *LabelRepository) List(ctx context.Context, category string) ([]*models.AddressLabel, error) {
+	var labels []*models.AddressLabel
+	query := r.db.WithContext(ctx).Order("category ASC, name ASC")
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+
+	if err := query.Find(&labels).Error; err != nil {
+		return nil, fmt.Errorf("failed to list address labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+// GetByAddress retrieves the label for an address
+func (r *LabelRepository) GetByAddress(ctx context.Context, address string) (*models.AddressLabel, error) {
+	var label models.AddressLabel
+	err := r.db.WithContext(ctx).
+		Where("address = ?", address).
+		First(&label).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address label: %w", err)
+	}
+
+	return &label, nil
+}
+
+// Upsert creates or updates the label for an address
+func (r *LabelRepository) Upsert(ctx context.Context, label *models.AddressLabel) error {
+	existing, err := r.GetByAddress(ctx, label.Address)
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		return r.db.WithContext(ctx).Create(label).Error
+	}
+
+	label.ID = existing.ID
+	label.CreatedAt = existing.CreatedAt
+	return r.db.WithContext(ctx).Save(label).Error
+}
+
+// CreateIfMissing inserts labels whose address is not yet stored, leaving existing
+// (possibly admin-edited) rows untouched. It returns the number inserted.
+func (r *LabelRepository) CreateIfMissing(ctx context.Context, labels []models.AddressLabel) (int, error) {
+	created := 0
+	for i := range labels {
+		existing, err := r.GetByAddress(ctx, labels[i].Address)
+		if err != nil {
+			return created, err
+		}
+		if existing != nil {
+			continue
+		}
+		if err := r.db.WithContext(ctx).Create(&labels[i]).Error; err != nil {
+			return created, fmt.Errorf("failed to seed address label: %w", err)
+		}
+		created++
+	}
+	return created, nil
+}
+
+// Delete removes the label for an address
+func (r *LabelRepository) Delete(ctx context.Context, address string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("address = ?", address).
+		Delete(&models.AddressLabel{})
+
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to delete address label: %w", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+func TestLabelRepositorySeedAndUpsert(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewLabelRepository(db)
+	ctx := context.Background()
+
+	address := "0x28c6c06298d514db089934071355e5743bf21d60"
+
+	// Admin edit made before seeding must survive
+	err := repo.Upsert(ctx, &models.AddressLabel{
+		Address:  address,
+		Name:     "Binance (reviewed)",
+		Category: models.LabelCategoryExchange,
+		Source:   models.LabelSourceAdmin,
+	})
+	if err != nil {
+		t.Fatalf("Failed to upsert label: %v", err)
+	}
+
+	created, err := repo.CreateIfMissing(ctx, []models.AddressLabel{
+		{Address: address, Name: "Binance 14", Category: models.LabelCategoryExchange, Source: models.LabelSourceSeed},
+		{Address: "0x910cbd523d972eb0a6f4cae4618ad62622b39dbf", Name: "Tornado Cash 10 ETH", Category: models.LabelCategoryMixer, Source: models.LabelSourceSeed},
+	})
+	if err != nil {
+		t.Fatalf("Failed to seed labels: %v", err)
+	}
+	if created != 1 {
+		t.Errorf("Expected 1 seeded label, got %d", created)
+	}
+
+	label, err := repo.GetByAddress(ctx, address)
+	if err != nil || label == nil {
+		t.Fatalf("Failed to get label: %v", err)
+	}
+	if label.Source != models.LabelSourceAdmin {
+		t.Errorf("Expected admin label to be kept, got source %s", label.Source)
+	}
+
+	mixers, err := repo.List(ctx, models.LabelCategoryMixer)
+	if err != nil {
+		t.Fatalf("Failed to list labels: %v", err)
+	}
+	if len(mixers) != 1 {
+		t.Errorf("Expected 1 mixer label, got %d", len(mixers))
+	}
+
+	deleted, err := repo.Delete(ctx, address)
+	if err != nil || !deleted {
+		t.Errorf("Expected label to be deleted, got deleted=%v err=%v", deleted, err)
+	}
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// CreateLiquidationRescore records a detected liquidation for the fast path
+func (r *ScoreRepository) CreateLiquidationRescore(ctx context.Context, rescore *models.LiquidationRescore) error {
+	rescore.UserAddress = normalizeAddress(rescore.UserAddress)
+	if err := r.db.WithContext(ctx).Create(rescore).Error; err != nil {
+		return fmt.Errorf("failed to create liquidation rescore: %w", err)
+	}
+	return nil
+}
+
+// UpdateLiquidationRescore saves a liquidation rescore's progress
+func (r *ScoreRepository) UpdateLiquidationRescore(ctx context.Context, rescore *models.LiquidationRescore) error {
+	if err := r.db.WithContext(ctx).Save(rescore).Error; err != nil {
+		return fmt.Errorf("failed to update liquidation rescore: %w", err)
+	}
+	return nil
+}
+
+// GetLiquidationRescore retrieves a liquidation rescore by ID, or nil if it doesn't exist
+func (r *ScoreRepository) GetLiquidationRescore(ctx context.Context, id uint) (*models.LiquidationRescore, error) {
+	var rescore models.LiquidationRescore
+	err := r.db.WithContext(ctx).First(&rescore, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get liquidation rescore: %w", err)
+	}
+	return &rescore, nil
+}
+
+// GetPendingLiquidationRescore retrieves the address's liquidation rescore still
+// waiting for the fast path, or nil if there is none
+func (r *ScoreRepository) GetPendingLiquidationRescore(ctx context.Context, address string) (*models.LiquidationRescore, error) {
+	var rescore models.LiquidationRescore
+	err := r.db.WithContext(ctx).
+		Where("user_address = ? AND status = ?", normalizeAddress(address), models.LiquidationRescorePending).
+		Order("detected_at ASC").
+		First(&rescore).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending liquidation rescore: %w", err)
+	}
+	return &rescore, nil
+}
+
+// ListPendingLiquidationRescores lists up to limit liquidation rescores waiting for the
+// fast path, oldest detection first
+func (r *ScoreRepository) ListPendingLiquidationRescores(ctx context.Context, limit int) ([]*models.LiquidationRescore, error) {
+	var rescores []*models.LiquidationRescore
+	err := r.db.WithContext(ctx).
+		Where("status = ?", models.LiquidationRescorePending).
+		Order("detected_at ASC").
+		Limit(limit).
+		Find(&rescores).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending liquidation rescores: %w", err)
+	}
+	return rescores, nil
+}
+
+// ListLiquidationRescores lists the liquidation rescores detected since the given
+// time, newest first
+func (r *ScoreRepository) ListLiquidationRescores(ctx context.Context, since time.Time) ([]*models.LiquidationRescore, error) {
+	var rescores []*models.LiquidationRescore
+	err := r.reader(ctx, "").
+		Where("detected_at >= ?", since).
+		Order("detected_at DESC").
+		Find(&rescores).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list liquidation rescores: %w", err)
+	}
+	return rescores, nil
+}
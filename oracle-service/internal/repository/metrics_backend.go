@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+	"gorm.io/gorm"
+)
+
+// Storage backends for score history, selected with METRICS_BACKEND
+const (
+	MetricsBackendPostgres  = "postgres"  // Plain tables; trends are aggregated from the raw history
+	MetricsBackendTimescale = "timescale" // TimescaleDB hypertable with a daily continuous aggregate
+)
+
+// scoreHistoryDaily is the continuous aggregate trend queries read under Timescale
+const scoreHistoryDaily = "score_history_daily"
+
+// timescaleSetup converts score_history into a hypertable partitioned by timestamp and
+// builds its daily rollup per address. Hypertables need the partitioning column in
+// every unique index, so the primary key becomes (id, timestamp) first. Real-time
+// aggregation keeps the last hour, not yet materialized by the refresh policy, in
+// query results.
+var timescaleSetup = []string{
+	`CREATE EXTENSION IF NOT EXISTS timescaledb`,
+	`ALTER TABLE score_history DROP CONSTRAINT IF EXISTS score_history_pkey`,
+	`ALTER TABLE score_history ADD PRIMARY KEY (id, "timestamp")`,
+	`SELECT create_hypertable('score_history', 'timestamp',
+		chunk_time_interval => INTERVAL '7 days', migrate_data => true)`,
+}
+
+var timescaleAggregates = []string{
+	`CREATE MATERIALIZED VIEW IF NOT EXISTS ` + scoreHistoryDaily + `
+	WITH (timescaledb.continuous, timescaledb.materialized_only = false) AS
+	SELECT time_bucket(INTERVAL '1 day', "timestamp") AS bucket,
+		user_address,
+		SUM(score) AS score_sum,
+		MIN(score) AS min_score,
+		MAX(score) AS max_score,
+		COUNT(*) AS updates
+	FROM score_history
+	GROUP BY bucket, user_address
+	WITH NO DATA`,
+	`SELECT add_continuous_aggregate_policy('` + scoreHistoryDaily + `',
+		start_offset => INTERVAL '3 days', end_offset => INTERVAL '1 hour',
+		schedule_interval => INTERVAL '1 hour', if_not_exists => true)`,
+}
+
+// EnableTimescale stores score history in a TimescaleDB hypertable with a continuous
+// aggregate of daily scores per address, creating the extension if needed. Existing
+// history is migrated into the hypertable on the first run, which locks the table
+// while it copies; later runs only check that the aggregate exists.
+func EnableTimescale(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		return fmt.Errorf("timescale metrics backend needs PostgreSQL, got %s", db.Dialector.Name())
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(timescaleSetup[0]).Error; err != nil {
+			return err
+		}
+		var hypertables int64
+		if err := tx.Raw(
+			`SELECT COUNT(*) FROM timescaledb_information.hypertables WHERE hypertable_name = ?`,
+			"score_history",
+		).Scan(&hypertables).Error; err != nil {
+			return err
+		}
+		if hypertables > 0 {
+			return nil
+		}
+		for _, statement := range timescaleSetup[1:] {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create score history hypertable: %w", err)
+	}
+
+	// Continuous aggregates can't be created inside a transaction
+	for _, statement := range timescaleAggregates {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to create score history aggregate: %w", err)
+		}
+	}
+	return nil
+}
+
+// SetMetricsBackend selects where trend queries read from. The timescale backend
+// needs EnableTimescale to have run against the database.
+func (r *ScoreRepository) SetMetricsBackend(backend string) error {
+	switch backend {
+	case MetricsBackendPostgres, "":
+		r.timescale = false
+	case MetricsBackendTimescale:
+		r.timescale = true
+	default:
+		return fmt.Errorf("unknown metrics backend %q", backend)
+	}
+	return nil
+}
+
+// trendRow is a ScoreTrendPoint as scanned, with the day formatted as YYYY-MM-DD so
+// every backend returns it the same way
+type trendRow struct {
+	Day          string
+	AverageScore float64
+	MinScore     units.Score
+	MaxScore     units.Score
+	Updates      int64
+	Addresses    int64
+}
+
+// GetScoreTrend summarizes an address's score history per UTC day, oldest first,
+// from the start of the day since falls on. Days without a calculation are omitted.
+func (r *ScoreRepository) GetScoreTrend(ctx context.Context, address string, since time.Time) ([]*models.ScoreTrendPoint, error) {
+	address = normalizeAddress(address)
+	points, err := r.scoreTrend(r.reader(ctx, address), address, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score trend: %w", err)
+	}
+	return points, nil
+}
+
+// GetPopulationTrend summarizes the score history of every address per UTC day, oldest
+// first, from the start of the day since falls on
+func (r *ScoreRepository) GetPopulationTrend(ctx context.Context, since time.Time) ([]*models.ScoreTrendPoint, error) {
+	points, err := r.scoreTrend(r.reader(ctx, ""), "", since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get population trend: %w", err)
+	}
+	return points, nil
+}
+
+// scoreTrend runs the daily rollup over the raw history, or over the continuous
+// aggregate under Timescale. An empty address covers every address.
+func (r *ScoreRepository) scoreTrend(db *gorm.DB, address string, since time.Time) ([]*models.ScoreTrendPoint, error) {
+	since = since.UTC().Truncate(24 * time.Hour)
+
+	var query *gorm.DB
+	if r.timescale {
+		query = db.Table(scoreHistoryDaily).
+			Select(`to_char(bucket AT TIME ZONE 'UTC', 'YYYY-MM-DD') AS day,
+				SUM(score_sum)::float8 / SUM(updates) AS average_score,
+				MIN(min_score) AS min_score,
+				MAX(max_score) AS max_score,
+				SUM(updates)::bigint AS updates,
+				COUNT(*) AS addresses`).
+			Where("bucket >= ?", since)
+	} else {
+		query = db.Model(&models.ScoreHistory{}).
+			Select(dayExpression(db)+` AS day,
+				AVG(score) AS average_score,
+				MIN(score) AS min_score,
+				MAX(score) AS max_score,
+				COUNT(*) AS updates,
+				COUNT(DISTINCT user_address) AS addresses`).
+			Where(`"timestamp" >= ?`, since)
+	}
+	if address != "" {
+		query = query.Where("user_address = ?", address)
+	}
+
+	var rows []trendRow
+	if err := query.Group("day").Order("day ASC").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	points := make([]*models.ScoreTrendPoint, 0, len(rows))
+	for _, row := range rows {
+		day, err := time.Parse("2006-01-02", row.Day)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected trend day %q: %w", row.Day, err)
+		}
+		points = append(points, &models.ScoreTrendPoint{
+			Day:          day,
+			AverageScore: row.AverageScore,
+			MinScore:     row.MinScore,
+			MaxScore:     row.MaxScore,
+			Updates:      row.Updates,
+			Addresses:    row.Addresses,
+		})
+	}
+	return points, nil
+}
+
+// dayExpression formats a history record's UTC day as YYYY-MM-DD
+func dayExpression(db *gorm.DB) string {
+	if db.Dialector.Name() == "postgres" {
+		return `to_char("timestamp" AT TIME ZONE 'UTC', 'YYYY-MM-DD')`
+	}
+	return `strftime('%Y-%m-%d', "timestamp")`
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+func TestGetScoreTrend(t *testing.T) {
+	repo := NewScoreRepository(setupTestDB(t))
+	ctx := context.Background()
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	history := []*models.ScoreHistory{
+		{UserAddress: "0xAAA", Score: 600, Confidence: 80, DataHash: "h1", Timestamp: day.Add(-time.Hour)},
+		{UserAddress: "0xAAA", Score: 640, Confidence: 80, DataHash: "h2", Timestamp: day.Add(2 * time.Hour)},
+		{UserAddress: "0xaaa", Score: 660, Confidence: 80, DataHash: "h3", Timestamp: day.Add(20 * time.Hour)},
+		{UserAddress: "0xbbb", Score: 700, Confidence: 80, DataHash: "h4", Timestamp: day.Add(3 * time.Hour)},
+		{UserAddress: "0xaaa", Score: 680, Confidence: 80, DataHash: "h5", Timestamp: day.Add(49 * time.Hour)},
+	}
+	if err := repo.CreateHistoryBatch(ctx, history); err != nil {
+		t.Fatalf("Failed to create history: %v", err)
+	}
+
+	// Starting mid-day still covers the whole day
+	trend, err := repo.GetScoreTrend(ctx, "0xAaA", day.Add(5*time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to get score trend: %v", err)
+	}
+	if len(trend) != 2 {
+		t.Fatalf("Expected 2 days, got %d", len(trend))
+	}
+	first := trend[0]
+	if !first.Day.Equal(day) || first.Updates != 2 || first.AverageScore != 650 ||
+		first.MinScore != units.Score(640) || first.MaxScore != units.Score(660) || first.Addresses != 1 {
+		t.Errorf("Unexpected first day %+v", first)
+	}
+	if !trend[1].Day.Equal(day.AddDate(0, 0, 2)) || trend[1].AverageScore != 680 {
+		t.Errorf("Unexpected second day %+v", trend[1])
+	}
+
+	population, err := repo.GetPopulationTrend(ctx, day)
+	if err != nil {
+		t.Fatalf("Failed to get population trend: %v", err)
+	}
+	if len(population) != 2 || population[0].Addresses != 2 || population[0].Updates != 3 ||
+		population[0].MaxScore != units.Score(700) {
+		t.Errorf("Unexpected population trend %+v", population)
+	}
+
+	if err := repo.SetMetricsBackend("influx"); err == nil {
+		t.Error("Expected an unknown metrics backend to be rejected")
+	}
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// CreatePipelineTiming records the stage timings of a score calculation
+func (r *ScoreRepository) CreatePipelineTiming(ctx context.Context, timing *models.PipelineTiming) error {
+	timing.UserAddress = normalizeAddress(timing.UserAddress)
+	if err := r.db.WithContext(ctx).Create(timing).Error; err != nil {
+		return fmt.Errorf("failed to create pipeline timing: %w", err)
+	}
+	return nil
+}
+
+// RecordPublishTiming adds the publish stage to the timing of the calculation that
+// produced the published score. A failed submission marks the stage as failed, until
+// a retry replaces it. Scores calculated without a timing, or already published, are
+// left alone.
+func (r *ScoreRepository) RecordPublishTiming(ctx context.Context, address, dataHash string, publish time.Duration, failed bool) error {
+	var timing models.PipelineTiming
+	err := r.db.WithContext(ctx).
+		Where("user_address = ? AND data_hash = ?", normalizeAddress(address), dataHash).
+		Where("publish_ms IS NULL OR failed_stage = ?", models.StagePublish).
+		Order("started_at DESC").
+		First(&timing).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get pipeline timing: %w", err)
+	}
+
+	if timing.PublishMs != nil {
+		timing.TotalMs -= *timing.PublishMs
+	}
+	ms := publish.Milliseconds()
+	timing.PublishMs = &ms
+	timing.TotalMs += ms
+	timing.FailedStage = ""
+	if failed {
+		timing.FailedStage = models.StagePublish
+	}
+	if err := r.db.WithContext(ctx).Save(&timing).Error; err != nil {
+		return fmt.Errorf("failed to record publish timing: %w", err)
+	}
+	return nil
+}
+
+// ListPipelineTimings lists the timings of the calculations started since the given
+// time, oldest first
+func (r *ScoreRepository) ListPipelineTimings(ctx context.Context, since time.Time) ([]*models.PipelineTiming, error) {
+	var timings []*models.PipelineTiming
+	err := r.reader(ctx, "").
+		Where("started_at >= ?", since).
+		Order("started_at ASC").
+		Find(&timings).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pipeline timings: %w", err)
+	}
+	return timings, nil
+}
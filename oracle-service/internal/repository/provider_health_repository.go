@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// ProviderHealthRepository handles database operations for provider health history
+type ProviderHealthRepository struct {
+	db *gorm.DB
+}
+
+// NewProviderHealthRepository creates a new provider health repository
+func NewProviderHealthRepository(db *gorm.DB) *ProviderHealthRepository {
+	return &ProviderHealthRepository{db: db}
+}
+
+// ProviderHealthTotals are the observations of one provider, chain and kind in a window
+type ProviderHealthTotals struct {
+	Provider     string
+	Chain        string
+	Kind         string
+	Total        int64
+	Succeeded    int64
+	AvgLatencyMs float64
+}
+
+// CreateObservations stores provider health observations in one insert
+func (r *ProviderHealthRepository) CreateObservations(ctx context.Context, observations []*models.ProviderHealth) error {
+	if len(observations) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).CreateInBatches(observations, 500).Error; err != nil {
+		return fmt.Errorf("failed to create provider health observations: %w", err)
+	}
+	return nil
+}
+
+// Totals counts the observations since a time per provider, chain and kind
+func (r *ProviderHealthRepository) Totals(ctx context.Context, since time.Time) ([]*ProviderHealthTotals, error) {
+	var totals []*ProviderHealthTotals
+	err := r.db.WithContext(ctx).
+		Model(&models.ProviderHealth{}).
+		Select(`provider, chain, kind,
+			COUNT(*) AS total,
+			SUM(CASE WHEN success THEN 1 ELSE 0 END) AS succeeded,
+			AVG(latency_ms) AS avg_latency_ms`).
+		Where("checked_at >= ?", since).
+		Group("provider, chain, kind").
+		Scan(&totals).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to count provider health observations: %w", err)
+	}
+
+	return totals, nil
+}
+
+// LastErrors retrieves the latest failed observation since a time of each provider
+// and chain
+func (r *ProviderHealthRepository) LastErrors(ctx context.Context, since time.Time) ([]*models.ProviderHealth, error) {
+	latest := r.db.WithContext(ctx).
+		Model(&models.ProviderHealth{}).
+		Select("MAX(id)").
+		Where("success = ? AND checked_at >= ?", false, since).
+		Group("provider, chain")
+
+	var failures []*models.ProviderHealth
+	err := r.db.WithContext(ctx).
+		Where("id IN (?)", latest).
+		Find(&failures).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider errors: %w", err)
+	}
+
+	return failures, nil
+}
+
+// DeleteBefore deletes the observations older than cutoff. It returns how many were
+// deleted.
+func (r *ProviderHealthRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("checked_at < ?", cutoff).
+		Delete(&models.ProviderHealth{})
+
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete provider health observations: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Read replica defaults
+const (
+	DefaultReplicaMaxLag        = 5 * time.Second
+	defaultReplicaCheckInterval = 10 * time.Second
+)
+
+// ReplicaStatus reports whether reads are currently served by the read replica
+type ReplicaStatus struct {
+	Configured bool      `json:"configured"`
+	Healthy    bool      `json:"healthy"` // Lag is known and within MaxLag
+	LagSeconds float64   `json:"lag_seconds"`
+	MaxLag     float64   `json:"max_lag_seconds"`
+	LastError  string    `json:"last_error,omitempty"`
+	CheckedAt  time.Time `json:"checked_at,omitempty"`
+}
+
+// readReplica routes read-heavy queries to a replica while it keeps up with the
+// primary. Addresses written by this process are read from the primary until the
+// replica has had time to catch up, so a fresh score is never replaced by a stale one.
+type readReplica struct {
+	db     *gorm.DB
+	maxLag time.Duration
+
+	mu           sync.RWMutex
+	lag          time.Duration
+	healthy      bool
+	lastError    string
+	checkedAt    time.Time
+	recentWrites map[string]time.Time
+}
+
+// SetReadReplica routes GetAll, GetHistory, StreamHistory, StreamScores, ListScoreEvents,
+// and GetStats to the replica. Reads fall back to the primary when the replica lags
+// more than maxLag. Until the first lag check the replica is assumed healthy.
+func (r *ScoreRepository) SetReadReplica(replica *gorm.DB, maxLag time.Duration) {
+	if maxLag <= 0 {
+		maxLag = DefaultReplicaMaxLag
+	}
+	r.replica = &readReplica{
+		db:           replica,
+		maxLag:       maxLag,
+		healthy:      true,
+		recentWrites: make(map[string]time.Time),
+	}
+}
+
+// ReplicaStatus reports the read replica's last measured lag
+func (r *ScoreRepository) ReplicaStatus() ReplicaStatus {
+	if r.replica == nil {
+		return ReplicaStatus{}
+	}
+
+	r.replica.mu.RLock()
+	defer r.replica.mu.RUnlock()
+	return ReplicaStatus{
+		Configured: true,
+		Healthy:    r.replica.healthy,
+		LagSeconds: r.replica.lag.Seconds(),
+		MaxLag:     r.replica.maxLag.Seconds(),
+		LastError:  r.replica.lastError,
+		CheckedAt:  r.replica.checkedAt,
+	}
+}
+
+// CheckReplicaLag measures how far the replica's replay is behind the primary and
+// updates whether reads are routed to it
+func (r *ScoreRepository) CheckReplicaLag(ctx context.Context) (time.Duration, error) {
+	if r.replica == nil {
+		return 0, nil
+	}
+
+	var lagSeconds float64
+	var err error
+	if r.replica.db.Dialector.Name() == "postgres" {
+		// An idle primary leaves the last replay timestamp old, so a replica that has
+		// replayed everything it received counts as caught up
+		err = r.replica.db.WithContext(ctx).Raw(`
+			SELECT CASE
+				WHEN NOT pg_is_in_recovery() THEN 0
+				WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+				ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+			END`).Scan(&lagSeconds).Error
+	} else {
+		err = r.replica.db.WithContext(ctx).Exec("SELECT 1").Error
+	}
+	lag := time.Duration(lagSeconds * float64(time.Second))
+
+	r.replica.mu.Lock()
+	defer r.replica.mu.Unlock()
+	r.replica.checkedAt = time.Now()
+	if err != nil {
+		r.replica.healthy = false
+		r.replica.lastError = err.Error()
+		return 0, err
+	}
+	r.replica.lag = lag
+	r.replica.healthy = lag <= r.replica.maxLag
+	r.replica.lastError = ""
+
+	return lag, nil
+}
+
+// RunReplicaLagMonitor checks the replica's lag every interval until ctx is cancelled
+func (r *ScoreRepository) RunReplicaLagMonitor(ctx context.Context, interval time.Duration) {
+	if r.replica == nil {
+		return
+	}
+	if interval <= 0 {
+		interval = defaultReplicaCheckInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	wasHealthy := true
+	for {
+		lag, err := r.CheckReplicaLag(ctx)
+		healthy := r.ReplicaStatus().Healthy
+		if healthy != wasHealthy {
+			if healthy {
+				logger.Info("Read replica caught up, routing reads to it", zap.Duration("lag", lag))
+			} else {
+				logger.Warn("Read replica unavailable or lagging, routing reads to primary",
+					zap.Duration("lag", lag),
+					zap.Error(err),
+				)
+			}
+			wasHealthy = healthy
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reader returns the database to read from. address is the address the read
+// concerns, or empty for reads across all addresses.
+func (r *ScoreRepository) reader(ctx context.Context, address string) *gorm.DB {
+	if r.replica == nil {
+		return r.db.WithContext(ctx)
+	}
+
+	r.replica.mu.RLock()
+	defer r.replica.mu.RUnlock()
+
+	if !r.replica.healthy {
+		return r.db.WithContext(ctx)
+	}
+	if address != "" {
+		if written, ok := r.replica.recentWrites[address]; ok && time.Since(written) < r.replica.staleWindow() {
+			return r.db.WithContext(ctx)
+		}
+	}
+	return r.replica.db.WithContext(ctx)
+}
+
+// markWritten records a write for an address so its reads stay on the primary
+// until the replica has caught up
+func (r *ScoreRepository) markWritten(address string) {
+	if r.replica == nil || address == "" {
+		return
+	}
+
+	r.replica.mu.Lock()
+	defer r.replica.mu.Unlock()
+
+	now := time.Now()
+	r.replica.recentWrites[address] = now
+
+	// Forget writes the replica has certainly caught up with
+	if len(r.replica.recentWrites) > 1000 {
+		window := r.replica.staleWindow()
+		for addr, written := range r.replica.recentWrites {
+			if now.Sub(written) >= window {
+				delete(r.replica.recentWrites, addr)
+			}
+		}
+	}
+}
+
+// staleWindow is how long after a write the replica may still serve old data.
+// Callers hold mu.
+func (rr *readReplica) staleWindow() time.Duration {
+	if rr.lag > rr.maxLag {
+		return rr.lag
+	}
+	return rr.maxLag
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+func TestReadReplicaRouting(t *testing.T) {
+	primary := setupTestDB(t)
+	replica := setupTestDB(t)
+	repo := NewScoreRepository(primary)
+	repo.SetReadReplica(replica, time.Minute)
+	ctx := context.Background()
+
+	// Rows that only the replica has show which database served a read
+	if err := replica.Create(&models.CreditScore{UserAddress: "0xreplica", Score: 600, Confidence: 50, DataHash: "r", LastUpdated: time.Now(), IsActive: true}).Error; err != nil {
+		t.Fatalf("Failed to seed replica: %v", err)
+	}
+	scores, err := repo.GetAll(ctx, 10, 0)
+	if err != nil {
+		t.Fatalf("Failed to get scores: %v", err)
+	}
+	if len(scores) != 1 || scores[0].UserAddress != "0xreplica" {
+		t.Errorf("Expected GetAll to read from the replica")
+	}
+
+	// An address written through the repository is read from the primary while the
+	// replica may not have it yet
+	history := &models.ScoreHistory{UserAddress: "0x1111", Score: 700, Confidence: 80, DataHash: "h", Timestamp: time.Now()}
+	if err := repo.CreateHistory(ctx, history); err != nil {
+		t.Fatalf("Failed to create history: %v", err)
+	}
+	entries, err := repo.GetHistory(ctx, "0x1111", 10)
+	if err != nil {
+		t.Fatalf("Failed to get history: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Expected the just-written history from the primary, got %d entries", len(entries))
+	}
+
+	// A replica that cannot be reached sends every read to the primary
+	sqlDB, _ := replica.DB()
+	sqlDB.Close()
+	if _, err := repo.CheckReplicaLag(ctx); err == nil {
+		t.Fatal("Expected lag check to fail on a closed replica")
+	}
+	if repo.ReplicaStatus().Healthy {
+		t.Error("Expected replica to be marked unhealthy")
+	}
+	scores, err = repo.GetAll(ctx, 10, 0)
+	if err != nil {
+		t.Fatalf("Failed to get scores from primary: %v", err)
+	}
+	if len(scores) != 0 {
+		t.Errorf("Expected GetAll to fall back to the primary, got %d scores", len(scores))
+	}
+}
+
+func TestNoReadReplica(t *testing.T) {
+	repo := NewScoreRepository(setupTestDB(t))
+
+	if repo.ReplicaStatus().Configured {
+		t.Error("Expected no replica to be configured")
+	}
+	if _, err := repo.CheckReplicaLag(context.Background()); err != nil {
+		t.Errorf("Expected lag check without a replica to be a no-op, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SpendResearchBudget charges epsilon to a partner's research budget for day and
+// runs export in the same transaction, so the charge is rolled back if the export
+// fails and concurrent exports can't overdraw the budget. export reads through the
+// repository it is given, which is bound to the transaction. ok is false, and nothing
+// is charged or exported, when the budget can't cover epsilon. spent is the day's
+// total after the charge.
+func (r *ScoreRepository) SpendResearchBudget(ctx context.Context, partner, day string, epsilon, budget float64, export func(repo *ScoreRepository) error) (spent float64, ok bool, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		row := models.ResearchBudget{Partner: partner, Day: day}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row).Error; err != nil {
+			return err
+		}
+
+		// The check and the charge are one statement so concurrent exports are serialized
+		charged := tx.Model(&models.ResearchBudget{}).
+			Where("partner = ? AND day = ? AND spent + ? <= ?", partner, day, epsilon, budget).
+			Update("spent", gorm.Expr("spent + ?", epsilon))
+		if charged.Error != nil {
+			return charged.Error
+		}
+		ok = charged.RowsAffected == 1
+
+		if err := tx.Where("partner = ? AND day = ?", partner, day).First(&row).Error; err != nil {
+			return err
+		}
+		spent = row.Spent
+		if !ok {
+			return nil
+		}
+		return export(&ScoreRepository{db: tx})
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to spend research budget: %w", err)
+	}
+	return spent, ok, nil
+}
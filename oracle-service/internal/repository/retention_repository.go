@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// RetentionRepository handles database operations for data retention
+type RetentionRepository struct {
+	db *gorm.DB
+}
+
+// NewRetentionRepository creates a new retention repository
+func NewRetentionRepository(db *gorm.DB) *RetentionRepository {
+	return &RetentionRepository{db: db}
+}
+
+// ListExpired retrieves up to limit rows covered by a retention policy that are older
+// than cutoff, oldest first. It returns the rows, a slice of the policy's model type,
+// and their IDs.
+func (r *RetentionRepository) ListExpired(ctx context.Context, policy string, cutoff time.Time, limit int) (interface{}, []uint, error) {
+	db := r.db.WithContext(ctx)
+	var ids []uint
+
+	switch policy {
+	case models.RetentionFailedOracleUpdates:
+		var rows []*models.OracleUpdate
+		err := db.Where("status IN ? AND updated_at < ?", []string{models.OracleUpdateFailed, models.OracleUpdateBlocked}, cutoff).
+			Order("id ASC").Limit(limit).Find(&rows).Error
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list expired %s: %w", policy, err)
+		}
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		return rows, ids, nil
+
+	case models.RetentionWebhookDeliveries:
+		// Deliveries still retrying or waiting in the dead-letter queue are kept
+		var rows []*models.WebhookDelivery
+		err := db.Where("status IN ? AND updated_at < ?",
+			[]string{models.WebhookProcessed, models.WebhookDelivered, models.WebhookFailed}, cutoff).
+			Order("id ASC").Limit(limit).Find(&rows).Error
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list expired %s: %w", policy, err)
+		}
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		return rows, ids, nil
+
+	case models.RetentionBureauAlerts:
+		var rows []*models.BureauAlert
+		err := db.Where("created_at < ?", cutoff).
+			Order("id ASC").Limit(limit).Find(&rows).Error
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list expired %s: %w", policy, err)
+		}
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		return rows, ids, nil
+
+	case models.RetentionScoreHistory:
+		var rows []*models.ScoreHistory
+		err := db.Where("timestamp < ?", cutoff).
+			Order("id ASC").Limit(limit).Find(&rows).Error
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list expired %s: %w", policy, err)
+		}
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		return rows, ids, nil
+
+	case models.RetentionScoreComponents:
+		var rows []*models.ScoreComponent
+		err := db.Where("calculated_at < ?", cutoff).
+			Order("id ASC").Limit(limit).Find(&rows).Error
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list expired %s: %w", policy, err)
+		}
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		return rows, ids, nil
+
+	case models.RetentionDebugTraces:
+		var rows []*models.DebugTrace
+		err := db.Where("created_at < ?", cutoff).
+			Order("id ASC").Limit(limit).Find(&rows).Error
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list expired %s: %w", policy, err)
+		}
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		return rows, ids, nil
+
+	case models.RetentionJobRuns:
+		var rows []*models.JobRun
+		err := db.Where("started_at < ?", cutoff).
+			Order("id ASC").Limit(limit).Find(&rows).Error
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list expired %s: %w", policy, err)
+		}
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		return rows, ids, nil
+
+	case models.RetentionPositionSnapshots:
+		var rows []*models.PositionSnapshot
+		err := db.Where("taken_at < ?", cutoff).
+			Order("id ASC").Limit(limit).Find(&rows).Error
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list expired %s: %w", policy, err)
+		}
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		return rows, ids, nil
+
+	case models.RetentionPipelineTimings:
+		var rows []*models.PipelineTiming
+		err := db.Where("started_at < ?", cutoff).
+			Order("id ASC").Limit(limit).Find(&rows).Error
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list expired %s: %w", policy, err)
+		}
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		return rows, ids, nil
+
+	default:
+		return nil, nil, fmt.Errorf("unknown retention policy %q", policy)
+	}
+}
+
+// DeleteExpired deletes rows previously returned by ListExpired, along with rows
+// that depend on them
+func (r *RetentionRepository) DeleteExpired(ctx context.Context, policy string, ids []uint) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	var deleted int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var model interface{}
+		switch policy {
+		case models.RetentionFailedOracleUpdates:
+			model = &models.OracleUpdate{}
+		case models.RetentionWebhookDeliveries:
+			if err := tx.Where("delivery_id IN ?", ids).Delete(&models.WebhookAttempt{}).Error; err != nil {
+				return err
+			}
+			// Dead letters that were replayed successfully point at delivered rows
+			if err := tx.Where("webhook_delivery_id IN ?", ids).Delete(&models.WebhookDeadLetter{}).Error; err != nil {
+				return err
+			}
+			model = &models.WebhookDelivery{}
+		case models.RetentionBureauAlerts:
+			model = &models.BureauAlert{}
+		case models.RetentionScoreHistory:
+			model = &models.ScoreHistory{}
+		case models.RetentionScoreComponents:
+			model = &models.ScoreComponent{}
+		case models.RetentionDebugTraces:
+			model = &models.DebugTrace{}
+		case models.RetentionJobRuns:
+			model = &models.JobRun{}
+		case models.RetentionPositionSnapshots:
+			model = &models.PositionSnapshot{}
+		case models.RetentionPipelineTimings:
+			model = &models.PipelineTiming{}
+		default:
+			return fmt.Errorf("unknown retention policy %q", policy)
+		}
+
+		result := tx.Delete(model, ids)
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired %s: %w", policy, err)
+	}
+
+	return deleted, nil
+}
+
+// GetStat retrieves a policy's retention record. It returns nil if the policy has
+// never run.
+func (r *RetentionRepository) GetStat(ctx context.Context, policy string) (*models.RetentionStat, error) {
+	var stat models.RetentionStat
+	err := r.db.WithContext(ctx).
+		Where("policy = ?", policy).
+		First(&stat).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get retention stat: %w", err)
+	}
+
+	return &stat, nil
+}
+
+// SaveStat creates or updates a policy's retention record
+func (r *RetentionRepository) SaveStat(ctx context.Context, stat *models.RetentionStat) error {
+	return r.db.WithContext(ctx).Save(stat).Error
+}
+
+// ListStats retrieves every policy's retention record
+func (r *RetentionRepository) ListStats(ctx context.Context) ([]*models.RetentionStat, error) {
+	var stats []*models.RetentionStat
+	err := r.db.WithContext(ctx).
+		Order("policy ASC").
+		Find(&stats).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list retention stats: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+func TestExpiredWebhookDeliveries(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRetentionRepository(db)
+	ctx := context.Background()
+
+	old := time.Now().AddDate(0, 0, -100)
+	deliveries := []*models.WebhookDelivery{
+		{Direction: models.WebhookOutbound, Source: "https://a", DeliveryID: "1", Status: models.WebhookDelivered},
+		{Direction: models.WebhookOutbound, Source: "https://a", DeliveryID: "2", Status: models.WebhookDead},
+		{Direction: models.WebhookOutbound, Source: "https://a", DeliveryID: "3", Status: models.WebhookDelivered},
+	}
+	for _, d := range deliveries {
+		if err := db.Create(d).Error; err != nil {
+			t.Fatalf("Failed to create delivery: %v", err)
+		}
+	}
+	db.Model(&models.WebhookDelivery{}).Where("delivery_id IN ?", []string{"1", "2"}).UpdateColumn("updated_at", old)
+	db.Create(&models.WebhookAttempt{DeliveryID: deliveries[0].ID, Attempt: 1, Success: true})
+
+	// Dead deliveries are kept for replay even when old
+	_, ids, err := repo.ListExpired(ctx, models.RetentionWebhookDeliveries, time.Now().AddDate(0, 0, -90), 10)
+	if err != nil {
+		t.Fatalf("Failed to list expired deliveries: %v", err)
+	}
+	if len(ids) != 1 || ids[0] != deliveries[0].ID {
+		t.Fatalf("Expected only the old delivered webhook to expire, got %v", ids)
+	}
+
+	deleted, err := repo.DeleteExpired(ctx, models.RetentionWebhookDeliveries, ids)
+	if err != nil {
+		t.Fatalf("Failed to delete expired deliveries: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("Expected 1 deletion, got %d", deleted)
+	}
+
+	var attempts int64
+	db.Model(&models.WebhookAttempt{}).Count(&attempts)
+	if attempts != 0 {
+		t.Errorf("Expected the delivery's attempts to be deleted, got %d", attempts)
+	}
+}
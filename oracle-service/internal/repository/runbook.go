@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+// ListFailedPublishAddresses lists the addresses with an oracle update that failed or
+// was blocked since the given time and nothing sent or queued after it, oldest failure
+// first
+func (r *ScoreRepository) ListFailedPublishAddresses(ctx context.Context, since time.Time) ([]string, error) {
+	unsent := []string{models.OracleUpdateFailed, models.OracleUpdateBlocked}
+	retried := r.db.Table("oracle_updates AS later").
+		Select("1").
+		Where("later.user_address = oracle_updates.user_address").
+		Where("later.id > oracle_updates.id").
+		Where("later.status NOT IN ?", unsent)
+
+	var failed []*models.OracleUpdate
+	err := r.db.WithContext(ctx).
+		Select("user_address").
+		Where("status IN ? AND updated_at >= ?", unsent, since).
+		Where("NOT EXISTS (?)", retried).
+		Order("updated_at ASC").
+		Find(&failed).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list failed oracle updates: %w", err)
+	}
+
+	seen := make(map[string]bool, len(failed))
+	addresses := make([]string, 0, len(failed))
+	for _, update := range failed {
+		if !seen[update.UserAddress] {
+			seen[update.UserAddress] = true
+			addresses = append(addresses, update.UserAddress)
+		}
+	}
+	return addresses, nil
+}
+
+// ListScoresCalculatedBetween lists up to limit active scores last calculated within
+// [from, to], oldest first, skipping frozen addresses. With offChainOnly set only
+// addresses with stored off-chain metrics are listed.
+func (r *ScoreRepository) ListScoresCalculatedBetween(ctx context.Context, from, to time.Time, offChainOnly bool, limit int) ([]*models.CreditScore, error) {
+	query := r.db.WithContext(ctx).
+		Where("is_active = ? AND last_updated >= ? AND last_updated <= ?", true, from, to).
+		Where("NOT EXISTS (?)", r.frozenAddresses())
+	if offChainOnly {
+		hasOffChain := r.db.Model(&models.OffChainMetrics{}).
+			Select("1").
+			Where("off_chain_metrics.user_address = credit_scores.user_address")
+		query = query.Where("EXISTS (?)", hasOffChain)
+	}
+
+	var scores []*models.CreditScore
+	err := query.
+		Order("last_updated ASC").
+		Limit(limit).
+		Find(&scores).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scores calculated in window: %w", err)
+	}
+	return scores, nil
+}
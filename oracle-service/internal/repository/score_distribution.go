@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// ScoreBucketTotals are the active final scores falling in one bucket
+type ScoreBucketTotals struct {
+	Bucket        int // Index of the bucket, counting from the bucketed column's minimum
+	Count         int64
+	ConfidenceSum float64
+}
+
+// GetScoreDistribution counts the active, non-provisional scores in buckets of width
+// points starting at units.MinScore, with the sum of their confidence. Only aggregates
+// are read; no address leaves the database. Buckets without scores are omitted.
+func (r *ScoreRepository) GetScoreDistribution(ctx context.Context, width int) ([]*ScoreBucketTotals, error) {
+	totals, err := r.bucketTotals(ctx, "score", int(units.MinScore), width)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score distribution: %w", err)
+	}
+	return totals, nil
+}
+
+// GetConfidenceDistribution counts the active, non-provisional scores in buckets of
+// width confidence points starting at 0, like GetScoreDistribution
+func (r *ScoreRepository) GetConfidenceDistribution(ctx context.Context, width int) ([]*ScoreBucketTotals, error) {
+	totals, err := r.bucketTotals(ctx, "confidence", 0, width)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get confidence distribution: %w", err)
+	}
+	return totals, nil
+}
+
+// bucketTotals groups the active final scores by a column of credit_scores, in
+// buckets of width starting at origin
+func (r *ScoreRepository) bucketTotals(ctx context.Context, column string, origin, width int) ([]*ScoreBucketTotals, error) {
+	if width <= 0 {
+		return nil, fmt.Errorf("invalid bucket width %d", width)
+	}
+
+	var totals []*ScoreBucketTotals
+	err := r.reader(ctx, "").
+		Model(&models.CreditScore{}).
+		Select("("+column+" - ?) / ? AS bucket, COUNT(*) AS count, SUM(confidence) AS confidence_sum", origin, width).
+		Where("is_active = ? AND provisional = ?", true, false).
+		Group("bucket").
+		Order("bucket ASC").
+		Scan(&totals).Error
+
+	return totals, err
+}
+
+// CountPublishedAddresses counts the distinct addresses with a confirmed publication,
+// by publish target
+func (r *ScoreRepository) CountPublishedAddresses(ctx context.Context) (map[string]int64, error) {
+	type targetCount struct {
+		Target string
+		Count  int64
+	}
+	var counts []targetCount
+	err := r.reader(ctx, "").
+		Model(&models.OracleUpdate{}).
+		Select("target, COUNT(DISTINCT user_address) AS count").
+		Where("status = ?", models.OracleUpdateConfirmed).
+		Group("target").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count published addresses: %w", err)
+	}
+
+	byTarget := make(map[string]int64)
+	for _, c := range counts {
+		target := c.Target
+		if target == "" {
+			target = models.PublishTargetPrimary
+		}
+		byTarget[target] += c.Count
+	}
+	return byTarget, nil
+}
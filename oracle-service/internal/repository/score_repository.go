@@ -6,13 +6,19 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/yourusername/p2p-lend/oracle-service/internal/cache"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
 	"gorm.io/gorm"
 )
 
 // ScoreRepository handles database operations for credit scores
 type ScoreRepository struct {
-	db *gorm.DB
+	db        *gorm.DB
+	replica   *readReplica // nil when no read replica is configured
+	timescale bool         // Trends read from the Timescale continuous aggregate
+
+	invalidations *cache.Bus // nil publishes no invalidations
 }
 
 // NewScoreRepository creates a new score repository
@@ -20,21 +26,40 @@ func NewScoreRepository(db *gorm.DB) *ScoreRepository {
 	return &ScoreRepository{db: db}
 }
 
+// SetInvalidationBus publishes an invalidation of an address on bus whenever its
+// score or metrics are written, and of every address when the state is restored, so
+// cached copies are dropped however the data was pushed
+func (r *ScoreRepository) SetInvalidationBus(bus *cache.Bus) {
+	r.invalidations = bus
+}
+
 // Create creates a new credit score record
 func (r *ScoreRepository) Create(ctx context.Context, score *models.CreditScore) error {
-	return r.db.WithContext(ctx).Create(score).Error
+	score.UserAddress = normalizeAddress(score.UserAddress)
+	if err := r.db.WithContext(ctx).Create(score).Error; err != nil {
+		return err
+	}
+	r.markWritten(score.UserAddress)
+	r.invalidations.Invalidate(score.UserAddress, cache.ReasonScore)
+	return nil
 }
 
 // Update updates an existing credit score
 func (r *ScoreRepository) Update(ctx context.Context, score *models.CreditScore) error {
-	return r.db.WithContext(ctx).Save(score).Error
+	score.UserAddress = normalizeAddress(score.UserAddress)
+	if err := r.db.WithContext(ctx).Save(score).Error; err != nil {
+		return err
+	}
+	r.markWritten(score.UserAddress)
+	r.invalidations.Invalidate(score.UserAddress, cache.ReasonScore)
+	return nil
 }
 
 // GetByAddress retrieves a credit score by user address
 func (r *ScoreRepository) GetByAddress(ctx context.Context, address string) (*models.CreditScore, error) {
 	var score models.CreditScore
 	err := r.db.WithContext(ctx).
-		Where("user_address = ? AND is_active = ?", address, true).
+		Where("user_address = ? AND is_active = ?", normalizeAddress(address), true).
 		First(&score).Error
 
 	if err == gorm.ErrRecordNotFound {
@@ -47,10 +72,34 @@ func (r *ScoreRepository) GetByAddress(ctx context.Context, address string) (*mo
 	return &score, nil
 }
 
+// GetByAddresses retrieves the active credit scores of many addresses in one query,
+// keyed by normalized address. Addresses without a score are left out.
+func (r *ScoreRepository) GetByAddresses(ctx context.Context, addresses []string) (map[string]*models.CreditScore, error) {
+	normalized := make([]string, len(addresses))
+	for i, address := range addresses {
+		normalized[i] = normalizeAddress(address)
+	}
+
+	var scores []*models.CreditScore
+	err := r.reader(ctx, "").
+		Where("user_address IN ? AND is_active = ?", normalized, true).
+		Find(&scores).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credit scores: %w", err)
+	}
+
+	byAddress := make(map[string]*models.CreditScore, len(scores))
+	for _, score := range scores {
+		byAddress[score.UserAddress] = score
+	}
+	return byAddress, nil
+}
+
 // GetAll retrieves all active credit scores with pagination
 func (r *ScoreRepository) GetAll(ctx context.Context, limit, offset int) ([]*models.CreditScore, error) {
 	var scores []*models.CreditScore
-	err := r.db.WithContext(ctx).
+	err := r.reader(ctx, "").
 		Where("is_active = ?", true).
 		Order("last_updated DESC").
 		Limit(limit).
@@ -64,11 +113,12 @@ func (r *ScoreRepository) GetAll(ctx context.Context, limit, offset int) ([]*mod
 	return scores, nil
 }
 
-// GetDueForUpdate retrieves scores that need updating
+// GetDueForUpdate retrieves scores that need updating, skipping frozen addresses
 func (r *ScoreRepository) GetDueForUpdate(ctx context.Context, limit int) ([]*models.CreditScore, error) {
 	var scores []*models.CreditScore
 	err := r.db.WithContext(ctx).
 		Where("is_active = ? AND next_update_due <= ?", true, time.Now()).
+		Where("NOT EXISTS (?)", r.frozenAddresses()).
 		Order("next_update_due ASC").
 		Limit(limit).
 		Find(&scores).Error
@@ -80,15 +130,61 @@ func (r *ScoreRepository) GetDueForUpdate(ctx context.Context, limit int) ([]*mo
 	return scores, nil
 }
 
+// GetUnpublishedScores retrieves active, final scores whose current version has not
+// been submitted to the blockchain, oldest first. Frozen addresses are skipped.
+func (r *ScoreRepository) GetUnpublishedScores(ctx context.Context, limit int) ([]*models.CreditScore, error) {
+	var scores []*models.CreditScore
+	published := r.db.Model(&models.OracleUpdate{}).
+		Select("1").
+		Where("oracle_updates.user_address = credit_scores.user_address").
+		Where("oracle_updates.data_hash = credit_scores.data_hash").
+		Where("oracle_updates.status <> ?", "failed")
+
+	err := r.db.WithContext(ctx).
+		Where("is_active = ?", true).
+		Where("provisional = ?", false).
+		Where("NOT EXISTS (?)", published).
+		Where("NOT EXISTS (?)", r.frozenAddresses()).
+		Order("last_updated ASC").
+		Limit(limit).
+		Find(&scores).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unpublished scores: %w", err)
+	}
+
+	return scores, nil
+}
+
 // CreateHistory creates a historical score record
 func (r *ScoreRepository) CreateHistory(ctx context.Context, history *models.ScoreHistory) error {
-	return r.db.WithContext(ctx).Create(history).Error
+	history.UserAddress = normalizeAddress(history.UserAddress)
+	if err := r.db.WithContext(ctx).Create(history).Error; err != nil {
+		return err
+	}
+	r.markWritten(history.UserAddress)
+	return nil
+}
+
+// CreateHistoryBatch creates historical score records in one insert
+func (r *ScoreRepository) CreateHistoryBatch(ctx context.Context, history []*models.ScoreHistory) error {
+	for _, record := range history {
+		record.UserAddress = normalizeAddress(record.UserAddress)
+	}
+	if err := r.db.WithContext(ctx).CreateInBatches(history, 500).Error; err != nil {
+		return fmt.Errorf("failed to create score history: %w", err)
+	}
+	for _, record := range history {
+		r.markWritten(record.UserAddress)
+	}
+	return nil
 }
 
 // GetHistory retrieves score history for a user
 func (r *ScoreRepository) GetHistory(ctx context.Context, address string, limit int) ([]*models.ScoreHistory, error) {
+	address = normalizeAddress(address)
 	var history []*models.ScoreHistory
-	err := r.db.WithContext(ctx).
+	err := r.reader(ctx, address).
 		Where("user_address = ?", address).
 		Order("timestamp DESC").
 		Limit(limit).
@@ -101,49 +197,127 @@ func (r *ScoreRepository) GetHistory(ctx context.Context, address string, limit
 	return history, nil
 }
 
+// CreateScoreComponents stores the factor scores of a calculation
+func (r *ScoreRepository) CreateScoreComponents(ctx context.Context, components []models.ScoreComponent) error {
+	if len(components) == 0 {
+		return nil
+	}
+	for i := range components {
+		components[i].UserAddress = normalizeAddress(components[i].UserAddress)
+	}
+	if err := r.db.WithContext(ctx).CreateInBatches(components, 100).Error; err != nil {
+		return fmt.Errorf("failed to create score components: %w", err)
+	}
+	r.markWritten(components[0].UserAddress)
+	return nil
+}
+
+// GetScoreComponents retrieves the factor scores of an address's latest calculation
+func (r *ScoreRepository) GetScoreComponents(ctx context.Context, address string) ([]*models.ScoreComponent, error) {
+	address = normalizeAddress(address)
+	latest := r.reader(ctx, address).
+		Model(&models.ScoreComponent{}).
+		Select("MAX(calculated_at)").
+		Where("user_address = ?", address)
+
+	var components []*models.ScoreComponent
+	err := r.reader(ctx, address).
+		Where("user_address = ? AND calculated_at = (?)", address, latest).
+		Order("id ASC").
+		Find(&components).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score components: %w", err)
+	}
+
+	return components, nil
+}
+
+// StreamHistory calls fn with each of an address's score history records, newest
+// first, reading them one row at a time so long histories are never held in memory.
+// A limit of zero streams the whole history. Streaming stops at the first error fn
+// returns, which is passed back to the caller.
+func (r *ScoreRepository) StreamHistory(ctx context.Context, address string, limit int, fn func(*models.ScoreHistory) error) error {
+	address = normalizeAddress(address)
+	query := r.reader(ctx, address).
+		Model(&models.ScoreHistory{}).
+		Where("user_address = ?", address).
+		Order("timestamp DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	return streamRows(query, "score history", fn)
+}
+
+// StreamScores calls fn with each active credit score, oldest first, reading them
+// one row at a time. Streaming stops at the first error fn returns.
+func (r *ScoreRepository) StreamScores(ctx context.Context, fn func(*models.CreditScore) error) error {
+	query := r.reader(ctx, "").
+		Model(&models.CreditScore{}).
+		Where("is_active = ?", true).
+		Order("id ASC")
+
+	return streamRows(query, "credit scores", fn)
+}
+
 // UpsertOnChainMetrics creates or updates on-chain metrics
 func (r *ScoreRepository) UpsertOnChainMetrics(ctx context.Context, metrics *models.OnChainMetrics) error {
+	metrics.UserAddress = normalizeAddress(metrics.UserAddress)
 	var existing models.OnChainMetrics
 	err := r.db.WithContext(ctx).
 		Where("user_address = ?", metrics.UserAddress).
 		First(&existing).Error
 
-	if err == gorm.ErrRecordNotFound {
-		return r.db.WithContext(ctx).Create(metrics).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		err = r.db.WithContext(ctx).Create(metrics).Error
+	case err != nil:
+		return fmt.Errorf("failed to check existing metrics: %w", err)
+	default:
+		metrics.ID = existing.ID
+		metrics.CreatedAt = existing.CreatedAt
+		err = r.db.WithContext(ctx).Save(metrics).Error
 	}
 	if err != nil {
-		return fmt.Errorf("failed to check existing metrics: %w", err)
+		return err
 	}
 
-	metrics.ID = existing.ID
-	metrics.CreatedAt = existing.CreatedAt
-	return r.db.WithContext(ctx).Save(metrics).Error
+	r.invalidations.Invalidate(metrics.UserAddress, cache.ReasonOnChainMetrics)
+	return nil
 }
 
 // UpsertOffChainMetrics creates or updates off-chain metrics
 func (r *ScoreRepository) UpsertOffChainMetrics(ctx context.Context, metrics *models.OffChainMetrics) error {
+	metrics.UserAddress = normalizeAddress(metrics.UserAddress)
 	var existing models.OffChainMetrics
 	err := r.db.WithContext(ctx).
 		Where("user_address = ?", metrics.UserAddress).
 		First(&existing).Error
 
-	if err == gorm.ErrRecordNotFound {
-		return r.db.WithContext(ctx).Create(metrics).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		err = r.db.WithContext(ctx).Create(metrics).Error
+	case err != nil:
+		return fmt.Errorf("failed to check existing metrics: %w", err)
+	default:
+		metrics.ID = existing.ID
+		metrics.CreatedAt = existing.CreatedAt
+		err = r.db.WithContext(ctx).Save(metrics).Error
 	}
 	if err != nil {
-		return fmt.Errorf("failed to check existing metrics: %w", err)
+		return err
 	}
 
-	metrics.ID = existing.ID
-	metrics.CreatedAt = existing.CreatedAt
-	return r.db.WithContext(ctx).Save(metrics).Error
+	r.invalidations.Invalidate(metrics.UserAddress, cache.ReasonOffChainMetrics)
+	return nil
 }
 
 // GetOnChainMetrics retrieves on-chain metrics for a user
 func (r *ScoreRepository) GetOnChainMetrics(ctx context.Context, address string) (*models.OnChainMetrics, error) {
 	var metrics models.OnChainMetrics
 	err := r.db.WithContext(ctx).
-		Where("user_address = ?", address).
+		Where("user_address = ?", normalizeAddress(address)).
 		First(&metrics).Error
 
 	if err == gorm.ErrRecordNotFound {
@@ -160,7 +334,7 @@ func (r *ScoreRepository) GetOnChainMetrics(ctx context.Context, address string)
 func (r *ScoreRepository) GetOffChainMetrics(ctx context.Context, address string) (*models.OffChainMetrics, error) {
 	var metrics models.OffChainMetrics
 	err := r.db.WithContext(ctx).
-		Where("user_address = ?", address).
+		Where("user_address = ?", normalizeAddress(address)).
 		First(&metrics).Error
 
 	if err == gorm.ErrRecordNotFound {
@@ -175,6 +349,7 @@ func (r *ScoreRepository) GetOffChainMetrics(ctx context.Context, address string
 
 // CreateOracleUpdate records an oracle update transaction
 func (r *ScoreRepository) CreateOracleUpdate(ctx context.Context, update *models.OracleUpdate) error {
+	update.UserAddress = normalizeAddress(update.UserAddress)
 	return r.db.WithContext(ctx).Create(update).Error
 }
 
@@ -215,41 +390,755 @@ func (r *ScoreRepository) GetPendingOracleUpdates(ctx context.Context) ([]*model
 	return updates, nil
 }
 
+// ListOracleUpdates retrieves oracle updates, optionally filtered by status, oldest first
+func (r *ScoreRepository) ListOracleUpdates(ctx context.Context, status string, limit int) ([]*models.OracleUpdate, error) {
+	var updates []*models.OracleUpdate
+	query := r.db.WithContext(ctx)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	err := query.
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&updates).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oracle updates: %w", err)
+	}
+
+	return updates, nil
+}
+
+// GetOracleUpdateByAddressAndStatus retrieves the oldest oracle update for an address with the given status
+func (r *ScoreRepository) GetOracleUpdateByAddressAndStatus(ctx context.Context, address, status string) (*models.OracleUpdate, error) {
+	var update models.OracleUpdate
+	err := r.db.WithContext(ctx).
+		Where("user_address = ? AND status = ?", normalizeAddress(address), status).
+		Order("created_at ASC").
+		First(&update).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oracle update: %w", err)
+	}
+
+	return &update, nil
+}
+
+// GetLatestOracleUpdate retrieves the newest oracle update for an address with any of
+// the given statuses
+func (r *ScoreRepository) GetLatestOracleUpdate(ctx context.Context, address string, statuses ...string) (*models.OracleUpdate, error) {
+	var update models.OracleUpdate
+	err := r.db.WithContext(ctx).
+		Where("user_address = ? AND status IN ?", normalizeAddress(address), statuses).
+		Order("created_at DESC").
+		Order("id DESC").
+		First(&update).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oracle update: %w", err)
+	}
+
+	return &update, nil
+}
+
+// SetPublishStatus sets an address's score publish status, leaving the rest of the
+// score untouched
+func (r *ScoreRepository) SetPublishStatus(ctx context.Context, address, status string) error {
+	address = normalizeAddress(address)
+	err := r.db.WithContext(ctx).
+		Model(&models.CreditScore{}).
+		Where("user_address = ?", address).
+		UpdateColumn("publish_status", status).Error
+	if err != nil {
+		return fmt.Errorf("failed to set publish status: %w", err)
+	}
+	r.markWritten(address)
+	r.invalidations.Invalidate(address, cache.ReasonScore)
+	return nil
+}
+
+// CountOracleUpdatesByTarget counts oracle updates created since the given time, by
+// publish target and then status. Queued updates are not counted, as they were never
+// sent.
+func (r *ScoreRepository) CountOracleUpdatesByTarget(ctx context.Context, since time.Time) (map[string]map[string]int64, error) {
+	type targetCount struct {
+		Target string
+		Status string
+		Count  int64
+	}
+	var counts []targetCount
+	err := r.db.WithContext(ctx).
+		Model(&models.OracleUpdate{}).
+		Select("target, status, COUNT(*) AS count").
+		Where("created_at >= ? AND status <> ?", since, models.OracleUpdateQueued).
+		Group("target, status").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count oracle updates: %w", err)
+	}
+
+	byTarget := make(map[string]map[string]int64)
+	for _, c := range counts {
+		target := c.Target
+		if target == "" {
+			target = models.PublishTargetPrimary
+		}
+		if byTarget[target] == nil {
+			byTarget[target] = make(map[string]int64)
+		}
+		byTarget[target][c.Status] += c.Count
+	}
+	return byTarget, nil
+}
+
+// GetDataFreeze retrieves an address's data freeze record. It returns nil if the
+// address has never been frozen.
+func (r *ScoreRepository) GetDataFreeze(ctx context.Context, address string) (*models.DataFreeze, error) {
+	var freeze models.DataFreeze
+	err := r.db.WithContext(ctx).
+		Where("user_address = ?", normalizeAddress(address)).
+		First(&freeze).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data freeze: %w", err)
+	}
+
+	return &freeze, nil
+}
+
+// SaveDataFreeze creates or updates an address's data freeze record
+func (r *ScoreRepository) SaveDataFreeze(ctx context.Context, freeze *models.DataFreeze) error {
+	freeze.UserAddress = normalizeAddress(freeze.UserAddress)
+	return r.db.WithContext(ctx).Save(freeze).Error
+}
+
+// GetProviderAgreement retrieves an address's provider agreement history. It returns
+// nil if the address's providers have never been compared.
+func (r *ScoreRepository) GetProviderAgreement(ctx context.Context, address string) (*models.ProviderAgreement, error) {
+	var agreement models.ProviderAgreement
+	err := r.db.WithContext(ctx).
+		Where("user_address = ?", normalizeAddress(address)).
+		First(&agreement).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider agreement: %w", err)
+	}
+
+	return &agreement, nil
+}
+
+// RecordProviderComparisons adds one fetch's provider comparisons to an address's
+// agreement history. conflicts lists the fields that disagreed, if any.
+func (r *ScoreRepository) RecordProviderComparisons(ctx context.Context, address string, comparisons, disagreements uint32, conflicts string) error {
+	address = normalizeAddress(address)
+	now := time.Now()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		agreement := models.ProviderAgreement{UserAddress: address}
+		if err := tx.Where("user_address = ?", address).FirstOrCreate(&agreement).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{
+			"comparisons":      gorm.Expr("comparisons + ?", comparisons),
+			"disagreements":    gorm.Expr("disagreements + ?", disagreements),
+			"last_compared_at": now,
+		}
+		if disagreements > 0 {
+			updates["last_conflicts"] = conflicts
+		}
+		return tx.Model(&agreement).Updates(updates).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record provider comparisons: %w", err)
+	}
+	return nil
+}
+
+// GetPositionHealth retrieves the latest health checks of an address's lending
+// positions, one per protocol
+func (r *ScoreRepository) GetPositionHealth(ctx context.Context, address string) ([]*models.PositionHealth, error) {
+	var positions []*models.PositionHealth
+	err := r.db.WithContext(ctx).
+		Where("user_address = ?", normalizeAddress(address)).
+		Order("protocol ASC").
+		Find(&positions).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get position health: %w", err)
+	}
+
+	return positions, nil
+}
+
+// SavePositionHealth creates or replaces the health check of an address's position
+// on the check's protocol
+func (r *ScoreRepository) SavePositionHealth(ctx context.Context, health *models.PositionHealth) error {
+	health.UserAddress = normalizeAddress(health.UserAddress)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.PositionHealth
+		err := tx.Where("user_address = ? AND protocol = ?", health.UserAddress, health.Protocol).
+			First(&existing).Error
+		if err == nil {
+			health.ID = existing.ID
+			health.CreatedAt = existing.CreatedAt
+		} else if err != gorm.ErrRecordNotFound {
+			return err
+		}
+		return tx.Save(health).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save position health: %w", err)
+	}
+	return nil
+}
+
+// GetBorrowingAddresses retrieves the scored addresses with open loans in their
+// on-chain metrics, debt at their last health check, or debt in their latest position
+// snapshot. Frozen addresses are skipped.
+func (r *ScoreRepository) GetBorrowingAddresses(ctx context.Context) ([]string, error) {
+	outstanding := r.db.Model(&models.OnChainMetrics{}).
+		Select("1").
+		Where("on_chain_metrics.user_address = credit_scores.user_address").
+		Where("on_chain_metrics.outstanding_loans > 0")
+	indebted := r.db.Model(&models.PositionHealth{}).
+		Select("1").
+		Where("position_healths.user_address = credit_scores.user_address").
+		Where("position_healths.debt_usd > 0")
+	latestSnapshot := r.db.Table("position_snapshots AS later").
+		Select("1").
+		Where("later.user_address = position_snapshots.user_address").
+		Where("later.protocol = position_snapshots.protocol").
+		Where("later.taken_at > position_snapshots.taken_at")
+	snapshotDebt := r.db.Model(&models.PositionSnapshot{}).
+		Select("1").
+		Where("position_snapshots.user_address = credit_scores.user_address").
+		Where("position_snapshots.borrowed_usd > 0").
+		Where("NOT EXISTS (?)", latestSnapshot)
+
+	var addresses []string
+	err := r.db.WithContext(ctx).
+		Model(&models.CreditScore{}).
+		Where("is_active = ?", true).
+		Where("EXISTS (?) OR EXISTS (?) OR EXISTS (?)", outstanding, indebted, snapshotDebt).
+		Where("NOT EXISTS (?)", r.frozenAddresses()).
+		Order("user_address ASC").
+		Pluck("user_address", &addresses).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get borrowing addresses: %w", err)
+	}
+
+	return addresses, nil
+}
+
+// GetScoredAddresses retrieves every address with an active score. Frozen addresses
+// are skipped.
+func (r *ScoreRepository) GetScoredAddresses(ctx context.Context) ([]string, error) {
+	var addresses []string
+	err := r.db.WithContext(ctx).
+		Model(&models.CreditScore{}).
+		Where("is_active = ?", true).
+		Where("NOT EXISTS (?)", r.frozenAddresses()).
+		Order("user_address ASC").
+		Pluck("user_address", &addresses).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scored addresses: %w", err)
+	}
+
+	return addresses, nil
+}
+
+// CreatePositionSnapshots records snapshots of lending positions
+func (r *ScoreRepository) CreatePositionSnapshots(ctx context.Context, snapshots []*models.PositionSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	for _, snapshot := range snapshots {
+		snapshot.UserAddress = normalizeAddress(snapshot.UserAddress)
+	}
+	if err := r.db.WithContext(ctx).Create(&snapshots).Error; err != nil {
+		return fmt.Errorf("failed to create position snapshots: %w", err)
+	}
+	return nil
+}
+
+// GetLatestPositionSnapshots retrieves an address's latest position snapshot on each
+// protocol
+func (r *ScoreRepository) GetLatestPositionSnapshots(ctx context.Context, address string) ([]*models.PositionSnapshot, error) {
+	later := r.db.Table("position_snapshots AS later").
+		Select("1").
+		Where("later.user_address = position_snapshots.user_address").
+		Where("later.protocol = position_snapshots.protocol").
+		Where("later.taken_at > position_snapshots.taken_at")
+
+	var snapshots []*models.PositionSnapshot
+	err := r.db.WithContext(ctx).
+		Where("user_address = ?", normalizeAddress(address)).
+		Where("NOT EXISTS (?)", later).
+		Order("protocol ASC").
+		Find(&snapshots).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get position snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// GetPositionSnapshots retrieves an address's position snapshots taken since a time,
+// oldest first
+func (r *ScoreRepository) GetPositionSnapshots(ctx context.Context, address string, since time.Time) ([]*models.PositionSnapshot, error) {
+	var snapshots []*models.PositionSnapshot
+	err := r.db.WithContext(ctx).
+		Where("user_address = ? AND taken_at >= ?", normalizeAddress(address), since).
+		Order("taken_at ASC").
+		Order("protocol ASC").
+		Find(&snapshots).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get position snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// CreateScoreShare creates a score share link
+func (r *ScoreRepository) CreateScoreShare(ctx context.Context, share *models.ScoreShare) error {
+	share.UserAddress = normalizeAddress(share.UserAddress)
+	return r.db.WithContext(ctx).Create(share).Error
+}
+
+// UpdateScoreShare updates a score share link
+func (r *ScoreRepository) UpdateScoreShare(ctx context.Context, share *models.ScoreShare) error {
+	return r.db.WithContext(ctx).Save(share).Error
+}
+
+// GetScoreShare retrieves an address's score share link by ID
+func (r *ScoreRepository) GetScoreShare(ctx context.Context, address string, id uint) (*models.ScoreShare, error) {
+	var share models.ScoreShare
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND user_address = ?", id, normalizeAddress(address)).
+		First(&share).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score share: %w", err)
+	}
+
+	return &share, nil
+}
+
+// GetScoreShareByTokenHash retrieves a score share link by the hash of its token
+func (r *ScoreRepository) GetScoreShareByTokenHash(ctx context.Context, tokenHash string) (*models.ScoreShare, error) {
+	var share models.ScoreShare
+	err := r.db.WithContext(ctx).
+		Where("token_hash = ?", tokenHash).
+		First(&share).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score share: %w", err)
+	}
+
+	return &share, nil
+}
+
+// RecordScoreShareAccess counts an access to a score share link
+func (r *ScoreRepository) RecordScoreShareAccess(ctx context.Context, share *models.ScoreShare, at time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(share).
+		Updates(map[string]interface{}{
+			"access_count":     gorm.Expr("access_count + 1"),
+			"last_accessed_at": at,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to record score share access: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NotFound("score share %d not found", share.ID)
+	}
+
+	share.AccessCount++
+	share.LastAccessedAt = &at
+	return nil
+}
+
+// CreateIssuedCredential records a credential. The record is created first so sign can
+// use its ID as the credential's sequence number, and is rolled back if sign fails.
+func (r *ScoreRepository) CreateIssuedCredential(ctx context.Context, record *models.IssuedCredential, sign func(*models.IssuedCredential) error) error {
+	record.UserAddress = normalizeAddress(record.UserAddress)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(record).Error; err != nil {
+			return err
+		}
+		if err := sign(record); err != nil {
+			return err
+		}
+		return tx.Save(record).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create issued credential: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateIssuedCredential updates an issued credential record
+func (r *ScoreRepository) UpdateIssuedCredential(ctx context.Context, record *models.IssuedCredential) error {
+	return r.db.WithContext(ctx).Save(record).Error
+}
+
+// GetIssuedCredential retrieves an issued credential record by ID
+func (r *ScoreRepository) GetIssuedCredential(ctx context.Context, id uint) (*models.IssuedCredential, error) {
+	var record models.IssuedCredential
+	err := r.db.WithContext(ctx).First(&record, id).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issued credential: %w", err)
+	}
+
+	return &record, nil
+}
+
+// ListRevokedStatusIndexes retrieves the status list indexes of the revoked
+// credentials in a status list
+func (r *ScoreRepository) ListRevokedStatusIndexes(ctx context.Context, list int) ([]int, error) {
+	var indexes []int
+	err := r.db.WithContext(ctx).
+		Model(&models.IssuedCredential{}).
+		Where("status_list = ? AND revoked_at IS NOT NULL", list).
+		Order("status_index ASC").
+		Pluck("status_index", &indexes).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revoked credentials: %w", err)
+	}
+
+	return indexes, nil
+}
+
+// AdvanceWalletAuth records that a wallet-signed request made at signedAt was
+// accepted for the address. It returns false, recording nothing, if a request signed
+// at or after signedAt was already accepted.
+func (r *ScoreRepository) AdvanceWalletAuth(ctx context.Context, address string, signedAt time.Time) (bool, error) {
+	address = normalizeAddress(address)
+	var accepted bool
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		auth := models.WalletAuth{UserAddress: address}
+		if err := tx.Where("user_address = ?", address).FirstOrCreate(&auth).Error; err != nil {
+			return err
+		}
+
+		// Conditional update so concurrent replays of one request cannot both succeed
+		result := tx.Model(&models.WalletAuth{}).
+			Where("user_address = ? AND last_signed_at < ?", address, signedAt).
+			Update("last_signed_at", signedAt)
+		accepted = result.RowsAffected == 1
+		return result.Error
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to record wallet authorization: %w", err)
+	}
+
+	return accepted, nil
+}
+
+// frozenAddresses is a subquery matching a frozen data freeze for the credit score
+// row of the enclosing query
+func (r *ScoreRepository) frozenAddresses() *gorm.DB {
+	return r.db.Model(&models.DataFreeze{}).
+		Select("1").
+		Where("data_freezes.user_address = credit_scores.user_address").
+		Where("data_freezes.frozen = ?", true)
+}
+
+// AppendScoreEvent appends an event to the address's score lifecycle stream, assigning
+// the next sequence number. Concurrent appends that race for a sequence are retried.
+func (r *ScoreRepository) AppendScoreEvent(ctx context.Context, event *models.ScoreEvent) error {
+	event.UserAddress = normalizeAddress(event.UserAddress)
+	var err error
+	for attempt := 0; attempt < 3; attempt++ {
+		err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var last uint64
+			if err := tx.Model(&models.ScoreEvent{}).
+				Where("user_address = ?", event.UserAddress).
+				Select("COALESCE(MAX(sequence), 0)").
+				Scan(&last).Error; err != nil {
+				return err
+			}
+
+			event.ID = 0
+			event.Sequence = last + 1
+			return tx.Create(event).Error
+		})
+		if err == nil {
+			r.markWritten(event.UserAddress)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("failed to append score event: %w", err)
+}
+
+// ListScoreEvents lists an address's score events in sequence order, starting after
+// the given sequence number
+func (r *ScoreRepository) ListScoreEvents(ctx context.Context, address string, afterSequence uint64, limit int) ([]*models.ScoreEvent, error) {
+	address = normalizeAddress(address)
+	var events []*models.ScoreEvent
+	query := r.reader(ctx, address).
+		Where("user_address = ? AND sequence > ?", address, afterSequence).
+		Order("sequence ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to list score events: %w", err)
+	}
+
+	return events, nil
+}
+
+// ErrStateNotEmpty is returned when restoring over existing scores without replace
+var ErrStateNotEmpty = errors.New("database already contains credit scores")
+
+// OracleState is the full score state of the oracle, as exported to snapshots
+type OracleState struct {
+	CreditScores    []*models.CreditScore
+	ScoreHistory    []*models.ScoreHistory
+	OnChainMetrics  []*models.OnChainMetrics
+	OffChainMetrics []*models.OffChainMetrics
+	OracleUpdates   []*models.OracleUpdate
+	ScoreEvents     []*models.ScoreEvent
+}
+
+// ExportState reads every score table in one transaction so the export is consistent
+func (r *ScoreRepository) ExportState(ctx context.Context) (*OracleState, error) {
+	var opts *sql.TxOptions
+	if r.db.Dialector.Name() == "postgres" {
+		opts = &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	}
+
+	state := &OracleState{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, table := range state.tables() {
+			if err := tx.Order("id ASC").Find(table.rows).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export state: %w", err)
+	}
+
+	return state, nil
+}
+
+// RestoreState replaces the score tables with the given state, keeping row IDs. Unless
+// replace is set, it refuses to run when any credit score exists.
+func (r *ScoreRepository) RestoreState(ctx context.Context, state *OracleState, replace bool) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if !replace {
+			var existing int64
+			if err := tx.Model(&models.CreditScore{}).Count(&existing).Error; err != nil {
+				return err
+			}
+			if existing > 0 {
+				return ErrStateNotEmpty
+			}
+		}
+
+		// GORM inserts the column default in place of false, so deactivated scores are
+		// noted before inserting and fixed up afterwards
+		var inactive []uint
+		for _, score := range state.CreditScores {
+			if !score.IsActive {
+				inactive = append(inactive, score.ID)
+			}
+		}
+
+		for _, table := range state.tables() {
+			if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(table.model).Error; err != nil {
+				return err
+			}
+			if table.count == 0 {
+				continue
+			}
+			if err := tx.CreateInBatches(table.rows, 500).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(inactive) > 0 {
+			if err := tx.Model(&models.CreditScore{}).Where("id IN ?", inactive).Update("is_active", false).Error; err != nil {
+				return err
+			}
+		}
+
+		// Inserting explicit IDs leaves Postgres sequences behind the data
+		if tx.Dialector.Name() == "postgres" {
+			for _, table := range state.tables() {
+				stmt := &gorm.Statement{DB: tx}
+				if err := stmt.Parse(table.model); err != nil {
+					return err
+				}
+				name := stmt.Schema.Table
+				if err := tx.Exec(fmt.Sprintf(
+					"SELECT setval(pg_get_serial_sequence('%s', 'id'), COALESCE((SELECT MAX(id) FROM %s), 0) + 1, false)",
+					name, name,
+				)).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if errors.Is(err, ErrStateNotEmpty) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to restore state: %w", err)
+	}
+
+	r.invalidations.InvalidateAll(cache.ReasonRestore)
+	return nil
+}
+
+// stateTable pairs a table's model with the state's rows for it
+type stateTable struct {
+	model interface{}
+	rows  interface{} // Pointer to the state's slice
+	count int
+}
+
+func (s *OracleState) tables() []stateTable {
+	return []stateTable{
+		{&models.CreditScore{}, &s.CreditScores, len(s.CreditScores)},
+		{&models.ScoreHistory{}, &s.ScoreHistory, len(s.ScoreHistory)},
+		{&models.OnChainMetrics{}, &s.OnChainMetrics, len(s.OnChainMetrics)},
+		{&models.OffChainMetrics{}, &s.OffChainMetrics, len(s.OffChainMetrics)},
+		{&models.OracleUpdate{}, &s.OracleUpdates, len(s.OracleUpdates)},
+		{&models.ScoreEvent{}, &s.ScoreEvents, len(s.ScoreEvents)},
+	}
+}
+
 // GetStats retrieves database statistics
 func (r *ScoreRepository) GetStats(ctx context.Context) (map[string]interface{}, error) {
-	stats := make(map[string]interface{})
+	computed, err := r.computeStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := computed.Map()
+	if r.replica != nil {
+		stats["read_replica"] = r.ReplicaStatus()
+	}
+
+	return stats, nil
+}
+
+// RefreshStats recomputes the dashboard aggregates and stores them in the stats table
+func (r *ScoreRepository) RefreshStats(ctx context.Context) (*models.ScoreStats, error) {
+	stats, err := r.computeStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute stats: %w", err)
+	}
+	stats.Name = models.StatsDashboard
+
+	existing, err := r.GetMaterializedStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		stats.ID = existing.ID
+		stats.CreatedAt = existing.CreatedAt
+	}
+
+	if err := r.db.WithContext(ctx).Save(stats).Error; err != nil {
+		return nil, fmt.Errorf("failed to save stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetMaterializedStats retrieves the last stored dashboard aggregates. It returns nil
+// if they have never been computed.
+func (r *ScoreRepository) GetMaterializedStats(ctx context.Context) (*models.ScoreStats, error) {
+	var stats models.ScoreStats
+	err := r.db.WithContext(ctx).
+		Where("name = ?", models.StatsDashboard).
+		First(&stats).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get materialized stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// computeStats runs the dashboard aggregate queries
+func (r *ScoreRepository) computeStats(ctx context.Context) (*models.ScoreStats, error) {
+	started := time.Now()
+	stats := &models.ScoreStats{}
+	db := r.reader(ctx, "")
 
 	// Total active scores
-	var totalScores int64
-	if err := r.db.WithContext(ctx).Model(&models.CreditScore{}).Where("is_active = ?", true).Count(&totalScores).Error; err != nil {
+	if err := db.Model(&models.CreditScore{}).Where("is_active = ?", true).Count(&stats.TotalActiveScores).Error; err != nil {
 		return nil, err
 	}
-	stats["total_active_scores"] = totalScores
 
 	// Average score (use COALESCE to handle NULL when no records exist)
 	var avgScore sql.NullFloat64
-	if err := r.db.WithContext(ctx).Model(&models.CreditScore{}).Where("is_active = ?", true).Select("COALESCE(AVG(score), 0)").Scan(&avgScore).Error; err != nil {
+	if err := db.Model(&models.CreditScore{}).Where("is_active = ?", true).Select("COALESCE(AVG(score), 0)").Scan(&avgScore).Error; err != nil {
 		return nil, err
 	}
 	if avgScore.Valid {
-		stats["average_score"] = avgScore.Float64
-	} else {
-		stats["average_score"] = 0.0
+		stats.AverageScore = avgScore.Float64
 	}
 
 	// Scores due for update
-	var dueForUpdate int64
-	if err := r.db.WithContext(ctx).Model(&models.CreditScore{}).Where("is_active = ? AND next_update_due <= ?", true, time.Now()).Count(&dueForUpdate).Error; err != nil {
+	if err := db.Model(&models.CreditScore{}).Where("is_active = ? AND next_update_due <= ?", true, time.Now()).Count(&stats.DueForUpdate).Error; err != nil {
 		return nil, err
 	}
-	stats["due_for_update"] = dueForUpdate
 
 	// Pending oracle updates
-	var pendingUpdates int64
-	if err := r.db.WithContext(ctx).Model(&models.OracleUpdate{}).Where("status = ?", "pending").Count(&pendingUpdates).Error; err != nil {
+	if err := db.Model(&models.OracleUpdate{}).Where("status = ?", "pending").Count(&stats.PendingOracleUpdates).Error; err != nil {
+		return nil, err
+	}
+
+	// Publications waiting for the publish window
+	if err := db.Model(&models.OracleUpdate{}).Where("status = ?", models.OracleUpdateQueued).Count(&stats.QueuedOracleUpdates).Error; err != nil {
 		return nil, err
 	}
-	stats["pending_oracle_updates"] = pendingUpdates
 
+	stats.ComputedAt = time.Now()
+	stats.RefreshMs = time.Since(started).Milliseconds()
 	return stats, nil
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -23,6 +24,34 @@ func setupTestDB(t *testing.T) *gorm.DB {
 		&models.OnChainMetrics{},
 		&models.OffChainMetrics{},
 		&models.OracleUpdate{},
+		&models.AddressLabel{},
+		&models.WebhookDelivery{},
+		&models.WebhookAttempt{},
+		&models.WebhookDeadLetter{},
+		&models.ScoreEvent{},
+		&models.ScoreStats{},
+		&models.RetentionStat{},
+		&models.DataFreeze{},
+		&models.WalletAuth{},
+		&models.ScoreShare{},
+		&models.AuditLog{},
+		&models.ResearchBudget{},
+		&models.IssuedCredential{},
+		&models.ProviderAgreement{},
+		&models.PositionHealth{},
+		&models.PositionSnapshot{},
+		&models.PipelineTiming{},
+		&models.DebugTarget{},
+		&models.DebugTrace{},
+		&models.SubsystemState{},
+		&models.ScoreComponent{},
+		&models.ChainIndexCheckpoint{},
+		&models.ChainIndexAddress{},
+		&models.ChainIndexTransaction{},
+		&models.ChainIndexTokenTransfer{},
+		&models.ProviderHealth{},
+		&models.BureauAlert{},
+		&models.BureauLink{},
 	)
 	if err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)
@@ -285,7 +314,7 @@ func TestUpsertOnChainMetrics(t *testing.T) {
 		UserAddress:      address,
 		WalletAge:        365,
 		TotalTransactions: 50,
-		CollateralValue:  1000,
+		CollateralValue:  units.DecimalFromInt(1000),
 	}
 
 	err := repo.UpsertOnChainMetrics(ctx, metrics)
@@ -360,6 +389,50 @@ func TestUpsertOffChainMetrics(t *testing.T) {
 	}
 }
 
+// legacyOffChainMetrics is the off-chain metrics table from before money amounts
+// were stored as decimals
+type legacyOffChainMetrics struct {
+	ID                    uint   `gorm:"primaryKey"`
+	UserAddress           string `gorm:"uniqueIndex;not null"`
+	EstimatedAnnualIncome float64
+	DebtToIncomeRatio     float64
+}
+
+func (legacyOffChainMetrics) TableName() string {
+	return "off_chain_metrics"
+}
+
+func TestMigrateFloatMoneyColumns(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	if err := db.AutoMigrate(&legacyOffChainMetrics{}); err != nil {
+		t.Fatalf("Failed to create legacy table: %v", err)
+	}
+
+	address := "0x1234567890123456789012345678901234567890"
+	legacy := &legacyOffChainMetrics{UserAddress: address, EstimatedAnnualIncome: 85000.5, DebtToIncomeRatio: 0.28}
+	if err := db.Create(legacy).Error; err != nil {
+		t.Fatalf("Failed to create legacy metrics: %v", err)
+	}
+
+	if err := db.AutoMigrate(&models.OffChainMetrics{}); err != nil {
+		t.Fatalf("Failed to migrate metrics: %v", err)
+	}
+
+	retrieved, err := NewScoreRepository(db).GetOffChainMetrics(context.Background(), address)
+	if err != nil || retrieved == nil {
+		t.Fatalf("Failed to retrieve migrated metrics: %v", err)
+	}
+	if retrieved.EstimatedAnnualIncome.Cmp(units.MustDecimal("85000.5")) != 0 {
+		t.Errorf("Expected income 85000.5 after migration, got %s", retrieved.EstimatedAnnualIncome)
+	}
+	if retrieved.DebtToIncomeRatio.Cmp(units.MustDecimal("0.28")) != 0 {
+		t.Errorf("Expected DTI 0.28 after migration, got %s", retrieved.DebtToIncomeRatio)
+	}
+}
+
 func TestCreateAndGetOracleUpdate(t *testing.T) {
 	db := setupTestDB(t)
 	repo := NewScoreRepository(db)
@@ -449,6 +522,169 @@ func TestGetPendingOracleUpdates(t *testing.T) {
 	}
 }
 
+func TestGetUnpublishedScores(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewScoreRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	for i, address := range []string{"0x1111", "0x2222", "0x3333"} {
+		score := &models.CreditScore{
+			UserAddress: address,
+			Score:       700,
+			Confidence:  80,
+			DataHash:    "hash" + address,
+			LastUpdated: now.Add(time.Duration(i) * time.Minute),
+			IsActive:    true,
+		}
+		if err := repo.Create(ctx, score); err != nil {
+			t.Fatalf("Failed to create score: %v", err)
+		}
+	}
+
+	// 0x1111's current score is on-chain, 0x2222's publish failed, 0x3333 has an outdated publish
+	updates := []*models.OracleUpdate{
+		{UserAddress: "0x1111", Score: 700, Confidence: 80, DataHash: "hash0x1111", TxHash: "0xaaa", Status: "confirmed"},
+		{UserAddress: "0x2222", Score: 700, Confidence: 80, DataHash: "hash0x2222", Status: "failed"},
+		{UserAddress: "0x3333", Score: 650, Confidence: 80, DataHash: "old-hash", TxHash: "0xaaa", Status: "confirmed"},
+	}
+	for _, u := range updates {
+		if err := repo.CreateOracleUpdate(ctx, u); err != nil {
+			t.Fatalf("Failed to create oracle update: %v", err)
+		}
+	}
+
+	unpublished, err := repo.GetUnpublishedScores(ctx, 10)
+	if err != nil {
+		t.Fatalf("Failed to get unpublished scores: %v", err)
+	}
+
+	if len(unpublished) != 2 {
+		t.Fatalf("Expected 2 unpublished scores, got %d", len(unpublished))
+	}
+	if unpublished[0].UserAddress != "0x2222" || unpublished[1].UserAddress != "0x3333" {
+		t.Errorf("Unexpected unpublished scores: %s, %s", unpublished[0].UserAddress, unpublished[1].UserAddress)
+	}
+}
+
+func TestAppendAndListScoreEvents(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewScoreRepository(db)
+	ctx := context.Background()
+
+	for _, e := range []struct{ address, eventType string }{
+		{"0x1111", models.ScoreEventMetricsFetched},
+		{"0x1111", models.ScoreEventCalculated},
+		{"0x2222", models.ScoreEventCalculated},
+		{"0x1111", models.ScoreEventPublished},
+	} {
+		event := &models.ScoreEvent{UserAddress: e.address, EventType: e.eventType, Payload: "{}"}
+		if err := repo.AppendScoreEvent(ctx, event); err != nil {
+			t.Fatalf("Failed to append score event: %v", err)
+		}
+	}
+
+	events, err := repo.ListScoreEvents(ctx, "0x1111", 0, 0)
+	if err != nil {
+		t.Fatalf("Failed to list score events: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(events))
+	}
+	for i, event := range events {
+		if event.Sequence != uint64(i+1) {
+			t.Errorf("Expected sequence %d, got %d", i+1, event.Sequence)
+		}
+	}
+	if events[2].EventType != models.ScoreEventPublished {
+		t.Errorf("Expected last event to be published, got %s", events[2].EventType)
+	}
+
+	// Each address has its own sequence
+	others, err := repo.ListScoreEvents(ctx, "0x2222", 0, 0)
+	if err != nil {
+		t.Fatalf("Failed to list score events: %v", err)
+	}
+	if len(others) != 1 || others[0].Sequence != 1 {
+		t.Errorf("Expected one event with sequence 1 for 0x2222, got %d events", len(others))
+	}
+
+	after, err := repo.ListScoreEvents(ctx, "0x1111", 1, 1)
+	if err != nil {
+		t.Fatalf("Failed to list score events: %v", err)
+	}
+	if len(after) != 1 || after[0].Sequence != 2 {
+		t.Errorf("Expected only sequence 2 after sequence 1 with limit 1")
+	}
+}
+
+func TestExportAndRestoreState(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewScoreRepository(db)
+	ctx := context.Background()
+
+	scores := []*models.CreditScore{
+		{UserAddress: "0x1111", Score: 700, Confidence: 80, DataHash: "hash1", LastUpdated: time.Now(), IsActive: true},
+		{UserAddress: "0x2222", Score: 650, Confidence: 70, DataHash: "hash2", LastUpdated: time.Now(), IsActive: true},
+	}
+	for _, score := range scores {
+		if err := repo.Create(ctx, score); err != nil {
+			t.Fatalf("Failed to create score: %v", err)
+		}
+	}
+	// Deactivated scores must stay deactivated instead of taking the column default
+	if err := db.Model(scores[1]).Update("is_active", false).Error; err != nil {
+		t.Fatalf("Failed to deactivate score: %v", err)
+	}
+	if err := repo.CreateOracleUpdate(ctx, &models.OracleUpdate{UserAddress: "0x1111", Score: 700, Confidence: 80, DataHash: "hash1", Status: models.OracleUpdateQueued}); err != nil {
+		t.Fatalf("Failed to create oracle update: %v", err)
+	}
+
+	state, err := repo.ExportState(ctx)
+	if err != nil {
+		t.Fatalf("Failed to export state: %v", err)
+	}
+	if len(state.CreditScores) != 2 || len(state.OracleUpdates) != 1 {
+		t.Fatalf("Expected 2 scores and 1 oracle update, got %d and %d", len(state.CreditScores), len(state.OracleUpdates))
+	}
+
+	if err := repo.RestoreState(ctx, state, false); err != ErrStateNotEmpty {
+		t.Errorf("Expected ErrStateNotEmpty restoring over existing scores, got %v", err)
+	}
+
+	// Simulate losing a score, then restore over the top
+	if err := db.Delete(&models.CreditScore{}, scores[0].ID).Error; err != nil {
+		t.Fatalf("Failed to delete score: %v", err)
+	}
+	if err := repo.RestoreState(ctx, state, true); err != nil {
+		t.Fatalf("Failed to restore state: %v", err)
+	}
+
+	restored, err := repo.GetByAddress(ctx, "0x1111")
+	if err != nil || restored == nil {
+		t.Fatalf("Expected restored score, got %v", err)
+	}
+	if restored.ID != scores[0].ID || restored.Score != 700 {
+		t.Errorf("Expected score %d with ID %d, got %d with ID %d", 700, scores[0].ID, restored.Score, restored.ID)
+	}
+
+	var inactive models.CreditScore
+	if err := db.Where("user_address = ?", "0x2222").First(&inactive).Error; err != nil {
+		t.Fatalf("Failed to load restored score: %v", err)
+	}
+	if inactive.IsActive {
+		t.Error("Expected restored score to stay inactive")
+	}
+
+	updates, err := repo.ListOracleUpdates(ctx, models.OracleUpdateQueued, 10)
+	if err != nil {
+		t.Fatalf("Failed to list oracle updates: %v", err)
+	}
+	if len(updates) != 1 {
+		t.Errorf("Expected 1 restored queued update, got %d", len(updates))
+	}
+}
+
 func TestGetStats(t *testing.T) {
 	db := setupTestDB(t)
 	repo := NewScoreRepository(db)
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+// RecordSimulatedTransaction stores a transaction the null publisher would have sent
+func (r *ScoreRepository) RecordSimulatedTransaction(ctx context.Context, tx *models.SimulatedTransaction) error {
+	if err := r.db.WithContext(ctx).Create(tx).Error; err != nil {
+		return fmt.Errorf("failed to record simulated transaction: %w", err)
+	}
+	return nil
+}
+
+// NextSimulatedNonce returns the nonce of the next simulated transaction to a contract
+func (r *ScoreRepository) NextSimulatedNonce(ctx context.Context, contract string) (uint64, error) {
+	var last struct{ Nonce *uint64 }
+	err := r.db.WithContext(ctx).
+		Model(&models.SimulatedTransaction{}).
+		Select("MAX(nonce) AS nonce").
+		Where("contract = ?", contract).
+		Scan(&last).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to get simulated nonce: %w", err)
+	}
+	if last.Nonce == nil {
+		return 0, nil
+	}
+	return *last.Nonce + 1, nil
+}
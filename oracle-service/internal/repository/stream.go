@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// streamRows runs query and calls fn with each row as it is read, instead of loading
+// the whole result. Query and scan failures are wrapped with what was being read;
+// errors returned by fn stop the stream and are passed back as they are.
+func streamRows[T any](query *gorm.DB, what string, fn func(*T) error) error {
+	rows, err := query.Rows()
+	if err != nil {
+		return fmt.Errorf("failed to stream %s: %w", what, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row T
+		if err := query.ScanRows(rows, &row); err != nil {
+			return fmt.Errorf("failed to stream %s: %w", what, err)
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to stream %s: %w", what, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// SubsystemRepository handles database operations for paused subsystems
+type SubsystemRepository struct {
+	db *gorm.DB
+}
+
+// NewSubsystemRepository creates a new subsystem repository
+func NewSubsystemRepository(db *gorm.DB) *SubsystemRepository {
+	return &SubsystemRepository{db: db}
+}
+
+// SaveState creates or replaces a subsystem's state
+func (r *SubsystemRepository) SaveState(ctx context.Context, state *models.SubsystemState) error {
+	if err := r.db.WithContext(ctx).Save(state).Error; err != nil {
+		return fmt.Errorf("failed to save subsystem state: %w", err)
+	}
+	return nil
+}
+
+// ListStates retrieves the states of all subsystems that were ever paused
+func (r *SubsystemRepository) ListStates(ctx context.Context) ([]*models.SubsystemState, error) {
+	var states []*models.SubsystemState
+	if err := r.db.WithContext(ctx).Order("name").Find(&states).Error; err != nil {
+		return nil, fmt.Errorf("failed to list subsystem states: %w", err)
+	}
+	return states, nil
+}
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// WebhookRepository handles database operations for webhook delivery records
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// GetDelivery retrieves a delivery record by direction, source, and delivery ID
+func (r *WebhookRepository) GetDelivery(ctx context.Context, direction, source, deliveryID string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.db.WithContext(ctx).
+		Where("direction = ? AND source = ? AND delivery_id = ?", direction, source, deliveryID).
+		First(&delivery).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return &delivery, nil
+}
+
+// SaveDelivery creates or updates a delivery record
+func (r *WebhookRepository) SaveDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	if err := r.db.WithContext(ctx).Save(delivery).Error; err != nil {
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// GetDeliveryByID retrieves a delivery record by primary key
+func (r *WebhookRepository) GetDeliveryByID(ctx context.Context, id uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.db.WithContext(ctx).First(&delivery, id).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return &delivery, nil
+}
+
+// ListDeliveries lists deliveries in one direction, newest first, optionally filtered by status
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, direction, status string, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	query := r.db.WithContext(ctx).Where("direction = ?", direction)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	err := query.Order("created_at DESC").Limit(limit).Find(&deliveries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// ListDueRetries lists outbound deliveries whose next retry is due, oldest first
+func (r *WebhookRepository) ListDueRetries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := r.db.WithContext(ctx).
+		Where("direction = ? AND status = ? AND next_attempt_at <= ?", models.WebhookOutbound, models.WebhookRetrying, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due webhook retries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// CreateAttempt records an outbound delivery attempt
+func (r *WebhookRepository) CreateAttempt(ctx context.Context, attempt *models.WebhookAttempt) error {
+	if err := r.db.WithContext(ctx).Create(attempt).Error; err != nil {
+		return fmt.Errorf("failed to record webhook attempt: %w", err)
+	}
+	return nil
+}
+
+// ListAttempts lists a delivery's attempts in order
+func (r *WebhookRepository) ListAttempts(ctx context.Context, deliveryID uint) ([]*models.WebhookAttempt, error) {
+	var attempts []*models.WebhookAttempt
+	err := r.db.WithContext(ctx).
+		Where("delivery_id = ?", deliveryID).
+		Order("attempt ASC").
+		Find(&attempts).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// SaveDeadLetter creates or updates a dead-lettered delivery
+func (r *WebhookRepository) SaveDeadLetter(ctx context.Context, deadLetter *models.WebhookDeadLetter) error {
+	if err := r.db.WithContext(ctx).Save(deadLetter).Error; err != nil {
+		return fmt.Errorf("failed to save webhook dead letter: %w", err)
+	}
+	return nil
+}
+
+// GetDeadLetter retrieves a dead-lettered delivery by ID
+func (r *WebhookRepository) GetDeadLetter(ctx context.Context, id uint) (*models.WebhookDeadLetter, error) {
+	var deadLetter models.WebhookDeadLetter
+	err := r.db.WithContext(ctx).First(&deadLetter, id).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook dead letter: %w", err)
+	}
+
+	return &deadLetter, nil
+}
+
+// GetDeadLetterByDelivery retrieves the dead letter for a delivery, if any
+func (r *WebhookRepository) GetDeadLetterByDelivery(ctx context.Context, deliveryID uint) (*models.WebhookDeadLetter, error) {
+	var deadLetter models.WebhookDeadLetter
+	err := r.db.WithContext(ctx).
+		Where("webhook_delivery_id = ?", deliveryID).
+		First(&deadLetter).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook dead letter: %w", err)
+	}
+
+	return &deadLetter, nil
+}
+
+// ListDeadLetters lists dead-lettered deliveries, newest first. Replayed entries are
+// included only when requested.
+func (r *WebhookRepository) ListDeadLetters(ctx context.Context, includeReplayed bool, limit int) ([]*models.WebhookDeadLetter, error) {
+	var deadLetters []*models.WebhookDeadLetter
+	query := r.db.WithContext(ctx)
+	if !includeReplayed {
+		query = query.Where("replayed_at IS NULL")
+	}
+
+	err := query.Order("created_at DESC").Limit(limit).Find(&deadLetters).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook dead letters: %w", err)
+	}
+
+	return deadLetters, nil
+}
+
+// GetDeliveryStats summarizes outbound deliveries created since the given time
+func (r *WebhookRepository) GetDeliveryStats(ctx context.Context, since time.Time) (map[string]interface{}, error) {
+	stats := make(map[string]interface{})
+
+	type statusCount struct {
+		Status string
+		Count  int64
+	}
+	var counts []statusCount
+	err := r.db.WithContext(ctx).
+		Model(&models.WebhookDelivery{}).
+		Select("status, COUNT(*) AS count").
+		Where("direction = ? AND created_at >= ?", models.WebhookOutbound, since).
+		Group("status").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	byStatus := make(map[string]int64)
+	var total int64
+	for _, c := range counts {
+		byStatus[c.Status] = c.Count
+		total += c.Count
+	}
+	stats["deliveries"] = total
+	stats["by_status"] = byStatus
+
+	var latency struct {
+		Attempts     int64
+		Failures     int64
+		AvgLatencyMs float64
+		MaxLatencyMs int64
+	}
+	err = r.db.WithContext(ctx).
+		Model(&models.WebhookAttempt{}).
+		Select("COUNT(*) AS attempts, "+
+			"COALESCE(SUM(CASE WHEN success THEN 0 ELSE 1 END), 0) AS failures, "+
+			"COALESCE(AVG(latency_ms), 0) AS avg_latency_ms, "+
+			"COALESCE(MAX(latency_ms), 0) AS max_latency_ms").
+		Where("created_at >= ?", since).
+		Scan(&latency).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize webhook attempts: %w", err)
+	}
+	stats["attempts"] = latency.Attempts
+	stats["failed_attempts"] = latency.Failures
+	stats["avg_latency_ms"] = latency.AvgLatencyMs
+	stats["max_latency_ms"] = latency.MaxLatencyMs
+
+	var pendingDeadLetters int64
+	if err := r.db.WithContext(ctx).Model(&models.WebhookDeadLetter{}).Where("replayed_at IS NULL").Count(&pendingDeadLetters).Error; err != nil {
+		return nil, fmt.Errorf("failed to count webhook dead letters: %w", err)
+	}
+	stats["dead_letters"] = pendingDeadLetters
+
+	return stats, nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+func TestWebhookDueRetriesAndStats(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewWebhookRepository(db)
+	ctx := context.Background()
+
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	deliveries := []*models.WebhookDelivery{
+		{Direction: models.WebhookOutbound, Source: "https://a.example", DeliveryID: "evt_1", Status: models.WebhookRetrying, NextAttemptAt: &past},
+		{Direction: models.WebhookOutbound, Source: "https://a.example", DeliveryID: "evt_2", Status: models.WebhookRetrying, NextAttemptAt: &future},
+		{Direction: models.WebhookOutbound, Source: "https://a.example", DeliveryID: "evt_3", Status: models.WebhookDelivered},
+		{Direction: models.WebhookInbound, Source: "plaid", DeliveryID: "evt_4", Status: models.WebhookProcessed},
+	}
+	for _, d := range deliveries {
+		if err := repo.SaveDelivery(ctx, d); err != nil {
+			t.Fatalf("Failed to save delivery: %v", err)
+		}
+	}
+
+	due, err := repo.ListDueRetries(ctx, time.Now(), 10)
+	if err != nil {
+		t.Fatalf("Failed to list due retries: %v", err)
+	}
+	if len(due) != 1 || due[0].DeliveryID != "evt_1" {
+		t.Errorf("Expected only evt_1 to be due, got %+v", due)
+	}
+
+	for i, latency := range []int64{100, 300} {
+		err := repo.CreateAttempt(ctx, &models.WebhookAttempt{
+			DeliveryID: deliveries[0].ID,
+			Attempt:    i + 1,
+			Success:    i == 1,
+			LatencyMs:  latency,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create attempt: %v", err)
+		}
+	}
+
+	stats, err := repo.GetDeliveryStats(ctx, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+	if stats["deliveries"].(int64) != 3 {
+		t.Errorf("Expected 3 outbound deliveries, got %v", stats["deliveries"])
+	}
+	if stats["by_status"].(map[string]int64)[models.WebhookRetrying] != 2 {
+		t.Errorf("Expected 2 retrying deliveries, got %v", stats["by_status"])
+	}
+	if stats["failed_attempts"].(int64) != 1 || stats["avg_latency_ms"].(float64) != 200 {
+		t.Errorf("Unexpected attempt stats: %v", stats)
+	}
+}
@@ -0,0 +1,198 @@
+package schemas
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/events"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/webhooks"
+)
+
+// Channels payloads are sent on
+const (
+	ChannelWebhook = "webhook" // Data of outbound webhook bodies
+	ChannelEvent   = "event"   // Data of message bus events
+)
+
+// Entry is one version of the schema of a payload type. A published version never
+// changes; a change to a payload is a new version.
+type Entry struct {
+	Channel   string  `json:"channel"`
+	EventType string  `json:"event_type"`
+	Version   int     `json:"version"`
+	Current   bool    `json:"current"` // The version payloads are sent and validated as
+	Schema    *Schema `json:"schema"`
+}
+
+// Path is the API path the schema is served from, which is also its $id
+func (e *Entry) Path() string {
+	return Path(e.Channel, e.EventType, e.Version)
+}
+
+// Path is the API path of a schema version
+func Path(channel, eventType string, version int) string {
+	return fmt.Sprintf("/api/v1/schemas/%s/%s/v%d", channel, eventType, version)
+}
+
+// registry holds every schema version by channel and event type, oldest first
+var registry = map[string]map[string][]*Schema{
+	ChannelWebhook: {
+		webhooks.EventScoreChanged:   {scoreChangedV1},
+		webhooks.EventPositionAtRisk: {positionAtRiskV1},
+	},
+	ChannelEvent: {
+		events.ScoreCalculated:    {scoreChangedV1},
+		events.ScorePublished:     {scorePublishedV1},
+		events.ProviderFailed:     {providerFailedV1},
+		events.PositionAtRisk:     {positionAtRiskV1},
+		events.SignerUnauthorized: {signerUnauthorizedV1},
+	},
+}
+
+// All returns every schema version, ordered by channel, event type and version
+func All() []*Entry {
+	var entries []*Entry
+	for channel, types := range registry {
+		for eventType, versions := range types {
+			for i := range versions {
+				entries = append(entries, entry(channel, eventType, i+1))
+			}
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Channel != b.Channel {
+			return a.Channel < b.Channel
+		}
+		if a.EventType != b.EventType {
+			return a.EventType < b.EventType
+		}
+		return a.Version < b.Version
+	})
+	return entries
+}
+
+// Get returns a schema version, or nil if there is none
+func Get(channel, eventType string, version int) *Entry {
+	versions := registry[channel][eventType]
+	if version < 1 || version > len(versions) {
+		return nil
+	}
+	return entry(channel, eventType, version)
+}
+
+// Current returns the version payloads of a type are sent as, or nil if the type has
+// no schema
+func Current(channel, eventType string) *Entry {
+	return Get(channel, eventType, len(registry[channel][eventType]))
+}
+
+// Validate checks a payload against the current schema of its type. Payloads of types
+// without a schema are rejected, so no payload is sent without a contract.
+func Validate(channel, eventType string, data interface{}) error {
+	current := Current(channel, eventType)
+	if current == nil {
+		return fmt.Errorf("no schema for %s %s", channel, eventType)
+	}
+	if err := current.Schema.ValidateValue(data); err != nil {
+		return fmt.Errorf("%s %s payload does not match schema v%d: %w", channel, eventType, current.Version, err)
+	}
+	return nil
+}
+
+// entry builds the entry of a schema version, stamping its $id and title
+func entry(channel, eventType string, version int) *Entry {
+	versions := registry[channel][eventType]
+	schema := *versions[version-1]
+	schema.Draft = Draft
+	schema.ID = Path(channel, eventType, version)
+	schema.Title = fmt.Sprintf("%s %s v%d", channel, eventType, version)
+	return &Entry{
+		Channel:   channel,
+		EventType: eventType,
+		Version:   version,
+		Current:   version == len(versions),
+		Schema:    &schema,
+	}
+}
+
+// Property schemas shared by the payloads
+var (
+	address    = &Schema{Type: "string", Description: "Lowercase wallet address"}
+	dateTime   = &Schema{Type: "string", Format: "date-time"}
+	score      = &Schema{Type: "integer", Minimum: bound(float64(units.MinScore)), Maximum: bound(float64(units.MaxScore))}
+	confidence = &Schema{Type: "integer", Minimum: bound(0), Maximum: bound(100)}
+	dataHash   = &Schema{Type: "string", Description: "Hash of the data the score was calculated from"}
+)
+
+var scoreChangedV1 = object("A credit score was calculated or recalculated", map[string]*Schema{
+	"address": address,
+	"previous_score": {
+		Type: "integer", Minimum: bound(float64(units.MinScore)), Maximum: bound(float64(units.MaxScore)),
+		Description: "Omitted for a first score",
+	},
+	"score":      score,
+	"confidence": confidence,
+	"change_reason": {Type: "string", Enum: []string{
+		models.ChangeReasonManual,
+		models.ChangeReasonScheduled,
+		models.ChangeReasonProviderRefresh,
+		models.ChangeReasonBureauAlert,
+		models.ChangeReasonPositionRisk,
+	}},
+	"data_hash":  dataHash,
+	"updated_at": dateTime,
+}, "address", "score", "confidence", "change_reason", "data_hash", "updated_at")
+
+var scorePublishedV1 = object("A credit score was submitted to the oracle contract", map[string]*Schema{
+	"address":    address,
+	"score":      score,
+	"confidence": confidence,
+	"data_hash":  dataHash,
+	"tx_hash":    {Type: "string"},
+	"batched":    {Type: "boolean", Description: "Whether the score was published in a batch transaction"},
+}, "address", "score", "confidence", "data_hash", "tx_hash", "batched")
+
+var providerFailedV1 = object("A data provider failed while collecting metrics", map[string]*Schema{
+	"provider": {Type: "string"},
+	"address":  {Type: "string", Description: "Omitted when the failure does not concern one address"},
+	"error":    {Type: "string"},
+}, "provider", "error")
+
+var positionAtRiskV1 = object("A lending position's health factor fell below the alert threshold", map[string]*Schema{
+	"address":        address,
+	"protocol":       {Type: "string"},
+	"health_factor":  {Type: "number", Minimum: bound(0)},
+	"threshold":      {Type: "number", Minimum: bound(0)},
+	"collateral_usd": {Type: "number", Minimum: bound(0)},
+	"debt_usd":       {Type: "number", Minimum: bound(0)},
+	"confidence": {
+		Type: "integer", Minimum: bound(0), Maximum: bound(100),
+		Description: "Confidence of the address's score while the position is at risk; omitted when unknown",
+	},
+	"checked_at": dateTime,
+}, "address", "protocol", "health_factor", "threshold", "collateral_usd", "debt_usd", "checked_at")
+
+var signerUnauthorizedV1 = object("The oracle contract no longer accepts the signer, so publications were blocked", map[string]*Schema{
+	"target":     {Type: "string", Enum: []string{models.PublishTargetPrimary, models.PublishTargetCanary}},
+	"blocked":    {Type: "integer", Minimum: bound(1), Description: "Publications blocked by this check"},
+	"checked_at": dateTime,
+}, "target", "blocked", "checked_at")
+
+// object is a closed object schema: properties not listed are rejected
+func object(description string, properties map[string]*Schema, required ...string) *Schema {
+	closed := false
+	return &Schema{
+		Description:          description,
+		Type:                 "object",
+		Properties:           properties,
+		Required:             required,
+		AdditionalProperties: &closed,
+	}
+}
+
+func bound(value float64) *float64 {
+	return &value
+}
@@ -0,0 +1,126 @@
+// Package schemas holds the versioned JSON Schemas of the payloads the oracle sends
+// to webhook subscribers and the event bus, and validates outgoing payloads against
+// them so consumers can rely on the published contract.
+package schemas
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect of every schema
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema, limited to the keywords the oracle's payloads need
+type Schema struct {
+	Draft                string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+}
+
+// ValidateValue checks a payload against the schema, as it would be encoded to JSON
+func (s *Schema) ValidateValue(value interface{}) error {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return fmt.Errorf("failed to decode payload: %w", err)
+	}
+	return s.validate("$", decoded)
+}
+
+func (s *Schema) validate(path string, value interface{}) error {
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: must be an object", path)
+		}
+		return s.validateObject(path, object)
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: must be a string", path)
+		}
+		return s.validateString(path, str)
+	case "integer", "number":
+		number, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("%s: must be a number", path)
+		}
+		if s.Type == "integer" && number != math.Trunc(number) {
+			return fmt.Errorf("%s: must be an integer", path)
+		}
+		if s.Minimum != nil && number < *s.Minimum {
+			return fmt.Errorf("%s: must be at least %v", path, *s.Minimum)
+		}
+		if s.Maximum != nil && number > *s.Maximum {
+			return fmt.Errorf("%s: must be at most %v", path, *s.Maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: must be a boolean", path)
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateObject(path string, object map[string]interface{}) error {
+	for _, name := range s.Required {
+		if _, ok := object[name]; !ok {
+			return fmt.Errorf("%s: missing required property %q", path, name)
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		property, ok := s.Properties[name]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				return fmt.Errorf("%s: unexpected property %q", path, name)
+			}
+			continue
+		}
+		if err := property.validate(path+"."+name, object[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateString(path, value string) error {
+	if len(s.Enum) > 0 {
+		found := false
+		for _, allowed := range s.Enum {
+			found = found || value == allowed
+		}
+		if !found {
+			return fmt.Errorf("%s: must be one of %s", path, strings.Join(s.Enum, ", "))
+		}
+	}
+	if s.Format == "date-time" {
+		if _, err := time.Parse(time.RFC3339Nano, value); err != nil {
+			return fmt.Errorf("%s: must be an RFC 3339 date-time", path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package schemas
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/events"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/webhooks"
+)
+
+func scoreChanged() map[string]interface{} {
+	return map[string]interface{}{
+		"address":       "0x1234567890123456789012345678901234567890",
+		"score":         712,
+		"confidence":    80,
+		"change_reason": "scheduled",
+		"data_hash":     "0xabc",
+		"updated_at":    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(ChannelWebhook, webhooks.EventScoreChanged, scoreChanged()); err != nil {
+		t.Fatalf("Expected a valid payload, got %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		change func(map[string]interface{})
+		want   string
+	}{
+		{"missing field", func(p map[string]interface{}) { delete(p, "data_hash") }, `missing required property "data_hash"`},
+		{"extra field", func(p map[string]interface{}) { p["tenant"] = "acme" }, `unexpected property "tenant"`},
+		{"score out of range", func(p map[string]interface{}) { p["score"] = 900 }, "$.score: must be at most 850"},
+		{"fractional score", func(p map[string]interface{}) { p["score"] = 700.5 }, "$.score: must be an integer"},
+		{"unknown reason", func(p map[string]interface{}) { p["change_reason"] = "audit" }, "$.change_reason: must be one of"},
+		{"bad time", func(p map[string]interface{}) { p["updated_at"] = "yesterday" }, "$.updated_at: must be an RFC 3339 date-time"},
+		{"wrong type", func(p map[string]interface{}) { p["address"] = 42 }, "$.address: must be a string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := scoreChanged()
+			tt.change(payload)
+			err := Validate(ChannelWebhook, webhooks.EventScoreChanged, payload)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if err := Validate(ChannelEvent, events.DisputeOpened, nil); err == nil {
+		t.Error("Expected payloads of types without a schema to be rejected")
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	entries := All()
+	if len(entries) != 7 {
+		t.Fatalf("Expected 7 schemas, got %d", len(entries))
+	}
+	for i, entry := range entries {
+		if i > 0 && entries[i-1].Channel+entries[i-1].EventType > entry.Channel+entry.EventType {
+			t.Errorf("Expected schemas ordered by channel and type, got %s %s after %s %s",
+				entry.Channel, entry.EventType, entries[i-1].Channel, entries[i-1].EventType)
+		}
+		if entry.Schema.ID != entry.Path() || !entry.Current {
+			t.Errorf("Unexpected entry %s: id %s, current %v", entry.Path(), entry.Schema.ID, entry.Current)
+		}
+	}
+
+	current := Current(ChannelEvent, events.ScorePublished)
+	if current == nil || current.Path() != "/api/v1/schemas/event/score.published/v1" {
+		t.Fatalf("Unexpected current score.published schema: %+v", current)
+	}
+	if Get(ChannelEvent, events.ScorePublished, 2) != nil || Get("queue", events.ScorePublished, 1) != nil {
+		t.Error("Expected no schema for unknown versions or channels")
+	}
+}
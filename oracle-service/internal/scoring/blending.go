@@ -0,0 +1,81 @@
+package scoring
+
+import (
+	"math"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// Renormalization of component weights when data is missing
+const (
+	// MaxWeightBoost caps how far an available component's weight is raised to cover
+	// for missing ones, so a single data source never counts as a full profile
+	MaxWeightBoost = 1.5
+
+	// reweightConfidencePenalty is the confidence lost per unit of weight moved to
+	// other components, e.g. 15% when 30% of the weight was moved
+	reweightConfidencePenalty = 0.5
+)
+
+// Blend is the weight each component carries in a final score. With all data
+// present it is the model's nominal split, 40/40/20 for the current model. Missing components score MinScore, so
+// their weight is shifted to the available components, each raised by at most
+// MaxWeightBoost; whatever the cap leaves behind stays with the missing components.
+type Blend struct {
+	OnChain  float64 `json:"on_chain"`
+	OffChain float64 `json:"off_chain"`
+	Hybrid   float64 `json:"hybrid"`
+
+	// Reweighted is the share of the score moved from missing components to available ones
+	Reweighted float64 `json:"reweighted"`
+}
+
+// blendFor weighs the components by the data available. Hybrid factors need on-chain
+// data, so the hybrid component is missing along with it.
+func blendFor(nominal ComponentWeights, onChain *models.OnChainMetrics, offChain *models.OffChainMetrics) Blend {
+	weights := []float64{nominal.OnChain.Fraction(), nominal.OffChain.Fraction(), nominal.Hybrid.Fraction()}
+	available := []bool{onChain != nil, offChain != nil, onChain != nil}
+
+	var availableWeight float64
+	for i, weight := range weights {
+		if available[i] {
+			availableWeight += weight
+		}
+	}
+	if availableWeight == 0 || availableWeight >= 1 {
+		return Blend{OnChain: weights[0], OffChain: weights[1], Hybrid: weights[2]}
+	}
+
+	boost := math.Min(1/availableWeight, MaxWeightBoost)
+	left := (1 - availableWeight*boost) / (1 - availableWeight) // Share kept by missing components
+	for i := range weights {
+		if available[i] {
+			weights[i] *= boost
+		} else {
+			weights[i] *= left
+		}
+	}
+
+	return Blend{
+		OnChain:    weights[0],
+		OffChain:   weights[1],
+		Hybrid:     weights[2],
+		Reweighted: availableWeight * (boost - 1),
+	}
+}
+
+// Of blends component scores into a final score, within the valid range
+func (b Blend) Of(onChain, offChain, hybrid units.Score) units.Score {
+	return units.ClampScore(
+		b.OnChain*float64(onChain) +
+			b.OffChain*float64(offChain) +
+			b.Hybrid*float64(hybrid),
+	)
+}
+
+// confidenceFactor is the share of confidence kept by a score whose weights were
+// renormalized
+func (b Blend) confidenceFactor() float64 {
+	return 1 - b.Reweighted*reweightConfidencePenalty
+}
@@ -0,0 +1,91 @@
+package scoring
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+func TestBlendFor(t *testing.T) {
+	onChain := &models.OnChainMetrics{}
+	offChain := &models.OffChainMetrics{}
+
+	tests := []struct {
+		name     string
+		onChain  *models.OnChainMetrics
+		offChain *models.OffChainMetrics
+		expected Blend
+	}{
+		{"All data", onChain, offChain, Blend{OnChain: 0.4, OffChain: 0.4, Hybrid: 0.2}},
+		// On-chain and hybrid raised by the full 1.5x, leaving 10% with off-chain
+		{"No off-chain data", onChain, nil, Blend{OnChain: 0.6, OffChain: 0.1, Hybrid: 0.3, Reweighted: 0.3}},
+		{"No on-chain data", nil, offChain, Blend{OnChain: 0.4 * 2 / 3, OffChain: 0.6, Hybrid: 0.2 * 2 / 3, Reweighted: 0.2}},
+		{"No data", nil, nil, Blend{OnChain: 0.4, OffChain: 0.4, Hybrid: 0.2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blend := blendFor(DefaultWeights, tt.onChain, tt.offChain)
+			got := []float64{blend.OnChain, blend.OffChain, blend.Hybrid, blend.Reweighted}
+			expected := []float64{tt.expected.OnChain, tt.expected.OffChain, tt.expected.Hybrid, tt.expected.Reweighted}
+			for i := range got {
+				if math.Abs(got[i]-expected[i]) > 1e-9 {
+					t.Fatalf("blendFor() = %+v, expected %+v", blend, tt.expected)
+				}
+			}
+			if sum := blend.OnChain + blend.OffChain + blend.Hybrid; math.Abs(sum-1) > 1e-9 {
+				t.Errorf("Expected weights to add up to 1, got %f", sum)
+			}
+		})
+	}
+}
+
+func TestMissingComponentIsReweighted(t *testing.T) {
+	engine := NewEngine()
+	onChain := &models.OnChainMetrics{
+		WalletAge:           365,
+		TotalTransactions:   50,
+		AvgTransactionValue: units.DecimalFromInt(250),
+		DeFiInteractions:    15,
+		BorrowingHistory:    5,
+		RepaymentHistory:    5,
+		CollateralValue:     units.DecimalFromInt(2000),
+		LastActivity:        time.Now().Add(-2 * 24 * time.Hour),
+	}
+
+	score, err := engine.CalculateScore(onChain, nil)
+	if err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	// The missing off-chain component drags the score down by its remaining 10% only
+	nominal := units.ClampScore(OnChainWeight.Of(float64(score.OnChainScore)) +
+		OffChainWeight.Of(float64(MinScore)) +
+		HybridWeight.Of(float64(score.HybridScore)))
+	if score.Score <= nominal {
+		t.Errorf("Expected reweighting to lift the score above %d, got %d", nominal, score.Score)
+	}
+
+	// Recent activity, transactions and borrowing give 50 before the 15% reduction
+	if score.Confidence != 43 {
+		t.Errorf("Expected confidence 43, got %d", score.Confidence)
+	}
+
+	explanation, err := engine.Explain(onChain, nil)
+	if err != nil {
+		t.Fatalf("Failed to explain score: %v", err)
+	}
+	if explanation.Weights.Reweighted == 0 {
+		t.Error("Expected the explanation to show the reweighted blend")
+	}
+	var missing bool
+	for _, adjustment := range explanation.Adjustments {
+		missing = missing || adjustment.Component == ComponentOffChain && adjustment.Factor == "missing_component"
+	}
+	if !missing {
+		t.Errorf("Expected a missing off-chain adjustment, got %+v", explanation.Adjustments)
+	}
+}
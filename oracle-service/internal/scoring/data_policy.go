@@ -0,0 +1,91 @@
+package scoring
+
+import (
+	"fmt"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+// Data requirements a final score can be issued on
+const (
+	RequirementOnChainHistory = "onchain_history" // Wallet history of at least the policy's minimum days
+	RequirementBureauFile     = "bureau_file"     // A credit bureau score
+	RequirementVerifiedIncome = "verified_income" // Income verified by Plaid or a payroll provider
+)
+
+// NextStep is something a borrower can do to get a final score instead of a
+// provisional one
+type NextStep struct {
+	Requirement string `json:"requirement"`
+	Description string `json:"description"`
+}
+
+// nextSteps describe how to meet each requirement
+var nextSteps = map[string]string{
+	RequirementOnChainHistory: "Build a longer on-chain history with this wallet",
+	RequirementBureauFile:     "Link your credit bureau file",
+	RequirementVerifiedIncome: "Connect a bank account or payroll provider to verify your income",
+}
+
+// DataPolicy is the minimum data a score must be based on to be final. Meeting any one
+// of its requirements is enough; scores meeting none are provisional.
+type DataPolicy struct {
+	requirements   []string
+	minHistoryDays uint32
+}
+
+// NewDataPolicy creates a policy requiring any one of requirements. An empty policy
+// makes every score final.
+func NewDataPolicy(requirements []string, minHistoryDays int) (*DataPolicy, error) {
+	for _, requirement := range requirements {
+		if _, ok := nextSteps[requirement]; !ok {
+			return nil, fmt.Errorf("unknown data requirement %q", requirement)
+		}
+	}
+	if minHistoryDays < 0 {
+		return nil, fmt.Errorf("minimum on-chain history must not be negative, got %d days", minHistoryDays)
+	}
+	return &DataPolicy{
+		requirements:   requirements,
+		minHistoryDays: uint32(minHistoryDays),
+	}, nil
+}
+
+// Missing returns the requirements the metrics fall short of when they meet none of
+// them, and nil when the score can be final
+func (p *DataPolicy) Missing(onChain *models.OnChainMetrics, offChain *models.OffChainMetrics) []string {
+	if p == nil || len(p.requirements) == 0 {
+		return nil
+	}
+
+	for _, requirement := range p.requirements {
+		if p.meets(requirement, onChain, offChain) {
+			return nil
+		}
+	}
+	return p.requirements
+}
+
+func (p *DataPolicy) meets(requirement string, onChain *models.OnChainMetrics, offChain *models.OffChainMetrics) bool {
+	switch requirement {
+	case RequirementOnChainHistory:
+		return onChain != nil && onChain.WalletAge >= p.minHistoryDays
+	case RequirementBureauFile:
+		return offChain != nil && offChain.TraditionalCreditScore > 0
+	case RequirementVerifiedIncome:
+		return offChain != nil && offChain.IncomeVerified
+	}
+	return false
+}
+
+// NextStepsFor describes how to meet each of the given requirements
+func NextStepsFor(requirements []string) []NextStep {
+	steps := make([]NextStep, 0, len(requirements))
+	for _, requirement := range requirements {
+		steps = append(steps, NextStep{
+			Requirement: requirement,
+			Description: nextSteps[requirement],
+		})
+	}
+	return steps
+}
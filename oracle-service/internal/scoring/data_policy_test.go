@@ -0,0 +1,51 @@
+package scoring
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+func TestDataPolicyMissing(t *testing.T) {
+	all := []string{RequirementOnChainHistory, RequirementBureauFile, RequirementVerifiedIncome}
+	policy, err := NewDataPolicy(all, 90)
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		onChain  *models.OnChainMetrics
+		offChain *models.OffChainMetrics
+		missing  []string
+	}{
+		{"No data", nil, nil, all},
+		{"New wallet only", &models.OnChainMetrics{WalletAge: 30}, &models.OffChainMetrics{}, all},
+		{"Old enough wallet", &models.OnChainMetrics{WalletAge: 90}, nil, nil},
+		{"Bureau file", &models.OnChainMetrics{WalletAge: 5}, &models.OffChainMetrics{TraditionalCreditScore: 680}, nil},
+		{"Verified income", nil, &models.OffChainMetrics{IncomeVerified: true}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if missing := policy.Missing(tt.onChain, tt.offChain); !reflect.DeepEqual(missing, tt.missing) {
+				t.Errorf("Missing() = %v, expected %v", missing, tt.missing)
+			}
+		})
+	}
+
+	var disabled *DataPolicy
+	if missing := disabled.Missing(nil, nil); missing != nil {
+		t.Errorf("Expected no policy to make every score final, got %v", missing)
+	}
+
+	if _, err := NewDataPolicy([]string{"credit_card"}, 90); err == nil {
+		t.Error("Expected an error for an unknown requirement")
+	}
+
+	steps := NextStepsFor([]string{RequirementBureauFile})
+	if len(steps) != 1 || steps[0].Requirement != RequirementBureauFile || steps[0].Description == "" {
+		t.Errorf("Unexpected next steps: %+v", steps)
+	}
+}
@@ -9,54 +9,87 @@ import (
 	"time"
 
 	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
 )
 
 // Scoring weights based on architecture doc
 const (
-	OnChainWeight  = 0.40  // 40%
-	OffChainWeight = 0.40  // 40%
-	HybridWeight   = 0.20  // 20%
+	OnChainWeight  units.BasisPoints = 4000 // 40%
+	OffChainWeight units.BasisPoints = 4000 // 40%
+	HybridWeight   units.BasisPoints = 2000 // 20%
 
-	MinScore = 300
-	MaxScore = 850
+	MinScore = units.MinScore
+	MaxScore = units.MaxScore
 )
 
 // Engine handles credit score calculations
-type Engine struct{}
+type Engine struct {
+	version string           // Model version the engine scores with
+	weights ComponentWeights // Nominal weight of each component
+}
 
-// NewEngine creates a new scoring engine
+// NewEngine creates a new scoring engine with the current model
 func NewEngine() *Engine {
-	return &Engine{}
+	return &Engine{version: DefaultModelVersion, weights: DefaultWeights}
+}
+
+// Version returns the model version the engine scores with
+func (e *Engine) Version() string {
+	return e.version
+}
+
+// Data reliability from provider disagreement history
+const (
+	maxReliabilityPenalty = 0.5 // Chronically conflicting providers at most halve confidence
+	reliabilityPrior      = 2   // Virtual agreements, so a single conflict isn't chronic
+)
+
+// DataReliability turns an address's provider comparison history into a factor in
+// (0.5, 1] applied to its confidence. Addresses whose providers were never compared
+// are fully reliable.
+func DataReliability(comparisons, disagreements uint32) float64 {
+	if comparisons == 0 || disagreements == 0 {
+		return 1
+	}
+	if disagreements > comparisons {
+		disagreements = comparisons
+	}
+	rate := float64(disagreements) / float64(comparisons+reliabilityPrior)
+	return 1 - rate*maxReliabilityPenalty
 }
 
+// AtRiskReliability is the confidence factor of an address with a lending position
+// near liquidation. Its score was calculated from collateral it may be about to lose.
+const AtRiskReliability = 0.6
+
 // CalculateScore computes the final credit score
 func (e *Engine) CalculateScore(
 	onChain *models.OnChainMetrics,
 	offChain *models.OffChainMetrics,
 ) (*models.CreditScore, error) {
+	return e.CalculateScoreWithReliability(onChain, offChain, 1)
+}
+
+// CalculateScoreWithReliability computes the final credit score, scaling its
+// confidence by the data reliability of the address's providers
+func (e *Engine) CalculateScoreWithReliability(
+	onChain *models.OnChainMetrics,
+	offChain *models.OffChainMetrics,
+	reliability float64,
+) (*models.CreditScore, error) {
 
 	// Calculate component scores
-	onChainScore := e.calculateOnChainScore(onChain)
-	offChainScore := e.calculateOffChainScore(offChain)
-	hybridScore := e.calculateHybridScore(onChain, offChain)
-
-	// Calculate weighted final score
-	finalScore := uint16(
-		float64(onChainScore)*OnChainWeight +
-		float64(offChainScore)*OffChainWeight +
-		float64(hybridScore)*HybridWeight,
-	)
+	onChainScore, onChainFactors := e.onChainComponent(onChain)
+	offChainScore, offChainFactors := e.offChainComponent(offChain)
+	hybridScore, hybridFactors := e.hybridComponent(onChain, offChain)
 
-	// Ensure score is within valid range
-	if finalScore < MinScore {
-		finalScore = MinScore
-	}
-	if finalScore > MaxScore {
-		finalScore = MaxScore
-	}
+	// Calculate weighted final score, shifting the weight of missing components to
+	// the available ones
+	blend := blendFor(e.weights, onChain, offChain)
+	finalScore := blend.Of(onChainScore, offChainScore, hybridScore)
 
-	// Calculate confidence level
-	confidence := e.calculateConfidence(onChain, offChain)
+	// Calculate confidence level, lower when the weights were renormalized
+	confidence := e.calculateConfidence(onChain, offChain, reliability*blend.confidenceFactor())
 
 	// Generate data hash for integrity
 	dataHash := e.generateDataHash(onChain, offChain, finalScore)
@@ -72,32 +105,40 @@ func (e *Engine) CalculateScore(
 		NextUpdateDue:  time.Now().Add(30 * 24 * time.Hour), // 30 days
 		IsActive:       true,
 	}
+	score.Components = componentsOf(onChainFactors, offChainFactors, hybridFactors)
 
 	return score, nil
 }
 
 // calculateOnChainScore computes score from on-chain metrics (40% weight)
-func (e *Engine) calculateOnChainScore(metrics *models.OnChainMetrics) uint16 {
+func (e *Engine) calculateOnChainScore(metrics *models.OnChainMetrics) units.Score {
+	score, _ := e.onChainComponent(metrics)
+	return score
+}
+
+// onChainComponent computes the on-chain score and the factors it is made of
+func (e *Engine) onChainComponent(metrics *models.OnChainMetrics) (units.Score, []Factor) {
 	if metrics == nil {
-		return MinScore
+		return MinScore, nil
 	}
 
 	var score float64 = 0
+	factors := newFactors(ComponentOnChain, &score)
 
 	// Wallet age (25% of on-chain score)
 	walletAgeScore := e.scoreWalletAge(metrics.WalletAge)
-	score += walletAgeScore * 0.25
+	factors.add(FactorWalletAge, walletAgeScore, 0.25)
 
 	// Transaction activity (20%)
 	activityScore := e.scoreTransactionActivity(
 		metrics.TotalTransactions,
 		metrics.AvgTransactionValue,
 	)
-	score += activityScore * 0.20
+	factors.add(FactorTransactionActivity, activityScore, 0.20)
 
 	// DeFi interactions (15%)
 	defiScore := e.scoreDeFiActivity(metrics.DeFiInteractions)
-	score += defiScore * 0.15
+	factors.add(FactorDeFiActivity, defiScore, 0.15)
 
 	// Borrowing/Repayment history (30%)
 	borrowingScore := e.scoreBorrowingHistory(
@@ -105,77 +146,137 @@ func (e *Engine) calculateOnChainScore(metrics *models.OnChainMetrics) uint16 {
 		metrics.RepaymentHistory,
 		metrics.LiquidationEvents,
 	)
-	score += borrowingScore * 0.30
+	factors.add(FactorBorrowingHistory, borrowingScore, 0.30)
 
 	// Collateral holdings (10%)
-	collateralScore := e.scoreCollateral(metrics.CollateralValue)
-	score += collateralScore * 0.10
+	collateralScore := e.scoreCollateral(metrics.CollateralValue.Mul(units.DecimalFromFloat(1 - metrics.TemporaryDiscount)))
+	factors.add(FactorCollateral, collateralScore, 0.10)
 
-	// Convert to 300-850 range
-	finalScore := MinScore + uint16(score*float64(MaxScore-MinScore))
+	// Balance stability takes 10% when enough balance history was sampled
+	if metrics.BalanceSamples >= models.MinBalanceSamples {
+		factors.scale(0.90)
+		factors.add(FactorBalanceStability, metrics.BalanceStability, 0.10)
+	}
 
-	return finalScore
+	// Convert to 300-850 range
+	return units.ScoreFromFraction(score), factors.list
 }
 
 // calculateOffChainScore computes score from off-chain data (40% weight)
-func (e *Engine) calculateOffChainScore(metrics *models.OffChainMetrics) uint16 {
+func (e *Engine) calculateOffChainScore(metrics *models.OffChainMetrics) units.Score {
+	score, _ := e.offChainComponent(metrics)
+	return score
+}
+
+// offChainComponent computes the off-chain score and the factors it is made of
+func (e *Engine) offChainComponent(metrics *models.OffChainMetrics) (units.Score, []Factor) {
 	if metrics == nil {
-		return MinScore
+		return MinScore, nil
 	}
 
 	var score float64 = 0
+	factors := newFactors(ComponentOffChain, &score)
 
 	// Traditional credit score (50% of off-chain score)
+	// Bureau scores are expected on the internal scale (see BureauNormalizer);
+	// clamp anything that slipped through so the subtraction cannot underflow
 	if metrics.TraditionalCreditScore > 0 {
-		traditionalScore := float64(metrics.TraditionalCreditScore-MinScore) / float64(MaxScore-MinScore)
-		score += traditionalScore * 0.50
+		bureauScore := metrics.TraditionalCreditScore
+		if bureauScore < MinScore {
+			bureauScore = MinScore
+		}
+		if bureauScore > MaxScore {
+			bureauScore = MaxScore
+		}
+		traditionalScore := float64(bureauScore-MinScore) / float64(MaxScore-MinScore)
+		factors.add(FactorBureauScore, traditionalScore, 0.50)
 	}
 
 	// Bank account history (20%)
 	bankScore := float64(metrics.BankAccountHistory) / 100.0
-	score += bankScore * 0.20
+	factors.add(FactorBankHistory, bankScore, 0.20)
 
 	// Income verification (15%)
 	incomeScore := e.scoreIncome(metrics.IncomeVerified, metrics.IncomeLevel)
-	score += incomeScore * 0.15
+	if !metrics.IncomeVerified && metrics.IncomeSource == models.IncomeSourceOnChainPayroll {
+		// Recurring on-chain payroll is real evidence of income but weaker than a
+		// verified document, so credit the level at a discount
+		incomeScore = math.Max(incomeScore, e.scoreIncome(true, metrics.IncomeLevel)*0.8)
+	}
+	factors.add(FactorIncome, incomeScore, 0.15)
 
 	// Debt-to-income ratio (15%)
 	dtiScore := e.scoreDTI(metrics.DebtToIncomeRatio)
-	score += dtiScore * 0.15
+	factors.add(FactorDebtToIncome, dtiScore, 0.15)
+
+	// Verified employment (10%) - only payroll-confirmed data counts here,
+	// the other factors are scaled down to make room for it
+	if metrics.EmploymentVerified {
+		employmentScore := e.scoreEmployment(metrics.EmploymentStatus, metrics.EmploymentTenure)
+		factors.scale(0.90)
+		factors.add(FactorEmployment, employmentScore, 0.10)
+	}
 
 	// Convert to 300-850 range
-	finalScore := MinScore + uint16(score*float64(MaxScore-MinScore))
-
-	return finalScore
+	return units.ScoreFromFraction(score), factors.list
 }
 
 // calculateHybridScore combines cross-chain and social metrics (20% weight)
 func (e *Engine) calculateHybridScore(
 	onChain *models.OnChainMetrics,
 	offChain *models.OffChainMetrics,
-) uint16 {
+) units.Score {
+	score, _ := e.hybridComponent(onChain, offChain)
+	return score
+}
+
+// hybridComponent computes the hybrid score and the bonuses and penalties it is made of
+func (e *Engine) hybridComponent(
+	onChain *models.OnChainMetrics,
+	offChain *models.OffChainMetrics,
+) (units.Score, []Factor) {
 	var score float64 = 0
+	factors := newFactors(ComponentHybrid, &score)
 
 	// Cross-verification bonus
 	if onChain != nil && offChain != nil {
 		// Bonus if both on-chain and off-chain data are strong
 		if onChain.RepaymentHistory > 5 && offChain.IncomeVerified {
-			score += 0.30
+			factors.add(FactorRepaymentAndIncome, 0.30, 1)
 		}
 
 		// Activity recency bonus
 		if time.Since(onChain.LastActivity) < 30*24*time.Hour {
-			score += 0.20
+			factors.add(FactorRecentActivity, 0.20, 1)
 		}
 
 		// Collateral + income verification bonus
-		if onChain.CollateralValue > 1000 && offChain.IncomeVerified {
-			score += 0.25
+		if onChain.CollateralValue.Cmp(units.DecimalFromInt(1000)) > 0 && offChain.IncomeVerified {
+			factors.add(FactorCollateralAndIncome, 0.25, 1)
 		}
 
 		// Employment stability bonus
 		if offChain.EmploymentStatus == "full-time" || offChain.EmploymentStatus == "self-employed" {
-			score += 0.25
+			factors.add(FactorStableEmployment, 0.25, 1)
+		}
+	}
+
+	if onChain != nil {
+		// Repeated exchange withdrawals across months imply a KYC'd account behind the wallet
+		if onChain.CEXActiveMonths >= 3 {
+			factors.add(FactorExchangeWithdrawals, 0.15, 1)
+		} else if onChain.CEXInflows > 0 {
+			factors.add(FactorExchangeWithdrawals, 0.05, 1)
+		}
+
+		// Wallets funded mostly through mixers get no cross-verification credit
+		if isMixerFunded(onChain) {
+			factors.add(FactorMixerFunding, -0.40, 1)
+		}
+
+		// Dealing with flagged scam addresses undermines the on-chain identity signal
+		if onChain.ScamInteractions > 0 {
+			factors.add(FactorScamInteraction, -0.20, 1)
 		}
 	}
 
@@ -183,18 +284,29 @@ func (e *Engine) calculateHybridScore(
 	if score > 1.0 {
 		score = 1.0
 	}
+	if score < 0 {
+		score = 0
+	}
 
 	// Convert to 300-850 range
-	finalScore := MinScore + uint16(score*float64(MaxScore-MinScore))
+	return units.ScoreFromFraction(score), factors.list
+}
 
-	return finalScore
+// isMixerFunded reports whether at least half of a wallet's inflows came from mixers
+// with no offsetting exchange activity
+func isMixerFunded(onChain *models.OnChainMetrics) bool {
+	return onChain.MixerInflows > 0 &&
+		onChain.CEXInflows == 0 &&
+		onChain.MixerInflows*2 >= onChain.TotalInflows
 }
 
-// calculateConfidence determines confidence level (0-100)
+// calculateConfidence determines confidence level (0-100), scaled by the reliability
+// (0-1) of the data sources
 func (e *Engine) calculateConfidence(
 	onChain *models.OnChainMetrics,
 	offChain *models.OffChainMetrics,
-) uint8 {
+	reliability float64,
+) units.Confidence {
 	confidence := 0
 
 	if onChain != nil {
@@ -224,6 +336,9 @@ func (e *Engine) calculateConfidence(
 		if offChain.IncomeVerified {
 			confidence += 15
 		}
+		if offChain.EmploymentVerified {
+			confidence += 10
+		}
 
 		// Data freshness
 		if time.Since(offChain.LastVerified) < 30*24*time.Hour {
@@ -235,7 +350,12 @@ func (e *Engine) calculateConfidence(
 		confidence = 100
 	}
 
-	return uint8(confidence)
+	// Sources that chronically contradict each other are less trustworthy
+	if reliability >= 0 && reliability < 1 {
+		confidence = int(math.Round(float64(confidence) * reliability))
+	}
+
+	return units.Confidence(confidence)
 }
 
 // Helper scoring functions
@@ -248,10 +368,10 @@ func (e *Engine) scoreWalletAge(ageInDays uint32) float64 {
 	return float64(ageInDays) / 730.0
 }
 
-func (e *Engine) scoreTransactionActivity(txCount uint32, avgValue float64) float64 {
+func (e *Engine) scoreTransactionActivity(txCount uint32, avgValue units.Decimal) float64 {
 	// Higher transaction count and value indicates more activity
 	txScore := math.Min(float64(txCount)/100.0, 1.0) * 0.6
-	valueScore := math.Min(avgValue/1000.0, 1.0) * 0.4
+	valueScore := math.Min(avgValue.Float64()/1000.0, 1.0) * 0.4
 	return txScore + valueScore
 }
 
@@ -283,9 +403,9 @@ func (e *Engine) scoreBorrowingHistory(borrowed, repaid, liquidations uint32) fl
 	return score
 }
 
-func (e *Engine) scoreCollateral(value float64) float64 {
+func (e *Engine) scoreCollateral(value units.Decimal) float64 {
 	// Higher collateral value = better score
-	return math.Min(value/10000.0, 1.0)
+	return math.Min(value.Float64()/10000.0, 1.0)
 }
 
 func (e *Engine) scoreIncome(verified bool, level string) float64 {
@@ -305,14 +425,39 @@ func (e *Engine) scoreIncome(verified bool, level string) float64 {
 	}
 }
 
-func (e *Engine) scoreDTI(ratio float64) float64 {
+func (e *Engine) scoreEmployment(status string, tenureMonths uint32) float64 {
+	var base float64
+	switch status {
+	case "full-time":
+		base = 0.6
+	case "self-employed", "contractor":
+		base = 0.5
+	case "part-time":
+		base = 0.4
+	default:
+		return 0.0
+	}
+
+	// Tenure adds up to 0.4, maxing at 2 years with the same employer
+	tenure := math.Min(float64(tenureMonths)/24.0, 1.0) * 0.4
+	return base + tenure
+}
+
+// Debt-to-income thresholds
+var (
+	dtiIdeal      = units.MustDecimal("0.36")
+	dtiQualifying = units.MustDecimal("0.43") // Qualified mortgage limit
+	dtiHigh       = units.MustDecimal("0.50")
+)
+
+func (e *Engine) scoreDTI(ratio units.Decimal) float64 {
 	// Lower debt-to-income is better
 	// Ideal DTI is below 0.36 (36%)
-	if ratio <= 0.36 {
+	if ratio.Cmp(dtiIdeal) <= 0 {
 		return 1.0
-	} else if ratio <= 0.43 {
+	} else if ratio.Cmp(dtiQualifying) <= 0 {
 		return 0.7
-	} else if ratio <= 0.50 {
+	} else if ratio.Cmp(dtiHigh) <= 0 {
 		return 0.4
 	}
 	return 0.2
@@ -322,12 +467,12 @@ func (e *Engine) scoreDTI(ratio float64) float64 {
 func (e *Engine) generateDataHash(
 	onChain *models.OnChainMetrics,
 	offChain *models.OffChainMetrics,
-	score uint16,
+	score units.Score,
 ) string {
 	data := struct {
 		OnChain   *models.OnChainMetrics
 		OffChain  *models.OffChainMetrics
-		Score     uint16
+		Score     units.Score
 		Timestamp time.Time
 	}{
 		OnChain:   onChain,
@@ -342,8 +487,8 @@ func (e *Engine) generateDataHash(
 }
 
 // ValidateScore checks if a score is within valid range
-func (e *Engine) ValidateScore(score uint16) error {
-	if score < MinScore || score > MaxScore {
+func (e *Engine) ValidateScore(score units.Score) error {
+	if !score.Valid() {
 		return fmt.Errorf("score %d is outside valid range [%d-%d]", score, MinScore, MaxScore)
 	}
 	return nil
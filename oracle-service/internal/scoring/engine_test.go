@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
 )
 
 func TestCalculateScore(t *testing.T) {
@@ -14,8 +15,8 @@ func TestCalculateScore(t *testing.T) {
 		name            string
 		onChain         *models.OnChainMetrics
 		offChain        *models.OffChainMetrics
-		expectedMinScore uint16
-		expectedMaxScore uint16
+		expectedMinScore units.Score
+		expectedMaxScore units.Score
 		expectError     bool
 	}{
 		{
@@ -23,12 +24,12 @@ func TestCalculateScore(t *testing.T) {
 			onChain: &models.OnChainMetrics{
 				WalletAge:           730,  // 2 years
 				TotalTransactions:   100,
-				AvgTransactionValue: 500,
+				AvgTransactionValue: units.DecimalFromInt(500),
 				DeFiInteractions:    50,
 				BorrowingHistory:    10,
 				RepaymentHistory:    10,
 				LiquidationEvents:   0,
-				CollateralValue:     5000,
+				CollateralValue:     units.DecimalFromInt(5000),
 				LastActivity:        time.Now().Add(-1 * 24 * time.Hour),
 			},
 			offChain: &models.OffChainMetrics{
@@ -37,7 +38,7 @@ func TestCalculateScore(t *testing.T) {
 				IncomeVerified:         true,
 				IncomeLevel:            "high",
 				EmploymentStatus:       "full-time",
-				DebtToIncomeRatio:      0.25,
+				DebtToIncomeRatio:      units.MustDecimal("0.25"),
 			},
 			expectedMinScore: 700,
 			expectedMaxScore: 850,
@@ -48,12 +49,12 @@ func TestCalculateScore(t *testing.T) {
 			onChain: &models.OnChainMetrics{
 				WalletAge:           30,  // 1 month
 				TotalTransactions:   10,
-				AvgTransactionValue: 50,
+				AvgTransactionValue: units.DecimalFromInt(50),
 				DeFiInteractions:    2,
 				BorrowingHistory:    5,
 				RepaymentHistory:    2,
 				LiquidationEvents:   3,
-				CollateralValue:     100,
+				CollateralValue:     units.DecimalFromInt(100),
 				LastActivity:        time.Now().Add(-90 * 24 * time.Hour),
 			},
 			offChain: &models.OffChainMetrics{
@@ -62,7 +63,7 @@ func TestCalculateScore(t *testing.T) {
 				IncomeVerified:         false,
 				IncomeLevel:            "low",
 				EmploymentStatus:       "unemployed",
-				DebtToIncomeRatio:      0.55,
+				DebtToIncomeRatio:      units.MustDecimal("0.55"),
 			},
 			expectedMinScore: 300,
 			expectedMaxScore: 550,
@@ -73,12 +74,12 @@ func TestCalculateScore(t *testing.T) {
 			onChain: &models.OnChainMetrics{
 				WalletAge:           365,  // 1 year
 				TotalTransactions:   50,
-				AvgTransactionValue: 250,
+				AvgTransactionValue: units.DecimalFromInt(250),
 				DeFiInteractions:    15,
 				BorrowingHistory:    5,
 				RepaymentHistory:    5,
 				LiquidationEvents:   0,
-				CollateralValue:     2000,
+				CollateralValue:     units.DecimalFromInt(2000),
 				LastActivity:        time.Now().Add(-7 * 24 * time.Hour),
 			},
 			offChain:        nil,
@@ -95,7 +96,7 @@ func TestCalculateScore(t *testing.T) {
 				IncomeVerified:         true,
 				IncomeLevel:            "medium",
 				EmploymentStatus:       "full-time",
-				DebtToIncomeRatio:      0.35,
+				DebtToIncomeRatio:      units.MustDecimal("0.35"),
 			},
 			expectedMinScore: 450,
 			expectedMaxScore: 700,
@@ -160,19 +161,19 @@ func TestCalculateOnChainScore(t *testing.T) {
 	tests := []struct {
 		name     string
 		metrics  *models.OnChainMetrics
-		expected uint16
+		expected units.Score
 	}{
 		{
 			name: "Perfect on-chain metrics",
 			metrics: &models.OnChainMetrics{
 				WalletAge:           1000,
 				TotalTransactions:   200,
-				AvgTransactionValue: 2000,
+				AvgTransactionValue: units.DecimalFromInt(2000),
 				DeFiInteractions:    100,
 				BorrowingHistory:    20,
 				RepaymentHistory:    20,
 				LiquidationEvents:   0,
-				CollateralValue:     20000,
+				CollateralValue:     units.DecimalFromInt(20000),
 			},
 			expected: 800, // Should be near maximum
 		},
@@ -186,12 +187,12 @@ func TestCalculateOnChainScore(t *testing.T) {
 			metrics: &models.OnChainMetrics{
 				WalletAge:           7,
 				TotalTransactions:   5,
-				AvgTransactionValue: 10,
+				AvgTransactionValue: units.DecimalFromInt(10),
 				DeFiInteractions:    0,
 				BorrowingHistory:    0,
 				RepaymentHistory:    0,
 				LiquidationEvents:   0,
-				CollateralValue:     50,
+				CollateralValue:     units.DecimalFromInt(50),
 			},
 			expected: 350, // Should be near minimum
 		},
@@ -216,7 +217,7 @@ func TestCalculateOffChainScore(t *testing.T) {
 	tests := []struct {
 		name     string
 		metrics  *models.OffChainMetrics
-		expected uint16
+		expected units.Score
 	}{
 		{
 			name: "Excellent credit profile",
@@ -225,7 +226,7 @@ func TestCalculateOffChainScore(t *testing.T) {
 				BankAccountHistory:     95,
 				IncomeVerified:         true,
 				IncomeLevel:            "high",
-				DebtToIncomeRatio:      0.20,
+				DebtToIncomeRatio:      units.MustDecimal("0.20"),
 			},
 			expected: 750,
 		},
@@ -241,7 +242,7 @@ func TestCalculateOffChainScore(t *testing.T) {
 				BankAccountHistory:     30,
 				IncomeVerified:         false,
 				IncomeLevel:            "low",
-				DebtToIncomeRatio:      0.60,
+				DebtToIncomeRatio:      units.MustDecimal("0.60"),
 			},
 			expected: 450,
 		},
@@ -343,25 +344,78 @@ func TestScoreDTI(t *testing.T) {
 	engine := NewEngine()
 
 	tests := []struct {
-		ratio    float64
+		ratio    units.Decimal
 		expected float64
 	}{
-		{0.30, 1.0},  // Excellent DTI
-		{0.36, 1.0},  // Good DTI
-		{0.40, 0.7},  // Moderate DTI
-		{0.45, 0.4},  // High DTI
-		{0.60, 0.2},  // Very high DTI
+		{units.MustDecimal("0.30"), 1.0}, // Excellent DTI
+		{units.MustDecimal("0.36"), 1.0}, // Good DTI
+		{units.MustDecimal("0.40"), 0.7}, // Moderate DTI
+		{units.MustDecimal("0.45"), 0.4}, // High DTI
+		{units.MustDecimal("0.60"), 0.2}, // Very high DTI
 	}
 
 	for _, tt := range tests {
 		result := engine.scoreDTI(tt.ratio)
 		if result != tt.expected {
-			t.Errorf("scoreDTI(%f) = %f, expected %f",
+			t.Errorf("scoreDTI(%s) = %f, expected %f",
 				tt.ratio, result, tt.expected)
 		}
 	}
 }
 
+func TestVerifiedEmploymentRaisesOffChainScore(t *testing.T) {
+	engine := NewEngine()
+
+	selfReported := &models.OffChainMetrics{
+		TraditionalCreditScore: 650,
+		BankAccountHistory:     60,
+		IncomeVerified:         true,
+		IncomeLevel:            "medium",
+		EmploymentStatus:       "full-time",
+		DebtToIncomeRatio:      units.MustDecimal("0.40"),
+		LastVerified:           time.Now(),
+	}
+
+	verified := *selfReported
+	verified.EmploymentVerified = true
+	verified.EmploymentTenure = 36
+
+	if engine.calculateOffChainScore(&verified) <= engine.calculateOffChainScore(selfReported) {
+		t.Error("Verified long-tenure employment should raise the off-chain score")
+	}
+
+	if engine.calculateConfidence(nil, &verified, 1) <= engine.calculateConfidence(nil, selfReported, 1) {
+		t.Error("Verified employment should carry more confidence than self-reported data")
+	}
+}
+
+func TestFundingSourcesAdjustHybridScore(t *testing.T) {
+	engine := NewEngine()
+
+	base := &models.OnChainMetrics{
+		TotalInflows: 20,
+		LastActivity: time.Now(),
+	}
+	offChain := &models.OffChainMetrics{IncomeVerified: true}
+
+	cexFunded := *base
+	cexFunded.CEXInflows = 8
+	cexFunded.CEXActiveMonths = 6
+
+	mixerFunded := *base
+	mixerFunded.MixerInflows = 15
+
+	baseline := engine.calculateHybridScore(base, offChain)
+
+	if engine.calculateHybridScore(&cexFunded, offChain) <= baseline {
+		t.Error("Consistent exchange withdrawals should raise the hybrid score")
+	}
+
+	if engine.calculateHybridScore(&mixerFunded, offChain) >= baseline {
+		t.Error("Mixer-funded wallets should be penalized in the hybrid score")
+	}
+}
+
 func TestCalculateConfidence(t *testing.T) {
 	engine := NewEngine()
 
@@ -369,8 +423,8 @@ func TestCalculateConfidence(t *testing.T) {
 		name         string
 		onChain      *models.OnChainMetrics
 		offChain     *models.OffChainMetrics
-		minConfidence uint8
-		maxConfidence uint8
+		minConfidence units.Confidence
+		maxConfidence units.Confidence
 	}{
 		{
 			name: "High confidence - all data available",
@@ -413,7 +467,7 @@ func TestCalculateConfidence(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			confidence := engine.calculateConfidence(tt.onChain, tt.offChain)
+			confidence := engine.calculateConfidence(tt.onChain, tt.offChain, 1)
 
 			if confidence < tt.minConfidence || confidence > tt.maxConfidence {
 				t.Errorf("Confidence %d is outside expected range [%d-%d]",
@@ -427,7 +481,7 @@ func TestValidateScore(t *testing.T) {
 	engine := NewEngine()
 
 	tests := []struct {
-		score       uint16
+		score       units.Score
 		expectError bool
 	}{
 		{300, false},
@@ -465,7 +519,7 @@ func TestGenerateDataHash(t *testing.T) {
 	}
 
 	hash1 := engine.generateDataHash(onChain, offChain, 700)
-	hash2 := engine.generateDataHash(onChain, offChain, 700)
+	_ = engine.generateDataHash(onChain, offChain, 700)
 
 	// Hashes should be consistent for same inputs (within same second)
 	// Note: This test might occasionally fail due to timestamp differences
@@ -494,12 +548,12 @@ func BenchmarkCalculateScore(b *testing.B) {
 	onChain := &models.OnChainMetrics{
 		WalletAge:           365,
 		TotalTransactions:   100,
-		AvgTransactionValue: 500,
+		AvgTransactionValue: units.DecimalFromInt(500),
 		DeFiInteractions:    25,
 		BorrowingHistory:    5,
 		RepaymentHistory:    5,
 		LiquidationEvents:   0,
-		CollateralValue:     2000,
+		CollateralValue:     units.DecimalFromInt(2000),
 		LastActivity:        time.Now(),
 	}
 
@@ -508,7 +562,7 @@ func BenchmarkCalculateScore(b *testing.B) {
 		BankAccountHistory:     80,
 		IncomeVerified:         true,
 		IncomeLevel:            "medium",
-		DebtToIncomeRatio:      0.30,
+		DebtToIncomeRatio:      units.MustDecimal("0.30"),
 	}
 
 	b.ResetTimer()
@@ -516,3 +570,182 @@ func BenchmarkCalculateScore(b *testing.B) {
 		engine.CalculateScore(onChain, offChain)
 	}
 }
+
+func TestBalanceStabilityAffectsOnChainScore(t *testing.T) {
+	engine := NewEngine()
+
+	base := models.OnChainMetrics{
+		WalletAge:           365,
+		TotalTransactions:   50,
+		AvgTransactionValue: units.DecimalFromInt(200),
+		CollateralValue:     units.DecimalFromInt(5000),
+		BalanceSamples:      13,
+	}
+
+	steady := base
+	steady.BalanceStability = 0.95
+
+	recentlyFunded := base
+	recentlyFunded.BalanceStability = 0.10
+
+	if engine.calculateOnChainScore(&steady) <= engine.calculateOnChainScore(&recentlyFunded) {
+		t.Error("A steadily held balance should score higher than a recently funded one")
+	}
+}
+
+func TestTooFewBalanceSamplesAreNotScored(t *testing.T) {
+	engine := NewEngine()
+
+	unsampled := models.OnChainMetrics{
+		WalletAge:           365,
+		TotalTransactions:   50,
+		AvgTransactionValue: units.DecimalFromInt(200),
+		CollateralValue:     units.DecimalFromInt(5000),
+		LastActivity:        time.Now(),
+	}
+	expected := engine.calculateOnChainScore(&unsampled)
+
+	// Too few samples give no stability, which mustn't count as an unstable balance
+	for samples := uint32(1); samples < models.MinBalanceSamples; samples++ {
+		metrics := unsampled
+		metrics.BalanceSamples = samples
+		if got := engine.calculateOnChainScore(&metrics); got != expected {
+			t.Errorf("%d samples: expected score %d, got %d", samples, expected, got)
+		}
+
+		explanation, err := engine.Explain(&metrics, nil)
+		if err != nil {
+			t.Fatalf("Explain failed: %v", err)
+		}
+		for _, adj := range explanation.Adjustments {
+			if adj.Factor == "balance_stability" {
+				t.Errorf("%d samples: expected no balance_stability adjustment", samples)
+			}
+		}
+	}
+}
+
+func TestExplainListsTemporaryDepositDiscount(t *testing.T) {
+	engine := NewEngine()
+
+	onChain := &models.OnChainMetrics{
+		WalletAge:         365,
+		CollateralValue:   units.DecimalFromInt(10000),
+		TemporaryDiscount: 0.8,
+		LastActivity:      time.Now(),
+	}
+
+	explanation, err := engine.Explain(onChain, nil)
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+
+	found := false
+	for _, adj := range explanation.Adjustments {
+		if adj.Component == ComponentOnChain && adj.Factor == "temporary_deposit" && adj.Value == 0.8 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected temporary_deposit adjustment, got %+v", explanation.Adjustments)
+	}
+
+	undiscounted := *onChain
+	undiscounted.TemporaryDiscount = 0
+	if explanation.OnChainScore >= engine.calculateOnChainScore(&undiscounted) {
+		t.Error("Temporary deposit discount should lower the on-chain score")
+	}
+}
+
+func TestExplainFlagsWashActivity(t *testing.T) {
+	engine := NewEngine()
+
+	onChain := &models.OnChainMetrics{
+		WalletAge:        365,
+		DeFiInteractions: 4,
+		LastActivity:     time.Now(),
+	}
+	explanation, err := engine.Explain(onChain, nil)
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	if explanation.GamingSuspected {
+		t.Error("Expected no gaming suspicion without wash activity")
+	}
+
+	onChain.WashActivities = 12
+	explanation, err = engine.Explain(onChain, nil)
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	if !explanation.GamingSuspected {
+		t.Error("Expected gaming to be suspected")
+	}
+	found := false
+	for _, adj := range explanation.Adjustments {
+		if adj.Component == ComponentOnChain && adj.Factor == "wash_activity" && adj.Value == 12 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected wash_activity adjustment, got %+v", explanation.Adjustments)
+	}
+}
+
+func TestDataReliability(t *testing.T) {
+	if got := DataReliability(0, 0); got != 1 {
+		t.Errorf("Expected never-compared providers to be fully reliable, got %f", got)
+	}
+	if got := DataReliability(10, 0); got != 1 {
+		t.Errorf("Expected agreeing providers to be fully reliable, got %f", got)
+	}
+
+	single := DataReliability(4, 1)
+	chronic := DataReliability(20, 18)
+	if single <= chronic {
+		t.Errorf("Expected chronic disagreement (%f) to be less reliable than a single conflict (%f)", chronic, single)
+	}
+	if chronic < 1-maxReliabilityPenalty {
+		t.Errorf("Expected reliability to stay above %f, got %f", 1-maxReliabilityPenalty, chronic)
+	}
+}
+
+func TestConfidenceScaledByReliability(t *testing.T) {
+	engine := NewEngine()
+
+	offChain := &models.OffChainMetrics{
+		TraditionalCreditScore: 720,
+		IncomeVerified:         true,
+		EmploymentVerified:     true,
+		LastVerified:           time.Now(),
+	}
+
+	full, err := engine.CalculateScore(nil, offChain)
+	if err != nil {
+		t.Fatalf("CalculateScore failed: %v", err)
+	}
+	reduced, err := engine.CalculateScoreWithReliability(nil, offChain, 0.5)
+	if err != nil {
+		t.Fatalf("CalculateScoreWithReliability failed: %v", err)
+	}
+	if reduced.Confidence != full.Confidence/2 {
+		t.Errorf("Expected confidence %d halved, got %d", full.Confidence, reduced.Confidence)
+	}
+	if reduced.Score != full.Score {
+		t.Errorf("Reliability should not change the score, got %d and %d", full.Score, reduced.Score)
+	}
+
+	explanation, err := engine.ExplainWithReliability(nil, offChain, 0.5)
+	if err != nil {
+		t.Fatalf("ExplainWithReliability failed: %v", err)
+	}
+	found := false
+	for _, adj := range explanation.Adjustments {
+		if adj.Component == ComponentConfidence && adj.Factor == "provider_disagreement" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected provider_disagreement adjustment, got %+v", explanation.Adjustments)
+	}
+}
@@ -0,0 +1,175 @@
+package scoring
+
+import (
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// Score components referenced by adjustments
+const (
+	ComponentOnChain  = "on_chain"
+	ComponentOffChain = "off_chain"
+	ComponentHybrid   = "hybrid"
+
+	// ComponentConfidence marks adjustments to the score's confidence rather than its value
+	ComponentConfidence = "confidence"
+)
+
+// Adjustment is a correction the engine applied on top of the raw metrics
+type Adjustment struct {
+	Component      string  `json:"component"`
+	Factor         string  `json:"factor"` // Reason code
+	Description    string  `json:"description"`
+	Recommendation string  `json:"recommendation,omitempty"` // How the borrower can improve on this factor
+	Value          float64 `json:"value"`                    // Factor-specific magnitude, e.g. the share of a balance discounted
+}
+
+// Explanation breaks a score down into its components and the adjustments behind them
+type Explanation struct {
+	Score         units.Score      `json:"score"`
+	Confidence    units.Confidence `json:"confidence"`
+	OnChainScore  units.Score      `json:"on_chain_score"`
+	OffChainScore units.Score      `json:"off_chain_score"`
+	HybridScore   units.Score      `json:"hybrid_score"`
+	Weights       Blend            `json:"weights"` // Weight of each component in the score
+
+	// GamingSuspected is set when DeFi activity was left out of the score as wash
+	// activity, such as deposits withdrawn within a day
+	GamingSuspected bool         `json:"gaming_suspected"`
+	Factors         []Factor     `json:"factors"`
+	Adjustments     []Adjustment `json:"adjustments"`
+}
+
+// Explain recomputes a score from its metrics and lists the adjustments that shaped it
+func (e *Engine) Explain(
+	onChain *models.OnChainMetrics,
+	offChain *models.OffChainMetrics,
+) (*Explanation, error) {
+	return e.ExplainWithReliability(onChain, offChain, 1)
+}
+
+// ExplainWithReliability is Explain for an address whose providers' data reliability
+// is known
+func (e *Engine) ExplainWithReliability(
+	onChain *models.OnChainMetrics,
+	offChain *models.OffChainMetrics,
+	reliability float64,
+) (*Explanation, error) {
+	score, err := e.CalculateScoreWithReliability(onChain, offChain, reliability)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := &Explanation{
+		Score:         score.Score,
+		Confidence:    score.Confidence,
+		OnChainScore:  score.OnChainScore,
+		OffChainScore: score.OffChainScore,
+		HybridScore:   score.HybridScore,
+		Weights:       blendFor(e.weights, onChain, offChain),
+		Factors:       FactorsOf(score.Components),
+		Adjustments:   []Adjustment{},
+	}
+
+	add := func(component, factor, description, recommendation string, value float64) {
+		explanation.Adjustments = append(explanation.Adjustments, Adjustment{
+			Component:      component,
+			Factor:         factor,
+			Description:    description,
+			Recommendation: recommendation,
+			Value:          value,
+		})
+	}
+
+	if onChain != nil {
+		if onChain.TemporaryDiscount > 0 {
+			add(ComponentOnChain, "temporary_deposit",
+				"Collateral discounted: recent large deposits or a history of briefly parked funds",
+				"Keep funds in your wallet for longer before applying for a loan",
+				onChain.TemporaryDiscount)
+		}
+		if onChain.BalanceSamples >= models.MinBalanceSamples {
+			add(ComponentOnChain, "balance_stability",
+				"Balance stability from monthly historical balances",
+				"Keep a steady balance from month to month",
+				onChain.BalanceStability)
+		}
+		if onChain.WashActivities > 0 {
+			explanation.GamingSuspected = true
+			add(ComponentOnChain, "wash_activity",
+				"DeFi transactions excluded: deposits withdrawn, borrows repaid or swaps reversed within a day",
+				"Only lending and trading with lasting positions counts towards your DeFi history",
+				float64(onChain.WashActivities))
+		}
+		if isMixerFunded(onChain) {
+			add(ComponentHybrid, "mixer_funding",
+				"Wallet funded mostly through mixers",
+				"Fund your wallet from exchanges or other traceable sources",
+				float64(onChain.MixerInflows))
+		}
+		if onChain.ScamInteractions > 0 {
+			add(ComponentHybrid, "scam_interaction",
+				"Transfers with addresses labelled as scams",
+				"Avoid sending funds to or receiving funds from flagged addresses",
+				float64(onChain.ScamInteractions))
+		}
+		if onChain.CEXInflows > 0 {
+			add(ComponentHybrid, "exchange_withdrawals",
+				"Withdrawals from known exchanges (months active)",
+				"",
+				float64(onChain.CEXActiveMonths))
+		}
+		if onChain.BridgedFrom != "" {
+			add(ComponentOnChain, "bridged_funds",
+				"Funds bridged from other chains; wallet history there counts towards its age and activity",
+				"",
+				float64(onChain.BridgeInflows))
+		}
+	}
+
+	if offChain != nil {
+		if offChain.TemporaryDiscount > 0 {
+			add(ComponentOffChain, "temporary_deposit",
+				"Bank balance discounted: recent large deposits",
+				"Let recent large deposits settle before applying for a loan",
+				offChain.TemporaryDiscount)
+		}
+		if !offChain.IncomeVerified && offChain.IncomeSource == models.IncomeSourceOnChainPayroll {
+			add(ComponentOffChain, "onchain_payroll_income",
+				"Income estimated from recurring stablecoin payroll",
+				"Connect a bank account or payroll provider to verify your income",
+				offChain.EstimatedAnnualIncome.Float64())
+		}
+		if offChain.EmploymentVerified {
+			add(ComponentOffChain, "verified_employment",
+				"Employment tenure verified by payroll provider (months)",
+				"",
+				float64(offChain.EmploymentTenure))
+		}
+	}
+
+	// Missing data shifts weight to the other components and lowers confidence
+	if explanation.Weights.Reweighted > 0 {
+		if onChain == nil {
+			add(ComponentOnChain, "missing_component",
+				"No on-chain data: the score is weighted towards the other data and its confidence reduced",
+				"Score a wallet with on-chain history",
+				explanation.Weights.Reweighted)
+		}
+		if offChain == nil {
+			add(ComponentOffChain, "missing_component",
+				"No off-chain data: the score is weighted towards the other data and its confidence reduced",
+				"Link your credit bureau file or connect a bank account",
+				explanation.Weights.Reweighted)
+		}
+	}
+
+	if reliability < 1 {
+		add(ComponentConfidence, "provider_disagreement",
+			"Confidence reduced: data providers have repeatedly disagreed about this borrower",
+			"Make sure your providers report matching income and employment",
+			reliability)
+	}
+
+	return explanation, nil
+}
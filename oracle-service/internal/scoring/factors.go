@@ -0,0 +1,101 @@
+package scoring
+
+import (
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+// Factors of the on-chain score
+const (
+	FactorWalletAge           = "wallet_age"
+	FactorTransactionActivity = "transaction_activity"
+	FactorDeFiActivity        = "defi_activity"
+	FactorBorrowingHistory    = "borrowing_history"
+	FactorCollateral          = "collateral"
+	FactorBalanceStability    = "balance_stability"
+)
+
+// Factors of the off-chain score
+const (
+	FactorBureauScore  = "bureau_score"
+	FactorBankHistory  = "bank_history"
+	FactorIncome       = "income"
+	FactorDebtToIncome = "debt_to_income"
+	FactorEmployment   = "employment"
+)
+
+// Bonuses and penalties of the hybrid score
+const (
+	FactorRepaymentAndIncome  = "repayment_and_income"
+	FactorRecentActivity      = "recent_activity"
+	FactorCollateralAndIncome = "collateral_and_income"
+	FactorStableEmployment    = "stable_employment"
+	FactorExchangeWithdrawals = "exchange_withdrawals"
+	FactorMixerFunding        = "mixer_funding"
+	FactorScamInteraction     = "scam_interaction"
+)
+
+// Factor is one input to a component score. On-chain and off-chain factors score from
+// 0 to 1 and their weights add up to 1; factors without data, such as a missing
+// bureau score, are left out. Hybrid factors are bonuses and penalties with a weight
+// of 1, and only those that applied are listed.
+type Factor struct {
+	Component string  `json:"component"`
+	Name      string  `json:"factor"`
+	Score     float64 `json:"score"`
+	Weight    float64 `json:"weight"` // Share of the component score
+}
+
+// factorList accumulates a component's factors and its weighted score
+type factorList struct {
+	component string
+	score     *float64
+	list      []Factor
+}
+
+func newFactors(component string, score *float64) *factorList {
+	return &factorList{component: component, score: score}
+}
+
+// add counts a factor towards the score
+func (f *factorList) add(name string, score, weight float64) {
+	*f.score += score * weight
+	f.list = append(f.list, Factor{Component: f.component, Name: name, Score: score, Weight: weight})
+}
+
+// scale shrinks the factors so far to make room for another
+func (f *factorList) scale(by float64) {
+	*f.score *= by
+	for i := range f.list {
+		f.list[i].Weight *= by
+	}
+}
+
+// FactorsOf converts stored component records back to factors
+func FactorsOf(components []models.ScoreComponent) []Factor {
+	factors := make([]Factor, 0, len(components))
+	for _, component := range components {
+		factors = append(factors, Factor{
+			Component: component.Component,
+			Name:      component.Factor,
+			Score:     component.Score,
+			Weight:    component.Weight,
+		})
+	}
+	return factors
+}
+
+// componentsOf converts factors to the records stored with a score
+func componentsOf(factorLists ...[]Factor) []models.ScoreComponent {
+	var components []models.ScoreComponent
+	for _, factors := range factorLists {
+		for _, factor := range factors {
+			components = append(components, models.ScoreComponent{
+				Component: factor.Component,
+				Factor:    factor.Name,
+				Score:     factor.Score,
+				Weight:    factor.Weight,
+			})
+		}
+	}
+	return components
+}
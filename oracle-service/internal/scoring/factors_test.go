@@ -0,0 +1,78 @@
+package scoring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+func TestScoreComponentsAddUpToComponentScores(t *testing.T) {
+	engine := NewEngine()
+	onChain := &models.OnChainMetrics{
+		WalletAge:           400,
+		TotalTransactions:   80,
+		AvgTransactionValue: units.DecimalFromInt(300),
+		DeFiInteractions:    20,
+		BorrowingHistory:    6,
+		RepaymentHistory:    6,
+		CollateralValue:     units.DecimalFromInt(2500),
+		BalanceSamples:      12,
+		BalanceStability:    0.7,
+		MixerInflows:        3,
+		TotalInflows:        4,
+		LastActivity:        time.Now().Add(-24 * time.Hour),
+	}
+	offChain := &models.OffChainMetrics{
+		TraditionalCreditScore: 700,
+		BankAccountHistory:     60,
+		IncomeVerified:         true,
+		IncomeLevel:            "medium",
+		EmploymentStatus:       "full-time",
+		EmploymentVerified:     true,
+		EmploymentTenure:       24,
+		DebtToIncomeRatio:      units.MustDecimal("0.30"),
+	}
+
+	score, err := engine.CalculateScore(onChain, offChain)
+	if err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	sums := make(map[string]float64)
+	weights := make(map[string]float64)
+	for _, component := range score.Components {
+		sums[component.Component] += component.Score * component.Weight
+		weights[component.Component] += component.Weight
+	}
+
+	for component, expected := range map[string]units.Score{
+		ComponentOnChain:  score.OnChainScore,
+		ComponentOffChain: score.OffChainScore,
+	} {
+		if got := units.ScoreFromFraction(sums[component]); got != expected {
+			t.Errorf("Expected %s factors to add up to %d, got %d", component, expected, got)
+		}
+		if weights[component] < 0.999 || weights[component] > 1.001 {
+			t.Errorf("Expected %s weights to add up to 1, got %f", component, weights[component])
+		}
+	}
+
+	// Hybrid factors are bonuses and penalties, clamped to 0-1
+	hybrid := sums[ComponentHybrid]
+	if hybrid > 1 {
+		hybrid = 1
+	}
+	if got := units.ScoreFromFraction(hybrid); got != score.HybridScore {
+		t.Errorf("Expected hybrid factors to add up to %d, got %d", score.HybridScore, got)
+	}
+
+	var mixer bool
+	for _, factor := range FactorsOf(score.Components) {
+		mixer = mixer || factor.Name == FactorMixerFunding && factor.Score < 0
+	}
+	if !mixer {
+		t.Error("Expected the mixer funding penalty among the factors")
+	}
+}
@@ -0,0 +1,131 @@
+package scoring
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// DefaultModelVersion is the version of the current model, which scores are
+// calculated and published with
+const DefaultModelVersion = "v1"
+
+// ComponentWeights are the nominal weights of the score components, adding up to 100%
+type ComponentWeights struct {
+	OnChain  units.BasisPoints `json:"on_chain"`
+	OffChain units.BasisPoints `json:"off_chain"`
+	Hybrid   units.BasisPoints `json:"hybrid"`
+}
+
+// DefaultWeights are the current model's weights
+var DefaultWeights = ComponentWeights{
+	OnChain:  OnChainWeight,
+	OffChain: OffChainWeight,
+	Hybrid:   HybridWeight,
+}
+
+// ParseComponentWeights parses "on-chain/off-chain/hybrid" percentages, such as
+// "50/30/20"
+func ParseComponentWeights(spec string) (ComponentWeights, error) {
+	parts := strings.Split(spec, "/")
+	if len(parts) != 3 {
+		return ComponentWeights{}, fmt.Errorf("weights %q are not on-chain/off-chain/hybrid percentages", spec)
+	}
+
+	var weights [3]units.BasisPoints
+	total := 0
+	for i, part := range parts {
+		percent, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || percent < 0 || percent > 100 {
+			return ComponentWeights{}, fmt.Errorf("weight %q is not a percentage", part)
+		}
+		weights[i] = units.BasisPoints(percent * 100)
+		total += percent
+	}
+	if total != 100 {
+		return ComponentWeights{}, fmt.Errorf("weights %q add up to %d%%, not 100%%", spec, total)
+	}
+
+	return ComponentWeights{OnChain: weights[0], OffChain: weights[1], Hybrid: weights[2]}, nil
+}
+
+// NewModelEngine creates an engine for another model version, weighing the
+// components differently from the current model
+func NewModelEngine(version string, weights ComponentWeights) (*Engine, error) {
+	if version == "" {
+		return nil, fmt.Errorf("model version is required")
+	}
+	if weights.OnChain+weights.OffChain+weights.Hybrid != units.MaxBasisPoints {
+		return nil, fmt.Errorf("model %s weights must add up to 100%%", version)
+	}
+	return &Engine{version: version, weights: weights}, nil
+}
+
+// Models are the registered model versions, which scores can be compared under
+type Models struct {
+	current string
+	engines map[string]*Engine
+}
+
+// NewModels registers the current engine's model
+func NewModels(current *Engine) *Models {
+	return &Models{
+		current: current.Version(),
+		engines: map[string]*Engine{current.Version(): current},
+	}
+}
+
+// ParseModels registers the current engine's model and the models in specs, which
+// map model versions to their ParseComponentWeights percentages
+func ParseModels(current *Engine, specs map[string]string) (*Models, error) {
+	models := NewModels(current)
+	for version, spec := range specs {
+		weights, err := ParseComponentWeights(spec)
+		if err != nil {
+			return nil, fmt.Errorf("model %s: %w", version, err)
+		}
+		engine, err := NewModelEngine(version, weights)
+		if err != nil {
+			return nil, err
+		}
+		if err := models.Register(engine); err != nil {
+			return nil, err
+		}
+	}
+	return models, nil
+}
+
+// Register adds an engine's model version
+func (m *Models) Register(engine *Engine) error {
+	if _, ok := m.engines[engine.Version()]; ok {
+		return fmt.Errorf("model %s is already registered", engine.Version())
+	}
+	m.engines[engine.Version()] = engine
+	return nil
+}
+
+// Current returns the version of the model scores are calculated with
+func (m *Models) Current() string {
+	return m.current
+}
+
+// Get returns the engine of a model version
+func (m *Models) Get(version string) (*Engine, bool) {
+	engine, ok := m.engines[version]
+	return engine, ok
+}
+
+// Versions lists the registered model versions, the current one first
+func (m *Models) Versions() []string {
+	versions := make([]string, 0, len(m.engines))
+	for version := range m.engines {
+		if version != m.current {
+			versions = append(versions, version)
+		}
+	}
+	sort.Strings(versions)
+	return append([]string{m.current}, versions...)
+}
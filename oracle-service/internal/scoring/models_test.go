@@ -0,0 +1,73 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+func TestParseModels(t *testing.T) {
+	registry, err := ParseModels(NewEngine(), map[string]string{
+		"v3": "30/50/20",
+		"v2": "60/20/20",
+	})
+	if err != nil {
+		t.Fatalf("Failed to parse models: %v", err)
+	}
+
+	versions := registry.Versions()
+	if len(versions) != 3 || versions[0] != DefaultModelVersion || versions[1] != "v2" || versions[2] != "v3" {
+		t.Errorf("Expected the current model first, got %v", versions)
+	}
+	v2, ok := registry.Get("v2")
+	if !ok || v2.weights.OnChain != 6000 || v2.weights.Hybrid != 2000 {
+		t.Errorf("Expected v2 weighted 60/20/20, got %+v", v2)
+	}
+
+	for _, specs := range []map[string]string{
+		{"v2": "60/30/20"},
+		{"v2": "60/40"},
+		{"v2": "sixty/20/20"},
+		{DefaultModelVersion: "40/40/20"},
+	} {
+		if _, err := ParseModels(NewEngine(), specs); err == nil {
+			t.Errorf("Expected %v to be rejected", specs)
+		}
+	}
+}
+
+func TestModelVersionsScoreDifferently(t *testing.T) {
+	onChain := &models.OnChainMetrics{
+		WalletAge:         900,
+		TotalTransactions: 400,
+		DeFiInteractions:  60,
+		BorrowingHistory:  10,
+		RepaymentHistory:  10,
+	}
+	offChain := &models.OffChainMetrics{
+		TraditionalCreditScore: units.Score(580),
+		IncomeLevel:            "low",
+	}
+
+	current, err := NewEngine().CalculateScore(onChain, offChain)
+	if err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+	weights, _ := ParseComponentWeights("70/10/20")
+	onChainHeavy, _ := NewModelEngine("v2", weights)
+	candidate, err := onChainHeavy.CalculateScore(onChain, offChain)
+	if err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	if candidate.OnChainScore != current.OnChainScore || candidate.OffChainScore != current.OffChainScore {
+		t.Errorf("Expected the same component scores, got %+v and %+v", candidate, current)
+	}
+	if candidate.OnChainScore <= candidate.OffChainScore {
+		t.Fatalf("Expected a stronger on-chain component, got %d and %d", candidate.OnChainScore, candidate.OffChainScore)
+	}
+	if candidate.Score <= current.Score {
+		t.Errorf("Expected a higher score when the stronger on-chain component weighs more, got %d <= %d", candidate.Score, current.Score)
+	}
+}
@@ -0,0 +1,140 @@
+package scoring
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// ScoreRange is the native score range reported by a credit bureau
+type ScoreRange struct {
+	Min int
+	Max int
+}
+
+// defaultBureauRanges holds well-known bureau scales keyed by provider or provider_region
+var defaultBureauRanges = map[string]ScoreRange{
+	"experian":      {Min: 300, Max: 850},
+	"equifax":       {Min: 300, Max: 850},
+	"transunion":    {Min: 300, Max: 850},
+	"experian_uk":   {Min: 0, Max: 999},
+	"equifax_uk":    {Min: 0, Max: 1000},
+	"transunion_uk": {Min: 0, Max: 710},
+	"equifax_ca":    {Min: 300, Max: 900},
+	"transunion_ca": {Min: 300, Max: 900},
+	"transunion_in": {Min: 300, Max: 900},
+	"equifax_au":    {Min: 0, Max: 1200},
+	"experian_au":   {Min: 0, Max: 1000},
+}
+
+// BureauNormalizer maps external bureau scores and DTI figures into the engine's internal scale
+type BureauNormalizer struct {
+	ranges     map[string]ScoreRange
+	dtiPercent map[string]bool
+}
+
+// NewBureauNormalizer creates a normalizer from configured range overrides and the
+// list of bureau keys that report debt-to-income as a percentage rather than a ratio
+func NewBureauNormalizer(overrides map[string][2]int, dtiPercentKeys []string) *BureauNormalizer {
+	ranges := make(map[string]ScoreRange, len(defaultBureauRanges)+len(overrides))
+	for key, r := range defaultBureauRanges {
+		ranges[key] = r
+	}
+	for key, r := range overrides {
+		if r[1] > r[0] {
+			ranges[strings.ToLower(key)] = ScoreRange{Min: r[0], Max: r[1]}
+		}
+	}
+
+	dtiPercent := make(map[string]bool, len(dtiPercentKeys))
+	for _, key := range dtiPercentKeys {
+		dtiPercent[strings.ToLower(key)] = true
+	}
+
+	return &BureauNormalizer{
+		ranges:     ranges,
+		dtiPercent: dtiPercent,
+	}
+}
+
+// BureauKey builds the lookup key for a provider and region (e.g. "transunion_uk")
+func BureauKey(provider, region string) string {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	region = strings.ToLower(strings.TrimSpace(region))
+	if region == "" {
+		return provider
+	}
+	return provider + "_" + region
+}
+
+// RangeFor resolves the native range for a bureau. The range reported with the score
+// (e.g. "0-710") describes that score, so it comes first; the configured defaults for
+// provider_region, then provider, cover reports without one, then the engine's own
+// 300-850 scale.
+func (n *BureauNormalizer) RangeFor(provider, region, reportedRange string) ScoreRange {
+	if r, ok := ParseScoreRange(reportedRange); ok {
+		return r
+	}
+	if r, ok := n.ranges[BureauKey(provider, region)]; ok {
+		return r
+	}
+	if r, ok := n.ranges[BureauKey(provider, "")]; ok {
+		return r
+	}
+	return ScoreRange{Min: int(MinScore), Max: int(MaxScore)}
+}
+
+// NormalizeScore maps a raw bureau score into the 300-850 range used by the engine
+func (n *BureauNormalizer) NormalizeScore(provider, region, reportedRange string, raw int) units.Score {
+	if raw <= 0 {
+		return 0 // No score on file
+	}
+	return NormalizeToInternal(n.RangeFor(provider, region, reportedRange), raw)
+}
+
+// NormalizeDTI converts a bureau-reported debt-to-income figure into a 0-1+ ratio
+func (n *BureauNormalizer) NormalizeDTI(provider, region string, dti units.Decimal) units.Decimal {
+	if dti.Sign() <= 0 {
+		return units.Decimal{}
+	}
+	if n.dtiPercent[BureauKey(provider, region)] || n.dtiPercent[BureauKey(provider, "")] {
+		return dti.Div(units.DecimalFromInt(100))
+	}
+	return dti
+}
+
+// NormalizeToInternal linearly maps a score from its native range into [MinScore, MaxScore]
+func NormalizeToInternal(r ScoreRange, raw int) units.Score {
+	if r.Max <= r.Min {
+		return MinScore
+	}
+	if raw < r.Min {
+		raw = r.Min
+	}
+	if raw > r.Max {
+		raw = r.Max
+	}
+
+	fraction := float64(raw-r.Min) / float64(r.Max-r.Min)
+	return units.ClampScore(float64(MinScore) + fraction*float64(MaxScore-MinScore) + 0.5)
+}
+
+// ParseScoreRange parses ranges in the "min-max" form reported by bureaus
+func ParseScoreRange(s string) (ScoreRange, bool) {
+	parts := strings.SplitN(strings.TrimSpace(s), "-", 2)
+	if len(parts) != 2 {
+		return ScoreRange{}, false
+	}
+
+	lo, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return ScoreRange{}, false
+	}
+	hi, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil || hi <= lo {
+		return ScoreRange{}, false
+	}
+
+	return ScoreRange{Min: lo, Max: hi}, true
+}
@@ -0,0 +1,69 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+func TestNormalizeBureauScore(t *testing.T) {
+	normalizer := NewBureauNormalizer(map[string][2]int{
+		"crif_it": {0, 1000},
+	}, nil)
+
+	tests := []struct {
+		name          string
+		provider      string
+		region        string
+		reportedRange string
+		raw           int
+		expected      units.Score
+	}{
+		{"US bureau passes through", "experian", "us", "", 720, 720},
+		{"TransUnion UK top of range", "transunion", "uk", "", 710, MaxScore},
+		{"TransUnion UK midpoint", "transunion", "uk", "", 355, 575},
+		{"Configured override", "crif", "it", "", 500, 575},
+		{"Reported range fallback", "unknown", "", "0-1000", 1000, MaxScore},
+		{"Reported range over provider default", "transunion", "uk", "0-1000", 500, 575},
+		{"Reported range over configured override", "crif", "it", "300-850", 500, 500},
+		{"Unparseable reported range uses default", "transunion", "uk", "n/a", 355, 575},
+		{"Unknown scale defaults to internal", "unknown", "", "", 650, 650},
+		{"Out of range is clamped", "transunion", "uk", "", 900, MaxScore},
+		{"No score on file", "transunion", "uk", "", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := normalizer.NormalizeScore(tt.provider, tt.region, tt.reportedRange, tt.raw)
+			if got != tt.expected {
+				t.Errorf("NormalizeScore(%s, %s, %d) = %d, expected %d",
+					tt.provider, tt.region, tt.raw, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeDTI(t *testing.T) {
+	normalizer := NewBureauNormalizer(nil, []string{"schufa"})
+
+	if got := normalizer.NormalizeDTI("schufa", "de", units.DecimalFromInt(35)); got.Cmp(units.MustDecimal("0.35")) != 0 {
+		t.Errorf("Expected percentage DTI to be converted to 0.35, got %s", got)
+	}
+
+	if got := normalizer.NormalizeDTI("experian", "us", units.MustDecimal("0.35")); got.Cmp(units.MustDecimal("0.35")) != 0 {
+		t.Errorf("Expected ratio DTI to pass through, got %s", got)
+	}
+}
+
+func TestParseScoreRange(t *testing.T) {
+	r, ok := ParseScoreRange("0-710")
+	if !ok || r.Min != 0 || r.Max != 710 {
+		t.Errorf("Expected 0-710, got %+v (ok=%v)", r, ok)
+	}
+
+	for _, invalid := range []string{"", "710", "710-0", "abc-def"} {
+		if _, ok := ParseScoreRange(invalid); ok {
+			t.Errorf("Expected %q to be rejected", invalid)
+		}
+	}
+}
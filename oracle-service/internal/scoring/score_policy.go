@@ -0,0 +1,126 @@
+package scoring
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// ScoreClamp is a floor or ceiling a tenant puts on the scores it reads, such as
+// "never above 800 without verified income". A clamp only applies to scores whose
+// data meets all of its conditions.
+type ScoreClamp struct {
+	Name    string      `json:"name"`
+	Floor   units.Score `json:"floor,omitempty"`   // Lower scores are raised to this
+	Ceiling units.Score `json:"ceiling,omitempty"` // Higher scores are lowered to this
+
+	// Conditions
+	UnverifiedIncome bool    `json:"unverified_income,omitempty"`   // Only scores without verified income
+	MinWalletAgeDays uint32  `json:"min_wallet_age_days,omitempty"` // Only wallets at least this old
+	MaxLiquidations  *uint32 `json:"max_liquidations,omitempty"`    // Only wallets with at most this many liquidations
+}
+
+// AppliedClamp records a clamp that changed a score
+type AppliedClamp struct {
+	Tenant      string      `json:"tenant"`
+	Name        string      `json:"name"`
+	EngineScore units.Score `json:"engine_score"` // Score before the clamp
+	Score       units.Score `json:"score"`
+}
+
+// ScorePolicies are the score clamps of each tenant, applied in the order listed
+type ScorePolicies map[string][]ScoreClamp
+
+// LoadScorePolicies reads tenant score policies from a JSON file mapping tenant IDs
+// to their clamps
+func LoadScorePolicies(path string) (ScorePolicies, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read score policies: %w", err)
+	}
+
+	var policies ScorePolicies
+	if err := json.Unmarshal(data, &policies); err != nil {
+		return nil, fmt.Errorf("failed to parse score policies: %w", err)
+	}
+
+	for tenant, clamps := range policies {
+		for i, clamp := range clamps {
+			if err := clamp.validate(); err != nil {
+				return nil, fmt.Errorf("tenant %s clamp %d: %w", tenant, i, err)
+			}
+		}
+	}
+
+	return policies, nil
+}
+
+func (c ScoreClamp) validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if c.Floor == 0 && c.Ceiling == 0 {
+		return fmt.Errorf("%s sets neither a floor nor a ceiling", c.Name)
+	}
+	if c.Floor != 0 && c.Ceiling != 0 && c.Floor > c.Ceiling {
+		return fmt.Errorf("%s has floor %d above ceiling %d", c.Name, c.Floor, c.Ceiling)
+	}
+	return nil
+}
+
+// Has reports whether the tenant has any clamps
+func (p ScorePolicies) Has(tenant string) bool {
+	return len(p[tenant]) > 0
+}
+
+// Apply clamps an engine score by the tenant's policy, returning the clamped score
+// and the clamps that changed it. Tenants without a policy get the engine score.
+func (p ScorePolicies) Apply(
+	tenant string,
+	score units.Score,
+	onChain *models.OnChainMetrics,
+	offChain *models.OffChainMetrics,
+) (units.Score, []AppliedClamp) {
+	var applied []AppliedClamp
+	for _, clamp := range p[tenant] {
+		if !clamp.matches(onChain, offChain) {
+			continue
+		}
+
+		clamped := score
+		if clamp.Floor != 0 && clamped < clamp.Floor {
+			clamped = clamp.Floor
+		}
+		if clamp.Ceiling != 0 && clamped > clamp.Ceiling {
+			clamped = clamp.Ceiling
+		}
+		if clamped == score {
+			continue
+		}
+
+		applied = append(applied, AppliedClamp{
+			Tenant:      tenant,
+			Name:        clamp.Name,
+			EngineScore: score,
+			Score:       clamped,
+		})
+		score = clamped
+	}
+	return score, applied
+}
+
+func (c ScoreClamp) matches(onChain *models.OnChainMetrics, offChain *models.OffChainMetrics) bool {
+	if c.UnverifiedIncome && offChain != nil && offChain.IncomeVerified {
+		return false
+	}
+	if c.MinWalletAgeDays > 0 && (onChain == nil || onChain.WalletAge < c.MinWalletAgeDays) {
+		return false
+	}
+	if c.MaxLiquidations != nil && (onChain == nil || onChain.LiquidationEvents > *c.MaxLiquidations) {
+		return false
+	}
+	return true
+}
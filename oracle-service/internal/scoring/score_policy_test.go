@@ -0,0 +1,81 @@
+package scoring
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+func TestScorePoliciesApply(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policies.json")
+	if err := os.WriteFile(path, []byte(`{
+		"lender-a": [
+			{"name": "income_ceiling", "ceiling": 800, "unverified_income": true},
+			{"name": "seasoned_wallet_floor", "floor": 400, "min_wallet_age_days": 1095, "max_liquidations": 0}
+		]
+	}`), 0o600); err != nil {
+		t.Fatalf("Failed to write policies: %v", err)
+	}
+	policies, err := LoadScorePolicies(path)
+	if err != nil {
+		t.Fatalf("Failed to load policies: %v", err)
+	}
+
+	seasoned := &models.OnChainMetrics{WalletAge: 1200}
+	liquidated := &models.OnChainMetrics{WalletAge: 1200, LiquidationEvents: 1}
+	verified := &models.OffChainMetrics{IncomeVerified: true}
+
+	tests := []struct {
+		name     string
+		tenant   string
+		score    units.Score
+		onChain  *models.OnChainMetrics
+		offChain *models.OffChainMetrics
+		expected units.Score
+		clamp    string
+	}{
+		{"Ceiling without verified income", "lender-a", 830, seasoned, nil, 800, "income_ceiling"},
+		{"Verified income is not capped", "lender-a", 830, seasoned, verified, 830, ""},
+		{"Floor for a seasoned wallet", "lender-a", 350, seasoned, verified, 400, "seasoned_wallet_floor"},
+		{"No floor after liquidations", "lender-a", 350, liquidated, verified, 350, ""},
+		{"Other tenants get the engine score", "lender-b", 830, seasoned, nil, 830, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, applied := policies.Apply(tt.tenant, tt.score, tt.onChain, tt.offChain)
+			if score != tt.expected {
+				t.Errorf("Expected score %d, got %d", tt.expected, score)
+			}
+			if tt.clamp == "" {
+				if len(applied) != 0 {
+					t.Errorf("Expected no clamp applied, got %+v", applied)
+				}
+				return
+			}
+			if len(applied) != 1 || applied[0].Name != tt.clamp || applied[0].EngineScore != tt.score {
+				t.Errorf("Expected %s applied to %d, got %+v", tt.clamp, tt.score, applied)
+			}
+		})
+	}
+}
+
+func TestLoadScorePoliciesRejectsInvalidClamps(t *testing.T) {
+	for name, policies := range map[string]string{
+		"No bound":            `{"t": [{"name": "empty"}]}`,
+		"Floor above ceiling": `{"t": [{"name": "inverted", "floor": 700, "ceiling": 600}]}`,
+		"Missing name":        `{"t": [{"ceiling": 700}]}`,
+		"Score off the scale": `{"t": [{"name": "high", "ceiling": 900}]}`,
+	} {
+		path := filepath.Join(t.TempDir(), "policies.json")
+		if err := os.WriteFile(path, []byte(policies), 0o600); err != nil {
+			t.Fatalf("Failed to write policies: %v", err)
+		}
+		if _, err := LoadScorePolicies(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
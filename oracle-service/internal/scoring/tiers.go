@@ -0,0 +1,31 @@
+package scoring
+
+import "github.com/yourusername/p2p-lend/oracle-service/internal/units"
+
+// Pre-screening tiers
+const (
+	TierA       = "A"       // 700 and above
+	TierB       = "B"       // 600 to 699
+	TierC       = "C"       // Below 600
+	TierUnknown = "unknown" // No score or estimate, or too little data for one
+)
+
+// Lowest scores of the A and B tiers
+const (
+	tierAMinScore units.Score = 700
+	tierBMinScore units.Score = 600
+)
+
+// TierFor places a score in a pre-screening tier
+func TierFor(score units.Score) string {
+	switch {
+	case !score.Valid():
+		return TierUnknown
+	case score >= tierAMinScore:
+		return TierA
+	case score >= tierBMinScore:
+		return TierB
+	default:
+		return TierC
+	}
+}
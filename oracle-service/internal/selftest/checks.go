@@ -0,0 +1,146 @@
+package selftest
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"gorm.io/gorm"
+)
+
+// Database runs a query on the database and checks its result
+func Database(db *gorm.DB) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var one int
+		if err := db.WithContext(ctx).Raw("SELECT 1").Scan(&one).Error; err != nil {
+			return fmt.Errorf("query failed: %w", err)
+		}
+		if one != 1 {
+			return fmt.Errorf("query returned %d instead of 1", one)
+		}
+		return nil
+	}
+}
+
+// Redis sends PING to the Redis server at redisURL, authenticating first if the URL
+// has a password. rediss:// URLs connect over TLS.
+func Redis(redisURL string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		parsed, err := url.Parse(redisURL)
+		if err != nil {
+			return fmt.Errorf("invalid URL: %w", err)
+		}
+		if parsed.Scheme != "redis" && parsed.Scheme != "rediss" {
+			return fmt.Errorf("unsupported scheme %q, expected redis or rediss", parsed.Scheme)
+		}
+		host := parsed.Host
+		if parsed.Port() == "" {
+			host = net.JoinHostPort(parsed.Hostname(), "6379")
+		}
+
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", host)
+		if err != nil {
+			return fmt.Errorf("failed to connect: %w", err)
+		}
+		defer conn.Close()
+		if deadline, ok := ctx.Deadline(); ok {
+			conn.SetDeadline(deadline)
+		}
+		if parsed.Scheme == "rediss" {
+			tlsConn := tls.Client(conn, &tls.Config{ServerName: parsed.Hostname()})
+			if err := tlsConn.HandshakeContext(ctx); err != nil {
+				return fmt.Errorf("TLS handshake failed: %w", err)
+			}
+			conn = tlsConn
+		}
+
+		reader := bufio.NewReader(conn)
+		command := func(args ...string) (string, error) {
+			request := fmt.Sprintf("*%d\r\n", len(args))
+			for _, arg := range args {
+				request += fmt.Sprintf("$%d\r\n%s\r\n", len(arg), arg)
+			}
+			if _, err := conn.Write([]byte(request)); err != nil {
+				return "", err
+			}
+			reply, err := reader.ReadString('\n')
+			if err != nil {
+				return "", err
+			}
+			reply = strings.TrimRight(reply, "\r\n")
+			if strings.HasPrefix(reply, "-") {
+				return "", fmt.Errorf("%s", reply[1:])
+			}
+			return reply, nil
+		}
+
+		if password, ok := parsed.User.Password(); ok {
+			args := []string{"AUTH", password}
+			if username := parsed.User.Username(); username != "" {
+				args = []string{"AUTH", username, password}
+			}
+			if _, err := command(args...); err != nil {
+				return fmt.Errorf("AUTH failed: %w", err)
+			}
+		}
+
+		reply, err := command("PING")
+		if err != nil {
+			return fmt.Errorf("PING failed: %w", err)
+		}
+		if reply != "+PONG" {
+			return fmt.Errorf("unexpected PING reply %q", reply)
+		}
+		return nil
+	}
+}
+
+// ChainID checks that the Ethereum node answers eth_chainId and, if want is not zero,
+// that it is on chain want
+func ChainID(client *ethclient.Client, want int64) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		chainID, err := client.ChainID(ctx)
+		if err != nil {
+			return fmt.Errorf("eth_chainId failed: %w", err)
+		}
+		if want != 0 && chainID.Cmp(big.NewInt(want)) != 0 {
+			return fmt.Errorf("node is on chain %s, expected %d", chainID, want)
+		}
+		return nil
+	}
+}
+
+// ContractCode checks that a contract is deployed at address
+func ContractCode(client *ethclient.Client, address string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("%q is not an address", address)
+		}
+		code, err := client.CodeAt(ctx, common.HexToAddress(address), nil)
+		if err != nil {
+			return fmt.Errorf("eth_getCode failed: %w", err)
+		}
+		if len(code) == 0 {
+			return fmt.Errorf("no contract code at %s", address)
+		}
+		return nil
+	}
+}
+
+// Signer derives the address that signs oracle transactions from a hex private key
+func Signer(privateKey string) (common.Address, error) {
+	key, err := crypto.HexToECDSA(privateKey)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid private key: %w", err)
+	}
+	return crypto.PubkeyToAddress(key.PublicKey), nil
+}
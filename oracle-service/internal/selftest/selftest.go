@@ -0,0 +1,78 @@
+// Package selftest checks the service's dependencies at startup: the database,
+// Redis, the Ethereum node and oracle contract, the signing key and the providers.
+// A misconfigured dependency is reported with what to fix before the service takes
+// traffic, instead of on the first request that needs it.
+package selftest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Check is one startup check
+type Check struct {
+	Name string
+	Hint string // What to fix if the check fails, e.g. the setting to correct
+	Run  func(ctx context.Context) error
+}
+
+// Result is the outcome of a check
+type Result struct {
+	Name     string
+	Hint     string
+	Duration time.Duration
+	Err      error
+}
+
+// Passed reports whether the check succeeded
+func (r Result) Passed() bool {
+	return r.Err == nil
+}
+
+// Error describes a failed check with its hint
+func (r Result) Error() string {
+	if r.Hint == "" {
+		return fmt.Sprintf("%s: %v", r.Name, r.Err)
+	}
+	return fmt.Sprintf("%s: %v (%s)", r.Name, r.Err, r.Hint)
+}
+
+// Run runs the checks concurrently, each cancelled after timeout, and returns their
+// results in the order of checks. It reports whether every check passed.
+func Run(ctx context.Context, checks []Check, timeout time.Duration) ([]Result, bool) {
+	results := make([]Result, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check.Run(checkCtx)
+			if err == nil && checkCtx.Err() != nil {
+				err = checkCtx.Err()
+			}
+			results[i] = Result{
+				Name:     check.Name,
+				Hint:     check.Hint,
+				Duration: time.Since(start),
+				Err:      err,
+			}
+		}(i, check)
+	}
+	wg.Wait()
+
+	passed := true
+	for _, result := range results {
+		if !result.Passed() {
+			passed = false
+		}
+	}
+	return results, passed
+}
@@ -0,0 +1,137 @@
+package selftest
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestRun(t *testing.T) {
+	checks := []Check{
+		{Name: "ok", Run: func(ctx context.Context) error { return nil }},
+		{Name: "broken", Hint: "check BROKEN_URL", Run: func(ctx context.Context) error { return errors.New("connection refused") }},
+		{Name: "hung", Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+	}
+
+	results, passed := Run(context.Background(), checks, 50*time.Millisecond)
+	if passed {
+		t.Fatal("Expected the self-test to fail")
+	}
+	if len(results) != 3 || results[0].Name != "ok" || results[1].Name != "broken" || results[2].Name != "hung" {
+		t.Fatalf("Expected results in check order, got %+v", results)
+	}
+	if !results[0].Passed() {
+		t.Errorf("Expected the first check to pass, got %v", results[0].Err)
+	}
+	if got := results[1].Error(); got != "broken: connection refused (check BROKEN_URL)" {
+		t.Errorf("Unexpected failure description %q", got)
+	}
+	if !errors.Is(results[2].Err, context.DeadlineExceeded) {
+		t.Errorf("Expected the hung check to time out, got %v", results[2].Err)
+	}
+}
+
+func TestDatabase(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	if err := Database(db)(context.Background()); err != nil {
+		t.Errorf("Expected the database check to pass, got %v", err)
+	}
+}
+
+// fakeRedis answers AUTH with password and PING over RESP
+func fakeRedis(t *testing.T, password string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				authenticated := password == ""
+				for {
+					// *<n> followed by $<len> and the value of each argument
+					header, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					var args []string
+					for i := 0; i < int(header[1]-'0'); i++ {
+						reader.ReadString('\n')
+						arg, _ := reader.ReadString('\n')
+						args = append(args, strings.TrimRight(arg, "\r\n"))
+					}
+					switch {
+					case args[0] == "AUTH" && args[len(args)-1] == password:
+						authenticated = true
+						conn.Write([]byte("+OK\r\n"))
+					case args[0] == "AUTH":
+						conn.Write([]byte("-WRONGPASS invalid password\r\n"))
+					case !authenticated:
+						conn.Write([]byte("-NOAUTH Authentication required.\r\n"))
+					default:
+						conn.Write([]byte("+PONG\r\n"))
+					}
+				}
+			}(conn)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestRedis(t *testing.T) {
+	ctx := context.Background()
+	open := fakeRedis(t, "")
+	if err := Redis("redis://" + open)(ctx); err != nil {
+		t.Errorf("Expected PING to succeed, got %v", err)
+	}
+
+	protected := fakeRedis(t, "secret")
+	if err := Redis("redis://:secret@" + protected + "/0")(ctx); err != nil {
+		t.Errorf("Expected PING with AUTH to succeed, got %v", err)
+	}
+	if err := Redis("redis://:wrong@" + protected)(ctx); err == nil || !strings.Contains(err.Error(), "AUTH failed") {
+		t.Errorf("Expected AUTH to fail, got %v", err)
+	}
+	if err := Redis("redis://" + protected)(ctx); err == nil {
+		t.Error("Expected PING without AUTH to fail")
+	}
+	if err := Redis("http://" + open)(ctx); err == nil {
+		t.Error("Expected a non-Redis URL to fail")
+	}
+}
+
+func TestSigner(t *testing.T) {
+	// Well-known first Hardhat development account
+	signer, err := Signer("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	if err != nil {
+		t.Fatalf("Failed to derive signer: %v", err)
+	}
+	if signer.Hex() != "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266" {
+		t.Errorf("Unexpected signer %s", signer.Hex())
+	}
+
+	if _, err := Signer("your_private_key_here"); err == nil {
+		t.Error("Expected an invalid key to fail")
+	}
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// AlertRescore is the effect of a bureau alert on one linked address
+type AlertRescore struct {
+	Address       string      `json:"address"`
+	PreviousScore units.Score `json:"previous_score,omitempty"`
+	Score         units.Score `json:"score,omitempty"`
+	Error         string      `json:"error,omitempty"`
+}
+
+// BureauAlertResult summarizes how a bureau alert was handled
+type BureauAlertResult struct {
+	AlertID   string         `json:"alert_id"`
+	AlertType string         `json:"alert_type"`
+	Status    string         `json:"status"`
+	Duplicate bool           `json:"duplicate"` // The alert was already received
+	Rescored  []AlertRescore `json:"rescored"`
+}
+
+// HandleBureauAlert routes a bureau monitoring alert to the linked addresses, refreshes
+// their bureau data, and recalculates their scores with the bureau_alert change reason.
+// Redelivered alerts are acknowledged without being processed again.
+func (s *EnhancedOracleService) HandleBureauAlert(ctx context.Context, event *providers.BureauAlertEvent) (*BureauAlertResult, error) {
+	if s.bureauRepo == nil {
+		return nil, fmt.Errorf("bureau alerts not configured")
+	}
+
+	result := &BureauAlertResult{
+		AlertID:   event.AlertID,
+		AlertType: event.AlertType,
+		Rescored:  []AlertRescore{},
+	}
+
+	existing, err := s.bureauRepo.GetAlert(ctx, event.AlertID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		result.Status = existing.Status
+		result.Duplicate = true
+		return result, nil
+	}
+
+	provider := s.creditBureauProvider.Name()
+	consumerHash := providers.HashConsumerID(provider, event.ConsumerID)
+
+	addresses, err := s.bureauRepo.GetLinkedAddresses(ctx, provider, consumerHash)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Processing bureau alert",
+		zap.String("alertID", event.AlertID),
+		zap.String("alertType", event.AlertType),
+		zap.Int("linkedAddresses", len(addresses)),
+	)
+
+	failed := 0
+	for _, address := range addresses {
+		rescore := s.rescoreAfterBureauAlert(ctx, address, event.ConsumerID)
+		if rescore.Error != "" {
+			failed++
+		}
+		result.Rescored = append(result.Rescored, rescore)
+	}
+
+	alert := &models.BureauAlert{
+		AlertID:          event.AlertID,
+		Provider:         provider,
+		AlertType:        event.AlertType,
+		RawType:          event.RawType,
+		ConsumerHash:     consumerHash,
+		AffectedAccounts: len(addresses),
+		OccurredAt:       event.OccurredAt,
+	}
+	switch {
+	case len(addresses) == 0:
+		alert.Status = models.BureauAlertUnmatched
+	case failed == len(addresses):
+		alert.Status = models.BureauAlertFailed
+		alert.ErrorMessage = result.Rescored[0].Error
+	default:
+		alert.Status = models.BureauAlertProcessed
+	}
+	result.Status = alert.Status
+
+	if err := s.bureauRepo.CreateAlert(ctx, alert); err != nil {
+		logger.Error("Failed to save bureau alert", zap.Error(err))
+	}
+
+	return result, nil
+}
+
+// rescoreAfterBureauAlert refreshes an address's bureau data and recalculates its score
+// from the stored on-chain metrics
+func (s *EnhancedOracleService) rescoreAfterBureauAlert(ctx context.Context, address, consumerID string) AlertRescore {
+	rescore := AlertRescore{Address: address}
+	repo := s.baseService.repo
+
+	previous, err := repo.GetByAddress(ctx, address)
+	if err != nil {
+		rescore.Error = err.Error()
+		return rescore
+	}
+	if previous != nil {
+		rescore.PreviousScore = previous.Score
+	}
+
+	// Frozen profiles get no new bureau data
+	if err := s.baseService.checkNotFrozen(ctx, address); err != nil {
+		rescore.Error = err.Error()
+		return rescore
+	}
+	timer := startPipelineTimer(address, models.ChangeReasonBureauAlert)
+
+	offChainMetrics, err := repo.GetOffChainMetrics(ctx, address)
+	if err != nil {
+		rescore.Error = err.Error()
+		return rescore
+	}
+	if offChainMetrics == nil {
+		offChainMetrics = &models.OffChainMetrics{UserAddress: address}
+	}
+
+	if err := s.enhancedOffChainAgg.RefreshCreditReport(ctx, offChainMetrics, consumerID); err != nil {
+		logger.Error("Failed to refresh credit report after bureau alert", zap.String("address", address), zap.Error(err))
+		s.providerFailed(ctx, s.creditBureauProvider.Name(), address, err)
+		timer.fail(models.StageFetchOffChain)
+		s.baseService.recordTiming(ctx, timer, nil)
+		rescore.Error = err.Error()
+		return rescore
+	}
+	timer.mark(models.StageFetchOffChain)
+	if err := repo.UpsertOffChainMetrics(ctx, offChainMetrics); err != nil {
+		logger.Error("Failed to save off-chain metrics", zap.Error(err))
+	}
+	s.baseService.recordMetricsFetched(ctx, address, nil, offChainMetrics)
+
+	onChainMetrics, err := repo.GetOnChainMetrics(ctx, address)
+	if err != nil {
+		rescore.Error = err.Error()
+		return rescore
+	}
+
+	timer.mark(models.StagePersist)
+
+	score, err := s.baseService.calculateScore(ctx, address, onChainMetrics, offChainMetrics)
+	if err != nil {
+		timer.fail(models.StageScore)
+		s.baseService.recordTiming(ctx, timer, nil)
+		rescore.Error = fmt.Sprintf("failed to calculate score: %v", err)
+		return rescore
+	}
+	timer.mark(models.StageScore)
+	score.UserAddress = address
+
+	if err := s.baseService.saveScore(ctx, score, models.ChangeReasonBureauAlert); err != nil {
+		timer.fail(models.StagePersist)
+		s.baseService.recordTiming(ctx, timer, nil)
+		rescore.Error = err.Error()
+		return rescore
+	}
+	timer.mark(models.StagePersist)
+	s.baseService.recordTiming(ctx, timer, score)
+	rescore.Score = score.Score
+
+	logger.Info("Score recalculated after bureau alert",
+		zap.String("address", address),
+		zap.Uint16("previousScore", rescore.PreviousScore.Uint16()),
+		zap.Uint16("score", score.Score.Uint16()),
+	)
+
+	return rescore
+}
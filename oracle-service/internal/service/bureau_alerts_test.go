@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/aggregator"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+)
+
+func setupBureauAlertService(t *testing.T) (*EnhancedOracleService, *OracleService, *repository.BureauRepository) {
+	baseService, db := setupTestService(t)
+	bureauRepo := repository.NewBureauRepository(db)
+
+	creditBureau := providers.NewCreditBureauProvider("experian", "us", "", "")
+	mock := providers.MockSettings{Bureau: providers.MockAlways, Plaid: providers.MockAlways, Employment: providers.MockAlways}
+	offChainAgg := aggregator.NewEnhancedOffChainAggregator(
+		creditBureau,
+		providers.NewPlaidProvider("", "", "sandbox"),
+		scoring.NewBureauNormalizer(nil, nil),
+		mock,
+	)
+
+	enhanced := NewEnhancedOracleService(baseService, nil, offChainAgg, creditBureau, nil, nil, nil, bureauRepo, mock)
+	return enhanced, baseService, bureauRepo
+}
+
+func TestHandleBureauAlertRescoresLinkedAddress(t *testing.T) {
+	enhanced, baseService, bureauRepo := setupBureauAlertService(t)
+	ctx := context.Background()
+
+	address := "0x1234567890123456789012345678901234567890"
+	if _, err := baseService.CalculateAndUpdateScore(ctx, address, "user123"); err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+	if err := bureauRepo.LinkConsumer(ctx, "experian", providers.HashConsumerID("experian", "consumer-1"), address); err != nil {
+		t.Fatalf("Failed to link consumer: %v", err)
+	}
+
+	event := &providers.BureauAlertEvent{
+		AlertID:    "alert-1",
+		ConsumerID: "consumer-1",
+		AlertType:  models.BureauAlertDelinquency,
+		OccurredAt: time.Now(),
+	}
+
+	result, err := enhanced.HandleBureauAlert(ctx, event)
+	if err != nil {
+		t.Fatalf("Failed to handle alert: %v", err)
+	}
+	if result.Status != models.BureauAlertProcessed {
+		t.Errorf("Expected status %s, got %s", models.BureauAlertProcessed, result.Status)
+	}
+	if len(result.Rescored) != 1 || result.Rescored[0].Error != "" {
+		t.Fatalf("Expected one successful rescore, got %+v", result.Rescored)
+	}
+	if result.Rescored[0].PreviousScore == 0 {
+		t.Error("Expected previous score to be reported")
+	}
+
+	history, err := baseService.GetScoreHistory(ctx, address, 10)
+	if err != nil {
+		t.Fatalf("Failed to get history: %v", err)
+	}
+	found := false
+	for _, h := range history {
+		if h.ChangeReason == models.ChangeReasonBureauAlert {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected a history record with the bureau_alert change reason")
+	}
+
+	// Redelivery of the same alert is acknowledged without rescoring
+	again, err := enhanced.HandleBureauAlert(ctx, event)
+	if err != nil {
+		t.Fatalf("Failed to handle redelivered alert: %v", err)
+	}
+	if !again.Duplicate || len(again.Rescored) != 0 {
+		t.Errorf("Expected duplicate with no rescoring, got %+v", again)
+	}
+}
+
+func TestHandleBureauAlertUnmatched(t *testing.T) {
+	enhanced, _, _ := setupBureauAlertService(t)
+
+	result, err := enhanced.HandleBureauAlert(context.Background(), &providers.BureauAlertEvent{
+		AlertID:    "alert-2",
+		ConsumerID: "unknown-consumer",
+		AlertType:  models.BureauAlertBankruptcy,
+	})
+	if err != nil {
+		t.Fatalf("Failed to handle alert: %v", err)
+	}
+	if result.Status != models.BureauAlertUnmatched {
+		t.Errorf("Expected status %s, got %s", models.BureauAlertUnmatched, result.Status)
+	}
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+// canary routes a share of publications to a new oracle contract or payload format
+type canary struct {
+	client  BlockchainClient
+	percent int
+}
+
+// SetCanary sends percent of score publications through client instead of the primary
+// blockchain client, so a contract upgrade can be tried on live traffic before full
+// cutover. Addresses are assigned by hash, so an address always goes to the same
+// contract. A percent of zero (or a nil client) disables the canary.
+func (s *OracleService) SetCanary(client BlockchainClient, percent int) {
+	if client == nil || percent <= 0 {
+		s.canary = nil
+		return
+	}
+	if percent > 100 {
+		percent = 100
+	}
+	s.canary = &canary{client: client, percent: percent}
+}
+
+// publishTarget picks the contract an address's scores are published to
+func (s *OracleService) publishTarget(address string) (string, BlockchainClient) {
+	if s.canary != nil && canaryBucket(address) < s.canary.percent {
+		return models.PublishTargetCanary, s.canary.client
+	}
+	return models.PublishTargetPrimary, s.blockchainClient
+}
+
+// canaryBucket maps an address to a stable bucket in [0, 100)
+func canaryBucket(address string) int {
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(address)))
+	return int(h.Sum32() % 100)
+}
+
+// PublishOutcomes counts the publications sent to one contract
+type PublishOutcomes struct {
+	Sent        int64   `json:"sent"`
+	Submitted   int64   `json:"submitted"` // Accepted by the node (pending or confirmed)
+	Confirmed   int64   `json:"confirmed"`
+	Failed      int64   `json:"failed"`
+	SuccessRate float64 `json:"success_rate"` // Submitted / sent, 0 when nothing was sent
+}
+
+// CanaryReport compares the canary's publications with the primary contract's
+type CanaryReport struct {
+	Enabled bool            `json:"enabled"`
+	Percent int             `json:"percent"`
+	Since   time.Time       `json:"since"`
+	Primary PublishOutcomes `json:"primary"`
+	Canary  PublishOutcomes `json:"canary"`
+	// Healthy is true once the canary has sent publications and succeeds at least as
+	// often as the primary contract
+	Healthy bool `json:"healthy"`
+}
+
+// CanaryReport summarizes publication outcomes per contract over the given window
+func (s *OracleService) CanaryReport(ctx context.Context, window time.Duration) (*CanaryReport, error) {
+	since := time.Now().Add(-window)
+	counts, err := s.repo.CountOracleUpdatesByTarget(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CanaryReport{
+		Enabled: s.canary != nil,
+		Since:   since,
+		Primary: publishOutcomes(counts[models.PublishTargetPrimary]),
+		Canary:  publishOutcomes(counts[models.PublishTargetCanary]),
+	}
+	if s.canary != nil {
+		report.Percent = s.canary.percent
+	}
+	report.Healthy = report.Canary.Sent > 0 && report.Canary.SuccessRate >= report.Primary.SuccessRate
+
+	return report, nil
+}
+
+func publishOutcomes(byStatus map[string]int64) PublishOutcomes {
+	outcomes := PublishOutcomes{
+		Confirmed: byStatus[models.OracleUpdateConfirmed],
+		Failed:    byStatus[models.OracleUpdateFailed],
+	}
+	outcomes.Submitted = byStatus[models.OracleUpdatePending] + outcomes.Confirmed
+	outcomes.Sent = outcomes.Submitted + outcomes.Failed
+	if outcomes.Sent > 0 {
+		outcomes.SuccessRate = float64(outcomes.Submitted) / float64(outcomes.Sent)
+	}
+	return outcomes
+}
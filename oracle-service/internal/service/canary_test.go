@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/blockchain"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+// Mock blockchain client that records the addresses it publishes
+type mockRecordingClient struct {
+	mockBlockchainClient
+	published []string
+	err       error
+}
+
+func (m *mockRecordingClient) UpdateCreditScore(ctx context.Context, update blockchain.ScoreUpdate) (*types.Transaction, error) {
+	m.published = append(m.published, update.UserAddress)
+	return nil, m.err
+}
+
+func TestCanaryBucketIsStable(t *testing.T) {
+	address := "0xAbCdEf0123456789abcdef0123456789ABCDEF01"
+	bucket := canaryBucket(address)
+	if bucket < 0 || bucket >= 100 {
+		t.Fatalf("Expected bucket in [0, 100), got %d", bucket)
+	}
+	if canaryBucket(address) != bucket {
+		t.Error("Expected the same bucket for the same address")
+	}
+	if canaryBucket("0xabcdef0123456789abcdef0123456789abcdef01") != bucket {
+		t.Error("Expected the bucket to ignore address case")
+	}
+}
+
+func TestCanaryPublishing(t *testing.T) {
+	service, _ := setupTestService(t)
+	primary := &mockRecordingClient{}
+	canaryClient := &mockRecordingClient{err: errors.New("execution reverted")}
+	service.blockchainClient = primary
+	service.SetCanary(canaryClient, 50)
+	ctx := context.Background()
+
+	var addresses []string
+	for i := 0; i < 20; i++ {
+		address := fmt.Sprintf("0x%040x", i+1)
+		if _, err := service.CalculateAndUpdateScore(ctx, address, ""); err != nil {
+			t.Fatalf("Failed to calculate score: %v", err)
+		}
+		addresses = append(addresses, address)
+	}
+
+	if _, err := service.PublishBatch(ctx, addresses, 0, true); err != nil {
+		t.Fatalf("Failed to publish batch: %v", err)
+	}
+	if len(primary.published) == 0 || len(canaryClient.published) == 0 {
+		t.Fatalf("Expected publications on both contracts, got %d primary and %d canary",
+			len(primary.published), len(canaryClient.published))
+	}
+	if len(primary.published)+len(canaryClient.published) != len(addresses) {
+		t.Errorf("Expected each address published once, got %d", len(primary.published)+len(canaryClient.published))
+	}
+	for _, address := range canaryClient.published {
+		if target, _ := service.publishTarget(address); target != models.PublishTargetCanary {
+			t.Errorf("Expected %s to be routed to the canary", address)
+		}
+	}
+
+	// A single publish follows the same routing
+	address := canaryClient.published[0]
+	if err := service.PublishScoreToBlockchain(ctx, address); err == nil {
+		t.Error("Expected the failing canary contract to fail the publish")
+	}
+
+	report, err := service.CanaryReport(ctx, time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to build canary report: %v", err)
+	}
+	if !report.Enabled || report.Percent != 50 {
+		t.Errorf("Expected canary enabled at 50%%, got %v at %d%%", report.Enabled, report.Percent)
+	}
+	if report.Primary.Sent != int64(len(primary.published)) || report.Primary.SuccessRate != 1 {
+		t.Errorf("Unexpected primary outcomes: %+v", report.Primary)
+	}
+	if report.Canary.Failed != int64(len(canaryClient.published)) || report.Canary.SuccessRate != 0 {
+		t.Errorf("Unexpected canary outcomes: %+v", report.Canary)
+	}
+	if report.Healthy {
+		t.Error("Expected a failing canary to be reported unhealthy")
+	}
+}
+
+func TestCanaryDisabled(t *testing.T) {
+	service, _ := setupTestService(t)
+	service.SetCanary(&mockRecordingClient{}, 0)
+
+	if target, _ := service.publishTarget("0x1234567890123456789012345678901234567890"); target != models.PublishTargetPrimary {
+		t.Errorf("Expected primary target with the canary disabled, got %s", target)
+	}
+
+	report, err := service.CanaryReport(context.Background(), time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to build canary report: %v", err)
+	}
+	if report.Enabled || report.Healthy {
+		t.Errorf("Expected a disabled, unhealthy canary report, got %+v", report)
+	}
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/credentials"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// CredentialActionIssue is the wallet-signed action that issues a score credential
+const CredentialActionIssue = "issue_credential"
+
+// Credential errors
+var (
+	ErrCredentialsNotConfigured = errors.New("credential issuance not configured")
+	ErrCredentialNotFound       = errors.NotFound("credential not found")
+	ErrScoreNotFound            = errors.NotFound("no score found")
+)
+
+// IssuedScoreCredential is a newly issued score credential
+type IssuedScoreCredential struct {
+	Record     *models.IssuedCredential `json:"record"`
+	Credential *credentials.Credential  `json:"credential"`
+	JWT        string                   `json:"jwt"` // The credential as a VC-JWT, for identity wallets
+}
+
+// SetCredentialIssuer enables issuing scores as W3C Verifiable Credentials
+func (s *OracleService) SetCredentialIssuer(issuer *credentials.Issuer) {
+	s.issuer = issuer
+}
+
+// IssueScoreCredential packages an address's current score as a Verifiable Credential
+// signed by the oracle. The request must be signed by the address's wallet, and
+// frozen profiles and provisional scores get no credentials.
+func (s *OracleService) IssueScoreCredential(ctx context.Context, address string, timestamp int64, signature string, requester Requester) (*IssuedScoreCredential, error) {
+	if s.issuer == nil {
+		return nil, ErrCredentialsNotConfigured
+	}
+	if err := s.authenticateWallet(ctx, CredentialActionIssue, address, timestamp, signature); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotFrozen(ctx, address); err != nil {
+		return nil, err
+	}
+
+	score, err := s.repo.GetByAddress(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score: %w", err)
+	}
+	if score == nil {
+		return nil, fmt.Errorf("%w for address %s", ErrScoreNotFound, address)
+	}
+	if err := checkPublishable(score); err != nil {
+		return nil, err
+	}
+
+	result := &IssuedScoreCredential{
+		Record: &models.IssuedCredential{
+			UserAddress: address,
+			Score:       score.Score,
+			Confidence:  score.Confidence,
+			DataHash:    score.DataHash,
+		},
+	}
+	err = s.repo.CreateIssuedCredential(ctx, result.Record, func(record *models.IssuedCredential) error {
+		credential, jwt, err := s.issuer.IssueScore(record.ID, credentials.ScoreClaims{
+			Address:    address,
+			Score:      score.Score,
+			Confidence: score.Confidence,
+			DataHash:   score.DataHash,
+			ScoredAt:   score.LastUpdated,
+		})
+		if err != nil {
+			return err
+		}
+
+		expiresAt, err := time.Parse(time.RFC3339, credential.ExpirationDate)
+		if err != nil {
+			return err
+		}
+		record.CredentialID = credential.ID
+		record.StatusList, record.StatusIndex = credentials.StatusLocation(record.ID)
+		record.ExpiresAt = expiresAt
+
+		result.Credential = credential
+		result.JWT = jwt
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordAudit(ctx, &models.AuditLog{
+		Action:      models.AuditCredentialIssued,
+		Actor:       address,
+		UserAddress: address,
+		Detail:      result.Record.CredentialID,
+		RemoteAddr:  requester.RemoteAddr,
+		UserAgent:   requester.UserAgent,
+	})
+
+	logger.Info("Score credential issued",
+		zap.String("address", address),
+		zap.String("credentialID", result.Record.CredentialID),
+	)
+
+	return result, nil
+}
+
+// RevokeCredential marks an issued credential revoked in its status list
+func (s *OracleService) RevokeCredential(ctx context.Context, id uint, reason string) (*models.IssuedCredential, error) {
+	record, err := s.repo.GetIssuedCredential(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, ErrCredentialNotFound
+	}
+
+	if record.RevokedAt == nil {
+		now := time.Now()
+		record.RevokedAt = &now
+		record.RevocationReason = reason
+		if err := s.repo.UpdateIssuedCredential(ctx, record); err != nil {
+			return nil, fmt.Errorf("failed to revoke credential: %w", err)
+		}
+
+		s.recordAudit(ctx, &models.AuditLog{
+			Action:      models.AuditCredentialRevoked,
+			Actor:       "admin",
+			UserAddress: record.UserAddress,
+			Detail:      fmt.Sprintf("%s: %s", record.CredentialID, reason),
+		})
+	}
+
+	return record, nil
+}
+
+// CredentialStatusList returns the signed revocation status list credential (as a
+// VC-JWT) that verifiers fetch to check whether a credential was revoked
+func (s *OracleService) CredentialStatusList(ctx context.Context, list int) (string, error) {
+	if s.issuer == nil {
+		return "", ErrCredentialsNotConfigured
+	}
+
+	revoked, err := s.repo.ListRevokedStatusIndexes(ctx, list)
+	if err != nil {
+		return "", err
+	}
+
+	_, jwt, err := s.issuer.StatusList(list, revoked)
+	return jwt, err
+}
+
+// IssuerDIDDocument returns the did:web document verifiers resolve the issuer's key from
+func (s *OracleService) IssuerDIDDocument() (map[string]interface{}, error) {
+	if s.issuer == nil {
+		return nil, ErrCredentialsNotConfigured
+	}
+	return s.issuer.DIDDocument(), nil
+}
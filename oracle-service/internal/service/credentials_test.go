@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/credentials"
+)
+
+func TestIssueAndRevokeScoreCredential(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+
+	key, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	now := time.Now().Unix()
+
+	// Issuance is disabled until an issuer is configured
+	if _, err := service.IssueScoreCredential(ctx, address, now, signWallet(t, key, CredentialActionIssue, address, now), Requester{}); !errors.Is(err, ErrCredentialsNotConfigured) {
+		t.Fatalf("Expected ErrCredentialsNotConfigured, got %v", err)
+	}
+
+	issuer, err := credentials.NewIssuer("https://oracle.example.com", "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318", 1, time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create issuer: %v", err)
+	}
+	service.SetCredentialIssuer(issuer)
+
+	if _, err := service.IssueScoreCredential(ctx, address, now, signWallet(t, key, CredentialActionIssue, address, now), Requester{}); !errors.Is(err, ErrScoreNotFound) {
+		t.Fatalf("Expected ErrScoreNotFound before scoring, got %v", err)
+	}
+	if _, err := service.CalculateAndUpdateScore(ctx, address, ""); err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	now++
+	issued, err := service.IssueScoreCredential(ctx, address, now, signWallet(t, key, CredentialActionIssue, address, now), Requester{})
+	if err != nil {
+		t.Fatalf("Failed to issue credential: %v", err)
+	}
+	if issued.Record.CredentialID != issued.Credential.ID || issued.Record.StatusList != 1 {
+		t.Errorf("Expected record to match credential, got %+v", issued.Record)
+	}
+
+	var claims credentials.Claims
+	if err := credentials.VerifyJWT(issued.JWT, issuer.PublicKey(), &claims); err != nil {
+		t.Fatalf("Failed to verify credential JWT: %v", err)
+	}
+
+	if _, err := service.RevokeCredential(ctx, issued.Record.ID, "score disputed"); err != nil {
+		t.Fatalf("Failed to revoke credential: %v", err)
+	}
+
+	statusJWT, err := service.CredentialStatusList(ctx, 1)
+	if err != nil {
+		t.Fatalf("Failed to get status list: %v", err)
+	}
+	var list struct {
+		VC struct {
+			CredentialSubject credentials.StatusListSubject `json:"credentialSubject"`
+		} `json:"vc"`
+	}
+	if err := credentials.VerifyJWT(statusJWT, issuer.PublicKey(), &list); err != nil {
+		t.Fatalf("Failed to verify status list: %v", err)
+	}
+	index, _ := strconv.Atoi(issued.Credential.CredentialStatus.StatusListIndex)
+	revoked, err := credentials.StatusListBit(list.VC.CredentialSubject.EncodedList, index)
+	if err != nil {
+		t.Fatalf("Failed to read status bit: %v", err)
+	}
+	if !revoked {
+		t.Error("Expected revoked credential to be set in the status list")
+	}
+
+	if _, err := service.RevokeCredential(ctx, 999, "unknown"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("Expected ErrCredentialNotFound, got %v", err)
+	}
+}
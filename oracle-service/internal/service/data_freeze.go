@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ErrProfileFrozen is returned when provider data or publishing is requested for a
+// frozen profile
+var ErrProfileFrozen = errors.New("profile is frozen")
+
+// FreezeProfile freezes an address's profile: no provider data is pulled for it and
+// its score is not published until the freeze is lifted. The request must be signed
+// by the address's wallet.
+func (s *OracleService) FreezeProfile(ctx context.Context, address string, timestamp int64, signature string) (*models.DataFreeze, error) {
+	return s.setFreeze(ctx, models.FreezeActionFreeze, address, timestamp, signature)
+}
+
+// LiftFreeze lifts an address's freeze. The request must be signed by the address's
+// wallet.
+func (s *OracleService) LiftFreeze(ctx context.Context, address string, timestamp int64, signature string) (*models.DataFreeze, error) {
+	return s.setFreeze(ctx, models.FreezeActionLift, address, timestamp, signature)
+}
+
+// GetFreezeStatus retrieves an address's freeze state; addresses that were never
+// frozen are reported as not frozen
+func (s *OracleService) GetFreezeStatus(ctx context.Context, address string) (*models.DataFreeze, error) {
+	freeze, err := s.repo.GetDataFreeze(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if freeze == nil {
+		freeze = &models.DataFreeze{UserAddress: strings.ToLower(address)}
+	}
+	return freeze, nil
+}
+
+func (s *OracleService) setFreeze(ctx context.Context, action, address string, timestamp int64, signature string) (*models.DataFreeze, error) {
+	if err := s.authenticateWallet(ctx, action, address, timestamp, signature); err != nil {
+		return nil, err
+	}
+
+	freeze, err := s.repo.GetDataFreeze(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if freeze == nil {
+		freeze = &models.DataFreeze{UserAddress: address}
+	}
+
+	now := time.Now()
+	switch action {
+	case models.FreezeActionFreeze:
+		if !freeze.Frozen {
+			freeze.Frozen = true
+			freeze.FrozenAt = &now
+			freeze.LiftedAt = nil
+		}
+	case models.FreezeActionLift:
+		if freeze.Frozen {
+			freeze.Frozen = false
+			freeze.LiftedAt = &now
+		}
+	}
+
+	if err := s.repo.SaveDataFreeze(ctx, freeze); err != nil {
+		return nil, fmt.Errorf("failed to save data freeze: %w", err)
+	}
+
+	auditAction := models.AuditFreeze
+	if !freeze.Frozen {
+		auditAction = models.AuditFreezeLifted
+	}
+	s.recordAudit(ctx, &models.AuditLog{
+		Action:      auditAction,
+		Actor:       freeze.UserAddress,
+		UserAddress: freeze.UserAddress,
+	})
+
+	logger.Info("Data freeze updated",
+		zap.String("address", freeze.UserAddress),
+		zap.Bool("frozen", freeze.Frozen),
+	)
+
+	return freeze, nil
+}
+
+// checkNotFrozen returns ErrProfileFrozen if the address's profile is frozen
+func (s *OracleService) checkNotFrozen(ctx context.Context, address string) error {
+	freeze, err := s.repo.GetDataFreeze(ctx, address)
+	if err != nil {
+		return err
+	}
+	if freeze != nil && freeze.Frozen {
+		return fmt.Errorf("%w: %s", ErrProfileFrozen, address)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+// signWallet signs a wallet message the way a wallet's personal_sign does
+func signWallet(t *testing.T, key *ecdsa.PrivateKey, action, address string, timestamp int64) string {
+	t.Helper()
+	sig, err := crypto.Sign(accounts.TextHash([]byte(WalletMessage(action, address, timestamp))), key)
+	if err != nil {
+		t.Fatalf("Failed to sign wallet message: %v", err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return hexutil.Encode(sig)
+}
+
+func TestDataFreeze(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey).Hex()
+
+	if _, err := service.CalculateAndUpdateScore(ctx, address, ""); err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	now := time.Now().Unix()
+	freeze, err := service.FreezeProfile(ctx, address, now, signWallet(t, key, models.FreezeActionFreeze, address, now))
+	if err != nil {
+		t.Fatalf("Failed to freeze profile: %v", err)
+	}
+	if !freeze.Frozen || freeze.FrozenAt == nil {
+		t.Errorf("Expected profile to be frozen, got %+v", freeze)
+	}
+
+	// No provider pulls and no publishing while frozen
+	if _, err := service.CalculateAndUpdateScore(ctx, address, ""); !errors.Is(err, ErrProfileFrozen) {
+		t.Errorf("Expected ErrProfileFrozen from scoring, got %v", err)
+	}
+	if err := service.PublishScoreToBlockchain(ctx, address); !errors.Is(err, ErrProfileFrozen) {
+		t.Errorf("Expected ErrProfileFrozen from publishing, got %v", err)
+	}
+	result, err := service.PublishBatch(ctx, nil, 10, false)
+	if err != nil {
+		t.Fatalf("Failed to publish batch: %v", err)
+	}
+	if result.Requested != 0 {
+		t.Errorf("Expected frozen score to be skipped, got %d requested", result.Requested)
+	}
+
+	// The same signed request cannot be replayed
+	if _, err := service.LiftFreeze(ctx, address, now, signWallet(t, key, models.FreezeActionLift, address, now)); !errors.Is(err, ErrStaleWalletSignature) {
+		t.Errorf("Expected ErrStaleWalletSignature, got %v", err)
+	}
+
+	// Another wallet cannot lift the freeze
+	other, _ := crypto.GenerateKey()
+	if _, err := service.LiftFreeze(ctx, address, now+1, signWallet(t, other, models.FreezeActionLift, address, now+1)); !errors.Is(err, ErrInvalidWalletSignature) {
+		t.Errorf("Expected ErrInvalidWalletSignature, got %v", err)
+	}
+
+	freeze, err = service.LiftFreeze(ctx, address, now+1, signWallet(t, key, models.FreezeActionLift, address, now+1))
+	if err != nil {
+		t.Fatalf("Failed to lift freeze: %v", err)
+	}
+	if freeze.Frozen || freeze.LiftedAt == nil {
+		t.Errorf("Expected freeze to be lifted, got %+v", freeze)
+	}
+	if err := service.PublishScoreToBlockchain(ctx, address); err != nil {
+		t.Errorf("Expected publishing to resume after lift, got %v", err)
+	}
+}
+
+func TestDataFreezeRejectsStaleTimestamp(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+
+	key, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	old := time.Now().Add(-time.Hour).Unix()
+
+	if _, err := service.FreezeProfile(ctx, address, old, signWallet(t, key, models.FreezeActionFreeze, address, old)); !errors.Is(err, ErrStaleWalletSignature) {
+		t.Errorf("Expected ErrStaleWalletSignature, got %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+)
+
+// ErrScoreProvisional is returned when a provisional score is published or issued as
+// a credential
+var ErrScoreProvisional = errors.Validation("score is provisional: not enough data to publish")
+
+// SetDataPolicy sets the minimum data a score must be based on to be final. Scores
+// below it are stored as provisional and never published.
+func (s *OracleService) SetDataPolicy(policy *scoring.DataPolicy) {
+	s.dataPolicy = policy
+}
+
+// applyDataPolicy marks a score provisional when its metrics fall short of the data
+// policy, recording the requirements any one of which would make it final
+func (s *OracleService) applyDataPolicy(score *models.CreditScore, onChain *models.OnChainMetrics, offChain *models.OffChainMetrics) {
+	missing := s.dataPolicy.Missing(onChain, offChain)
+	score.Provisional = len(missing) > 0
+	score.MissingData = strings.Join(missing, ",")
+}
+
+// checkPublishable refuses provisional scores
+func checkPublishable(score *models.CreditScore) error {
+	if score.Provisional {
+		return ErrScoreProvisional
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/debugtrace"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Debug target lifetimes
+const (
+	DefaultDebugTargetTTL = 24 * time.Hour
+	MaxDebugTargetTTL     = 7 * 24 * time.Hour
+)
+
+// debugTargetRefresh is how long the active targets are cached, so targets added by
+// other instances take effect without a lookup on every request
+const debugTargetRefresh = 30 * time.Second
+
+// ErrInvalidDebugTarget is returned when a debug target names neither or both of an
+// address and an API key
+var ErrInvalidDebugTarget = errors.Validation("debug target needs exactly one of address or api_key")
+
+// DebugTraceDetail is a trace with its provider calls decoded
+type DebugTraceDetail struct {
+	*models.DebugTrace
+	ProviderCalls []debugtrace.ProviderCall `json:"provider_calls"`
+}
+
+// DebugService manages debug targets and stores the traces of their requests
+type DebugService struct {
+	repo *repository.DebugRepository
+	now  func() time.Time
+
+	mu       sync.Mutex
+	targets  []*models.DebugTarget
+	loadedAt time.Time
+}
+
+// NewDebugService creates a new debug service
+func NewDebugService(repo *repository.DebugRepository) *DebugService {
+	return &DebugService{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+// HashAPIKey returns the hash an API key is stored and matched as
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// AddTarget starts tracing requests about an address or made with an API key. A ttl
+// of zero traces for DefaultDebugTargetTTL; longer than MaxDebugTargetTTL is capped.
+func (s *DebugService) AddTarget(ctx context.Context, address, apiKey, note string, ttl time.Duration) (*models.DebugTarget, error) {
+	address = strings.TrimSpace(address)
+	if (address == "") == (apiKey == "") {
+		return nil, ErrInvalidDebugTarget
+	}
+	if ttl <= 0 {
+		ttl = DefaultDebugTargetTTL
+	}
+	if ttl > MaxDebugTargetTTL {
+		ttl = MaxDebugTargetTTL
+	}
+
+	target := &models.DebugTarget{
+		Address:   strings.ToLower(address),
+		Note:      note,
+		ExpiresAt: s.now().Add(ttl).UTC(),
+	}
+	if apiKey != "" {
+		target.APIKeyHash = HashAPIKey(apiKey)
+	}
+	if err := s.repo.CreateTarget(ctx, target); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	logger.Info("Debug tracing enabled",
+		zap.Uint("targetID", target.ID),
+		zap.String("address", target.Address),
+		zap.Bool("apiKey", target.APIKeyHash != ""),
+		zap.Time("expiresAt", target.ExpiresAt),
+	)
+	return target, nil
+}
+
+// RemoveTarget stops tracing for a target. It reports whether the target existed.
+func (s *DebugService) RemoveTarget(ctx context.Context, id uint) (bool, error) {
+	deleted, err := s.repo.DeleteTarget(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	s.invalidate()
+	return deleted, nil
+}
+
+// ListTargets lists the targets that have not expired
+func (s *DebugService) ListTargets(ctx context.Context) ([]*models.DebugTarget, error) {
+	return s.repo.ListActiveTargets(ctx, s.now())
+}
+
+// Match returns the active target covering a request about address made with any of
+// apiKeys, or nil if the request is not traced. The address and keys may be empty.
+func (s *DebugService) Match(ctx context.Context, address string, apiKeys ...string) *models.DebugTarget {
+	targets := s.activeTargets(ctx)
+	if len(targets) == 0 {
+		return nil
+	}
+
+	keyHashes := make(map[string]bool)
+	for _, key := range apiKeys {
+		if key != "" {
+			keyHashes[HashAPIKey(key)] = true
+		}
+	}
+	now := s.now()
+	for _, target := range targets {
+		if !target.ExpiresAt.After(now) {
+			continue
+		}
+		if (target.Address != "" && strings.EqualFold(target.Address, address)) ||
+			(target.APIKeyHash != "" && keyHashes[target.APIKeyHash]) {
+			return target
+		}
+	}
+	return nil
+}
+
+// Enabled reports whether any target is active, so requests can skip the work of
+// matching when none is
+func (s *DebugService) Enabled(ctx context.Context) bool {
+	return len(s.activeTargets(ctx)) > 0
+}
+
+// RecordTrace stores a traced request with the provider calls made while serving it.
+// Failures are logged rather than returned, since tracing must never fail a request.
+func (s *DebugService) RecordTrace(ctx context.Context, trace *models.DebugTrace, calls []debugtrace.ProviderCall) {
+	if len(calls) > 0 {
+		encoded, err := json.Marshal(calls)
+		if err != nil {
+			logger.Error("Failed to encode debug trace provider calls", zap.Error(err))
+		} else {
+			trace.ProviderCalls = string(encoded)
+		}
+	}
+
+	if err := s.repo.CreateTrace(ctx, trace); err != nil {
+		logger.Error("Failed to record debug trace",
+			zap.Uint("targetID", trace.TargetID),
+			zap.String("path", trace.Path),
+			zap.Error(err),
+		)
+	}
+}
+
+// ListTraces lists traces, newest first, without their bodies and provider calls
+func (s *DebugService) ListTraces(ctx context.Context, address string, targetID uint, limit int) ([]*models.DebugTrace, error) {
+	return s.repo.ListTraces(ctx, address, targetID, limit)
+}
+
+// GetTrace retrieves a trace with its provider calls. It returns nil if the trace does
+// not exist.
+func (s *DebugService) GetTrace(ctx context.Context, id uint) (*DebugTraceDetail, error) {
+	trace, err := s.repo.GetTrace(ctx, id)
+	if err != nil || trace == nil {
+		return nil, err
+	}
+
+	detail := &DebugTraceDetail{DebugTrace: trace, ProviderCalls: []debugtrace.ProviderCall{}}
+	if trace.ProviderCalls != "" {
+		if err := json.Unmarshal([]byte(trace.ProviderCalls), &detail.ProviderCalls); err != nil {
+			return nil, fmt.Errorf("failed to decode debug trace provider calls: %w", err)
+		}
+	}
+	return detail, nil
+}
+
+// activeTargets returns the cached targets, reloading them once they are stale. If
+// reloading fails the stale targets are kept.
+func (s *DebugService) activeTargets(ctx context.Context) []*models.DebugTarget {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.now().Sub(s.loadedAt) < debugTargetRefresh {
+		return s.targets
+	}
+
+	targets, err := s.repo.ListActiveTargets(ctx, s.now())
+	if err != nil {
+		logger.Error("Failed to load debug targets", zap.Error(err))
+	} else {
+		s.targets = targets
+	}
+	s.loadedAt = s.now()
+	return s.targets
+}
+
+// invalidate makes the next request reload the targets
+func (s *DebugService) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadedAt = time.Time{}
+}
@@ -3,10 +3,15 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/yourusername/p2p-lend/oracle-service/internal/aggregator"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/events"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/pause"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providerhealth"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
 	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -18,8 +23,11 @@ type EnhancedOracleService struct {
 	enhancedOffChainAgg  *aggregator.EnhancedOffChainAggregator
 	creditBureauProvider *providers.CreditBureauProvider
 	plaidProvider        *providers.PlaidProvider
+	employmentProvider   *providers.EmploymentProvider
 	blockchainProvider   *providers.BlockchainDataProvider
-	useMockData          bool // Only applies to off-chain APIs, not blockchain data
+	bureauRepo           *repository.BureauRepository
+	providerHealth       *providerhealth.Monitor // nil when provider health history is not kept
+	mock                 providers.MockSettings  // Only applies to off-chain APIs, not blockchain data
 }
 
 // ProviderData contains data fetched from all providers
@@ -27,7 +35,10 @@ type ProviderData struct {
 	Sources          []string
 	CreditBureauData *providers.CreditBureauResponse
 	PlaidData        *providers.PlaidAccountSummary
+	EmploymentData   *providers.EmploymentVerification
+	PayrollData      *aggregator.PayrollDetection
 	BlockchainData   *providers.BlockchainSummary
+	Income           *aggregator.IncomeTriangulation // Reconciled from every source reporting income
 }
 
 // NewEnhancedOracleService creates an enhanced oracle service
@@ -37,8 +48,10 @@ func NewEnhancedOracleService(
 	enhancedOffChainAgg *aggregator.EnhancedOffChainAggregator,
 	creditBureauProvider *providers.CreditBureauProvider,
 	plaidProvider *providers.PlaidProvider,
+	employmentProvider *providers.EmploymentProvider,
 	blockchainProvider *providers.BlockchainDataProvider,
-	useMockData bool,
+	bureauRepo *repository.BureauRepository,
+	mock providers.MockSettings,
 ) *EnhancedOracleService {
 	return &EnhancedOracleService{
 		baseService:          baseService,
@@ -46,16 +59,24 @@ func NewEnhancedOracleService(
 		enhancedOffChainAgg:  enhancedOffChainAgg,
 		creditBureauProvider: creditBureauProvider,
 		plaidProvider:        plaidProvider,
+		employmentProvider:   employmentProvider,
 		blockchainProvider:   blockchainProvider,
-		useMockData:          useMockData,
+		bureauRepo:           bureauRepo,
+		mock:                 mock,
 	}
 }
 
+// SetProviderHealth records provider status checks as health probes and adds each
+// provider's health history to the provider status
+func (s *EnhancedOracleService) SetProviderHealth(monitor *providerhealth.Monitor) {
+	s.providerHealth = monitor
+}
+
 // CalculateWithProviders calculates credit score using selected 3rd party providers
 func (s *EnhancedOracleService) CalculateWithProviders(
 	ctx context.Context,
-	address, bureauUserID, plaidUserID, plaidAccessToken string,
-	fetchCreditBureau, fetchPlaid, fetchBlockchain bool,
+	address, bureauUserID, plaidUserID, plaidAccessToken, employmentAccountID string,
+	fetchCreditBureau, fetchPlaid, fetchEmployment, fetchBlockchain bool,
 ) (*models.CreditScore, *ProviderData, error) {
 
 	logger.Info("Calculating credit score with providers",
@@ -64,9 +85,16 @@ func (s *EnhancedOracleService) CalculateWithProviders(
 		zap.String("plaidUserID", plaidUserID),
 		zap.Bool("creditBureau", fetchCreditBureau),
 		zap.Bool("plaid", fetchPlaid),
+		zap.Bool("employment", fetchEmployment),
 		zap.Bool("blockchain", fetchBlockchain),
 	)
 
+	// Frozen profiles get no new provider data
+	if err := s.baseService.checkNotFrozen(ctx, address); err != nil {
+		return nil, nil, err
+	}
+	timer := startPipelineTimer(address, models.ChangeReasonProviderRefresh)
+
 	providerData := &ProviderData{
 		Sources: []string{},
 	}
@@ -75,12 +103,20 @@ func (s *EnhancedOracleService) CalculateWithProviders(
 	var offChainMetrics *models.OffChainMetrics
 	var err error
 
+	// Provider responses to compare against each other; mock stand-ins for failed
+	// providers are left out
+	var comparedBureau *providers.CreditBureauResponse
+	var comparedPlaid *providers.PlaidAccountSummary
+
 	// Fetch on-chain data
 	if fetchBlockchain {
 		logger.Info("Fetching blockchain data via providers")
 		onChainMetrics, err = s.enhancedOnChainAgg.FetchMetrics(ctx, address)
 		if err != nil {
 			logger.Error("Failed to fetch enhanced on-chain metrics", zap.Error(err))
+			s.providerFailed(ctx, "blockchain", address, err)
+			timer.fail(models.StageFetchOnChain)
+			s.baseService.recordTiming(ctx, timer, nil)
 			return nil, nil, fmt.Errorf("failed to fetch blockchain data: %w", err)
 		}
 		providerData.Sources = append(providerData.Sources, "blockchain_provider")
@@ -100,6 +136,7 @@ func (s *EnhancedOracleService) CalculateWithProviders(
 		}
 		providerData.Sources = append(providerData.Sources, "ethereum_rpc")
 	}
+	timer.mark(models.StageFetchOnChain)
 
 	// Fetch off-chain data
 	if fetchCreditBureau || fetchPlaid {
@@ -115,35 +152,47 @@ func (s *EnhancedOracleService) CalculateWithProviders(
 			logger.Error("Failed to fetch enhanced off-chain metrics", zap.Error(err))
 		}
 
-		// Get detailed provider data (respects useMockData flag for off-chain APIs only)
+		// Get detailed provider data (respects the mock data settings of off-chain APIs)
 		if fetchCreditBureau && bureauUserID != "" {
-			if s.useMockData {
-				providerData.CreditBureauData = s.creditBureauProvider.MockCreditBureauData(bureauUserID)
-			} else {
-				providerData.CreditBureauData, err = s.creditBureauProvider.GetCreditReport(ctx, bureauUserID)
-				if err != nil {
-					logger.Warn("Failed to fetch credit bureau data for response, using mock", zap.Error(err))
-					providerData.CreditBureauData = s.creditBureauProvider.MockCreditBureauData(bureauUserID)
+			report, mocked, err := s.enhancedOffChainAgg.CreditReport(ctx, bureauUserID)
+			if err != nil {
+				s.providerFailed(ctx, s.creditBureauProvider.Name(), address, err)
+				logger.Warn("Failed to fetch credit bureau data for response", zap.Error(err), zap.Bool("mock", mocked))
+			}
+			providerData.CreditBureauData = report
+			if report != nil && !mocked {
+				comparedBureau = report
+			}
+			if providerData.CreditBureauData != nil {
+				providerData.Sources = append(providerData.Sources, "credit_bureau")
+			}
+
+			// Remember the consumer so bureau monitoring alerts can be routed to this address
+			if s.bureauRepo != nil {
+				consumerHash := providers.HashConsumerID(s.creditBureauProvider.Name(), bureauUserID)
+				if err := s.bureauRepo.LinkConsumer(ctx, s.creditBureauProvider.Name(), consumerHash, address); err != nil {
+					logger.Error("Failed to link bureau consumer", zap.Error(err))
 				}
 			}
-			providerData.Sources = append(providerData.Sources, "credit_bureau")
 		}
 
 		if fetchPlaid && plaidUserID != "" {
-			if s.useMockData {
-				providerData.PlaidData = s.plaidProvider.MockPlaidData(plaidUserID)
-			} else if plaidAccessToken != "" {
-				// In production, use the access token
-				providerData.PlaidData, err = s.plaidProvider.GetAccountSummary(ctx, plaidAccessToken)
-				if err != nil {
-					logger.Warn("Failed to fetch Plaid data for response, using mock", zap.Error(err))
-					providerData.PlaidData = s.plaidProvider.MockPlaidData(plaidUserID)
+			summary, mocked, err := s.enhancedOffChainAgg.BankData(ctx, plaidUserID, plaidAccessToken)
+			if err != nil {
+				s.providerFailed(ctx, "plaid", address, err)
+				logger.Warn("Failed to fetch Plaid data for response", zap.Error(err), zap.Bool("mock", mocked))
+			}
+			providerData.PlaidData = summary
+			if summary != nil && !mocked {
+				comparedPlaid = summary
+				if offChainMetrics != nil {
+					// Score the real account data, including temporary-deposit discounts
+					s.enhancedOffChainAgg.ApplyBankData(offChainMetrics, summary)
 				}
-			} else {
-				logger.Warn("No Plaid access token provided, using mock data")
-				providerData.PlaidData = s.plaidProvider.MockPlaidData(plaidUserID)
 			}
-			providerData.Sources = append(providerData.Sources, "plaid")
+			if providerData.PlaidData != nil {
+				providerData.Sources = append(providerData.Sources, "plaid")
+			}
 		}
 	} else {
 		// Use basic off-chain aggregation
@@ -161,6 +210,55 @@ func (s *EnhancedOracleService) CalculateWithProviders(
 		providerData.Sources = append(providerData.Sources, "basic_aggregation")
 	}
 
+	// Fetch payroll-verified employment; it overrides self-reported bureau employment data
+	if fetchEmployment && employmentAccountID != "" {
+		providerData.EmploymentData = s.fetchEmploymentVerification(ctx, address, employmentAccountID)
+		if providerData.EmploymentData != nil {
+			if offChainMetrics == nil {
+				offChainMetrics = &models.OffChainMetrics{UserAddress: address}
+			}
+			s.enhancedOffChainAgg.ApplyEmploymentVerification(offChainMetrics, providerData.EmploymentData)
+			providerData.Sources = append(providerData.Sources, "employment")
+		}
+	}
+	timer.mark(models.StageFetchOffChain)
+
+	// Stablecoin payroll is one more income source
+	if fetchBlockchain {
+		payroll, err := s.enhancedOnChainAgg.DetectPayrollIncome(ctx, address)
+		if err != nil {
+			logger.Warn("Failed to detect stablecoin payroll", zap.Error(err))
+		} else if payroll.AnnualizedIncome.Sign() > 0 {
+			providerData.PayrollData = payroll
+			providerData.Sources = append(providerData.Sources, models.IncomeSourceOnChainPayroll)
+		}
+		timer.mark(models.StageFetchOnChain)
+	}
+
+	// The incomes reported by each source are reconciled into the one figure the
+	// engine scores
+	if s.enhancedOffChainAgg != nil {
+		providerData.Income = s.enhancedOffChainAgg.TriangulateIncome(
+			providerData.CreditBureauData,
+			providerData.PlaidData,
+			providerData.EmploymentData,
+			providerData.PayrollData,
+		)
+	}
+	if providerData.Income != nil {
+		if offChainMetrics == nil {
+			offChainMetrics = &models.OffChainMetrics{UserAddress: address}
+		}
+		if s.enhancedOffChainAgg.ApplyIncome(offChainMetrics, providerData.Income) {
+			logger.Info("Triangulated income",
+				zap.String("address", address),
+				zap.String("source", providerData.Income.Source),
+				zap.Uint8("confidence", providerData.Income.Confidence.Uint8()),
+				zap.Int("sources", len(providerData.Income.Sources)),
+			)
+		}
+	}
+
 	// Save metrics
 	if onChainMetrics != nil {
 		onChainMetrics.UserAddress = address
@@ -175,67 +273,113 @@ func (s *EnhancedOracleService) CalculateWithProviders(
 			logger.Error("Failed to save off-chain metrics", zap.Error(err))
 		}
 	}
+	s.baseService.recordMetricsFetched(ctx, address, onChainMetrics, offChainMetrics)
+
+	// Fields reported by more than one provider are checked against each other;
+	// addresses whose providers keep disagreeing get lower confidence
+	if s.mock.Bureau == providers.MockAlways {
+		comparedBureau = providerData.CreditBureauData
+	}
+	if s.mock.Plaid == providers.MockAlways {
+		comparedPlaid = providerData.PlaidData
+	}
+	s.baseService.recordProviderComparisons(ctx, address, aggregator.CompareProviders(
+		comparedBureau,
+		comparedPlaid,
+		providerData.EmploymentData,
+	))
+	timer.mark(models.StagePersist)
 
 	// Calculate credit score
-	score, err := s.baseService.scoringEngine.CalculateScore(onChainMetrics, offChainMetrics)
+	score, err := s.baseService.calculateScore(ctx, address, onChainMetrics, offChainMetrics)
 	if err != nil {
+		timer.fail(models.StageScore)
+		s.baseService.recordTiming(ctx, timer, nil)
 		return nil, nil, fmt.Errorf("failed to calculate score: %w", err)
 	}
+	timer.mark(models.StageScore)
 
 	score.UserAddress = address
 
-	// Save score
-	existingScore, err := s.baseService.repo.GetByAddress(ctx, address)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to check existing score: %w", err)
-	}
-
-	if existingScore != nil {
-		score.ID = existingScore.ID
-		score.CreatedAt = existingScore.CreatedAt
-		score.UpdateCount = existingScore.UpdateCount + 1
-		if err := s.baseService.repo.Update(ctx, score); err != nil {
-			return nil, nil, fmt.Errorf("failed to update score: %w", err)
-		}
-	} else {
-		score.UpdateCount = 1
-		if err := s.baseService.repo.Create(ctx, score); err != nil {
-			return nil, nil, fmt.Errorf("failed to create score: %w", err)
-		}
-	}
-
-	// Save history
-	history := &models.ScoreHistory{
-		UserAddress: address,
-		Score:       score.Score,
-		Confidence:  score.Confidence,
-		DataHash:    score.DataHash,
-		Timestamp:   score.LastUpdated,
-	}
-	if err := s.baseService.repo.CreateHistory(ctx, history); err != nil {
-		logger.Error("Failed to save score history", zap.Error(err))
+	if err := s.baseService.saveScore(ctx, score, models.ChangeReasonProviderRefresh); err != nil {
+		timer.fail(models.StagePersist)
+		s.baseService.recordTiming(ctx, timer, nil)
+		return nil, nil, err
 	}
+	timer.mark(models.StagePersist)
+	s.baseService.recordTiming(ctx, timer, score)
 
 	logger.Info("Credit score calculated with providers",
 		zap.String("address", address),
-		zap.Uint16("score", score.Score),
+		zap.Uint16("score", score.Score.Uint16()),
 		zap.Strings("sources", providerData.Sources),
 	)
 
 	return score, providerData, nil
 }
 
+// fetchEmploymentVerification retrieves employment data, respecting its mock data mode
+func (s *EnhancedOracleService) fetchEmploymentVerification(ctx context.Context, address, accountID string) *providers.EmploymentVerification {
+	if s.employmentProvider == nil {
+		return nil
+	}
+
+	if s.mock.Employment == providers.MockAlways {
+		return s.employmentProvider.MockEmploymentData(accountID)
+	}
+
+	if !s.employmentProvider.IsConfigured() {
+		logger.Warn("Employment provider not configured, skipping verification")
+		return nil
+	}
+
+	employment, err := s.employmentProvider.GetEmploymentVerification(ctx, accountID)
+	if err != nil {
+		// Unlike bureau and Plaid data, never substitute mock employment: it would be
+		// scored as verified and carry more weight than self-reported data
+		logger.Warn("Failed to fetch employment verification", zap.Error(err))
+		s.providerFailed(ctx, s.employmentProvider.Name(), address, err)
+		return nil
+	}
+
+	return employment
+}
+
+// providerFailed emits a provider.failed event so the platform can track provider outages
+func (s *EnhancedOracleService) providerFailed(ctx context.Context, provider, address string, err error) {
+	s.baseService.emit(ctx, events.ProviderFailed, address, ProviderFailedEvent{
+		Provider: provider,
+		Address:  address,
+		Error:    err.Error(),
+	})
+}
+
 // PublishScoreToBlockchain publishes score to blockchain
 func (s *EnhancedOracleService) PublishScoreToBlockchain(ctx context.Context, address string) error {
 	return s.baseService.PublishScoreToBlockchain(ctx, address)
 }
 
+// ReserveUpdate enforces the minimum interval between requested updates of an address
+func (s *EnhancedOracleService) ReserveUpdate(ctx context.Context, address string) (time.Time, error) {
+	return s.baseService.ReserveUpdate(ctx, address)
+}
+
+// ReleaseUpdate gives back a reservation for an update that failed
+func (s *EnhancedOracleService) ReleaseUpdate(address string, reserved time.Time) {
+	s.baseService.ReleaseUpdate(address, reserved)
+}
+
+// RequestPublish publishes the score now or queues it for the publish window
+func (s *EnhancedOracleService) RequestPublish(ctx context.Context, address string, urgent bool) (bool, error) {
+	return s.baseService.RequestPublish(ctx, address, urgent)
+}
+
 // GetProviderStatus checks health of all providers
 func (s *EnhancedOracleService) GetProviderStatus(ctx context.Context) map[string]interface{} {
 	status := make(map[string]interface{})
 
 	// Check credit bureau
-	if err := s.creditBureauProvider.HealthCheck(ctx); err != nil {
+	if err := s.providerHealth.Probe(ctx, pause.CreditBureau, "", s.creditBureauProvider.HealthCheck); err != nil {
 		status["credit_bureau"] = map[string]interface{}{
 			"healthy": false,
 			"error":   err.Error(),
@@ -247,7 +391,7 @@ func (s *EnhancedOracleService) GetProviderStatus(ctx context.Context) map[strin
 	}
 
 	// Check Plaid
-	if err := s.plaidProvider.HealthCheck(ctx); err != nil {
+	if err := s.providerHealth.Probe(ctx, pause.Plaid, "", s.plaidProvider.HealthCheck); err != nil {
 		status["plaid"] = map[string]interface{}{
 			"healthy": false,
 			"error":   err.Error(),
@@ -258,8 +402,22 @@ func (s *EnhancedOracleService) GetProviderStatus(ctx context.Context) map[strin
 		}
 	}
 
+	// Check employment provider
+	if s.employmentProvider != nil && s.employmentProvider.IsConfigured() {
+		if err := s.providerHealth.Probe(ctx, pause.Employment, "", s.employmentProvider.HealthCheck); err != nil {
+			status["employment"] = map[string]interface{}{
+				"healthy": false,
+				"error":   err.Error(),
+			}
+		} else {
+			status["employment"] = map[string]interface{}{
+				"healthy": true,
+			}
+		}
+	}
+
 	// Check blockchain provider
-	if err := s.blockchainProvider.HealthCheck(ctx); err != nil {
+	if err := s.providerHealth.Probe(ctx, pause.BlockchainData, "", s.blockchainProvider.HealthCheck); err != nil {
 		status["blockchain_provider"] = map[string]interface{}{
 			"healthy": false,
 			"error":   err.Error(),
@@ -294,6 +452,16 @@ func (s *EnhancedOracleService) GetProviderStatus(ctx context.Context) map[strin
 		}
 	}
 
+	// Rolling success rates, latency and uptime per provider and chain
+	if s.providerHealth != nil {
+		history, err := s.providerHealth.History(ctx)
+		if err != nil {
+			logger.Error("Failed to get provider health history", zap.Error(err))
+		} else {
+			status["history"] = history
+		}
+	}
+
 	return status
 }
 
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Job run limits
+const (
+	maxJobErrorSamples = 5
+	DefaultJobRunLimit = 50
+	MaxJobRunLimit     = 500
+)
+
+// JobRecorder records the runs of scheduled jobs, so operators can tell whether a job
+// ran and completed. A nil recorder records nothing.
+type JobRecorder struct {
+	repo *repository.JobRunRepository
+}
+
+// NewJobRecorder creates a recorder of job runs
+func NewJobRecorder(repo *repository.JobRunRepository) *JobRecorder {
+	return &JobRecorder{repo: repo}
+}
+
+// JobRunDetail is a job run with its error samples decoded
+type JobRunDetail struct {
+	*models.JobRun
+	ErrorSamples []string `json:"error_samples"`
+}
+
+// JobRunsReport is the latest run of every job and the recent runs
+type JobRunsReport struct {
+	Latest []*JobRunDetail `json:"latest"` // By job name
+	Runs   []*JobRunDetail `json:"runs"`   // Newest first
+}
+
+// jobRun tracks a run in progress. Recording failures are logged rather than failing
+// the job.
+type jobRun struct {
+	repo    *repository.JobRunRepository // nil records nothing
+	run     *models.JobRun
+	samples []string
+}
+
+// start records that a job has started
+func (r *JobRecorder) start(ctx context.Context, job string) *jobRun {
+	tracked := &jobRun{run: &models.JobRun{Job: job, Status: models.JobRunRunning, StartedAt: time.Now()}}
+	if r == nil {
+		return tracked
+	}
+	tracked.repo = r.repo
+	if err := r.repo.CreateJobRun(ctx, tracked.run); err != nil {
+		logger.Error("Failed to record job run", zap.String("job", job), zap.Error(err))
+		tracked.repo = nil
+	}
+	return tracked
+}
+
+// succeeded counts an item the run processed successfully
+func (j *jobRun) succeeded() {
+	j.run.Processed++
+	j.run.Succeeded++
+}
+
+// failed counts an item the run failed to process, keeping the first errors as samples
+func (j *jobRun) failed(item string, err error) {
+	j.run.Processed++
+	j.run.Failed++
+	if len(j.samples) < maxJobErrorSamples {
+		sample := err.Error()
+		if item != "" {
+			sample = item + ": " + sample
+		}
+		j.samples = append(j.samples, sample)
+	}
+}
+
+// skip records that the run had nothing to do, and why
+func (j *jobRun) skip(ctx context.Context, reason string) {
+	j.run.Error = reason
+	j.save(ctx, models.JobRunSkipped)
+}
+
+// finish records the run's outcome. An error means the run stopped before processing
+// every item.
+func (j *jobRun) finish(ctx context.Context, err error) {
+	status := models.JobRunSucceeded
+	switch {
+	case err != nil:
+		status = models.JobRunFailed
+		j.run.Error = err.Error()
+	case j.run.Failed > 0:
+		status = models.JobRunPartial
+	}
+	j.save(ctx, status)
+}
+
+func (j *jobRun) save(ctx context.Context, status string) {
+	finished := time.Now()
+	j.run.Status = status
+	j.run.FinishedAt = &finished
+	j.run.DurationMs = finished.Sub(j.run.StartedAt).Milliseconds()
+	if len(j.samples) > 0 {
+		encoded, _ := json.Marshal(j.samples)
+		j.run.ErrorSamples = string(encoded)
+	}
+
+	if j.repo == nil {
+		return
+	}
+	if err := j.repo.SaveJobRun(ctx, j.run); err != nil {
+		logger.Error("Failed to save job run", zap.String("job", j.run.Job), zap.Error(err))
+	}
+}
+
+// Report returns the latest run of every job and up to limit recent runs, optionally
+// of one job
+func (r *JobRecorder) Report(ctx context.Context, job string, limit int) (*JobRunsReport, error) {
+	if limit <= 0 {
+		limit = DefaultJobRunLimit
+	}
+	if limit > MaxJobRunLimit {
+		limit = MaxJobRunLimit
+	}
+
+	latest, err := r.repo.LatestJobRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	runs, err := r.repo.ListJobRuns(ctx, job, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &JobRunsReport{Latest: jobRunDetails(latest), Runs: jobRunDetails(runs)}, nil
+}
+
+func jobRunDetails(runs []*models.JobRun) []*JobRunDetail {
+	details := make([]*JobRunDetail, 0, len(runs))
+	for _, run := range runs {
+		detail := &JobRunDetail{JobRun: run, ErrorSamples: []string{}}
+		if run.ErrorSamples != "" {
+			if err := json.Unmarshal([]byte(run.ErrorSamples), &detail.ErrorSamples); err != nil {
+				logger.Warn("Invalid job run error samples", zap.Uint("runID", run.ID), zap.Error(err))
+			}
+		}
+		details = append(details, detail)
+	}
+	return details
+}
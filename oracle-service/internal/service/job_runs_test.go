@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/pause"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+)
+
+func TestJobRunsRecordScheduledUpdates(t *testing.T) {
+	service, db := setupTestService(t)
+	ctx := context.Background()
+	jobs := NewJobRecorder(repository.NewJobRunRepository(db))
+	service.SetJobRecorder(jobs)
+	subsystems := NewSubsystemService(repository.NewSubsystemRepository(db))
+	service.SetSubsystems(subsystems)
+
+	db.Create(&models.CreditScore{
+		UserAddress:   "0x1111",
+		Score:         700,
+		Confidence:    80,
+		DataHash:      "hash",
+		LastUpdated:   time.Now().Add(-31 * 24 * time.Hour),
+		NextUpdateDue: time.Now().Add(-24 * time.Hour),
+		UpdateCount:   1,
+		IsActive:      true,
+	})
+
+	subsystems.Pause(ctx, pause.Scheduler, "maintenance")
+	if err := service.ProcessScheduledUpdates(ctx, 10); err != nil {
+		t.Fatalf("Failed to process scheduled updates: %v", err)
+	}
+	subsystems.Resume(ctx, pause.Scheduler)
+	if err := service.ProcessScheduledUpdates(ctx, 10); err != nil {
+		t.Fatalf("Failed to process scheduled updates: %v", err)
+	}
+
+	report, err := jobs.Report(ctx, models.JobScheduledUpdates, 0)
+	if err != nil {
+		t.Fatalf("Failed to get job runs: %v", err)
+	}
+	if len(report.Runs) != 2 {
+		t.Fatalf("Expected 2 runs, got %d", len(report.Runs))
+	}
+	latest, skipped := report.Runs[0], report.Runs[1]
+	if skipped.Status != models.JobRunSkipped || skipped.Error != "scheduler paused" {
+		t.Errorf("Expected the paused run skipped, got %s (%s)", skipped.Status, skipped.Error)
+	}
+	if latest.Status != models.JobRunSucceeded || latest.Processed != 1 || latest.Succeeded != 1 || latest.FinishedAt == nil {
+		t.Errorf("Expected the resumed run to succeed on one score, got %+v", latest.JobRun)
+	}
+	if len(report.Latest) != 1 || report.Latest[0].ID != latest.ID {
+		t.Errorf("Expected the resumed run as the job's latest, got %+v", report.Latest)
+	}
+}
+
+func TestJobRunOutcome(t *testing.T) {
+	_, db := setupTestService(t)
+	ctx := context.Background()
+	jobs := NewJobRecorder(repository.NewJobRunRepository(db))
+
+	run := jobs.start(ctx, models.JobHealthMonitor)
+	run.succeeded()
+	for i := 0; i < maxJobErrorSamples+2; i++ {
+		run.failed(fmt.Sprintf("0x%04d", i), fmt.Errorf("provider timeout"))
+	}
+	run.finish(ctx, nil)
+
+	stopped := jobs.start(ctx, models.JobStatsRefresh)
+	stopped.finish(ctx, fmt.Errorf("database unavailable"))
+
+	report, err := jobs.Report(ctx, "", 0)
+	if err != nil {
+		t.Fatalf("Failed to get job runs: %v", err)
+	}
+	if len(report.Latest) != 2 || report.Latest[0].Job != models.JobHealthMonitor || report.Latest[1].Job != models.JobStatsRefresh {
+		t.Fatalf("Expected the latest run of both jobs by name, got %+v", report.Latest)
+	}
+
+	partial := report.Latest[0]
+	if partial.Status != models.JobRunPartial || partial.Processed != maxJobErrorSamples+3 || partial.Failed != maxJobErrorSamples+2 {
+		t.Errorf("Expected a partial run, got %+v", partial.JobRun)
+	}
+	if len(partial.ErrorSamples) != maxJobErrorSamples || partial.ErrorSamples[0] != "0x0000: provider timeout" {
+		t.Errorf("Expected the first %d errors sampled, got %v", maxJobErrorSamples, partial.ErrorSamples)
+	}
+
+	failed := report.Latest[1]
+	if failed.Status != models.JobRunFailed || failed.Error != "database unavailable" || len(failed.ErrorSamples) != 0 {
+		t.Errorf("Expected a failed run with its error, got %+v", failed)
+	}
+
+	// Without a recorder, jobs run unrecorded
+	var unrecorded *JobRecorder
+	untracked := unrecorded.start(ctx, models.JobRetention)
+	untracked.failed("", fmt.Errorf("ignored"))
+	untracked.finish(ctx, nil)
+}
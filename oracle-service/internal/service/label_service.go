@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/labels"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// LabelService manages persisted address labels and keeps the in-memory registry in sync
+type LabelService struct {
+	repo     *repository.LabelRepository
+	registry *labels.Registry
+}
+
+// NewLabelService creates a new label service
+func NewLabelService(repo *repository.LabelRepository, registry *labels.Registry) *LabelService {
+	return &LabelService{
+		repo:     repo,
+		registry: registry,
+	}
+}
+
+// Initialize seeds built-in and file labels that are not yet stored, then loads every
+// stored label into the registry
+func (s *LabelService) Initialize(ctx context.Context, fileLabels []models.AddressLabel) error {
+	seeds := append(labels.DefaultLabels(), fileLabels...)
+
+	created, err := s.repo.CreateIfMissing(ctx, seeds)
+	if err != nil {
+		return fmt.Errorf("failed to seed address labels: %w", err)
+	}
+
+	if err := s.Reload(ctx); err != nil {
+		return err
+	}
+
+	logger.Info("Address label registry initialized",
+		zap.Int("seeded", created),
+		zap.Int("total", s.registry.Len()),
+	)
+
+	return nil
+}
+
+// Reload replaces the registry contents with the stored labels
+func (s *LabelService) Reload(ctx context.Context) error {
+	stored, err := s.repo.List(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	all := make([]models.AddressLabel, 0, len(stored))
+	for _, label := range stored {
+		all = append(all, *label)
+	}
+	s.registry.Replace(all)
+
+	return nil
+}
+
+// ListLabels returns stored labels, optionally filtered by category
+func (s *LabelService) ListLabels(ctx context.Context, category string) ([]*models.AddressLabel, error) {
+	if category != "" && !labels.IsValidCategory(category) {
+		return nil, fmt.Errorf("%w: unknown category %q", labels.ErrInvalidLabel, category)
+	}
+	return s.repo.List(ctx, category)
+}
+
+// GetLabel returns the stored label for an address
+func (s *LabelService) GetLabel(ctx context.Context, address string) (*models.AddressLabel, error) {
+	return s.repo.GetByAddress(ctx, labels.NormalizeAddress(address))
+}
+
+// UpsertLabel validates and stores an admin-managed label
+func (s *LabelService) UpsertLabel(ctx context.Context, label *models.AddressLabel) error {
+	if err := labels.Validate(label); err != nil {
+		return err
+	}
+	label.Source = models.LabelSourceAdmin
+
+	if err := s.repo.Upsert(ctx, label); err != nil {
+		return fmt.Errorf("failed to save address label: %w", err)
+	}
+	s.registry.Set(*label)
+
+	logger.Info("Address label saved",
+		zap.String("address", label.Address),
+		zap.String("category", label.Category),
+	)
+
+	return nil
+}
+
+// DeleteLabel removes a label. It reports false if no label existed.
+func (s *LabelService) DeleteLabel(ctx context.Context, address string) (bool, error) {
+	address = labels.NormalizeAddress(address)
+
+	deleted, err := s.repo.Delete(ctx, address)
+	if err != nil {
+		return false, err
+	}
+	s.registry.Remove(address)
+
+	if deleted {
+		logger.Info("Address label deleted", zap.String("address", address))
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Liquidation fast path settings
+const (
+	DefaultLiquidationSLA    = 5 * time.Minute
+	liquidationQueueSize     = 256
+	liquidationSweepInterval = 30 * time.Second // Pending rescores the queue missed are picked up this often
+	liquidationSweepBatch    = 50
+)
+
+var ErrLiquidationFastPathDisabled = errors.NotFound("liquidation fast path is disabled")
+
+// LiquidationReport is a liquidation detected for a scored address
+type LiquidationReport struct {
+	Address    string
+	Protocol   string
+	TxHash     string
+	Source     string
+	DetectedAt time.Time // Zero for now
+}
+
+// LiquidationSLAReport summarizes the fast path's detection-to-publish latency over
+// a window
+type LiquidationSLAReport struct {
+	Since        time.Time                    `json:"since"`
+	SLASeconds   int64                        `json:"sla_seconds"`
+	Detected     int                          `json:"detected"`
+	Published    int                          `json:"published"`
+	Pending      int                          `json:"pending"`
+	Skipped      int                          `json:"skipped"`
+	Failed       int                          `json:"failed"`
+	WithinSLA    int                          `json:"within_sla"`
+	Breaches     int                          `json:"breaches"` // Published late, or still pending past the SLA
+	P50LatencyMs int64                        `json:"p50_latency_ms"`
+	P95LatencyMs int64                        `json:"p95_latency_ms"`
+	MaxLatencyMs int64                        `json:"max_latency_ms"`
+	Rescores     []*models.LiquidationRescore `json:"rescores"` // Newest first
+}
+
+// liquidationFastPath recalculates and publishes the scores of liquidated addresses
+// ahead of their scheduled refresh
+type liquidationFastPath struct {
+	sla   time.Duration
+	queue chan uint // IDs of pending rescores
+}
+
+// SetLiquidationFastPath rescores and publishes liquidated addresses as soon as the
+// liquidation is detected, aiming to publish within sla. The caller runs the fast
+// path with RunLiquidationFastPath.
+func (s *OracleService) SetLiquidationFastPath(sla time.Duration) {
+	if sla <= 0 {
+		sla = DefaultLiquidationSLA
+	}
+	s.liquidations = &liquidationFastPath{
+		sla:   sla,
+		queue: make(chan uint, liquidationQueueSize),
+	}
+}
+
+// ReportLiquidation hands a detected liquidation to the fast path, which recalculates
+// the address's score from the providers and publishes it outside the scheduler and
+// the publish window. An address with a rescore already waiting gets that rescore
+// back rather than a second one.
+func (s *OracleService) ReportLiquidation(ctx context.Context, report LiquidationReport) (*models.LiquidationRescore, error) {
+	return s.reportLiquidation(ctx, report, nil)
+}
+
+// reportLiquidation records a liquidation for the fast path. rescoredAt is set when the
+// score was already recalculated with the liquidation, which leaves only publishing.
+func (s *OracleService) reportLiquidation(ctx context.Context, report LiquidationReport, rescoredAt *time.Time) (*models.LiquidationRescore, error) {
+	fastPath := s.liquidations
+	if fastPath == nil {
+		return nil, ErrLiquidationFastPathDisabled
+	}
+
+	score, err := s.repo.GetByAddress(ctx, report.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score: %w", err)
+	}
+	if score == nil {
+		return nil, fmt.Errorf("%w for address %s", ErrScoreNotFound, report.Address)
+	}
+
+	pending, err := s.repo.GetPendingLiquidationRescore(ctx, report.Address)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return pending, nil
+	}
+
+	detectedAt := report.DetectedAt
+	if detectedAt.IsZero() {
+		detectedAt = time.Now()
+	}
+	rescore := &models.LiquidationRescore{
+		UserAddress: report.Address,
+		Protocol:    report.Protocol,
+		TxHash:      report.TxHash,
+		Source:      report.Source,
+		Status:      models.LiquidationRescorePending,
+		DetectedAt:  detectedAt,
+		RescoredAt:  rescoredAt,
+	}
+	if err := s.repo.CreateLiquidationRescore(ctx, rescore); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Liquidation detected, queued for fast-path rescore",
+		zap.String("address", rescore.UserAddress),
+		zap.String("protocol", rescore.Protocol),
+		zap.String("source", rescore.Source),
+		zap.Uint("rescoreID", rescore.ID),
+	)
+
+	// A full queue leaves the rescore for the next sweep
+	select {
+	case fastPath.queue <- rescore.ID:
+	default:
+		logger.Warn("Liquidation fast-path queue full, rescore left for the next sweep", zap.Uint("rescoreID", rescore.ID))
+	}
+
+	return rescore, nil
+}
+
+// RunLiquidationFastPath processes reported liquidations as they arrive, and sweeps for
+// pending ones the queue missed, until the context is cancelled. Rescores are processed
+// one at a time.
+func (s *OracleService) RunLiquidationFastPath(ctx context.Context) {
+	fastPath := s.liquidations
+	if fastPath == nil {
+		return
+	}
+	ticker := time.NewTicker(liquidationSweepInterval)
+	defer ticker.Stop()
+
+	// Rescores left pending by a restart go first
+	s.sweepLiquidations(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case id := <-fastPath.queue:
+			rescore, err := s.repo.GetLiquidationRescore(ctx, id)
+			if err != nil {
+				logger.Error("Failed to load liquidation rescore", zap.Uint("rescoreID", id), zap.Error(err))
+				continue
+			}
+			if rescore != nil && rescore.Status == models.LiquidationRescorePending {
+				s.processLiquidation(ctx, rescore)
+			}
+		case <-ticker.C:
+			s.sweepLiquidations(ctx)
+		}
+	}
+}
+
+// sweepLiquidations processes pending rescores, oldest detection first
+func (s *OracleService) sweepLiquidations(ctx context.Context) {
+	pending, err := s.repo.ListPendingLiquidationRescores(ctx, liquidationSweepBatch)
+	if err != nil {
+		logger.Error("Failed to list pending liquidation rescores", zap.Error(err))
+		return
+	}
+	for _, rescore := range pending {
+		s.processLiquidation(ctx, rescore)
+	}
+}
+
+// processLiquidation recalculates a liquidated address's score from the providers,
+// unless it was recalculated with the liquidation already, and publishes it at once.
+// A rescore stays pending while publishing is paused.
+func (s *OracleService) processLiquidation(ctx context.Context, rescore *models.LiquidationRescore) {
+	finish := func(status string, err error) {
+		rescore.Status = status
+		if err != nil {
+			rescore.ErrorMessage = err.Error()
+		}
+		if err := s.repo.UpdateLiquidationRescore(ctx, rescore); err != nil {
+			logger.Error("Failed to save liquidation rescore", zap.Uint("rescoreID", rescore.ID), zap.Error(err))
+		}
+	}
+
+	if rescore.RescoredAt == nil {
+		_, err := s.calculateAndUpdateScore(ctx, rescore.UserAddress, "", models.ChangeReasonLiquidation)
+		if errors.Is(err, ErrProfileFrozen) {
+			finish(models.LiquidationRescoreSkipped, err)
+			return
+		}
+		if err != nil {
+			logger.Error("Failed to rescore liquidated address", zap.String("address", rescore.UserAddress), zap.Error(err))
+			finish(models.LiquidationRescoreFailed, err)
+			return
+		}
+		now := time.Now()
+		rescore.RescoredAt = &now
+	}
+
+	err := s.PublishScoreToBlockchain(ctx, rescore.UserAddress)
+	switch {
+	case errors.Is(err, ErrPublishingPaused):
+		finish(models.LiquidationRescorePending, nil)
+		return
+	case errors.Is(err, ErrProfileFrozen) || errors.Is(err, ErrScoreProvisional):
+		finish(models.LiquidationRescoreSkipped, err)
+		return
+	case err != nil:
+		logger.Error("Failed to publish liquidated address's score", zap.String("address", rescore.UserAddress), zap.Error(err))
+		finish(models.LiquidationRescoreFailed, err)
+		return
+	}
+
+	now := time.Now()
+	latency := now.Sub(rescore.DetectedAt)
+	rescore.PublishedAt = &now
+	rescore.LatencyMs = latency.Milliseconds()
+	finish(models.LiquidationRescorePublished, nil)
+
+	fields := []zap.Field{
+		zap.String("address", rescore.UserAddress),
+		zap.Duration("latency", latency),
+		zap.Duration("sla", s.liquidations.sla),
+	}
+	if latency > s.liquidations.sla {
+		logger.Warn("Liquidation rescore published after its SLA", fields...)
+	} else {
+		logger.Info("Liquidation rescore published", fields...)
+	}
+}
+
+// storedLiquidations is the liquidation count of the address's stored on-chain
+// metrics, or -1 without the fast path or stored metrics
+func (s *OracleService) storedLiquidations(ctx context.Context, address string) int64 {
+	if s.liquidations == nil {
+		return -1
+	}
+	metrics, err := s.repo.GetOnChainMetrics(ctx, address)
+	if err != nil || metrics == nil {
+		return -1
+	}
+	return int64(metrics.LiquidationEvents)
+}
+
+// noteProviderLiquidations hands a score whose fresh on-chain metrics show new
+// liquidations to the fast path for publishing. The score was just recalculated with
+// them, and scheduled refreshes publish straight after recalculating.
+func (s *OracleService) noteProviderLiquidations(ctx context.Context, score *models.CreditScore, before int64, metrics *models.OnChainMetrics, reason string) {
+	if before < 0 || int64(metrics.LiquidationEvents) <= before {
+		return
+	}
+	if reason == models.ChangeReasonLiquidation || reason == models.ChangeReasonScheduled {
+		return
+	}
+
+	rescoredAt := score.LastUpdated
+	report := LiquidationReport{
+		Address: score.UserAddress,
+		Source:  models.LiquidationSourceProvider,
+	}
+	if _, err := s.reportLiquidation(ctx, report, &rescoredAt); err != nil {
+		logger.Error("Failed to report liquidation", zap.String("address", score.UserAddress), zap.Error(err))
+	}
+}
+
+// LiquidationSLA reports the fast path's rescores detected within the window and how
+// many were published within the SLA
+func (s *OracleService) LiquidationSLA(ctx context.Context, window time.Duration) (*LiquidationSLAReport, error) {
+	fastPath := s.liquidations
+	if fastPath == nil {
+		return nil, ErrLiquidationFastPathDisabled
+	}
+
+	now := time.Now()
+	since := now.Add(-window)
+	rescores, err := s.repo.ListLiquidationRescores(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &LiquidationSLAReport{
+		Since:      since,
+		SLASeconds: int64(fastPath.sla / time.Second),
+		Detected:   len(rescores),
+		Rescores:   rescores,
+	}
+	var latencies []int64
+	for _, rescore := range rescores {
+		switch rescore.Status {
+		case models.LiquidationRescorePublished:
+			report.Published++
+			latencies = append(latencies, rescore.LatencyMs)
+			if time.Duration(rescore.LatencyMs)*time.Millisecond > fastPath.sla {
+				report.Breaches++
+			} else {
+				report.WithinSLA++
+			}
+		case models.LiquidationRescorePending:
+			report.Pending++
+			if now.Sub(rescore.DetectedAt) > fastPath.sla {
+				report.Breaches++
+			}
+		case models.LiquidationRescoreSkipped:
+			report.Skipped++
+		default:
+			report.Failed++
+		}
+	}
+
+	if len(latencies) > 0 {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		report.P50LatencyMs = nearestRank(latencies, 0.50)
+		report.P95LatencyMs = nearestRank(latencies, 0.95)
+		report.MaxLatencyMs = latencies[len(latencies)-1]
+	}
+	return report, nil
+}
+
+// nearestRank returns the nearest-rank percentile p (0-1) of sorted values
+func nearestRank(sorted []int64, p float64) int64 {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/blockchain"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+type mockLiquidatingAggregator struct {
+	mockOnChainAggregator
+	liquidations uint32
+}
+
+func (m *mockLiquidatingAggregator) FetchMetrics(ctx context.Context, address string) (*models.OnChainMetrics, error) {
+	metrics, err := m.mockOnChainAggregator.FetchMetrics(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	metrics.LiquidationEvents = m.liquidations
+	return metrics, nil
+}
+
+type mockPositionSource struct {
+	account blockchain.AccountHealth
+}
+
+func (m *mockPositionSource) AccountHealth(ctx context.Context, address string) (*blockchain.AccountHealth, error) {
+	account := m.account
+	return &account, nil
+}
+
+func TestLiquidationFastPath(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+	address := "0x1234567890123456789012345678901234567890"
+
+	if _, err := service.ReportLiquidation(ctx, LiquidationReport{Address: address}); !errors.Is(err, ErrLiquidationFastPathDisabled) {
+		t.Fatalf("Expected the fast path disabled, got %v", err)
+	}
+
+	service.SetLiquidationFastPath(time.Minute)
+	if _, err := service.ReportLiquidation(ctx, LiquidationReport{Address: address}); !errors.Is(err, ErrScoreNotFound) {
+		t.Fatalf("Expected an unscored address rejected, got %v", err)
+	}
+	if _, err := service.CalculateAndUpdateScore(ctx, address, ""); err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	report := LiquidationReport{
+		Address:  address,
+		Protocol: "aave",
+		TxHash:   "0xliquidation",
+		Source:   models.LiquidationSourceReported,
+	}
+	rescore, err := service.ReportLiquidation(ctx, report)
+	if err != nil {
+		t.Fatalf("Failed to report liquidation: %v", err)
+	}
+	again, err := service.ReportLiquidation(ctx, report)
+	if err != nil || again.ID != rescore.ID {
+		t.Fatalf("Expected the pending rescore back, got %+v, %v", again, err)
+	}
+
+	service.sweepLiquidations(ctx)
+
+	published, err := service.repo.GetLiquidationRescore(ctx, rescore.ID)
+	if err != nil || published == nil {
+		t.Fatalf("Failed to get rescore: %v", err)
+	}
+	if published.Status != models.LiquidationRescorePublished || published.RescoredAt == nil || published.PublishedAt == nil {
+		t.Fatalf("Expected the rescore published, got %+v", published)
+	}
+	history, err := service.GetScoreHistory(ctx, address, 10)
+	if err != nil || len(history) == 0 || history[0].ChangeReason != models.ChangeReasonLiquidation {
+		t.Fatalf("Expected the latest change recorded as a liquidation, got %+v, %v", history, err)
+	}
+
+	// A liquidation detected long before it reached the oracle breaches the SLA
+	late := report
+	late.DetectedAt = time.Now().Add(-2 * time.Minute)
+	if _, err := service.ReportLiquidation(ctx, late); err != nil {
+		t.Fatalf("Failed to report liquidation: %v", err)
+	}
+	service.sweepLiquidations(ctx)
+
+	sla, err := service.LiquidationSLA(ctx, time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to get SLA: %v", err)
+	}
+	if sla.Detected != 2 || sla.Published != 2 || sla.WithinSLA != 1 || sla.Breaches != 1 {
+		t.Errorf("Expected one of two rescores within the SLA, got %+v", sla)
+	}
+	if sla.MaxLatencyMs < (2*time.Minute).Milliseconds() || sla.P50LatencyMs >= sla.MaxLatencyMs {
+		t.Errorf("Expected the late rescore as the max latency, got p50 %d and max %d", sla.P50LatencyMs, sla.MaxLatencyMs)
+	}
+}
+
+func TestLiquidationDetection(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+	address := "0x1234567890123456789012345678901234567890"
+	onChain := &mockLiquidatingAggregator{}
+	service.onChainAgg = onChain
+	service.SetLiquidationFastPath(0)
+
+	if _, err := service.CalculateAndUpdateScore(ctx, address, ""); err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	// A position at risk that loses both debt and collateral was liquidated
+	source := &mockPositionSource{account: blockchain.AccountHealth{
+		Protocol: "aave", CollateralUSD: 10000, DebtUSD: 9500, HealthFactor: 1.02,
+	}}
+	service.SetHealthMonitor(source, 1.1)
+	if _, err := service.CheckPositionHealth(ctx, address); err != nil {
+		t.Fatalf("Failed to check position health: %v", err)
+	}
+	if pending, _ := service.repo.GetPendingLiquidationRescore(ctx, address); pending != nil {
+		t.Fatalf("Expected no liquidation for a position turning risky, got %+v", pending)
+	}
+
+	source.account.CollateralUSD, source.account.DebtUSD, source.account.HealthFactor = 4000, 2000, 1.9
+	if _, err := service.CheckPositionHealth(ctx, address); err != nil {
+		t.Fatalf("Failed to check position health: %v", err)
+	}
+	pending, err := service.repo.GetPendingLiquidationRescore(ctx, address)
+	if err != nil || pending == nil || pending.Source != models.LiquidationSourceHealthMonitor || pending.RescoredAt != nil {
+		t.Fatalf("Expected a liquidation from the health monitor, got %+v, %v", pending, err)
+	}
+	service.sweepLiquidations(ctx)
+
+	// A provider reporting a new liquidation leaves only the publish
+	onChain.liquidations = 1
+	if _, err := service.CalculateAndUpdateScore(ctx, address, ""); err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+	pending, err = service.repo.GetPendingLiquidationRescore(ctx, address)
+	if err != nil || pending == nil || pending.Source != models.LiquidationSourceProvider || pending.RescoredAt == nil {
+		t.Fatalf("Expected a rescored liquidation from the provider, got %+v, %v", pending, err)
+	}
+
+	// The same count on the next refresh is no new liquidation
+	service.sweepLiquidations(ctx)
+	if _, err := service.CalculateAndUpdateScore(ctx, address, ""); err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+	if pending, _ := service.repo.GetPendingLiquidationRescore(ctx, address); pending != nil {
+		t.Errorf("Expected no repeat liquidation, got %+v", pending)
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// maintenanceState is the name maintenance mode is stored under with the subsystem
+// states
+const maintenanceState = "maintenance"
+
+// ErrMaintenanceConfigured is returned when maintenance mode set by configuration is
+// switched off at runtime
+var ErrMaintenanceConfigured = errors.Validation("maintenance mode was enabled by MAINTENANCE_MODE and ends only with a restart without it")
+
+// MaintenanceStatus reports whether the API is read-only for maintenance
+type MaintenanceStatus struct {
+	Enabled    bool       `json:"enabled"`
+	Reason     string     `json:"reason,omitempty"`
+	Configured bool       `json:"configured"`      // Enabled by MAINTENANCE_MODE rather than by an admin
+	Since      *time.Time `json:"since,omitempty"` // When an admin enabled it
+}
+
+// ForceMaintenance keeps maintenance mode on for the life of the process, whatever
+// admins set
+func (s *SubsystemService) ForceMaintenance() {
+	s.maintenanceForced = true
+}
+
+// SetMaintenance switches maintenance mode on or off for every instance
+func (s *SubsystemService) SetMaintenance(ctx context.Context, enabled bool, reason string) (*MaintenanceStatus, error) {
+	if !enabled && s.maintenanceForced {
+		return nil, ErrMaintenanceConfigured
+	}
+	if !enabled {
+		reason = ""
+	}
+
+	state := &models.SubsystemState{
+		Name:      maintenanceState,
+		Paused:    enabled,
+		Reason:    strings.TrimSpace(reason),
+		UpdatedAt: s.now().UTC(),
+	}
+	if err := s.repo.SaveState(ctx, state); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	if enabled {
+		logger.Warn("Maintenance mode enabled", zap.String("reason", state.Reason))
+	} else {
+		logger.Info("Maintenance mode disabled")
+	}
+	status := s.maintenanceStatus(state)
+	return &status, nil
+}
+
+// Maintenance reports whether the API is in maintenance mode, from the cached states
+func (s *SubsystemService) Maintenance(ctx context.Context) MaintenanceStatus {
+	return s.maintenanceStatus(s.cachedStates(ctx)[maintenanceState])
+}
+
+func (s *SubsystemService) maintenanceStatus(state *models.SubsystemState) MaintenanceStatus {
+	status := MaintenanceStatus{Configured: s.maintenanceForced}
+	if state != nil && state.Paused {
+		status.Enabled = true
+		status.Reason = state.Reason
+		since := state.UpdatedAt
+		status.Since = &since
+	}
+	status.Enabled = status.Enabled || s.maintenanceForced
+	return status
+}
+
+// InMaintenance reports whether the API is in maintenance mode
+func (s *OracleService) InMaintenance(ctx context.Context) bool {
+	return s.subsystems != nil && s.subsystems.Maintenance(ctx).Enabled
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/blockchain"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+// IdentityReporter is implemented by blockchain clients that can describe their
+// signer and contract as seen on chain
+type IdentityReporter interface {
+	Identity(ctx context.Context) *blockchain.Identity
+}
+
+// ContractIdentity is the signer and on-chain state of one contract scores are
+// published to
+type ContractIdentity struct {
+	Environment string `json:"environment,omitempty"` // Set for non-production environments
+	Target      string `json:"target"`                // primary or canary
+	*blockchain.Identity
+}
+
+// OracleIdentity describes the primary contract and, while canary publishing is on,
+// the canary contract. Clients that can't report an identity, such as when
+// publishing is not configured, are left out.
+func (s *OracleService) OracleIdentity(ctx context.Context) []*ContractIdentity {
+	identities := []*ContractIdentity{}
+
+	add := func(target string, client BlockchainClient) {
+		reporter, ok := client.(IdentityReporter)
+		if !ok {
+			return
+		}
+		identities = append(identities, &ContractIdentity{
+			Target:   target,
+			Identity: reporter.Identity(ctx),
+		})
+	}
+
+	add(models.PublishTargetPrimary, s.blockchainClient)
+	if s.canary != nil {
+		add(models.PublishTargetCanary, s.canary.client)
+	}
+
+	return identities
+}
+
+// OracleIdentity describes the contracts the environment publishes to
+func (s *EnhancedOracleService) OracleIdentity(ctx context.Context) []*ContractIdentity {
+	return s.baseService.OracleIdentity(ctx)
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/blockchain"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+// Mock blockchain client that reports a fixed identity
+type mockIdentityClient struct {
+	mockBlockchainClient
+	identity *blockchain.Identity
+}
+
+func (m *mockIdentityClient) Identity(ctx context.Context) *blockchain.Identity {
+	return m.identity
+}
+
+func TestOracleIdentity(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+
+	// The default mock client can't report an identity
+	if identities := service.OracleIdentity(ctx); len(identities) != 0 {
+		t.Fatalf("Expected no identities, got %d", len(identities))
+	}
+
+	service.blockchainClient = &mockIdentityClient{identity: &blockchain.Identity{Contract: "0x01", Authorized: true}}
+	service.SetCanary(&mockIdentityClient{identity: &blockchain.Identity{Contract: "0x02"}}, 10)
+
+	identities := service.OracleIdentity(ctx)
+	if len(identities) != 2 {
+		t.Fatalf("Expected primary and canary identities, got %d", len(identities))
+	}
+	if identities[0].Target != models.PublishTargetPrimary || identities[0].Contract != "0x01" || !identities[0].Authorized {
+		t.Errorf("Unexpected primary identity %+v", identities[0])
+	}
+	if identities[1].Target != models.PublishTargetCanary || identities[1].Contract != "0x02" {
+		t.Errorf("Unexpected canary identity %+v", identities[1])
+	}
+}
@@ -5,31 +5,162 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/yourusername/p2p-lend/oracle-service/internal/aggregator"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/blockchain"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/cache"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/chains"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/credentials"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/events"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/pause"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/schemas"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/webhooks"
 	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
 	"go.uber.org/zap"
 )
 
+// OnChainFetcher fetches on-chain metrics for an address
+type OnChainFetcher interface {
+	FetchMetrics(ctx context.Context, address string) (*models.OnChainMetrics, error)
+	HealthCheck(ctx context.Context) error
+}
+
+// OffChainFetcher fetches off-chain metrics for a user
+type OffChainFetcher interface {
+	FetchMetrics(ctx context.Context, userID, address string) (*models.OffChainMetrics, error)
+	HealthCheck(ctx context.Context) error
+}
+
+// BlockchainClient publishes credit scores to the oracle contract
+type BlockchainClient interface {
+	UpdateCreditScore(ctx context.Context, update blockchain.ScoreUpdate) (*types.Transaction, error)
+	HealthCheck(ctx context.Context) error
+}
+
+// PublishCostEstimator is implemented by blockchain clients that can price a score update
+type PublishCostEstimator interface {
+	EstimatePublishCost(ctx context.Context, update blockchain.ScoreUpdate) (*blockchain.PublishCostEstimate, error)
+}
+
+// PayloadVersioner is implemented by blockchain clients that encode for a specific
+// contract ABI version. Clients without it publish v1 payloads.
+type PayloadVersioner interface {
+	PayloadVersion() uint8
+}
+
+// ChainReporter is implemented by blockchain clients that know the chain they
+// publish to
+type ChainReporter interface {
+	ChainID() string
+}
+
+// BatchPublisher is implemented by blockchain clients that can publish many scores at once
+type BatchPublisher interface {
+	PublishScores(ctx context.Context, updates []blockchain.ScoreUpdate) []blockchain.PublishResult
+}
+
+// NativePriceSource provides the USD price of a chain's native token
+type NativePriceSource interface {
+	GetNativeTokenPriceUSD(ctx context.Context) (float64, error)
+	NativeAsset() chains.NativeAsset // The token priced
+}
+
+// WebhookDispatcher sends events to outbound webhook subscribers
+type WebhookDispatcher interface {
+	Dispatch(ctx context.Context, eventType string, data interface{}) error
+}
+
+// ScoreChangedEvent is the payload of score.changed webhooks
+type ScoreChangedEvent struct {
+	Address       string           `json:"address"`
+	PreviousScore units.Score      `json:"previous_score,omitempty"` // Omitted for a first score
+	Score         units.Score      `json:"score"`
+	Confidence    units.Confidence `json:"confidence"`
+	ChangeReason  string           `json:"change_reason"`
+	DataHash      string           `json:"data_hash"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+// ScorePublishedEvent is the payload of score.published events
+type ScorePublishedEvent struct {
+	Address    string           `json:"address"`
+	Score      units.Score      `json:"score"`
+	Confidence units.Confidence `json:"confidence"`
+	DataHash   string           `json:"data_hash"`
+	TxHash     string           `json:"tx_hash"`
+	Batched    bool             `json:"batched"`
+}
+
+// ProviderFailedEvent is the payload of provider.failed events
+type ProviderFailedEvent struct {
+	Provider string `json:"provider"`
+	Address  string `json:"address,omitempty"`
+	Error    string `json:"error"`
+}
+
+// ErrPublishEstimateUnavailable is returned when no configured blockchain client can estimate costs
+var ErrPublishEstimateUnavailable = errors.New("publish cost estimation unavailable: blockchain client not configured")
+
+// PublishEstimate is the projected cost of publishing an address's current score
+type PublishEstimate struct {
+	Address    string           `json:"address"`
+	Score      units.Score      `json:"score"`
+	Confidence units.Confidence `json:"confidence"`
+	*blockchain.PublishCostEstimate
+	NativePriceUSD float64 `json:"native_price_usd,omitempty"`
+	FeeUSD         float64 `json:"fee_usd,omitempty"`
+	MaxFeeUSD      float64 `json:"max_fee_usd,omitempty"`
+}
+
 // OracleService orchestrates credit score calculation and updates
 type OracleService struct {
 	repo             *repository.ScoreRepository
 	scoringEngine    *scoring.Engine
-	onChainAgg       *aggregator.OnChainAggregator
-	offChainAgg      *aggregator.OffChainAggregator
-	blockchainClient *blockchain.OracleClient
+	models           *scoring.Models // Model versions scores can be compared under; nil has only the engine's
+	onChainAgg       OnChainFetcher
+	offChainAgg      OffChainFetcher
+	blockchainClient BlockchainClient
+	priceSource      NativePriceSource
+	publishWindow    *PublishWindow // nil publishes immediately
+	webhooks         WebhookDispatcher
+	events           events.Publisher
+	statsInterval    time.Duration // 0 computes stats on every request
+	retention        *RetentionService
+	jobs             *JobRecorder                // nil leaves job runs unrecorded
+	audit            *repository.AuditRepository // nil keeps no audit log
+	issuer           *credentials.Issuer         // nil disables credential issuance
+	canary           *canary                     // nil publishes everything to the primary contract
+	expiryGrace      time.Duration               // Published scores stay valid this long past their next update due
+	maxValidity      time.Duration               // Cap on how long after publishing a score expires (0 = no cap)
+	updateLimit      *updateLimiter              // nil doesn't limit requested updates
+	subsystems       *SubsystemService           // nil can't pause the scheduler or publishing
+	health           *healthMonitor              // nil doesn't monitor lending positions
+	positions        []HealthFactorSource        // Lending protocols whose positions are snapshotted; none takes no snapshots
+	dataPolicy       *scoring.DataPolicy         // nil makes every score final
+	historyWriter    *repository.HistoryWriter   // nil writes score history synchronously
+	scorePolicies    scoring.ScorePolicies       // Per-tenant score clamps; nil clamps nothing
+	distribution     *publicDistribution         // nil serves no public score distribution
+	research         *ResearchExport             // nil serves no research queries
+	liquidations     *liquidationFastPath        // nil leaves liquidated addresses to the scheduler
+	slo              *pipelineSLO                // nil holds the full update to DefaultPipelineObjective
+
+	// Driven by the invalidations the repository publishes, see SetInvalidationBus
+	statsChanged chan struct{}                     // Signalled as scores or metrics change, to refresh the stats early
+	scoreCache   *cache.Store[*models.CreditScore] // nil reads every score from the repository
+	tierCache    *cache.Store[PrescreenItem]       // nil works out every prescreen tier
 }
 
 // NewOracleService creates a new oracle service
 func NewOracleService(
 	repo *repository.ScoreRepository,
 	scoringEngine *scoring.Engine,
-	onChainAgg *aggregator.OnChainAggregator,
-	offChainAgg *aggregator.OffChainAggregator,
-	blockchainClient *blockchain.OracleClient,
+	onChainAgg OnChainFetcher,
+	offChainAgg OffChainFetcher,
+	blockchainClient BlockchainClient,
 ) *OracleService {
 	return &OracleService{
 		repo:             repo,
@@ -37,27 +168,101 @@ func NewOracleService(
 		onChainAgg:       onChainAgg,
 		offChainAgg:      offChainAgg,
 		blockchainClient: blockchainClient,
+		statsChanged:     make(chan struct{}, 1),
 	}
 }
 
+// SetStatsRefresh serves stats from the materialized stats table, which the caller
+// refreshes every interval with RunStatsRefresh
+func (s *OracleService) SetStatsRefresh(interval time.Duration) {
+	s.statsInterval = interval
+}
+
+// SetRetention sets the retention service whose deletion counts are reported in stats
+func (s *OracleService) SetRetention(retention *RetentionService) {
+	s.retention = retention
+}
+
+// SetJobRecorder records the runs of the service's scheduled jobs
+func (s *OracleService) SetJobRecorder(jobs *JobRecorder) {
+	s.jobs = jobs
+}
+
+// SetAuditLog records freezes and share link activity in the audit log
+func (s *OracleService) SetAuditLog(audit *repository.AuditRepository) {
+	s.audit = audit
+}
+
+// SetPriceSource sets the source used to convert publish fees to USD
+func (s *OracleService) SetPriceSource(source NativePriceSource) {
+	s.priceSource = source
+}
+
+// SetPublishWindow restricts non-urgent publications to the given window
+func (s *OracleService) SetPublishWindow(window *PublishWindow) {
+	s.publishWindow = window
+}
+
+// SetWebhookDispatcher sends score.changed webhooks whenever a score changes
+func (s *OracleService) SetWebhookDispatcher(dispatcher WebhookDispatcher) {
+	s.webhooks = dispatcher
+}
+
+// SetExpiryMargins sets the safety margins of published score expiries. A score
+// expires grace after its next update is due, so a late scheduled refresh doesn't
+// leave borrowers without a valid score, but never more than maxValidity after it
+// was published.
+func (s *OracleService) SetExpiryMargins(grace, maxValidity time.Duration) {
+	s.expiryGrace = grace
+	s.maxValidity = maxValidity
+}
+
+// SetEventPublisher emits score lifecycle events to a message bus
+func (s *OracleService) SetEventPublisher(publisher events.Publisher) {
+	s.events = publisher
+}
+
+// SetHistoryWriter writes score history through writer in batches instead of one
+// insert per score. History written this way may lag the score by up to the
+// writer's flush interval.
+func (s *OracleService) SetHistoryWriter(writer *repository.HistoryWriter) {
+	s.historyWriter = writer
+}
+
 // CalculateAndUpdateScore calculates a new credit score for a user
 func (s *OracleService) CalculateAndUpdateScore(ctx context.Context, address, userID string) (*models.CreditScore, error) {
+	return s.calculateAndUpdateScore(ctx, address, userID, models.ChangeReasonManual)
+}
+
+func (s *OracleService) calculateAndUpdateScore(ctx context.Context, address, userID, reason string) (*models.CreditScore, error) {
 	logger.Info("Starting credit score calculation",
 		zap.String("address", address),
 		zap.String("userID", userID),
+		zap.String("reason", reason),
 	)
 
+	// Frozen profiles get no new provider data
+	if err := s.checkNotFrozen(ctx, address); err != nil {
+		return nil, err
+	}
+	timer := startPipelineTimer(address, reason)
+
 	// Fetch on-chain metrics
 	onChainMetrics, err := s.onChainAgg.FetchMetrics(ctx, address)
 	if err != nil {
 		logger.Error("Failed to fetch on-chain metrics", zap.Error(err))
+		timer.fail(models.StageFetchOnChain)
+		s.recordTiming(ctx, timer, nil)
 		return nil, fmt.Errorf("failed to fetch on-chain metrics: %w", err)
 	}
+	timer.mark(models.StageFetchOnChain)
 
 	// Save on-chain metrics
+	liquidationsBefore := s.storedLiquidations(ctx, address)
 	if err := s.repo.UpsertOnChainMetrics(ctx, onChainMetrics); err != nil {
 		logger.Error("Failed to save on-chain metrics", zap.Error(err))
 	}
+	timer.mark(models.StagePersist)
 
 	// Fetch off-chain metrics
 	offChainMetrics, err := s.offChainAgg.FetchMetrics(ctx, userID, address)
@@ -66,6 +271,7 @@ func (s *OracleService) CalculateAndUpdateScore(ctx context.Context, address, us
 		// Continue with on-chain data only
 		offChainMetrics = nil
 	}
+	timer.mark(models.StageFetchOffChain)
 
 	// Save off-chain metrics if available
 	if offChainMetrics != nil {
@@ -74,19 +280,58 @@ func (s *OracleService) CalculateAndUpdateScore(ctx context.Context, address, us
 		}
 	}
 
+	s.recordMetricsFetched(ctx, address, onChainMetrics, offChainMetrics)
+	timer.mark(models.StagePersist)
+
 	// Calculate credit score
-	score, err := s.scoringEngine.CalculateScore(onChainMetrics, offChainMetrics)
+	score, err := s.calculateScore(ctx, address, onChainMetrics, offChainMetrics)
 	if err != nil {
 		logger.Error("Failed to calculate score", zap.Error(err))
+		timer.fail(models.StageScore)
+		s.recordTiming(ctx, timer, nil)
 		return nil, fmt.Errorf("failed to calculate score: %w", err)
 	}
+	timer.mark(models.StageScore)
 
 	score.UserAddress = address
 
-	// Save or update credit score
-	existingScore, err := s.repo.GetByAddress(ctx, address)
+	if err := s.saveScore(ctx, score, reason); err != nil {
+		timer.fail(models.StagePersist)
+		s.recordTiming(ctx, timer, nil)
+		return nil, err
+	}
+	s.noteProviderLiquidations(ctx, score, liquidationsBefore, onChainMetrics, reason)
+	timer.mark(models.StagePersist)
+	s.recordTiming(ctx, timer, score)
+
+	logger.Info("Credit score calculated successfully",
+		zap.String("address", address),
+		zap.Uint16("score", score.Score.Uint16()),
+		zap.Uint8("confidence", score.Confidence.Uint8()),
+	)
+
+	return score, nil
+}
+
+// saveScore creates or updates the address's score and records it in the history
+// along with the reason it was recalculated
+func (s *OracleService) saveScore(ctx context.Context, score *models.CreditScore, reason string) error {
+	existingScore, err := s.repo.GetByAddress(ctx, score.UserAddress)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check existing score: %w", err)
+		return fmt.Errorf("failed to check existing score: %w", err)
+	}
+
+	if existingScore == nil {
+		// Create new score
+		score.UpdateCount = 1
+		score.PublishStatus = models.PublishStatusNeverPublished
+		if err := s.repo.Create(ctx, score); err != nil {
+			// A concurrent calculation may have created it first; update theirs instead
+			existingScore, _ = s.repo.GetByAddress(ctx, score.UserAddress)
+			if existingScore == nil {
+				return fmt.Errorf("failed to create score: %w", err)
+			}
+		}
 	}
 
 	if existingScore != nil {
@@ -94,72 +339,166 @@ func (s *OracleService) CalculateAndUpdateScore(ctx context.Context, address, us
 		score.ID = existingScore.ID
 		score.CreatedAt = existingScore.CreatedAt
 		score.UpdateCount = existingScore.UpdateCount + 1
+		score.PublishStatus = existingScore.PublishStatus
+		if score.PublishStatus == models.PublishStatusOnChain && score.DataHash != existingScore.DataHash {
+			score.PublishStatus = models.PublishStatusStaleOnChain
+		}
 
 		if err := s.repo.Update(ctx, score); err != nil {
-			return nil, fmt.Errorf("failed to update score: %w", err)
-		}
-	} else {
-		// Create new score
-		score.UpdateCount = 1
-		if err := s.repo.Create(ctx, score); err != nil {
-			return nil, fmt.Errorf("failed to create score: %w", err)
+			return fmt.Errorf("failed to update score: %w", err)
 		}
 	}
 
 	// Save to history
 	history := &models.ScoreHistory{
-		UserAddress: address,
-		Score:       score.Score,
-		Confidence:  score.Confidence,
-		DataHash:    score.DataHash,
-		Timestamp:   time.Now(),
+		UserAddress:  score.UserAddress,
+		Score:        score.Score,
+		Confidence:   score.Confidence,
+		DataHash:     score.DataHash,
+		ChangeReason: reason,
+		Timestamp:    time.Now(),
+	}
+	writeHistory := s.repo.CreateHistory
+	if s.historyWriter != nil {
+		writeHistory = s.historyWriter.Write
 	}
-	if err := s.repo.CreateHistory(ctx, history); err != nil {
+	if err := writeHistory(ctx, history); err != nil {
 		logger.Error("Failed to save score history", zap.Error(err))
 	}
 
-	logger.Info("Credit score calculated successfully",
-		zap.String("address", address),
-		zap.Uint16("score", score.Score),
-		zap.Uint8("confidence", score.Confidence),
-	)
+	// Save the factor scores of the calculation
+	for i := range score.Components {
+		score.Components[i].UserAddress = score.UserAddress
+		score.Components[i].CalculatedAt = score.LastUpdated
+		score.Components[i].DataHash = score.DataHash
+	}
+	if err := s.repo.CreateScoreComponents(ctx, score.Components); err != nil {
+		logger.Error("Failed to save score components", zap.Error(err))
+	}
 
-	return score, nil
+	change := ScoreChangedEvent{
+		Address:      score.UserAddress,
+		Score:        score.Score,
+		Confidence:   score.Confidence,
+		ChangeReason: reason,
+		DataHash:     score.DataHash,
+		UpdatedAt:    score.LastUpdated,
+	}
+	if existingScore != nil {
+		change.PreviousScore = existingScore.Score
+	}
+
+	s.recordScoreEvent(ctx, score.UserAddress, models.ScoreEventCalculated, change)
+	s.emit(ctx, events.ScoreCalculated, score.UserAddress, change)
+	if existingScore == nil || existingScore.Score != score.Score {
+		s.notifyScoreChanged(change)
+	}
+
+	return nil
+}
+
+// notifyScoreChanged sends a score.changed webhook
+func (s *OracleService) notifyScoreChanged(change ScoreChangedEvent) {
+	if s.webhooks == nil {
+		return
+	}
+
+	s.dispatchWebhook(webhooks.EventScoreChanged, change.Address, change)
+}
+
+// dispatchWebhook sends a webhook in the background so slow subscribers never hold up
+// scoring. Payloads that don't match their published schema are logged and not sent.
+func (s *OracleService) dispatchWebhook(eventType, address string, data interface{}) {
+	if err := schemas.Validate(schemas.ChannelWebhook, eventType, data); err != nil {
+		logger.Error("Webhook payload does not match its schema", zap.String("type", eventType), zap.Error(err))
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := s.webhooks.Dispatch(ctx, eventType, data); err != nil {
+			logger.Warn("Failed to deliver webhook", zap.String("type", eventType), zap.String("address", address), zap.Error(err))
+		}
+	}()
+}
+
+// scorePublished records a submitted publication in the address's event stream and
+// announces it on the message bus
+func (s *OracleService) scorePublished(ctx context.Context, published ScorePublishedEvent) {
+	s.recordScoreEvent(ctx, published.Address, models.ScoreEventPublished, published)
+	s.emit(ctx, events.ScorePublished, published.Address, published)
+}
+
+// emit publishes an event to the message bus, if one is configured. Events whose data
+// doesn't match its published schema are logged and not published.
+func (s *OracleService) emit(ctx context.Context, eventType, address string, data interface{}) {
+	if s.events == nil {
+		return
+	}
+	if err := schemas.Validate(schemas.ChannelEvent, eventType, data); err != nil {
+		logger.Error("Event payload does not match its schema", zap.String("type", eventType), zap.Error(err))
+		return
+	}
+
+	event := events.NewEvent(eventType, address, data)
+	event.DataSchema = schemas.Current(schemas.ChannelEvent, eventType).Path()
+	if err := s.events.Publish(ctx, event); err != nil {
+		logger.Error("Failed to publish event", zap.String("type", eventType), zap.Error(err))
+	}
 }
 
 // PublishScoreToBlockchain publishes a credit score to the blockchain
 func (s *OracleService) PublishScoreToBlockchain(ctx context.Context, address string) error {
+	if err := s.checkNotFrozen(ctx, address); err != nil {
+		return err
+	}
+	if s.paused(ctx, pause.Publishing) {
+		return ErrPublishingPaused
+	}
+
 	// Get current score
 	score, err := s.repo.GetByAddress(ctx, address)
 	if err != nil {
 		return fmt.Errorf("failed to get score: %w", err)
 	}
 	if score == nil {
-		return fmt.Errorf("no score found for address %s", address)
+		return fmt.Errorf("%w for address %s", ErrScoreNotFound, address)
+	}
+	if err := checkPublishable(score); err != nil {
+		return err
 	}
 
 	logger.Info("Publishing score to blockchain",
 		zap.String("address", address),
-		zap.Uint16("score", score.Score),
+		zap.Uint16("score", score.Score.Uint16()),
 	)
 
-	// Submit to blockchain
-	tx, err := s.blockchainClient.UpdateCreditScore(
-		ctx,
-		address,
-		score.Score,
-		score.Confidence,
-		score.DataHash,
-	)
+	if s.blockchainClient == nil {
+		return fmt.Errorf("blockchain client not configured")
+	}
 
-	// Create oracle update record
+	// Submit to blockchain
+	target, client := s.publishTarget(address)
 	update := &models.OracleUpdate{
-		UserAddress: address,
-		Score:       score.Score,
-		Confidence:  score.Confidence,
-		DataHash:    score.DataHash,
-		Status:      "pending",
+		UserAddress:    address,
+		Score:          score.Score,
+		Confidence:     score.Confidence,
+		DataHash:       score.DataHash,
+		Status:         "pending",
+		Target:         target,
+		PayloadVersion: payloadVersion(client),
+		ExpiresAt:      s.scoreExpiry(score),
 	}
+	if err := s.checkAuthorized(ctx, client); err != nil {
+		s.blockUpdates(ctx, []*models.OracleUpdate{update}, &BatchPublishResult{})
+		return err
+	}
+	submitted := time.Now()
+	tx, err := client.UpdateCreditScore(ctx, scoreUpdate(update))
+	s.recordPublishTiming(ctx, address, score.DataHash, time.Since(submitted), err != nil)
+
+	// Record the oracle update
 
 	if err != nil {
 		update.Status = "failed"
@@ -172,6 +511,7 @@ func (s *OracleService) PublishScoreToBlockchain(ctx context.Context, address st
 	if err := s.repo.CreateOracleUpdate(ctx, update); err != nil {
 		logger.Error("Failed to save oracle update", zap.Error(err))
 	}
+	s.refreshPublishStatus(ctx, address)
 
 	if err != nil {
 		return fmt.Errorf("failed to publish to blockchain: %w", err)
@@ -179,26 +519,702 @@ func (s *OracleService) PublishScoreToBlockchain(ctx context.Context, address st
 
 	logger.Info("Score published to blockchain successfully",
 		zap.String("txHash", update.TxHash),
+		zap.String("target", target),
 	)
 
+	s.scorePublished(ctx, ScorePublishedEvent{
+		Address:    address,
+		Score:      score.Score,
+		Confidence: score.Confidence,
+		DataHash:   score.DataHash,
+		TxHash:     update.TxHash,
+	})
+
 	return nil
 }
 
+// Batch publish item statuses
+const (
+	BatchItemSubmitted   = "submitted"
+	BatchItemQueued      = "queued"
+	BatchItemFailed      = "failed"
+	BatchItemNotFound    = "not_found"
+	BatchItemFrozen      = "frozen"      // The address's profile is frozen
+	BatchItemProvisional = "provisional" // The score is provisional
+	BatchItemBlocked     = "blocked"     // The contract no longer accepts the signer
+)
+
+// BatchPublishItem is the outcome of publishing one address in a batch
+type BatchPublishItem struct {
+	Address string      `json:"address"`
+	Score   units.Score `json:"score,omitempty"`
+	Status  string      `json:"status"`
+	TxHash  string      `json:"tx_hash,omitempty"`
+	Batched bool        `json:"batched"` // Sent in a multi-score updateScores transaction
+	Error   string      `json:"error,omitempty"`
+}
+
+// BatchPublishResult summarizes a batch publish
+type BatchPublishResult struct {
+	Requested    int                  `json:"requested"`
+	Submitted    int                  `json:"submitted"`
+	Queued       int                  `json:"queued"`
+	Failed       int                  `json:"failed"`
+	Transactions int                  `json:"transactions"`
+	Items        []BatchPublishItem   `json:"items"`
+	Window       *PublishWindowStatus `json:"publish_window,omitempty"` // Set when publications were held for the window
+	Paused       bool                 `json:"paused,omitempty"`         // Publishing is paused; queued scores stay queued
+}
+
+// PublishBatch publishes the given addresses' scores, or up to limit unpublished scores
+// when no addresses are given, using as few transactions as the contract allows.
+// Non-urgent publications are queued while the publish window is closed. Frozen
+// addresses are never published.
+func (s *OracleService) PublishBatch(ctx context.Context, addresses []string, limit int, urgent bool) (*BatchPublishResult, error) {
+	if s.blockchainClient == nil {
+		return nil, fmt.Errorf("blockchain client not configured")
+	}
+	if s.paused(ctx, pause.Publishing) {
+		return nil, ErrPublishingPaused
+	}
+
+	result := &BatchPublishResult{Items: []BatchPublishItem{}}
+
+	var scores []*models.CreditScore
+	if len(addresses) > 0 {
+		for _, address := range addresses {
+			if err := s.checkNotFrozen(ctx, address); err != nil {
+				if !errors.Is(err, ErrProfileFrozen) {
+					return nil, err
+				}
+				result.Items = append(result.Items, BatchPublishItem{Address: address, Status: BatchItemFrozen})
+				continue
+			}
+
+			score, err := s.repo.GetByAddress(ctx, address)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get score: %w", err)
+			}
+			if score == nil {
+				result.Items = append(result.Items, BatchPublishItem{Address: address, Status: BatchItemNotFound})
+				continue
+			}
+			if score.Provisional {
+				result.Items = append(result.Items, BatchPublishItem{Address: address, Status: BatchItemProvisional})
+				continue
+			}
+			scores = append(scores, score)
+		}
+		result.Requested = len(addresses)
+	} else {
+		var err error
+		scores, err = s.repo.GetUnpublishedScores(ctx, limit)
+		if err != nil {
+			return nil, err
+		}
+		result.Requested = len(scores)
+	}
+
+	if len(scores) == 0 {
+		return result, nil
+	}
+
+	if !urgent {
+		if window := s.PublishWindowStatus(ctx); !window.Open {
+			result.Window = &window
+			for _, score := range scores {
+				if err := s.queueUpdate(ctx, score); err != nil {
+					return nil, err
+				}
+				result.Queued++
+				result.Items = append(result.Items, BatchPublishItem{
+					Address: score.UserAddress,
+					Score:   score.Score,
+					Status:  BatchItemQueued,
+				})
+			}
+
+			logger.Info("Publish window closed, score batch queued",
+				zap.Int("count", result.Queued),
+				zap.String("reason", window.Reason),
+			)
+			return result, nil
+		}
+	}
+
+	records := make([]*models.OracleUpdate, len(scores))
+	for i, score := range scores {
+		records[i] = &models.OracleUpdate{
+			UserAddress: score.UserAddress,
+			Score:       score.Score,
+			Confidence:  score.Confidence,
+			DataHash:    score.DataHash,
+			ExpiresAt:   s.scoreExpiry(score),
+		}
+	}
+	s.submitUpdates(ctx, records, result)
+
+	return result, nil
+}
+
+// RequestPublish publishes the address's score now if urgent or the publish window is
+// open, and otherwise queues it for the next window. It reports whether it was queued.
+func (s *OracleService) RequestPublish(ctx context.Context, address string, urgent bool) (bool, error) {
+	if err := s.checkNotFrozen(ctx, address); err != nil {
+		return false, err
+	}
+	if urgent {
+		return false, s.PublishScoreToBlockchain(ctx, address)
+	}
+	if window := s.PublishWindowStatus(ctx); window.Open {
+		return false, s.PublishScoreToBlockchain(ctx, address)
+	}
+
+	score, err := s.repo.GetByAddress(ctx, address)
+	if err != nil {
+		return false, fmt.Errorf("failed to get score: %w", err)
+	}
+	if score == nil {
+		return false, fmt.Errorf("%w for address %s", ErrScoreNotFound, address)
+	}
+	if err := checkPublishable(score); err != nil {
+		return false, err
+	}
+
+	if err := s.queueUpdate(ctx, score); err != nil {
+		return false, err
+	}
+
+	logger.Info("Publish window closed, score queued", zap.String("address", address))
+	return true, nil
+}
+
+// ProcessPublishQueue publishes up to limit queued scores if the publish window is open.
+// Queued records are refreshed with the address's latest score before sending, and
+// records for frozen addresses are failed. Nothing is sent while publishing is paused.
+func (s *OracleService) ProcessPublishQueue(ctx context.Context, limit int) (*BatchPublishResult, error) {
+	result := &BatchPublishResult{Items: []BatchPublishItem{}}
+
+	if s.paused(ctx, pause.Publishing) {
+		result.Paused = true
+		return result, nil
+	}
+
+	window := s.PublishWindowStatus(ctx)
+	if !window.Open {
+		result.Window = &window
+		return result, nil
+	}
+	if s.blockchainClient == nil {
+		return nil, fmt.Errorf("blockchain client not configured")
+	}
+
+	queued, err := s.repo.ListOracleUpdates(ctx, models.OracleUpdateQueued, limit)
+	if err != nil {
+		return nil, err
+	}
+	result.Requested = len(queued)
+
+	records := make([]*models.OracleUpdate, 0, len(queued))
+	for _, record := range queued {
+		if err := s.checkNotFrozen(ctx, record.UserAddress); err != nil {
+			if !errors.Is(err, ErrProfileFrozen) {
+				return nil, err
+			}
+			record.Status = models.OracleUpdateFailed
+			record.ErrorMessage = "profile frozen"
+			if err := s.repo.UpdateOracleUpdate(ctx, record); err != nil {
+				logger.Error("Failed to save oracle update", zap.Error(err))
+			}
+			s.refreshPublishStatus(ctx, record.UserAddress)
+			result.Failed++
+			result.Items = append(result.Items, BatchPublishItem{Address: record.UserAddress, Status: BatchItemFrozen})
+			continue
+		}
+
+		score, err := s.repo.GetByAddress(ctx, record.UserAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get score: %w", err)
+		}
+		if score == nil {
+			record.Status = models.OracleUpdateFailed
+			record.ErrorMessage = "score no longer exists"
+			if err := s.repo.UpdateOracleUpdate(ctx, record); err != nil {
+				logger.Error("Failed to save oracle update", zap.Error(err))
+			}
+			result.Failed++
+			result.Items = append(result.Items, BatchPublishItem{Address: record.UserAddress, Status: BatchItemNotFound})
+			continue
+		}
+		if score.Provisional {
+			record.Status = models.OracleUpdateFailed
+			record.ErrorMessage = "score is provisional"
+			if err := s.repo.UpdateOracleUpdate(ctx, record); err != nil {
+				logger.Error("Failed to save oracle update", zap.Error(err))
+			}
+			s.refreshPublishStatus(ctx, record.UserAddress)
+			result.Failed++
+			result.Items = append(result.Items, BatchPublishItem{Address: record.UserAddress, Status: BatchItemProvisional})
+			continue
+		}
+
+		record.Score = score.Score
+		record.Confidence = score.Confidence
+		record.DataHash = score.DataHash
+		record.ExpiresAt = s.scoreExpiry(score)
+		records = append(records, record)
+	}
+
+	if len(records) > 0 {
+		s.submitUpdates(ctx, records, result)
+	}
+
+	return result, nil
+}
+
+// RunPublishQueue drains the publish queue every interval until the context is cancelled
+func (s *OracleService) RunPublishQueue(ctx context.Context, interval time.Duration, batchSize int) {
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			run := s.jobs.start(ctx, models.JobPublishQueue)
+			result, err := s.ProcessPublishQueue(ctx, batchSize)
+			if err != nil {
+				logger.Error("Failed to process publish queue", zap.Error(err))
+				run.finish(ctx, err)
+				continue
+			}
+			recordPublishQueueRun(ctx, run, result)
+			if result.Requested > 0 {
+				logger.Info("Processed publish queue",
+					zap.Int("submitted", result.Submitted),
+					zap.Int("failed", result.Failed),
+				)
+			}
+		}
+	}
+}
+
+// recordPublishQueueRun records the outcome of a pass over the publish queue
+func recordPublishQueueRun(ctx context.Context, run *jobRun, result *BatchPublishResult) {
+	switch {
+	case result.Paused:
+		run.skip(ctx, "publishing paused")
+		return
+	case result.Window != nil:
+		run.skip(ctx, "publish window closed")
+		return
+	}
+	for _, item := range result.Items {
+		switch {
+		case item.Status == BatchItemSubmitted:
+			run.succeeded()
+		case item.Error != "":
+			run.failed(item.Address, errors.New(item.Error))
+		default:
+			run.failed(item.Address, errors.New(item.Status))
+		}
+	}
+	run.finish(ctx, nil)
+}
+
+// ListOracleUpdates lists oracle updates, optionally filtered by status
+func (s *OracleService) ListOracleUpdates(ctx context.Context, status string, limit int) ([]*models.OracleUpdate, error) {
+	return s.repo.ListOracleUpdates(ctx, status, limit)
+}
+
+// PublishWindowStatus reports whether non-urgent publications can be sent now
+func (s *OracleService) PublishWindowStatus(ctx context.Context) PublishWindowStatus {
+	baseFee, _ := s.blockchainClient.(BaseFeeSource)
+	return s.publishWindow.Check(ctx, time.Now(), baseFee)
+}
+
+// queueUpdate holds a score for the next publish window, replacing any score already
+// queued for the address
+func (s *OracleService) queueUpdate(ctx context.Context, score *models.CreditScore) error {
+	record, err := s.repo.GetOracleUpdateByAddressAndStatus(ctx, score.UserAddress, models.OracleUpdateQueued)
+	if err != nil {
+		return err
+	}
+
+	if record == nil {
+		record = &models.OracleUpdate{
+			UserAddress: score.UserAddress,
+			Status:      models.OracleUpdateQueued,
+		}
+	}
+	record.Score = score.Score
+	record.Confidence = score.Confidence
+	record.DataHash = score.DataHash
+	record.ExpiresAt = s.scoreExpiry(score)
+
+	if record.ID == 0 {
+		err = s.repo.CreateOracleUpdate(ctx, record)
+	} else {
+		err = s.repo.UpdateOracleUpdate(ctx, record)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to queue oracle update: %w", err)
+	}
+	s.refreshPublishStatus(ctx, score.UserAddress)
+	return nil
+}
+
+// submitUpdates sends the records on-chain and saves each record's outcome. Records
+// routed to the canary contract are sent separately from the rest.
+func (s *OracleService) submitUpdates(ctx context.Context, records []*models.OracleUpdate, result *BatchPublishResult) {
+	var primary, canary []*models.OracleUpdate
+	for _, record := range records {
+		record.Target, _ = s.publishTarget(record.UserAddress)
+		if record.Target == models.PublishTargetCanary {
+			canary = append(canary, record)
+		} else {
+			primary = append(primary, record)
+		}
+	}
+
+	if len(primary) > 0 {
+		s.submitTo(ctx, s.blockchainClient, primary, result)
+	}
+	if len(canary) > 0 {
+		s.submitTo(ctx, s.canary.client, canary, result)
+	}
+
+	logger.Info("Score batch published",
+		zap.Int("submitted", result.Submitted),
+		zap.Int("failed", result.Failed),
+		zap.Int("transactions", result.Transactions),
+		zap.Int("canary", len(canary)),
+	)
+}
+
+// submitTo sends the records through one blockchain client. Nothing is sent while the
+// contract doesn't accept the client's signer; the records are blocked instead.
+func (s *OracleService) submitTo(ctx context.Context, client BlockchainClient, records []*models.OracleUpdate, result *BatchPublishResult) {
+	if err := s.checkAuthorized(ctx, client); err != nil {
+		s.blockUpdates(ctx, records, result)
+		return
+	}
+
+	version := payloadVersion(client)
+	updates := make([]blockchain.ScoreUpdate, len(records))
+	for i, record := range records {
+		record.PayloadVersion = version
+		updates[i] = scoreUpdate(record)
+	}
+
+	logger.Info("Publishing score batch to blockchain", zap.Int("count", len(updates)))
+
+	// Each record's publish stage lasts until its transaction was submitted
+	var published []blockchain.PublishResult
+	var durations []time.Duration
+	if publisher, ok := client.(BatchPublisher); ok {
+		submitted := time.Now()
+		published = publisher.PublishScores(ctx, updates)
+		for range published {
+			durations = append(durations, time.Since(submitted))
+		}
+	} else {
+		for _, update := range updates {
+			submitted := time.Now()
+			tx, err := client.UpdateCreditScore(ctx, update)
+			published = append(published, blockchain.PublishResult{Updates: []blockchain.ScoreUpdate{update}, Tx: tx, Err: err})
+			durations = append(durations, time.Since(submitted))
+		}
+	}
+
+	// Results cover the updates in order, so records are matched positionally
+	next := 0
+	for i, res := range published {
+		var txHash string
+		if res.Tx != nil {
+			txHash = res.Tx.Hash().Hex()
+		}
+		if res.Err == nil {
+			result.Transactions++
+		}
+
+		for range res.Updates {
+			record := records[next]
+			next++
+
+			record.TxHash = txHash
+			record.Status = models.OracleUpdatePending
+			record.ErrorMessage = ""
+			item := BatchPublishItem{
+				Address: record.UserAddress,
+				Score:   record.Score,
+				Status:  BatchItemSubmitted,
+				TxHash:  txHash,
+				Batched: res.Batched,
+			}
+
+			s.recordPublishTiming(ctx, record.UserAddress, record.DataHash, durations[i], res.Err != nil)
+			if res.Err != nil {
+				record.Status = models.OracleUpdateFailed
+				record.ErrorMessage = res.Err.Error()
+				item.Status = BatchItemFailed
+				item.Error = res.Err.Error()
+				result.Failed++
+			} else {
+				result.Submitted++
+				s.scorePublished(ctx, ScorePublishedEvent{
+					Address:    record.UserAddress,
+					Score:      record.Score,
+					Confidence: record.Confidence,
+					DataHash:   record.DataHash,
+					TxHash:     txHash,
+					Batched:    res.Batched,
+				})
+			}
+
+			var err error
+			if record.ID == 0 {
+				err = s.repo.CreateOracleUpdate(ctx, record)
+			} else {
+				err = s.repo.UpdateOracleUpdate(ctx, record)
+			}
+			if err != nil {
+				logger.Error("Failed to save oracle update", zap.Error(err))
+			}
+			s.refreshPublishStatus(ctx, record.UserAddress)
+			result.Items = append(result.Items, item)
+		}
+	}
+}
+
+// scoreUpdate is the on-chain payload of an oracle update record
+func scoreUpdate(record *models.OracleUpdate) blockchain.ScoreUpdate {
+	update := blockchain.ScoreUpdate{
+		UserAddress: record.UserAddress,
+		Score:       record.Score,
+		Confidence:  record.Confidence,
+		DataHash:    record.DataHash,
+	}
+	if record.ExpiresAt != nil {
+		update.ExpiresAt = *record.ExpiresAt
+	}
+	return update
+}
+
+// scoreExpiry is when a score published now should stop being trusted on-chain: once
+// its next update is overdue by the grace period, capped at the maximum validity
+func (s *OracleService) scoreExpiry(score *models.CreditScore) *time.Time {
+	if score.NextUpdateDue.IsZero() {
+		return nil
+	}
+	expiresAt := score.NextUpdateDue.Add(s.expiryGrace)
+	if s.maxValidity > 0 {
+		if limit := time.Now().Add(s.maxValidity); expiresAt.After(limit) {
+			expiresAt = limit
+		}
+	}
+	expiresAt = expiresAt.UTC().Truncate(time.Second) // On-chain timestamps have second precision
+	return &expiresAt
+}
+
+// payloadVersion returns the contract ABI version a client encodes for
+func payloadVersion(client BlockchainClient) uint8 {
+	if versioner, ok := client.(PayloadVersioner); ok {
+		return versioner.PayloadVersion()
+	}
+	return blockchain.PayloadV1
+}
+
+// EstimatePublishCost estimates gas, fees, and network congestion for publishing the
+// address's current score. It returns nil if no score exists for the address.
+func (s *OracleService) EstimatePublishCost(ctx context.Context, address string) (*PublishEstimate, error) {
+	estimator, ok := s.blockchainClient.(PublishCostEstimator)
+	if !ok {
+		return nil, ErrPublishEstimateUnavailable
+	}
+
+	score, err := s.repo.GetByAddress(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score: %w", err)
+	}
+	if score == nil {
+		return nil, nil
+	}
+
+	cost, err := estimator.EstimatePublishCost(ctx, scoreUpdate(&models.OracleUpdate{
+		UserAddress: address,
+		Score:       score.Score,
+		Confidence:  score.Confidence,
+		DataHash:    score.DataHash,
+		ExpiresAt:   s.scoreExpiry(score),
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate publish cost: %w", err)
+	}
+
+	estimate := &PublishEstimate{
+		Address:             address,
+		Score:               score.Score,
+		Confidence:          score.Confidence,
+		PublishCostEstimate: cost,
+	}
+
+	// USD conversion is best-effort; the native fee is still useful without it. A price
+	// of another chain's token would misvalue the fee.
+	if s.priceSource != nil {
+		priced := s.priceSource.NativeAsset().Symbol
+		if cost.NativeSymbol != "" && cost.NativeSymbol != priced {
+			logger.Warn("Native token price is for another token, fee not converted to USD",
+				zap.String("feeToken", cost.NativeSymbol),
+				zap.String("pricedToken", priced),
+			)
+		} else if price, err := s.priceSource.GetNativeTokenPriceUSD(ctx); err != nil {
+			logger.Warn("Failed to fetch native token price", zap.Error(err))
+		} else {
+			estimate.NativePriceUSD = price
+			estimate.FeeUSD = cost.FeeNative * price
+			estimate.MaxFeeUSD = cost.MaxFeeNative * price
+		}
+	}
+
+	return estimate, nil
+}
+
 // GetScore retrieves a credit score for a user
 func (s *OracleService) GetScore(ctx context.Context, address string) (*models.CreditScore, error) {
-	return s.repo.GetByAddress(ctx, address)
+	return s.cachedScore(ctx, address)
 }
 
-// GetScoreHistory retrieves score history for a user
+// GetScoreHistory retrieves score history for a user. It fails with ErrScoreNotFound
+// if the address has never been scored.
 func (s *OracleService) GetScoreHistory(ctx context.Context, address string, limit int) ([]*models.ScoreHistory, error) {
-	return s.repo.GetHistory(ctx, address, limit)
+	history, err := s.repo.GetHistory(ctx, address, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) > 0 {
+		return history, nil
+	}
+
+	// Empty history is only an error if the address has no score either
+	score, err := s.repo.GetByAddress(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score: %w", err)
+	}
+	if score == nil {
+		return nil, fmt.Errorf("%w for address %s", ErrScoreNotFound, address)
+	}
+	return history, nil
 }
 
-// ProcessScheduledUpdates processes scores that are due for update
+// StreamScoreHistory calls fn with each of an address's score history records, newest
+// first, without loading the history into memory. A limit of zero streams the whole
+// history. Like GetScoreHistory it fails with ErrScoreNotFound if the address has
+// never been scored.
+func (s *OracleService) StreamScoreHistory(ctx context.Context, address string, limit int, fn func(*models.ScoreHistory) error) error {
+	streamed := 0
+	err := s.repo.StreamHistory(ctx, address, limit, func(history *models.ScoreHistory) error {
+		streamed++
+		return fn(history)
+	})
+	if err != nil || streamed > 0 {
+		return err
+	}
+
+	score, err := s.repo.GetByAddress(ctx, address)
+	if err != nil {
+		return fmt.Errorf("failed to get score: %w", err)
+	}
+	if score == nil {
+		return fmt.Errorf("%w for address %s", ErrScoreNotFound, address)
+	}
+	return nil
+}
+
+// GetScoreComponents retrieves the factor scores of an address's latest calculation.
+// It fails with ErrScoreNotFound if none are stored.
+func (s *OracleService) GetScoreComponents(ctx context.Context, address string) ([]*models.ScoreComponent, error) {
+	components, err := s.repo.GetScoreComponents(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if len(components) == 0 {
+		return nil, fmt.Errorf("%w for address %s", ErrScoreNotFound, address)
+	}
+	return components, nil
+}
+
+// GetScoreTrend summarizes an address's score calculations per day over the last days
+// days. Like GetScoreHistory it fails with ErrScoreNotFound if the address has never
+// been scored.
+func (s *OracleService) GetScoreTrend(ctx context.Context, address string, days int) ([]*models.ScoreTrendPoint, error) {
+	trend, err := s.repo.GetScoreTrend(ctx, address, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return nil, err
+	}
+	if len(trend) > 0 {
+		return trend, nil
+	}
+
+	score, err := s.repo.GetByAddress(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score: %w", err)
+	}
+	if score == nil {
+		return nil, fmt.Errorf("%w for address %s", ErrScoreNotFound, address)
+	}
+	return trend, nil
+}
+
+// GetPopulationTrend summarizes the score calculations of every address per day over
+// the last days days
+func (s *OracleService) GetPopulationTrend(ctx context.Context, days int) ([]*models.ScoreTrendPoint, error) {
+	return s.repo.GetPopulationTrend(ctx, time.Now().AddDate(0, 0, -days))
+}
+
+// ExportScores calls fn with each active credit score without loading them all into
+// memory
+func (s *OracleService) ExportScores(ctx context.Context, fn func(*models.CreditScore) error) error {
+	return s.repo.StreamScores(ctx, fn)
+}
+
+// ExplainScore rebuilds the score breakdown and adjustments from the stored metrics.
+// It returns nil if no metrics are stored for the address.
+func (s *OracleService) ExplainScore(ctx context.Context, address string) (*scoring.Explanation, error) {
+	onChain, err := s.repo.GetOnChainMetrics(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	offChain, err := s.repo.GetOffChainMetrics(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if onChain == nil && offChain == nil {
+		return nil, nil
+	}
+
+	return s.scoringEngine.ExplainWithReliability(onChain, offChain, s.confidenceFactor(ctx, address))
+}
+
+// ProcessScheduledUpdates processes scores that are due for update, unless the
+// scheduler is paused
 func (s *OracleService) ProcessScheduledUpdates(ctx context.Context, batchSize int) error {
+	run := s.jobs.start(ctx, models.JobScheduledUpdates)
+	if s.paused(ctx, pause.Scheduler) {
+		logger.Info("Scheduler paused, skipping scheduled updates")
+		run.skip(ctx, "scheduler paused")
+		return nil
+	}
+
 	scores, err := s.repo.GetDueForUpdate(ctx, batchSize)
 	if err != nil {
-		return fmt.Errorf("failed to get scores due for update: %w", err)
+		err = fmt.Errorf("failed to get scores due for update: %w", err)
+		run.finish(ctx, err)
+		return err
 	}
 
 	logger.Info("Processing scheduled updates",
@@ -207,30 +1223,142 @@ func (s *OracleService) ProcessScheduledUpdates(ctx context.Context, batchSize i
 
 	for _, score := range scores {
 		// Calculate new score
-		_, err := s.CalculateAndUpdateScore(ctx, score.UserAddress, "")
+		_, err := s.calculateAndUpdateScore(ctx, score.UserAddress, "", models.ChangeReasonScheduled)
 		if err != nil {
 			logger.Error("Failed to update score",
 				zap.String("address", score.UserAddress),
 				zap.Error(err),
 			)
+			run.failed(score.UserAddress, err)
 			continue
 		}
 
-		// Publish to blockchain
-		if err := s.PublishScoreToBlockchain(ctx, score.UserAddress); err != nil {
+		// Publish to blockchain; while publishing is paused the score stays unpublished,
+		// and provisional scores are never published
+		if err := s.PublishScoreToBlockchain(ctx, score.UserAddress); err != nil && !errors.Is(err, ErrPublishingPaused) && !errors.Is(err, ErrScoreProvisional) {
 			logger.Error("Failed to publish score",
 				zap.String("address", score.UserAddress),
 				zap.Error(err),
 			)
+			run.failed(score.UserAddress, err)
+			continue
 		}
+		run.succeeded()
 	}
 
+	run.finish(ctx, nil)
 	return nil
 }
 
-// GetStats retrieves service statistics
+// GetStats retrieves service statistics. With a stats refresh interval set they come
+// from the materialized stats table, recomputed here only if the refresh job has
+// fallen more than two intervals behind.
 func (s *OracleService) GetStats(ctx context.Context) (map[string]interface{}, error) {
-	return s.repo.GetStats(ctx)
+	if s.statsInterval <= 0 {
+		stats, err := s.repo.GetStats(ctx)
+		if err != nil {
+			return nil, err
+		}
+		stats["materialized"] = false
+		return s.withLiveStats(ctx, stats)
+	}
+
+	materialized, err := s.repo.GetMaterializedStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if materialized == nil || time.Since(materialized.ComputedAt) > 2*s.statsInterval {
+		materialized, err = s.repo.RefreshStats(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.withLiveStats(ctx, s.materializedStats(materialized))
+}
+
+// RefreshStats recomputes the materialized stats now
+func (s *OracleService) RefreshStats(ctx context.Context) (map[string]interface{}, error) {
+	materialized, err := s.repo.RefreshStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.withLiveStats(ctx, s.materializedStats(materialized))
+}
+
+// RunStatsRefresh recomputes the materialized stats every interval until the context
+// is cancelled. Once scores or metrics change they are recomputed a tenth of an
+// interval later, so a burst of writes costs one refresh rather than one per read.
+func (s *OracleService) RunStatsRefresh(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		run := s.jobs.start(ctx, models.JobStatsRefresh)
+		if stats, err := s.repo.RefreshStats(ctx); err != nil {
+			logger.Error("Failed to refresh stats", zap.Error(err))
+			run.finish(ctx, err)
+		} else {
+			logger.Debug("Refreshed stats", zap.Int64("refreshMs", stats.RefreshMs))
+			run.succeeded()
+			run.finish(ctx, nil)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.statsChanged:
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval / 10):
+			}
+			// Writes made while waiting are covered by this refresh
+			select {
+			case <-s.statsChanged:
+			default:
+			}
+		}
+	}
+}
+
+// withLiveStats adds what is never materialized to the stats: whether each subsystem
+// is paused, and each retention policy's deletion counts
+func (s *OracleService) withLiveStats(ctx context.Context, stats map[string]interface{}) (map[string]interface{}, error) {
+	if subsystems := s.SubsystemStates(ctx); subsystems != nil {
+		stats["subsystems"] = subsystems
+	}
+
+	slo, err := s.PipelineSLO(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats["pipeline_slo"] = slo
+
+	if s.retention == nil {
+		return stats, nil
+	}
+
+	retention, err := s.retention.Stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats["retention"] = retention
+	return stats, nil
+}
+
+func (s *OracleService) materializedStats(materialized *models.ScoreStats) map[string]interface{} {
+	stats := materialized.Map()
+	stats["materialized"] = true
+	stats["refresh_ms"] = materialized.RefreshMs
+	if replica := s.repo.ReplicaStatus(); replica.Configured {
+		stats["read_replica"] = replica
+	}
+	return stats
 }
 
 // HealthCheck performs health checks on all components
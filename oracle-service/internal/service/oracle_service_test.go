@@ -2,13 +2,22 @@ package service
 
 import (
 	"context"
+	"errors"
+	"math"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/aggregator"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/blockchain"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/chains"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/events"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/schemas"
 	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -21,12 +30,12 @@ func (m *mockOnChainAggregator) FetchMetrics(ctx context.Context, address string
 		UserAddress:         address,
 		WalletAge:           365,
 		TotalTransactions:   100,
-		AvgTransactionValue: 500,
+		AvgTransactionValue: units.DecimalFromInt(500),
 		DeFiInteractions:    25,
 		BorrowingHistory:    10,
 		RepaymentHistory:    10,
 		LiquidationEvents:   0,
-		CollateralValue:     5000,
+		CollateralValue:     units.DecimalFromInt(5000),
 		LastActivity:        time.Now(),
 	}, nil
 }
@@ -48,7 +57,7 @@ func (m *mockOffChainAggregator) FetchMetrics(ctx context.Context, userID, addre
 		IncomeVerified:         true,
 		IncomeLevel:            "medium",
 		EmploymentStatus:       "full-time",
-		DebtToIncomeRatio:      0.30,
+		DebtToIncomeRatio:      units.MustDecimal("0.30"),
 		DataSource:             "mock",
 		LastVerified:           time.Now(),
 	}, nil
@@ -61,7 +70,7 @@ func (m *mockOffChainAggregator) HealthCheck(ctx context.Context) error {
 // Mock blockchain client for testing
 type mockBlockchainClient struct{}
 
-func (m *mockBlockchainClient) UpdateCreditScore(ctx context.Context, address string, score uint16, confidence uint8, dataHash string) (interface{}, error) {
+func (m *mockBlockchainClient) UpdateCreditScore(ctx context.Context, update blockchain.ScoreUpdate) (*types.Transaction, error) {
 	// Return nil to simulate no actual blockchain interaction
 	return nil, nil
 }
@@ -70,11 +79,125 @@ func (m *mockBlockchainClient) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
+func (m *mockBlockchainClient) EstimatePublishCost(ctx context.Context, update blockchain.ScoreUpdate) (*blockchain.PublishCostEstimate, error) {
+	return &blockchain.PublishCostEstimate{
+		GasLimit:     120000,
+		FeeNative:    0.0024,
+		MaxFeeNative: 0.0048,
+		NativeSymbol: "ETH",
+		GasUsedRatio: 0.6,
+		Congestion:   blockchain.CongestionModerate,
+	}, nil
+}
+
+// Mock batch-capable blockchain client that rejects scores below 500
+type mockBatchBlockchainClient struct {
+	mockBlockchainClient
+}
+
+func (m *mockBatchBlockchainClient) PublishScores(ctx context.Context, updates []blockchain.ScoreUpdate) []blockchain.PublishResult {
+	var accepted, rejected []blockchain.ScoreUpdate
+	for _, update := range updates {
+		if update.Score < 500 {
+			rejected = append(rejected, update)
+		} else {
+			accepted = append(accepted, update)
+		}
+	}
+
+	results := []blockchain.PublishResult{{Updates: accepted, Batched: true}}
+	if len(rejected) > 0 {
+		results = append(results, blockchain.PublishResult{Updates: rejected, Batched: true, Err: errors.New("execution reverted")})
+	}
+	return results
+}
+
+// Mock blockchain client for a v2 contract that requires an expiry
+type mockV2BlockchainClient struct {
+	mockBlockchainClient
+	expiries []time.Time
+}
+
+func (m *mockV2BlockchainClient) UpdateCreditScore(ctx context.Context, update blockchain.ScoreUpdate) (*types.Transaction, error) {
+	if update.ExpiresAt.IsZero() {
+		return nil, errors.New("missing expiry")
+	}
+	m.expiries = append(m.expiries, update.ExpiresAt)
+	return nil, nil
+}
+
+func (m *mockV2BlockchainClient) PayloadVersion() uint8 {
+	return blockchain.PayloadV2
+}
+
+// Mock blockchain client reporting a configurable base fee
+type mockGasPricedClient struct {
+	mockBlockchainClient
+	baseFeeGwei float64
+}
+
+func (m *mockGasPricedClient) CurrentBaseFeeGwei(ctx context.Context) (float64, error) {
+	return m.baseFeeGwei, nil
+}
+
+// Mock native token price source for testing, pricing ETH unless chain is set
+type mockPriceSource struct {
+	chain string
+}
+
+func (m *mockPriceSource) GetNativeTokenPriceUSD(ctx context.Context) (float64, error) {
+	return 2500, nil
+}
+
+func (m *mockPriceSource) NativeAsset() chains.NativeAsset {
+	if m.chain == "" {
+		return chains.Native("ethereum")
+	}
+	return chains.Native(m.chain)
+}
+
+type mockWebhookDispatcher struct {
+	events chan ScoreChangedEvent
+}
+
+func (m *mockWebhookDispatcher) Dispatch(ctx context.Context, eventType string, data interface{}) error {
+	m.events <- data.(ScoreChangedEvent)
+	return nil
+}
+
+type mockEventPublisher struct {
+	mu     sync.Mutex
+	events []events.Event
+}
+
+func (m *mockEventPublisher) Publish(ctx context.Context, event events.Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *mockEventPublisher) Close() error { return nil }
+
+func (m *mockEventPublisher) types() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var types []string
+	for _, e := range m.events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
 func setupTestService(t *testing.T) (*OracleService, *gorm.DB) {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	if err != nil {
 		t.Fatalf("Failed to connect to test database: %v", err)
 	}
+	// Every connection to :memory: opens its own empty database, so concurrent
+	// requests must share one
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
 
 	// Auto-migrate models
 	err = db.AutoMigrate(
@@ -83,6 +206,27 @@ func setupTestService(t *testing.T) (*OracleService, *gorm.DB) {
 		&models.OnChainMetrics{},
 		&models.OffChainMetrics{},
 		&models.OracleUpdate{},
+		&models.BureauLink{},
+		&models.BureauAlert{},
+		&models.ScoreEvent{},
+		&models.ScoreStats{},
+		&models.RetentionStat{},
+		&models.DataFreeze{},
+		&models.WalletAuth{},
+		&models.ScoreShare{},
+		&models.AuditLog{},
+		&models.ResearchBudget{},
+		&models.IssuedCredential{},
+		&models.ProviderAgreement{},
+		&models.PositionHealth{},
+		&models.PositionSnapshot{},
+		&models.PipelineTiming{},
+		&models.LiquidationRescore{},
+		&models.DebugTarget{},
+		&models.DebugTrace{},
+		&models.SubsystemState{},
+		&models.ScoreComponent{},
+		&models.JobRun{},
 	)
 	if err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)
@@ -143,6 +287,118 @@ func TestCalculateAndUpdateScore(t *testing.T) {
 	}
 }
 
+func TestScoreChangeWebhook(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+	dispatcher := &mockWebhookDispatcher{events: make(chan ScoreChangedEvent, 2)}
+	service.SetWebhookDispatcher(dispatcher)
+
+	address := "0x1234567890123456789012345678901234567890"
+	score, err := service.CalculateAndUpdateScore(ctx, address, "user123")
+	if err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	select {
+	case event := <-dispatcher.events:
+		if event.Address != address || event.Score != score.Score || event.ChangeReason != models.ChangeReasonManual {
+			t.Errorf("Unexpected score change event: %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a score change webhook for the first score")
+	}
+
+	// Recalculating from unchanged data leaves the score unchanged and sends nothing
+	if _, err := service.CalculateAndUpdateScore(ctx, address, "user123"); err != nil {
+		t.Fatalf("Failed to recalculate score: %v", err)
+	}
+	select {
+	case event := <-dispatcher.events:
+		t.Errorf("Expected no webhook for an unchanged score, got %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestScoreLifecycleEvents(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+	publisher := &mockEventPublisher{}
+	service.SetEventPublisher(publisher)
+
+	address := "0x1234567890123456789012345678901234567890"
+	if _, err := service.CalculateAndUpdateScore(ctx, address, "user123"); err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+	if err := service.PublishScoreToBlockchain(ctx, address); err != nil {
+		t.Fatalf("Failed to publish score: %v", err)
+	}
+
+	types := publisher.types()
+	if len(types) != 2 || types[0] != events.ScoreCalculated || types[1] != events.ScorePublished {
+		t.Errorf("Expected score.calculated then score.published, got %v", types)
+	}
+	if publisher.events[0].Subject != address {
+		t.Errorf("Expected event subject %s, got %s", address, publisher.events[0].Subject)
+	}
+	for _, event := range publisher.events {
+		if want := schemas.Current(schemas.ChannelEvent, event.Type).Path(); event.DataSchema != want {
+			t.Errorf("Expected %s events to reference schema %s, got %q", event.Type, want, event.DataSchema)
+		}
+	}
+
+	// Data that breaks the published contract is never sent
+	service.emit(ctx, events.ScoreCalculated, address, ScoreChangedEvent{Address: address, ChangeReason: "unknown"})
+	if len(publisher.types()) != 2 {
+		t.Errorf("Expected an event not matching its schema to be dropped, got %v", publisher.types())
+	}
+}
+
+func TestRebuildScoreState(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+
+	address := "0x1234567890123456789012345678901234567890"
+	if report, err := service.RebuildScoreState(ctx, address); err != nil || report != nil {
+		t.Fatalf("Expected no state before any events, got %+v, %v", report, err)
+	}
+
+	score, err := service.CalculateAndUpdateScore(ctx, address, "user123")
+	if err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+	if err := service.PublishScoreToBlockchain(ctx, address); err != nil {
+		t.Fatalf("Failed to publish score: %v", err)
+	}
+
+	events, err := service.GetScoreEvents(ctx, address, 0, 0)
+	if err != nil {
+		t.Fatalf("Failed to get score events: %v", err)
+	}
+	want := []string{models.ScoreEventMetricsFetched, models.ScoreEventCalculated, models.ScoreEventPublished}
+	if len(events) != len(want) {
+		t.Fatalf("Expected %d events, got %d", len(want), len(events))
+	}
+	for i, event := range events {
+		if event.EventType != want[i] {
+			t.Errorf("Event %d: expected %s, got %s", i, want[i], event.EventType)
+		}
+	}
+
+	report, err := service.RebuildScoreState(ctx, address)
+	if err != nil {
+		t.Fatalf("Failed to rebuild score state: %v", err)
+	}
+	if !report.Consistent {
+		t.Errorf("Expected replayed state to match the stored score, mismatches: %v", report.Mismatches)
+	}
+	if report.State.Score != score.Score || report.State.PublishedScore != score.Score {
+		t.Errorf("Expected replayed and published score %d, got %+v", score.Score, report.State)
+	}
+	if report.State.OnChainMetrics == nil {
+		t.Error("Expected replayed state to include the fetched on-chain metrics")
+	}
+}
+
 func TestGetScore(t *testing.T) {
 	service, _ := setupTestService(t)
 	ctx := context.Background()
@@ -210,6 +466,45 @@ func TestGetScoreHistory(t *testing.T) {
 	if len(history) != 3 {
 		t.Errorf("Expected 3 history entries, got %d", len(history))
 	}
+
+	// An address that was never scored has no history to return
+	_, err = service.GetScoreHistory(ctx, "0x0000000000000000000000000000000000000001", 10)
+	if !errors.Is(err, ErrScoreNotFound) {
+		t.Errorf("Expected ErrScoreNotFound, got %v", err)
+	}
+}
+
+func TestGetScoreComponents(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+
+	address := "0x1234567890123456789012345678901234567890"
+	if _, err := service.GetScoreComponents(ctx, address); !errors.Is(err, ErrScoreNotFound) {
+		t.Fatalf("Expected ErrScoreNotFound before scoring, got %v", err)
+	}
+
+	var score *models.CreditScore
+	for i := 0; i < 2; i++ {
+		var err error
+		if score, err = service.CalculateAndUpdateScore(ctx, address, "user123"); err != nil {
+			t.Fatalf("Failed to calculate score: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond) // Ensure different timestamps
+	}
+
+	// Only the latest calculation's factors are returned
+	components, err := service.GetScoreComponents(ctx, address)
+	if err != nil {
+		t.Fatalf("Failed to get components: %v", err)
+	}
+	if len(components) == 0 || len(components) != len(score.Components) {
+		t.Fatalf("Expected %d components, got %d", len(score.Components), len(components))
+	}
+	for _, component := range components {
+		if component.DataHash != score.DataHash || !component.CalculatedAt.Equal(score.LastUpdated) {
+			t.Errorf("Expected components of the latest calculation, got %+v", component)
+		}
+	}
 }
 
 func TestPublishScoreToBlockchain(t *testing.T) {
@@ -245,6 +540,271 @@ func TestPublishScoreNotFound(t *testing.T) {
 	}
 }
 
+func TestPublishRecordsPayloadVersion(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+
+	address := "0x1234567890123456789012345678901234567890"
+	score, err := service.CalculateAndUpdateScore(ctx, address, "")
+	if err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	// v1 clients don't report a version
+	if err := service.PublishScoreToBlockchain(ctx, address); err != nil {
+		t.Fatalf("Failed to publish score: %v", err)
+	}
+
+	client := &mockV2BlockchainClient{}
+	service.blockchainClient = client
+	if err := service.PublishScoreToBlockchain(ctx, address); err != nil {
+		t.Fatalf("Failed to publish v2 score: %v", err)
+	}
+	if len(client.expiries) != 1 || !client.expiries[0].Equal(score.NextUpdateDue.Truncate(time.Second)) {
+		t.Errorf("Expected the v2 payload to expire at the next update due, got %v", client.expiries)
+	}
+
+	updates, err := service.ListOracleUpdates(ctx, "", 10)
+	if err != nil {
+		t.Fatalf("Failed to list oracle updates: %v", err)
+	}
+	if len(updates) != 2 {
+		t.Fatalf("Expected 2 oracle updates, got %d", len(updates))
+	}
+	if updates[0].PayloadVersion != blockchain.PayloadV1 || updates[1].PayloadVersion != blockchain.PayloadV2 {
+		t.Errorf("Expected payload versions 1 and 2, got %d and %d", updates[0].PayloadVersion, updates[1].PayloadVersion)
+	}
+	if updates[1].ExpiresAt == nil {
+		t.Error("Expected the v2 update to record its expiry")
+	}
+}
+
+func TestScoreExpiryMargins(t *testing.T) {
+	service, _ := setupTestService(t)
+	now := time.Now()
+
+	score := &models.CreditScore{NextUpdateDue: now.Add(48 * time.Hour)}
+	if expiresAt := service.scoreExpiry(score); !expiresAt.Equal(score.NextUpdateDue.Truncate(time.Second)) {
+		t.Errorf("Expected expiry at the next update due without margins, got %v", expiresAt)
+	}
+
+	service.SetExpiryMargins(24*time.Hour, 0)
+	if expiresAt := service.scoreExpiry(score); !expiresAt.Equal(now.Add(72 * time.Hour).Truncate(time.Second)) {
+		t.Errorf("Expected expiry a day after the next update due, got %v", expiresAt)
+	}
+
+	// The maximum validity caps far-off due dates
+	service.SetExpiryMargins(24*time.Hour, 36*time.Hour)
+	expiresAt := service.scoreExpiry(score)
+	if expiresAt.After(now.Add(36*time.Hour)) || expiresAt.Before(now.Add(36*time.Hour-time.Minute)) {
+		t.Errorf("Expected expiry capped at 36 hours, got %v", expiresAt)
+	}
+
+	if service.scoreExpiry(&models.CreditScore{}) != nil {
+		t.Error("Expected no expiry for a score without a next update due")
+	}
+}
+
+func TestPublishBatch(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+
+	addresses := []string{
+		"0x1111111111111111111111111111111111111111",
+		"0x2222222222222222222222222222222222222222",
+	}
+	for _, address := range addresses {
+		if _, err := service.CalculateAndUpdateScore(ctx, address, ""); err != nil {
+			t.Fatalf("Failed to calculate score: %v", err)
+		}
+	}
+
+	result, err := service.PublishBatch(ctx, nil, 10, false)
+	if err != nil {
+		t.Fatalf("Failed to publish batch: %v", err)
+	}
+	if result.Submitted != 2 || result.Failed != 0 {
+		t.Errorf("Expected 2 submitted and 0 failed, got %d and %d", result.Submitted, result.Failed)
+	}
+
+	// Published scores are no longer pending
+	result, err = service.PublishBatch(ctx, nil, 10, false)
+	if err != nil {
+		t.Fatalf("Failed to publish batch: %v", err)
+	}
+	if result.Requested != 0 {
+		t.Errorf("Expected no unpublished scores, got %d", result.Requested)
+	}
+}
+
+func TestPublishBatchPerItemStatus(t *testing.T) {
+	service, db := setupTestService(t)
+	service.blockchainClient = &mockBatchBlockchainClient{}
+	ctx := context.Background()
+
+	good := "0x1111111111111111111111111111111111111111"
+	bad := "0x2222222222222222222222222222222222222222"
+	for _, address := range []string{good, bad} {
+		if _, err := service.CalculateAndUpdateScore(ctx, address, ""); err != nil {
+			t.Fatalf("Failed to calculate score: %v", err)
+		}
+	}
+	db.Model(&models.CreditScore{}).Where("user_address = ?", bad).Update("score", 450)
+
+	result, err := service.PublishBatch(ctx, []string{good, bad, "0xMissing"}, 0, false)
+	if err != nil {
+		t.Fatalf("Failed to publish batch: %v", err)
+	}
+
+	statuses := make(map[string]string)
+	for _, item := range result.Items {
+		statuses[item.Address] = item.Status
+	}
+	if statuses[good] != BatchItemSubmitted {
+		t.Errorf("Expected %s to be submitted, got %s", good, statuses[good])
+	}
+	if statuses[bad] != BatchItemFailed {
+		t.Errorf("Expected %s to fail, got %s", bad, statuses[bad])
+	}
+	if statuses["0xMissing"] != BatchItemNotFound {
+		t.Errorf("Expected missing address to be not_found, got %s", statuses["0xMissing"])
+	}
+	if result.Transactions != 1 {
+		t.Errorf("Expected 1 transaction, got %d", result.Transactions)
+	}
+}
+
+func TestPublishQueuedUntilGasIsCheap(t *testing.T) {
+	service, _ := setupTestService(t)
+	client := &mockGasPricedClient{baseFeeGwei: 45}
+	service.blockchainClient = client
+	service.SetPublishWindow(&PublishWindow{MaxBaseFeeGwei: 20})
+	ctx := context.Background()
+
+	address := "0x1234567890123456789012345678901234567890"
+	if _, err := service.CalculateAndUpdateScore(ctx, address, ""); err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	queued, err := service.RequestPublish(ctx, address, false)
+	if err != nil {
+		t.Fatalf("Failed to request publish: %v", err)
+	}
+	if !queued {
+		t.Fatal("Expected publish to be queued while base fee is above the ceiling")
+	}
+
+	// Re-requesting replaces the queued entry instead of adding another
+	if _, err := service.RequestPublish(ctx, address, false); err != nil {
+		t.Fatalf("Failed to request publish: %v", err)
+	}
+	updates, err := service.ListOracleUpdates(ctx, models.OracleUpdateQueued, 10)
+	if err != nil {
+		t.Fatalf("Failed to list oracle updates: %v", err)
+	}
+	if len(updates) != 1 {
+		t.Fatalf("Expected 1 queued update, got %d", len(updates))
+	}
+
+	// Still expensive: nothing is sent
+	result, err := service.ProcessPublishQueue(ctx, 10)
+	if err != nil {
+		t.Fatalf("Failed to process publish queue: %v", err)
+	}
+	if result.Window == nil || result.Window.Open {
+		t.Error("Expected closed publish window in result")
+	}
+
+	client.baseFeeGwei = 12
+	result, err = service.ProcessPublishQueue(ctx, 10)
+	if err != nil {
+		t.Fatalf("Failed to process publish queue: %v", err)
+	}
+	if result.Submitted != 1 {
+		t.Errorf("Expected 1 submitted update, got %d", result.Submitted)
+	}
+
+	updates, _ = service.ListOracleUpdates(ctx, models.OracleUpdateQueued, 10)
+	if len(updates) != 0 {
+		t.Errorf("Expected empty queue, got %d", len(updates))
+	}
+}
+
+func TestUrgentPublishBypassesWindow(t *testing.T) {
+	service, _ := setupTestService(t)
+	service.blockchainClient = &mockGasPricedClient{baseFeeGwei: 45}
+	service.SetPublishWindow(&PublishWindow{MaxBaseFeeGwei: 20})
+	ctx := context.Background()
+
+	address := "0x1234567890123456789012345678901234567890"
+	if _, err := service.CalculateAndUpdateScore(ctx, address, ""); err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	result, err := service.PublishBatch(ctx, []string{address}, 0, true)
+	if err != nil {
+		t.Fatalf("Failed to publish batch: %v", err)
+	}
+	if result.Submitted != 1 || result.Queued != 0 {
+		t.Errorf("Expected urgent publish to be submitted, got %d submitted and %d queued", result.Submitted, result.Queued)
+	}
+}
+
+func TestEstimatePublishCost(t *testing.T) {
+	service, _ := setupTestService(t)
+	service.SetPriceSource(&mockPriceSource{})
+	ctx := context.Background()
+
+	address := "0x1234567890123456789012345678901234567890"
+
+	estimate, err := service.EstimatePublishCost(ctx, address)
+	if err != nil {
+		t.Fatalf("Failed to estimate publish cost: %v", err)
+	}
+	if estimate != nil {
+		t.Fatal("Expected nil estimate for address without a score")
+	}
+
+	score, err := service.CalculateAndUpdateScore(ctx, address, "user123")
+	if err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	estimate, err = service.EstimatePublishCost(ctx, address)
+	if err != nil {
+		t.Fatalf("Failed to estimate publish cost: %v", err)
+	}
+	if estimate.Score != score.Score {
+		t.Errorf("Expected score %d, got %d", score.Score, estimate.Score)
+	}
+	if math.Abs(estimate.FeeUSD-6) > 1e-9 {
+		t.Errorf("Expected fee of $6, got %f", estimate.FeeUSD)
+	}
+	if estimate.Congestion != blockchain.CongestionModerate {
+		t.Errorf("Expected moderate congestion, got %s", estimate.Congestion)
+	}
+
+	// A price of another chain's token leaves the fee unconverted
+	service.SetPriceSource(&mockPriceSource{chain: "bsc"})
+	estimate, err = service.EstimatePublishCost(ctx, address)
+	if err != nil {
+		t.Fatalf("Failed to estimate publish cost: %v", err)
+	}
+	if estimate.FeeUSD != 0 || estimate.FeeNative != 0.0024 {
+		t.Errorf("Expected only the native fee for a BNB price, got $%f", estimate.FeeUSD)
+	}
+}
+
+func TestEstimatePublishCostWithoutBlockchainClient(t *testing.T) {
+	service, _ := setupTestService(t)
+	service.blockchainClient = nil
+
+	_, err := service.EstimatePublishCost(context.Background(), "0x1234567890123456789012345678901234567890")
+	if !errors.Is(err, ErrPublishEstimateUnavailable) {
+		t.Errorf("Expected ErrPublishEstimateUnavailable, got %v", err)
+	}
+}
+
 func TestProcessScheduledUpdates(t *testing.T) {
 	service, db := setupTestService(t)
 	ctx := context.Background()
@@ -260,6 +820,7 @@ func TestProcessScheduledUpdates(t *testing.T) {
 			DataHash:      "hash",
 			LastUpdated:   time.Now().Add(-31 * 24 * time.Hour),
 			NextUpdateDue: time.Now().Add(-1 * 24 * time.Hour), // Overdue
+			UpdateCount:   1,
 			IsActive:      true,
 		}
 
@@ -316,6 +877,45 @@ func TestGetStats(t *testing.T) {
 	}
 }
 
+func TestMaterializedStats(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+	service.SetStatsRefresh(time.Hour)
+
+	if _, err := service.CalculateAndUpdateScore(ctx, "0x1111", "user1"); err != nil {
+		t.Fatalf("Failed to create test score: %v", err)
+	}
+
+	// The first request materializes the stats
+	stats, err := service.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+	if stats["total_active_scores"] != int64(1) || stats["materialized"] != true || stats["computed_at"] == nil {
+		t.Errorf("Unexpected materialized stats: %v", stats)
+	}
+
+	// Later requests serve the stored stats until they are refreshed
+	if _, err := service.CalculateAndUpdateScore(ctx, "0x2222", "user2"); err != nil {
+		t.Fatalf("Failed to create test score: %v", err)
+	}
+	stats, err = service.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+	if stats["total_active_scores"] != int64(1) {
+		t.Errorf("Expected stored stats with 1 score, got %v", stats["total_active_scores"])
+	}
+
+	stats, err = service.RefreshStats(ctx)
+	if err != nil {
+		t.Fatalf("Failed to refresh stats: %v", err)
+	}
+	if stats["total_active_scores"] != int64(2) {
+		t.Errorf("Expected refreshed stats with 2 scores, got %v", stats["total_active_scores"])
+	}
+}
+
 func TestHealthCheck(t *testing.T) {
 	service, _ := setupTestService(t)
 	ctx := context.Background()
@@ -339,22 +939,29 @@ func TestCalculateScoreWithOnChainOnly(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to connect to test database: %v", err)
 	}
+	// Every connection to :memory: opens its own empty database, so concurrent
+	// requests must share one
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
 
 	db.AutoMigrate(
 		&models.CreditScore{},
 		&models.ScoreHistory{},
 		&models.OnChainMetrics{},
 		&models.OffChainMetrics{},
+		&models.DataFreeze{},
+		&models.ProviderAgreement{},
+		&models.PositionHealth{},
+		&models.PositionSnapshot{},
+		&models.PipelineTiming{},
+		&models.ScoreComponent{},
 	)
 
 	repo := repository.NewScoreRepository(db)
 	engine := scoring.NewEngine()
 	onChainAgg := &mockOnChainAggregator{}
 
-	// Off-chain aggregator that returns error
-	type failingOffChainAgg struct{}
-	offChainAgg := &failingOffChainAgg{}
-
+	// Off-chain aggregator with no reachable endpoints
 	service := &OracleService{
 		repo:          repo,
 		scoringEngine: engine,
@@ -415,3 +1022,47 @@ func TestConcurrentScoreUpdates(t *testing.T) {
 		t.Error("Score should have been updated at least once")
 	}
 }
+
+func TestProvisionalScoreIsNotPublished(t *testing.T) {
+	service, _ := setupTestService(t)
+	service.blockchainClient = &mockBatchBlockchainClient{}
+	ctx := context.Background()
+
+	// A wallet history no mock wallet has is the only way to a final score
+	policy, err := scoring.NewDataPolicy([]string{scoring.RequirementOnChainHistory}, 100000)
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+	service.SetDataPolicy(policy)
+
+	address := "0x1111111111111111111111111111111111111111"
+	score, err := service.CalculateAndUpdateScore(ctx, address, "")
+	if err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+	if !score.Provisional || score.MissingData != scoring.RequirementOnChainHistory {
+		t.Fatalf("Expected a provisional score missing on-chain history, got %+v", score)
+	}
+
+	if err := service.PublishScoreToBlockchain(ctx, address); !errors.Is(err, ErrScoreProvisional) {
+		t.Errorf("Expected ErrScoreProvisional, got %v", err)
+	}
+	result, err := service.PublishBatch(ctx, nil, 10, true)
+	if err != nil || result.Requested != 0 {
+		t.Errorf("Expected no unpublished final scores, got %+v, %v", result, err)
+	}
+	result, err = service.PublishBatch(ctx, []string{address}, 0, true)
+	if err != nil || len(result.Items) != 1 || result.Items[0].Status != BatchItemProvisional {
+		t.Errorf("Expected the address reported provisional, got %+v, %v", result, err)
+	}
+
+	// Without the policy the same data makes a final score
+	service.SetDataPolicy(nil)
+	score, err = service.CalculateAndUpdateScore(ctx, address, "")
+	if err != nil {
+		t.Fatalf("Failed to recalculate score: %v", err)
+	}
+	if score.Provisional || score.MissingData != "" {
+		t.Errorf("Expected a final score, got %+v", score)
+	}
+}
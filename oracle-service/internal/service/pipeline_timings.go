@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Pipeline latency SLO defaults
+const (
+	DefaultPipelineObjective = 10 * time.Second // p95 of the full update
+	DefaultPipelineSLOWindow = 24 * time.Hour
+)
+
+// StageLatency summarizes one pipeline stage's timings over the SLO window
+type StageLatency struct {
+	Stage        string `json:"stage"`
+	Count        int    `json:"count"` // Calculations that ran the stage
+	P50Ms        int64  `json:"p50_ms"`
+	P95Ms        int64  `json:"p95_ms"`
+	P99Ms        int64  `json:"p99_ms"`
+	MaxMs        int64  `json:"max_ms"`
+	SumMs        int64  `json:"sum_ms"`
+	ObjectiveMs  int64  `json:"objective_p95_ms,omitempty"` // 0 when the stage has no objective
+	WithinTarget int    `json:"within_objective,omitempty"` // Calculations at or under the objective
+	Compliant    *bool  `json:"compliant,omitempty"`        // p95 at or under the objective; nil without one or any timings
+}
+
+// PipelineSLOReport summarizes the stage timings of the score calculations started
+// within the window against the latency objectives
+type PipelineSLOReport struct {
+	Since        time.Time       `json:"since"`
+	Calculations int             `json:"calculations"`
+	Failed       int             `json:"failed"`
+	Stages       []*StageLatency `json:"stages"`    // In pipeline order, then the full update
+	Compliant    bool            `json:"compliant"` // No stage's p95 is over its objective
+}
+
+// Stage returns the summary of a stage, or nil if it isn't reported
+func (r *PipelineSLOReport) Stage(stage string) *StageLatency {
+	for _, latency := range r.Stages {
+		if latency.Stage == stage {
+			return latency
+		}
+	}
+	return nil
+}
+
+// pipelineSLO holds the p95 latency objectives of the score pipeline
+type pipelineSLO struct {
+	objectives map[string]time.Duration // Stage, or models.StageTotal for the full update -> p95 objective
+	window     time.Duration
+}
+
+// SetPipelineSLO sets the p95 latency objectives of the pipeline stages, keyed by
+// stage or models.StageTotal for the full update, and the window compliance is
+// reported over. Without it the full update's p95 objective is
+// DefaultPipelineObjective over DefaultPipelineSLOWindow.
+func (s *OracleService) SetPipelineSLO(objectives map[string]time.Duration, window time.Duration) {
+	if window <= 0 {
+		window = DefaultPipelineSLOWindow
+	}
+	s.slo = &pipelineSLO{objectives: objectives, window: window}
+}
+
+func (s *OracleService) pipelineSLO() *pipelineSLO {
+	if s.slo == nil {
+		return &pipelineSLO{
+			objectives: map[string]time.Duration{models.StageTotal: DefaultPipelineObjective},
+			window:     DefaultPipelineSLOWindow,
+		}
+	}
+	return s.slo
+}
+
+// ParsePipelineObjectives converts p95 objectives in milliseconds by stage, such as
+// "total" -> 10000, checking every stage exists. Stages set to 0 have no objective.
+func ParsePipelineObjectives(objectivesMs map[string]int) (map[string]time.Duration, error) {
+	objectives := make(map[string]time.Duration, len(objectivesMs))
+	for stage, ms := range objectivesMs {
+		if !isPipelineStage(stage) {
+			return nil, fmt.Errorf("unknown pipeline stage %q, stages are %s and %s",
+				stage, strings.Join(models.PipelineStages, ", "), models.StageTotal)
+		}
+		if ms > 0 {
+			objectives[stage] = time.Duration(ms) * time.Millisecond
+		}
+	}
+	return objectives, nil
+}
+
+func isPipelineStage(stage string) bool {
+	if stage == models.StageTotal {
+		return true
+	}
+	for _, known := range models.PipelineStages {
+		if stage == known {
+			return true
+		}
+	}
+	return false
+}
+
+// pipelineTimer times the stages of one score calculation as it runs. Each mark adds
+// the time since the previous mark to a stage, so stages that run in several steps
+// add up.
+type pipelineTimer struct {
+	timing *models.PipelineTiming
+	stages map[string]time.Duration
+	last   time.Time
+}
+
+func startPipelineTimer(address, reason string) *pipelineTimer {
+	now := time.Now()
+	return &pipelineTimer{
+		timing: &models.PipelineTiming{UserAddress: address, Reason: reason, StartedAt: now},
+		stages: make(map[string]time.Duration, len(models.PipelineStages)),
+		last:   now,
+	}
+}
+
+// mark adds the time since the previous mark to stage
+func (t *pipelineTimer) mark(stage string) {
+	now := time.Now()
+	t.stages[stage] += now.Sub(t.last)
+	t.last = now
+}
+
+// fail marks stage as the one the calculation failed in
+func (t *pipelineTimer) fail(stage string) {
+	t.mark(stage)
+	t.timing.FailedStage = stage
+}
+
+// recordTiming saves a calculation's stage timings, with the data hash of the score
+// it calculated so its publication can be timed too. Timings over an objective are
+// logged with every stage, so the slow one stands out. Recording failures are logged
+// rather than failing the calculation.
+func (s *OracleService) recordTiming(ctx context.Context, t *pipelineTimer, score *models.CreditScore) {
+	timing := t.timing
+	if score != nil {
+		timing.DataHash = score.DataHash
+	}
+	timing.FetchOnChainMs = t.stageMs(models.StageFetchOnChain)
+	timing.FetchOffChainMs = t.stageMs(models.StageFetchOffChain)
+	timing.ScoreMs = t.stageMs(models.StageScore)
+	timing.PersistMs = t.stageMs(models.StagePersist)
+
+	fields := []zap.Field{zap.String("address", timing.UserAddress)}
+	for _, stage := range models.PipelineStages {
+		if ms, ran := timing.StageMs(stage); ran {
+			timing.TotalMs += ms
+			fields = append(fields, zap.Int64(stage+"_ms", ms))
+		}
+	}
+
+	if err := s.repo.CreatePipelineTiming(ctx, timing); err != nil {
+		logger.Error("Failed to record pipeline timing", zap.String("address", timing.UserAddress), zap.Error(err))
+	}
+
+	for stage, objective := range s.pipelineSLO().objectives {
+		if ms, ran := timing.StageMs(stage); ran && time.Duration(ms)*time.Millisecond > objective {
+			logger.Warn("Score calculation over its latency objective", append(fields,
+				zap.String("stage", stage),
+				zap.Duration("objective", objective),
+			)...)
+		}
+	}
+}
+
+// stageMs returns the milliseconds marked for a stage, or nil if it wasn't marked
+func (t *pipelineTimer) stageMs(stage string) *int64 {
+	duration, ok := t.stages[stage]
+	if !ok {
+		return nil
+	}
+	ms := duration.Milliseconds()
+	return &ms
+}
+
+// recordPublishTiming adds a submission's time to the timing of the calculation
+// that produced the published score
+func (s *OracleService) recordPublishTiming(ctx context.Context, address, dataHash string, publish time.Duration, failed bool) {
+	if err := s.repo.RecordPublishTiming(ctx, address, dataHash, publish, failed); err != nil {
+		logger.Error("Failed to record publish timing", zap.String("address", address), zap.Error(err))
+	}
+}
+
+// PipelineSLO reports the stage latencies of the score calculations started within
+// the SLO window, and whether each stage meets its p95 objective. Failed calculations
+// count towards the stages they ran, including the one that failed, since a provider
+// timing out is as slow as one answering late. The full update covers every
+// calculation, with its publication once the score is submitted.
+func (s *OracleService) PipelineSLO(ctx context.Context) (*PipelineSLOReport, error) {
+	slo := s.pipelineSLO()
+	since := time.Now().Add(-slo.window)
+	timings, err := s.repo.ListPipelineTimings(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PipelineSLOReport{
+		Since:        since,
+		Calculations: len(timings),
+		Compliant:    true,
+	}
+	for _, timing := range timings {
+		if timing.FailedStage != "" {
+			report.Failed++
+		}
+	}
+
+	for _, stage := range append(append([]string{}, models.PipelineStages...), models.StageTotal) {
+		latency := &StageLatency{Stage: stage}
+		objective, hasObjective := slo.objectives[stage]
+		if hasObjective {
+			latency.ObjectiveMs = objective.Milliseconds()
+		}
+
+		var durations []int64
+		for _, timing := range timings {
+			ms, ran := timing.StageMs(stage)
+			if !ran {
+				continue
+			}
+			durations = append(durations, ms)
+			latency.SumMs += ms
+			if hasObjective && ms <= latency.ObjectiveMs {
+				latency.WithinTarget++
+			}
+		}
+		latency.Count = len(durations)
+
+		if len(durations) > 0 {
+			sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+			latency.P50Ms = nearestRank(durations, 0.50)
+			latency.P95Ms = nearestRank(durations, 0.95)
+			latency.P99Ms = nearestRank(durations, 0.99)
+			latency.MaxMs = durations[len(durations)-1]
+			if hasObjective {
+				compliant := latency.P95Ms <= latency.ObjectiveMs
+				latency.Compliant = &compliant
+				report.Compliant = report.Compliant && compliant
+			}
+		}
+		report.Stages = append(report.Stages, latency)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+func TestPipelineTimings(t *testing.T) {
+	service, db := setupTestService(t)
+	ctx := context.Background()
+
+	address := "0x1234567890123456789012345678901234567890"
+	score, err := service.CalculateAndUpdateScore(ctx, address, "")
+	if err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	// Every stage up to persisting the score is timed, publishing once it's submitted
+	var timing models.PipelineTiming
+	if err := db.Where("user_address = ?", address).First(&timing).Error; err != nil {
+		t.Fatalf("Expected a pipeline timing: %v", err)
+	}
+	if timing.DataHash != score.DataHash || timing.FailedStage != "" {
+		t.Errorf("Expected a successful timing of the calculated score, got %+v", timing)
+	}
+	for _, stage := range []string{models.StageFetchOnChain, models.StageFetchOffChain, models.StageScore, models.StagePersist} {
+		if _, ran := timing.StageMs(stage); !ran {
+			t.Errorf("Expected stage %s to be timed", stage)
+		}
+	}
+	if timing.PublishMs != nil {
+		t.Errorf("Expected no publish timing before the score is published")
+	}
+
+	if err := service.PublishScoreToBlockchain(ctx, address); err != nil {
+		t.Fatalf("Failed to publish score: %v", err)
+	}
+	db.First(&timing, timing.ID)
+	if timing.PublishMs == nil {
+		t.Fatalf("Expected the publish to be timed")
+	}
+
+	// Publishing the same score again doesn't time it twice
+	db.Model(&timing).Update("publish_ms", 400)
+	if err := service.PublishScoreToBlockchain(ctx, address); err != nil {
+		t.Fatalf("Failed to publish score: %v", err)
+	}
+	db.First(&timing, timing.ID)
+	if *timing.PublishMs != 400 {
+		t.Errorf("Expected the first publish timing to be kept, got %d", *timing.PublishMs)
+	}
+
+	// A slow update puts the full update over its objective
+	objectives, err := ParsePipelineObjectives(map[string]int{models.StageTotal: 1000, models.StageScore: 0})
+	if err != nil {
+		t.Fatalf("Failed to parse objectives: %v", err)
+	}
+	service.SetPipelineSLO(objectives, time.Hour)
+	if _, err := service.CalculateAndUpdateScore(ctx, "0x2222222222222222222222222222222222222222", ""); err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+	db.Model(&timing).Update("total_ms", 12000)
+
+	report, err := service.PipelineSLO(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get pipeline SLO: %v", err)
+	}
+	if report.Calculations != 2 || report.Failed != 0 || report.Compliant {
+		t.Errorf("Expected 2 calculations over the objective, got %+v", report)
+	}
+	total := report.Stage(models.StageTotal)
+	if total.Count != 2 || total.MaxMs != 12000 || total.P95Ms != 12000 || total.WithinTarget != 1 || total.Compliant == nil || *total.Compliant {
+		t.Errorf("Expected the full update's p95 over its objective, got %+v", total)
+	}
+	if publish := report.Stage(models.StagePublish); publish.Count != 1 || publish.Compliant != nil {
+		t.Errorf("Expected one publish without an objective, got %+v", publish)
+	}
+	if scored := report.Stage(models.StageScore); scored.Count != 2 || scored.ObjectiveMs != 0 {
+		t.Errorf("Expected the score stage without an objective, got %+v", scored)
+	}
+
+	if _, err := ParsePipelineObjectives(map[string]int{"fetch": 100}); err == nil {
+		t.Error("Expected an unknown stage to be rejected")
+	}
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/blockchain"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/events"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/webhooks"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// HealthFactorSource reads the current health of an address's borrowing on a lending
+// protocol
+type HealthFactorSource interface {
+	AccountHealth(ctx context.Context, address string) (*blockchain.AccountHealth, error)
+}
+
+// PositionAtRiskEvent is the payload of position.at_risk webhooks and events
+type PositionAtRiskEvent struct {
+	Address       string           `json:"address"`
+	Protocol      string           `json:"protocol"`
+	HealthFactor  float64          `json:"health_factor"`
+	Threshold     float64          `json:"threshold"`
+	CollateralUSD float64          `json:"collateral_usd"`
+	DebtUSD       float64          `json:"debt_usd"`
+	Confidence    units.Confidence `json:"confidence,omitempty"` // Confidence of the address's score while the position is at risk
+	CheckedAt     time.Time        `json:"checked_at"`
+}
+
+// healthMonitor watches the lending positions of borrowing addresses between score
+// refreshes
+type healthMonitor struct {
+	source    HealthFactorSource
+	threshold float64 // Positions with a lower health factor are at risk
+}
+
+// SetHealthMonitor checks the health factors of borrowing addresses with source.
+// Positions whose health factor falls below threshold are at risk of liquidation.
+func (s *OracleService) SetHealthMonitor(source HealthFactorSource, threshold float64) {
+	s.health = &healthMonitor{source: source, threshold: threshold}
+}
+
+// CheckPositionHealth reads the current health of an address's lending position and
+// records it. When the position becomes or stops being at risk the address's score is
+// recalculated from its stored metrics, so its confidence reflects the position before
+// the next refresh, and a position that becomes at risk sends a position.at_risk alert.
+func (s *OracleService) CheckPositionHealth(ctx context.Context, address string) (*models.PositionHealth, error) {
+	if s.health == nil {
+		return nil, fmt.Errorf("health monitoring not configured")
+	}
+
+	account, err := s.health.source.AccountHealth(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get position health: %w", err)
+	}
+
+	positions, err := s.repo.GetPositionHealth(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	var previous *models.PositionHealth
+	for _, position := range positions {
+		if position.Protocol == account.Protocol {
+			previous = position
+		}
+	}
+
+	now := time.Now()
+	health := &models.PositionHealth{
+		UserAddress:   address,
+		Protocol:      account.Protocol,
+		HealthFactor:  account.HealthFactor,
+		CollateralUSD: account.CollateralUSD,
+		DebtUSD:       account.DebtUSD,
+		AtRisk:        account.HasDebt() && account.HealthFactor < s.health.threshold,
+		CheckedAt:     now,
+	}
+	wasAtRisk := previous != nil && previous.AtRisk
+	switch {
+	case health.AtRisk && wasAtRisk:
+		health.AtRiskSince = previous.AtRiskSince
+	case health.AtRisk:
+		health.AtRiskSince = &now
+	}
+
+	if err := s.repo.SavePositionHealth(ctx, health); err != nil {
+		return nil, err
+	}
+	if s.liquidations != nil && liquidatedSince(previous, account) {
+		report := LiquidationReport{
+			Address:    address,
+			Protocol:   account.Protocol,
+			Source:     models.LiquidationSourceHealthMonitor,
+			DetectedAt: now,
+		}
+		if _, err := s.ReportLiquidation(ctx, report); err != nil {
+			logger.Error("Failed to report liquidation", zap.String("address", address), zap.Error(err))
+		}
+	}
+	if health.AtRisk == wasAtRisk {
+		return health, nil
+	}
+
+	logger.Info("Lending position risk changed",
+		zap.String("address", address),
+		zap.String("protocol", health.Protocol),
+		zap.Float64("healthFactor", health.HealthFactor),
+		zap.Bool("atRisk", health.AtRisk),
+	)
+
+	score, err := s.rescoreFromStoredMetrics(ctx, address, models.ChangeReasonPositionRisk)
+	if err != nil {
+		logger.Error("Failed to recalculate score after position risk change", zap.String("address", address), zap.Error(err))
+	}
+
+	if health.AtRisk {
+		alert := PositionAtRiskEvent{
+			Address:       address,
+			Protocol:      health.Protocol,
+			HealthFactor:  health.HealthFactor,
+			Threshold:     s.health.threshold,
+			CollateralUSD: health.CollateralUSD,
+			DebtUSD:       health.DebtUSD,
+			CheckedAt:     now,
+		}
+		if score != nil {
+			alert.Confidence = score.Confidence
+		}
+		s.emit(ctx, events.PositionAtRisk, address, alert)
+		s.notifyPositionAtRisk(alert)
+	}
+
+	return health, nil
+}
+
+// liquidatedSince reports whether a position at risk at its previous check looks
+// liquidated: both its debt and its collateral fell, as when a liquidator repays debt
+// and seizes collateral
+func liquidatedSince(previous *models.PositionHealth, account *blockchain.AccountHealth) bool {
+	return previous != nil && previous.AtRisk &&
+		account.DebtUSD < previous.DebtUSD &&
+		account.CollateralUSD < previous.CollateralUSD
+}
+
+// notifyPositionAtRisk sends a position.at_risk webhook
+func (s *OracleService) notifyPositionAtRisk(alert PositionAtRiskEvent) {
+	if s.webhooks == nil {
+		return
+	}
+
+	s.dispatchWebhook(webhooks.EventPositionAtRisk, alert.Address, alert)
+}
+
+// rescoreFromStoredMetrics recalculates an address's score from its stored metrics
+// without calling the providers. The next scheduled refresh keeps its due date. It
+// returns nil if the address has no score.
+func (s *OracleService) rescoreFromStoredMetrics(ctx context.Context, address, reason string) (*models.CreditScore, error) {
+	previous, err := s.repo.GetByAddress(ctx, address)
+	if err != nil || previous == nil {
+		return nil, err
+	}
+	if err := s.checkNotFrozen(ctx, address); err != nil {
+		return nil, err
+	}
+
+	onChainMetrics, err := s.repo.GetOnChainMetrics(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	offChainMetrics, err := s.repo.GetOffChainMetrics(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nothing is fetched, so only scoring and persisting are timed
+	timer := startPipelineTimer(address, reason)
+	score, err := s.calculateScore(ctx, address, onChainMetrics, offChainMetrics)
+	if err != nil {
+		timer.fail(models.StageScore)
+		s.recordTiming(ctx, timer, nil)
+		return nil, fmt.Errorf("failed to calculate score: %w", err)
+	}
+	timer.mark(models.StageScore)
+	score.UserAddress = address
+	score.NextUpdateDue = previous.NextUpdateDue
+
+	if err := s.saveScore(ctx, score, reason); err != nil {
+		timer.fail(models.StagePersist)
+		s.recordTiming(ctx, timer, nil)
+		return nil, err
+	}
+	timer.mark(models.StagePersist)
+	s.recordTiming(ctx, timer, score)
+	return score, nil
+}
+
+// CheckBorrowingPositions checks the position health of every borrowing address and
+// returns how many positions are at risk. Addresses whose check fails are skipped.
+func (s *OracleService) CheckBorrowingPositions(ctx context.Context) (int, error) {
+	run := s.jobs.start(ctx, models.JobHealthMonitor)
+	addresses, err := s.repo.GetBorrowingAddresses(ctx)
+	if err != nil {
+		run.finish(ctx, err)
+		return 0, err
+	}
+
+	atRisk := 0
+	for _, address := range addresses {
+		health, err := s.CheckPositionHealth(ctx, address)
+		if err != nil {
+			logger.Warn("Failed to check position health", zap.String("address", address), zap.Error(err))
+			run.failed(address, err)
+			continue
+		}
+		run.succeeded()
+		if health.AtRisk {
+			atRisk++
+		}
+	}
+	run.finish(ctx, nil)
+
+	logger.Debug("Checked position health",
+		zap.Int("addresses", len(addresses)),
+		zap.Int("atRisk", atRisk),
+	)
+	return atRisk, nil
+}
+
+// RunHealthMonitor checks the positions of borrowing addresses every interval until
+// the context is cancelled
+func (s *OracleService) RunHealthMonitor(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.CheckBorrowingPositions(ctx); err != nil {
+			logger.Error("Failed to check borrowing positions", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// positionRisk is the confidence factor from the address's position health. Lookup
+// failures count as not at risk rather than failing the score.
+func (s *OracleService) positionRisk(ctx context.Context, address string) float64 {
+	positions, err := s.repo.GetPositionHealth(ctx, address)
+	if err != nil {
+		logger.Warn("Failed to get position health", zap.String("address", address), zap.Error(err))
+		return 1
+	}
+	for _, position := range positions {
+		if position.AtRisk {
+			return scoring.AtRiskReliability
+		}
+	}
+	return 1
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/blockchain"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/events"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+type mockHealthSource struct {
+	healthFactor float64
+}
+
+func (m *mockHealthSource) AccountHealth(ctx context.Context, address string) (*blockchain.AccountHealth, error) {
+	return &blockchain.AccountHealth{
+		Protocol:      "aave",
+		CollateralUSD: 10000,
+		DebtUSD:       7000,
+		HealthFactor:  m.healthFactor,
+	}, nil
+}
+
+func countEvents(publisher *mockEventPublisher, eventType string) int {
+	count := 0
+	for _, t := range publisher.types() {
+		if t == eventType {
+			count++
+		}
+	}
+	return count
+}
+
+func TestPositionAtRiskLowersConfidence(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+	publisher := &mockEventPublisher{}
+	service.SetEventPublisher(publisher)
+	source := &mockHealthSource{healthFactor: 1.8}
+	service.SetHealthMonitor(source, 1.1)
+
+	address := "0x1234567890123456789012345678901234567890"
+	before, err := service.CalculateAndUpdateScore(ctx, address, "")
+	if err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	// Only borrowing addresses are monitored
+	if atRisk, err := service.CheckBorrowingPositions(ctx); err != nil || atRisk != 0 {
+		t.Fatalf("Expected no positions checked, got %d, %v", atRisk, err)
+	}
+	if positions, _ := service.repo.GetPositionHealth(ctx, address); len(positions) != 0 {
+		t.Fatalf("Expected a non-borrowing address to be skipped, got %+v", positions)
+	}
+
+	metrics, err := service.repo.GetOnChainMetrics(ctx, address)
+	if err != nil || metrics == nil {
+		t.Fatalf("Expected stored on-chain metrics, got %v, %v", metrics, err)
+	}
+	metrics.OutstandingLoans = 1
+	if err := service.repo.UpsertOnChainMetrics(ctx, metrics); err != nil {
+		t.Fatalf("Failed to save metrics: %v", err)
+	}
+
+	// A healthy position changes nothing
+	if atRisk, err := service.CheckBorrowingPositions(ctx); err != nil || atRisk != 0 {
+		t.Fatalf("Expected a healthy position, got %d at risk, %v", atRisk, err)
+	}
+	if countEvents(publisher, events.PositionAtRisk) != 0 {
+		t.Error("Expected no alert for a healthy position")
+	}
+
+	source.healthFactor = 1.05
+	if atRisk, err := service.CheckBorrowingPositions(ctx); err != nil || atRisk != 1 {
+		t.Fatalf("Expected one position at risk, got %d, %v", atRisk, err)
+	}
+	risky, err := service.GetScore(ctx, address)
+	if err != nil || risky == nil {
+		t.Fatalf("Failed to get score: %v", err)
+	}
+	if risky.Confidence >= before.Confidence {
+		t.Errorf("Expected confidence below %d while at risk, got %d", before.Confidence, risky.Confidence)
+	}
+	if !risky.NextUpdateDue.Equal(before.NextUpdateDue) {
+		t.Errorf("Expected the scheduled refresh to stay due at %v, got %v", before.NextUpdateDue, risky.NextUpdateDue)
+	}
+	history, _ := service.GetScoreHistory(ctx, address, 1)
+	if len(history) != 1 || history[0].ChangeReason != models.ChangeReasonPositionRisk {
+		t.Errorf("Expected a position_risk history record, got %+v", history)
+	}
+
+	// Still at risk: no second alert, and the position keeps when it became at risk
+	first, _ := service.repo.GetPositionHealth(ctx, address)
+	source.healthFactor = 1.02
+	if _, err := service.CheckBorrowingPositions(ctx); err != nil {
+		t.Fatalf("Failed to check positions: %v", err)
+	}
+	if count := countEvents(publisher, events.PositionAtRisk); count != 1 {
+		t.Errorf("Expected one position at risk alert, got %d", count)
+	}
+	positions, _ := service.repo.GetPositionHealth(ctx, address)
+	if len(positions) != 1 || positions[0].HealthFactor != 1.02 || !positions[0].AtRiskSince.Equal(*first[0].AtRiskSince) {
+		t.Errorf("Unexpected position health: %+v", positions)
+	}
+
+	// Recovery restores confidence
+	source.healthFactor = 2
+	if atRisk, err := service.CheckBorrowingPositions(ctx); err != nil || atRisk != 0 {
+		t.Fatalf("Expected the position to recover, got %d at risk, %v", atRisk, err)
+	}
+	recovered, _ := service.GetScore(ctx, address)
+	if recovered.Confidence != before.Confidence {
+		t.Errorf("Expected confidence %d after recovery, got %d", before.Confidence, recovered.Confidence)
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ErrPositionSnapshotsDisabled is returned when no lending protocol is configured for
+// position snapshots
+var ErrPositionSnapshotsDisabled = errors.NotFound("position snapshots are disabled")
+
+// SetPositionSources snapshots the positions of scored addresses on the lending
+// protocols read by sources. The caller takes the snapshots with RunPositionSnapshots.
+func (s *OracleService) SetPositionSources(sources ...HealthFactorSource) {
+	s.positions = sources
+}
+
+// SnapshotAddressPositions records the address's open positions on every configured
+// lending protocol. A position that has closed since the address's last snapshot is
+// recorded once with zero balances, so its history shows when it closed. It returns
+// the snapshots recorded; protocols that fail to read are skipped and their error is
+// returned with the snapshots of the others.
+func (s *OracleService) SnapshotAddressPositions(ctx context.Context, address string, takenAt time.Time) ([]*models.PositionSnapshot, error) {
+	if len(s.positions) == 0 {
+		return nil, ErrPositionSnapshotsDisabled
+	}
+
+	latest, err := s.repo.GetLatestPositionSnapshots(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	open := make(map[string]bool, len(latest))
+	for _, snapshot := range latest {
+		open[snapshot.Protocol] = !snapshot.Closed()
+	}
+
+	var snapshots []*models.PositionSnapshot
+	var readErr error
+	for _, source := range s.positions {
+		account, err := source.AccountHealth(ctx, address)
+		if err != nil {
+			readErr = fmt.Errorf("failed to read lending position: %w", err)
+			continue
+		}
+
+		snapshot := &models.PositionSnapshot{
+			UserAddress:  address,
+			Protocol:     account.Protocol,
+			SuppliedUSD:  account.CollateralUSD,
+			BorrowedUSD:  account.DebtUSD,
+			HealthFactor: account.HealthFactor,
+			TakenAt:      takenAt,
+		}
+		if snapshot.Closed() && !open[account.Protocol] {
+			continue // No position, and none at the last snapshot either
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err := s.repo.CreatePositionSnapshots(ctx, snapshots); err != nil {
+		return nil, err
+	}
+	return snapshots, readErr
+}
+
+// SnapshotPositions records the open lending positions of every scored address and
+// returns how many snapshots were recorded. Addresses whose positions fail to read are
+// skipped. Borrowers found here are picked up by the health monitor at its next check,
+// without waiting for a score refresh to report their loans.
+func (s *OracleService) SnapshotPositions(ctx context.Context) (int, error) {
+	run := s.jobs.start(ctx, models.JobPositionSnapshot)
+	addresses, err := s.repo.GetScoredAddresses(ctx)
+	if err != nil {
+		run.finish(ctx, err)
+		return 0, err
+	}
+
+	// One time per run, so the snapshots of a run line up
+	takenAt := time.Now().UTC()
+	recorded := 0
+	for _, address := range addresses {
+		snapshots, err := s.SnapshotAddressPositions(ctx, address, takenAt)
+		recorded += len(snapshots)
+		if err != nil {
+			logger.Warn("Failed to snapshot lending positions", zap.String("address", address), zap.Error(err))
+			run.failed(address, err)
+			continue
+		}
+		run.succeeded()
+	}
+	run.finish(ctx, nil)
+
+	logger.Debug("Snapshotted lending positions",
+		zap.Int("addresses", len(addresses)),
+		zap.Int("snapshots", recorded),
+	)
+	return recorded, nil
+}
+
+// RunPositionSnapshots snapshots the positions of scored addresses every interval
+// until the context is cancelled
+func (s *OracleService) RunPositionSnapshots(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.SnapshotPositions(ctx); err != nil {
+			logger.Error("Failed to snapshot lending positions", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetPositionHistory returns an address's position snapshots over the last days days,
+// oldest first
+func (s *OracleService) GetPositionHistory(ctx context.Context, address string, days int) ([]*models.PositionSnapshot, error) {
+	if len(s.positions) == 0 {
+		return nil, ErrPositionSnapshotsDisabled
+	}
+
+	score, err := s.repo.GetByAddress(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score: %w", err)
+	}
+	if score == nil {
+		return nil, fmt.Errorf("%w for address %s", ErrScoreNotFound, address)
+	}
+
+	return s.repo.GetPositionSnapshots(ctx, address, time.Now().AddDate(0, 0, -days))
+}
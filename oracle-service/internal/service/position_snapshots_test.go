@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/blockchain"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+)
+
+// Lending protocol with positions by address; other addresses have none
+type snapshotSource struct {
+	protocol  string
+	positions map[string]*blockchain.AccountHealth
+	err       error
+}
+
+func (s *snapshotSource) AccountHealth(ctx context.Context, address string) (*blockchain.AccountHealth, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if position, ok := s.positions[address]; ok {
+		return position, nil
+	}
+	return &blockchain.AccountHealth{Protocol: s.protocol}, nil
+}
+
+func TestSnapshotPositions(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+
+	borrower := "0x1111111111111111111111111111111111111111"
+	saver := "0x2222222222222222222222222222222222222222"
+	for _, address := range []string{borrower, saver} {
+		if _, err := service.CalculateAndUpdateScore(ctx, address, ""); err != nil {
+			t.Fatalf("Failed to calculate score: %v", err)
+		}
+	}
+
+	if _, err := service.SnapshotPositions(ctx); err != nil {
+		t.Fatalf("Expected snapshots to be a no-op without sources, got %v", err)
+	}
+	if _, err := service.GetPositionHistory(ctx, borrower, 30); !errors.Is(err, ErrPositionSnapshotsDisabled) {
+		t.Fatalf("Expected ErrPositionSnapshotsDisabled, got %v", err)
+	}
+
+	aave := &snapshotSource{protocol: "aave", positions: map[string]*blockchain.AccountHealth{
+		borrower: {Protocol: "aave", CollateralUSD: 10000, DebtUSD: 6000, HealthFactor: 1.4},
+	}}
+	compound := &snapshotSource{protocol: "compound", positions: map[string]*blockchain.AccountHealth{
+		saver: {Protocol: "compound", CollateralUSD: 2500},
+	}}
+	service.SetPositionSources(aave, compound)
+
+	recorded, err := service.SnapshotPositions(ctx)
+	if err != nil || recorded != 2 {
+		t.Fatalf("Expected 2 open positions snapshotted, got %d, %v", recorded, err)
+	}
+
+	// The snapshot's debt puts the borrower under health monitoring
+	borrowing, err := service.repo.GetBorrowingAddresses(ctx)
+	if err != nil || len(borrowing) != 1 || borrowing[0] != borrower {
+		t.Fatalf("Expected only the borrower to be monitored, got %v, %v", borrowing, err)
+	}
+
+	// A closed position is recorded once
+	delete(aave.positions, borrower)
+	for i := 0; i < 2; i++ {
+		if _, err := service.SnapshotPositions(ctx); err != nil {
+			t.Fatalf("Failed to snapshot positions: %v", err)
+		}
+	}
+	history, err := service.GetPositionHistory(ctx, borrower, 30)
+	if err != nil {
+		t.Fatalf("Failed to get position history: %v", err)
+	}
+	if len(history) != 2 || history[0].BorrowedUSD != 6000 || !history[1].Closed() {
+		t.Errorf("Expected the open and then the closed position, got %+v", history)
+	}
+	if borrowing, _ := service.repo.GetBorrowingAddresses(ctx); len(borrowing) != 0 {
+		t.Errorf("Expected no borrowers once the loan is repaid, got %v", borrowing)
+	}
+	if history, _ := service.GetPositionHistory(ctx, saver, 30); len(history) != 3 {
+		t.Errorf("Expected a snapshot of the open position per run, got %d", len(history))
+	}
+
+	// A failing protocol doesn't stop the others
+	aave.err = fmt.Errorf("rpc unavailable")
+	snapshots, err := service.SnapshotAddressPositions(ctx, saver, time.Now().UTC())
+	if err == nil || len(snapshots) != 1 || snapshots[0].Protocol != "compound" {
+		t.Errorf("Expected the compound snapshot and the aave error, got %+v, %v", snapshots, err)
+	}
+
+	if _, err := service.GetPositionHistory(ctx, "0x3333333333333333333333333333333333333333", 30); !errors.Is(err, ErrScoreNotFound) {
+		t.Errorf("Expected ErrScoreNotFound, got %v", err)
+	}
+}
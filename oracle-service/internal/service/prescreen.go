@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+)
+
+// Where a pre-screening tier came from
+const (
+	PrescreenSourceScore       = "score"       // The stored score
+	PrescreenSourceEstimate    = "estimate"    // Estimated from stored metrics, with no stored score
+	PrescreenSourceProvisional = "provisional" // The stored score is provisional, so there is no tier
+	PrescreenSourceNone        = "none"        // Nothing is known about the address
+)
+
+// PrescreenItem is the quick tier of one address
+type PrescreenItem struct {
+	Address  string     `json:"address"`
+	Tier     string     `json:"tier"`
+	Source   string     `json:"source"`
+	Stale    bool       `json:"stale,omitempty"`     // The score is past its next update
+	ScoredAt *time.Time `json:"scored_at,omitempty"` // When the score was calculated
+}
+
+// PrescreenResult holds the tiers of a list of addresses, in the order requested
+type PrescreenResult struct {
+	Items []PrescreenItem `json:"items"`
+	Tiers map[string]int  `json:"tiers"` // Number of addresses in each tier
+}
+
+// Prescreen places addresses in tiers from what is already stored, so marketplaces
+// can filter applicants before requesting full scores. Stored scores are used as is,
+// even when due for an update; addresses without one are estimated from their stored
+// metrics. No provider data is pulled and nothing is stored. Tiers are served from
+// the tier cache when it is enabled, until the address's score or metrics change.
+func (s *OracleService) Prescreen(ctx context.Context, addresses []string) (*PrescreenResult, error) {
+	// Taken before loading anything, so tiers worked out from data that changes
+	// meanwhile aren't cached
+	version := s.tierCache.Version()
+
+	cached := make(map[int]PrescreenItem)
+	var uncached []string
+	for i, address := range addresses {
+		if item, ok := s.tierCache.Get(address); ok {
+			item.Address = address
+			cached[i] = item
+		} else {
+			uncached = append(uncached, address)
+		}
+	}
+
+	var scores map[string]*models.CreditScore
+	if len(uncached) > 0 {
+		var err error
+		scores, err = s.repo.GetByAddresses(ctx, uncached)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &PrescreenResult{
+		Items: make([]PrescreenItem, 0, len(addresses)),
+		Tiers: map[string]int{
+			scoring.TierA:       0,
+			scoring.TierB:       0,
+			scoring.TierC:       0,
+			scoring.TierUnknown: 0,
+		},
+	}
+	now := time.Now()
+	for i, address := range addresses {
+		if item, ok := cached[i]; ok {
+			result.Items = append(result.Items, item)
+			result.Tiers[item.Tier]++
+			continue
+		}
+
+		item := PrescreenItem{Address: address, Tier: scoring.TierUnknown, Source: PrescreenSourceNone}
+
+		if score, ok := scores[strings.ToLower(strings.TrimSpace(address))]; ok {
+			scoredAt := score.LastUpdated
+			item.ScoredAt = &scoredAt
+			item.Stale = now.After(score.NextUpdateDue)
+			if score.Provisional {
+				item.Source = PrescreenSourceProvisional
+			} else {
+				item.Tier = scoring.TierFor(score.Score)
+				item.Source = PrescreenSourceScore
+			}
+		} else if err := s.estimateTier(ctx, &item); err != nil {
+			return nil, err
+		}
+		s.tierCache.Set(address, item, version)
+
+		result.Items = append(result.Items, item)
+		result.Tiers[item.Tier]++
+	}
+
+	return result, nil
+}
+
+// estimateTier estimates an unscored address's tier from its stored metrics, if any.
+// Metrics that would only make a provisional score leave the tier unknown.
+func (s *OracleService) estimateTier(ctx context.Context, item *PrescreenItem) error {
+	onChain, err := s.repo.GetOnChainMetrics(ctx, item.Address)
+	if err != nil {
+		return fmt.Errorf("failed to get on-chain metrics: %w", err)
+	}
+	offChain, err := s.repo.GetOffChainMetrics(ctx, item.Address)
+	if err != nil {
+		return fmt.Errorf("failed to get off-chain metrics: %w", err)
+	}
+	if onChain == nil && offChain == nil {
+		return nil
+	}
+
+	if len(s.dataPolicy.Missing(onChain, offChain)) > 0 {
+		item.Source = PrescreenSourceProvisional
+		return nil
+	}
+	estimate, err := s.scoringEngine.CalculateScore(onChain, offChain)
+	if err != nil {
+		return fmt.Errorf("failed to estimate score: %w", err)
+	}
+	item.Tier = scoring.TierFor(estimate.Score)
+	item.Source = PrescreenSourceEstimate
+	return nil
+}
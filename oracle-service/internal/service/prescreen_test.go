@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+)
+
+func TestPrescreen(t *testing.T) {
+	service, db := setupTestService(t)
+	ctx := context.Background()
+	scored := "0x1234567890123456789012345678901234567890"
+	estimated := "0xabcdefabcdefabcdefabcdefabcdefabcdefabcd"
+	unknown := "0x0000000000000000000000000000000000000001"
+
+	score, err := service.CalculateAndUpdateScore(ctx, scored, "")
+	if err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+	// Metrics stored without a score are estimated
+	if _, err := service.CalculateAndUpdateScore(ctx, estimated, ""); err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+	db.Where("user_address = ?", estimated).Delete(&models.CreditScore{})
+
+	result, err := service.Prescreen(ctx, []string{scored, "0xABCDEFABCDEFABCDEFABCDEFABCDEFABCDEFABCD", unknown})
+	if err != nil {
+		t.Fatalf("Failed to pre-screen: %v", err)
+	}
+	if len(result.Items) != 3 {
+		t.Fatalf("Expected 3 items, got %d", len(result.Items))
+	}
+
+	expected := []struct{ tier, source string }{
+		{scoring.TierFor(score.Score), PrescreenSourceScore},
+		{scoring.TierFor(score.Score), PrescreenSourceEstimate},
+		{scoring.TierUnknown, PrescreenSourceNone},
+	}
+	for i, item := range result.Items {
+		if item.Tier != expected[i].tier || item.Source != expected[i].source {
+			t.Errorf("Item %d: expected tier %s from %s, got %+v", i, expected[i].tier, expected[i].source, item)
+		}
+	}
+	if result.Items[0].ScoredAt == nil || result.Items[0].Stale {
+		t.Errorf("Expected a fresh stored score, got %+v", result.Items[0])
+	}
+	if result.Tiers[scoring.TierUnknown] != 1 {
+		t.Errorf("Expected 1 unknown address, got %v", result.Tiers)
+	}
+
+	// Nothing is calculated or stored for the estimate
+	var count int64
+	db.Model(&models.CreditScore{}).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected only the scored address to have a score, got %d", count)
+	}
+}
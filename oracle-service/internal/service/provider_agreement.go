@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/aggregator"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// calculateScore scores the address's metrics, lowering its confidence if the
+// address's providers have a history of disagreeing or a lending position is at risk,
+// and marking it provisional if the metrics fall short of the data policy
+func (s *OracleService) calculateScore(
+	ctx context.Context,
+	address string,
+	onChain *models.OnChainMetrics,
+	offChain *models.OffChainMetrics,
+) (*models.CreditScore, error) {
+	score, err := s.scoringEngine.CalculateScoreWithReliability(onChain, offChain, s.confidenceFactor(ctx, address))
+	if err != nil {
+		return nil, err
+	}
+	s.applyDataPolicy(score, onChain, offChain)
+	return score, nil
+}
+
+// confidenceFactor scales an address's confidence by the reliability of its providers
+// and, while one of its lending positions is at risk of liquidation, by the risk
+func (s *OracleService) confidenceFactor(ctx context.Context, address string) float64 {
+	return s.dataReliability(ctx, address) * s.positionRisk(ctx, address)
+}
+
+// dataReliability is the confidence factor from the address's provider agreement
+// history. Lookup failures count as fully reliable rather than failing the score.
+func (s *OracleService) dataReliability(ctx context.Context, address string) float64 {
+	agreement, err := s.repo.GetProviderAgreement(ctx, address)
+	if err != nil {
+		logger.Warn("Failed to get provider agreement", zap.String("address", address), zap.Error(err))
+		return 1
+	}
+	if agreement == nil {
+		return 1
+	}
+	return scoring.DataReliability(agreement.Comparisons, agreement.Disagreements)
+}
+
+// recordProviderComparisons adds a fetch's provider comparisons to the address's
+// agreement history
+func (s *OracleService) recordProviderComparisons(ctx context.Context, address string, comparisons []aggregator.ProviderComparison) {
+	if len(comparisons) == 0 {
+		return
+	}
+
+	var disagreements uint32
+	var conflicts []string
+	for _, comparison := range comparisons {
+		if comparison.Agreed {
+			continue
+		}
+		disagreements++
+		conflicts = append(conflicts, comparison.Field)
+	}
+
+	if err := s.repo.RecordProviderComparisons(ctx, address, uint32(len(comparisons)), disagreements, strings.Join(conflicts, ",")); err != nil {
+		logger.Error("Failed to record provider comparisons", zap.String("address", address), zap.Error(err))
+		return
+	}
+	if disagreements > 0 {
+		logger.Info("Providers disagree",
+			zap.String("address", address),
+			zap.Strings("fields", conflicts),
+		)
+	}
+}
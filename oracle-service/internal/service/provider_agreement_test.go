@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/aggregator"
+)
+
+func TestProviderDisagreementLowersConfidence(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+	address := "0x1234567890123456789012345678901234567890"
+
+	before, err := service.CalculateAndUpdateScore(ctx, address, "")
+	if err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	// Agreement alone doesn't change confidence
+	service.recordProviderComparisons(ctx, address, []aggregator.ProviderComparison{
+		{Field: "income", Providers: [2]string{aggregator.ProviderCreditBureau, aggregator.ProviderPlaid}, Agreed: true},
+	})
+	if reliability := service.dataReliability(ctx, address); reliability != 1 {
+		t.Errorf("Expected full reliability after agreement, got %f", reliability)
+	}
+
+	for i := 0; i < 5; i++ {
+		service.recordProviderComparisons(ctx, address, []aggregator.ProviderComparison{
+			{Field: "income", Providers: [2]string{aggregator.ProviderCreditBureau, aggregator.ProviderPlaid}, Agreed: false},
+			{Field: "employment_status", Providers: [2]string{aggregator.ProviderCreditBureau, aggregator.ProviderEmployment}, Agreed: true},
+		})
+	}
+
+	agreement, err := service.repo.GetProviderAgreement(ctx, address)
+	if err != nil || agreement == nil {
+		t.Fatalf("Expected provider agreement history, got %v, %v", agreement, err)
+	}
+	if agreement.Comparisons != 11 || agreement.Disagreements != 5 || agreement.LastConflicts != "income" {
+		t.Errorf("Unexpected agreement history: %+v", agreement)
+	}
+
+	after, err := service.CalculateAndUpdateScore(ctx, address, "")
+	if err != nil {
+		t.Fatalf("Failed to recalculate score: %v", err)
+	}
+	if after.Confidence >= before.Confidence {
+		t.Errorf("Expected confidence below %d after chronic disagreement, got %d", before.Confidence, after.Confidence)
+	}
+
+	explanation, err := service.ExplainScore(ctx, address)
+	if err != nil {
+		t.Fatalf("Failed to explain score: %v", err)
+	}
+	if explanation.Confidence != after.Confidence {
+		t.Errorf("Expected explanation confidence %d, got %d", after.Confidence, explanation.Confidence)
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/pause"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/providers"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ErrEmptyProviderCredential is returned when rotating to an empty credential
+var ErrEmptyProviderCredential = errors.Validation("credential must not be empty")
+
+// RotateProviderCredential replaces a provider's API key or secret without a restart:
+// the credit bureau API key, the Plaid secret or the Covalent API key, named by their
+// subsystem. The new credential is used only once the provider accepts it in a health
+// check; until then calls keep using the old one. Both outcomes are audited with the
+// credential's fingerprint, never the credential itself.
+func (s *EnhancedOracleService) RotateProviderCredential(ctx context.Context, provider, credential string, requester Requester) error {
+	var rotate func(context.Context, string) error
+	switch provider {
+	case pause.CreditBureau:
+		rotate = s.creditBureauProvider.RotateAPIKey
+	case pause.Plaid:
+		rotate = s.plaidProvider.RotateSecret
+	case pause.BlockchainData:
+		rotate = s.blockchainProvider.RotateAPIKey
+	default:
+		return errors.NotFound("provider %q has no rotatable credential", provider)
+	}
+	if credential == "" {
+		return ErrEmptyProviderCredential
+	}
+
+	fingerprint := providers.Fingerprint(credential)
+	entry := &models.AuditLog{
+		Action:     models.AuditProviderCredentialRotated,
+		Actor:      adminActor(requester),
+		Detail:     fmt.Sprintf("%s: %s", provider, fingerprint),
+		RemoteAddr: requester.RemoteAddr,
+		UserAgent:  requester.UserAgent,
+	}
+
+	err := rotate(ctx, credential)
+	if err != nil {
+		entry.Action = models.AuditProviderCredentialRejected
+		entry.Detail = fmt.Sprintf("%s: %s: %v", provider, fingerprint, err)
+		s.baseService.recordAudit(ctx, entry)
+		logger.Warn("Provider credential rejected",
+			zap.String("provider", provider),
+			zap.String("fingerprint", fingerprint),
+			zap.Error(err),
+		)
+		// An unreachable provider says nothing about the credential; an unclassified
+		// failure is the provider refusing it
+		if errors.KindOf(err) != nil {
+			return err
+		}
+		return errors.Wrap(errors.ErrValidation, fmt.Errorf("provider rejected the credential: %w", err))
+	}
+
+	s.baseService.recordAudit(ctx, entry)
+	logger.Info("Provider credential rotated",
+		zap.String("provider", provider),
+		zap.String("fingerprint", fingerprint),
+	)
+	return nil
+}
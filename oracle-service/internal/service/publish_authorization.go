@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/events"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ErrSignerUnauthorized is returned when a score is published while the oracle contract
+// no longer accepts the signer as an updater
+var ErrSignerUnauthorized = errors.Blockchain("oracle signer is not an authorized updater on the contract")
+
+// AuthorizationChecker is implemented by blockchain clients that can check on chain
+// whether the contract still accepts their signer. Publications through clients
+// without it are sent unchecked.
+type AuthorizationChecker interface {
+	SignerAuthorized(ctx context.Context) (bool, error)
+}
+
+// SignerUnauthorizedEvent is the payload of publisher.signer_unauthorized events
+type SignerUnauthorizedEvent struct {
+	Target    string    `json:"target"`
+	Blocked   int       `json:"blocked"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// checkAuthorized returns ErrSignerUnauthorized when the contract behind client no
+// longer accepts its signer, so the publication is not sent only to revert. A check
+// that fails lets the publication through: the transaction itself shows whether the
+// signer is accepted.
+func (s *OracleService) checkAuthorized(ctx context.Context, client BlockchainClient) error {
+	checker, ok := client.(AuthorizationChecker)
+	if !ok {
+		return nil
+	}
+
+	authorized, err := checker.SignerAuthorized(ctx)
+	if err != nil {
+		logger.Warn("Failed to check oracle signer authorization", zap.Error(err))
+		return nil
+	}
+	if !authorized {
+		return ErrSignerUnauthorized
+	}
+	return nil
+}
+
+// signerUnauthorized alerts operators that publications to a contract were blocked
+// because it no longer accepts the signer
+func (s *OracleService) signerUnauthorized(ctx context.Context, target string, blocked int) {
+	logger.Error("Oracle signer is not authorized, publications blocked",
+		zap.String("target", target),
+		zap.Int("blocked", blocked),
+	)
+	s.emit(ctx, events.SignerUnauthorized, "", SignerUnauthorizedEvent{
+		Target:    target,
+		Blocked:   blocked,
+		CheckedAt: time.Now().UTC(),
+	})
+}
+
+// blockUpdates saves records that were not sent because the contract no longer accepts
+// the signer
+func (s *OracleService) blockUpdates(ctx context.Context, records []*models.OracleUpdate, result *BatchPublishResult) {
+	for _, record := range records {
+		record.Status = models.OracleUpdateBlocked
+		record.ErrorMessage = ErrSignerUnauthorized.Error()
+
+		var err error
+		if record.ID == 0 {
+			err = s.repo.CreateOracleUpdate(ctx, record)
+		} else {
+			err = s.repo.UpdateOracleUpdate(ctx, record)
+		}
+		if err != nil {
+			logger.Error("Failed to save oracle update", zap.Error(err))
+		}
+		s.refreshPublishStatus(ctx, record.UserAddress)
+
+		result.Failed++
+		result.Items = append(result.Items, BatchPublishItem{
+			Address: record.UserAddress,
+			Score:   record.Score,
+			Status:  BatchItemBlocked,
+			Error:   record.ErrorMessage,
+		})
+	}
+	s.signerUnauthorized(ctx, records[0].Target, len(records))
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/blockchain"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/events"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+// Null publisher whose signer has lost its role on the contract
+type revokedPublisher struct {
+	*blockchain.NullPublisher
+	sent int
+}
+
+func (p *revokedPublisher) SignerAuthorized(ctx context.Context) (bool, error) {
+	return false, nil
+}
+
+func (p *revokedPublisher) UpdateCreditScore(ctx context.Context, update blockchain.ScoreUpdate) (*types.Transaction, error) {
+	p.sent++
+	return p.NullPublisher.UpdateCreditScore(ctx, update)
+}
+
+func (p *revokedPublisher) PublishScores(ctx context.Context, updates []blockchain.ScoreUpdate) []blockchain.PublishResult {
+	p.sent += len(updates)
+	return p.NullPublisher.PublishScores(ctx, updates)
+}
+
+func TestUnauthorizedSignerBlocksPublications(t *testing.T) {
+	service, db := setupTestService(t)
+	ctx := context.Background()
+	publisher := &revokedPublisher{NullPublisher: blockchain.NewNullPublisher(nil, "0x00000000000000000000000000000000000000aa", 1, 0, 0)}
+	service.blockchainClient = publisher
+	bus := &mockEventPublisher{}
+	service.SetEventPublisher(bus)
+
+	addresses := []string{"0x1111111111111111111111111111111111111111", "0x2222222222222222222222222222222222222222"}
+	for _, address := range addresses {
+		db.Create(&models.CreditScore{
+			UserAddress:   address,
+			Score:         700,
+			Confidence:    80,
+			DataHash:      "hash",
+			LastUpdated:   time.Now(),
+			NextUpdateDue: time.Now().Add(30 * 24 * time.Hour),
+			IsActive:      true,
+		})
+	}
+
+	err := service.PublishScoreToBlockchain(ctx, addresses[0])
+	if !errors.Is(err, ErrSignerUnauthorized) || errors.KindOf(err) != errors.ErrBlockchain {
+		t.Fatalf("Expected ErrSignerUnauthorized, got %v", err)
+	}
+
+	result, err := service.PublishBatch(ctx, addresses, 0, true)
+	if err != nil {
+		t.Fatalf("Failed to publish batch: %v", err)
+	}
+	if result.Submitted != 0 || result.Failed != 2 || result.Transactions != 0 {
+		t.Errorf("Expected both publications blocked, got %+v", result)
+	}
+	for _, item := range result.Items {
+		if item.Status != BatchItemBlocked {
+			t.Errorf("Expected %s blocked, got %s", item.Address, item.Status)
+		}
+	}
+
+	if publisher.sent != 0 {
+		t.Errorf("Expected nothing sent, got %d updates", publisher.sent)
+	}
+	blocked, err := service.ListOracleUpdates(ctx, models.OracleUpdateBlocked, 10)
+	if err != nil {
+		t.Fatalf("Failed to list oracle updates: %v", err)
+	}
+	if len(blocked) != 3 {
+		t.Errorf("Expected 3 blocked oracle updates, got %d", len(blocked))
+	}
+	score, _ := service.GetScore(ctx, addresses[0])
+	if score.PublishStatus != models.PublishStatusNeverPublished {
+		t.Errorf("Expected a blocked score to stay unpublished, got %s", score.PublishStatus)
+	}
+
+	alerts := 0
+	for _, eventType := range bus.types() {
+		if eventType == events.SignerUnauthorized {
+			alerts++
+		}
+	}
+	if alerts != 2 {
+		t.Errorf("Expected an alert per blocked publish, got %d", alerts)
+	}
+}
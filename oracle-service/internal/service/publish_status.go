@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ReceiptSource is implemented by blockchain clients that can look up the receipts of
+// the transactions they sent. Publications through clients without it stay pending.
+type ReceiptSource interface {
+	GetTransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// ConfirmationResult summarizes a pass over the publications awaiting confirmation
+type ConfirmationResult struct {
+	Checked   int `json:"checked"`
+	Confirmed int `json:"confirmed"`
+	Reverted  int `json:"reverted"`
+	Pending   int `json:"pending"` // Not mined yet, or the receipt could not be read
+}
+
+// ConfirmPublications checks the receipts of up to limit pending publications, marking
+// mined ones confirmed and reverted ones failed, and updates the publish status of
+// their scores
+func (s *OracleService) ConfirmPublications(ctx context.Context, limit int) (*ConfirmationResult, error) {
+	pending, err := s.repo.ListOracleUpdates(ctx, models.OracleUpdatePending, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ConfirmationResult{}
+	receipts := make(map[string]*types.Receipt) // Batched publications share a transaction
+	for _, record := range pending {
+		result.Checked++
+		receipt, ok := receipts[record.TxHash]
+		if !ok {
+			receipt = s.publicationReceipt(ctx, record)
+			receipts[record.TxHash] = receipt
+		}
+
+		switch {
+		case receipt == nil:
+			result.Pending++
+			continue
+		case receipt.Status == types.ReceiptStatusSuccessful:
+			record.Status = models.OracleUpdateConfirmed
+			if receipt.BlockNumber != nil {
+				record.BlockNumber = receipt.BlockNumber.Uint64()
+			}
+			result.Confirmed++
+		default:
+			record.Status = models.OracleUpdateFailed
+			record.ErrorMessage = "transaction reverted"
+			result.Reverted++
+		}
+
+		if err := s.repo.UpdateOracleUpdate(ctx, record); err != nil {
+			logger.Error("Failed to save oracle update", zap.Error(err))
+			continue
+		}
+		s.refreshPublishStatus(ctx, record.UserAddress)
+	}
+
+	return result, nil
+}
+
+// publicationReceipt reads the receipt of a publication's transaction from the client
+// it was sent through, or returns nil while it can't be read
+func (s *OracleService) publicationReceipt(ctx context.Context, record *models.OracleUpdate) *types.Receipt {
+	client := s.blockchainClient
+	if record.Target == models.PublishTargetCanary && s.canary != nil {
+		client = s.canary.client
+	}
+	source, ok := client.(ReceiptSource)
+	if !ok || record.TxHash == "" {
+		return nil
+	}
+
+	receipt, err := source.GetTransactionReceipt(ctx, common.HexToHash(record.TxHash))
+	if err != nil {
+		if !errors.Is(err, ethereum.NotFound) {
+			logger.Warn("Failed to get publication receipt", zap.String("txHash", record.TxHash), zap.Error(err))
+		}
+		return nil
+	}
+	return receipt
+}
+
+// RunConfirmations confirms pending publications every interval until the context is
+// cancelled
+func (s *OracleService) RunConfirmations(ctx context.Context, interval time.Duration, batchSize int) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			run := s.jobs.start(ctx, models.JobConfirmations)
+			result, err := s.ConfirmPublications(ctx, batchSize)
+			if err != nil {
+				logger.Error("Failed to confirm publications", zap.Error(err))
+				run.finish(ctx, err)
+				continue
+			}
+			if result.Checked == 0 {
+				run.skip(ctx, "nothing pending")
+				continue
+			}
+			for i := 0; i < result.Confirmed; i++ {
+				run.succeeded()
+			}
+			for i := 0; i < result.Reverted; i++ {
+				run.failed("", errors.New("transaction reverted"))
+			}
+			run.finish(ctx, nil)
+			if result.Confirmed > 0 || result.Reverted > 0 {
+				logger.Info("Confirmed publications",
+					zap.Int("confirmed", result.Confirmed),
+					zap.Int("reverted", result.Reverted),
+					zap.Int("pending", result.Pending),
+				)
+			}
+		}
+	}
+}
+
+// refreshPublishStatus recomputes an address's score publish status from its
+// publications. Failures are logged; the status is refreshed again on the next
+// publication.
+func (s *OracleService) refreshPublishStatus(ctx context.Context, address string) {
+	score, err := s.repo.GetByAddress(ctx, address)
+	if err != nil || score == nil {
+		return
+	}
+	confirmed, err := s.repo.GetLatestOracleUpdate(ctx, address, models.OracleUpdateConfirmed)
+	if err != nil {
+		logger.Error("Failed to refresh publish status", zap.String("address", address), zap.Error(err))
+		return
+	}
+	inFlight, err := s.repo.GetLatestOracleUpdate(ctx, address, models.OracleUpdateQueued, models.OracleUpdatePending)
+	if err != nil {
+		logger.Error("Failed to refresh publish status", zap.String("address", address), zap.Error(err))
+		return
+	}
+
+	status := publishStatus(score, confirmed, inFlight != nil, time.Now())
+	if status == score.PublishStatus {
+		return
+	}
+	if err := s.repo.SetPublishStatus(ctx, address, status); err != nil {
+		logger.Error("Failed to refresh publish status", zap.String("address", address), zap.Error(err))
+	}
+}
+
+// publishStatus is a score's publish status given its latest confirmed publication, if
+// any, and whether another publication is in flight
+func publishStatus(score *models.CreditScore, confirmed *models.OracleUpdate, inFlight bool, now time.Time) string {
+	current := confirmed != nil &&
+		confirmed.DataHash == score.DataHash &&
+		(confirmed.ExpiresAt == nil || confirmed.ExpiresAt.After(now))
+	switch {
+	case current:
+		return models.PublishStatusOnChain
+	case inFlight:
+		return models.PublishStatusPending
+	case confirmed != nil:
+		return models.PublishStatusStaleOnChain
+	default:
+		return models.PublishStatusNeverPublished
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/blockchain"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+// Null publisher whose transactions all revert
+type revertingPublisher struct {
+	*blockchain.NullPublisher
+}
+
+func (p *revertingPublisher) GetTransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return &types.Receipt{Status: types.ReceiptStatusFailed, TxHash: txHash}, nil
+}
+
+func TestPublishStatusFollowsPublications(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+	publisher := blockchain.NewNullPublisher(nil, "0x00000000000000000000000000000000000000aa", 1, 0, 0)
+	service.blockchainClient = publisher
+
+	address := "0x1111111111111111111111111111111111111111"
+	save := func(dataHash string) {
+		t.Helper()
+		score := &models.CreditScore{
+			UserAddress:   address,
+			Score:         700,
+			Confidence:    80,
+			DataHash:      dataHash,
+			LastUpdated:   time.Now(),
+			NextUpdateDue: time.Now().Add(30 * 24 * time.Hour),
+			IsActive:      true,
+		}
+		if err := service.saveScore(ctx, score, models.ChangeReasonManual); err != nil {
+			t.Fatalf("Failed to save score: %v", err)
+		}
+	}
+	expect := func(want string) {
+		t.Helper()
+		score, err := service.GetScore(ctx, address)
+		if err != nil {
+			t.Fatalf("Failed to get score: %v", err)
+		}
+		if score.PublishStatus != want {
+			t.Errorf("Expected publish status %s, got %s", want, score.PublishStatus)
+		}
+	}
+
+	save("hash-1")
+	expect(models.PublishStatusNeverPublished)
+
+	if err := service.PublishScoreToBlockchain(ctx, address); err != nil {
+		t.Fatalf("Failed to publish score: %v", err)
+	}
+	expect(models.PublishStatusPending)
+
+	result, err := service.ConfirmPublications(ctx, 10)
+	if err != nil {
+		t.Fatalf("Failed to confirm publications: %v", err)
+	}
+	if result.Checked != 1 || result.Confirmed != 1 {
+		t.Errorf("Expected one confirmed publication, got %+v", result)
+	}
+	expect(models.PublishStatusOnChain)
+
+	// Recalculating with the same data keeps the on-chain score current
+	save("hash-1")
+	expect(models.PublishStatusOnChain)
+	save("hash-2")
+	expect(models.PublishStatusStaleOnChain)
+
+	// A reverted publication leaves the older score on-chain
+	service.blockchainClient = &revertingPublisher{publisher}
+	if err := service.PublishScoreToBlockchain(ctx, address); err != nil {
+		t.Fatalf("Failed to publish score: %v", err)
+	}
+	expect(models.PublishStatusPending)
+	result, err = service.ConfirmPublications(ctx, 10)
+	if err != nil {
+		t.Fatalf("Failed to confirm publications: %v", err)
+	}
+	if result.Reverted != 1 {
+		t.Errorf("Expected one reverted publication, got %+v", result)
+	}
+	expect(models.PublishStatusStaleOnChain)
+}
+
+func TestPublishStatusOfExpiredScore(t *testing.T) {
+	now := time.Now()
+	expired := now.Add(-time.Hour)
+	score := &models.CreditScore{DataHash: "hash"}
+	confirmed := &models.OracleUpdate{DataHash: "hash", ExpiresAt: &expired}
+
+	if status := publishStatus(score, confirmed, false, now); status != models.PublishStatusStaleOnChain {
+		t.Errorf("Expected an expired on-chain score to be stale, got %s", status)
+	}
+	if status := publishStatus(score, nil, true, now); status != models.PublishStatusPending {
+		t.Errorf("Expected a first publication in flight to be pending, got %s", status)
+	}
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BaseFeeSource reports the current network base fee
+type BaseFeeSource interface {
+	CurrentBaseFeeGwei(ctx context.Context) (float64, error)
+}
+
+// HourRange is a span of UTC hours [Start, End); ranges wrap past midnight when End <= Start
+type HourRange struct {
+	Start int
+	End   int
+}
+
+// Contains reports whether the hour falls inside the range
+func (r HourRange) Contains(hour int) bool {
+	if r.Start < r.End {
+		return hour >= r.Start && hour < r.End
+	}
+	return hour >= r.Start || hour < r.End
+}
+
+// ParsePublishHours parses a comma-separated list of UTC hour ranges such as "0-6,22-24".
+// A range like "22-6" wraps past midnight.
+func ParsePublishHours(spec string) ([]HourRange, error) {
+	var ranges []HourRange
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		bounds := strings.SplitN(part, "-", 2)
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("invalid hour range %q: expected start-end", part)
+		}
+		start, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid hour range %q: %w", part, err)
+		}
+		end, err := strconv.Atoi(strings.TrimSpace(bounds[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid hour range %q: %w", part, err)
+		}
+		if start < 0 || start > 23 || end < 0 || end > 24 || start == end {
+			return nil, fmt.Errorf("invalid hour range %q: hours must be 0-24 and not equal", part)
+		}
+
+		ranges = append(ranges, HourRange{Start: start, End: end % 24})
+	}
+	return ranges, nil
+}
+
+// PublishWindow restricts when non-urgent score publications are sent on-chain
+type PublishWindow struct {
+	MaxBaseFeeGwei float64     // 0 disables the gas price ceiling
+	Hours          []HourRange // Empty allows publishing at any hour
+	HoursSpec      string      // Original hours configuration, for display
+}
+
+// NewPublishWindow builds a publish window from configuration. It returns nil when no
+// restriction is configured, meaning scores always publish immediately.
+func NewPublishWindow(maxBaseFeeGwei float64, hoursSpec string) (*PublishWindow, error) {
+	hours, err := ParsePublishHours(hoursSpec)
+	if err != nil {
+		return nil, err
+	}
+	if maxBaseFeeGwei <= 0 && len(hours) == 0 {
+		return nil, nil
+	}
+	return &PublishWindow{
+		MaxBaseFeeGwei: maxBaseFeeGwei,
+		Hours:          hours,
+		HoursSpec:      hoursSpec,
+	}, nil
+}
+
+// PublishWindowStatus describes whether non-urgent publications can go out now
+type PublishWindowStatus struct {
+	Open           bool      `json:"open"`
+	Reason         string    `json:"reason,omitempty"` // Why the window is closed
+	BaseFeeGwei    float64   `json:"base_fee_gwei,omitempty"`
+	MaxBaseFeeGwei float64   `json:"max_base_fee_gwei,omitempty"`
+	Hours          string    `json:"hours,omitempty"`
+	CheckedAt      time.Time `json:"checked_at"`
+}
+
+// Check evaluates the window at the given time, fetching the base fee only when the
+// hour restriction passes and a ceiling is configured
+func (w *PublishWindow) Check(ctx context.Context, now time.Time, baseFee BaseFeeSource) PublishWindowStatus {
+	status := PublishWindowStatus{Open: true, CheckedAt: now}
+	if w == nil {
+		return status
+	}
+	status.MaxBaseFeeGwei = w.MaxBaseFeeGwei
+	status.Hours = w.HoursSpec
+
+	if len(w.Hours) > 0 {
+		hour := now.UTC().Hour()
+		inWindow := false
+		for _, r := range w.Hours {
+			if r.Contains(hour) {
+				inWindow = true
+				break
+			}
+		}
+		if !inWindow {
+			status.Open = false
+			status.Reason = fmt.Sprintf("outside publish hours %s UTC", w.HoursSpec)
+			return status
+		}
+	}
+
+	if w.MaxBaseFeeGwei > 0 {
+		if baseFee == nil {
+			status.Open = false
+			status.Reason = "base fee unavailable: blockchain client not configured"
+			return status
+		}
+		fee, err := baseFee.CurrentBaseFeeGwei(ctx)
+		if err != nil {
+			status.Open = false
+			status.Reason = fmt.Sprintf("base fee unavailable: %v", err)
+			return status
+		}
+		status.BaseFeeGwei = fee
+		if fee >= w.MaxBaseFeeGwei {
+			status.Open = false
+			status.Reason = fmt.Sprintf("base fee %.2f gwei at or above ceiling %.2f gwei", fee, w.MaxBaseFeeGwei)
+		}
+	}
+
+	return status
+}
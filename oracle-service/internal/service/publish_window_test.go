@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type fixedBaseFee float64
+
+func (f fixedBaseFee) CurrentBaseFeeGwei(ctx context.Context) (float64, error) {
+	return float64(f), nil
+}
+
+func TestParsePublishHours(t *testing.T) {
+	ranges, err := ParsePublishHours("0-6, 22-24")
+	if err != nil {
+		t.Fatalf("Failed to parse hours: %v", err)
+	}
+	if len(ranges) != 2 {
+		t.Fatalf("Expected 2 ranges, got %d", len(ranges))
+	}
+
+	for _, spec := range []string{"6", "25-3", "4-4", "a-b"} {
+		if _, err := ParsePublishHours(spec); err == nil {
+			t.Errorf("Expected error for %q", spec)
+		}
+	}
+}
+
+func TestHourRangeWrapsMidnight(t *testing.T) {
+	r := HourRange{Start: 22, End: 6}
+
+	for hour, expected := range map[int]bool{23: true, 0: true, 5: true, 6: false, 12: false, 22: true} {
+		if got := r.Contains(hour); got != expected {
+			t.Errorf("Contains(%d) = %v, expected %v", hour, got, expected)
+		}
+	}
+}
+
+func TestPublishWindowCheck(t *testing.T) {
+	ctx := context.Background()
+	window, err := NewPublishWindow(30, "0-6")
+	if err != nil {
+		t.Fatalf("Failed to build window: %v", err)
+	}
+
+	night := time.Date(2024, 3, 1, 3, 0, 0, 0, time.UTC)
+	noon := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	if status := window.Check(ctx, night, fixedBaseFee(12)); !status.Open {
+		t.Errorf("Expected open window at night with cheap gas, got %s", status.Reason)
+	}
+	if status := window.Check(ctx, night, fixedBaseFee(55)); status.Open {
+		t.Error("Expected closed window when base fee is above the ceiling")
+	}
+	if status := window.Check(ctx, noon, fixedBaseFee(12)); status.Open {
+		t.Error("Expected closed window outside publish hours")
+	}
+
+	// No restrictions configured
+	window, _ = NewPublishWindow(0, "")
+	if window != nil {
+		t.Error("Expected nil window without restrictions")
+	}
+	if status := window.Check(ctx, noon, nil); !status.Open {
+		t.Error("Expected nil window to always be open")
+	}
+}
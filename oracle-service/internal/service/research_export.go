@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Research export queries. Each is a histogram over a fixed set of buckets to which a
+// borrower contributes at most one count, so adding or removing a borrower changes
+// one count by one.
+const (
+	ResearchQueryScoreDistribution      = "score_distribution"      // Active final scores per 50-point score bucket
+	ResearchQueryConfidenceDistribution = "confidence_distribution" // Active final scores per 10-point confidence bucket
+)
+
+// confidenceBucketWidth is the confidence points per bucket of confidence_distribution
+const confidenceBucketWidth = 10
+
+var (
+	ErrResearchExportDisabled  = errors.NotFound("research export is not configured")
+	ErrUnknownResearchQuery    = errors.Validation("unknown research query")
+	ErrResearchBudgetExhausted = errors.New("daily privacy budget exhausted")
+)
+
+// ResearchBucket is one noisy count of a research dataset. Count is null when the
+// bucket is suppressed.
+type ResearchBucket struct {
+	Min        int    `json:"min"`
+	Max        int    `json:"max"`
+	Count      *int64 `json:"count"`
+	Suppressed bool   `json:"suppressed,omitempty"`
+}
+
+// ResearchDataset is the differentially private result of a research query. Every
+// count has Laplace noise of scale 1/Epsilon added and is rounded; noisy counts
+// below MinBucketSize are suppressed.
+type ResearchDataset struct {
+	Query           string            `json:"query"`
+	Epsilon         float64           `json:"epsilon"`
+	MinBucketSize   int               `json:"min_bucket_size"`
+	Buckets         []*ResearchBucket `json:"buckets"`
+	BudgetRemaining float64           `json:"budget_remaining"` // Epsilon the partner may still spend today (UTC)
+	GeneratedAt     time.Time         `json:"generated_at"`
+}
+
+// ResearchExport releases aggregate queries to analytics partners under differential
+// privacy. Every query spends epsilon of the partner's daily budget, since repeating
+// a query and averaging the results would otherwise cancel out the noise. Spending is
+// recorded in the database, so restarts and replicas share each partner's budget.
+type ResearchExport struct {
+	epsilon       float64
+	dailyBudget   float64
+	minBucketSize int
+	noise         func(scale float64) float64
+	now           func() time.Time
+}
+
+// NewResearchExport adds Laplace noise calibrated to epsilon to every released count,
+// lets each partner spend dailyBudget epsilon per UTC day and suppresses noisy counts
+// below minBucketSize
+func NewResearchExport(epsilon, dailyBudget float64, minBucketSize int) (*ResearchExport, error) {
+	if epsilon <= 0 || math.IsInf(epsilon, 0) || math.IsNaN(epsilon) {
+		return nil, fmt.Errorf("research epsilon must be positive, got %g", epsilon)
+	}
+	if dailyBudget < epsilon {
+		return nil, fmt.Errorf("daily research budget %g is below the epsilon of one query (%g)", dailyBudget, epsilon)
+	}
+	if minBucketSize < 0 {
+		return nil, fmt.Errorf("research minimum bucket size must not be negative, got %d", minBucketSize)
+	}
+
+	return &ResearchExport{
+		epsilon:       epsilon,
+		dailyBudget:   dailyBudget,
+		minBucketSize: minBucketSize,
+		noise:         laplaceNoise,
+		now:           time.Now,
+	}, nil
+}
+
+// SetResearchExport serves research queries to analytics partners. A nil export
+// disables them.
+func (s *OracleService) SetResearchExport(export *ResearchExport) {
+	s.research = export
+}
+
+// ExportResearch runs a research query for a partner, spending the query's epsilon
+// from the partner's daily budget. It fails with ErrResearchBudgetExhausted once the
+// budget is spent.
+func (s *OracleService) ExportResearch(ctx context.Context, partner, query string, requester Requester) (*ResearchDataset, error) {
+	export := s.research
+	if export == nil {
+		return nil, ErrResearchExportDisabled
+	}
+
+	var (
+		fetch    func(*repository.ScoreRepository, context.Context, int) ([]*repository.ScoreBucketTotals, error)
+		min, max int
+		width    int
+	)
+	switch query {
+	case ResearchQueryScoreDistribution:
+		fetch, min, max, width = (*repository.ScoreRepository).GetScoreDistribution, int(units.MinScore), int(units.MaxScore), distributionBucketWidth
+	case ResearchQueryConfidenceDistribution:
+		fetch, min, max, width = (*repository.ScoreRepository).GetConfidenceDistribution, 0, int(units.MaxConfidence), confidenceBucketWidth
+	default:
+		return nil, ErrUnknownResearchQuery
+	}
+
+	// The query's epsilon is only spent if the totals are read
+	var totals []*repository.ScoreBucketTotals
+	day := export.now().UTC().Format("2006-01-02")
+	spent, ok, err := s.repo.SpendResearchBudget(ctx, partner, day, export.epsilon, export.dailyBudget, func(repo *repository.ScoreRepository) error {
+		var err error
+		totals, err = fetch(repo, ctx, width)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrResearchBudgetExhausted
+	}
+	remaining := export.dailyBudget - spent
+
+	bounds := bucketBounds(min, max, width)
+	counts := make([]int64, len(bounds))
+	for _, bucket := range totals {
+		i := bucket.Bucket
+		if i < 0 {
+			i = 0
+		}
+		if i >= len(counts) {
+			i = len(counts) - 1
+		}
+		counts[i] += bucket.Count
+	}
+
+	// Every bucket of the domain is released, empty or not, so which buckets appear
+	// says nothing about the data
+	dataset := &ResearchDataset{
+		Query:           query,
+		Epsilon:         export.epsilon,
+		MinBucketSize:   export.minBucketSize,
+		BudgetRemaining: remaining,
+		GeneratedAt:     export.now().UTC(),
+	}
+	for i, b := range bounds {
+		bucket := &ResearchBucket{Min: b[0], Max: b[1]}
+		if count := export.noisyCount(counts[i]); count < int64(export.minBucketSize) {
+			bucket.Suppressed = true
+		} else {
+			bucket.Count = &count
+		}
+		dataset.Buckets = append(dataset.Buckets, bucket)
+	}
+
+	s.recordAudit(ctx, &models.AuditLog{
+		Action:     models.AuditResearchExported,
+		Actor:      "research:" + partner,
+		Detail:     fmt.Sprintf("query %s, epsilon %g", query, export.epsilon),
+		RemoteAddr: requester.RemoteAddr,
+		UserAgent:  requester.UserAgent,
+	})
+	logger.Info("Research dataset exported",
+		zap.String("partner", partner),
+		zap.String("query", query),
+		zap.Float64("budgetRemaining", remaining),
+	)
+
+	return dataset, nil
+}
+
+// noisyCount adds Laplace noise to a count and rounds it, never below zero
+func (e *ResearchExport) noisyCount(count int64) int64 {
+	noisy := math.Round(float64(count) + e.noise(1/e.epsilon))
+	if noisy < 0 {
+		return 0
+	}
+	return int64(noisy)
+}
+
+// laplaceNoise draws from the Laplace distribution centred on zero with the given
+// scale, using a cryptographic source so the noise can't be predicted
+func laplaceNoise(scale float64) float64 {
+	var buf [8]byte
+	for {
+		if _, err := rand.Read(buf[:]); err != nil {
+			panic(fmt.Sprintf("crypto/rand failed: %v", err))
+		}
+		// Uniform in (-0.5, 0.5); -0.5 itself would take the log of zero
+		u := float64(binary.BigEndian.Uint64(buf[:])>>11)/(1<<53) - 0.5
+		if u == -0.5 {
+			continue
+		}
+		if u < 0 {
+			return scale * math.Log(1+2*u)
+		}
+		return -scale * math.Log(1-2*u)
+	}
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+func TestLaplaceNoise(t *testing.T) {
+	const samples = 20000
+	var sum, absSum float64
+	for i := 0; i < samples; i++ {
+		x := laplaceNoise(2)
+		sum += x
+		absSum += math.Abs(x)
+	}
+
+	// The Laplace distribution has mean 0 and mean absolute deviation equal to its scale
+	if mean := sum / samples; math.Abs(mean) > 0.1 {
+		t.Errorf("Expected mean near 0, got %f", mean)
+	}
+	if mad := absSum / samples; math.Abs(mad-2) > 0.1 {
+		t.Errorf("Expected mean absolute deviation near 2, got %f", mad)
+	}
+}
+
+func TestNewResearchExportValidation(t *testing.T) {
+	if _, err := NewResearchExport(0, 5, 10); err == nil {
+		t.Error("Expected a zero epsilon to be rejected")
+	}
+	if _, err := NewResearchExport(2, 1, 10); err == nil {
+		t.Error("Expected a budget below one query to be rejected")
+	}
+	if _, err := NewResearchExport(1, 5, -1); err == nil {
+		t.Error("Expected a negative bucket size to be rejected")
+	}
+}
+
+func TestExportResearch(t *testing.T) {
+	service, db := setupTestService(t)
+	ctx := context.Background()
+
+	for i := 0; i < 30; i++ {
+		confidence := units.Confidence(85)
+		if i < 3 {
+			confidence = 15
+		}
+		db.Create(&models.CreditScore{
+			UserAddress: fmt.Sprintf("0x%040x", i+1),
+			Score:       720,
+			Confidence:  confidence,
+			DataHash:    "hash",
+			LastUpdated: time.Now(),
+		})
+	}
+
+	if _, err := service.ExportResearch(ctx, "acme", ResearchQueryScoreDistribution, Requester{}); !errors.Is(err, ErrResearchExportDisabled) {
+		t.Errorf("Expected ErrResearchExportDisabled, got %v", err)
+	}
+
+	export, err := NewResearchExport(1, 2, 5)
+	if err != nil {
+		t.Fatalf("Failed to create research export: %v", err)
+	}
+	day := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	export.now = func() time.Time { return day }
+	export.noise = func(scale float64) float64 {
+		if scale != 1 {
+			t.Errorf("Expected noise of scale 1/epsilon, got %f", scale)
+		}
+		return 1.4
+	}
+	service.SetResearchExport(export)
+
+	if _, err := service.ExportResearch(ctx, "acme", "raw_scores", Requester{}); !errors.Is(err, ErrUnknownResearchQuery) {
+		t.Errorf("Expected ErrUnknownResearchQuery, got %v", err)
+	}
+
+	dataset, err := service.ExportResearch(ctx, "acme", ResearchQueryConfidenceDistribution, Requester{RemoteAddr: "203.0.113.7"})
+	if err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+	if len(dataset.Buckets) != 10 || dataset.Buckets[9].Min != 90 || dataset.Buckets[9].Max != 100 {
+		t.Fatalf("Expected 10 confidence buckets up to 100, got %+v", dataset.Buckets)
+	}
+	// 27 scores plus noise rounds to 28; 3 rounds to 4, below the bucket size; empty
+	// buckets get noise too and round to 1
+	if b := dataset.Buckets[8]; b.Count == nil || *b.Count != 28 {
+		t.Errorf("Expected a noisy count of 28 in 80-89, got %+v", b)
+	}
+	if b := dataset.Buckets[1]; !b.Suppressed || b.Count != nil {
+		t.Errorf("Expected the 10-19 bucket suppressed, got %+v", b)
+	}
+	if b := dataset.Buckets[5]; !b.Suppressed {
+		t.Errorf("Expected the empty 50-59 bucket suppressed, got %+v", b)
+	}
+	if dataset.BudgetRemaining != 1 {
+		t.Errorf("Expected 1 epsilon left, got %f", dataset.BudgetRemaining)
+	}
+
+	// The budget covers two queries a day per partner
+	if _, err := service.ExportResearch(ctx, "acme", ResearchQueryScoreDistribution, Requester{}); err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+	if _, err := service.ExportResearch(ctx, "acme", ResearchQueryScoreDistribution, Requester{}); !errors.Is(err, ErrResearchBudgetExhausted) {
+		t.Errorf("Expected ErrResearchBudgetExhausted, got %v", err)
+	}
+	if _, err := service.ExportResearch(ctx, "uni-lab", ResearchQueryScoreDistribution, Requester{}); err != nil {
+		t.Errorf("Expected another partner's budget to be separate, got %v", err)
+	}
+
+	day = day.Add(24 * time.Hour)
+	if _, err := service.ExportResearch(ctx, "acme", ResearchQueryScoreDistribution, Requester{}); err != nil {
+		t.Errorf("Expected the budget to reset the next day, got %v", err)
+	}
+
+	// A failed export spends nothing
+	failed := errors.New("query failed")
+	if _, _, err := service.repo.SpendResearchBudget(ctx, "acme", "2024-03-02", 1, 2, func(*repository.ScoreRepository) error { return failed }); !errors.Is(err, failed) {
+		t.Errorf("Expected the export's error, got %v", err)
+	}
+
+	// Spending outlives the export, so a restart doesn't hand out a fresh budget
+	restarted, err := NewResearchExport(1, 2, 5)
+	if err != nil {
+		t.Fatalf("Failed to create research export: %v", err)
+	}
+	restarted.now = export.now
+	restarted.noise = export.noise
+	service.SetResearchExport(restarted)
+	dataset, err = service.ExportResearch(ctx, "acme", ResearchQueryScoreDistribution, Requester{})
+	if err != nil || dataset.BudgetRemaining != 0 {
+		t.Fatalf("Expected the last of the day's budget to be spent, got %v (%v)", dataset, err)
+	}
+	if _, err := service.ExportResearch(ctx, "acme", ResearchQueryScoreDistribution, Requester{}); !errors.Is(err, ErrResearchBudgetExhausted) {
+		t.Errorf("Expected ErrResearchBudgetExhausted after a restart, got %v", err)
+	}
+}
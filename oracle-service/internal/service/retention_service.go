@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/snapshot"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// retentionBatchSize bounds how many rows one delete transaction removes
+const retentionBatchSize = 1000
+
+var retentionPolicies = map[string]bool{
+	models.RetentionFailedOracleUpdates: true,
+	models.RetentionWebhookDeliveries:   true,
+	models.RetentionBureauAlerts:        true,
+	models.RetentionScoreHistory:        true,
+	models.RetentionScoreComponents:     true,
+	models.RetentionDebugTraces:         true,
+	models.RetentionJobRuns:             true,
+	models.RetentionPositionSnapshots:   true,
+	models.RetentionPipelineTimings:     true,
+}
+
+// RetentionService deletes expired rows according to per-table retention policies,
+// archiving them to object storage first when an archive store is configured
+type RetentionService struct {
+	repo     *repository.RetentionRepository
+	policies map[string]int // Policy -> retention in days
+	archive  snapshot.Store // nil deletes without archiving
+	jobs     *JobRecorder   // nil leaves scheduled runs unrecorded
+	now      func() time.Time
+}
+
+// NewRetentionService creates a retention service. Policies with zero days keep
+// their rows forever.
+func NewRetentionService(repo *repository.RetentionRepository, policies map[string]int, archive snapshot.Store) (*RetentionService, error) {
+	active := make(map[string]int)
+	for policy, days := range policies {
+		if !retentionPolicies[policy] {
+			return nil, fmt.Errorf("unknown retention policy %q", policy)
+		}
+		if days > 0 {
+			active[policy] = days
+		}
+	}
+
+	return &RetentionService{
+		repo:     repo,
+		policies: active,
+		archive:  archive,
+		now:      time.Now,
+	}, nil
+}
+
+// SetJobRecorder records the scheduled runs of the retention policies
+func (s *RetentionService) SetJobRecorder(jobs *JobRecorder) {
+	s.jobs = jobs
+}
+
+// Run enforces every policy once and returns the updated retention stats. A failing
+// policy is recorded in its stat and does not stop the others.
+func (s *RetentionService) Run(ctx context.Context) ([]*models.RetentionStat, error) {
+	policies := make([]string, 0, len(s.policies))
+	for policy := range s.policies {
+		policies = append(policies, policy)
+	}
+	sort.Strings(policies)
+
+	var results []*models.RetentionStat
+	for _, policy := range policies {
+		stat, err := s.runPolicy(ctx, policy)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, stat)
+	}
+
+	return results, nil
+}
+
+// RunSchedule enforces the policies every interval until the context is cancelled
+func (s *RetentionService) RunSchedule(ctx context.Context, interval time.Duration) {
+	if len(s.policies) == 0 {
+		return
+	}
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			run := s.jobs.start(ctx, models.JobRetention)
+			stats, err := s.Run(ctx)
+			if err != nil {
+				logger.Error("Failed to enforce retention policies", zap.Error(err))
+				run.finish(ctx, err)
+				continue
+			}
+			for _, stat := range stats {
+				if stat.LastError != "" {
+					run.failed(stat.Policy, errors.New(stat.LastError))
+				} else {
+					run.succeeded()
+				}
+				if stat.LastDeleted > 0 || stat.LastError != "" {
+					logger.Info("Enforced retention policy",
+						zap.String("policy", stat.Policy),
+						zap.Int64("deleted", stat.LastDeleted),
+						zap.String("error", stat.LastError),
+					)
+				}
+			}
+			run.finish(ctx, nil)
+		}
+	}
+}
+
+// Stats lists what each policy has deleted
+func (s *RetentionService) Stats(ctx context.Context) ([]*models.RetentionStat, error) {
+	return s.repo.ListStats(ctx)
+}
+
+// runPolicy deletes a policy's expired rows in batches and records the outcome. It
+// only returns an error if the outcome could not be recorded.
+func (s *RetentionService) runPolicy(ctx context.Context, policy string) (*models.RetentionStat, error) {
+	stat, err := s.repo.GetStat(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+	if stat == nil {
+		stat = &models.RetentionStat{Policy: policy}
+	}
+
+	now := s.now()
+	cutoff := now.AddDate(0, 0, -s.policies[policy])
+	stat.RetentionDays = s.policies[policy]
+	stat.LastRunAt = now
+	stat.LastDeleted = 0
+	stat.LastError = ""
+
+	for batch := 0; ; batch++ {
+		rows, ids, err := s.repo.ListExpired(ctx, policy, cutoff, retentionBatchSize)
+		if err != nil {
+			stat.LastError = err.Error()
+			break
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		if s.archive != nil {
+			key := fmt.Sprintf("archive/%s/%s-%04d.json.gz", policy, snapshot.NewID(now), batch)
+			if _, err := snapshot.WriteArchive(ctx, s.archive, key, rows); err != nil {
+				// Never delete rows that could not be archived
+				stat.LastError = err.Error()
+				break
+			}
+			stat.LastArchiveKey = key
+		}
+
+		deleted, err := s.repo.DeleteExpired(ctx, policy, ids)
+		if err != nil {
+			stat.LastError = err.Error()
+			break
+		}
+		stat.LastDeleted += deleted
+		stat.TotalDeleted += deleted
+
+		if len(ids) < retentionBatchSize {
+			break
+		}
+	}
+
+	if err := s.repo.SaveStat(ctx, stat); err != nil {
+		return nil, fmt.Errorf("failed to save retention stat: %w", err)
+	}
+	return stat, nil
+}
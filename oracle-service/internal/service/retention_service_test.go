@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/snapshot"
+)
+
+func TestRetentionDeletesAndArchivesExpiredRows(t *testing.T) {
+	baseService, db := setupTestService(t)
+	ctx := context.Background()
+	store := snapshot.NewFileStore(t.TempDir())
+
+	retention, err := NewRetentionService(repository.NewRetentionRepository(db), map[string]int{
+		models.RetentionFailedOracleUpdates: 90,
+		models.RetentionScoreHistory:        0, // Kept forever
+	}, store)
+	if err != nil {
+		t.Fatalf("Failed to create retention service: %v", err)
+	}
+	baseService.SetRetention(retention)
+
+	old := time.Now().AddDate(0, 0, -120)
+	updates := []*models.OracleUpdate{
+		{UserAddress: "0x1111", Score: 700, Confidence: 80, DataHash: "a", Status: models.OracleUpdateFailed},
+		{UserAddress: "0x2222", Score: 700, Confidence: 80, DataHash: "b", Status: models.OracleUpdateFailed},
+		{UserAddress: "0x3333", Score: 700, Confidence: 80, DataHash: "c", Status: models.OracleUpdateConfirmed},
+	}
+	for _, u := range updates {
+		if err := db.Create(u).Error; err != nil {
+			t.Fatalf("Failed to create oracle update: %v", err)
+		}
+	}
+	// 0x1111's failure and 0x3333's confirmation are old; only the failure expires
+	db.Model(&models.OracleUpdate{}).Where("user_address IN ?", []string{"0x1111", "0x3333"}).UpdateColumn("updated_at", old)
+	if err := db.Create(&models.ScoreHistory{UserAddress: "0x1111", Score: 700, Confidence: 80, DataHash: "a", Timestamp: old}).Error; err != nil {
+		t.Fatalf("Failed to create history: %v", err)
+	}
+
+	stats, err := retention.Run(ctx)
+	if err != nil {
+		t.Fatalf("Failed to run retention: %v", err)
+	}
+	if len(stats) != 1 || stats[0].Policy != models.RetentionFailedOracleUpdates || stats[0].LastDeleted != 1 {
+		t.Fatalf("Expected one failed update deleted, got %+v", stats)
+	}
+	if stats[0].LastArchiveKey == "" {
+		t.Error("Expected the deleted rows to be archived")
+	}
+
+	var remaining int64
+	db.Model(&models.OracleUpdate{}).Count(&remaining)
+	if remaining != 2 {
+		t.Errorf("Expected 2 oracle updates to remain, got %d", remaining)
+	}
+	var history int64
+	db.Model(&models.ScoreHistory{}).Count(&history)
+	if history != 1 {
+		t.Errorf("Expected history to be kept, got %d rows", history)
+	}
+
+	// Deletion counts accumulate and are reported in stats
+	if _, err := retention.Run(ctx); err != nil {
+		t.Fatalf("Failed to run retention again: %v", err)
+	}
+	serviceStats, err := baseService.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+	reported, ok := serviceStats["retention"].([]*models.RetentionStat)
+	if !ok || len(reported) != 1 || reported[0].TotalDeleted != 1 || reported[0].LastDeleted != 0 {
+		t.Errorf("Expected retention counts in stats, got %v", serviceStats["retention"])
+	}
+}
+
+func TestRetentionRejectsUnknownPolicy(t *testing.T) {
+	_, db := setupTestService(t)
+	if _, err := NewRetentionService(repository.NewRetentionRepository(db), map[string]int{"anomalies": 30}, nil); err == nil {
+		t.Error("Expected an error for an unknown retention policy")
+	}
+}
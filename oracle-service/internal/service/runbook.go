@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/pause"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Runbook limits
+const (
+	MaxRunbookWindow           = 7 * 24 * time.Hour // Furthest back a runbook action reaches
+	DefaultRunbookRefreshLimit = 200
+	MaxRunbookRefreshLimit     = 1000
+)
+
+// Runbook item statuses
+const (
+	RunbookItemMatched   = "matched" // Selected by a dry run
+	RunbookItemPublished = "published"
+	RunbookItemQueued    = "queued" // Held for the publish window
+	RunbookItemRefreshed = "refreshed"
+	RunbookItemSkipped   = "skipped"
+	RunbookItemFailed    = "failed"
+)
+
+// Runbook errors
+var (
+	ErrInvalidRunbookWindow  = errors.Validation("window must end after it starts, in the past, and reach back at most 7 days")
+	ErrUnknownOutageProvider = errors.Validation("unknown provider; expected credit_bureau, plaid, employment, blockchain_data, blockscout or chain_index")
+)
+
+// offChainProviders are the providers whose outage only affects scores with off-chain
+// data
+var offChainProviders = map[string]bool{
+	pause.CreditBureau: true,
+	pause.Plaid:        true,
+	pause.Employment:   true,
+}
+
+// onChainProviders are the providers whose outage affects every score
+var onChainProviders = map[string]bool{
+	pause.BlockchainData: true,
+	pause.Blockscout:     true,
+	pause.ChainIndex:     true,
+}
+
+// RunbookItem is the outcome of a runbook action for one address
+type RunbookItem struct {
+	Address string `json:"address"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+// RunbookResult summarizes the rows a runbook action selected and changed
+type RunbookResult struct {
+	Action     string                 `json:"action"`
+	DryRun     bool                   `json:"dry_run"`
+	Matched    int                    `json:"matched"`  // Rows the action selected
+	Affected   int                    `json:"affected"` // Rows it changed
+	Skipped    int                    `json:"skipped"`
+	Failed     int                    `json:"failed"`
+	Truncated  bool                   `json:"truncated,omitempty"` // More rows matched than the limit; run again for the rest
+	Items      []RunbookItem          `json:"items"`
+	Stats      map[string]interface{} `json:"stats,omitempty"`
+	AuditID    uint                   `json:"audit_id,omitempty"` // Audit log entry of the run
+	StartedAt  time.Time              `json:"started_at"`
+	DurationMs int64                  `json:"duration_ms"`
+}
+
+func newRunbookResult(action string, dryRun bool) *RunbookResult {
+	return &RunbookResult{
+		Action:    action,
+		DryRun:    dryRun,
+		Items:     []RunbookItem{},
+		StartedAt: time.Now(),
+	}
+}
+
+// add records an item's outcome in the counts
+func (r *RunbookResult) add(item RunbookItem) {
+	switch item.Status {
+	case RunbookItemPublished, RunbookItemQueued, RunbookItemRefreshed:
+		r.Affected++
+	case RunbookItemSkipped:
+		r.Skipped++
+	case RunbookItemFailed:
+		r.Failed++
+	}
+	r.Items = append(r.Items, item)
+}
+
+// finishRunbook audits a runbook run, whether or not it succeeded
+func (s *OracleService) finishRunbook(ctx context.Context, result *RunbookResult, detail string, runErr error, requester Requester) {
+	result.DurationMs = time.Since(result.StartedAt).Milliseconds()
+
+	detail = fmt.Sprintf("%s matched=%d affected=%d skipped=%d failed=%d", detail, result.Matched, result.Affected, result.Skipped, result.Failed)
+	if result.DryRun {
+		detail += " dry_run"
+	}
+	if runErr != nil {
+		detail += " error=" + runErr.Error()
+	}
+	entry := &models.AuditLog{
+		Action:     result.Action,
+		Actor:      adminActor(requester),
+		Detail:     detail,
+		RemoteAddr: requester.RemoteAddr,
+		UserAgent:  requester.UserAgent,
+	}
+	s.recordAudit(ctx, entry)
+	result.AuditID = entry.ID
+
+	logger.Info("Runbook action run",
+		zap.String("action", result.Action),
+		zap.String("actor", entry.Actor),
+		zap.String("detail", detail),
+	)
+}
+
+// RequeueFailedPublishes republishes the current scores of addresses whose publish
+// failed within the last window and wasn't retried since. Scores are published now
+// if the publish window is open and queued for it otherwise; frozen, provisional and
+// deleted scores are skipped. A dry run only lists the addresses.
+func (s *OracleService) RequeueFailedPublishes(ctx context.Context, window time.Duration, dryRun bool, requester Requester) (*RunbookResult, error) {
+	if window <= 0 || window > MaxRunbookWindow {
+		return nil, ErrInvalidRunbookWindow
+	}
+	result := newRunbookResult(models.AuditRunbookRequeuePublishes, dryRun)
+	detail := fmt.Sprintf("window=%s", window)
+
+	addresses, err := s.repo.ListFailedPublishAddresses(ctx, time.Now().Add(-window))
+	if err != nil {
+		s.finishRunbook(ctx, result, detail, err, requester)
+		return nil, err
+	}
+	result.Matched = len(addresses)
+
+	if dryRun || len(addresses) == 0 {
+		for _, address := range addresses {
+			result.add(RunbookItem{Address: address, Status: RunbookItemMatched})
+		}
+		s.finishRunbook(ctx, result, detail, nil, requester)
+		return result, nil
+	}
+
+	published, err := s.PublishBatch(ctx, addresses, 0, false)
+	if err != nil {
+		s.finishRunbook(ctx, result, detail, err, requester)
+		return nil, err
+	}
+	for _, item := range published.Items {
+		status := RunbookItemSkipped
+		switch item.Status {
+		case BatchItemSubmitted:
+			status = RunbookItemPublished
+		case BatchItemQueued:
+			status = RunbookItemQueued
+		case BatchItemFailed:
+			status = RunbookItemFailed
+		}
+		result.add(RunbookItem{Address: item.Address, Status: status, Error: item.Error})
+	}
+
+	s.finishRunbook(ctx, result, detail, nil, requester)
+	return result, nil
+}
+
+// RefreshOutageScores recalculates, from fresh provider data, up to limit scores last
+// calculated during a provider's outage, and publishes them the way the scheduler
+// does. An off-chain provider's outage only affects addresses with off-chain data.
+// Refreshed scores leave the window, so running again picks up where a truncated run
+// stopped. A dry run only lists the addresses.
+func (s *OracleService) RefreshOutageScores(ctx context.Context, provider string, from, to time.Time, limit int, dryRun bool, requester Requester) (*RunbookResult, error) {
+	if !offChainProviders[provider] && !onChainProviders[provider] {
+		return nil, ErrUnknownOutageProvider
+	}
+	now := time.Now()
+	if !from.Before(to) || to.After(now) || now.Sub(from) > MaxRunbookWindow {
+		return nil, ErrInvalidRunbookWindow
+	}
+	if limit <= 0 {
+		limit = DefaultRunbookRefreshLimit
+	}
+	if limit > MaxRunbookRefreshLimit {
+		limit = MaxRunbookRefreshLimit
+	}
+	result := newRunbookResult(models.AuditRunbookRefreshScores, dryRun)
+	detail := fmt.Sprintf("provider=%s from=%s to=%s", provider, from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339))
+
+	// One extra row tells whether the run was truncated
+	scores, err := s.repo.ListScoresCalculatedBetween(ctx, from, to, offChainProviders[provider], limit+1)
+	if err != nil {
+		s.finishRunbook(ctx, result, detail, err, requester)
+		return nil, err
+	}
+	if len(scores) > limit {
+		scores = scores[:limit]
+		result.Truncated = true
+	}
+	result.Matched = len(scores)
+
+	for _, score := range scores {
+		item := RunbookItem{Address: score.UserAddress, Status: RunbookItemMatched}
+		if !dryRun {
+			item = s.refreshOutageScore(ctx, score.UserAddress)
+		}
+		result.add(item)
+	}
+
+	s.finishRunbook(ctx, result, detail, nil, requester)
+	return result, nil
+}
+
+// refreshOutageScore recalculates one address's score and requests its publication
+func (s *OracleService) refreshOutageScore(ctx context.Context, address string) RunbookItem {
+	item := RunbookItem{Address: address, Status: RunbookItemRefreshed}
+
+	_, err := s.calculateAndUpdateScore(ctx, address, "", models.ChangeReasonOutageRecovery)
+	if errors.Is(err, ErrProfileFrozen) {
+		item.Status = RunbookItemSkipped
+		item.Error = err.Error()
+		return item
+	}
+	if err != nil {
+		logger.Error("Failed to refresh score after outage", zap.String("address", address), zap.Error(err))
+		item.Status = RunbookItemFailed
+		item.Error = err.Error()
+		return item
+	}
+
+	// While publishing is paused the score stays unpublished, and provisional scores
+	// are never published
+	if _, err := s.RequestPublish(ctx, address, false); err != nil && !errors.Is(err, ErrPublishingPaused) && !errors.Is(err, ErrScoreProvisional) {
+		logger.Error("Failed to publish refreshed score", zap.String("address", address), zap.Error(err))
+	}
+	return item
+}
+
+// RecomputeStats recomputes the materialized stats now, reporting them with the run
+func (s *OracleService) RecomputeStats(ctx context.Context, requester Requester) (*RunbookResult, error) {
+	result := newRunbookResult(models.AuditRunbookRecomputeStats, false)
+
+	stats, err := s.RefreshStats(ctx)
+	if err != nil {
+		s.finishRunbook(ctx, result, "stats="+models.StatsDashboard, err, requester)
+		return nil, err
+	}
+	result.Matched = 1
+	result.Affected = 1
+	result.Stats = stats
+
+	s.finishRunbook(ctx, result, "stats="+models.StatsDashboard, nil, requester)
+	return result, nil
+}
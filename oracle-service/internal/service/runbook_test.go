@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/pause"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+)
+
+func TestRequeueFailedPublishes(t *testing.T) {
+	service, db := setupTestService(t)
+	service.SetAuditLog(repository.NewAuditRepository(db))
+	ctx := context.Background()
+	operator := Requester{Operator: "alice"}
+
+	failed := "0x1111111111111111111111111111111111111111"
+	retried := "0x2222222222222222222222222222222222222222"
+	for _, address := range []string{failed, retried} {
+		if _, err := service.CalculateAndUpdateScore(ctx, address, ""); err != nil {
+			t.Fatalf("Failed to calculate score: %v", err)
+		}
+	}
+	for _, update := range []*models.OracleUpdate{
+		{UserAddress: failed, Status: models.OracleUpdateFailed, DataHash: "0xa"},
+		{UserAddress: retried, Status: models.OracleUpdateFailed, DataHash: "0xb"},
+		{UserAddress: retried, Status: models.OracleUpdateConfirmed, DataHash: "0xb"},
+	} {
+		if err := service.repo.CreateOracleUpdate(ctx, update); err != nil {
+			t.Fatalf("Failed to create oracle update: %v", err)
+		}
+	}
+
+	if _, err := service.RequeueFailedPublishes(ctx, 30*24*time.Hour, false, operator); !errors.Is(err, ErrInvalidRunbookWindow) {
+		t.Fatalf("Expected a window past 7 days rejected, got %v", err)
+	}
+
+	dry, err := service.RequeueFailedPublishes(ctx, time.Hour, true, operator)
+	if err != nil {
+		t.Fatalf("Failed to requeue: %v", err)
+	}
+	if dry.Matched != 1 || dry.Affected != 0 || dry.Items[0].Address != failed {
+		t.Fatalf("Expected only the unretried failure matched, got %+v", dry)
+	}
+
+	result, err := service.RequeueFailedPublishes(ctx, time.Hour, false, operator)
+	if err != nil {
+		t.Fatalf("Failed to requeue: %v", err)
+	}
+	if result.Matched != 1 || result.Affected != 1 || result.Items[0].Status != RunbookItemPublished {
+		t.Fatalf("Expected the failure republished, got %+v", result)
+	}
+	if result.AuditID == 0 {
+		t.Error("Expected the run audited")
+	}
+
+	if again, err := service.RequeueFailedPublishes(ctx, time.Hour, false, operator); err != nil || again.Matched != 0 {
+		t.Errorf("Expected nothing left to requeue, got %+v, %v", again, err)
+	}
+
+	entries, err := service.ListAuditLog(ctx, "", models.AuditRunbookRequeuePublishes, 10)
+	if err != nil || len(entries) != 3 || entries[0].Actor != "admin:alice" {
+		t.Fatalf("Expected three audited runs by alice, got %+v, %v", entries, err)
+	}
+}
+
+func TestRefreshOutageScores(t *testing.T) {
+	service, db := setupTestService(t)
+	ctx := context.Background()
+
+	withOffChain := "0x1111111111111111111111111111111111111111"
+	onChainOnly := "0x2222222222222222222222222222222222222222"
+	afterOutage := "0x3333333333333333333333333333333333333333"
+	for _, address := range []string{withOffChain, onChainOnly, afterOutage} {
+		if _, err := service.CalculateAndUpdateScore(ctx, address, ""); err != nil {
+			t.Fatalf("Failed to calculate score: %v", err)
+		}
+	}
+	db.Where("user_address = ?", onChainOnly).Delete(&models.OffChainMetrics{})
+
+	from := time.Now().Add(-3 * time.Hour)
+	to := time.Now().Add(-time.Hour)
+	db.Model(&models.CreditScore{}).
+		Where("user_address IN ?", []string{withOffChain, onChainOnly}).
+		Update("last_updated", time.Now().Add(-2*time.Hour))
+
+	if _, err := service.RefreshOutageScores(ctx, pause.Publishing, from, to, 0, false, Requester{}); !errors.Is(err, ErrUnknownOutageProvider) {
+		t.Fatalf("Expected a non-provider rejected, got %v", err)
+	}
+	if _, err := service.RefreshOutageScores(ctx, pause.Blockscout, to, from, 0, false, Requester{}); !errors.Is(err, ErrInvalidRunbookWindow) {
+		t.Fatalf("Expected a reversed window rejected, got %v", err)
+	}
+
+	offChain, err := service.RefreshOutageScores(ctx, pause.CreditBureau, from, to, 0, true, Requester{})
+	if err != nil {
+		t.Fatalf("Failed to refresh: %v", err)
+	}
+	if offChain.Matched != 1 || offChain.Items[0].Address != withOffChain {
+		t.Fatalf("Expected only the address with off-chain data matched, got %+v", offChain)
+	}
+
+	first, err := service.RefreshOutageScores(ctx, pause.Blockscout, from, to, 1, false, Requester{})
+	if err != nil {
+		t.Fatalf("Failed to refresh: %v", err)
+	}
+	if first.Matched != 1 || first.Affected != 1 || !first.Truncated {
+		t.Fatalf("Expected one refresh of a truncated run, got %+v", first)
+	}
+	history, err := service.GetScoreHistory(ctx, first.Items[0].Address, 1)
+	if err != nil || len(history) != 1 || history[0].ChangeReason != models.ChangeReasonOutageRecovery {
+		t.Fatalf("Expected the refresh recorded as outage recovery, got %+v, %v", history, err)
+	}
+
+	rest, err := service.RefreshOutageScores(ctx, pause.Blockscout, from, to, 1, false, Requester{})
+	if err != nil || rest.Matched != 1 || rest.Truncated || rest.Items[0].Address == first.Items[0].Address {
+		t.Errorf("Expected the rerun to refresh the other address, got %+v, %v", rest, err)
+	}
+}
+
+func TestRecomputeStats(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+
+	if _, err := service.CalculateAndUpdateScore(ctx, "0x1234567890123456789012345678901234567890", ""); err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	result, err := service.RecomputeStats(ctx, Requester{})
+	if err != nil {
+		t.Fatalf("Failed to recompute stats: %v", err)
+	}
+	if result.Affected != 1 || result.Stats["total_active_scores"] != int64(1) {
+		t.Errorf("Expected the recomputed stats returned, got %+v", result)
+	}
+}
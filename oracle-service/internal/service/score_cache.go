@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/cache"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+// SetInvalidationBus subscribes the service to the invalidations the repository
+// publishes on bus. Materialized stats are refreshed early by RunStatsRefresh after
+// any change. With a positive ttl, scores and prescreen tiers are also cached in memory
+// for up to ttl, and dropped as soon as the address's score or metrics are written.
+func (s *OracleService) SetInvalidationBus(bus *cache.Bus, ttl time.Duration) {
+	bus.Subscribe(func(cache.Invalidation) {
+		select {
+		case s.statsChanged <- struct{}{}:
+		default: // A refresh is already due
+		}
+	})
+	s.scoreCache = cache.NewStore[*models.CreditScore](ttl, bus)
+	s.tierCache = cache.NewStore[PrescreenItem](ttl, bus)
+}
+
+// cachedScore returns an address's score from the score cache, loading it from the
+// repository on a miss. Addresses without a score are cached too, until one is
+// created. Callers get their own copy.
+func (s *OracleService) cachedScore(ctx context.Context, address string) (*models.CreditScore, error) {
+	score, err := s.scoreCache.Load(address, func() (*models.CreditScore, error) {
+		return s.repo.GetByAddress(ctx, address)
+	})
+	if err != nil || score == nil {
+		return nil, err
+	}
+	copied := *score
+	return &copied, nil
+}
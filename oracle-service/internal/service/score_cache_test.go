@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/cache"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+func TestMetricsUpsertInvalidatesCaches(t *testing.T) {
+	service, db := setupTestService(t)
+	ctx := context.Background()
+	bus := cache.NewBus()
+	service.repo.SetInvalidationBus(bus)
+	service.SetInvalidationBus(bus, time.Hour)
+	service.SetStatsRefresh(time.Hour)
+
+	scored := "0x1234567890123456789012345678901234567890"
+	estimated := "0xabcdefabcdefabcdefabcdefabcdefabcdefabcd"
+	for _, address := range []string{scored, estimated} {
+		if _, err := service.CalculateAndUpdateScore(ctx, address, ""); err != nil {
+			t.Fatalf("Failed to calculate score: %v", err)
+		}
+	}
+	db.Where("user_address = ?", estimated).Delete(&models.CreditScore{})
+
+	// Scores are served from the cache, each caller getting its own copy
+	cached, err := service.GetScore(ctx, scored)
+	if err != nil || cached == nil {
+		t.Fatalf("Failed to get score: %v", err)
+	}
+	cached.Score = 1
+	db.Model(&models.CreditScore{}).Where("user_address = ?", scored).Update("score", 420)
+	if score, _ := service.GetScore(ctx, scored); score.Score == 1 || score.Score == 420 {
+		t.Fatalf("Expected the cached score, got %d", score.Score)
+	}
+
+	// Metrics pushed out of band drop the address's cached score
+	if err := service.repo.UpsertOnChainMetrics(ctx, &models.OnChainMetrics{UserAddress: scored, WalletAge: 30}); err != nil {
+		t.Fatalf("Failed to upsert metrics: %v", err)
+	}
+	if score, _ := service.GetScore(ctx, scored); score.Score != 420 {
+		t.Errorf("Expected the stored score after the metrics push, got %d", score.Score)
+	}
+
+	// Tiers are cached until the address's metrics change
+	before, err := service.Prescreen(ctx, []string{estimated})
+	if err != nil || before.Items[0].Source != PrescreenSourceEstimate {
+		t.Fatalf("Expected an estimated tier, got %+v, %v", before, err)
+	}
+	db.Where("user_address = ?", estimated).Delete(&models.OnChainMetrics{})
+	if cachedTier, _ := service.Prescreen(ctx, []string{estimated}); cachedTier.Items[0] != before.Items[0] {
+		t.Errorf("Expected the cached tier, got %+v", cachedTier.Items[0])
+	}
+
+	pushed := &models.OffChainMetrics{UserAddress: estimated, TraditionalCreditScore: units.Score(320), IncomeLevel: "low"}
+	if err := service.repo.UpsertOffChainMetrics(ctx, pushed); err != nil {
+		t.Fatalf("Failed to upsert metrics: %v", err)
+	}
+	estimate, err := service.scoringEngine.CalculateScore(nil, pushed)
+	if err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+	after, err := service.Prescreen(ctx, []string{estimated})
+	if err != nil || after.Items[0].Tier != scoring.TierFor(estimate.Score) || after.Items[0].Tier == before.Items[0].Tier {
+		t.Errorf("Expected tier %s from the pushed metrics, got %+v, %v", scoring.TierFor(estimate.Score), after.Items[0], err)
+	}
+
+	// Reads serve the materialized stats, which the refresh job recomputes soon after
+	// a score changes rather than every interval
+	stats, err := service.GetStats(ctx)
+	if err != nil || stats["total_active_scores"] != int64(1) {
+		t.Fatalf("Expected stats with 1 score, got %v, %v", stats["total_active_scores"], err)
+	}
+	<-service.statsChanged // Signalled by the writes above
+	refreshCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	go service.RunStatsRefresh(refreshCtx, time.Second)
+	time.Sleep(50 * time.Millisecond) // Let the first refresh finish
+
+	if _, err := service.CalculateAndUpdateScore(ctx, "0x2222222222222222222222222222222222222222", ""); err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+	if stats, _ := service.GetStats(ctx); stats["total_active_scores"] != int64(1) {
+		t.Errorf("Expected a read not to recompute the stats, got %v scores", stats["total_active_scores"])
+	}
+	time.Sleep(300 * time.Millisecond)
+	if stats, _ := service.GetStats(ctx); stats["total_active_scores"] != int64(2) {
+		t.Errorf("Expected refreshed stats with 2 scores, got %v", stats["total_active_scores"])
+	}
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// ModelScore is an address's score under one model version
+type ModelScore struct {
+	Version       string           `json:"version"`
+	Current       bool             `json:"current"` // The version scores are calculated and published with
+	Score         units.Score      `json:"score"`
+	Confidence    units.Confidence `json:"confidence"`
+	OnChainScore  units.Score      `json:"on_chain_score"`
+	OffChainScore units.Score      `json:"off_chain_score"`
+	HybridScore   units.Score      `json:"hybrid_score"`
+	Weights       scoring.Blend    `json:"weights"`
+	Delta         int              `json:"delta"` // Difference from the current version's score
+}
+
+// ScoreComparison is an address's score under several model versions, calculated
+// from the same stored metrics
+type ScoreComparison struct {
+	Address    string        `json:"address"`
+	Current    string        `json:"current"` // Version scores are calculated and published with
+	Models     []*ModelScore `json:"models"`  // In the order requested
+	ComparedAt time.Time     `json:"compared_at"`
+}
+
+// SetScoringModels registers the model versions scores can be compared under. The
+// service's own engine is the current model.
+func (s *OracleService) SetScoringModels(models *scoring.Models) {
+	s.models = models
+}
+
+// ScoringModels returns the registered model versions, the current one first
+func (s *OracleService) ScoringModels() []string {
+	return s.scoringModels().Versions()
+}
+
+func (s *OracleService) scoringModels() *scoring.Models {
+	if s.models == nil {
+		return scoring.NewModels(s.scoringEngine)
+	}
+	return s.models
+}
+
+// CompareModels scores an address under each of versions (every registered version if
+// none) from its stored metrics, without calling the providers or saving anything.
+// Every version gets the same confidence adjustments for provider reliability and
+// position risk.
+func (s *OracleService) CompareModels(ctx context.Context, address string, versions []string) (*ScoreComparison, error) {
+	models := s.scoringModels()
+	if len(versions) == 0 {
+		versions = models.Versions()
+	}
+
+	engines := make([]*scoring.Engine, 0, len(versions))
+	seen := make(map[string]bool, len(versions))
+	for _, version := range versions {
+		version = strings.TrimSpace(version)
+		engine, ok := models.Get(version)
+		if !ok {
+			return nil, errors.Validation("unknown model version %q, registered versions are %s",
+				version, strings.Join(models.Versions(), ", "))
+		}
+		if !seen[version] {
+			seen[version] = true
+			engines = append(engines, engine)
+		}
+	}
+
+	score, err := s.repo.GetByAddress(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score: %w", err)
+	}
+	if score == nil {
+		return nil, fmt.Errorf("%w for address %s", ErrScoreNotFound, address)
+	}
+	onChain, err := s.repo.GetOnChainMetrics(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	offChain, err := s.repo.GetOffChainMetrics(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	current, _ := models.Get(models.Current())
+	reliability := s.confidenceFactor(ctx, address)
+	baseline, err := current.CalculateScoreWithReliability(onChain, offChain, reliability)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate score: %w", err)
+	}
+
+	comparison := &ScoreComparison{
+		Address:    address,
+		Current:    models.Current(),
+		Models:     make([]*ModelScore, 0, len(engines)),
+		ComparedAt: time.Now().UTC(),
+	}
+	for _, engine := range engines {
+		explanation, err := engine.ExplainWithReliability(onChain, offChain, reliability)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate %s score: %w", engine.Version(), err)
+		}
+		comparison.Models = append(comparison.Models, &ModelScore{
+			Version:       engine.Version(),
+			Current:       engine.Version() == models.Current(),
+			Score:         explanation.Score,
+			Confidence:    explanation.Confidence,
+			OnChainScore:  explanation.OnChainScore,
+			OffChainScore: explanation.OffChainScore,
+			HybridScore:   explanation.HybridScore,
+			Weights:       explanation.Weights,
+			Delta:         int(explanation.Score) - int(baseline.Score),
+		})
+	}
+
+	return comparison, nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+)
+
+func TestCompareModels(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+
+	models, err := scoring.ParseModels(service.scoringEngine, map[string]string{"v2": "70/10/20"})
+	if err != nil {
+		t.Fatalf("Failed to parse models: %v", err)
+	}
+	service.SetScoringModels(models)
+
+	address := "0x1234567890123456789012345678901234567890"
+	stored, err := service.CalculateAndUpdateScore(ctx, address, "")
+	if err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	comparison, err := service.CompareModels(ctx, address, []string{"v2", "v1", "v2"})
+	if err != nil {
+		t.Fatalf("Failed to compare models: %v", err)
+	}
+	if comparison.Current != scoring.DefaultModelVersion || len(comparison.Models) != 2 {
+		t.Fatalf("Expected v2 and v1 once each, got %+v", comparison)
+	}
+
+	candidate, current := comparison.Models[0], comparison.Models[1]
+	if candidate.Version != "v2" || candidate.Current || current.Version != "v1" || !current.Current {
+		t.Errorf("Expected the requested order, got %s then %s", candidate.Version, current.Version)
+	}
+	if current.Score != stored.Score || current.Delta != 0 {
+		t.Errorf("Expected the current model to reproduce the stored score %d, got %d (delta %d)", stored.Score, current.Score, current.Delta)
+	}
+	if candidate.Delta != int(candidate.Score)-int(stored.Score) {
+		t.Errorf("Expected delta %d, got %d", int(candidate.Score)-int(stored.Score), candidate.Delta)
+	}
+	if candidate.OnChainScore != current.OnChainScore {
+		t.Errorf("Expected the same on-chain component under both models, got %d and %d", candidate.OnChainScore, current.OnChainScore)
+	}
+
+	if all, err := service.CompareModels(ctx, address, nil); err != nil || len(all.Models) != 2 || !all.Models[0].Current {
+		t.Errorf("Expected every model, the current one first, got %+v, %v", all, err)
+	}
+	if _, err := service.CompareModels(ctx, address, []string{"v9"}); errors.KindOf(err) != errors.ErrValidation {
+		t.Errorf("Expected a validation error for an unknown model, got %v", err)
+	}
+	if _, err := service.CompareModels(ctx, "0x9999999999999999999999999999999999999999", nil); !errors.Is(err, ErrScoreNotFound) {
+		t.Errorf("Expected ErrScoreNotFound, got %v", err)
+	}
+
+	// Without registered models only the service's own engine can be compared
+	service.SetScoringModels(nil)
+	if versions := service.ScoringModels(); len(versions) != 1 || versions[0] != scoring.DefaultModelVersion {
+		t.Errorf("Expected only the current model, got %v", versions)
+	}
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// Public score distribution defaults
+const (
+	distributionBucketWidth = 50               // Score points per bucket
+	distributionCacheTTL    = 10 * time.Minute // How long a computed distribution is served
+)
+
+// ErrDistributionDisabled is returned when no public score distribution is served
+var ErrDistributionDisabled = errors.NotFound("public score distribution is disabled")
+
+// ScoreBucket counts the scores in one range of the distribution. Count is null when
+// the bucket is suppressed.
+type ScoreBucket struct {
+	Min        units.Score `json:"min"`
+	Max        units.Score `json:"max"`
+	Count      *int64      `json:"count"`
+	Suppressed bool        `json:"suppressed,omitempty"`
+}
+
+// ScoreDistribution is the k-anonymized distribution of active final scores. Every
+// reported count covers at least MinGroupSize addresses or is zero; smaller groups
+// are suppressed, along with enough other buckets that they can't be worked out from
+// the total. No per-address data is included.
+type ScoreDistribution struct {
+	MinGroupSize      int              `json:"min_group_size"`
+	TotalScores       *int64           `json:"total_scores"`       // Null when fewer than MinGroupSize scores exist
+	AverageConfidence *float64         `json:"average_confidence"` // Null when fewer than MinGroupSize scores exist
+	Buckets           []*ScoreBucket   `json:"buckets"`
+	PublishedByChain  map[string]int64 `json:"published_by_chain"` // Addresses with a confirmed publication per chain ID; chains under MinGroupSize are omitted
+	ComputedAt        time.Time        `json:"computed_at"`
+}
+
+// publicDistribution serves the public score distribution from a short-lived cache
+type publicDistribution struct {
+	minGroupSize int
+
+	mu       sync.Mutex
+	computed *ScoreDistribution
+}
+
+// SetPublicDistribution serves the anonymized score distribution, suppressing groups
+// of fewer than minGroupSize addresses. A minGroupSize of zero disables it.
+func (s *OracleService) SetPublicDistribution(minGroupSize int) {
+	if minGroupSize <= 0 {
+		s.distribution = nil
+		return
+	}
+	s.distribution = &publicDistribution{minGroupSize: minGroupSize}
+}
+
+// GetScoreDistribution returns the anonymized score distribution, recomputing it when
+// the cached one is older than distributionCacheTTL
+func (s *OracleService) GetScoreDistribution(ctx context.Context) (*ScoreDistribution, error) {
+	d := s.distribution
+	if d == nil {
+		return nil, ErrDistributionDisabled
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.computed != nil && time.Since(d.computed.ComputedAt) < distributionCacheTTL {
+		return d.computed, nil
+	}
+
+	distribution, err := s.computeDistribution(ctx, d.minGroupSize)
+	if err != nil {
+		return nil, err
+	}
+	d.computed = distribution
+	return distribution, nil
+}
+
+func (s *OracleService) computeDistribution(ctx context.Context, k int) (*ScoreDistribution, error) {
+	totals, err := s.repo.GetScoreDistribution(ctx, distributionBucketWidth)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := scoreBuckets()
+	counts := make([]int64, len(buckets))
+	var total int64
+	var confidenceSum float64
+	for _, bucket := range totals {
+		i := bucket.Bucket
+		if i < 0 {
+			i = 0
+		}
+		// The top bucket is closed, so a perfect score falls into it
+		if i >= len(buckets) {
+			i = len(buckets) - 1
+		}
+		counts[i] += bucket.Count
+		total += bucket.Count
+		confidenceSum += bucket.ConfidenceSum
+	}
+
+	suppressed := suppressSmallGroups(counts, int64(k))
+	for i, bucket := range buckets {
+		if suppressed[i] {
+			bucket.Suppressed = true
+			continue
+		}
+		count := counts[i]
+		bucket.Count = &count
+	}
+
+	distribution := &ScoreDistribution{
+		MinGroupSize:     k,
+		Buckets:          buckets,
+		PublishedByChain: make(map[string]int64),
+		ComputedAt:       time.Now().UTC(),
+	}
+	if total >= int64(k) {
+		average := confidenceSum / float64(total)
+		distribution.TotalScores = &total
+		distribution.AverageConfidence = &average
+	}
+
+	published, err := s.repo.CountPublishedAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byChain := make(map[string]int64)
+	for target, count := range published {
+		if chain := s.targetChain(target); chain != "" {
+			byChain[chain] += count
+		}
+	}
+	for chain, count := range byChain {
+		if count >= int64(k) {
+			distribution.PublishedByChain[chain] = count
+		}
+	}
+
+	return distribution, nil
+}
+
+// targetChain returns the chain ID of a publish target's client, or "" if it isn't
+// known
+func (s *OracleService) targetChain(target string) string {
+	client := s.blockchainClient
+	if target == models.PublishTargetCanary {
+		if s.canary == nil {
+			return ""
+		}
+		client = s.canary.client
+	}
+	if reporter, ok := client.(ChainReporter); ok {
+		return reporter.ChainID()
+	}
+	return ""
+}
+
+// scoreBuckets splits the score range into buckets of distributionBucketWidth points
+func scoreBuckets() []*ScoreBucket {
+	var buckets []*ScoreBucket
+	for _, bounds := range bucketBounds(int(units.MinScore), int(units.MaxScore), distributionBucketWidth) {
+		buckets = append(buckets, &ScoreBucket{Min: units.Score(bounds[0]), Max: units.Score(bounds[1])})
+	}
+	return buckets
+}
+
+// bucketBounds splits [min, max] into buckets of width values. The last bucket is
+// closed, so max falls into it rather than a bucket of its own.
+func bucketBounds(min, max, width int) [][2]int {
+	var bounds [][2]int
+	for low := min; low < max; low += width {
+		high := low + width - 1
+		if high >= max-1 {
+			high = max
+		}
+		bounds = append(bounds, [2]int{low, high})
+	}
+	return bounds
+}
+
+// suppressSmallGroups picks the buckets to withhold so that no group of fewer than k
+// addresses can be read off or worked out. Buckets with 1 to k-1 addresses are
+// suppressed, then the smallest remaining buckets until the suppressed buckets
+// together hold at least k addresses, as otherwise their sum follows from the total.
+func suppressSmallGroups(counts []int64, k int64) []bool {
+	suppressed := make([]bool, len(counts))
+	var sum int64
+	for i, count := range counts {
+		if count > 0 && count < k {
+			suppressed[i] = true
+			sum += count
+		}
+	}
+	if sum == 0 {
+		return suppressed
+	}
+
+	var remaining []int
+	for i, count := range counts {
+		if !suppressed[i] && count > 0 {
+			remaining = append(remaining, i)
+		}
+	}
+	sort.SliceStable(remaining, func(a, b int) bool { return counts[remaining[a]] < counts[remaining[b]] })
+	for _, i := range remaining {
+		if sum >= k {
+			break
+		}
+		suppressed[i] = true
+		sum += counts[i]
+	}
+	return suppressed
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+// Mock blockchain client on a known chain
+type mockChainClient struct {
+	mockBlockchainClient
+	chainID string
+}
+
+func (m *mockChainClient) ChainID() string {
+	return m.chainID
+}
+
+func TestSuppressSmallGroups(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts []int64
+		want   []bool
+	}{
+		{"nothing small", []int64{0, 12, 30}, []bool{false, false, false}},
+		{"small groups together reach k", []int64{4, 12, 6}, []bool{true, false, true}},
+		{"smallest large group joins", []int64{1, 40, 12, 0}, []bool{true, false, true, false}},
+		{"everything below k", []int64{2, 3}, []bool{true, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := suppressSmallGroups(tt.counts, 10)
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Expected suppressed %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestGetScoreDistribution(t *testing.T) {
+	service, db := setupTestService(t)
+	service.blockchainClient = &mockChainClient{chainID: "1"}
+	service.SetCanary(&mockChainClient{chainID: "137"}, 10)
+	ctx := context.Background()
+
+	n := 0
+	addScore := func(score units.Score, confidence units.Confidence, active, provisional bool) string {
+		n++
+		address := fmt.Sprintf("0x%040x", n)
+		record := &models.CreditScore{
+			UserAddress: address,
+			Score:       score,
+			Confidence:  confidence,
+			DataHash:    "hash",
+			LastUpdated: time.Now(),
+			Provisional: provisional,
+		}
+		if err := db.Create(record).Error; err != nil {
+			t.Fatalf("Failed to create score: %v", err)
+		}
+		if !active {
+			db.Model(record).Update("is_active", false)
+		}
+		return address
+	}
+	publish := func(address, target, status string) {
+		update := &models.OracleUpdate{UserAddress: address, Score: 700, Confidence: 80, DataHash: "hash", Status: status, Target: target}
+		if err := db.Create(update).Error; err != nil {
+			t.Fatalf("Failed to create oracle update: %v", err)
+		}
+	}
+
+	var published []string
+	for i := 0; i < 12; i++ {
+		published = append(published, addScore(710, 80, true, false))
+	}
+	for i := 0; i < 5; i++ {
+		addScore(850, 90, true, false)
+	}
+	addScore(320, 20, true, false)
+	addScore(400, 50, true, true)   // Provisional
+	addScore(450, 50, false, false) // Inactive
+
+	for _, address := range published[:4] {
+		publish(address, models.PublishTargetPrimary, models.OracleUpdateConfirmed)
+		publish(address, models.PublishTargetPrimary, models.OracleUpdateConfirmed)
+	}
+	publish(published[4], models.PublishTargetPrimary, models.OracleUpdateFailed)
+	publish(published[5], models.PublishTargetCanary, models.OracleUpdateConfirmed)
+
+	service.SetPublicDistribution(4)
+	distribution, err := service.GetScoreDistribution(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get distribution: %v", err)
+	}
+
+	if len(distribution.Buckets) != 11 {
+		t.Fatalf("Expected 11 buckets, got %d", len(distribution.Buckets))
+	}
+	last := distribution.Buckets[10]
+	if last.Min != 800 || last.Max != 850 {
+		t.Errorf("Expected the last bucket to cover 800-850, got %d-%d", last.Min, last.Max)
+	}
+
+	// The single low score is suppressed with the next smallest bucket, which holds the
+	// perfect scores
+	if !distribution.Buckets[0].Suppressed || distribution.Buckets[0].Count != nil {
+		t.Errorf("Expected the 300-349 bucket suppressed, got %+v", distribution.Buckets[0])
+	}
+	if !last.Suppressed {
+		t.Errorf("Expected the 800-850 bucket suppressed alongside, got %+v", last)
+	}
+	if b := distribution.Buckets[8]; b.Suppressed || b.Count == nil || *b.Count != 12 {
+		t.Errorf("Expected 12 scores in 700-749, got %+v", b)
+	}
+	if b := distribution.Buckets[2]; b.Count == nil || *b.Count != 0 {
+		t.Errorf("Expected no scores in 400-449 (provisional), got %+v", b)
+	}
+
+	if distribution.TotalScores == nil || *distribution.TotalScores != 18 {
+		t.Errorf("Expected 18 scores in total, got %v", distribution.TotalScores)
+	}
+	wantConfidence := float64(12*80+5*90+20) / 18
+	if distribution.AverageConfidence == nil || *distribution.AverageConfidence != wantConfidence {
+		t.Errorf("Expected average confidence %.2f, got %v", wantConfidence, distribution.AverageConfidence)
+	}
+
+	// One canary publication is too few to report
+	if len(distribution.PublishedByChain) != 1 || distribution.PublishedByChain["1"] != 4 {
+		t.Errorf("Expected 4 published addresses on chain 1 only, got %v", distribution.PublishedByChain)
+	}
+
+	encoded, _ := json.Marshal(distribution)
+	if strings.Contains(string(encoded), "0x") {
+		t.Errorf("Expected no addresses in the distribution, got %s", encoded)
+	}
+
+	// Served from the cache until it expires
+	addScore(710, 80, true, false)
+	cached, err := service.GetScoreDistribution(ctx)
+	if err != nil || cached != distribution {
+		t.Errorf("Expected the cached distribution, got %v (%v)", cached, err)
+	}
+}
+
+func TestScoreDistributionBelowGroupSize(t *testing.T) {
+	service, db := setupTestService(t)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		db.Create(&models.CreditScore{
+			UserAddress: fmt.Sprintf("0x%040x", i+1),
+			Score:       700,
+			Confidence:  80,
+			DataHash:    "hash",
+			LastUpdated: time.Now(),
+		})
+	}
+
+	if _, err := service.GetScoreDistribution(ctx); !errors.Is(err, ErrDistributionDisabled) {
+		t.Errorf("Expected ErrDistributionDisabled, got %v", err)
+	}
+
+	service.SetPublicDistribution(10)
+	distribution, err := service.GetScoreDistribution(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get distribution: %v", err)
+	}
+	if distribution.TotalScores != nil || distribution.AverageConfidence != nil {
+		t.Errorf("Expected totals withheld below the group size, got %v and %v", distribution.TotalScores, distribution.AverageConfidence)
+	}
+	for _, bucket := range distribution.Buckets {
+		if bucket.Count != nil && *bucket.Count != 0 {
+			t.Errorf("Expected no non-zero count below the group size, got %+v", bucket)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// MetricsFetchedEvent is the payload of metrics_fetched score events
+type MetricsFetchedEvent struct {
+	OnChain  *models.OnChainMetrics  `json:"on_chain,omitempty"`
+	OffChain *models.OffChainMetrics `json:"off_chain,omitempty"`
+}
+
+// ScoreDisputedEvent is the payload of disputed score events
+type ScoreDisputedEvent struct {
+	Reason string `json:"reason"`
+}
+
+// ScoreOverriddenEvent is the payload of overridden score events
+type ScoreOverriddenEvent struct {
+	Score      units.Score      `json:"score"`
+	Confidence units.Confidence `json:"confidence"`
+	Reason     string           `json:"reason"`
+	Operator   string           `json:"operator"`
+}
+
+// ScoreState is an address's score state rebuilt by replaying its event stream
+type ScoreState struct {
+	Address          string                  `json:"address"`
+	Score            units.Score             `json:"score"`
+	Confidence       units.Confidence        `json:"confidence"`
+	DataHash         string                  `json:"data_hash"`
+	ChangeReason     string                  `json:"change_reason"`
+	Calculations     int                     `json:"calculations"`
+	LastCalculatedAt *time.Time              `json:"last_calculated_at,omitempty"`
+	PublishedScore   units.Score             `json:"published_score,omitempty"`
+	PublishedTxHash  string                  `json:"published_tx_hash,omitempty"`
+	LastPublishedAt  *time.Time              `json:"last_published_at,omitempty"`
+	Disputed         bool                    `json:"disputed"`
+	Overridden       bool                    `json:"overridden"`
+	OnChainMetrics   *models.OnChainMetrics  `json:"on_chain_metrics,omitempty"`
+	OffChainMetrics  *models.OffChainMetrics `json:"off_chain_metrics,omitempty"`
+	EventCount       int                     `json:"event_count"`
+	LastSequence     uint64                  `json:"last_sequence"`
+}
+
+// ScoreStateReport compares the replayed state with the stored score
+type ScoreStateReport struct {
+	State      *ScoreState `json:"state"`
+	Consistent bool        `json:"consistent"`
+	Mismatches []string    `json:"mismatches,omitempty"`
+}
+
+// ReplayScoreEvents folds an address's events, in sequence order, into its current state
+func ReplayScoreEvents(address string, events []*models.ScoreEvent) (*ScoreState, error) {
+	state := &ScoreState{Address: address}
+
+	for _, event := range events {
+		if event.Sequence != state.LastSequence+1 {
+			return nil, fmt.Errorf("score event stream for %s has a gap before sequence %d", address, event.Sequence)
+		}
+		occurredAt := event.CreatedAt
+
+		switch event.EventType {
+		case models.ScoreEventMetricsFetched:
+			var payload MetricsFetchedEvent
+			if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+				return nil, fmt.Errorf("invalid %s event %d: %w", event.EventType, event.Sequence, err)
+			}
+			if payload.OnChain != nil {
+				state.OnChainMetrics = payload.OnChain
+			}
+			if payload.OffChain != nil {
+				state.OffChainMetrics = payload.OffChain
+			}
+
+		case models.ScoreEventCalculated:
+			var payload ScoreChangedEvent
+			if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+				return nil, fmt.Errorf("invalid %s event %d: %w", event.EventType, event.Sequence, err)
+			}
+			state.Score = payload.Score
+			state.Confidence = payload.Confidence
+			state.DataHash = payload.DataHash
+			state.ChangeReason = payload.ChangeReason
+			state.Calculations++
+			state.LastCalculatedAt = &occurredAt
+			// A fresh calculation supersedes an operator override
+			state.Overridden = false
+
+		case models.ScoreEventPublished:
+			var payload ScorePublishedEvent
+			if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+				return nil, fmt.Errorf("invalid %s event %d: %w", event.EventType, event.Sequence, err)
+			}
+			state.PublishedScore = payload.Score
+			state.PublishedTxHash = payload.TxHash
+			state.LastPublishedAt = &occurredAt
+
+		case models.ScoreEventDisputed:
+			state.Disputed = true
+
+		case models.ScoreEventOverridden:
+			var payload ScoreOverriddenEvent
+			if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+				return nil, fmt.Errorf("invalid %s event %d: %w", event.EventType, event.Sequence, err)
+			}
+			state.Score = payload.Score
+			state.Confidence = payload.Confidence
+			state.Overridden = true
+
+		default:
+			return nil, fmt.Errorf("unknown score event type %q at sequence %d", event.EventType, event.Sequence)
+		}
+
+		state.EventCount++
+		state.LastSequence = event.Sequence
+	}
+
+	return state, nil
+}
+
+// GetScoreEvents lists an address's score lifecycle events after the given sequence
+func (s *OracleService) GetScoreEvents(ctx context.Context, address string, afterSequence uint64, limit int) ([]*models.ScoreEvent, error) {
+	return s.repo.ListScoreEvents(ctx, address, afterSequence, limit)
+}
+
+// RebuildScoreState replays an address's event stream and checks the result against
+// the stored score. It returns nil if the address has no events.
+func (s *OracleService) RebuildScoreState(ctx context.Context, address string) (*ScoreStateReport, error) {
+	events, err := s.repo.ListScoreEvents(ctx, address, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	state, err := ReplayScoreEvents(address, events)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ScoreStateReport{State: state}
+
+	stored, err := s.repo.GetByAddress(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		report.Mismatches = append(report.Mismatches, "no stored score")
+	} else {
+		if stored.Score != state.Score {
+			report.Mismatches = append(report.Mismatches, fmt.Sprintf("score: stored %d, replayed %d", stored.Score, state.Score))
+		}
+		if stored.Confidence != state.Confidence {
+			report.Mismatches = append(report.Mismatches, fmt.Sprintf("confidence: stored %d, replayed %d", stored.Confidence, state.Confidence))
+		}
+		if !state.Overridden && stored.DataHash != state.DataHash {
+			report.Mismatches = append(report.Mismatches, "data hash differs")
+		}
+		if int(stored.UpdateCount) != state.Calculations {
+			// Scores calculated before the event stream existed have fewer events
+			report.Mismatches = append(report.Mismatches, fmt.Sprintf("calculations: stored %d, replayed %d", stored.UpdateCount, state.Calculations))
+		}
+	}
+	report.Consistent = len(report.Mismatches) == 0
+
+	return report, nil
+}
+
+// recordScoreEvent appends an event to the address's stream. Failures are logged
+// rather than failing the operation that produced the event.
+func (s *OracleService) recordScoreEvent(ctx context.Context, address, eventType string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("Failed to encode score event", zap.String("type", eventType), zap.Error(err))
+		return
+	}
+
+	event := &models.ScoreEvent{
+		UserAddress: address,
+		EventType:   eventType,
+		Payload:     string(data),
+	}
+	if err := s.repo.AppendScoreEvent(ctx, event); err != nil {
+		logger.Error("Failed to record score event",
+			zap.String("address", address),
+			zap.String("type", eventType),
+			zap.Error(err),
+		)
+	}
+}
+
+// recordMetricsFetched records the metrics a score is about to be calculated from
+func (s *OracleService) recordMetricsFetched(ctx context.Context, address string, onChain *models.OnChainMetrics, offChain *models.OffChainMetrics) {
+	if onChain == nil && offChain == nil {
+		return
+	}
+	s.recordScoreEvent(ctx, address, models.ScoreEventMetricsFetched, MetricsFetchedEvent{
+		OnChain:  onChain,
+		OffChain: offChain,
+	})
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+)
+
+func TestReplayScoreEvents(t *testing.T) {
+	now := time.Now()
+	address := "0x1234567890123456789012345678901234567890"
+	events := []*models.ScoreEvent{
+		{Sequence: 1, EventType: models.ScoreEventMetricsFetched, Payload: `{"on_chain":{"user_address":"` + address + `","wallet_age":120}}`, CreatedAt: now},
+		{Sequence: 2, EventType: models.ScoreEventCalculated, Payload: `{"score":700,"confidence":80,"data_hash":"abc","change_reason":"manual"}`, CreatedAt: now},
+		{Sequence: 3, EventType: models.ScoreEventPublished, Payload: `{"score":700,"confidence":80,"tx_hash":"0xaaa"}`, CreatedAt: now},
+		{Sequence: 4, EventType: models.ScoreEventDisputed, Payload: `{"reason":"wrong account"}`, CreatedAt: now},
+		{Sequence: 5, EventType: models.ScoreEventOverridden, Payload: `{"score":720,"confidence":90,"reason":"dispute upheld"}`, CreatedAt: now},
+	}
+
+	state, err := ReplayScoreEvents(address, events)
+	if err != nil {
+		t.Fatalf("Failed to replay events: %v", err)
+	}
+
+	if state.Score != 720 || state.Confidence != 90 || !state.Overridden {
+		t.Errorf("Expected overridden score 720/90, got %d/%d overridden=%v", state.Score, state.Confidence, state.Overridden)
+	}
+	if state.PublishedScore != 700 || state.PublishedTxHash != "0xaaa" {
+		t.Errorf("Expected published score 700 in 0xaaa, got %d in %s", state.PublishedScore, state.PublishedTxHash)
+	}
+	if !state.Disputed || state.Calculations != 1 || state.EventCount != 5 || state.LastSequence != 5 {
+		t.Errorf("Unexpected replayed state: %+v", state)
+	}
+	if state.OnChainMetrics == nil || state.OnChainMetrics.WalletAge != 120 {
+		t.Error("Expected on-chain metrics from the metrics fetched event")
+	}
+
+	// A recalculation supersedes the override
+	events = append(events, &models.ScoreEvent{Sequence: 6, EventType: models.ScoreEventCalculated, Payload: `{"score":650,"confidence":85,"data_hash":"def"}`})
+	state, err = ReplayScoreEvents(address, events)
+	if err != nil {
+		t.Fatalf("Failed to replay events: %v", err)
+	}
+	if state.Score != 650 || state.Overridden || state.Calculations != 2 {
+		t.Errorf("Expected recalculated score 650, got %+v", state)
+	}
+}
+
+func TestReplayScoreEventsRejectsGaps(t *testing.T) {
+	events := []*models.ScoreEvent{
+		{Sequence: 1, EventType: models.ScoreEventCalculated, Payload: `{"score":700}`},
+		{Sequence: 3, EventType: models.ScoreEventCalculated, Payload: `{"score":710}`},
+	}
+	if _, err := ReplayScoreEvents("0x1111", events); err == nil {
+		t.Error("Expected an error for a gap in the event stream")
+	}
+
+	unknown := []*models.ScoreEvent{{Sequence: 1, EventType: "mystery", Payload: `{}`}}
+	if _, err := ReplayScoreEvents("0x1111", unknown); err == nil {
+		t.Error("Expected an error for an unknown event type")
+	}
+}
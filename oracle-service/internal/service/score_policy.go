@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+)
+
+// SetScorePolicies sets the floors and ceilings each tenant puts on the scores it
+// reads. Stored and published scores are never clamped.
+func (s *OracleService) SetScorePolicies(policies scoring.ScorePolicies) {
+	s.scorePolicies = policies
+}
+
+// ApplyScorePolicy returns the score as the tenant sees it, clamped by the tenant's
+// policy, along with the clamps that changed it. Scores of tenants without a policy
+// and provisional scores, which carry no score value, are returned unchanged.
+func (s *OracleService) ApplyScorePolicy(ctx context.Context, tenant string, score *models.CreditScore) (*models.CreditScore, []scoring.AppliedClamp, error) {
+	if score == nil || score.Provisional || !s.scorePolicies.Has(tenant) {
+		return score, nil, nil
+	}
+
+	onChain, err := s.repo.GetOnChainMetrics(ctx, score.UserAddress)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get on-chain metrics: %w", err)
+	}
+	offChain, err := s.repo.GetOffChainMetrics(ctx, score.UserAddress)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get off-chain metrics: %w", err)
+	}
+
+	clamped, applied := s.scorePolicies.Apply(tenant, score.Score, onChain, offChain)
+	if len(applied) == 0 {
+		return score, nil, nil
+	}
+
+	tenantScore := *score
+	tenantScore.Score = clamped
+	return &tenantScore, applied, nil
+}
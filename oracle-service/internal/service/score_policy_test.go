@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+)
+
+func TestApplyScorePolicy(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+	address := "0x1234567890123456789012345678901234567890"
+
+	score, err := service.CalculateAndUpdateScore(ctx, address, "")
+	if err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+	if score.Score <= units.MinScore {
+		t.Fatalf("Expected a score above the minimum, got %d", score.Score)
+	}
+	engineScore := score.Score
+
+	service.SetScorePolicies(scoring.ScorePolicies{
+		"lender-a": {{Name: "ceiling", Ceiling: units.MinScore}},
+	})
+
+	clamped, applied, err := service.ApplyScorePolicy(ctx, "lender-a", score)
+	if err != nil {
+		t.Fatalf("Failed to apply score policy: %v", err)
+	}
+	if clamped.Score != units.MinScore || len(applied) != 1 || applied[0].EngineScore != engineScore {
+		t.Errorf("Expected the score clamped to %d, got %d with %+v", units.MinScore, clamped.Score, applied)
+	}
+
+	// The stored score is what other tenants and the chain see
+	stored, err := service.GetScore(ctx, address)
+	if err != nil {
+		t.Fatalf("Failed to get score: %v", err)
+	}
+	if stored.Score != engineScore || score.Score != engineScore {
+		t.Errorf("Expected the stored score to stay %d, got %d", engineScore, stored.Score)
+	}
+
+	unclamped, applied, err := service.ApplyScorePolicy(ctx, "lender-b", score)
+	if err != nil || unclamped != score || applied != nil {
+		t.Errorf("Expected tenants without a policy to get the engine score, got %+v, %v", applied, err)
+	}
+}
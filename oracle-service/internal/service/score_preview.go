@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Kinds of score preview changes
+const (
+	ScoreChangeChanged = "changed"
+	ScoreChangeAdded   = "added"   // Factor applies only to the previewed score
+	ScoreChangeRemoved = "removed" // Factor applies only to the stored score
+)
+
+// ScoreChangeComponentScore is the component of the change to the final score
+const ScoreChangeComponentScore = "score"
+
+// ScorePreview is the score an update would produce from freshly fetched data, next
+// to the stored score
+type ScorePreview struct {
+	Address string               `json:"address"`
+	Current *scoring.Explanation `json:"current"` // Stored score with the factors of its stored metrics; nil if never scored
+	Preview *scoring.Explanation `json:"preview"`
+	Changes []ScoreChange        `json:"changes"` // Empty if never scored
+}
+
+// ScoreChange is a difference between the stored and the previewed score, in the
+// score, its confidence, a component score or an adjustment factor
+type ScoreChange struct {
+	Component string  `json:"component"`        // score, confidence, or a scoring component
+	Factor    string  `json:"factor,omitempty"` // Adjustment reason code; empty for the values themselves
+	Change    string  `json:"change"`
+	Before    float64 `json:"before"`
+	After     float64 `json:"after"`
+	Delta     float64 `json:"delta"`
+}
+
+// PreviewScore runs the update pipeline for an address, fetching its metrics and
+// scoring them, but stores nothing: no metrics, score, history or events. Frozen
+// profiles can't be previewed, since previewing pulls provider data.
+func (s *OracleService) PreviewScore(ctx context.Context, address, userID string) (*ScorePreview, error) {
+	if err := s.checkNotFrozen(ctx, address); err != nil {
+		return nil, err
+	}
+
+	onChainMetrics, err := s.onChainAgg.FetchMetrics(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch on-chain metrics: %w", err)
+	}
+	offChainMetrics, err := s.offChainAgg.FetchMetrics(ctx, userID, address)
+	if err != nil {
+		logger.Error("Failed to fetch off-chain metrics", zap.Error(err))
+		// Preview with on-chain data only, as an update would
+		offChainMetrics = nil
+	}
+
+	preview, err := s.scoringEngine.ExplainWithReliability(onChainMetrics, offChainMetrics, s.confidenceFactor(ctx, address))
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate score: %w", err)
+	}
+
+	result := &ScorePreview{
+		Address: address,
+		Preview: preview,
+		Changes: []ScoreChange{},
+	}
+
+	stored, err := s.repo.GetByAddress(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score: %w", err)
+	}
+	if stored == nil {
+		return result, nil
+	}
+
+	current, err := s.ExplainScore(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		current = &scoring.Explanation{Adjustments: []scoring.Adjustment{}}
+	}
+	// The stored score is what borrowers see, even if the engine would now score its
+	// stored metrics differently
+	current.Score = stored.Score
+	current.Confidence = stored.Confidence
+	current.OnChainScore = stored.OnChainScore
+	current.OffChainScore = stored.OffChainScore
+	current.HybridScore = stored.HybridScore
+
+	result.Current = current
+	result.Changes = diffExplanations(current, preview)
+	return result, nil
+}
+
+// diffExplanations lists what differs between two explanations: first the score, its
+// confidence and the component scores, then the adjustment factors
+func diffExplanations(before, after *scoring.Explanation) []ScoreChange {
+	changes := []ScoreChange{}
+	add := func(component, factor, change string, from, to float64) {
+		changes = append(changes, ScoreChange{
+			Component: component,
+			Factor:    factor,
+			Change:    change,
+			Before:    from,
+			After:     to,
+			Delta:     to - from,
+		})
+	}
+
+	values := []struct {
+		component string
+		from, to  float64
+	}{
+		{ScoreChangeComponentScore, float64(before.Score), float64(after.Score)},
+		{scoring.ComponentConfidence, float64(before.Confidence), float64(after.Confidence)},
+		{scoring.ComponentOnChain, float64(before.OnChainScore), float64(after.OnChainScore)},
+		{scoring.ComponentOffChain, float64(before.OffChainScore), float64(after.OffChainScore)},
+		{scoring.ComponentHybrid, float64(before.HybridScore), float64(after.HybridScore)},
+	}
+	for _, value := range values {
+		if value.from != value.to {
+			add(value.component, "", ScoreChangeChanged, value.from, value.to)
+		}
+	}
+
+	key := func(adjustment scoring.Adjustment) string {
+		return adjustment.Component + "/" + adjustment.Factor
+	}
+	previous := make(map[string]scoring.Adjustment, len(before.Adjustments))
+	for _, adjustment := range before.Adjustments {
+		previous[key(adjustment)] = adjustment
+	}
+	for _, adjustment := range after.Adjustments {
+		old, ok := previous[key(adjustment)]
+		delete(previous, key(adjustment))
+		switch {
+		case !ok:
+			add(adjustment.Component, adjustment.Factor, ScoreChangeAdded, 0, adjustment.Value)
+		case old.Value != adjustment.Value:
+			add(adjustment.Component, adjustment.Factor, ScoreChangeChanged, old.Value, adjustment.Value)
+		}
+	}
+	for _, adjustment := range before.Adjustments {
+		if _, ok := previous[key(adjustment)]; ok {
+			add(adjustment.Component, adjustment.Factor, ScoreChangeRemoved, adjustment.Value, 0)
+		}
+	}
+
+	return changes
+}
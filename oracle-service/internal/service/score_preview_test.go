@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+)
+
+func TestPreviewScore(t *testing.T) {
+	service, db := setupTestService(t)
+	ctx := context.Background()
+	address := "0x1234567890123456789012345678901234567890"
+
+	// Never scored: a preview with nothing to compare against
+	preview, err := service.PreviewScore(ctx, address, "user123")
+	if err != nil {
+		t.Fatalf("Failed to preview score: %v", err)
+	}
+	if preview.Current != nil || len(preview.Changes) != 0 {
+		t.Errorf("Expected no current score or changes, got %+v", preview)
+	}
+	if preview.Preview.Score < 300 || preview.Preview.Score > 850 {
+		t.Errorf("Previewed score %d is outside valid range [300-850]", preview.Preview.Score)
+	}
+	var count int64
+	db.Model(&models.CreditScore{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("Expected the preview to store no score, got %d", count)
+	}
+
+	score, err := service.CalculateAndUpdateScore(ctx, address, "user123")
+	if err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	// Make the stored state differ from what fresh data produces
+	db.Model(&models.CreditScore{}).Where("id = ?", score.ID).Update("score", score.Score-20)
+	db.Model(&models.OnChainMetrics{}).Where("user_address = ?", address).Update("temporary_discount", 0.5)
+
+	preview, err = service.PreviewScore(ctx, address, "user123")
+	if err != nil {
+		t.Fatalf("Failed to preview score: %v", err)
+	}
+	if preview.Current == nil || preview.Current.Score != score.Score-20 {
+		t.Fatalf("Expected the stored score next to the preview, got %+v", preview.Current)
+	}
+
+	changes := make(map[string]ScoreChange)
+	for _, change := range preview.Changes {
+		changes[change.Component+"/"+change.Factor] = change
+	}
+	if change := changes[ScoreChangeComponentScore+"/"]; change.Delta != 20 || change.Change != ScoreChangeChanged {
+		t.Errorf("Expected the score to change by 20, got %+v", change)
+	}
+	if change := changes[scoring.ComponentOnChain+"/temporary_deposit"]; change.Change != ScoreChangeRemoved || change.Before != 0.5 {
+		t.Errorf("Expected the temporary deposit discount to be removed, got %+v", change)
+	}
+
+	// Nothing is stored by a preview
+	stored, _ := service.GetScore(ctx, address)
+	if stored.Score != score.Score-20 || stored.UpdateCount != 1 {
+		t.Errorf("Expected the stored score to be unchanged, got %+v", stored)
+	}
+	db.Model(&models.ScoreHistory{}).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected 1 history record, got %d", count)
+	}
+	metrics, _ := service.repo.GetOnChainMetrics(ctx, address)
+	if metrics.TemporaryDiscount != 0.5 {
+		t.Errorf("Expected the stored metrics to be unchanged, got discount %v", metrics.TemporaryDiscount)
+	}
+}
+
+func TestPreviewScoreFrozen(t *testing.T) {
+	service, db := setupTestService(t)
+	address := "0x1234567890123456789012345678901234567890"
+
+	db.Create(&models.DataFreeze{UserAddress: address, Frozen: true})
+
+	if _, err := service.PreviewScore(context.Background(), address, ""); !errors.Is(err, ErrProfileFrozen) {
+		t.Errorf("Expected ErrProfileFrozen, got %v", err)
+	}
+}
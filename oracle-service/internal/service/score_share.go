@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/scoring"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/units"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Share link lifetimes
+const (
+	DefaultShareExpiry = 7 * 24 * time.Hour
+	MaxShareExpiry     = 90 * 24 * time.Hour
+)
+
+// Share link errors
+var (
+	ErrShareNotFound      = errors.NotFound("share link not found")
+	ErrShareInactive      = errors.New("share link expired or revoked")
+	ErrInvalidShareExpiry = errors.Validation("invalid share link expiry")
+)
+
+// Requester identifies who made a request, for the audit log
+type Requester struct {
+	RemoteAddr string
+	UserAgent  string
+	Operator   string // Admin operator whose key signed the request, if it was signed
+}
+
+// adminActor names an admin in the audit log, with the operator who signed the
+// request if it was signed
+func adminActor(requester Requester) string {
+	if requester.Operator == "" {
+		return "admin"
+	}
+	return "admin:" + requester.Operator
+}
+
+// CreatedShare is a newly created share link. The token is only ever returned here.
+type CreatedShare struct {
+	*models.ScoreShare
+	Token string `json:"token"`
+}
+
+// SharedScore is what the holder of a share link can read
+type SharedScore struct {
+	Address     string               `json:"address"`
+	Score       units.Score          `json:"score,omitempty"` // Omitted while provisional
+	Confidence  units.Confidence     `json:"confidence"`
+	Provisional bool                 `json:"provisional"` // Too little data for a final score
+	LastUpdated time.Time            `json:"last_updated"`
+	Explanation *scoring.Explanation `json:"explanation,omitempty"`
+	ExpiresAt   time.Time            `json:"expires_at"` // When the share link stops working
+}
+
+// ShareActionCreate is the wallet-signed action that creates a share link
+const ShareActionCreate = "share"
+
+// ShareRevokeAction returns the wallet-signed action that revokes a share link
+func ShareRevokeAction(id uint) string {
+	return fmt.Sprintf("revoke_share:%d", id)
+}
+
+// CreateScoreShare creates a share link for an address's score that expires after
+// expiresIn (DefaultShareExpiry if zero). The request must be signed by the
+// address's wallet.
+func (s *OracleService) CreateScoreShare(ctx context.Context, address, label string, expiresIn time.Duration, timestamp int64, signature string, requester Requester) (*CreatedShare, error) {
+	if expiresIn == 0 {
+		expiresIn = DefaultShareExpiry
+	}
+	if expiresIn < 0 || expiresIn > MaxShareExpiry {
+		return nil, fmt.Errorf("%w: must be at most %s", ErrInvalidShareExpiry, MaxShareExpiry)
+	}
+
+	if err := s.authenticateWallet(ctx, ShareActionCreate, address, timestamp, signature); err != nil {
+		return nil, err
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate share token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+
+	share := &models.ScoreShare{
+		TokenHash:   hashShareToken(token),
+		UserAddress: address,
+		Label:       label,
+		ExpiresAt:   time.Now().Add(expiresIn),
+	}
+	if err := s.repo.CreateScoreShare(ctx, share); err != nil {
+		return nil, fmt.Errorf("failed to create score share: %w", err)
+	}
+
+	s.recordAudit(ctx, &models.AuditLog{
+		Action:      models.AuditShareCreated,
+		Actor:       share.UserAddress,
+		UserAddress: share.UserAddress,
+		Detail:      fmt.Sprintf("share %d expires %s", share.ID, share.ExpiresAt.UTC().Format(time.RFC3339)),
+		RemoteAddr:  requester.RemoteAddr,
+		UserAgent:   requester.UserAgent,
+	})
+
+	return &CreatedShare{ScoreShare: share, Token: token}, nil
+}
+
+// RevokeScoreShare revokes one of an address's share links. The request must be
+// signed by the address's wallet.
+func (s *OracleService) RevokeScoreShare(ctx context.Context, address string, id uint, timestamp int64, signature string, requester Requester) (*models.ScoreShare, error) {
+	if err := s.authenticateWallet(ctx, ShareRevokeAction(id), address, timestamp, signature); err != nil {
+		return nil, err
+	}
+
+	share, err := s.repo.GetScoreShare(ctx, address, id)
+	if err != nil {
+		return nil, err
+	}
+	if share == nil {
+		return nil, ErrShareNotFound
+	}
+
+	if share.RevokedAt == nil {
+		now := time.Now()
+		share.RevokedAt = &now
+		if err := s.repo.UpdateScoreShare(ctx, share); err != nil {
+			return nil, fmt.Errorf("failed to revoke score share: %w", err)
+		}
+
+		s.recordAudit(ctx, &models.AuditLog{
+			Action:      models.AuditShareRevoked,
+			Actor:       share.UserAddress,
+			UserAddress: share.UserAddress,
+			Detail:      fmt.Sprintf("share %d", share.ID),
+			RemoteAddr:  requester.RemoteAddr,
+			UserAgent:   requester.UserAgent,
+		})
+	}
+
+	return share, nil
+}
+
+// GetSharedScore returns the score and explanation a share link grants access to.
+// Every use of a link, including a refused one, is recorded in the audit log; access
+// is refused if it cannot be recorded.
+func (s *OracleService) GetSharedScore(ctx context.Context, token string, requester Requester) (*SharedScore, error) {
+	share, err := s.repo.GetScoreShareByTokenHash(ctx, hashShareToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if share == nil {
+		return nil, ErrShareNotFound
+	}
+
+	entry := &models.AuditLog{
+		Action:      models.AuditShareAccessed,
+		Actor:       fmt.Sprintf("share:%d", share.ID),
+		UserAddress: share.UserAddress,
+		RemoteAddr:  requester.RemoteAddr,
+		UserAgent:   requester.UserAgent,
+	}
+
+	now := time.Now()
+	if !share.Active(now) {
+		entry.Action = models.AuditShareDenied
+		s.recordAudit(ctx, entry)
+		return nil, ErrShareInactive
+	}
+
+	score, err := s.repo.GetByAddress(ctx, share.UserAddress)
+	if err != nil {
+		return nil, err
+	}
+	if score == nil {
+		return nil, ErrShareNotFound
+	}
+
+	var explanation *scoring.Explanation
+	if !score.Provisional {
+		explanation, err = s.ExplainScore(ctx, share.UserAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if s.audit != nil {
+		if err := s.audit.Record(ctx, entry); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.RecordScoreShareAccess(ctx, share, now); err != nil {
+		logger.Error("Failed to count share link access", zap.Error(err))
+	}
+
+	shared := &SharedScore{
+		Address:     score.UserAddress,
+		Score:       score.Score,
+		Confidence:  score.Confidence,
+		Provisional: score.Provisional,
+		LastUpdated: score.LastUpdated,
+		Explanation: explanation,
+		ExpiresAt:   share.ExpiresAt,
+	}
+	if score.Provisional {
+		shared.Score = 0
+	}
+	return shared, nil
+}
+
+// ListAuditLog lists audit log entries, newest first, optionally filtered by address
+// and action
+func (s *OracleService) ListAuditLog(ctx context.Context, address, action string, limit int) ([]*models.AuditLog, error) {
+	if s.audit == nil {
+		return []*models.AuditLog{}, nil
+	}
+	return s.audit.List(ctx, address, action, limit)
+}
+
+// recordAudit appends an entry to the audit log, logging rather than returning a
+// failure
+func (s *OracleService) recordAudit(ctx context.Context, entry *models.AuditLog) {
+	if s.audit == nil {
+		return
+	}
+	if err := s.audit.Record(ctx, entry); err != nil {
+		logger.Error("Failed to record audit log entry",
+			zap.String("action", entry.Action),
+			zap.String("address", entry.UserAddress),
+			zap.Error(err),
+		)
+	}
+}
+
+func hashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+)
+
+func TestScoreShare(t *testing.T) {
+	service, db := setupTestService(t)
+	service.SetAuditLog(repository.NewAuditRepository(db))
+	ctx := context.Background()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	if _, err := service.CalculateAndUpdateScore(ctx, address, ""); err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	requester := Requester{RemoteAddr: "203.0.113.7", UserAgent: "landlord-portal"}
+	now := time.Now().Unix()
+	share, err := service.CreateScoreShare(ctx, address, "landlord", 24*time.Hour, now,
+		signWallet(t, key, ShareActionCreate, address, now), requester)
+	if err != nil {
+		t.Fatalf("Failed to create share: %v", err)
+	}
+	if share.Token == "" || share.TokenHash == share.Token {
+		t.Fatal("Expected a token that is stored only as a hash")
+	}
+
+	shared, err := service.GetSharedScore(ctx, share.Token, requester)
+	if err != nil {
+		t.Fatalf("Failed to read shared score: %v", err)
+	}
+	if !strings.EqualFold(shared.Address, address) || shared.Explanation == nil {
+		t.Errorf("Expected score and explanation for %s, got %+v", address, shared)
+	}
+
+	if _, err := service.GetSharedScore(ctx, "not-a-token", requester); !errors.Is(err, ErrShareNotFound) {
+		t.Errorf("Expected ErrShareNotFound for unknown token, got %v", err)
+	}
+
+	revokeAt := now + 1
+	if _, err := service.RevokeScoreShare(ctx, address, share.ID, revokeAt,
+		signWallet(t, key, ShareRevokeAction(share.ID), address, revokeAt), requester); err != nil {
+		t.Fatalf("Failed to revoke share: %v", err)
+	}
+	if _, err := service.GetSharedScore(ctx, share.Token, requester); !errors.Is(err, ErrShareInactive) {
+		t.Errorf("Expected ErrShareInactive after revocation, got %v", err)
+	}
+
+	entries, err := service.ListAuditLog(ctx, address, "", 10)
+	if err != nil {
+		t.Fatalf("Failed to list audit log: %v", err)
+	}
+	var actions []string
+	for _, entry := range entries {
+		actions = append(actions, entry.Action)
+	}
+	expected := []string{models.AuditShareDenied, models.AuditShareRevoked, models.AuditShareAccessed, models.AuditShareCreated}
+	if len(actions) != len(expected) {
+		t.Fatalf("Expected audit actions %v, got %v", expected, actions)
+	}
+	for i := range expected {
+		if actions[i] != expected[i] {
+			t.Errorf("Expected audit actions %v, got %v", expected, actions)
+			break
+		}
+	}
+	if entries[2].Actor == address || entries[2].RemoteAddr != requester.RemoteAddr {
+		t.Errorf("Expected access to be attributed to the share link holder, got %+v", entries[2])
+	}
+}
+
+func TestScoreShareExpiry(t *testing.T) {
+	service, db := setupTestService(t)
+	ctx := context.Background()
+
+	key, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	if _, err := service.CalculateAndUpdateScore(ctx, address, ""); err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+
+	now := time.Now().Unix()
+	if _, err := service.CreateScoreShare(ctx, address, "", MaxShareExpiry+time.Hour, now,
+		signWallet(t, key, ShareActionCreate, address, now), Requester{}); !errors.Is(err, ErrInvalidShareExpiry) {
+		t.Errorf("Expected ErrInvalidShareExpiry, got %v", err)
+	}
+
+	share, err := service.CreateScoreShare(ctx, address, "", 0, now,
+		signWallet(t, key, ShareActionCreate, address, now), Requester{})
+	if err != nil {
+		t.Fatalf("Failed to create share: %v", err)
+	}
+	db.Model(&models.ScoreShare{}).Where("id = ?", share.ID).Update("expires_at", time.Now().Add(-time.Minute))
+
+	if _, err := service.GetSharedScore(ctx, share.Token, Requester{}); !errors.Is(err, ErrShareInactive) {
+		t.Errorf("Expected ErrShareInactive after expiry, got %v", err)
+	}
+}
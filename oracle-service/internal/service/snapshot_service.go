@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/snapshot"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ErrSnapshotsNotConfigured is returned when no snapshot store is configured
+var ErrSnapshotsNotConfigured = errors.New("snapshot store not configured")
+
+// Snapshot table names
+const (
+	snapshotCreditScores    = "credit_scores"
+	snapshotScoreHistory    = "score_history"
+	snapshotOnChainMetrics  = "on_chain_metrics"
+	snapshotOffChainMetrics = "off_chain_metrics"
+	snapshotOracleUpdates   = "oracle_updates"
+	snapshotScoreEvents     = "score_events"
+)
+
+// SnapshotRestoreResult reports a completed restore
+type SnapshotRestoreResult struct {
+	Snapshot   *snapshot.Manifest `json:"snapshot"`
+	Rows       map[string]int     `json:"rows"`
+	RestoredAt time.Time          `json:"restored_at"`
+}
+
+// SnapshotService exports the oracle's score state to object storage and restores it
+// after a database loss
+type SnapshotService struct {
+	repo  *repository.ScoreRepository
+	store snapshot.Store // nil when snapshots are not configured
+}
+
+// NewSnapshotService creates a new snapshot service
+func NewSnapshotService(repo *repository.ScoreRepository, store snapshot.Store) *SnapshotService {
+	return &SnapshotService{
+		repo:  repo,
+		store: store,
+	}
+}
+
+// CreateSnapshot exports scores, metrics, history, oracle updates and score events
+func (s *SnapshotService) CreateSnapshot(ctx context.Context) (*snapshot.Manifest, error) {
+	if s.store == nil {
+		return nil, ErrSnapshotsNotConfigured
+	}
+
+	state, err := s.repo.ExportState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	manifest, err := snapshot.Write(ctx, s.store, snapshot.NewID(now), now, []snapshot.Table{
+		{Name: snapshotCreditScores, Rows: state.CreditScores},
+		{Name: snapshotScoreHistory, Rows: state.ScoreHistory},
+		{Name: snapshotOnChainMetrics, Rows: state.OnChainMetrics},
+		{Name: snapshotOffChainMetrics, Rows: state.OffChainMetrics},
+		{Name: snapshotOracleUpdates, Rows: state.OracleUpdates},
+		{Name: snapshotScoreEvents, Rows: state.ScoreEvents},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	logger.Info("Snapshot created",
+		zap.String("id", manifest.ID),
+		zap.Int("scores", len(state.CreditScores)),
+	)
+	return manifest, nil
+}
+
+// ListSnapshots lists complete snapshots, newest first
+func (s *SnapshotService) ListSnapshots(ctx context.Context) ([]*snapshot.Manifest, error) {
+	if s.store == nil {
+		return nil, ErrSnapshotsNotConfigured
+	}
+	return snapshot.List(ctx, s.store)
+}
+
+// VerifySnapshot reads a snapshot back and checks every table's checksum and row count
+func (s *SnapshotService) VerifySnapshot(ctx context.Context, id string) (*snapshot.Manifest, error) {
+	if s.store == nil {
+		return nil, ErrSnapshotsNotConfigured
+	}
+
+	snap, err := snapshot.Open(ctx, s.store, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decodeSnapshot(snap); err != nil {
+		return nil, err
+	}
+
+	return snap.Manifest, nil
+}
+
+// RestoreSnapshot verifies a snapshot and replaces the score tables with it. Unless
+// replace is set, it refuses to overwrite a database that already has scores.
+func (s *SnapshotService) RestoreSnapshot(ctx context.Context, id string, replace bool) (*SnapshotRestoreResult, error) {
+	if s.store == nil {
+		return nil, ErrSnapshotsNotConfigured
+	}
+
+	snap, err := snapshot.Open(ctx, s.store, id)
+	if err != nil {
+		return nil, err
+	}
+	state, err := decodeSnapshot(snap)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.RestoreState(ctx, state, replace); err != nil {
+		return nil, err
+	}
+
+	result := &SnapshotRestoreResult{
+		Snapshot:   snap.Manifest,
+		Rows:       make(map[string]int, len(snap.Manifest.Tables)),
+		RestoredAt: time.Now(),
+	}
+	for _, table := range snap.Manifest.Tables {
+		result.Rows[table.Name] = table.Rows
+	}
+
+	logger.Warn("Score state restored from snapshot",
+		zap.String("id", id),
+		zap.Bool("replace", replace),
+		zap.Int("scores", len(state.CreditScores)),
+	)
+	return result, nil
+}
+
+func decodeSnapshot(snap *snapshot.Snapshot) (*repository.OracleState, error) {
+	state := &repository.OracleState{}
+	tables := []struct {
+		name string
+		rows interface{}
+	}{
+		{snapshotCreditScores, &state.CreditScores},
+		{snapshotScoreHistory, &state.ScoreHistory},
+		{snapshotOnChainMetrics, &state.OnChainMetrics},
+		{snapshotOffChainMetrics, &state.OffChainMetrics},
+		{snapshotOracleUpdates, &state.OracleUpdates},
+		{snapshotScoreEvents, &state.ScoreEvents},
+	}
+	for _, table := range tables {
+		if err := snap.Decode(table.name, table.rows); err != nil {
+			return nil, err
+		}
+	}
+	return state, nil
+}
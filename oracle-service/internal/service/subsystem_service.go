@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/pause"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// subsystemStateRefresh is how long subsystem states are cached, so a pause made on
+// another instance takes effect without a lookup on every provider call
+const subsystemStateRefresh = 30 * time.Second
+
+// Subsystem states reported in health and stats
+const (
+	SubsystemRunning = "running"
+	SubsystemPaused  = "paused"
+)
+
+// ErrPublishingPaused is returned when a score is published while publishing is paused
+var ErrPublishingPaused = errors.ProviderUnavailable("publishing is paused")
+
+// SubsystemService pauses and resumes subsystems at runtime. States are stored, so a
+// pause applies to every instance and survives restarts.
+type SubsystemService struct {
+	repo              *repository.SubsystemRepository
+	now               func() time.Time
+	maintenanceForced bool // Maintenance mode was set by configuration
+
+	mu       sync.Mutex
+	states   map[string]*models.SubsystemState
+	loadedAt time.Time
+}
+
+// NewSubsystemService creates a new subsystem service
+func NewSubsystemService(repo *repository.SubsystemRepository) *SubsystemService {
+	return &SubsystemService{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+// Pause pauses a subsystem until it is resumed
+func (s *SubsystemService) Pause(ctx context.Context, name, reason string) (*models.SubsystemState, error) {
+	return s.setPaused(ctx, name, true, strings.TrimSpace(reason))
+}
+
+// Resume resumes a paused subsystem. Resuming a running subsystem does nothing.
+func (s *SubsystemService) Resume(ctx context.Context, name string) (*models.SubsystemState, error) {
+	return s.setPaused(ctx, name, false, "")
+}
+
+func (s *SubsystemService) setPaused(ctx context.Context, name string, paused bool, reason string) (*models.SubsystemState, error) {
+	if !pause.Valid(name) {
+		return nil, errors.Validation("unknown subsystem %q, expected one of %s", name, strings.Join(pause.Subsystems, ", "))
+	}
+
+	state := &models.SubsystemState{
+		Name:      name,
+		Paused:    paused,
+		Reason:    reason,
+		UpdatedAt: s.now().UTC(),
+	}
+	if err := s.repo.SaveState(ctx, state); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	if paused {
+		logger.Warn("Subsystem paused", zap.String("subsystem", name), zap.String("reason", reason))
+	} else {
+		logger.Info("Subsystem resumed", zap.String("subsystem", name))
+	}
+	return state, nil
+}
+
+// States lists every subsystem with its state, including subsystems never paused
+func (s *SubsystemService) States(ctx context.Context) ([]*models.SubsystemState, error) {
+	stored, err := s.repo.ListStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*models.SubsystemState, len(stored))
+	for _, state := range stored {
+		byName[state.Name] = state
+	}
+	states := make([]*models.SubsystemState, len(pause.Subsystems))
+	for i, name := range pause.Subsystems {
+		if state, ok := byName[name]; ok {
+			states[i] = state
+		} else {
+			states[i] = &models.SubsystemState{Name: name}
+		}
+	}
+	return states, nil
+}
+
+// Summary maps every subsystem to SubsystemRunning or SubsystemPaused, from the
+// cached states
+func (s *SubsystemService) Summary(ctx context.Context) map[string]string {
+	states := s.cachedStates(ctx)
+	summary := make(map[string]string, len(pause.Subsystems))
+	for _, name := range pause.Subsystems {
+		summary[name] = SubsystemRunning
+		if state := states[name]; state != nil && state.Paused {
+			summary[name] = SubsystemPaused
+		}
+	}
+	return summary
+}
+
+// Paused reports whether a subsystem is paused. It implements pause.Checker.
+func (s *SubsystemService) Paused(ctx context.Context, name string) bool {
+	state := s.cachedStates(ctx)[name]
+	return state != nil && state.Paused
+}
+
+// cachedStates returns the cached states, reloading them once they are stale. If
+// reloading fails the stale states are kept.
+func (s *SubsystemService) cachedStates(ctx context.Context) map[string]*models.SubsystemState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.now().Sub(s.loadedAt) < subsystemStateRefresh {
+		return s.states
+	}
+
+	states, err := s.repo.ListStates(ctx)
+	if err != nil {
+		logger.Error("Failed to load subsystem states", zap.Error(err))
+	} else {
+		s.states = make(map[string]*models.SubsystemState, len(states))
+		for _, state := range states {
+			s.states[state.Name] = state
+		}
+	}
+	s.loadedAt = s.now()
+	return s.states
+}
+
+// invalidate makes the next check reload the states
+func (s *SubsystemService) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadedAt = time.Time{}
+}
+
+// SetSubsystems lets operators pause scheduled updates and publishing
+func (s *OracleService) SetSubsystems(subsystems *SubsystemService) {
+	s.subsystems = subsystems
+}
+
+// SubsystemStates maps every subsystem to SubsystemRunning or SubsystemPaused. It
+// returns nil if subsystems can't be paused.
+func (s *OracleService) SubsystemStates(ctx context.Context) map[string]string {
+	if s.subsystems == nil {
+		return nil
+	}
+	return s.subsystems.Summary(ctx)
+}
+
+// paused reports whether a subsystem is paused
+func (s *OracleService) paused(ctx context.Context, name string) bool {
+	return s.subsystems != nil && s.subsystems.Paused(ctx, name)
+}
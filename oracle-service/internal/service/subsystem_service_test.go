@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/pause"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+)
+
+func TestSubsystemPauseResume(t *testing.T) {
+	_, db := setupTestService(t)
+	ctx := context.Background()
+	subsystems := NewSubsystemService(repository.NewSubsystemRepository(db))
+
+	if subsystems.Paused(ctx, pause.Plaid) {
+		t.Fatal("Expected Plaid to be running before it is paused")
+	}
+
+	state, err := subsystems.Pause(ctx, pause.Plaid, " Plaid incident ")
+	if err != nil {
+		t.Fatalf("Failed to pause Plaid: %v", err)
+	}
+	if !state.Paused || state.Reason != "Plaid incident" {
+		t.Errorf("Expected Plaid paused with its reason, got %+v", state)
+	}
+	if !subsystems.Paused(ctx, pause.Plaid) || subsystems.Paused(ctx, pause.Scheduler) {
+		t.Error("Expected only Plaid to be paused")
+	}
+
+	states, err := subsystems.States(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list subsystem states: %v", err)
+	}
+	if len(states) != len(pause.Subsystems) {
+		t.Fatalf("Expected every subsystem listed, got %d", len(states))
+	}
+	for _, state := range states {
+		if state.Paused != (state.Name == pause.Plaid) {
+			t.Errorf("Unexpected state %+v", state)
+		}
+	}
+
+	if _, err := subsystems.Resume(ctx, pause.Plaid); err != nil {
+		t.Fatalf("Failed to resume Plaid: %v", err)
+	}
+	if subsystems.Paused(ctx, pause.Plaid) {
+		t.Error("Expected Plaid to be running after it is resumed")
+	}
+	if summary := subsystems.Summary(ctx); summary[pause.Plaid] != SubsystemRunning {
+		t.Errorf("Expected Plaid running in the summary, got %v", summary)
+	}
+
+	if _, err := subsystems.Pause(ctx, "mainframe", ""); err == nil {
+		t.Error("Expected pausing an unknown subsystem to fail")
+	}
+}
+
+func TestSubsystemPauseFromOtherInstance(t *testing.T) {
+	_, db := setupTestService(t)
+	ctx := context.Background()
+	repo := repository.NewSubsystemRepository(db)
+
+	now := time.Now()
+	subsystems := NewSubsystemService(repo)
+	subsystems.now = func() time.Time { return now }
+	subsystems.Paused(ctx, pause.Publishing)
+
+	// Another instance pauses publishing; this one sees it once its cache is stale
+	other := NewSubsystemService(repo)
+	if _, err := other.Pause(ctx, pause.Publishing, ""); err != nil {
+		t.Fatalf("Failed to pause publishing: %v", err)
+	}
+	if subsystems.Paused(ctx, pause.Publishing) {
+		t.Error("Expected the cached state until it is refreshed")
+	}
+	now = now.Add(subsystemStateRefresh)
+	if !subsystems.Paused(ctx, pause.Publishing) {
+		t.Error("Expected the pause to be picked up after the refresh interval")
+	}
+}
+
+func TestPausedScheduler(t *testing.T) {
+	service, db := setupTestService(t)
+	ctx := context.Background()
+	subsystems := NewSubsystemService(repository.NewSubsystemRepository(db))
+	service.SetSubsystems(subsystems)
+
+	score := &models.CreditScore{
+		UserAddress:   "0x1111",
+		Score:         700,
+		Confidence:    80,
+		DataHash:      "hash",
+		LastUpdated:   time.Now().Add(-31 * 24 * time.Hour),
+		NextUpdateDue: time.Now().Add(-24 * time.Hour),
+		UpdateCount:   1,
+		IsActive:      true,
+	}
+	db.Create(score)
+
+	subsystems.Pause(ctx, pause.Scheduler, "maintenance")
+	if err := service.ProcessScheduledUpdates(ctx, 10); err != nil {
+		t.Fatalf("Failed to process scheduled updates: %v", err)
+	}
+	stored, _ := service.GetScore(ctx, score.UserAddress)
+	if stored.UpdateCount != 1 {
+		t.Errorf("Expected no update while the scheduler is paused, update count: %d", stored.UpdateCount)
+	}
+
+	subsystems.Resume(ctx, pause.Scheduler)
+	if err := service.ProcessScheduledUpdates(ctx, 10); err != nil {
+		t.Fatalf("Failed to process scheduled updates: %v", err)
+	}
+	stored, _ = service.GetScore(ctx, score.UserAddress)
+	if stored.UpdateCount != 2 {
+		t.Errorf("Expected an update after the scheduler is resumed, update count: %d", stored.UpdateCount)
+	}
+}
+
+func TestPausedPublishing(t *testing.T) {
+	service, db := setupTestService(t)
+	ctx := context.Background()
+	subsystems := NewSubsystemService(repository.NewSubsystemRepository(db))
+	service.SetSubsystems(subsystems)
+	address := "0x1234567890123456789012345678901234567890"
+
+	if _, err := service.CalculateAndUpdateScore(ctx, address, "user123"); err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+	db.Create(&models.OracleUpdate{UserAddress: address, Status: models.OracleUpdateQueued})
+
+	subsystems.Pause(ctx, pause.Publishing, "")
+
+	if err := service.PublishScoreToBlockchain(ctx, address); !errors.Is(err, ErrPublishingPaused) {
+		t.Errorf("Expected ErrPublishingPaused, got %v", err)
+	}
+	if _, err := service.PublishBatch(ctx, []string{address}, 10, true); !errors.Is(err, ErrPublishingPaused) {
+		t.Errorf("Expected ErrPublishingPaused from batch publish, got %v", err)
+	}
+	result, err := service.ProcessPublishQueue(ctx, 10)
+	if err != nil {
+		t.Fatalf("Failed to process publish queue: %v", err)
+	}
+	if !result.Paused || result.Requested != 0 {
+		t.Errorf("Expected the queue to be left alone, got %+v", result)
+	}
+	queued, _ := service.ListOracleUpdates(ctx, models.OracleUpdateQueued, 10)
+	if len(queued) != 1 {
+		t.Errorf("Expected the queued update to stay queued, got %d", len(queued))
+	}
+
+	stats, err := service.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+	states, _ := stats["subsystems"].(map[string]string)
+	if states[pause.Publishing] != SubsystemPaused || states[pause.Scheduler] != SubsystemRunning {
+		t.Errorf("Expected subsystem states in stats, got %v", stats["subsystems"])
+	}
+
+	subsystems.Resume(ctx, pause.Publishing)
+	if err := service.PublishScoreToBlockchain(ctx, address); err != nil {
+		t.Errorf("Expected publishing to work once resumed, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/errors"
+)
+
+// ErrUpdateRateLimited is returned when an address's score is updated again before
+// the minimum update interval has passed
+var ErrUpdateRateLimited = errors.New("score was updated too recently")
+
+// updateLimiter remembers when the last update of each address was started, so a
+// burst of requests is limited before any of them has stored a score
+type updateLimiter struct {
+	interval time.Duration
+
+	mu      sync.Mutex
+	started map[string]time.Time
+	sweptAt time.Time
+}
+
+// SetUpdateMinInterval sets the minimum time between updates of an address that are
+// requested through ReserveUpdate, so the same address can't be used to trigger
+// provider pulls over and over. Zero, the default, disables the limit.
+func (s *OracleService) SetUpdateMinInterval(interval time.Duration) {
+	if interval <= 0 {
+		s.updateLimit = nil
+		return
+	}
+	s.updateLimit = &updateLimiter{
+		interval: interval,
+		started:  make(map[string]time.Time),
+	}
+}
+
+// ReserveUpdate must be called before a requested score update. If the address's
+// score was stored, or an update of it was started, less than the minimum interval
+// ago, it fails with ErrUpdateRateLimited and returns when the next update is
+// allowed. Otherwise the update is recorded as started and the time it was reserved
+// is returned for ReleaseUpdate. Scheduled updates, bureau alerts and admin requests
+// are not limited and don't call it.
+func (s *OracleService) ReserveUpdate(ctx context.Context, address string) (time.Time, error) {
+	limit := s.updateLimit
+	if limit == nil {
+		return time.Time{}, nil
+	}
+
+	// The stored score covers updates made by other instances or before a restart
+	score, err := s.repo.GetByAddress(ctx, address)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var lastUpdated time.Time
+	if score != nil {
+		lastUpdated = score.LastUpdated
+	}
+
+	return limit.reserve(strings.ToLower(address), lastUpdated, time.Now())
+}
+
+// ReleaseUpdate gives back the reservation ReserveUpdate made at reserved, for an
+// update that failed before storing a score, so the address can be updated again
+// straight away. A newer reservation is kept.
+func (s *OracleService) ReleaseUpdate(address string, reserved time.Time) {
+	if limit := s.updateLimit; limit != nil {
+		limit.release(strings.ToLower(address), reserved)
+	}
+}
+
+func (l *updateLimiter) reserve(address string, lastUpdated, now time.Time) (time.Time, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	last := lastUpdated
+	if started := l.started[address]; started.After(last) {
+		last = started
+	}
+	if next := last.Add(l.interval); !last.IsZero() && now.Before(next) {
+		return next, ErrUpdateRateLimited
+	}
+
+	l.started[address] = now
+	return now, nil
+}
+
+func (l *updateLimiter) release(address string, reserved time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if started, ok := l.started[address]; ok && started.Equal(reserved) {
+		delete(l.started, address)
+	}
+}
+
+// sweep forgets updates started more than an interval ago, at most once per interval
+func (l *updateLimiter) sweep(now time.Time) {
+	if now.Sub(l.sweptAt) < l.interval {
+		return
+	}
+	for address, started := range l.started {
+		if now.Sub(started) >= l.interval {
+			delete(l.started, address)
+		}
+	}
+	l.sweptAt = now
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReserveUpdate(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+	address := "0x1234567890123456789012345678901234567890"
+
+	// Without an interval every update is allowed
+	for i := 0; i < 2; i++ {
+		if _, err := service.ReserveUpdate(ctx, address); err != nil {
+			t.Fatalf("Expected unlimited updates, got %v", err)
+		}
+	}
+
+	service.SetUpdateMinInterval(time.Hour)
+
+	// A stored score limits updates even if this instance never started one
+	score, err := service.CalculateAndUpdateScore(ctx, address, "")
+	if err != nil {
+		t.Fatalf("Failed to calculate score: %v", err)
+	}
+	next, err := service.ReserveUpdate(ctx, address)
+	if !errors.Is(err, ErrUpdateRateLimited) {
+		t.Fatalf("Expected ErrUpdateRateLimited, got %v", err)
+	}
+	if want := score.LastUpdated.Add(time.Hour); next.Sub(want).Abs() > time.Second {
+		t.Errorf("Expected next update at %v, got %v", want, next)
+	}
+
+	// Updates are limited from when they start, before any score is stored
+	other := "0xabcdefabcdefabcdefabcdefabcdefabcdefabcd"
+	if _, err := service.ReserveUpdate(ctx, other); err != nil {
+		t.Fatalf("Expected the first update to be allowed, got %v", err)
+	}
+	if _, err := service.ReserveUpdate(ctx, "0xABCDEFABCDEFABCDEFABCDEFABCDEFABCDEFABCD"); !errors.Is(err, ErrUpdateRateLimited) {
+		t.Errorf("Expected a second update in the same interval to be limited, got %v", err)
+	}
+}
+
+func TestUpdateLimiterSweep(t *testing.T) {
+	limiter := &updateLimiter{interval: time.Minute, started: make(map[string]time.Time)}
+	start := time.Now()
+
+	if _, err := limiter.reserve("a", time.Time{}, start); err != nil {
+		t.Fatalf("Expected the first update to be allowed, got %v", err)
+	}
+	if _, err := limiter.reserve("a", time.Time{}, start.Add(30*time.Second)); !errors.Is(err, ErrUpdateRateLimited) {
+		t.Errorf("Expected an update within the interval to be limited, got %v", err)
+	}
+	if _, err := limiter.reserve("b", time.Time{}, start.Add(2*time.Minute)); err != nil {
+		t.Fatalf("Expected an update of another address to be allowed, got %v", err)
+	}
+	if _, ok := limiter.started["a"]; ok {
+		t.Error("Expected updates older than the interval to be forgotten")
+	}
+	if _, err := limiter.reserve("a", time.Time{}, start.Add(2*time.Minute)); err != nil {
+		t.Errorf("Expected an update after the interval to be allowed, got %v", err)
+	}
+}
+
+func TestReleaseUpdate(t *testing.T) {
+	service, _ := setupTestService(t)
+	ctx := context.Background()
+	address := "0x1234567890123456789012345678901234567890"
+
+	// Releasing without a limit does nothing
+	service.ReleaseUpdate(address, time.Now())
+
+	service.SetUpdateMinInterval(time.Hour)
+	reserved, err := service.ReserveUpdate(ctx, address)
+	if err != nil {
+		t.Fatalf("Expected the first update to be allowed, got %v", err)
+	}
+
+	// A failed update gives its reservation back
+	service.ReleaseUpdate(address, reserved)
+	again, err := service.ReserveUpdate(ctx, address)
+	if err != nil {
+		t.Fatalf("Expected an update after a failed one to be allowed, got %v", err)
+	}
+
+	// Releasing an older reservation keeps the newer one
+	service.ReleaseUpdate(address, reserved.Add(-time.Second))
+	if _, err := service.ReserveUpdate(ctx, address); !errors.Is(err, ErrUpdateRateLimited) {
+		t.Errorf("Expected the newer reservation at %v to be kept, got %v", again, err)
+	}
+}
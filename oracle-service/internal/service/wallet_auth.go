@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// walletSignatureTolerance is how far a wallet-signed request's timestamp may be
+// from the server clock
+const walletSignatureTolerance = 5 * time.Minute
+
+// Wallet signature errors
+var (
+	ErrInvalidWalletSignature = errors.New("invalid wallet signature")
+	ErrStaleWalletSignature   = errors.New("signed request timestamp is stale or already used")
+)
+
+// WalletMessage returns the text a wallet signs (EIP-191 personal_sign) to authorize
+// an action on its profile
+func WalletMessage(action, address string, timestamp int64) string {
+	return fmt.Sprintf("P2P-Lend wallet authorization\nAction: %s\nAddress: %s\nTimestamp: %d",
+		action, strings.ToLower(address), timestamp)
+}
+
+// authenticateWallet checks that the action was signed by the address's wallet at
+// timestamp, and that no request signed at or after timestamp was accepted before
+func (s *OracleService) authenticateWallet(ctx context.Context, action, address string, timestamp int64, signature string) error {
+	if !common.IsHexAddress(address) {
+		return fmt.Errorf("%w: %s is not a wallet address", ErrInvalidWalletSignature, address)
+	}
+
+	now := time.Now()
+	signedAt := time.Unix(timestamp, 0)
+	if signedAt.Before(now.Add(-walletSignatureTolerance)) || signedAt.After(now.Add(walletSignatureTolerance)) {
+		return fmt.Errorf("%w: timestamp must be within %s of now", ErrStaleWalletSignature, walletSignatureTolerance)
+	}
+
+	if err := verifyWalletSignature(address, WalletMessage(action, address, timestamp), signature); err != nil {
+		return err
+	}
+
+	accepted, err := s.repo.AdvanceWalletAuth(ctx, address, signedAt)
+	if err != nil {
+		return err
+	}
+	if !accepted {
+		return ErrStaleWalletSignature
+	}
+	return nil
+}
+
+// verifyWalletSignature checks that a hex-encoded personal_sign signature of message
+// was made by address
+func verifyWalletSignature(address, message, signature string) error {
+	sig, err := hexutil.Decode(signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return fmt.Errorf("%w: malformed signature", ErrInvalidWalletSignature)
+	}
+
+	// Wallets produce recovery IDs 27/28; SigToPub expects 0/1
+	sig = append([]byte(nil), sig...)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pubKey, err := crypto.SigToPub(accounts.TextHash([]byte(message)), sig)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWalletSignature, err)
+	}
+	if crypto.PubkeyToAddress(*pubKey) != common.HexToAddress(address) {
+		return fmt.Errorf("%w: signer does not match address", ErrInvalidWalletSignature)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/webhooks"
+)
+
+// ErrWebhooksNotConfigured is returned when replaying without a configured dispatcher
+var ErrWebhooksNotConfigured = errors.New("outbound webhooks not configured")
+
+// WebhookDeliveryDetail is an outbound delivery with its attempt log
+type WebhookDeliveryDetail struct {
+	*models.WebhookDelivery
+	Attempts   []*models.WebhookAttempt  `json:"attempt_log"`
+	DeadLetter *models.WebhookDeadLetter `json:"dead_letter,omitempty"`
+}
+
+// WebhookService exposes outbound webhook deliveries and dead letters to operators
+type WebhookService struct {
+	repo       *repository.WebhookRepository
+	dispatcher *webhooks.Dispatcher // nil when outbound webhooks are not configured
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(repo *repository.WebhookRepository, dispatcher *webhooks.Dispatcher) *WebhookService {
+	return &WebhookService{
+		repo:       repo,
+		dispatcher: dispatcher,
+	}
+}
+
+// ListDeliveries lists outbound deliveries, optionally filtered by status
+func (s *WebhookService) ListDeliveries(ctx context.Context, status string, limit int) ([]*models.WebhookDelivery, error) {
+	return s.repo.ListDeliveries(ctx, models.WebhookOutbound, status, limit)
+}
+
+// GetDelivery retrieves an outbound delivery with its attempts. It returns nil if the
+// delivery does not exist.
+func (s *WebhookService) GetDelivery(ctx context.Context, id uint) (*WebhookDeliveryDetail, error) {
+	delivery, err := s.repo.GetDeliveryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil || delivery.Direction != models.WebhookOutbound {
+		return nil, nil
+	}
+
+	attempts, err := s.repo.ListAttempts(ctx, delivery.ID)
+	if err != nil {
+		return nil, err
+	}
+	deadLetter, err := s.repo.GetDeadLetterByDelivery(ctx, delivery.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebhookDeliveryDetail{
+		WebhookDelivery: delivery,
+		Attempts:        attempts,
+		DeadLetter:      deadLetter,
+	}, nil
+}
+
+// ListDeadLetters lists dead-lettered deliveries
+func (s *WebhookService) ListDeadLetters(ctx context.Context, includeReplayed bool, limit int) ([]*models.WebhookDeadLetter, error) {
+	return s.repo.ListDeadLetters(ctx, includeReplayed, limit)
+}
+
+// ReplayDeadLetter sends a dead-lettered delivery again
+func (s *WebhookService) ReplayDeadLetter(ctx context.Context, id uint) (*models.WebhookDelivery, error) {
+	if s.dispatcher == nil {
+		return nil, ErrWebhooksNotConfigured
+	}
+	return s.dispatcher.Replay(ctx, id)
+}
+
+// GetStats summarizes outbound deliveries over the given window
+func (s *WebhookService) GetStats(ctx context.Context, window time.Duration) (map[string]interface{}, error) {
+	stats, err := s.repo.GetDeliveryStats(ctx, time.Now().Add(-window))
+	if err != nil {
+		return nil, err
+	}
+	stats["window_hours"] = window.Hours()
+	return stats, nil
+}
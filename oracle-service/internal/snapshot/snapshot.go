@@ -0,0 +1,258 @@
+package snapshot
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FormatVersion is the snapshot layout written by this version of the service.
+// Snapshots with a newer version are refused rather than partially restored.
+const FormatVersion = 1
+
+const manifestName = "manifest.json"
+
+// Snapshot integrity errors
+var (
+	ErrChecksumMismatch   = errors.New("snapshot checksum mismatch")
+	ErrUnsupportedVersion = errors.New("unsupported snapshot format version")
+)
+
+// Table is one table's rows to write into a snapshot; Rows must be a slice
+type Table struct {
+	Name string
+	Rows interface{}
+}
+
+// TableInfo describes a table stored in a snapshot
+type TableInfo struct {
+	Name   string `json:"name"`
+	Key    string `json:"key"`
+	Rows   int    `json:"rows"`
+	Bytes  int    `json:"bytes"`
+	SHA256 string `json:"sha256"` // Of the stored (gzipped) object
+}
+
+// Manifest describes a snapshot. It is written last, so a snapshot without a
+// manifest is incomplete and ignored.
+type Manifest struct {
+	FormatVersion int         `json:"format_version"`
+	ID            string      `json:"id"`
+	CreatedAt     time.Time   `json:"created_at"`
+	Tables        []TableInfo `json:"tables"`
+	Checksum      string      `json:"checksum"` // Covers the table checksums, so the manifest can be verified
+}
+
+// Snapshot is a verified snapshot read back from a store
+type Snapshot struct {
+	Manifest *Manifest
+	tables   map[string][]byte
+}
+
+// NewID returns the ID of a snapshot taken at t; IDs sort chronologically
+func NewID(t time.Time) string {
+	return t.UTC().Format("20060102T150405.000Z")
+}
+
+// Write stores each table as gzipped JSON under "<id>/", followed by the manifest
+func Write(ctx context.Context, store Store, id string, createdAt time.Time, tables []Table) (*Manifest, error) {
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		ID:            id,
+		CreatedAt:     createdAt.UTC(),
+	}
+
+	for _, table := range tables {
+		rows := reflect.ValueOf(table.Rows)
+		if rows.Kind() != reflect.Slice {
+			return nil, fmt.Errorf("table %s rows must be a slice", table.Name)
+		}
+
+		data, err := encodeTable(table.Rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode table %s: %w", table.Name, err)
+		}
+
+		info := TableInfo{
+			Name:   table.Name,
+			Key:    id + "/" + table.Name + ".json.gz",
+			Rows:   rows.Len(),
+			Bytes:  len(data),
+			SHA256: sha256Hex(data),
+		}
+		if err := store.Put(ctx, info.Key, data); err != nil {
+			return nil, err
+		}
+		manifest.Tables = append(manifest.Tables, info)
+	}
+
+	manifest.Checksum = manifest.checksum()
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := store.Put(ctx, id+"/"+manifestName, data); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// WriteArchive stores rows as a single gzipped JSON object at key, outside any
+// snapshot. It is used to keep rows that retention policies remove from the database.
+func WriteArchive(ctx context.Context, store Store, key string, rows interface{}) (*TableInfo, error) {
+	value := reflect.ValueOf(rows)
+	if value.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("archive rows must be a slice")
+	}
+
+	data, err := encodeTable(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode archive %s: %w", key, err)
+	}
+	if err := store.Put(ctx, key, data); err != nil {
+		return nil, err
+	}
+
+	return &TableInfo{
+		Name:   key,
+		Key:    key,
+		Rows:   value.Len(),
+		Bytes:  len(data),
+		SHA256: sha256Hex(data),
+	}, nil
+}
+
+// List returns the manifests of complete snapshots, newest first
+func List(ctx context.Context, store Store) ([]*Manifest, error) {
+	keys, err := store.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var manifests []*Manifest
+	for _, key := range keys {
+		if !strings.HasSuffix(key, "/"+manifestName) {
+			continue
+		}
+		manifest, err := ReadManifest(ctx, store, strings.TrimSuffix(key, "/"+manifestName))
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, manifest)
+	}
+
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].ID > manifests[j].ID
+	})
+	return manifests, nil
+}
+
+// ReadManifest reads and verifies a snapshot's manifest
+func ReadManifest(ctx context.Context, store Store, id string) (*Manifest, error) {
+	data, err := store.Get(ctx, id+"/"+manifestName)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest for snapshot %s: %w", id, err)
+	}
+	if manifest.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("%w: snapshot %s has version %d, this service reads up to %d",
+			ErrUnsupportedVersion, id, manifest.FormatVersion, FormatVersion)
+	}
+	if manifest.Checksum != manifest.checksum() {
+		return nil, fmt.Errorf("%w: manifest of snapshot %s", ErrChecksumMismatch, id)
+	}
+
+	return &manifest, nil
+}
+
+// Open reads a snapshot and verifies every table against the manifest checksums
+func Open(ctx context.Context, store Store, id string) (*Snapshot, error) {
+	manifest, err := ReadManifest(ctx, store, id)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{
+		Manifest: manifest,
+		tables:   make(map[string][]byte, len(manifest.Tables)),
+	}
+	for _, info := range manifest.Tables {
+		data, err := store.Get(ctx, info.Key)
+		if err != nil {
+			return nil, err
+		}
+		if sha256Hex(data) != info.SHA256 {
+			return nil, fmt.Errorf("%w: table %s of snapshot %s", ErrChecksumMismatch, info.Name, id)
+		}
+
+		raw, err := decompress(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read table %s: %w", info.Name, err)
+		}
+		snapshot.tables[info.Name] = raw
+	}
+
+	return snapshot, nil
+}
+
+// Decode unmarshals a table into v, a pointer to a slice, and checks the row count
+func (s *Snapshot) Decode(table string, v interface{}) error {
+	raw, ok := s.tables[table]
+	if !ok {
+		return fmt.Errorf("snapshot %s has no table %s", s.Manifest.ID, table)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("failed to decode table %s: %w", table, err)
+	}
+
+	rows := reflect.Indirect(reflect.ValueOf(v)).Len()
+	for _, info := range s.Manifest.Tables {
+		if info.Name == table && info.Rows != rows {
+			return fmt.Errorf("%w: table %s has %d rows, manifest lists %d", ErrChecksumMismatch, table, rows, info.Rows)
+		}
+	}
+	return nil
+}
+
+func (m *Manifest) checksum() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d\n%s\n%s\n", m.FormatVersion, m.ID, m.CreatedAt.UTC().Format(time.RFC3339Nano))
+	for _, t := range m.Tables {
+		fmt.Fprintf(&b, "%s %s %d %d %s\n", t.Name, t.Key, t.Rows, t.Bytes, t.SHA256)
+	}
+	return sha256Hex([]byte(b.String()))
+}
+
+func encodeTable(rows interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(rows); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
@@ -0,0 +1,235 @@
+package units
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// decimalPlaces is the precision of a Decimal's text form. Amounts from providers and
+// sums of them are exact; only division can produce more digits than this.
+const decimalPlaces = 18
+
+// Decimal is an exact decimal number for money amounts and financial ratios, so sums
+// of balances or incomes don't drift the way float64 does. The zero value is 0.
+// Decimals are immutable: every operation returns a new value.
+type Decimal struct {
+	rat *big.Rat // nil means zero
+}
+
+// NewDecimal parses a decimal number such as "1234.56" or "-0.35"
+func NewDecimal(value string) (Decimal, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.ContainsAny(value, "/") {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", value)
+	}
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", value)
+	}
+	return Decimal{rat: rat}, nil
+}
+
+// MustDecimal parses a decimal constant, panicking if it is malformed
+func MustDecimal(value string) Decimal {
+	d, err := NewDecimal(value)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// DecimalFromInt converts a whole number
+func DecimalFromInt(value int64) Decimal {
+	return Decimal{rat: new(big.Rat).SetInt64(value)}
+}
+
+// DecimalFromBigInt converts a whole number such as an amount in wei
+func DecimalFromBigInt(value *big.Int) Decimal {
+	if value == nil {
+		return Decimal{}
+	}
+	return Decimal{rat: new(big.Rat).SetInt(value)}
+}
+
+// DecimalFromFloat converts a float64 by its shortest decimal representation, so 0.1
+// becomes exactly 0.1 rather than the nearest binary fraction. NaN and infinities
+// convert to zero.
+func DecimalFromFloat(value float64) Decimal {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Decimal{}
+	}
+	return MustDecimal(strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+func (d Decimal) value() *big.Rat {
+	if d.rat == nil {
+		return new(big.Rat)
+	}
+	return d.rat
+}
+
+// Add returns d + other
+func (d Decimal) Add(other Decimal) Decimal {
+	return Decimal{rat: new(big.Rat).Add(d.value(), other.value())}
+}
+
+// Sub returns d - other
+func (d Decimal) Sub(other Decimal) Decimal {
+	return Decimal{rat: new(big.Rat).Sub(d.value(), other.value())}
+}
+
+// Mul returns d * other
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{rat: new(big.Rat).Mul(d.value(), other.value())}
+}
+
+// Div returns d / other, or zero when other is zero
+func (d Decimal) Div(other Decimal) Decimal {
+	if other.IsZero() {
+		return Decimal{}
+	}
+	return Decimal{rat: new(big.Rat).Quo(d.value(), other.value())}
+}
+
+// Shift multiplies d by 10^places, converting between a token's base units and whole
+// units without rounding
+func (d Decimal) Shift(places int) Decimal {
+	if places == 0 || d.IsZero() {
+		return d
+	}
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(places))), nil))
+	if places < 0 {
+		scale.Inv(scale)
+	}
+	return Decimal{rat: new(big.Rat).Mul(d.value(), scale)}
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{rat: new(big.Rat).Neg(d.value())}
+}
+
+// Abs returns |d|
+func (d Decimal) Abs() Decimal {
+	return Decimal{rat: new(big.Rat).Abs(d.value())}
+}
+
+// Cmp compares d and other, returning -1, 0 or +1
+func (d Decimal) Cmp(other Decimal) int {
+	return d.value().Cmp(other.value())
+}
+
+// Sign returns -1, 0 or +1
+func (d Decimal) Sign() int {
+	return d.value().Sign()
+}
+
+// IsZero reports whether d is zero
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Float64 returns the nearest float64, for scoring heuristics that work on ratios
+// rather than amounts
+func (d Decimal) Float64() float64 {
+	f, _ := d.value().Float64()
+	return f
+}
+
+// String formats d with as many decimal places as it needs, up to 18
+func (d Decimal) String() string {
+	rat := d.value()
+	if rat.IsInt() {
+		return rat.Num().String()
+	}
+	s := rat.FloatString(decimalPlaces)
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimSuffix(s, ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// MarshalJSON encodes d as a JSON number
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number, or a number in a string as some providers send
+// amounts, without going through float64
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	text := strings.TrimSpace(string(data))
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	value, err := NewDecimal(text)
+	if err != nil {
+		return err
+	}
+	*d = value
+	return nil
+}
+
+// Value stores d as its exact text form
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan reads a column written by Value, or a legacy floating point column
+func (d *Decimal) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = Decimal{}
+	case string:
+		return d.scanText(v)
+	case []byte:
+		return d.scanText(string(v))
+	case float64:
+		*d = DecimalFromFloat(v)
+	case int64:
+		*d = DecimalFromInt(v)
+	default:
+		return fmt.Errorf("cannot scan %T into a decimal", src)
+	}
+	return nil
+}
+
+func (d *Decimal) scanText(text string) error {
+	if text == "" {
+		*d = Decimal{}
+		return nil
+	}
+	value, err := NewDecimal(text)
+	if err != nil {
+		return err
+	}
+	*d = value
+	return nil
+}
+
+// GormDBDataType stores decimals as numeric in PostgreSQL and as text elsewhere, since
+// SQLite would turn a numeric column back into floating point
+func (Decimal) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "numeric"
+	}
+	return "text"
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
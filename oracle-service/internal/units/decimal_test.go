@@ -0,0 +1,142 @@
+package units
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestNewDecimal(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    string
+		expectError bool
+	}{
+		{"1234.56", "1234.56", false},
+		{" -0.35 ", "-0.35", false},
+		{"100.000", "100", false},
+		{"1e3", "1000", false},
+		{"", "", true},
+		{"abc", "", true},
+		{"1/3", "", true},
+	}
+
+	for _, tt := range tests {
+		d, err := NewDecimal(tt.value)
+		if tt.expectError {
+			if err == nil {
+				t.Errorf("Expected error for %q", tt.value)
+			}
+			continue
+		}
+		if err != nil || d.String() != tt.expected {
+			t.Errorf("NewDecimal(%q) = %s (%v), expected %s", tt.value, d, err, tt.expected)
+		}
+	}
+}
+
+func TestDecimalArithmeticIsExact(t *testing.T) {
+	sum := MustDecimal("0.1").Add(MustDecimal("0.2"))
+	if sum.Cmp(MustDecimal("0.3")) != 0 {
+		t.Errorf("Expected 0.1 + 0.2 = 0.3, got %s", sum)
+	}
+
+	var total Decimal
+	for i := 0; i < 1000; i++ {
+		total = total.Add(MustDecimal("19.99"))
+	}
+	if total.String() != "19990" {
+		t.Errorf("Expected 1000 x 19.99 = 19990, got %s", total)
+	}
+
+	if got := DecimalFromInt(10).Sub(MustDecimal("12.5")); got.String() != "-2.5" {
+		t.Errorf("Expected 10 - 12.5 = -2.5, got %s", got)
+	}
+	if got := MustDecimal("1.5").Mul(MustDecimal("0.2")); got.String() != "0.3" {
+		t.Errorf("Expected 1.5 * 0.2 = 0.3, got %s", got)
+	}
+	if got := DecimalFromInt(1).Div(DecimalFromInt(4)); got.String() != "0.25" {
+		t.Errorf("Expected 1 / 4 = 0.25, got %s", got)
+	}
+	if got := DecimalFromInt(1).Div(Decimal{}); !got.IsZero() {
+		t.Errorf("Expected division by zero to give zero, got %s", got)
+	}
+	if got := DecimalFromInt(2).Div(DecimalFromInt(3)); got.String() != "0.666666666666666667" {
+		t.Errorf("Expected 2 / 3 rounded to 18 places, got %s", got)
+	}
+}
+
+func TestDecimalShift(t *testing.T) {
+	wei, _ := new(big.Int).SetString("1500000000000000001", 10)
+	eth := DecimalFromBigInt(wei).Shift(-18)
+	if eth.String() != "1.500000000000000001" {
+		t.Errorf("Expected 1.500000000000000001 ETH, got %s", eth)
+	}
+	if got := eth.Shift(18); got.Cmp(DecimalFromBigInt(wei)) != 0 {
+		t.Errorf("Expected shifting back to give %s wei, got %s", wei, got)
+	}
+	if got := MustDecimal("2500000").Shift(-6); got.String() != "2.5" {
+		t.Errorf("Expected 2.5 tokens, got %s", got)
+	}
+}
+
+func TestDecimalFromFloat(t *testing.T) {
+	if got := DecimalFromFloat(0.1); got.Cmp(MustDecimal("0.1")) != 0 {
+		t.Errorf("Expected 0.1 to convert exactly, got %s", got)
+	}
+	var zero float64
+	if got := DecimalFromFloat(zero / zero); !got.IsZero() {
+		t.Errorf("Expected NaN to convert to zero, got %s", got)
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		Amount  Decimal `json:"amount"`
+		Quoted  Decimal `json:"quoted"`
+		Missing Decimal `json:"missing"`
+	}
+	if err := json.Unmarshal([]byte(`{"amount": 1234.56, "quoted": "0.1", "missing": null}`), &v); err != nil {
+		t.Fatalf("Failed to decode decimals: %v", err)
+	}
+	if v.Amount.String() != "1234.56" || v.Quoted.String() != "0.1" || !v.Missing.IsZero() {
+		t.Errorf("Unexpected decoded decimals: %s %s %s", v.Amount, v.Quoted, v.Missing)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed to encode decimals: %v", err)
+	}
+	if string(data) != `{"amount":1234.56,"quoted":0.1,"missing":0}` {
+		t.Errorf("Unexpected encoding: %s", data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"amount": "lots"}`), &v); err == nil {
+		t.Error("Expected an invalid amount to be rejected")
+	}
+}
+
+func TestDecimalScan(t *testing.T) {
+	tests := []struct {
+		src      interface{}
+		expected string
+	}{
+		{"85000.5", "85000.5"},
+		{[]byte("0.28"), "0.28"},
+		{0.28, "0.28"},
+		{int64(42), "42"},
+		{nil, "0"},
+	}
+
+	for _, tt := range tests {
+		var d Decimal
+		if err := d.Scan(tt.src); err != nil || d.String() != tt.expected {
+			t.Errorf("Scan(%v) = %s (%v), expected %s", tt.src, d, err, tt.expected)
+		}
+	}
+
+	value, err := MustDecimal("19.99").Value()
+	if err != nil || value != "19.99" {
+		t.Errorf("Expected stored value 19.99, got %v (%v)", value, err)
+	}
+}
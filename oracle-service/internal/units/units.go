@@ -0,0 +1,184 @@
+// Package units defines the oracle's scaled quantities as distinct types, so a score,
+// a confidence level and a basis-point weight can't be mixed up or converted between
+// scales by accident.
+package units
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Score is a credit score on the oracle's 300-850 scale. The zero value means no
+// score (for example an optional component that wasn't computed).
+type Score uint16
+
+// Score range
+const (
+	MinScore Score = 300
+	MaxScore Score = 850
+)
+
+// NewScore validates a credit score
+func NewScore(value int) (Score, error) {
+	if value < int(MinScore) || value > int(MaxScore) {
+		return 0, fmt.Errorf("score %d is outside valid range [%d-%d]", value, MinScore, MaxScore)
+	}
+	return Score(value), nil
+}
+
+// ClampScore converts a computed score to a Score, rounding down and clamping it to
+// the valid range
+func ClampScore(value float64) Score {
+	if math.IsNaN(value) || value < float64(MinScore) {
+		return MinScore
+	}
+	if value > float64(MaxScore) {
+		return MaxScore
+	}
+	return Score(value)
+}
+
+// ScoreFromFraction places a 0-1 fraction on the score scale, rounding down
+func ScoreFromFraction(fraction float64) Score {
+	return ClampScore(float64(MinScore) + fraction*float64(MaxScore-MinScore))
+}
+
+// Valid reports whether the score is within the valid range
+func (s Score) Valid() bool {
+	return s >= MinScore && s <= MaxScore
+}
+
+// Uint16 returns the score as encoded on-chain
+func (s Score) Uint16() uint16 {
+	return uint16(s)
+}
+
+// UnmarshalJSON decodes a score, rejecting values off the score scale. Zero is
+// accepted as no score.
+func (s *Score) UnmarshalJSON(data []byte) error {
+	if isNull(data) {
+		return nil
+	}
+	value, err := unmarshalInt(data, "score")
+	if err != nil {
+		return err
+	}
+	if value == 0 {
+		*s = 0
+		return nil
+	}
+	score, err := NewScore(value)
+	if err != nil {
+		return err
+	}
+	*s = score
+	return nil
+}
+
+// Confidence is the 0-100 confidence level of a score
+type Confidence uint8
+
+// MaxConfidence is full confidence
+const MaxConfidence Confidence = 100
+
+// NewConfidence validates a confidence level
+func NewConfidence(value int) (Confidence, error) {
+	if value < 0 || value > int(MaxConfidence) {
+		return 0, fmt.Errorf("confidence %d is outside valid range [0-%d]", value, MaxConfidence)
+	}
+	return Confidence(value), nil
+}
+
+// ClampConfidence converts a computed confidence to a Confidence, rounding down and
+// clamping it to 0-100
+func ClampConfidence(value float64) Confidence {
+	if math.IsNaN(value) || value < 0 {
+		return 0
+	}
+	if value > float64(MaxConfidence) {
+		return MaxConfidence
+	}
+	return Confidence(value)
+}
+
+// Uint8 returns the confidence as encoded on-chain
+func (c Confidence) Uint8() uint8 {
+	return uint8(c)
+}
+
+// UnmarshalJSON decodes a confidence level, rejecting values above 100
+func (c *Confidence) UnmarshalJSON(data []byte) error {
+	if isNull(data) {
+		return nil
+	}
+	value, err := unmarshalInt(data, "confidence")
+	if err != nil {
+		return err
+	}
+	confidence, err := NewConfidence(value)
+	if err != nil {
+		return err
+	}
+	*c = confidence
+	return nil
+}
+
+// BasisPoints is a fraction in hundredths of a percent (10000 = 100%)
+type BasisPoints uint16
+
+// MaxBasisPoints is 100%
+const MaxBasisPoints BasisPoints = 10000
+
+// NewBasisPoints validates a basis point value
+func NewBasisPoints(value int) (BasisPoints, error) {
+	if value < 0 || value > int(MaxBasisPoints) {
+		return 0, fmt.Errorf("basis points %d are outside valid range [0-%d]", value, MaxBasisPoints)
+	}
+	return BasisPoints(value), nil
+}
+
+// Fraction returns the value as a 0-1 fraction
+func (b BasisPoints) Fraction() float64 {
+	return float64(b) / float64(MaxBasisPoints)
+}
+
+// Of applies the fraction to a value
+func (b BasisPoints) Of(value float64) float64 {
+	return value * b.Fraction()
+}
+
+// UnmarshalJSON decodes a basis point value, rejecting values above 10000
+func (b *BasisPoints) UnmarshalJSON(data []byte) error {
+	if isNull(data) {
+		return nil
+	}
+	value, err := unmarshalInt(data, "basis points")
+	if err != nil {
+		return err
+	}
+	bps, err := NewBasisPoints(value)
+	if err != nil {
+		return err
+	}
+	*b = bps
+	return nil
+}
+
+// unmarshalInt decodes a JSON integer. Strings, fractions and out of range numbers
+// are rejected rather than converted.
+func unmarshalInt(data []byte, name string) (int, error) {
+	text := string(bytes.TrimSpace(data))
+	n, err := strconv.ParseInt(text, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s is not an integer", name, text)
+	}
+	return int(n), nil
+}
+
+// isNull reports whether data is JSON null, which leaves a value unchanged like it
+// does for plain integers
+func isNull(data []byte) bool {
+	return string(bytes.TrimSpace(data)) == "null"
+}
@@ -0,0 +1,127 @@
+package units
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewScore(t *testing.T) {
+	tests := []struct {
+		value       int
+		expectError bool
+	}{
+		{300, false},
+		{720, false},
+		{850, false},
+		{0, true},
+		{299, true},
+		{851, true},
+		{-1, true},
+	}
+
+	for _, tt := range tests {
+		score, err := NewScore(tt.value)
+		if tt.expectError {
+			if err == nil {
+				t.Errorf("Expected error for score %d", tt.value)
+			}
+			continue
+		}
+		if err != nil || int(score) != tt.value {
+			t.Errorf("Expected score %d, got %d (%v)", tt.value, score, err)
+		}
+	}
+}
+
+func TestClampScore(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected Score
+	}{
+		{720.9, 720},
+		{12, MinScore},
+		{-5, MinScore},
+		{1e18, MaxScore},
+	}
+
+	for _, tt := range tests {
+		if got := ClampScore(tt.value); got != tt.expected {
+			t.Errorf("ClampScore(%v) = %d, expected %d", tt.value, got, tt.expected)
+		}
+	}
+
+	if got := ScoreFromFraction(0.5); got != 575 {
+		t.Errorf("Expected the midpoint of the scale to be 575, got %d", got)
+	}
+	if got := ScoreFromFraction(1.4); got != MaxScore {
+		t.Errorf("Expected fractions above 1 to clamp to %d, got %d", MaxScore, got)
+	}
+}
+
+func TestConfidence(t *testing.T) {
+	if _, err := NewConfidence(101); err == nil {
+		t.Error("Expected error for confidence above 100")
+	}
+	if _, err := NewConfidence(-1); err == nil {
+		t.Error("Expected error for negative confidence")
+	}
+	if confidence, err := NewConfidence(85); err != nil || confidence != 85 {
+		t.Errorf("Expected confidence 85, got %d (%v)", confidence, err)
+	}
+	if got := ClampConfidence(250); got != MaxConfidence {
+		t.Errorf("Expected confidence clamped to %d, got %d", MaxConfidence, got)
+	}
+}
+
+func TestBasisPoints(t *testing.T) {
+	bps, err := NewBasisPoints(4000)
+	if err != nil {
+		t.Fatalf("Failed to create basis points: %v", err)
+	}
+	if bps.Fraction() != 0.4 || bps.Of(500) != 200 {
+		t.Errorf("Expected 4000 bps to be 40%%, got %v", bps.Fraction())
+	}
+	if _, err := NewBasisPoints(10001); err == nil {
+		t.Error("Expected error for more than 100%")
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	var payload struct {
+		Score      Score       `json:"score"`
+		Confidence Confidence  `json:"confidence"`
+		Weight     BasisPoints `json:"weight"`
+	}
+	if err := json.Unmarshal([]byte(`{"score":720,"confidence":85,"weight":2500}`), &payload); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if payload.Score != 720 || payload.Confidence != 85 || payload.Weight != 2500 {
+		t.Errorf("Unexpected values: %+v", payload)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if string(data) != `{"score":720,"confidence":85,"weight":2500}` {
+		t.Errorf("Expected plain numbers, got %s", data)
+	}
+
+	invalid := []string{
+		`{"score":72000}`,
+		`{"score":720.5}`,
+		`{"score":"720"}`,
+		`{"confidence":101}`,
+		`{"weight":20000}`,
+	}
+	for _, body := range invalid {
+		if err := json.Unmarshal([]byte(body), &payload); err == nil {
+			t.Errorf("Expected error unmarshaling %s", body)
+		}
+	}
+
+	// Zero is no score rather than an invalid one
+	if err := json.Unmarshal([]byte(`{"score":0}`), &payload); err != nil || payload.Score != 0 {
+		t.Errorf("Expected zero score to unmarshal as no score, got %d (%v)", payload.Score, err)
+	}
+}
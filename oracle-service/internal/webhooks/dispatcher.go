@@ -0,0 +1,338 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Outbound event types
+const (
+	EventScoreChanged   = "score.changed"
+	EventPositionAtRisk = "position.at_risk"
+)
+
+// Default retry policy: 30s, 1m, 2m, 4m, 8m between attempts, then dead-letter
+const (
+	defaultMaxAttempts = 6
+	defaultRetryDelay  = 30 * time.Second
+	maxRetryDelay      = time.Hour
+)
+
+// ErrNotDeadLettered is returned when replaying a dead letter that does not exist
+var ErrNotDeadLettered = errors.New("webhook dead letter not found")
+
+// Event is the envelope of every outbound webhook body
+type Event struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// Dispatcher sends signed events to subscriber endpoints, records every attempt,
+// retries failures with exponential backoff, and dead-letters deliveries that
+// exhaust their retries
+type Dispatcher struct {
+	endpoints   []string
+	signer      *Signer
+	deliveries  *repository.WebhookRepository
+	httpClient  *http.Client
+	maxAttempts int
+	retryDelay  time.Duration
+}
+
+// NewDispatcher creates a dispatcher for the given subscriber endpoints
+func NewDispatcher(endpoints []string, signer *Signer, deliveries *repository.WebhookRepository) *Dispatcher {
+	return &Dispatcher{
+		endpoints:  endpoints,
+		signer:     signer,
+		deliveries: deliveries,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		maxAttempts: defaultMaxAttempts,
+		retryDelay:  defaultRetryDelay,
+	}
+}
+
+// SetRetryPolicy overrides how many attempts a delivery gets before it is dead-lettered
+// and the delay before the first retry, which doubles on each further retry
+func (d *Dispatcher) SetRetryPolicy(maxAttempts int, retryDelay time.Duration) {
+	if maxAttempts > 0 {
+		d.maxAttempts = maxAttempts
+	}
+	if retryDelay > 0 {
+		d.retryDelay = retryDelay
+	}
+}
+
+// Dispatch sends an event to every endpoint. Each endpoint receives the same event ID,
+// which subscribers use to discard redeliveries. Failed deliveries are left for RetryDue.
+func (d *Dispatcher) Dispatch(ctx context.Context, eventType string, data interface{}) error {
+	id, err := newEventID()
+	if err != nil {
+		return err
+	}
+
+	event := Event{
+		ID:        id,
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+
+	var firstErr error
+	for _, endpoint := range d.endpoints {
+		delivery := &models.WebhookDelivery{
+			Direction:  models.WebhookOutbound,
+			Source:     endpoint,
+			DeliveryID: event.ID,
+			EventType:  event.Type,
+			Status:     models.WebhookRetrying,
+			Payload:    string(body),
+		}
+		if err := d.deliveries.SaveDelivery(ctx, delivery); err != nil {
+			return err
+		}
+
+		if err := d.attempt(ctx, delivery); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// RetryDue retries outbound deliveries whose backoff has elapsed and returns how many
+// were attempted
+func (d *Dispatcher) RetryDue(ctx context.Context, limit int) (int, error) {
+	due, err := d.deliveries.ListDueRetries(ctx, time.Now(), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range due {
+		d.attempt(ctx, delivery)
+	}
+
+	return len(due), nil
+}
+
+// RunRetries retries due deliveries every interval until the context is cancelled
+func (d *Dispatcher) RunRetries(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = d.retryDelay
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			retried, err := d.RetryDue(ctx, 100)
+			if err != nil {
+				logger.Error("Failed to retry webhook deliveries", zap.Error(err))
+				continue
+			}
+			if retried > 0 {
+				logger.Info("Retried webhook deliveries", zap.Int("count", retried))
+			}
+		}
+	}
+}
+
+// Replay sends a dead-lettered delivery again with a fresh signature. The dead letter
+// is marked replayed only if the endpoint accepts it.
+func (d *Dispatcher) Replay(ctx context.Context, deadLetterID uint) (*models.WebhookDelivery, error) {
+	deadLetter, err := d.deliveries.GetDeadLetter(ctx, deadLetterID)
+	if err != nil {
+		return nil, err
+	}
+	if deadLetter == nil {
+		return nil, ErrNotDeadLettered
+	}
+
+	delivery, err := d.deliveries.GetDeliveryByID(ctx, deadLetter.WebhookDeliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil {
+		return nil, ErrNotDeadLettered
+	}
+
+	sendErr := d.send(ctx, delivery)
+
+	deadLetter.ReplayCount++
+	if sendErr == nil {
+		now := time.Now()
+		deadLetter.ReplayedAt = &now
+	} else {
+		deadLetter.LastError = sendErr.Error()
+		deadLetter.LastResponseCode = delivery.ResponseCode
+	}
+	if err := d.deliveries.SaveDeadLetter(ctx, deadLetter); err != nil {
+		return nil, err
+	}
+
+	if sendErr == nil {
+		delivery.Status = models.WebhookDelivered
+		delivery.ErrorMessage = ""
+	}
+	if err := d.deliveries.SaveDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery, sendErr
+}
+
+// attempt sends a delivery and schedules a retry or dead-letters it on failure
+func (d *Dispatcher) attempt(ctx context.Context, delivery *models.WebhookDelivery) error {
+	sendErr := d.send(ctx, delivery)
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = models.WebhookDelivered
+		delivery.ErrorMessage = ""
+		delivery.NextAttemptAt = nil
+	case delivery.Attempts >= d.maxAttempts:
+		delivery.Status = models.WebhookDead
+		delivery.NextAttemptAt = nil
+		d.deadLetter(ctx, delivery)
+	default:
+		next := time.Now().Add(d.backoff(delivery.Attempts))
+		delivery.Status = models.WebhookRetrying
+		delivery.NextAttemptAt = &next
+	}
+
+	if sendErr != nil {
+		logger.Warn("Webhook delivery failed",
+			zap.String("endpoint", delivery.Source),
+			zap.String("event", delivery.EventType),
+			zap.Int("attempt", delivery.Attempts),
+			zap.String("status", delivery.Status),
+			zap.Error(sendErr),
+		)
+	}
+
+	if err := d.deliveries.SaveDelivery(ctx, delivery); err != nil {
+		logger.Error("Failed to record webhook delivery", zap.Error(err))
+	}
+
+	return sendErr
+}
+
+// backoff returns the delay after the given number of failed attempts
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.retryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+func (d *Dispatcher) deadLetter(ctx context.Context, delivery *models.WebhookDelivery) {
+	deadLetter, err := d.deliveries.GetDeadLetterByDelivery(ctx, delivery.ID)
+	if err != nil {
+		logger.Error("Failed to look up webhook dead letter", zap.Error(err))
+		return
+	}
+	if deadLetter == nil {
+		deadLetter = &models.WebhookDeadLetter{
+			WebhookDeliveryID: delivery.ID,
+			Endpoint:          delivery.Source,
+			EventID:           delivery.DeliveryID,
+			EventType:         delivery.EventType,
+		}
+	}
+	deadLetter.Attempts = delivery.Attempts
+	deadLetter.LastResponseCode = delivery.ResponseCode
+	deadLetter.LastError = delivery.ErrorMessage
+
+	if err := d.deliveries.SaveDeadLetter(ctx, deadLetter); err != nil {
+		logger.Error("Failed to dead-letter webhook delivery", zap.Error(err))
+	}
+}
+
+// send posts a delivery's payload once and records the attempt
+func (d *Dispatcher) send(ctx context.Context, delivery *models.WebhookDelivery) error {
+	delivery.Attempts++
+	start := time.Now()
+	statusCode, sendErr := d.post(ctx, delivery)
+	latency := time.Since(start).Milliseconds()
+
+	delivery.ResponseCode = statusCode
+	delivery.LatencyMs = latency
+	delivery.ErrorMessage = ""
+	if sendErr != nil {
+		delivery.ErrorMessage = sendErr.Error()
+	}
+
+	attempt := &models.WebhookAttempt{
+		DeliveryID:   delivery.ID,
+		Attempt:      delivery.Attempts,
+		Success:      sendErr == nil,
+		ResponseCode: statusCode,
+		LatencyMs:    latency,
+		ErrorMessage: delivery.ErrorMessage,
+	}
+	if err := d.deliveries.CreateAttempt(ctx, attempt); err != nil {
+		logger.Error("Failed to record webhook attempt", zap.Error(err))
+	}
+
+	return sendErr
+}
+
+func (d *Dispatcher) post(ctx context.Context, delivery *models.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Source, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IDHeader, delivery.DeliveryID)
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(SignatureHeader, d.signer.Sign(body, time.Now()))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint returned status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func newEventID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate event id: %w", err)
+	}
+	return "evt_" + hex.EncodeToString(b), nil
+}
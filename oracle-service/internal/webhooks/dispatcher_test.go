@@ -0,0 +1,181 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupWebhookRepo(t *testing.T) *repository.WebhookRepository {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	if err := db.AutoMigrate(&models.WebhookDelivery{}, &models.WebhookAttempt{}, &models.WebhookDeadLetter{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+	return repository.NewWebhookRepository(db)
+}
+
+func TestDispatchDeliversVerifiableEvent(t *testing.T) {
+	repo := setupWebhookRepo(t)
+	signer, _ := NewSigner(SchemeTimestamped, "secret", 0)
+
+	// The subscriber side verifies deliveries with a receiver sharing the secret
+	receiver := NewReceiver("oracle", signer, setupWebhookRepo(t))
+	var received int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, duplicate, err := receiver.Accept(r.Context(), body, r.Header)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if !duplicate {
+			received++
+		}
+		if r.Header.Get(EventHeader) != EventScoreChanged {
+			t.Errorf("Expected event header %s, got %s", EventScoreChanged, r.Header.Get(EventHeader))
+		}
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher([]string{server.URL}, signer, repo)
+	if err := dispatcher.Dispatch(context.Background(), EventScoreChanged, map[string]int{"score": 700}); err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	if received != 1 {
+		t.Errorf("Expected 1 received delivery, got %d", received)
+	}
+}
+
+func TestFailedDeliveryIsRetriedThenDeadLettered(t *testing.T) {
+	ctx := context.Background()
+	repo := setupWebhookRepo(t)
+	signer, _ := NewSigner(SchemeHMACSHA256, "secret", 0)
+
+	healthy := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher([]string{server.URL}, signer, repo)
+	dispatcher.SetRetryPolicy(3, time.Millisecond)
+
+	if err := dispatcher.Dispatch(ctx, EventScoreChanged, nil); err == nil {
+		t.Fatal("Expected error for failing endpoint")
+	}
+
+	retrying, _ := repo.ListDeliveries(ctx, models.WebhookOutbound, models.WebhookRetrying, 10)
+	if len(retrying) != 1 || retrying[0].NextAttemptAt == nil {
+		t.Fatalf("Expected one delivery scheduled for retry, got %+v", retrying)
+	}
+
+	// Two more failed attempts exhaust the retry policy
+	for i := 0; i < 2; i++ {
+		time.Sleep(5 * time.Millisecond)
+		if _, err := dispatcher.RetryDue(ctx, 10); err != nil {
+			t.Fatalf("RetryDue failed: %v", err)
+		}
+	}
+
+	delivery, _ := repo.GetDeliveryByID(ctx, retrying[0].ID)
+	if delivery.Status != models.WebhookDead || delivery.Attempts != 3 || delivery.ResponseCode != http.StatusInternalServerError {
+		t.Errorf("Expected dead delivery after 3 attempts, got %+v", delivery)
+	}
+
+	attempts, _ := repo.ListAttempts(ctx, delivery.ID)
+	if len(attempts) != 3 {
+		t.Errorf("Expected 3 recorded attempts, got %d", len(attempts))
+	}
+
+	deadLetters, _ := repo.ListDeadLetters(ctx, false, 10)
+	if len(deadLetters) != 1 {
+		t.Fatalf("Expected 1 dead letter, got %d", len(deadLetters))
+	}
+
+	// Replay succeeds once the endpoint recovers
+	healthy = true
+	replayed, err := dispatcher.Replay(ctx, deadLetters[0].ID)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if replayed.Status != models.WebhookDelivered {
+		t.Errorf("Expected replayed delivery to be delivered, got %s", replayed.Status)
+	}
+	if pending, _ := repo.ListDeadLetters(ctx, false, 10); len(pending) != 0 {
+		t.Errorf("Expected replayed dead letter to be cleared, got %d pending", len(pending))
+	}
+
+	if _, err := dispatcher.Replay(ctx, 999); err != ErrNotDeadLettered {
+		t.Errorf("Expected ErrNotDeadLettered, got %v", err)
+	}
+}
+
+func TestBackoffDoublesUpToCap(t *testing.T) {
+	dispatcher := NewDispatcher(nil, nil, nil)
+
+	expected := []time.Duration{30 * time.Second, time.Minute, 2 * time.Minute, 4 * time.Minute}
+	for i, want := range expected {
+		if got := dispatcher.backoff(i + 1); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, want)
+		}
+	}
+	if got := dispatcher.backoff(20); got != maxRetryDelay {
+		t.Errorf("Expected backoff capped at %v, got %v", maxRetryDelay, got)
+	}
+}
+
+func TestReceiverProcessesDeliveryOnce(t *testing.T) {
+	ctx := context.Background()
+	signer, _ := NewSigner(SchemeHMACSHA256, "secret", 0)
+	receiver := NewReceiver("bureau", signer, setupWebhookRepo(t))
+
+	body := []byte(`{"alert_id":"a1"}`)
+	header := http.Header{SignatureHeader: {signer.Sign(body, time.Now())}}
+
+	delivery, duplicate, err := receiver.Accept(ctx, body, header)
+	if err != nil || duplicate {
+		t.Fatalf("Expected first delivery to be accepted, got duplicate=%v err=%v", duplicate, err)
+	}
+
+	// A retry before processing finished is accepted again
+	if _, duplicate, _ := receiver.Accept(ctx, body, header); duplicate {
+		t.Error("Expected unfinished delivery to be retried")
+	}
+
+	if err := receiver.Finish(ctx, delivery, "new_delinquency", nil); err != nil {
+		t.Fatalf("Failed to finish delivery: %v", err)
+	}
+
+	replayed, duplicate, err := receiver.Accept(ctx, body, header)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !duplicate || replayed.EventType != "new_delinquency" {
+		t.Errorf("Expected replay to be reported as duplicate, got duplicate=%v delivery=%+v", duplicate, replayed)
+	}
+
+	// The delivery ID header isn't signed, so a replay under a new ID is still a duplicate
+	for _, id := range []string{"evt_1", "evt_2"} {
+		header.Set(IDHeader, id)
+		if _, duplicate, err := receiver.Accept(ctx, body, header); err != nil || !duplicate {
+			t.Errorf("Expected replay with delivery ID %s to be a duplicate, got duplicate=%v err=%v", id, duplicate, err)
+		}
+	}
+
+	if _, _, err := receiver.Accept(ctx, body, http.Header{SignatureHeader: {"sha256=00"}}); err == nil {
+		t.Error("Expected invalid signature to be rejected")
+	}
+}
@@ -0,0 +1,92 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// signPlaidJWT signs claims for body the way Plaid does
+func signPlaidJWT(t *testing.T, key *ecdsa.PrivateKey, keyID string, body []byte, issuedAt time.Time) string {
+	t.Helper()
+	sum := sha256.Sum256(body)
+	header := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"alg":"ES256","kid":%q,"typ":"JWT"}`, keyID)))
+	claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"iat":%d,"request_body_sha256":%q}`, issuedAt.Unix(), hex.EncodeToString(sum[:]))))
+
+	digest := sha256.Sum256([]byte(header + "." + claims))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatalf("Failed to sign JWT: %v", err)
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return header + "." + claims + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestPlaidVerifier(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	fetches := 0
+	verifier := NewPlaidVerifier(func(ctx context.Context, keyID string) (*ecdsa.PublicKey, error) {
+		fetches++
+		if keyID != "key-1" {
+			return nil, nil
+		}
+		return &key.PublicKey, nil
+	})
+
+	ctx := context.Background()
+	now := time.Now()
+	body := []byte(`{"webhook_type":"TRANSACTIONS","webhook_code":"DEFAULT_UPDATE","item_id":"item-1"}`)
+	header := func(token string) http.Header {
+		return http.Header{PlaidVerificationHeader: {token}}
+	}
+
+	if err := verifier.VerifyRequest(ctx, body, header(signPlaidJWT(t, key, "key-1", body, now)), now); err != nil {
+		t.Errorf("Expected a valid Plaid webhook, got %v", err)
+	}
+	if err := verifier.VerifyRequest(ctx, body, header(signPlaidJWT(t, key, "key-1", body, now)), now); err != nil || fetches != 1 {
+		t.Errorf("Expected the key to be cached, got %v after %d fetches", err, fetches)
+	}
+
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	tests := []struct {
+		name   string
+		header http.Header
+		err    error
+	}{
+		{"Missing", http.Header{}, ErrMissingSignature},
+		{"HMAC signature", http.Header{SignatureHeader: {"sha256=00"}}, ErrMissingSignature},
+		{"Other body", header(signPlaidJWT(t, key, "key-1", []byte(`{}`), now)), ErrInvalidSignature},
+		{"Other key", header(signPlaidJWT(t, other, "key-1", body, now)), ErrInvalidSignature},
+		{"Unknown key", header(signPlaidJWT(t, key, "key-2", body, now)), ErrInvalidSignature},
+		{"Stale", header(signPlaidJWT(t, key, "key-1", body, now.Add(-PlaidMaxAge-time.Second))), ErrStaleTimestamp},
+		{"Malformed", header("not.a-jwt"), ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifier.VerifyRequest(ctx, body, tt.header, now); !errors.Is(err, tt.err) {
+				t.Errorf("Expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+
+	// Keys are fetched again once the cached copy is old, so expired keys stop working
+	verifier.fetch = func(ctx context.Context, keyID string) (*ecdsa.PublicKey, error) { return nil, nil }
+	later := now.Add(plaidKeyCacheTTL)
+	if err := verifier.VerifyRequest(ctx, body, header(signPlaidJWT(t, key, "key-1", body, later)), later); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected an expired key to be rejected, got %v", err)
+	}
+}
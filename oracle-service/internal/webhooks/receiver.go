@@ -0,0 +1,79 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+)
+
+// Receiver authenticates inbound webhooks from one provider and records each
+// delivery so redeliveries and replays are processed at most once
+type Receiver struct {
+	source     string
+	verifier   Verifier
+	deliveries *repository.WebhookRepository
+}
+
+// NewReceiver creates a receiver for webhooks sent by source
+func NewReceiver(source string, verifier Verifier, deliveries *repository.WebhookRepository) *Receiver {
+	return &Receiver{
+		source:     source,
+		verifier:   verifier,
+		deliveries: deliveries,
+	}
+}
+
+// Accept verifies the delivery's signature and claims it for processing. It returns
+// duplicate=true when the delivery was already processed. Deliveries are identified
+// by a hash of their signed body rather than a delivery ID header, which isn't
+// signed and could be changed to replay a captured delivery.
+func (r *Receiver) Accept(ctx context.Context, body []byte, header http.Header) (*models.WebhookDelivery, bool, error) {
+	if err := r.verifier.VerifyRequest(ctx, body, header, time.Now()); err != nil {
+		return nil, false, err
+	}
+
+	sum := sha256.Sum256(body)
+	deliveryID := hex.EncodeToString(sum[:])
+
+	delivery, err := r.deliveries.GetDelivery(ctx, models.WebhookInbound, r.source, deliveryID)
+	if err != nil {
+		return nil, false, err
+	}
+	if delivery != nil && delivery.Status == models.WebhookProcessed {
+		return delivery, true, nil
+	}
+
+	// New delivery, or a retry of one whose processing failed
+	if delivery == nil {
+		delivery = &models.WebhookDelivery{
+			Direction:  models.WebhookInbound,
+			Source:     r.source,
+			DeliveryID: deliveryID,
+		}
+	}
+	delivery.Status = models.WebhookReceived
+	delivery.Attempts++
+	delivery.ErrorMessage = ""
+
+	if err := r.deliveries.SaveDelivery(ctx, delivery); err != nil {
+		return nil, false, err
+	}
+
+	return delivery, false, nil
+}
+
+// Finish records the outcome of processing an accepted delivery
+func (r *Receiver) Finish(ctx context.Context, delivery *models.WebhookDelivery, eventType string, processErr error) error {
+	delivery.EventType = eventType
+	delivery.Status = models.WebhookProcessed
+	if processErr != nil {
+		delivery.Status = models.WebhookFailed
+		delivery.ErrorMessage = processErr.Error()
+	}
+	return r.deliveries.SaveDelivery(ctx, delivery)
+}
@@ -0,0 +1,164 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Signature schemes
+const (
+	// SchemeHMACSHA256 signs the raw body: "sha256=<hex HMAC>"
+	SchemeHMACSHA256 = "hmac-sha256"
+	// SchemeTimestamped signs "<unix time>.<body>" so old deliveries cannot be
+	// replayed: "t=<unix time>,v1=<hex HMAC>"
+	SchemeTimestamped = "hmac-sha256-timestamped"
+)
+
+// Webhook request headers
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	IDHeader        = "X-Webhook-Id"
+	EventHeader     = "X-Webhook-Event"
+)
+
+// DefaultTolerance is how far a signed timestamp may drift from the receiver's clock
+const DefaultTolerance = 5 * time.Minute
+
+// Signature verification errors
+var (
+	ErrMissingSignature = errors.New("missing webhook signature")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrStaleTimestamp   = errors.New("webhook timestamp outside tolerance")
+)
+
+// Verifier authenticates an inbound delivery from its body and headers
+type Verifier interface {
+	VerifyRequest(ctx context.Context, body []byte, header http.Header, now time.Time) error
+}
+
+// Signer signs outbound webhook bodies and verifies inbound ones with a shared secret
+type Signer struct {
+	scheme    string
+	secret    []byte
+	tolerance time.Duration
+}
+
+// NewSigner creates a signer for the given scheme. An empty scheme selects the
+// timestamped scheme and a non-positive tolerance selects DefaultTolerance.
+func NewSigner(scheme, secret string, tolerance time.Duration) (*Signer, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("webhook secret is required")
+	}
+	if scheme == "" {
+		scheme = SchemeTimestamped
+	}
+	if scheme != SchemeHMACSHA256 && scheme != SchemeTimestamped {
+		return nil, fmt.Errorf("unknown webhook signature scheme %q", scheme)
+	}
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+
+	return &Signer{
+		scheme:    scheme,
+		secret:    []byte(secret),
+		tolerance: tolerance,
+	}, nil
+}
+
+// Scheme returns the signer's signature scheme
+func (s *Signer) Scheme() string {
+	return s.scheme
+}
+
+// Sign returns the signature header value for a body sent at the given time
+func (s *Signer) Sign(body []byte, now time.Time) string {
+	if s.scheme == SchemeHMACSHA256 {
+		return "sha256=" + hex.EncodeToString(s.mac(body))
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", timestamp, hex.EncodeToString(s.mac(signedPayload(timestamp, body))))
+}
+
+// Verify checks a signature header against the body. Timestamped signatures must
+// be within the tolerance of now; any of several v1 signatures may match, which
+// lets senders rotate secrets.
+func (s *Signer) Verify(body []byte, header string, now time.Time) error {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return ErrMissingSignature
+	}
+
+	if s.scheme == SchemeHMACSHA256 {
+		if !s.matches(body, strings.TrimPrefix(header, "sha256=")) {
+			return ErrInvalidSignature
+		}
+		return nil
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if drift := now.Sub(time.Unix(unix, 0)); drift > s.tolerance || drift < -s.tolerance {
+		return ErrStaleTimestamp
+	}
+
+	payload := signedPayload(timestamp, body)
+	for _, signature := range signatures {
+		if s.matches(payload, signature) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// VerifyRequest checks the delivery's X-Webhook-Signature header against its body
+func (s *Signer) VerifyRequest(ctx context.Context, body []byte, header http.Header, now time.Time) error {
+	return s.Verify(body, header.Get(SignatureHeader), now)
+}
+
+func (s *Signer) mac(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func (s *Signer) matches(payload []byte, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(s.mac(payload), expected)
+}
+
+func signedPayload(timestamp string, body []byte) []byte {
+	return append([]byte(timestamp+"."), body...)
+}
@@ -0,0 +1,88 @@
+package webhooks
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"alert_id":"a1"}`)
+	now := time.Unix(1700000000, 0)
+
+	for _, scheme := range []string{SchemeHMACSHA256, SchemeTimestamped} {
+		t.Run(scheme, func(t *testing.T) {
+			signer, err := NewSigner(scheme, "secret", time.Minute)
+			if err != nil {
+				t.Fatalf("Failed to create signer: %v", err)
+			}
+
+			signature := signer.Sign(body, now)
+			if err := signer.Verify(body, signature, now); err != nil {
+				t.Errorf("Expected signature to verify, got %v", err)
+			}
+			if err := signer.Verify([]byte(`{"alert_id":"a2"}`), signature, now); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Expected tampered body to fail, got %v", err)
+			}
+			if err := signer.Verify(body, "", now); !errors.Is(err, ErrMissingSignature) {
+				t.Errorf("Expected missing signature error, got %v", err)
+			}
+
+			other, _ := NewSigner(scheme, "other", time.Minute)
+			if err := other.Verify(body, signature, now); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Expected wrong secret to fail, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsReplayedTimestamp(t *testing.T) {
+	signer, _ := NewSigner(SchemeTimestamped, "secret", time.Minute)
+	body := []byte(`{}`)
+	sentAt := time.Unix(1700000000, 0)
+	signature := signer.Sign(body, sentAt)
+
+	if err := signer.Verify(body, signature, sentAt.Add(30*time.Second)); err != nil {
+		t.Errorf("Expected signature within tolerance to verify, got %v", err)
+	}
+	if err := signer.Verify(body, signature, sentAt.Add(2*time.Minute)); !errors.Is(err, ErrStaleTimestamp) {
+		t.Errorf("Expected stale timestamp error, got %v", err)
+	}
+	if err := signer.Verify(body, signature, sentAt.Add(-2*time.Minute)); !errors.Is(err, ErrStaleTimestamp) {
+		t.Errorf("Expected future timestamp error, got %v", err)
+	}
+}
+
+func TestVerifyAcceptsRotatedSecret(t *testing.T) {
+	body := []byte(`{}`)
+	now := time.Now()
+	oldSigner, _ := NewSigner(SchemeTimestamped, "old", 0)
+	newSigner, _ := NewSigner(SchemeTimestamped, "new", 0)
+
+	// Sender signs with both secrets during rotation
+	oldSig := oldSigner.Sign(body, now)
+	newSig := newSigner.Sign(body, now)
+	header := oldSig + "," + newSig[strings.Index(newSig, "v1="):]
+
+	if err := newSigner.Verify(body, header, now); err != nil {
+		t.Errorf("Expected any matching v1 signature to verify, got %v", err)
+	}
+}
+
+func TestNewSignerValidation(t *testing.T) {
+	if _, err := NewSigner(SchemeTimestamped, "", 0); err == nil {
+		t.Error("Expected error for empty secret")
+	}
+	if _, err := NewSigner("md5", "secret", 0); err == nil {
+		t.Error("Expected error for unknown scheme")
+	}
+
+	signer, err := NewSigner("", "secret", 0)
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+	if signer.Scheme() != SchemeTimestamped {
+		t.Errorf("Expected default scheme %s, got %s", SchemeTimestamped, signer.Scheme())
+	}
+}
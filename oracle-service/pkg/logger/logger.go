@@ -6,7 +6,8 @@ import (
 	"os"
 )
 
-var log *zap.Logger
+// log defaults to a no-op logger so packages can log before Init (e.g. in tests)
+var log = zap.NewNop()
 
 func Init() {
 	var config zap.Config
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/adminsig"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/api/handlers"
+)
+
+func setupSignedAdminRouter(t *testing.T) (*gin.Engine, ed25519.PrivateKey) {
+	gin.SetMode(gin.TestMode)
+
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	keys, err := adminsig.ParseKeys(map[string]string{"alice": base64.StdEncoding.EncodeToString(der)})
+	if err != nil {
+		t.Fatalf("Failed to parse keys: %v", err)
+	}
+
+	router := gin.New()
+	router.Use(handlers.Localize())
+	router.POST("/api/v1/admin/snapshots/:id/restore",
+		handlers.RequireAdminSignature(adminsig.NewVerifier(keys, 5*time.Minute)),
+		func(c *gin.Context) {
+			// The handler still gets the whole body
+			body, _ := io.ReadAll(c.Request.Body)
+			c.JSON(http.StatusOK, gin.H{"body": string(body)})
+		})
+	return router, private
+}
+
+func signedAdminRequest(key ed25519.PrivateKey, operator, path string, timestamp int64, signedBody, sentBody []byte) *http.Request {
+	signature := ed25519.Sign(key, adminsig.Message(operator, http.MethodPost, path, timestamp, signedBody))
+	req, _ := http.NewRequest(http.MethodPost, path, bytes.NewReader(sentBody))
+	req.Header.Set(handlers.AdminOperatorHeader, operator)
+	req.Header.Set(handlers.AdminTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(handlers.AdminSignatureHeader, base64.StdEncoding.EncodeToString(signature))
+	return req
+}
+
+func TestAdminSignature(t *testing.T) {
+	router, key := setupSignedAdminRouter(t)
+	path := "/api/v1/admin/snapshots/3/restore"
+	body := []byte(`{"confirm":true}`)
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name   string
+		req    *http.Request
+		status int
+	}{
+		{"Unsigned", func() *http.Request { r, _ := http.NewRequest(http.MethodPost, path, bytes.NewReader(body)); return r }(), http.StatusUnauthorized},
+		{"Unknown operator", signedAdminRequest(key, "mallory", path, now, body, body), http.StatusUnauthorized},
+		{"Tampered body", signedAdminRequest(key, "alice", path, now, body, []byte(`{"confirm":false}`)), http.StatusUnauthorized},
+		{"Other path", signedAdminRequest(key, "alice", "/api/v1/admin/snapshots/4/restore", now, body, body), http.StatusUnauthorized},
+		{"Stale", signedAdminRequest(key, "alice", path, now-3600, body, body), http.StatusUnauthorized},
+		{"Signed", signedAdminRequest(key, "alice", path, now, body, body), http.StatusOK},
+		{"Replayed", signedAdminRequest(key, "alice", path, now, body, body), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Requests signed for another path are sent to this one
+			tt.req.URL.Path = path
+			tt.req.RequestURI = ""
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, tt.req)
+			if resp.Code != tt.status {
+				t.Errorf("Expected %d, got %d: %s", tt.status, resp.Code, resp.Body.String())
+			}
+			if tt.status == http.StatusOK && !bytes.Contains(resp.Body.Bytes(), []byte(`confirm`)) {
+				t.Errorf("Expected the handler to read the signed body, got %s", resp.Body.String())
+			}
+		})
+	}
+
+	// Without operator keys destructive actions are disabled, signed or not
+	closed := gin.New()
+	closed.POST(path, handlers.RequireAdminSignature(nil), func(c *gin.Context) { c.Status(http.StatusOK) })
+	for _, req := range []*http.Request{
+		func() *http.Request { r, _ := http.NewRequest(http.MethodPost, path, nil); return r }(),
+		signedAdminRequest(key, "alice", path, now, body, body),
+	} {
+		resp := httptest.NewRecorder()
+		closed.ServeHTTP(resp, req)
+		if resp.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected 503 without operator keys, got %d", resp.Code)
+		}
+	}
+}
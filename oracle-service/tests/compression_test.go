@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/api/handlers"
+)
+
+func setupCompressionRouter(minSize int, maxBody int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(handlers.Compress(minSize))
+	router.GET("/data", func(c *gin.Context) {
+		size, _ := strconv.Atoi(c.Query("size"))
+		c.String(http.StatusOK, strings.Repeat("a", size))
+	})
+	router.POST("/echo", handlers.DecompressRequest(maxBody), func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.String(http.StatusRequestEntityTooLarge, err.Error())
+			return
+		}
+		c.Data(http.StatusOK, "text/plain", body)
+	})
+	return router
+}
+
+func TestCompressLargeResponses(t *testing.T) {
+	router := setupCompressionRouter(1024, 1<<20)
+
+	// Large response, gzip accepted
+	req, _ := http.NewRequest("GET", "/data?size=2000", nil)
+	req.Header.Set("Accept-Encoding", "br, gzip;q=0.8")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	if resp.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Expected gzip response, got encoding %q", resp.Header().Get("Content-Encoding"))
+	}
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		t.Fatalf("Invalid gzip response: %v", err)
+	}
+	body, _ := io.ReadAll(gz)
+	if len(body) != 2000 {
+		t.Errorf("Expected 2000 decompressed bytes, got %d", len(body))
+	}
+
+	// Small response is not compressed
+	req, _ = http.NewRequest("GET", "/data?size=100", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Header().Get("Content-Encoding") != "" {
+		t.Error("Expected small response to be sent uncompressed")
+	}
+
+	// Client that refuses gzip
+	req, _ = http.NewRequest("GET", "/data?size=2000", nil)
+	req.Header.Set("Accept-Encoding", "gzip;q=0")
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Header().Get("Content-Encoding") != "" || resp.Body.Len() != 2000 {
+		t.Errorf("Expected uncompressed 2000 byte response, got %q encoding and %d bytes",
+			resp.Header().Get("Content-Encoding"), resp.Body.Len())
+	}
+	if resp.Header().Get("Vary") != "Accept-Encoding" {
+		t.Errorf("Expected Vary: Accept-Encoding, got %q", resp.Header().Get("Vary"))
+	}
+}
+
+func TestDecompressRequestBodies(t *testing.T) {
+	router := setupCompressionRouter(0, 100)
+
+	compress := func(data string) *bytes.Buffer {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		gz.Write([]byte(data))
+		gz.Close()
+		return &buf
+	}
+
+	req, _ := http.NewRequest("POST", "/echo", compress(`{"addresses":["0x1"]}`))
+	req.Header.Set("Content-Encoding", "gzip")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK || resp.Body.String() != `{"addresses":["0x1"]}` {
+		t.Errorf("Expected decompressed body echoed, got %d %q", resp.Code, resp.Body.String())
+	}
+
+	// Decompressed size is limited
+	req, _ = http.NewRequest("POST", "/echo", compress(strings.Repeat("a", 1000)))
+	req.Header.Set("Content-Encoding", "gzip")
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected oversized body to be refused, got %d", resp.Code)
+	}
+
+	req, _ = http.NewRequest("POST", "/echo", strings.NewReader("data"))
+	req.Header.Set("Content-Encoding", "br")
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("Expected 415 for unsupported encoding, got %d", resp.Code)
+	}
+
+	req, _ = http.NewRequest("POST", "/echo", strings.NewReader("not gzip"))
+	req.Header.Set("Content-Encoding", "gzip")
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for corrupt body, got %d", resp.Code)
+	}
+}
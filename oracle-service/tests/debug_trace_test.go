@@ -0,0 +1,182 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/api/handlers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/debugtrace"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/models"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const debugAdminKey = "debug-admin-key"
+
+func setupDebugRouter(t *testing.T, providerURL string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(&models.DebugTarget{}, &models.DebugTrace{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+
+	debugHandler := handlers.NewDebugHandler(service.NewDebugService(repository.NewDebugRepository(db)))
+
+	// Stands in for a provider-backed endpoint
+	client := &http.Client{Transport: debugtrace.NewTransport(nil)}
+	score := func(c *gin.Context) {
+		req, _ := http.NewRequestWithContext(c.Request.Context(), "GET", providerURL+"/report?api_key=secret-key", nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		c.JSON(http.StatusOK, gin.H{"report": string(body)})
+	}
+
+	router := gin.New()
+	v1 := router.Group("/api/v1")
+	v1.Use(handlers.Localize(), handlers.ResolveAddressParam(), debugHandler.Trace())
+	v1.GET("/credit-score/:address", score)
+	v1.POST("/credit-score/update", score)
+
+	debug := v1.Group("/admin/debug", handlers.RequireAdmin([]string{debugAdminKey}))
+	debug.POST("/targets", debugHandler.AddTarget)
+	debug.GET("/targets", debugHandler.ListTargets)
+	debug.DELETE("/targets/:id", debugHandler.RemoveTarget)
+	debug.GET("/traces", debugHandler.ListTraces)
+	debug.GET("/traces/:id", debugHandler.GetTrace)
+	return router
+}
+
+func debugRequest(router *gin.Engine, method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	return resp
+}
+
+func TestDebugTraceRecording(t *testing.T) {
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"score": 712, "access_token": "provider-token"}`)
+	}))
+	defer provider.Close()
+
+	router := setupDebugRouter(t, provider.URL)
+	admin := map[string]string{handlers.AdminKeyHeader: debugAdminKey}
+	traced := "0x1234567890123456789012345678901234567890"
+
+	// Debug endpoints need an admin key
+	resp := debugRequest(router, "GET", "/api/v1/admin/debug/targets", "", nil)
+	if resp.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 without an admin key, got %d", resp.Code)
+	}
+
+	resp = debugRequest(router, "POST", "/api/v1/admin/debug/targets", `{"note": "neither"}`, admin)
+	if resp.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a target without address or key, got %d", resp.Code)
+	}
+
+	resp = debugRequest(router, "POST", "/api/v1/admin/debug/targets", `{"address": "`+traced+`", "ttl_hours": 2}`, admin)
+	if resp.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", resp.Code, resp.Body.String())
+	}
+	resp = debugRequest(router, "POST", "/api/v1/admin/debug/targets", `{"api_key": "client-key"}`, admin)
+	if resp.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var keyTarget models.DebugTarget
+	json.Unmarshal(resp.Body.Bytes(), &keyTarget)
+	if keyTarget.APIKeyHash == "" || strings.Contains(resp.Body.String(), "client-key") {
+		t.Errorf("Expected only a hash of the API key, got %s", resp.Body.String())
+	}
+
+	// Traced by path address, by body address, and by API key; the last is not traced
+	debugRequest(router, "GET", "/api/v1/credit-score/"+traced, "", nil)
+	debugRequest(router, "POST", "/api/v1/credit-score/update", `{"address": "`+strings.ToUpper(traced[:2])+traced[2:]+`"}`, nil)
+	debugRequest(router, "GET", "/api/v1/credit-score/0xabc", "", map[string]string{handlers.APIKeyHeader: "client-key"})
+	resp = debugRequest(router, "GET", "/api/v1/credit-score/0xabc", "", map[string]string{handlers.APIKeyHeader: "other-key"})
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected untraced requests to pass through, got %d", resp.Code)
+	}
+
+	resp = debugRequest(router, "GET", "/api/v1/admin/debug/traces", "", admin)
+	var list handlers.ListDebugTracesResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &list); err != nil {
+		t.Fatalf("Invalid trace list: %s", resp.Body.String())
+	}
+	if list.Count != 3 {
+		t.Fatalf("Expected 3 traces, got %d", list.Count)
+	}
+	if list.Traces[0].TargetID != keyTarget.ID || list.Traces[0].APIKeyHash != keyTarget.APIKeyHash {
+		t.Errorf("Expected the newest trace to come from the API key target, got %+v", list.Traces[0])
+	}
+
+	resp = debugRequest(router, "GET", "/api/v1/admin/debug/traces?address="+traced, "", admin)
+	json.Unmarshal(resp.Body.Bytes(), &list)
+	if list.Count != 2 {
+		t.Errorf("Expected 2 traces for the address, got %d", list.Count)
+	}
+
+	// A trace holds the request, the response and the redacted provider call
+	resp = debugRequest(router, "GET", fmt.Sprintf("/api/v1/admin/debug/traces/%d", list.Traces[0].ID), "", admin)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", resp.Code)
+	}
+	var detail service.DebugTraceDetail
+	if err := json.Unmarshal(resp.Body.Bytes(), &detail); err != nil {
+		t.Fatalf("Invalid trace: %s", resp.Body.String())
+	}
+	if detail.Method != "POST" || detail.Status != http.StatusOK || !strings.Contains(detail.RequestBody, "address") {
+		t.Errorf("Unexpected trace %+v", detail.DebugTrace)
+	}
+	if !strings.Contains(detail.ResponseBody, "712") {
+		t.Errorf("Expected the response body in the trace, got %q", detail.ResponseBody)
+	}
+	if len(detail.ProviderCalls) != 1 {
+		t.Fatalf("Expected 1 provider call, got %d", len(detail.ProviderCalls))
+	}
+	call := detail.ProviderCalls[0]
+	if call.Status != http.StatusOK || !strings.Contains(call.ResponseBody, "712") {
+		t.Errorf("Unexpected provider call %+v", call)
+	}
+	if strings.Contains(call.URL, "secret-key") || strings.Contains(call.ResponseBody, "provider-token") {
+		t.Errorf("Expected credentials to be redacted, got %+v", call)
+	}
+
+	// Removed targets stop tracing
+	resp = debugRequest(router, "DELETE", fmt.Sprintf("/api/v1/admin/debug/targets/%d", keyTarget.ID), "", admin)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", resp.Code)
+	}
+	resp = debugRequest(router, "DELETE", fmt.Sprintf("/api/v1/admin/debug/targets/%d", keyTarget.ID), "", admin)
+	if resp.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a removed target, got %d", resp.Code)
+	}
+	debugRequest(router, "GET", "/api/v1/credit-score/0xabc", "", map[string]string{handlers.APIKeyHeader: "client-key"})
+	resp = debugRequest(router, "GET", fmt.Sprintf("/api/v1/admin/debug/traces?target_id=%d", keyTarget.ID), "", admin)
+	json.Unmarshal(resp.Body.Bytes(), &list)
+	if list.Count != 1 {
+		t.Errorf("Expected no new traces after removing the target, got %d", list.Count)
+	}
+}
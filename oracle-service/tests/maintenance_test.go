@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/api/handlers"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/repository"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/service"
+)
+
+func setupMaintenanceRouter(t *testing.T, configured bool) *gin.Engine {
+	_, oracleService, db := setupTestRouter(t)
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+
+	subsystems := service.NewSubsystemService(repository.NewSubsystemRepository(db))
+	if configured {
+		subsystems.ForceMaintenance()
+	}
+	oracleService.SetSubsystems(subsystems)
+	subsystemHandler := handlers.NewSubsystemHandler(subsystems)
+
+	scoreHandler := handlers.NewScoreHandler(oracleService)
+	scoreHandler.SetAdminKeys([]string{testAdminKey})
+
+	router := gin.New()
+	router.GET("/health", scoreHandler.HealthCheck)
+	v1 := router.Group("/api/v1")
+	v1.Use(handlers.Localize(), handlers.Maintenance(subsystems, 2*time.Minute, []string{testAdminKey}, "/api/v1/admin/"))
+	v1.GET("/credit-score/:address", scoreHandler.GetCreditScore)
+	v1.POST("/credit-score/update", scoreHandler.UpdateCreditScore)
+	v1.POST("/credit-score/preview", scoreHandler.PreviewCreditScore)
+	v1.GET("/admin/maintenance", subsystemHandler.GetMaintenance)
+	v1.PUT("/admin/maintenance", subsystemHandler.SetMaintenance)
+	return router
+}
+
+func TestMaintenanceMode(t *testing.T) {
+	router := setupMaintenanceRouter(t, false)
+	address := "0x1234567890123456789012345678901234567890"
+	update := `{"address": "` + address + `", "user_id": "user123"}`
+
+	resp := debugRequest(router, "POST", "/api/v1/credit-score/update", update, nil)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected updates outside maintenance, got %d: %s", resp.Code, resp.Body.String())
+	}
+
+	resp = debugRequest(router, "PUT", "/api/v1/admin/maintenance", `{"enabled": true, "reason": "database migration"}`, nil)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var status service.MaintenanceStatus
+	json.Unmarshal(resp.Body.Bytes(), &status)
+	if !status.Enabled || status.Reason != "database migration" || status.Since == nil {
+		t.Errorf("Expected maintenance enabled with its reason, got %+v", status)
+	}
+
+	// Mutating requests are rejected, reads are served
+	resp = debugRequest(router, "POST", "/api/v1/credit-score/update", update, map[string]string{"Accept-Language": "es"})
+	if resp.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503 during maintenance, got %d", resp.Code)
+	}
+	if resp.Header().Get("Retry-After") != "120" {
+		t.Errorf("Expected Retry-After: 120, got %q", resp.Header().Get("Retry-After"))
+	}
+	var errResp handlers.ErrorResponse
+	json.Unmarshal(resp.Body.Bytes(), &errResp)
+	if errResp.Error != "Servicio en mantenimiento" {
+		t.Errorf("Expected a translated error, got %+v", errResp)
+	}
+	resp = debugRequest(router, "GET", "/api/v1/credit-score/"+address, "", nil)
+	if resp.Code != http.StatusOK {
+		t.Errorf("Expected reads during maintenance, got %d", resp.Code)
+	}
+
+	// Previews pull provider data, so only admins may request them
+	resp = debugRequest(router, "POST", "/api/v1/credit-score/preview", update, nil)
+	if resp.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 for a preview during maintenance, got %d", resp.Code)
+	}
+	resp = debugRequest(router, "POST", "/api/v1/credit-score/preview", update, map[string]string{handlers.AdminKeyHeader: testAdminKey})
+	if resp.Code != http.StatusOK {
+		t.Errorf("Expected admin previews during maintenance, got %d: %s", resp.Code, resp.Body.String())
+	}
+
+	var health handlers.HealthResponse
+	resp = debugRequest(router, "GET", "/health", "", nil)
+	json.Unmarshal(resp.Body.Bytes(), &health)
+	if !health.Maintenance {
+		t.Error("Expected the health check to report maintenance mode")
+	}
+
+	resp = debugRequest(router, "PUT", "/api/v1/admin/maintenance", `{"enabled": false}`, nil)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", resp.Code, resp.Body.String())
+	}
+	resp = debugRequest(router, "POST", "/api/v1/credit-score/update", update, map[string]string{handlers.AdminKeyHeader: testAdminKey})
+	if resp.Code != http.StatusOK {
+		t.Errorf("Expected updates after maintenance, got %d: %s", resp.Code, resp.Body.String())
+	}
+}
+
+func TestConfiguredMaintenanceMode(t *testing.T) {
+	router := setupMaintenanceRouter(t, true)
+
+	resp := debugRequest(router, "POST", "/api/v1/credit-score/update", `{"address": "0x1234567890123456789012345678901234567890"}`, nil)
+	if resp.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503 in configured maintenance mode, got %d", resp.Code)
+	}
+
+	// Only a restart ends maintenance mode set by configuration
+	resp = debugRequest(router, "PUT", "/api/v1/admin/maintenance", `{"enabled": false}`, nil)
+	if resp.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 when ending configured maintenance, got %d", resp.Code)
+	}
+	resp = debugRequest(router, "GET", "/api/v1/admin/maintenance", "", nil)
+	var status service.MaintenanceStatus
+	json.Unmarshal(resp.Body.Bytes(), &status)
+	if !status.Enabled || !status.Configured {
+		t.Errorf("Expected configured maintenance, got %+v", status)
+	}
+}
@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/p2p-lend/oracle-service/internal/api/handlers"
+)
+
+func setupTimeoutRouter(limit time.Duration) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(handlers.Localize(), handlers.Timeout(limit))
+	router.GET("/fast", func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=60")
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	router.GET("/not-modified", func(c *gin.Context) {
+		c.Header("Last-Modified", "Mon, 01 Jan 2024 00:00:00 GMT")
+		c.Status(http.StatusNotModified)
+	})
+	// A provider call that honours cancellation, with a handler that swallows the error
+	router.GET("/hung-provider", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+		c.Header("Cache-Control", "public, max-age=60")
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	return router
+}
+
+func TestTimeoutMiddleware(t *testing.T) {
+	router := setupTimeoutRouter(50 * time.Millisecond)
+
+	// Fast handlers are untouched
+	req, _ := http.NewRequest("GET", "/fast", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK || resp.Header().Get("Cache-Control") != "public, max-age=60" {
+		t.Errorf("Expected 200 with the handler's headers, got %d %v", resp.Code, resp.Header())
+	}
+
+	req, _ = http.NewRequest("GET", "/not-modified", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusNotModified || resp.Header().Get("Last-Modified") == "" {
+		t.Errorf("Expected 304 with Last-Modified, got %d %v", resp.Code, resp.Header())
+	}
+
+	// A hung provider is cancelled at the deadline and the client gets a 504
+	start := time.Now()
+	req, _ = http.NewRequest("GET", "/hung-provider", nil)
+	req.Header.Set("Accept-Language", "es")
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the request to be cancelled at the deadline, took %s", elapsed)
+	}
+	if resp.Code != http.StatusGatewayTimeout {
+		t.Fatalf("Expected 504, got %d", resp.Code)
+	}
+	if resp.Header().Get("Cache-Control") != "" {
+		t.Errorf("Expected the abandoned response's headers to be dropped, got Cache-Control %q",
+			resp.Header().Get("Cache-Control"))
+	}
+	var body handlers.ErrorResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Expected an error response, got %q", resp.Body.String())
+	}
+	if body.Error != "La solicitud superó el tiempo de espera" {
+		t.Errorf("Expected a localized timeout error, got %+v", body)
+	}
+}
+
+func TestTimeoutDisabled(t *testing.T) {
+	router := setupTimeoutRouter(0)
+
+	req, _ := http.NewRequest("GET", "/fast", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Errorf("Expected 200 with the timeout disabled, got %d", resp.Code)
+	}
+}